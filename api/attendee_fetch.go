@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	AttendeeFetchConcurrencyEnvName = "ATTENDEE_FETCH_CONCURRENCY"
+	AttendeeFetchTimeoutEnvName     = "ATTENDEE_FETCH_TIMEOUT"
+
+	// DefaultAttendeeFetchConcurrency is how many attendee calendars are fetched at once by default.
+	DefaultAttendeeFetchConcurrency = 8
+	// DefaultAttendeeFetchTimeout is how long fetching one attendee's calendar can take by default.
+	DefaultAttendeeFetchTimeout = 20 * time.Second
+)
+
+// AttendeeFetchConfig bounds the fetching of attendee calendars when scheduling.
+type AttendeeFetchConfig struct {
+	// Concurrency is the most attendee calendars fetched at once.
+	Concurrency int
+	// Timeout is how long fetching one attendee's calendar can take before they are treated as unknown.
+	Timeout time.Duration
+}
+
+// DefaultAttendeeFetchConfig returns the default attendee fetch limits.
+func DefaultAttendeeFetchConfig() AttendeeFetchConfig {
+	return AttendeeFetchConfig{
+		Concurrency: DefaultAttendeeFetchConcurrency,
+		Timeout:     DefaultAttendeeFetchTimeout,
+	}
+}
+
+// Validate checks the limits are usable.
+func (c AttendeeFetchConfig) Validate() error {
+	if c.Concurrency <= 0 {
+		return fmt.Errorf("attendee fetch concurrency must be positive, got %d", c.Concurrency)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("attendee fetch timeout must be positive, got %s", c.Timeout)
+	}
+	return nil
+}
+
+// NewAttendeeFetchConfigFromEnv creates an AttendeeFetchConfig, using the defaults for any env values not set.
+// ATTENDEE_FETCH_TIMEOUT is a Go duration, eg. 15s.
+func NewAttendeeFetchConfigFromEnv() (AttendeeFetchConfig, error) {
+	cfg := DefaultAttendeeFetchConfig()
+
+	if concurrency, present := os.LookupEnv(AttendeeFetchConcurrencyEnvName); present {
+		var err error
+		if cfg.Concurrency, err = strconv.Atoi(concurrency); err != nil {
+			return AttendeeFetchConfig{}, fmt.Errorf("failed to parse %s env value: %w",
+				AttendeeFetchConcurrencyEnvName, err)
+		}
+	}
+
+	if timeout, present := os.LookupEnv(AttendeeFetchTimeoutEnvName); present {
+		var err error
+		if cfg.Timeout, err = time.ParseDuration(timeout); err != nil {
+			return AttendeeFetchConfig{}, fmt.Errorf("failed to parse %s env value: %w",
+				AttendeeFetchTimeoutEnvName, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return AttendeeFetchConfig{}, err
+	}
+	return cfg, nil
+}
+
+// getAttendeesFreeBusy gets the attendees' busy periods with a bounded pool of workers, keeping
+// the attendees' order. Attendees whose calendar can't be read within the per-attendee timeout
+// are returned as unknown rather than holding up everyone else. Cached calendars are refreshed first
+// if forceRefresh is true.
+func (s Server) getAttendeesFreeBusy(ctx context.Context, attendees []AttendeeBase,
+	start, end time.Time,
+	forceRefresh bool,
+) []attendeeFreeBusy {
+	cfg := s.AttendeeFetch
+	if cfg.Validate() != nil {
+		cfg = DefaultAttendeeFetchConfig()
+	}
+
+	results := make([]attendeeFreeBusy, len(attendees))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(cfg.Concurrency, len(attendees)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = s.getAttendeeFreeBusyWithTimeout(ctx, attendees[i], start, end, forceRefresh, cfg.Timeout)
+			}
+		}()
+	}
+
+	for i := range attendees {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// getAttendeeFreeBusyWithTimeout is getAttendeeFreeBusy, but returns the attendee as unknown once
+// timeout has passed, even if their calendar provider doesn't stop when its context is done. Their
+// preferences and time zone are loaded first, so an attendee who times out keeps their working hours.
+func (s Server) getAttendeeFreeBusyWithTimeout(ctx context.Context, a AttendeeBase,
+	start, end time.Time,
+	forceRefresh bool,
+	timeout time.Duration,
+) attendeeFreeBusy {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	unknown, userID, ok := s.getAttendeeSchedulingDetails(ctx, a)
+	if !ok {
+		return unknown
+	}
+
+	// Buffered so the fetch can finish and be garbage collected after we stop waiting for it
+	res := make(chan attendeeFreeBusy, 1)
+	go func() {
+		res <- s.getAttendeeFreeBusy(ctx, unknown, userID, start, end, forceRefresh)
+	}()
+
+	select {
+	case r := <-res:
+		return r
+	case <-ctx.Done():
+		s.Logger.Warn("gave up fetching attendee free busy, treating them as unknown",
+			zap.String("attendee_email", string(a.EmailAddress.Address)),
+			zap.Duration("timeout", timeout), zap.Error(ctx.Err()))
+		return unknown
+	}
+}
@@ -110,9 +110,75 @@ func (s Server) GetAPIAuthCallback(w http.ResponseWriter, r *http.Request, param
 		return
 	}
 
+	// Logging in doesn't wait on Graph, without a subscription the calendar is only polled
+	s.subscribeCalendarInBackground(r.Context(), u.ID)
+
+	CreateCookies(w, tks.AccessToken, tks.RefreshToken)
+
+	frontendURL, present := os.LookupEnv(FrontendURLEnvName)
+	if !present {
+		s.Logger.Error("failed to get FRONTEND_URL value")
+		sendError(w, http.StatusInternalServerError, "Sorry, failed to get required env var")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("%s/dashboard", frontendURL), http.StatusFound)
+}
+
+// (GET /api/auth/google/callback).
+func (s Server) GetAPIAuthGoogleCallback(w http.ResponseWriter, r *http.Request,
+	params GetAPIAuthGoogleCallbackParams,
+) {
+	if s.GoogleConfig == nil {
+		s.Logger.Error("google login attempted but google has not been configured")
+		sendError(w, http.StatusInternalServerError, "Sorry, Google login is not available.")
+		return
+	}
+
+	googleTokenRes, err := googleAuthoriseByCode(r.Context(), s.GoogleConfig, params.Code)
+	if err != nil {
+		s.Logger.Error("failed to get google tokens", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Sorry, try again later. Failed to get Google tokens.")
+		return
+	}
+
+	tx, err := s.DB.DB.Begin()
+	if err != nil {
+		s.Logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "google callback route: failed to start db transaction")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil {
+			s.Logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+	var u database.User
+	if u, err = getOrInsertUserByGoogleClaims(r.Context(), qtx, s.GoogleConfig, googleTokenRes); err != nil {
+		s.Logger.Error("failed to get user for google email", zap.Error(err))
+		sendError(w, http.StatusBadRequest, "failed to parse google tokens")
+		return
+	}
+
+	var tks jwt.AccessAndRefreshTokens
+	if tks, err = jwt.CreateAccessAndRefreshTokens(r.Context(), s.Logger, qtx, u.ID, u.Email); err != nil {
+		s.Logger.Error("failed to create and store tokens", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "failed to create slotify access and refresh token")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.Logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "failed to commit db transaction")
+		return
+	}
+
 	CreateCookies(w, tks.AccessToken, tks.RefreshToken)
 
-	frontendURL, present := os.LookupEnv("FRONTEND_URL")
+	frontendURL, present := os.LookupEnv(FrontendURLEnvName)
 	if !present {
 		s.Logger.Error("failed to get FRONTEND_URL value")
 		sendError(w, http.StatusInternalServerError, "Sorry, failed to get required env var")
@@ -1,13 +1,7 @@
 package api
 
 import (
-	"context"
 	"fmt"
-	"time"
-
-	"github.com/avast/retry-go"
-	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
-	graphusers "github.com/microsoftgraph/msgraph-sdk-go/users"
 
 	openapi_types "github.com/oapi-codegen/runtime/types"
 
@@ -36,14 +30,14 @@ func parseEventableResp(events []graphmodels.Eventable) ([]CalendarEvent, error)
 			joinURL = e.GetOnlineMeeting().GetJoinUrl()
 		}
 
-		var endTime *string
-		if e.GetEnd() != nil {
-			endTime = e.GetEnd().GetDateTime()
+		endTime, err := formatGraphEventTime(e.GetEnd())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse msft event end: %w", err)
 		}
 
-		var startTime *string
-		if e.GetStart() != nil {
-			startTime = e.GetStart().GetDateTime()
+		startTime, err := formatGraphEventTime(e.GetStart())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse msft event start: %w", err)
 		}
 
 		var body *string
@@ -51,70 +45,47 @@ func parseEventableResp(events []graphmodels.Eventable) ([]CalendarEvent, error)
 			body = e.GetBody().GetContent()
 		}
 
+		var originalStartTime *string
+		if e.GetOriginalStart() != nil {
+			formatted := FormatCalendarEventTime(*e.GetOriginalStart())
+			originalStartTime = &formatted
+		}
+
 		ce := CalendarEvent{
-			Attendees:   attendees,
-			Body:        body,
-			Created:     e.GetCreatedDateTime(),
-			EndTime:     endTime,
-			Id:          e.GetId(),
-			ICalUId:     e.GetICalUId(),
-			IsCancelled: e.GetIsCancelled(),
-			JoinURL:     joinURL,
-			Locations:   locations,
-			Organizer:   (*openapi_types.Email)(e.GetOrganizer().GetEmailAddress().GetAddress()),
-			StartTime:   startTime,
-			Subject:     e.GetSubject(),
-			WebLink:     e.GetWebLink(),
+			Attendees:         attendees,
+			Body:              body,
+			Created:           e.GetCreatedDateTime(),
+			EndTime:           endTime,
+			Id:                e.GetId(),
+			ICalUId:           e.GetICalUId(),
+			IsCancelled:       e.GetIsCancelled(),
+			IsOnlineMeeting:   e.GetIsOnlineMeeting(),
+			JoinURL:           joinURL,
+			Locations:         locations,
+			Organizer:         (*openapi_types.Email)(e.GetOrganizer().GetEmailAddress().GetAddress()),
+			OriginalStartTime: originalStartTime,
+			Recurrence:        parseMSFTRecurrence(e.GetRecurrence()),
+			SeriesMasterId:    e.GetSeriesMasterId(),
+			StartTime:         startTime,
+			Subject:           e.GetSubject(),
+			WebLink:           e.GetWebLink(),
 		}
 		calendarEvents = append(calendarEvents, ce)
 	}
 	return calendarEvents, nil
 }
 
-// makeCalendarMeAPICall lists a user's events within a certain time range.
-// See [MSFT Calendar Me API Call] for docs on the API call made.
-//
-// [MSFT Calendar Me API Call]:
-// https://learn.microsoft.com/en-us/graph/api/calendar-list-calendarview?view=graph-rest-1.0&tabs=http
-func makeCalendarMeAPICall(graph *msgraphsdkgo.GraphServiceClient, startTime,
-	endTime time.Time,
-) ([]CalendarEvent, error) {
-	// Prepare request by formatting request parameters correctly.
-	start := startTime.Format(time.RFC3339)
-	end := endTime.Format(time.RFC3339)
-
-	// default page size is 100, we could iterate through pages but 999 meetings a month
-	// should be more than enough
-	var pageSize int32 = 999
-	requestParameters := &graphusers.ItemCalendarCalendarViewRequestBuilderGetQueryParameters{
-		EndDateTime:   &end,
-		StartDateTime: &start,
-		Top:           &pageSize,
+// formatGraphEventTime converts a MSFT event time into the layout of CalendarEvent times, nil is
+// returned if it is missing.
+func formatGraphEventTime(dt graphmodels.DateTimeTimeZoneable) (*string, error) {
+	if dt == nil || dt.GetDateTime() == nil {
+		//nolint: nilnil // events without a time are still returned
+		return nil, nil
 	}
-
-	configuration := &graphusers.ItemCalendarCalendarViewRequestBuilderGetRequestConfiguration{
-		QueryParameters: requestParameters,
-	}
-
-	// Make actual API request.
-
-	var events graphmodels.EventCollectionResponseable
-	var err error
-	err = retry.Do(func() error {
-		events, err = graph.Me().Calendar().CalendarView().Get(context.Background(), configuration)
-		if err != nil || events == nil {
-			return fmt.Errorf("failed to make graph client call: %w", err)
-		}
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
+	t, err := parseGraphDateTimeTimeZone(dt)
 	if err != nil {
-		return nil, fmt.Errorf("failed msft create event after 3 retries: %w", err)
-	}
-
-	// Filter out attributes that we want.
-	var parsedEvents []CalendarEvent
-	if parsedEvents, err = parseEventableResp(events.GetValue()); err != nil {
-		return nil, fmt.Errorf("failed to parse msft eventable: %w", err)
+		return nil, err
 	}
-	return parsedEvents, nil
+	formatted := FormatCalendarEventTime(t)
+	return &formatted, nil
 }
@@ -0,0 +1,443 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	CalendarCacheEnabledEnvName      = "CALENDAR_CACHE_ENABLED"
+	CalendarCacheMaxStalenessEnvName = "CALENDAR_CACHE_MAX_STALENESS"
+
+	// DefaultCalendarCacheMaxStaleness is how old cached events can be before they are synced when read.
+	DefaultCalendarCacheMaxStaleness = 5 * time.Minute
+	// DefaultCalendarCacheWindowPast is how far into the past events are cached.
+	DefaultCalendarCacheWindowPast = 7 * 24 * time.Hour
+	// DefaultCalendarCacheWindowFuture is how far into the future events are cached.
+	DefaultCalendarCacheWindowFuture = 90 * 24 * time.Hour
+
+	// CalendarCacheIdleTime is how long a user's events stay cached after they were last read.
+	CalendarCacheIdleTime = 7 * 24 * time.Hour
+
+	// calendarCacheReadResolution is how often reading the cache updates when it was last read.
+	calendarCacheReadResolution = time.Hour
+	// calendarCacheSyncBatchSize is the most caches synced, or deleted at once, by SyncCalendarCaches.
+	calendarCacheSyncBatchSize = 50
+)
+
+// ensure that we've conformed to the `CalendarProvider` and `CalendarRefresher` interfaces
+// with a compile-time check.
+var (
+	_ CalendarProvider  = (*CachedCalendarProvider)(nil)
+	_ CalendarRefresher = (*CachedCalendarProvider)(nil)
+)
+
+// CalendarCacheConfig configures the cache of events for calendar providers that support delta syncs.
+type CalendarCacheConfig struct {
+	Enabled bool
+	// MaxStaleness is how old cached events can be before they are synced when read.
+	MaxStaleness time.Duration
+	// WindowPast and WindowFuture are how far either side of today events are cached,
+	// reads outside of the window go straight to the calendar provider.
+	WindowPast   time.Duration
+	WindowFuture time.Duration
+}
+
+// DefaultCalendarCacheConfig returns the default calendar cache config.
+func DefaultCalendarCacheConfig() CalendarCacheConfig {
+	return CalendarCacheConfig{
+		Enabled:      true,
+		MaxStaleness: DefaultCalendarCacheMaxStaleness,
+		WindowPast:   DefaultCalendarCacheWindowPast,
+		WindowFuture: DefaultCalendarCacheWindowFuture,
+	}
+}
+
+// Validate checks the config is usable.
+func (c CalendarCacheConfig) Validate() error {
+	if c.MaxStaleness <= 0 {
+		return fmt.Errorf("calendar cache max staleness must be positive, got %s", c.MaxStaleness)
+	}
+	if c.WindowPast < 0 || c.WindowFuture <= 0 {
+		return errors.New("calendar cache window must not be negative and must include the future")
+	}
+	return nil
+}
+
+// NewCalendarCacheConfigFromEnv creates a CalendarCacheConfig, using the defaults for any env values not set.
+// CALENDAR_CACHE_MAX_STALENESS is a Go duration, eg. 5m.
+func NewCalendarCacheConfigFromEnv() (CalendarCacheConfig, error) {
+	cfg := DefaultCalendarCacheConfig()
+
+	if enabled, present := os.LookupEnv(CalendarCacheEnabledEnvName); present {
+		var err error
+		if cfg.Enabled, err = strconv.ParseBool(enabled); err != nil {
+			return CalendarCacheConfig{}, fmt.Errorf("failed to parse %s env value: %w",
+				CalendarCacheEnabledEnvName, err)
+		}
+	}
+
+	if maxStaleness, present := os.LookupEnv(CalendarCacheMaxStalenessEnvName); present {
+		var err error
+		if cfg.MaxStaleness, err = time.ParseDuration(maxStaleness); err != nil {
+			return CalendarCacheConfig{}, fmt.Errorf("failed to parse %s env value: %w",
+				CalendarCacheMaxStalenessEnvName, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return CalendarCacheConfig{}, err
+	}
+	return cfg, nil
+}
+
+// CachedCalendarProvider serves a user's events from a cache in the database, kept up to date with
+// delta syncs. Everything else goes straight to the underlying CalendarProvider.
+type CachedCalendarProvider struct {
+	CalendarProvider
+	delta  CalendarDeltaSyncer
+	db     *database.Database
+	logger *logger.Logger
+	userID uint32
+	cfg    CalendarCacheConfig
+}
+
+// NewCachedCalendarProviderFactory returns a CalendarProviderFactory that caches the events of
+// providers that support delta syncs, other providers are returned as they are.
+func NewCachedCalendarProviderFactory(factory CalendarProviderFactory, db *database.Database,
+	l *logger.Logger,
+	cfg CalendarCacheConfig,
+) CalendarProviderFactory {
+	return func(ctx context.Context, userID uint32) (CalendarProvider, error) {
+		provider, err := factory(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		delta, ok := provider.(CalendarDeltaSyncer)
+		if !ok {
+			return provider, nil
+		}
+
+		return &CachedCalendarProvider{
+			CalendarProvider: provider,
+			delta:            delta,
+			db:               db,
+			logger:           l,
+			userID:           userID,
+			cfg:              cfg,
+		}, nil
+	}
+}
+
+// Unwrap returns the CalendarProvider whose events are cached.
+func (c *CachedCalendarProvider) Unwrap() CalendarProvider {
+	return c.CalendarProvider
+}
+
+// ListEvents lists the user's events between start and end, from the cache if they are within it.
+func (c *CachedCalendarProvider) ListEvents(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
+	cached, ok := c.cachedEvents(ctx, start, end)
+	if !ok {
+		return c.CalendarProvider.ListEvents(ctx, start, end)
+	}
+
+	events := make([]CalendarEvent, 0, len(cached))
+	for _, e := range cached {
+		var event CalendarEvent
+		if err := json.Unmarshal(e.Event, &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cached calendar event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// FreeBusy returns the periods between start and end in which the user is busy, from the cache if
+// they are within it.
+func (c *CachedCalendarProvider) FreeBusy(ctx context.Context, start, end time.Time) ([]TimeInterval, error) {
+	cached, ok := c.cachedEvents(ctx, start, end)
+	if !ok {
+		return c.CalendarProvider.FreeBusy(ctx, start, end)
+	}
+
+	busy := []TimeInterval{}
+	for _, e := range cached {
+		if e.Busy {
+			busy = append(busy, TimeInterval{Start: e.StartTime.UTC(), End: e.EndTime.UTC()})
+		}
+	}
+	return busy, nil
+}
+
+// CreateEvent creates an event in the user's calendar, the cache is synced on the next read.
+func (c *CachedCalendarProvider) CreateEvent(ctx context.Context, event CalendarEvent) (CalendarEvent, error) {
+	created, err := c.CalendarProvider.CreateEvent(ctx, event)
+	if err != nil {
+		return CalendarEvent{}, err
+	}
+	c.expire(ctx)
+	return created, nil
+}
+
+// PatchEvent updates one of the user's events, the cache is synced on the next read.
+func (c *CachedCalendarProvider) PatchEvent(ctx context.Context, eventID string, patch CalendarEventPatch) error {
+	if err := c.CalendarProvider.PatchEvent(ctx, eventID, patch); err != nil {
+		return err
+	}
+	c.expire(ctx)
+	return nil
+}
+
+// Refresh syncs the cached events with the user's calendar, however recently they were synced.
+func (c *CachedCalendarProvider) Refresh(ctx context.Context) error {
+	_, err := c.sync(ctx, true)
+	return err
+}
+
+// cachedEvents gets the cached events between start and end, syncing them first if they are stale.
+// False is returned if the range isn't cached or the cache can't be used, so the caller should
+// go to the calendar provider instead.
+func (c *CachedCalendarProvider) cachedEvents(ctx context.Context,
+	start, end time.Time,
+) ([]database.CalendarEventCache, bool) {
+	logger := c.logger.With(zap.Uint32("user_id", c.userID))
+
+	sync, err := c.sync(ctx, false)
+	if err != nil {
+		logger.Warn("failed to sync calendar cache, reading from the calendar provider", zap.Error(err))
+		return nil, false
+	}
+	if start.Before(sync.WindowStart) || end.After(sync.WindowEnd) {
+		return nil, false
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(sync.LastReadAt) > calendarCacheReadResolution {
+		if _, err = c.db.UpdateCalendarSyncLastReadAt(ctx, database.UpdateCalendarSyncLastReadAtParams{
+			LastReadAt: now,
+			UserID:     c.userID,
+		}); err != nil {
+			logger.Error("failed to update when calendar cache was last read", zap.Error(err))
+		}
+	}
+
+	events, err := c.db.ListCalendarEventCache(ctx, database.ListCalendarEventCacheParams{
+		UserID:     c.userID,
+		RangeEnd:   end.UTC(),
+		RangeStart: start.UTC(),
+	})
+	if err != nil {
+		logger.Error("failed to list cached calendar events, reading from the calendar provider", zap.Error(err))
+		return nil, false
+	}
+	return events, true
+}
+
+// window returns the range of events to cache when a full sync is done at now.
+func (c *CachedCalendarProvider) window(now time.Time) (time.Time, time.Time) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	return today.Add(-c.cfg.WindowPast), today.Add(c.cfg.WindowFuture)
+}
+
+// sync brings the cache up to date with a delta sync if it is stale or force is true, a full sync
+// is done if nothing has been cached yet, the delta link has expired or the window is a day behind.
+func (c *CachedCalendarProvider) sync(ctx context.Context, force bool) (database.CalendarSync, error) {
+	now := time.Now().UTC()
+
+	sync, err := c.db.GetCalendarSync(ctx, c.userID)
+	found := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return database.CalendarSync{}, fmt.Errorf("failed to get calendar sync: %w", err)
+	}
+
+	windowStart, _ := c.window(now)
+	full := !found || windowStart.Sub(sync.WindowStart) >= 24*time.Hour
+	if !full && !force && now.Sub(sync.LastSyncedAt) <= c.cfg.MaxStaleness {
+		return sync, nil
+	}
+
+	deltaLink := sync.DeltaLink
+	if full {
+		deltaLink = ""
+		sync.WindowStart, sync.WindowEnd = c.window(now)
+	}
+
+	delta, err := c.delta.DeltaEvents(ctx, deltaLink, sync.WindowStart, sync.WindowEnd)
+	if !full && errors.Is(err, ErrCalendarDeltaExpired) {
+		full = true
+		sync.WindowStart, sync.WindowEnd = c.window(now)
+		delta, err = c.delta.DeltaEvents(ctx, "", sync.WindowStart, sync.WindowEnd)
+	}
+	if err != nil {
+		return database.CalendarSync{}, fmt.Errorf("failed to get calendar delta: %w", err)
+	}
+
+	sync.UserID = c.userID
+	sync.DeltaLink = delta.DeltaLink
+	sync.LastSyncedAt = now
+	if !found {
+		sync.LastReadAt = now
+	}
+
+	if err = c.saveDelta(ctx, sync, delta, full); err != nil {
+		return database.CalendarSync{}, err
+	}
+	return sync, nil
+}
+
+// saveDelta applies a delta to the cache, a full delta replaces every cached event.
+func (c *CachedCalendarProvider) saveDelta(ctx context.Context, sync database.CalendarSync,
+	delta CalendarDelta,
+	full bool,
+) error {
+	tx, err := c.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start db transaction: %w", err)
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			c.logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := c.db.WithTx(tx)
+
+	// The sync has to exist before its events
+	if _, err = qtx.UpsertCalendarSync(ctx, database.UpsertCalendarSyncParams(sync)); err != nil {
+		return fmt.Errorf("failed to save calendar sync: %w", err)
+	}
+
+	if full {
+		if _, err = qtx.DeleteCalendarEventCache(ctx, c.userID); err != nil {
+			return fmt.Errorf("failed to clear calendar cache: %w", err)
+		}
+	}
+
+	for _, e := range delta.Events {
+		if e.Event.Id == nil {
+			continue
+		}
+
+		if e.Removed {
+			if _, err = qtx.DeleteCalendarEventCacheEvent(ctx, database.DeleteCalendarEventCacheEventParams{
+				UserID:  c.userID,
+				EventID: *e.Event.Id,
+			}); err != nil {
+				return fmt.Errorf("failed to delete cached calendar event: %w", err)
+			}
+			continue
+		}
+
+		var params database.UpsertCalendarEventCacheParams
+		if params, err = toUpsertCalendarEventCacheParams(c.userID, e); err != nil {
+			return err
+		}
+		if _, err = qtx.UpsertCalendarEventCache(ctx, params); err != nil {
+			return fmt.Errorf("failed to save cached calendar event: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit db transaction: %w", err)
+	}
+	return nil
+}
+
+// expire marks the cache as stale, so it is synced on the next read.
+func (c *CachedCalendarProvider) expire(ctx context.Context) {
+	if _, err := c.db.ExpireCalendarSync(ctx, c.userID); err != nil {
+		c.logger.Error("failed to expire calendar cache", zap.Uint32("user_id", c.userID), zap.Error(err))
+	}
+}
+
+// toUpsertCalendarEventCacheParams converts a changed event into DB params.
+func toUpsertCalendarEventCacheParams(userID uint32,
+	e CalendarDeltaEvent,
+) (database.UpsertCalendarEventCacheParams, error) {
+	if e.Event.StartTime == nil || e.Event.EndTime == nil {
+		return database.UpsertCalendarEventCacheParams{}, fmt.Errorf("calendar event %s has no start or end time",
+			*e.Event.Id)
+	}
+
+	start, err := ParseCalendarEventTime(*e.Event.StartTime)
+	if err != nil {
+		return database.UpsertCalendarEventCacheParams{}, err
+	}
+	end, err := ParseCalendarEventTime(*e.Event.EndTime)
+	if err != nil {
+		return database.UpsertCalendarEventCacheParams{}, err
+	}
+
+	event, err := json.Marshal(e.Event)
+	if err != nil {
+		return database.UpsertCalendarEventCacheParams{}, fmt.Errorf("failed to marshal calendar event: %w", err)
+	}
+
+	return database.UpsertCalendarEventCacheParams{
+		UserID:    userID,
+		EventID:   *e.Event.Id,
+		StartTime: start,
+		EndTime:   end,
+		Busy:      e.Busy,
+		Event:     event,
+	}, nil
+}
+
+// SyncCalendarCaches refreshes the cached events of users whose cache was read recently but hasn't been
+// synced within the max staleness, so reads rarely have to wait on a sync. Caches that haven't been
+// read within CalendarCacheIdleTime are deleted.
+func (s Server) SyncCalendarCaches(ctx context.Context) {
+	if !s.CalendarCache.Enabled {
+		return
+	}
+
+	now := time.Now().UTC()
+	idleSince := now.Add(-CalendarCacheIdleTime)
+
+	var deleted int64 = 1
+	for deleted != 0 {
+		var err error
+		if deleted, err = s.DB.BatchDeleteIdleCalendarSyncs(ctx, database.BatchDeleteIdleCalendarSyncsParams{
+			LastReadAt: idleSince,
+			Limit:      calendarCacheSyncBatchSize,
+		}); err != nil {
+			s.Logger.Error("failed to delete idle calendar caches", zap.Error(err))
+			break
+		}
+	}
+
+	userIDs, err := s.DB.ListCalendarSyncsToRefresh(ctx, database.ListCalendarSyncsToRefreshParams{
+		LastSyncedAt: now.Add(-s.CalendarCache.MaxStaleness),
+		LastReadAt:   idleSince,
+		Limit:        calendarCacheSyncBatchSize,
+	})
+	if err != nil {
+		s.Logger.Error("failed to list calendar caches to refresh", zap.Error(err))
+		return
+	}
+
+	for _, userID := range userIDs {
+		logger := s.Logger.With(zap.Uint32("user_id", userID))
+
+		calendar, err := s.CalendarProvider(ctx, userID)
+		if err != nil {
+			logger.Error("failed to create calendar provider to refresh calendar cache", zap.Error(err))
+			continue
+		}
+		if err = RefreshCalendar(ctx, calendar); err != nil {
+			logger.Error("failed to refresh calendar cache", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// CalendarChangeQueueSize is the most calendar changes waiting to be handled, the webhook asks Graph to
+	// send notifications again later once it is full.
+	CalendarChangeQueueSize = 1000
+	// CalendarChangeTimeout is how long handling one calendar change can take.
+	CalendarChangeTimeout = time.Minute
+)
+
+// calendarChange is a change notification for a user's calendar, from their subscription, that is waiting
+// to be handled.
+type calendarChange struct {
+	userID       uint32
+	notification GraphChangeNotification
+	// requestID is the id of the webhook request the change came in, for logs.
+	requestID string
+}
+
+// CalendarChangeQueue hands change notifications from the calendar webhook to the background, so Graph gets
+// its reply without waiting for calendars to be read.
+type CalendarChangeQueue struct {
+	changes chan calendarChange
+	handle  func(ctx context.Context, l *zap.SugaredLogger, c calendarChange)
+	l       *logger.Logger
+}
+
+// newCalendarChangeQueue creates a CalendarChangeQueue handling changes with handle.
+func newCalendarChangeQueue(handle func(ctx context.Context, l *zap.SugaredLogger, c calendarChange),
+	l *logger.Logger,
+) *CalendarChangeQueue {
+	return &CalendarChangeQueue{
+		changes: make(chan calendarChange, CalendarChangeQueueSize),
+		handle:  handle,
+		l:       l,
+	}
+}
+
+// enqueue queues c, false is returned if the queue is full.
+func (q *CalendarChangeQueue) enqueue(c calendarChange) bool {
+	select {
+	case q.changes <- c:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run handles calendar changes as they are queued until ctx is done.
+func (q *CalendarChangeQueue) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case c := <-q.changes:
+			q.handleWithTimeout(ctx, c)
+		}
+	}
+}
+
+// HandleQueued handles the calendar changes already queued without waiting for more, returning how many
+// were handled.
+func (q *CalendarChangeQueue) HandleQueued(ctx context.Context) int {
+	handled := 0
+	for {
+		select {
+		case c := <-q.changes:
+			q.handleWithTimeout(ctx, c)
+			handled++
+		default:
+			return handled
+		}
+	}
+}
+
+// handleWithTimeout handles c, giving up after CalendarChangeTimeout.
+func (q *CalendarChangeQueue) handleWithTimeout(ctx context.Context, c calendarChange) {
+	ctx, cancel := context.WithTimeout(ctx, CalendarChangeTimeout)
+	defer cancel()
+
+	q.handle(ctx, q.l.With(zap.String("request_id", c.requestID)), c)
+}
@@ -0,0 +1,781 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/avast/retry-go"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// googlePrimaryCalendar is the Google calendar id of the user's own calendar.
+const googlePrimaryCalendar = "primary"
+
+// ensure that we've conformed to the `CalendarProvider` interface with a compile-time check.
+var _ CalendarProvider = (*GoogleCalendarProvider)(nil)
+
+// GoogleCalendarProvider is a CalendarProvider backed by the Google Calendar REST API.
+type GoogleCalendarProvider struct {
+	client           *http.Client
+	calendarBaseURL  string
+	directoryBaseURL string
+}
+
+// googleAPIError is returned when the Google API responds with a non 2xx status code.
+type googleAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e googleAPIError) Error() string {
+	return fmt.Sprintf("google api returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// googleEventDateTime maps to [Google event start/end].
+//
+// [Google event start/end]: https://developers.google.com/calendar/api/v3/reference/events#resource
+type googleEventDateTime struct {
+	// Date is set instead of DateTime for all-day events.
+	Date     string `json:"date,omitempty"`
+	DateTime string `json:"dateTime,omitempty"`
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+type googleEventAttendee struct {
+	Email          string `json:"email"`
+	Optional       bool   `json:"optional,omitempty"`
+	Resource       bool   `json:"resource,omitempty"`
+	Organizer      bool   `json:"organizer,omitempty"`
+	ResponseStatus string `json:"responseStatus,omitempty"`
+}
+
+type googleEventOrganizer struct {
+	Email string `json:"email"`
+}
+
+// googleEvent maps to a [Google event].
+//
+// [Google event]: https://developers.google.com/calendar/api/v3/reference/events#resource
+type googleEvent struct {
+	ID           string                `json:"id,omitempty"`
+	ICalUID      string                `json:"iCalUID,omitempty"`
+	Status       string                `json:"status,omitempty"`
+	HTMLLink     string                `json:"htmlLink,omitempty"`
+	HangoutLink  string                `json:"hangoutLink,omitempty"`
+	Created      *time.Time            `json:"created,omitempty"`
+	Summary      string                `json:"summary,omitempty"`
+	Description  string                `json:"description,omitempty"`
+	Location     string                `json:"location,omitempty"`
+	Transparency string                `json:"transparency,omitempty"`
+	Start        *googleEventDateTime  `json:"start,omitempty"`
+	End          *googleEventDateTime  `json:"end,omitempty"`
+	Organizer    *googleEventOrganizer `json:"organizer,omitempty"`
+	Attendees    []googleEventAttendee `json:"attendees,omitempty"`
+	// Recurrence holds the RRULE of a recurring event.
+	Recurrence        []string             `json:"recurrence,omitempty"`
+	RecurringEventID  string               `json:"recurringEventId,omitempty"`
+	OriginalStartTime *googleEventDateTime `json:"originalStartTime,omitempty"`
+}
+
+type googleEventList struct {
+	Items         []googleEvent `json:"items"`
+	NextPageToken string        `json:"nextPageToken,omitempty"`
+}
+
+type googleFreeBusyRequestItem struct {
+	ID string `json:"id"`
+}
+
+type googleFreeBusyRequest struct {
+	TimeMin time.Time                   `json:"timeMin"`
+	TimeMax time.Time                   `json:"timeMax"`
+	Items   []googleFreeBusyRequestItem `json:"items"`
+}
+
+type googleFreeBusyCalendar struct {
+	Busy []struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	} `json:"busy"`
+	Errors []struct {
+		Domain string `json:"domain"`
+		Reason string `json:"reason"`
+	} `json:"errors,omitempty"`
+}
+
+type googleFreeBusyResponse struct {
+	Calendars map[string]googleFreeBusyCalendar `json:"calendars"`
+}
+
+type googleCalendarResource struct {
+	ResourceEmail string `json:"resourceEmail"`
+	ResourceName  string `json:"resourceName"`
+}
+
+type googleCalendarResourceList struct {
+	Items         []googleCalendarResource `json:"items"`
+	NextPageToken string                   `json:"nextPageToken,omitempty"`
+}
+
+// NewGoogleCalendarProvider creates a GoogleCalendarProvider, client must authenticate requests as the user.
+func NewGoogleCalendarProvider(client *http.Client, googleCfg *GoogleConfig) (*GoogleCalendarProvider, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	if googleCfg == nil {
+		return nil, ErrGoogleNotConfigured
+	}
+	return &GoogleCalendarProvider{
+		client:           client,
+		calendarBaseURL:  strings.TrimSuffix(googleCfg.CalendarBaseURL, "/"),
+		directoryBaseURL: strings.TrimSuffix(googleCfg.DirectoryBaseURL, "/"),
+	}, nil
+}
+
+// NewGoogleCalendarProviderFactory returns a CalendarProviderFactory that creates a
+// GoogleCalendarProvider using the user's stored Google tokens.
+func NewGoogleCalendarProviderFactory(googleCfg *GoogleConfig, db *database.Database) CalendarProviderFactory {
+	return func(ctx context.Context, userID uint32) (CalendarProvider, error) {
+		client, err := createGoogleHTTPClient(ctx, googleCfg, db, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create google http client: %w", err)
+		}
+
+		return NewGoogleCalendarProvider(client, googleCfg)
+	}
+}
+
+// ListEvents lists a user's events within a certain time range, recurring events are expanded.
+func (p *GoogleCalendarProvider) ListEvents(ctx context.Context, start, end time.Time) ([]CalendarEvent, error) {
+	query := url.Values{}
+	query.Set("timeMin", start.Format(time.RFC3339))
+	query.Set("timeMax", end.Format(time.RFC3339))
+	query.Set("singleEvents", "true")
+	query.Set("orderBy", "startTime")
+
+	googleEvents, err := p.listEvents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []CalendarEvent{}
+	for _, e := range googleEvents {
+		// Cancelled events are only returned when showDeleted is set, but be defensive
+		if e.Status == "cancelled" {
+			continue
+		}
+		var event CalendarEvent
+		if event, err = parseGoogleEvent(e); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// GetEvent gets a user's event by its Google event id.
+func (p *GoogleCalendarProvider) GetEvent(ctx context.Context, eventID string) (CalendarEvent, error) {
+	var e googleEvent
+	if err := p.do(ctx, http.MethodGet, p.eventURL(eventID, nil), nil, &e); err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to get google event: %w", err)
+	}
+	return parseGoogleEvent(e)
+}
+
+// GetEventByICalUID gets a user's event by its iCalUID.
+func (p *GoogleCalendarProvider) GetEventByICalUID(ctx context.Context, iCalUID string) (CalendarEvent, error) {
+	query := url.Values{}
+	query.Set("iCalUID", iCalUID)
+
+	googleEvents, err := p.listEvents(ctx, query)
+	if err != nil {
+		return CalendarEvent{}, err
+	}
+
+	if len(googleEvents) == 0 {
+		return CalendarEvent{}, fmt.Errorf("google returned empty array: %w", ErrCalendarEventNotFound)
+	}
+
+	return parseGoogleEvent(googleEvents[0])
+}
+
+// GetEventOccurrence gets the occurrence of a user's recurring event originally scheduled to start at
+// originalStart.
+func (p *GoogleCalendarProvider) GetEventOccurrence(ctx context.Context, seriesID string,
+	originalStart time.Time,
+) (CalendarEvent, error) {
+	query := url.Values{}
+	query.Set("originalStart", originalStart.UTC().Format(time.RFC3339))
+
+	var instances googleEventList
+	if err := p.do(ctx, http.MethodGet, p.eventInstancesURL(seriesID, query), nil, &instances); err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to get google event occurrence: %w", err)
+	}
+
+	if len(instances.Items) == 0 {
+		return CalendarEvent{}, fmt.Errorf("google returned empty array: %w", ErrCalendarEventNotFound)
+	}
+	return parseGoogleEvent(instances.Items[0])
+}
+
+// CreateEvent creates an event in the user's calendar, attendees are sent an invite.
+func (p *GoogleCalendarProvider) CreateEvent(ctx context.Context, event CalendarEvent) (CalendarEvent, error) {
+	body, err := parseCalendarEventToGoogleEvent(event)
+	if err != nil {
+		return CalendarEvent{}, err
+	}
+
+	query := url.Values{}
+	query.Set("sendUpdates", "all")
+
+	var created googleEvent
+	if err = p.do(ctx, http.MethodPost, p.eventsURL(query), body, &created); err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to create google event: %w", err)
+	}
+
+	return parseGoogleEvent(created)
+}
+
+// PatchEvent updates the time of a user's event, attendees are sent the update.
+func (p *GoogleCalendarProvider) PatchEvent(ctx context.Context, eventID string, patch CalendarEventPatch) error {
+	body := googleEvent{}
+	if patch.Start != nil {
+		body.Start = newGoogleEventDateTime(*patch.Start, patch.Location)
+	}
+	if patch.End != nil {
+		body.End = newGoogleEventDateTime(*patch.End, patch.Location)
+	}
+
+	query := url.Values{}
+	query.Set("sendUpdates", "all")
+
+	if err := p.do(ctx, http.MethodPatch, p.eventURL(eventID, query), body, nil); err != nil {
+		return fmt.Errorf("failed to update google event: %w", err)
+	}
+	return nil
+}
+
+// FreeBusy returns the periods in which the user is busy, using the Google freeBusy endpoint.
+func (p *GoogleCalendarProvider) FreeBusy(ctx context.Context, start, end time.Time) ([]TimeInterval, error) {
+	calendars, err := p.freeBusy(ctx, start, end, []string{googlePrimaryCalendar})
+	if err != nil {
+		return nil, err
+	}
+
+	busy, ok := calendars[googlePrimaryCalendar]
+	if !ok {
+		return nil, errors.New("google freeBusy did not return the primary calendar")
+	}
+	return busy, nil
+}
+
+// FindMeetingTimes suggests meeting times from the Google free/busy of the user and the attendees.
+// Attendees whose calendar can't be read by the user are treated as unknown.
+func (p *GoogleCalendarProvider) FindMeetingTimes(ctx context.Context,
+	body SchedulingSlotsBodySchema,
+) (SchedulingSlotsSuccessResponseBody, error) {
+	start, end, err := timeConstraintRange(body)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, err
+	}
+
+	ids := []string{googlePrimaryCalendar}
+	for _, a := range body.Attendees {
+		ids = append(ids, string(a.EmailAddress.Address))
+	}
+
+	calendars, err := p.freeBusy(ctx, start, end, ids)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, err
+	}
+
+	organiserBusy, ok := calendars[googlePrimaryCalendar]
+	organiser := attendeeFreeBusy{busy: organiserBusy, known: ok}
+
+	attendees := make([]attendeeFreeBusy, 0, len(body.Attendees))
+	for _, a := range body.Attendees {
+		busy, known := calendars[string(a.EmailAddress.Address)]
+		attendees = append(attendees, attendeeFreeBusy{attendee: a, busy: busy, known: known})
+	}
+
+	return findMeetingTimesFromFreeBusy(body, organiser, attendees)
+}
+
+// ListRooms lists the calendar resources in the user's Google Workspace.
+func (p *GoogleCalendarProvider) ListRooms(ctx context.Context) ([]Room, error) {
+	rooms := []Room{}
+	pageToken := ""
+	for {
+		query := url.Values{}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		var resources googleCalendarResourceList
+		if err := p.do(ctx, http.MethodGet,
+			fmt.Sprintf("%s/customer/my_customer/resources/calendars?%s", p.directoryBaseURL, query.Encode()),
+			nil, &resources); err != nil {
+			return nil, fmt.Errorf("failed to list google calendar resources: %w", err)
+		}
+
+		for _, r := range resources.Items {
+			if r.ResourceEmail == "" {
+				return nil, errors.New("email for room was nil")
+			}
+			rooms = append(rooms, Room{
+				Email: openapi_types.Email(r.ResourceEmail),
+				Name:  r.ResourceName,
+			})
+		}
+
+		if resources.NextPageToken == "" {
+			return rooms, nil
+		}
+		pageToken = resources.NextPageToken
+	}
+}
+
+// freeBusy gets the merged busy periods of each calendar id that could be read.
+func (p *GoogleCalendarProvider) freeBusy(ctx context.Context, start, end time.Time,
+	ids []string,
+) (map[string][]TimeInterval, error) {
+	reqBody := googleFreeBusyRequest{
+		TimeMin: start.UTC(),
+		TimeMax: end.UTC(),
+		Items:   make([]googleFreeBusyRequestItem, 0, len(ids)),
+	}
+	for _, id := range ids {
+		reqBody.Items = append(reqBody.Items, googleFreeBusyRequestItem{ID: id})
+	}
+
+	var resp googleFreeBusyResponse
+	if err := p.do(ctx, http.MethodPost, p.calendarBaseURL+"/freeBusy", reqBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get google free busy: %w", err)
+	}
+
+	calendars := map[string][]TimeInterval{}
+	for id, c := range resp.Calendars {
+		// Calendars which can't be read (eg. outside the workspace) are returned with errors
+		if len(c.Errors) > 0 {
+			continue
+		}
+		busy := make([]TimeInterval, 0, len(c.Busy))
+		for _, b := range c.Busy {
+			busy = append(busy, TimeInterval{Start: b.Start, End: b.End})
+		}
+		calendars[id] = mergeTimeIntervals(busy)
+	}
+	return calendars, nil
+}
+
+// listEvents lists the user's primary calendar events, following every page.
+func (p *GoogleCalendarProvider) listEvents(ctx context.Context, query url.Values) ([]googleEvent, error) {
+	events := []googleEvent{}
+	for {
+		var page googleEventList
+		if err := p.do(ctx, http.MethodGet, p.eventsURL(query), nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to list google events: %w", err)
+		}
+		events = append(events, page.Items...)
+
+		if page.NextPageToken == "" {
+			return events, nil
+		}
+		query.Set("pageToken", page.NextPageToken)
+	}
+}
+
+func (p *GoogleCalendarProvider) eventsURL(query url.Values) string {
+	u := fmt.Sprintf("%s/calendars/%s/events", p.calendarBaseURL, googlePrimaryCalendar)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+func (p *GoogleCalendarProvider) eventURL(eventID string, query url.Values) string {
+	u := fmt.Sprintf("%s/calendars/%s/events/%s", p.calendarBaseURL, googlePrimaryCalendar, url.PathEscape(eventID))
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+func (p *GoogleCalendarProvider) eventInstancesURL(eventID string, query url.Values) string {
+	u := fmt.Sprintf("%s/calendars/%s/events/%s/instances", p.calendarBaseURL, googlePrimaryCalendar,
+		url.PathEscape(eventID))
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// do makes a request to the Google API, retrying server errors.
+// reqBody and respBody are JSON encoded and decoded if not nil.
+// A 404 is returned as ErrCalendarEventNotFound.
+func (p *GoogleCalendarProvider) do(ctx context.Context, method, u string, reqBody any, respBody any) error {
+	var encodedBody []byte
+	if reqBody != nil {
+		var err error
+		if encodedBody, err = json.Marshal(reqBody); err != nil {
+			return fmt.Errorf("failed to marshal google request body: %w", err)
+		}
+	}
+
+	return retry.Do(func() error {
+		req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(encodedBody))
+		if err != nil {
+			return retry.Unrecoverable(fmt.Errorf("failed to create google request: %w", err))
+		}
+		if reqBody != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return retry.Unrecoverable(err)
+			}
+			return fmt.Errorf("failed to make google request: %w", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			//nolint: mnd // only read the start of the error body for logging
+			errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			apiErr := googleAPIError{StatusCode: resp.StatusCode, Body: string(errBody)}
+
+			switch {
+			case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+				return retry.Unrecoverable(fmt.Errorf("%w: %w", ErrCalendarEventNotFound, apiErr))
+			case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+				return apiErr
+			default:
+				return retry.Unrecoverable(apiErr)
+			}
+		}
+
+		if respBody == nil {
+			return nil
+		}
+		if err = json.NewDecoder(resp.Body).Decode(respBody); err != nil {
+			return retry.Unrecoverable(fmt.Errorf("failed to decode google response: %w", err))
+		}
+		return nil
+	}, retry.Attempts(3), retry.Delay(time.Millisecond*500), retry.LastErrorOnly(true))
+}
+
+// newGoogleEventDateTime converts t into a Google event time in loc (UTC if nil), recurring events
+// repeat at the same local time in it.
+func newGoogleEventDateTime(t time.Time, loc *time.Location) *googleEventDateTime {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &googleEventDateTime{DateTime: t.In(loc).Format(time.RFC3339), TimeZone: loc.String()}
+}
+
+// parseGoogleEventDateTime parses a Google event start or end time, all-day events start at midnight UTC.
+func parseGoogleEventDateTime(dt *googleEventDateTime) (*string, error) {
+	if dt == nil {
+		return nil, nil
+	}
+
+	var t time.Time
+	var err error
+	switch {
+	case dt.DateTime != "":
+		t, err = time.Parse(time.RFC3339, dt.DateTime)
+	case dt.Date != "":
+		t, err = time.Parse(time.DateOnly, dt.Date)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse google event time: %w", err)
+	}
+
+	formatted := FormatCalendarEventTime(t)
+	return &formatted, nil
+}
+
+// parseGoogleResponseStatus maps a Google attendee response status onto ours.
+func parseGoogleResponseStatus(status string) AttendeeResponseStatus {
+	switch status {
+	case "accepted":
+		return AttendeeResponseStatusAccepted
+	case "declined":
+		return AttendeeResponseStatusDeclined
+	case "tentative":
+		return AttendeeResponseStatusEntativelyAccepted
+	case "needsAction":
+		return AttendeeResponseStatusNotResponded
+	default:
+		return AttendeeResponseStatusNone
+	}
+}
+
+// parseGoogleEvent parses a Google event into a CalendarEvent, times are converted to UTC.
+func parseGoogleEvent(e googleEvent) (CalendarEvent, error) {
+	startTime, err := parseGoogleEventDateTime(e.Start)
+	if err != nil {
+		return CalendarEvent{}, err
+	}
+
+	endTime, err := parseGoogleEventDateTime(e.End)
+	if err != nil {
+		return CalendarEvent{}, err
+	}
+
+	attendees := []Attendee{}
+	for _, a := range e.Attendees {
+		attendeeType := Required
+		switch {
+		case a.Resource:
+			attendeeType = Resource
+		case a.Optional:
+			attendeeType = Optional
+		}
+
+		responseStatus := parseGoogleResponseStatus(a.ResponseStatus)
+		if a.Organizer {
+			responseStatus = AttendeeResponseStatusOrganizer
+		}
+
+		attendees = append(attendees, Attendee{
+			AttendeeType:   &attendeeType,
+			Email:          openapi_types.Email(a.Email),
+			ResponseStatus: &responseStatus,
+		})
+	}
+
+	locations := []Location{}
+	if e.Location != "" {
+		location := e.Location
+		locations = append(locations, Location{Name: &location})
+	}
+
+	isCancelled := e.Status == "cancelled"
+
+	ce := CalendarEvent{
+		Attendees:   attendees,
+		Created:     e.Created,
+		EndTime:     endTime,
+		IsCancelled: &isCancelled,
+		Locations:   locations,
+		StartTime:   startTime,
+	}
+	if e.ID != "" {
+		ce.Id = &e.ID
+	}
+	if e.ICalUID != "" {
+		ce.ICalUId = &e.ICalUID
+	}
+	if e.Summary != "" {
+		ce.Subject = &e.Summary
+	}
+	if e.Description != "" {
+		ce.Body = &e.Description
+	}
+	if e.HTMLLink != "" {
+		ce.WebLink = &e.HTMLLink
+	}
+	if e.HangoutLink != "" {
+		ce.JoinURL = &e.HangoutLink
+	}
+	if e.Organizer != nil && e.Organizer.Email != "" {
+		organizer := openapi_types.Email(e.Organizer.Email)
+		ce.Organizer = &organizer
+	}
+	if e.RecurringEventID != "" {
+		ce.SeriesMasterId = &e.RecurringEventID
+	}
+	if ce.OriginalStartTime, err = parseGoogleEventDateTime(e.OriginalStartTime); err != nil {
+		return CalendarEvent{}, err
+	}
+	ce.Recurrence = parseGoogleRecurrence(e.Recurrence)
+
+	return ce, nil
+}
+
+// parseCalendarEventToGoogleEvent converts a CalendarEvent into a Google event to be created.
+func parseCalendarEventToGoogleEvent(event CalendarEvent) (googleEvent, error) {
+	if event.StartTime == nil || event.EndTime == nil {
+		return googleEvent{}, errors.New("event is missing a start or end time")
+	}
+
+	start, err := ParseCalendarEventTime(*event.StartTime)
+	if err != nil {
+		return googleEvent{}, err
+	}
+
+	end, err := ParseCalendarEventTime(*event.EndTime)
+	if err != nil {
+		return googleEvent{}, err
+	}
+
+	loc, err := calendarEventLocation(event)
+	if err != nil {
+		return googleEvent{}, err
+	}
+
+	e := googleEvent{
+		Start: newGoogleEventDateTime(start, loc),
+		End:   newGoogleEventDateTime(end, loc),
+	}
+	if event.Subject != nil {
+		e.Summary = *event.Subject
+	}
+	if event.Body != nil {
+		e.Description = *event.Body
+	}
+	if len(event.Locations) > 0 && event.Locations[0].Name != nil {
+		e.Location = *event.Locations[0].Name
+	}
+
+	if event.Recurrence != nil {
+		e.Recurrence = []string{toGoogleRRULE(*event.Recurrence)}
+	}
+
+	for _, a := range event.Attendees {
+		attendee := googleEventAttendee{Email: string(a.Email)}
+		if a.AttendeeType != nil {
+			attendee.Optional = *a.AttendeeType == Optional
+			attendee.Resource = *a.AttendeeType == Resource
+		}
+		e.Attendees = append(e.Attendees, attendee)
+	}
+
+	return e, nil
+}
+
+// googleWeekdays maps weekdays onto their RRULE BYDAY abbreviations.
+// nolint: gochecknoglobals // immutable map, wont change at runtime
+var googleWeekdays = map[Weekday]string{
+	Monday:    "MO",
+	Tuesday:   "TU",
+	Wednesday: "WE",
+	Thursday:  "TH",
+	Friday:    "FR",
+	Saturday:  "SA",
+	Sunday:    "SU",
+}
+
+// parseGoogleWeekday parses an RRULE BYDAY abbreviation.
+func parseGoogleWeekday(abbreviation string) (Weekday, bool) {
+	for d, a := range googleWeekdays {
+		if a == abbreviation {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// googleRRULEUntilLayout is the layout of an RRULE UNTIL in UTC.
+const googleRRULEUntilLayout = "20060102T150405Z"
+
+// toGoogleRRULE converts a recurrence into the [RFC 5545 RRULE] Google uses for recurring events.
+// The first occurrence is the event's start, so monthly series repeat on its day of the month.
+//
+// [RFC 5545 RRULE]: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.3
+func toGoogleRRULE(r Recurrence) string {
+	rule := []string{"FREQ=" + strings.ToUpper(string(r.Pattern))}
+	if r.Interval != nil && *r.Interval > 1 {
+		rule = append(rule, fmt.Sprintf("INTERVAL=%d", *r.Interval))
+	}
+	if r.DaysOfWeek != nil && len(*r.DaysOfWeek) > 0 {
+		days := make([]string, 0, len(*r.DaysOfWeek))
+		for _, d := range *r.DaysOfWeek {
+			days = append(days, googleWeekdays[d])
+		}
+		rule = append(rule, "BYDAY="+strings.Join(days, ","))
+	}
+	switch {
+	case r.Occurrences != nil:
+		rule = append(rule, fmt.Sprintf("COUNT=%d", *r.Occurrences))
+	case r.EndDate != nil:
+		// UNTIL is inclusive, so occurrences can be at any time on the end date
+		until := r.EndDate.Time.UTC().AddDate(0, 0, 1).Add(-time.Second)
+		rule = append(rule, "UNTIL="+until.Format(googleRRULEUntilLayout))
+	}
+	return "RRULE:" + strings.Join(rule, ";")
+}
+
+// parseGoogleRecurrence parses the RRULE of a recurring Google event, nil is returned if there isn't one
+// or it can't be represented as a Recurrence, eg. "the first Monday of every month".
+func parseGoogleRecurrence(lines []string) *Recurrence {
+	for _, line := range lines {
+		rule, ok := strings.CutPrefix(line, "RRULE:")
+		if !ok {
+			continue
+		}
+
+		recurrence := Recurrence{}
+		for _, part := range strings.Split(rule, ";") {
+			key, value, _ := strings.Cut(part, "=")
+			switch key {
+			case "FREQ":
+				recurrence.Pattern = RecurrencePattern(strings.ToLower(value))
+				if _, ok = recurrenceFrequencies[recurrence.Pattern]; !ok {
+					return nil
+				}
+			case "INTERVAL":
+				interval, err := strconv.ParseInt(value, 10, 32)
+				if err != nil {
+					return nil
+				}
+				//nolint: gosec // parsed as a 32 bit int
+				interval32 := int32(interval)
+				recurrence.Interval = &interval32
+			case "COUNT":
+				count, err := strconv.ParseInt(value, 10, 32)
+				if err != nil {
+					return nil
+				}
+				//nolint: gosec // parsed as a 32 bit int
+				count32 := int32(count)
+				recurrence.Occurrences = &count32
+			case "UNTIL":
+				until, err := time.Parse(googleRRULEUntilLayout, value)
+				if err != nil {
+					// UNTIL is a date for all-day events
+					if until, err = time.Parse("20060102", value); err != nil {
+						return nil
+					}
+				}
+				year, month, day := until.Date()
+				recurrence.EndDate = &openapi_types.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+			case "BYDAY":
+				days := []Weekday{}
+				for _, abbreviation := range strings.Split(value, ",") {
+					// Days with an ordinal, eg. 1MO, aren't supported
+					weekday, found := parseGoogleWeekday(abbreviation)
+					if !found {
+						return nil
+					}
+					days = append(days, weekday)
+				}
+				recurrence.DaysOfWeek = &days
+			case "WKST":
+			default:
+				return nil
+			}
+		}
+		if recurrence.Pattern == "" || (recurrence.DaysOfWeek != nil && recurrence.Pattern != Weekly) {
+			return nil
+		}
+		return &recurrence
+	}
+	return nil
+}
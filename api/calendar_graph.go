@@ -0,0 +1,537 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/avast/retry-go"
+	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	graphusers "github.com/microsoftgraph/msgraph-sdk-go/users"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ensure that we've conformed to the `CalendarProvider`, `CalendarDeltaSyncer` and `CalendarSubscriber`
+// interfaces with a compile-time check.
+var (
+	_ CalendarProvider    = (*GraphCalendarProvider)(nil)
+	_ CalendarDeltaSyncer = (*GraphCalendarProvider)(nil)
+	_ CalendarSubscriber  = (*GraphCalendarProvider)(nil)
+)
+
+const (
+	// graphCalendarViewPageSize is how many events are requested per page of a calendar view,
+	// further pages are followed with @odata.nextLink.
+	graphCalendarViewPageSize int32 = 250
+
+	// graphOccurrenceSearchWindow is how far either side of its original start an occurrence is looked for,
+	// MSFT lists occurrences by when they are now rather than when they were originally scheduled.
+	graphOccurrenceSearchWindow = 7 * 24 * time.Hour
+)
+
+// GraphCalendarProvider is a CalendarProvider backed by Microsoft Graph.
+type GraphCalendarProvider struct {
+	graph *msgraphsdkgo.GraphServiceClient
+}
+
+// NewGraphCalendarProvider creates a GraphCalendarProvider for the user the graph client was created for.
+func NewGraphCalendarProvider(graph *msgraphsdkgo.GraphServiceClient) (*GraphCalendarProvider, error) {
+	if graph == nil {
+		return nil, errors.New("graph must not be nil")
+	}
+	return &GraphCalendarProvider{graph: graph}, nil
+}
+
+// NewGraphCalendarProviderFactory returns a CalendarProviderFactory that creates a
+// GraphCalendarProvider using the user's MSFT access token.
+func NewGraphCalendarProviderFactory(msalClient *confidential.Client, db *database.Database) CalendarProviderFactory {
+	return func(ctx context.Context, userID uint32) (CalendarProvider, error) {
+		if msalClient == nil {
+			return nil, errors.New("msal client has not been initialised")
+		}
+
+		graph, err := CreateMSFTGraphClient(ctx, msalClient, db, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create msgraph client: %w", err)
+		}
+
+		return NewGraphCalendarProvider(graph)
+	}
+}
+
+// ListEvents lists a user's events within a certain time range, following every page of the calendar view.
+// See [MSFT Calendar Me API Call] for docs on the API call made.
+//
+// [MSFT Calendar Me API Call]:
+// https://learn.microsoft.com/en-us/graph/api/calendar-list-calendarview?view=graph-rest-1.0&tabs=http
+func (p *GraphCalendarProvider) ListEvents(ctx context.Context, startTime,
+	endTime time.Time,
+) ([]CalendarEvent, error) {
+	events := []CalendarEvent{}
+	err := p.StreamEvents(ctx, startTime, endTime, func(e CalendarEvent) bool {
+		events = append(events, e)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// StreamEvents calls yield with each of a user's events within a certain time range, a page at a time,
+// so large calendar views don't have to be held in memory. Streaming stops early if yield returns false.
+func (p *GraphCalendarProvider) StreamEvents(ctx context.Context, startTime, endTime time.Time,
+	yield func(CalendarEvent) bool,
+) error {
+	var parseErr error
+	err := p.calendarView(ctx, startTime, endTime, func(e graphmodels.Eventable) bool {
+		var event CalendarEvent
+		if event, parseErr = parseSingleEventable(e); parseErr != nil {
+			return false
+		}
+		return yield(event)
+	})
+	if err != nil {
+		return err
+	}
+	if parseErr != nil {
+		return fmt.Errorf("failed to parse msft eventable: %w", parseErr)
+	}
+	return nil
+}
+
+// FreeBusy returns the periods in which the user is busy, built from their calendar view.
+// Cancelled events and events shown as free are ignored.
+func (p *GraphCalendarProvider) FreeBusy(ctx context.Context, startTime,
+	endTime time.Time,
+) ([]TimeInterval, error) {
+	busy := []TimeInterval{}
+	var parseErr error
+	err := p.calendarView(ctx, startTime, endTime, func(e graphmodels.Eventable) bool {
+		if !graphEventBusy(e) || e.GetStart() == nil || e.GetEnd() == nil ||
+			e.GetStart().GetDateTime() == nil || e.GetEnd().GetDateTime() == nil {
+			return true
+		}
+
+		var start, end time.Time
+		if start, parseErr = parseGraphDateTimeTimeZone(e.GetStart()); parseErr != nil {
+			return false
+		}
+		if end, parseErr = parseGraphDateTimeTimeZone(e.GetEnd()); parseErr != nil {
+			return false
+		}
+		busy = append(busy, TimeInterval{Start: start.UTC(), End: end.UTC()})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return busy, nil
+}
+
+// DeltaEvents gets the changes to a user's events within a certain time range since deltaLink was returned,
+// or every event if deltaLink is empty.
+// See [MSFT Calendar View Delta API Call] for docs on the API call made.
+//
+// [MSFT Calendar View Delta API Call]:
+// https://learn.microsoft.com/en-us/graph/api/event-delta?view=graph-rest-1.0&tabs=http
+func (p *GraphCalendarProvider) DeltaEvents(ctx context.Context, deltaLink string,
+	startTime, endTime time.Time,
+) (CalendarDelta, error) {
+	builder := p.graph.Me().CalendarView().Delta()
+	var configuration *graphusers.ItemCalendarViewDeltaRequestBuilderGetRequestConfiguration
+	if deltaLink != "" {
+		// The delta link keeps the time range of the first sync
+		builder = builder.WithUrl(deltaLink)
+	} else {
+		start := startTime.Format(time.RFC3339)
+		end := endTime.Format(time.RFC3339)
+		configuration = &graphusers.ItemCalendarViewDeltaRequestBuilderGetRequestConfiguration{
+			QueryParameters: &graphusers.ItemCalendarViewDeltaRequestBuilderGetQueryParameters{
+				StartDateTime: &start,
+				EndDateTime:   &end,
+			},
+		}
+	}
+
+	var resp graphusers.ItemCalendarViewDeltaGetResponseable
+	err := retry.Do(func() error {
+		var err error
+		resp, err = builder.GetAsDeltaGetResponse(ctx, configuration)
+		if err != nil {
+			var odataErr *odataerrors.ODataError
+			if errors.As(err, &odataErr) && odataErr.ResponseStatusCode == http.StatusGone {
+				return retry.Unrecoverable(fmt.Errorf("%w: %w", ErrCalendarDeltaExpired, err))
+			}
+			return fmt.Errorf("failed to make graph client call: %w", err)
+		}
+		if resp == nil {
+			return errors.New("graph returned an empty delta response")
+		}
+		return nil
+	}, retry.Attempts(3), retry.Delay(time.Millisecond*500), retry.Context(ctx), retry.LastErrorOnly(true))
+	if err != nil {
+		return CalendarDelta{}, fmt.Errorf("failed msft calendar view delta after 3 retries: %w", err)
+	}
+
+	pageIterator, err := msgraphcore.NewPageIterator[graphmodels.Eventable](resp, p.graph.GetAdapter(),
+		graphusers.CreateItemCalendarViewDeltaGetResponseFromDiscriminatorValue)
+	if err != nil {
+		return CalendarDelta{}, fmt.Errorf("failed to create msft delta page iterator: %w", err)
+	}
+
+	delta := CalendarDelta{Events: []CalendarDeltaEvent{}}
+	var parseErr error
+	err = pageIterator.Iterate(ctx, func(e graphmodels.Eventable) bool {
+		// Returned interfaces can be nil
+		if e == nil || e.GetId() == nil {
+			return true
+		}
+		if _, removed := e.GetAdditionalData()["@removed"]; removed {
+			delta.Events = append(delta.Events, CalendarDeltaEvent{Event: CalendarEvent{Id: e.GetId()}, Removed: true})
+			return true
+		}
+
+		var event CalendarEvent
+		if event, parseErr = parseSingleEventable(e); parseErr != nil {
+			return false
+		}
+		delta.Events = append(delta.Events, CalendarDeltaEvent{Event: event, Busy: graphEventBusy(e)})
+		return true
+	})
+	if err != nil {
+		return CalendarDelta{}, fmt.Errorf("failed to get next page of msft delta events: %w", err)
+	}
+	if parseErr != nil {
+		return CalendarDelta{}, fmt.Errorf("failed to parse msft eventable: %w", parseErr)
+	}
+
+	if pageIterator.GetOdataDeltaLink() == nil {
+		return CalendarDelta{}, errors.New("msft calendar view delta did not return a delta link")
+	}
+	delta.DeltaLink = *pageIterator.GetOdataDeltaLink()
+
+	return delta, nil
+}
+
+// graphEventBusy returns whether an event blocks the user's time, cancelled events and events
+// shown as free don't.
+func graphEventBusy(e graphmodels.Eventable) bool {
+	if e.GetIsCancelled() != nil && *e.GetIsCancelled() {
+		return false
+	}
+	return e.GetShowAs() == nil || *e.GetShowAs() != graphmodels.FREE_FREEBUSYSTATUS
+}
+
+// calendarView calls yield with each of the user's MSFT events within a certain time range, following
+// @odata.nextLink until every page has been read or yield returns false. Nil events are skipped.
+func (p *GraphCalendarProvider) calendarView(ctx context.Context, startTime,
+	endTime time.Time,
+	yield func(graphmodels.Eventable) bool,
+) error {
+	// Prepare request by formatting request parameters correctly.
+	start := startTime.Format(time.RFC3339)
+	end := endTime.Format(time.RFC3339)
+
+	pageSize := graphCalendarViewPageSize
+	requestParameters := &graphusers.ItemCalendarCalendarViewRequestBuilderGetQueryParameters{
+		EndDateTime:   &end,
+		StartDateTime: &start,
+		Top:           &pageSize,
+	}
+
+	configuration := &graphusers.ItemCalendarCalendarViewRequestBuilderGetRequestConfiguration{
+		QueryParameters: requestParameters,
+	}
+
+	// Make actual API request for the first page.
+
+	var events graphmodels.EventCollectionResponseable
+	var err error
+	err = retry.Do(func() error {
+		events, err = p.graph.Me().Calendar().CalendarView().Get(ctx, configuration)
+		if err != nil || events == nil {
+			return fmt.Errorf("failed to make graph client call: %w", err)
+		}
+		return nil
+	}, retry.Attempts(3), retry.Delay(time.Millisecond*500), retry.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("failed msft list events after 3 retries: %w", err)
+	}
+
+	pageIterator, err := msgraphcore.NewPageIterator[graphmodels.Eventable](events, p.graph.GetAdapter(),
+		graphmodels.CreateEventCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return fmt.Errorf("failed to create msft events page iterator: %w", err)
+	}
+
+	err = pageIterator.Iterate(ctx, func(e graphmodels.Eventable) bool {
+		// Returned interfaces can be nil
+		if e == nil {
+			return true
+		}
+		return yield(e)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get next page of msft events: %w", err)
+	}
+	return nil
+}
+
+// GetEvent gets a user's event by its MSFT event id.
+func (p *GraphCalendarProvider) GetEvent(ctx context.Context, eventID string) (CalendarEvent, error) {
+	msftMeeting, err := p.graph.Me().Events().ByEventId(eventID).Get(ctx, nil)
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to get meeting data from microsoft: %w", err)
+	}
+
+	return parseSingleEventable(msftMeeting)
+}
+
+// GetEventByICalUID gets a user's event by its iCalUId.
+func (p *GraphCalendarProvider) GetEventByICalUID(ctx context.Context, iCalUID string) (CalendarEvent, error) {
+	queryFilter := "iCalUId eq '" + iCalUID + "'"
+
+	requestConfig := graphusers.ItemEventsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphusers.ItemEventsRequestBuilderGetQueryParameters{
+			Filter: &queryFilter,
+		},
+	}
+
+	msftMeetingRes, err := p.graph.Me().Events().Get(ctx, &requestConfig)
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to get meeting data from microsoft: %w", err)
+	}
+
+	if msftMeetingRes == nil || len(msftMeetingRes.GetValue()) == 0 {
+		return CalendarEvent{}, fmt.Errorf("microsoft returned empty array: %w", ErrCalendarEventNotFound)
+	}
+
+	return parseSingleEventable(msftMeetingRes.GetValue()[0])
+}
+
+// GetEventOccurrence gets the occurrence of a user's recurring event originally scheduled to start at
+// originalStart, see [MSFT List Instances API Call].
+//
+// [MSFT List Instances API Call]: https://learn.microsoft.com/en-us/graph/api/event-list-instances?view=graph-rest-1.0
+func (p *GraphCalendarProvider) GetEventOccurrence(ctx context.Context, seriesID string,
+	originalStart time.Time,
+) (CalendarEvent, error) {
+	start := originalStart.Add(-graphOccurrenceSearchWindow).Format(time.RFC3339)
+	end := originalStart.Add(graphOccurrenceSearchWindow).Format(time.RFC3339)
+	pageSize := graphCalendarViewPageSize
+
+	requestConfig := graphusers.ItemEventsItemInstancesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphusers.ItemEventsItemInstancesRequestBuilderGetQueryParameters{
+			StartDateTime: &start,
+			EndDateTime:   &end,
+			Top:           &pageSize,
+		},
+	}
+
+	instances, err := p.graph.Me().Events().ByEventId(seriesID).Instances().Get(ctx, &requestConfig)
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to get event occurrences from microsoft: %w", err)
+	}
+
+	if instances != nil {
+		for _, e := range instances.GetValue() {
+			if e != nil && e.GetOriginalStart() != nil && e.GetOriginalStart().Equal(originalStart) {
+				return parseSingleEventable(e)
+			}
+		}
+	}
+	return CalendarEvent{}, fmt.Errorf("microsoft returned no occurrence at %s: %w",
+		originalStart.Format(time.RFC3339), ErrCalendarEventNotFound)
+}
+
+// CreateEvent creates an event in the user's calendar.
+func (p *GraphCalendarProvider) CreateEvent(ctx context.Context, event CalendarEvent) (CalendarEvent, error) {
+	msftEvent, err := parseCalendarEventToMSFTEvent(event)
+	if err != nil {
+		return CalendarEvent{}, err
+	}
+
+	var createdEventable graphmodels.Eventable
+	err = retry.Do(func() error {
+		createdEventable, err = p.graph.Me().Events().Post(ctx, msftEvent, nil)
+		if err != nil {
+			return fmt.Errorf("graph api create calendar failed: %w", err)
+		}
+		return nil
+	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed msft create event after 3 retries: %w", err)
+	}
+
+	return parseSingleEventable(createdEventable)
+}
+
+// PatchEvent updates the time of a user's event.
+func (p *GraphCalendarProvider) PatchEvent(ctx context.Context, eventID string, patch CalendarEventPatch) error {
+	requestBody := graphmodels.NewEvent()
+
+	if patch.Start != nil {
+		requestBody.SetStart(toGraphDateTimeTimeZone(*patch.Start, patch.Location))
+	}
+
+	if patch.End != nil {
+		requestBody.SetEnd(toGraphDateTimeTimeZone(*patch.End, patch.Location))
+	}
+
+	if _, err := p.graph.Me().Events().ByEventId(eventID).Patch(ctx, requestBody, nil); err != nil {
+		return fmt.Errorf("failed to update event in microsoft: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe creates a Graph subscription to changes to the user's events, see [MSFT Create Subscription API Call].
+//
+// [MSFT Create Subscription API Call]:
+// https://learn.microsoft.com/en-us/graph/api/subscription-post-subscriptions?view=graph-rest-1.0&tabs=http
+func (p *GraphCalendarProvider) Subscribe(ctx context.Context, notificationURL, clientState string,
+	expiresAt time.Time,
+) (CalendarSubscription, error) {
+	changeType := "created,updated,deleted"
+	resource := "me/events"
+
+	requestBody := graphmodels.NewSubscription()
+	requestBody.SetChangeType(&changeType)
+	requestBody.SetNotificationUrl(&notificationURL)
+	requestBody.SetResource(&resource)
+	requestBody.SetExpirationDateTime(&expiresAt)
+	requestBody.SetClientState(&clientState)
+
+	subscription, err := p.graph.Subscriptions().Post(ctx, requestBody, nil)
+	if err != nil {
+		return CalendarSubscription{}, fmt.Errorf("failed to create msft subscription: %w", err)
+	}
+
+	return parseSubscriptionable(subscription)
+}
+
+// RenewSubscription extends the expiry of a Graph subscription.
+func (p *GraphCalendarProvider) RenewSubscription(ctx context.Context, subscriptionID string,
+	expiresAt time.Time,
+) (CalendarSubscription, error) {
+	requestBody := graphmodels.NewSubscription()
+	requestBody.SetExpirationDateTime(&expiresAt)
+
+	subscription, err := p.graph.Subscriptions().BySubscriptionId(subscriptionID).Patch(ctx, requestBody, nil)
+	if err != nil {
+		var odataErr *odataerrors.ODataError
+		if errors.As(err, &odataErr) && odataErr.ResponseStatusCode == http.StatusNotFound {
+			return CalendarSubscription{}, fmt.Errorf("%w: %w", ErrCalendarSubscriptionNotFound, err)
+		}
+		return CalendarSubscription{}, fmt.Errorf("failed to renew msft subscription: %w", err)
+	}
+
+	return parseSubscriptionable(subscription)
+}
+
+// parseSubscriptionable converts a Graph subscription into a CalendarSubscription.
+func parseSubscriptionable(s graphmodels.Subscriptionable) (CalendarSubscription, error) {
+	if s == nil || s.GetId() == nil || s.GetExpirationDateTime() == nil {
+		return CalendarSubscription{}, errors.New("msft subscription is missing its id or expiry")
+	}
+
+	return CalendarSubscription{
+		ID:        *s.GetId(),
+		ExpiresAt: s.GetExpirationDateTime().UTC(),
+	}, nil
+}
+
+// FindMeetingTimes calls the MSFT findMeetingTimes endpoint.
+func (p *GraphCalendarProvider) FindMeetingTimes(ctx context.Context,
+	body SchedulingSlotsBodySchema,
+) (SchedulingSlotsSuccessResponseBody, error) {
+	// Get custom graph request header and config
+	graphConfigAndBody, err := CreateSchedulingGraphReqBody(&body)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{},
+			fmt.Errorf("failed to create graph req body for findMeetings: %w", err)
+	}
+
+	// Attempt to call FindMeetingTimes 3 times
+	var findMeetingTimes graphmodels.MeetingTimeSuggestionsResultable
+	err = retry.Do(func() error {
+		findMeetingTimes, err = p.graph.Me().FindMeetingTimes().Post(ctx,
+			graphConfigAndBody.reqBody, graphConfigAndBody.config)
+		if err != nil {
+			return fmt.Errorf("failed to make msgraph findMeeting API call: %w", err)
+		}
+
+		return nil
+	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{},
+			fmt.Errorf("failed msft find meeting times after 3 retries: %w", err)
+	}
+
+	// Process MSFT resp into our own types
+	respBody, err := ProcessFindMeetingsResponse(findMeetingTimes)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{},
+			fmt.Errorf("failed to process msft findMeeting response: %w", err)
+	}
+
+	return respBody, nil
+}
+
+// ListRooms lists the rooms in the user's tenant.
+func (p *GraphCalendarProvider) ListRooms(ctx context.Context) ([]Room, error) {
+	graphRoom, err := p.graph.Places().GraphRoom().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get response from msgraph: %w", err)
+	}
+
+	rooms := graphRoom.GetValue()
+	if rooms == nil {
+		return nil, errors.New("graph room GetValue was nil")
+	}
+
+	parsedRooms := make([]Room, 0)
+	for _, r := range rooms {
+		email := r.GetEmailAddress()
+		if email == nil {
+			return nil, errors.New("email for room was nil")
+		}
+
+		displayName := "placeholder name"
+		if r.GetDisplayName() != nil {
+			displayName = *r.GetDisplayName()
+		}
+		parsedRooms = append(parsedRooms, Room{
+			Email: openapi_types.Email(*email),
+			Name:  displayName,
+		})
+	}
+
+	return parsedRooms, nil
+}
+
+// parseSingleEventable parses a single MSFT event.
+func parseSingleEventable(e graphmodels.Eventable) (CalendarEvent, error) {
+	if e == nil {
+		return CalendarEvent{}, ErrCalendarEventNotFound
+	}
+
+	parsedEvents, err := parseEventableResp([]graphmodels.Eventable{e})
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("failed to parse msft eventable: %w", err)
+	}
+
+	return parsedEvents[0], nil
+}
@@ -3,16 +3,13 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
-	"github.com/SlotifyApp/slotify-backend/database"
-	"github.com/avast/retry-go"
+	"github.com/SlotifyApp/slotify-backend/notification"
 	"go.uber.org/zap"
-
-	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
-	"github.com/microsoftgraph/msgraph-sdk-go/users"
 )
 
 // (GET /api/calendar/{userID}).
@@ -27,20 +24,46 @@ func (s Server) GetAPICalendarUserID(w http.ResponseWriter,
 	ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
 	defer cancel()
 
-	// create graph client for the userID in query params.
+	// Events are returned in the time zone of the user asking for them unless the request says otherwise
+	loc, err := requestLocation(ctx, s.DB, loggedInUserID, params.TimeZone)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTimeZone) {
+			logger.Error("invalid calendar time zone", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.Error("failed to get user's time zone", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get user's time zone")
+		return
+	}
+
+	// create calendar provider for the userID in query params.
 	// TODO: stop private events etc. from being shown
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
+	calendar, err := s.CalendarProvider(ctx, userID)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
 		return
 	}
 
-	// Make call to API route and parse events
-	calendarEvents, err := makeCalendarMeAPICall(graph, params.Start, params.End)
+	if params.ForceRefresh != nil && *params.ForceRefresh {
+		if err = RefreshCalendar(ctx, calendar); err != nil {
+			logger.Error("failed to refresh calendar", zap.Error(err))
+			sendError(w, http.StatusBadGateway, "Failed to refresh calendar")
+			return
+		}
+	}
+
+	calendarEvents, err := calendar.ListEvents(ctx, params.Start, params.End)
 	if err != nil {
-		logger.Error("failed to make calendar me msgraph api call", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to make calendar me msgraph api call")
+		logger.Error("failed to list calendar events", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to list calendar events")
+		return
+	}
+
+	if calendarEvents, err = calendarEventsIn(calendarEvents, loc); err != nil {
+		logger.Error("failed to convert calendar events to time zone", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to list calendar events")
 		return
 	}
 
@@ -71,49 +94,81 @@ func (s Server) PostAPICalendarMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
+	if eventRequest.Recurrence != nil {
+		if err := validateRecurrence(*eventRequest.Recurrence); err != nil {
+			logger.Error("invalid event recurrence", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Events are created in the user's time zone unless the request says otherwise
+	loc, err := requestLocation(ctx, s.DB, userID, eventRequest.TimeZone)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
+		if errors.Is(err, ErrInvalidTimeZone) {
+			logger.Error("invalid event time zone", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.Error("failed to get user's time zone", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get user's time zone")
 		return
 	}
+	timeZone := loc.String()
+	eventRequest.TimeZone = &timeZone
 
-	event := parseCalendarEventToMSFTEvent(eventRequest)
+	calendar, err := s.CalendarProvider(ctx, userID)
+	if err != nil {
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
+		return
+	}
 
-	var createdEventable graphmodels.Eventable
-	err = retry.Do(func() error {
-		createdEventable, err = graph.Me().Events().Post(ctx, event, nil)
-		if err != nil {
-			return fmt.Errorf("graph api create calendar failed: %w", err)
-		}
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
+	createdEvent, err := s.createCalendarEvent(ctx, logger, calendar, userID, eventRequest)
 	if err != nil {
 		logger.Error("failed to create calendar event", zap.Error(err))
 		sendError(w, http.StatusBadGateway, "Failed to create event")
 		return
 	}
 
-	// if success, send through notifications
-	// TODO: send separate notification for other participants, saying a meeting has been added
-	notif := database.CreateNotificationParams{
-		Message: "Created meeting!",
-		Created: time.Now(),
+	if createdEvent, err = calendarEventIn(createdEvent, loc); err != nil {
+		logger.Error("failed to convert created event to time zone", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to create event")
+		return
 	}
-	err = s.NotificationService.SendNotification(ctx, s.Logger, s.DB, []uint32{userID}, notif)
+
+	SetHeaderAndWriteResponse(w, http.StatusCreated, createdEvent)
+}
+
+// createCalendarEvent creates event in userID's calendar and notifies them it was created, the event's time zone
+// must already be set. Failing to notify the user is only logged as the event was still created.
+func (s Server) createCalendarEvent(ctx context.Context, logger *zap.SugaredLogger, calendar CalendarProvider,
+	userID uint32, event CalendarEvent,
+) (CalendarEvent, error) {
+	createdEvent, err := calendar.CreateEvent(ctx, event)
 	if err != nil {
-		// dont send http error because the actual op succeeded
-		logger.Error("failed to send notification", zap.Error(err))
+		return CalendarEvent{}, fmt.Errorf("failed to create calendar event: %w", err)
 	}
 
-	var parsedEvent []CalendarEvent
-	if parsedEvent, err = parseEventableResp([]graphmodels.Eventable{createdEventable}); err != nil {
-		logger.Error("failed to parse msft event response", zap.Error(err))
-		sendError(w, http.StatusBadRequest, "failed to parse msft event response")
-		return
+	// if success, send through notifications
+	// TODO: send separate notification for other participants, saying a meeting has been added
+	var created notification.MeetingCreated
+	if createdEvent.Id != nil {
+		created.EventID = *createdEvent.Id
+	}
+	if createdEvent.Subject != nil {
+		created.Subject = *createdEvent.Subject
+	}
+	notif, err := notification.NewNotificationParams(created, time.Now())
+	if err == nil {
+		err = notification.Enqueue(ctx, s.DB, "", []uint32{userID}, notif)
+	}
+	if err != nil {
+		// dont return an error because the actual op succeeded
+		logger.Error("failed to enqueue notification", zap.Error(err))
 	}
 
-	SetHeaderAndWriteResponse(w, http.StatusCreated, parsedEvent[0])
+	return createdEvent, nil
 }
 
 // (GET /api/calendar/event).
@@ -126,20 +181,9 @@ func (s Server) GetAPICalendarEvent(w http.ResponseWriter, r *http.Request, para
 	ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
 	defer cancel()
 
-	// Make call to API route and parse events
-	// Get old meeting data from microsoft
-	var msftMeeting graphmodels.Eventable
-	//nolint: nestif // nesting complexity is not too much
+	// The iCalUId is shared between attendees, so look the event up in the owner's calendar
+	calendarOwnerID := userID
 	if params.IsICalUId {
-		queryFilter := "iCalUId eq '" + params.MsftID + "'"
-
-		// Get old meeting data from microsoft
-		requestConfig := users.ItemEventsRequestBuilderGetRequestConfiguration{
-			QueryParameters: &users.ItemEventsRequestBuilderGetQueryParameters{
-				Filter: &queryFilter,
-			},
-		}
-
 		meetingObj, err := s.DB.GetMeetingByMSFTID(ctx, params.MsftID)
 		if err != nil {
 			logger.Error("meeting not found in db to find owner of meeting with iCalUID", zap.Error(err))
@@ -154,54 +198,40 @@ func (s Server) GetAPICalendarEvent(w http.ResponseWriter, r *http.Request, para
 			sendError(w, http.StatusBadGateway, "owner is not found as a slotify user")
 			return
 		}
+		calendarOwnerID = ownerObj.ID
+	}
 
-		graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, ownerObj.ID)
-		if err != nil {
-			logger.Error("failed to create msgraph client with owner id", zap.Error(err))
-			sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
-			return
-		}
-
-		var msftMeetingRes graphmodels.EventCollectionResponseable
-		msftMeetingRes, err = graph.Me().Events().Get(ctx, &requestConfig)
-		if err != nil {
-			logger.Error("failed to get meeting data from microsoft", zap.Error(err))
-			sendError(w, http.StatusBadGateway, "Failed to get meeting data from microsoft")
-			return
-		}
+	calendar, err := s.CalendarProvider(ctx, calendarOwnerID)
+	if err != nil {
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
+		return
+	}
 
-		if msftMeetingRes != nil && msftMeetingRes.GetValue() != nil &&
-			len(msftMeetingRes.GetValue()) > 0 {
-			msftMeeting = msftMeetingRes.GetValue()[0]
-		} else {
-			logger.Error("failed to get meeting data from microsoft", zap.Error(err))
-			sendError(w, http.StatusBadGateway, "Failed to get meeting data from microsoft")
-			return
-		}
+	var event CalendarEvent
+	if params.IsICalUId {
+		event, err = calendar.GetEventByICalUID(ctx, params.MsftID)
 	} else {
-		// create graph client for the userID in query params.
-		graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
-		if err != nil {
-			logger.Error("failed to create msgraph client", zap.Error(err))
-			sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
-			return
-		}
-
-		msftMeeting, err = graph.Me().Events().ByEventId(params.MsftID).Get(ctx, nil)
-		if err != nil {
-			logger.Error("failed to get meeting data from microsoft", zap.Error(err))
-			sendError(w, http.StatusBadGateway, "Failed to get meeting data from microsoft")
-			return
-		}
+		event, err = calendar.GetEvent(ctx, params.MsftID)
+	}
+	if err != nil {
+		logger.Error("failed to get calendar event", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to get calendar event")
+		return
 	}
 
-	var parsedEvents []CalendarEvent
-	var err error
-	if parsedEvents, err = parseEventableResp([]graphmodels.Eventable{msftMeeting}); err != nil {
-		logger.Error("failed to get meeting data from microsoft", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to get meeting data from microsoft")
+	// The event is returned in the time zone of the user asking for it, not its owner's
+	loc, err := getUserLocation(ctx, s.DB, userID)
+	if err != nil {
+		logger.Error("failed to get user's time zone", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get user's time zone")
+		return
+	}
+	if event, err = calendarEventIn(event, loc); err != nil {
+		logger.Error("failed to convert calendar event to time zone", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to get calendar event")
 		return
 	}
 
-	SetHeaderAndWriteResponse(w, http.StatusOK, parsedEvents[0])
+	SetHeaderAndWriteResponse(w, http.StatusOK, event)
 }
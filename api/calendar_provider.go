@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
+	"github.com/SlotifyApp/slotify-backend/database"
+)
+
+// CalendarEventTimeLayout is the layout of CalendarEvent start and end times, RFC3339 in UTC.
+const CalendarEventTimeLayout = time.RFC3339
+
+var (
+	// ErrCalendarEventNotFound is returned by a CalendarProvider when an event does not exist.
+	ErrCalendarEventNotFound = errors.New("calendar event could not be found")
+	// ErrCalendarDeltaExpired is returned by a CalendarDeltaSyncer when a delta link can no longer be used
+	// and every event has to be synced again.
+	ErrCalendarDeltaExpired = errors.New("calendar delta link has expired")
+	// ErrCalendarSubscriptionNotFound is returned by a CalendarSubscriber when a subscription has lapsed
+	// or been deleted.
+	ErrCalendarSubscriptionNotFound = errors.New("calendar subscription could not be found")
+)
+
+// CalendarEventPatch holds the fields of a calendar event to update, nil fields are left unchanged.
+type CalendarEventPatch struct {
+	Start *time.Time
+	End   *time.Time
+	// Location is the time zone the new times are in, a recurring event keeps the same local time in it
+	// across DST changes. UTC if nil.
+	Location *time.Location
+}
+
+// TimeInterval is a period of time in which a user is busy.
+type TimeInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CalendarProvider is a calendar backend (eg. Microsoft Graph) acting on behalf of a single user.
+// Handlers should only talk to a user's calendar through this interface.
+type CalendarProvider interface {
+	// ListEvents lists the user's events that occur between start and end.
+	ListEvents(ctx context.Context, start, end time.Time) ([]CalendarEvent, error)
+	// GetEvent gets one of the user's events by the provider's event id.
+	GetEvent(ctx context.Context, eventID string) (CalendarEvent, error)
+	// GetEventByICalUID gets one of the user's events by its iCalUId, which is shared between attendees.
+	GetEventByICalUID(ctx context.Context, iCalUID string) (CalendarEvent, error)
+	// GetEventOccurrence gets the occurrence of one of the user's recurring events, by the provider's id of
+	// the series and the time the occurrence was originally scheduled to start.
+	GetEventOccurrence(ctx context.Context, seriesID string, originalStart time.Time) (CalendarEvent, error)
+	// CreateEvent creates an event in the user's calendar, returning the created event.
+	CreateEvent(ctx context.Context, event CalendarEvent) (CalendarEvent, error)
+	// PatchEvent updates one of the user's events by the provider's event id.
+	PatchEvent(ctx context.Context, eventID string, patch CalendarEventPatch) error
+	// FindMeetingTimes suggests meeting times for the user and the attendees in body.
+	FindMeetingTimes(ctx context.Context, body SchedulingSlotsBodySchema) (SchedulingSlotsSuccessResponseBody, error)
+	// ListRooms lists the rooms that can be booked by the user.
+	ListRooms(ctx context.Context) ([]Room, error)
+	// FreeBusy returns the periods between start and end in which the user is busy.
+	FreeBusy(ctx context.Context, start, end time.Time) ([]TimeInterval, error)
+}
+
+// CalendarDeltaEvent is an event that changed since the last delta sync.
+type CalendarDeltaEvent struct {
+	Event CalendarEvent
+	// Busy is false for events that don't block the user's time, eg. cancelled events or ones shown as free.
+	Busy bool
+	// Removed is true if the event was deleted or moved out of the synced range, only Event.Id is set.
+	Removed bool
+}
+
+// CalendarDelta is the changes to a user's events since the last delta sync.
+type CalendarDelta struct {
+	Events []CalendarDeltaEvent
+	// DeltaLink gets the changes since this delta sync.
+	DeltaLink string
+}
+
+// CalendarDeltaSyncer is implemented by CalendarProviders that can list what changed in a user's calendar,
+// which lets their events be cached.
+type CalendarDeltaSyncer interface {
+	// DeltaEvents gets the changes to the user's events between start and end since deltaLink was returned,
+	// or every event if deltaLink is empty. ErrCalendarDeltaExpired is returned if deltaLink can't be used.
+	DeltaEvents(ctx context.Context, deltaLink string, start, end time.Time) (CalendarDelta, error)
+}
+
+// CalendarRefresher is implemented by CalendarProviders that cache events, so the cache can be refreshed
+// when a caller needs the latest events.
+type CalendarRefresher interface {
+	// Refresh brings the user's cached events up to date with their calendar.
+	Refresh(ctx context.Context) error
+}
+
+// RefreshCalendar refreshes the provider's cached events, it does nothing for providers without a cache.
+func RefreshCalendar(ctx context.Context, provider CalendarProvider) error {
+	if refresher, ok := provider.(CalendarRefresher); ok {
+		return refresher.Refresh(ctx)
+	}
+	return nil
+}
+
+// CalendarSubscription is a subscription to changes to a user's events.
+type CalendarSubscription struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+// CalendarSubscriber is implemented by CalendarProviders that can call a webhook when a user's events change.
+type CalendarSubscriber interface {
+	// Subscribe subscribes notificationURL to changes to the user's events until expiresAt, clientState is
+	// sent with every notification so they can be checked.
+	Subscribe(ctx context.Context, notificationURL, clientState string,
+		expiresAt time.Time) (CalendarSubscription, error)
+	// RenewSubscription extends a subscription until expiresAt, ErrCalendarSubscriptionNotFound is returned
+	// if it has already lapsed.
+	RenewSubscription(ctx context.Context, subscriptionID string, expiresAt time.Time) (CalendarSubscription, error)
+}
+
+// calendarProviderUnwrapper is implemented by CalendarProviders that wrap another, eg. a cache.
+type calendarProviderUnwrapper interface {
+	Unwrap() CalendarProvider
+}
+
+// asCalendarSubscriber returns the provider, or a provider it wraps, as a CalendarSubscriber.
+func asCalendarSubscriber(provider CalendarProvider) (CalendarSubscriber, bool) {
+	for provider != nil {
+		if subscriber, ok := provider.(CalendarSubscriber); ok {
+			return subscriber, true
+		}
+
+		unwrapper, ok := provider.(calendarProviderUnwrapper)
+		if !ok {
+			break
+		}
+		provider = unwrapper.Unwrap()
+	}
+	return nil, false
+}
+
+// CalendarProviderFactory creates a CalendarProvider acting on behalf of userID.
+type CalendarProviderFactory func(ctx context.Context, userID uint32) (CalendarProvider, error)
+
+// NewCalendarProviderFactory returns a CalendarProviderFactory that creates a GoogleCalendarProvider
+// for users who logged in with Google, and a GraphCalendarProvider for everyone else.
+func NewCalendarProviderFactory(msalClient *confidential.Client, googleCfg *GoogleConfig,
+	db *database.Database,
+) CalendarProviderFactory {
+	graphFactory := NewGraphCalendarProviderFactory(msalClient, db)
+	googleFactory := NewGoogleCalendarProviderFactory(googleCfg, db)
+
+	return func(ctx context.Context, userID uint32) (CalendarProvider, error) {
+		provider, err := getUserCalendarProvider(ctx, db, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if provider == database.UserIdentityProviderGoogle {
+			return googleFactory(ctx, userID)
+		}
+		return graphFactory(ctx, userID)
+	}
+}
+
+// FormatCalendarEventTime formats t in the layout of CalendarEvent times.
+func FormatCalendarEventTime(t time.Time) string {
+	return t.UTC().Format(CalendarEventTimeLayout)
+}
+
+// ParseCalendarEventTime parses a CalendarEvent time, which is RFC3339 or, for events cached before
+// times carried their offset, a MSFT date time in UTC.
+func ParseCalendarEventTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err == nil {
+		return t.UTC(), nil
+	}
+
+	if t, err = time.Parse(graphDateTimeLayout, s); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse calendar event time %s: %w", s, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"go.uber.org/zap"
+)
+
+const (
+	CalendarWebhookURLEnvName           = "CALENDAR_WEBHOOK_URL"
+	CalendarSubscriptionLifetimeEnvName = "CALENDAR_SUBSCRIPTION_LIFETIME"
+
+	// DefaultCalendarSubscriptionLifetime is how long calendar subscriptions last before they have to be
+	// renewed by default, Graph allows just under 7 days for events.
+	DefaultCalendarSubscriptionLifetime = 3 * 24 * time.Hour
+	// CalendarSubscriptionRenewBefore is how long before they expire calendar subscriptions are renewed.
+	CalendarSubscriptionRenewBefore = 24 * time.Hour
+
+	// calendarSubscriptionRenewBatchSize is the most subscriptions renewed by one RenewCalendarSubscriptions.
+	calendarSubscriptionRenewBatchSize = 50
+	// calendarSubscribeTimeout is how long subscribing to a calendar in the background can take.
+	calendarSubscribeTimeout = time.Minute
+)
+
+// CalendarSubscriptionConfig configures the subscriptions to changes to users' calendars.
+type CalendarSubscriptionConfig struct {
+	// NotificationURL is the public URL of the calendar webhook, subscriptions aren't created if it is empty.
+	NotificationURL string
+	// Lifetime is how long a subscription lasts after it is created or renewed.
+	Lifetime time.Duration
+}
+
+// DefaultCalendarSubscriptionConfig returns the default calendar subscription config, which has no
+// notification URL so no subscriptions are created.
+func DefaultCalendarSubscriptionConfig() CalendarSubscriptionConfig {
+	return CalendarSubscriptionConfig{
+		Lifetime: DefaultCalendarSubscriptionLifetime,
+	}
+}
+
+// Validate checks the config is usable.
+func (c CalendarSubscriptionConfig) Validate() error {
+	if c.NotificationURL != "" {
+		u, err := url.Parse(c.NotificationURL)
+		if err != nil {
+			return fmt.Errorf("calendar webhook url is invalid: %w", err)
+		}
+		if !u.IsAbs() || u.Host == "" {
+			return fmt.Errorf("calendar webhook url must be absolute, got %s", c.NotificationURL)
+		}
+	}
+	if c.Lifetime <= CalendarSubscriptionRenewBefore {
+		return fmt.Errorf("calendar subscription lifetime must be more than %s, got %s",
+			CalendarSubscriptionRenewBefore, c.Lifetime)
+	}
+	return nil
+}
+
+// NewCalendarSubscriptionConfigFromEnv creates a CalendarSubscriptionConfig, using the defaults for any env
+// values not set. CALENDAR_SUBSCRIPTION_LIFETIME is a Go duration, eg. 48h.
+func NewCalendarSubscriptionConfigFromEnv() (CalendarSubscriptionConfig, error) {
+	cfg := DefaultCalendarSubscriptionConfig()
+
+	cfg.NotificationURL = os.Getenv(CalendarWebhookURLEnvName)
+
+	if lifetime, present := os.LookupEnv(CalendarSubscriptionLifetimeEnvName); present {
+		var err error
+		if cfg.Lifetime, err = time.ParseDuration(lifetime); err != nil {
+			return CalendarSubscriptionConfig{}, fmt.Errorf("failed to parse %s env value: %w",
+				CalendarSubscriptionLifetimeEnvName, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return CalendarSubscriptionConfig{}, err
+	}
+	return cfg, nil
+}
+
+// subscribeCalendar subscribes the calendar webhook to changes to the user's calendar, unless they already
+// have a subscription that isn't due to be renewed or their calendar provider doesn't support subscriptions.
+func (s Server) subscribeCalendar(ctx context.Context, userID uint32) error {
+	if s.CalendarSubscriptions.NotificationURL == "" {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	existing, err := s.DB.GetCalendarSubscriptionByUserID(ctx, userID)
+	if err == nil && existing.ExpiresAt.After(now.Add(CalendarSubscriptionRenewBefore)) {
+		return nil
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get calendar subscription: %w", err)
+	}
+
+	calendar, err := s.CalendarProvider(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar provider: %w", err)
+	}
+
+	subscriber, ok := asCalendarSubscriber(calendar)
+	if !ok {
+		return nil
+	}
+
+	clientState := rand.Text()
+	subscription, err := subscriber.Subscribe(ctx, s.CalendarSubscriptions.NotificationURL, clientState,
+		now.Add(s.CalendarSubscriptions.Lifetime))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to calendar: %w", err)
+	}
+
+	if _, err = s.DB.UpsertCalendarSubscription(ctx, database.UpsertCalendarSubscriptionParams{
+		ID:          subscription.ID,
+		UserID:      userID,
+		ClientState: clientState,
+		ExpiresAt:   subscription.ExpiresAt,
+	}); err != nil {
+		return fmt.Errorf("failed to save calendar subscription: %w", err)
+	}
+	return nil
+}
+
+// subscribeCalendarInBackground is subscribeCalendar without holding up the request it is called from, eg. a
+// login. Failures are only logged, the user is subscribed again when they next log in.
+func (s Server) subscribeCalendarInBackground(ctx context.Context, userID uint32) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), calendarSubscribeTimeout)
+	go func() {
+		defer cancel()
+		if err := s.subscribeCalendar(ctx, userID); err != nil {
+			s.Logger.Warn("failed to subscribe to user's calendar", zap.Uint32("user_id", userID), zap.Error(err))
+		}
+	}()
+}
+
+// RenewCalendarSubscriptions renews the calendar subscriptions that expire within
+// CalendarSubscriptionRenewBefore. Subscriptions that have already lapsed are created again.
+func (s Server) RenewCalendarSubscriptions(ctx context.Context) {
+	if s.CalendarSubscriptions.NotificationURL == "" {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	subscriptions, err := s.DB.ListCalendarSubscriptionsToRenew(ctx, database.ListCalendarSubscriptionsToRenewParams{
+		ExpiresAt: now.Add(CalendarSubscriptionRenewBefore),
+		Limit:     calendarSubscriptionRenewBatchSize,
+	})
+	if err != nil {
+		s.Logger.Error("failed to list calendar subscriptions to renew", zap.Error(err))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		logger := s.Logger.With(zap.Uint32("user_id", subscription.UserID),
+			zap.String("subscription_id", subscription.ID))
+
+		if err = s.renewCalendarSubscription(ctx, subscription, now); err != nil {
+			logger.Error("failed to renew calendar subscription", zap.Error(err))
+		}
+	}
+}
+
+// renewCalendarSubscription renews a subscription, creating it again if it has lapsed.
+func (s Server) renewCalendarSubscription(ctx context.Context, subscription database.CalendarSubscription,
+	now time.Time,
+) error {
+	calendar, err := s.CalendarProvider(ctx, subscription.UserID)
+	if err != nil && subscription.ExpiresAt.Before(now) {
+		// The subscription is already gone, it will be created again when the user next logs in
+		if _, err = s.DB.DeleteCalendarSubscription(ctx, subscription.ID); err != nil {
+			return fmt.Errorf("failed to delete lapsed calendar subscription: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create calendar provider: %w", err)
+	}
+
+	subscriber, ok := asCalendarSubscriber(calendar)
+	if !ok {
+		// eg. the user now logs in with another provider
+		if _, err = s.DB.DeleteCalendarSubscription(ctx, subscription.ID); err != nil {
+			return fmt.Errorf("failed to delete calendar subscription: %w", err)
+		}
+		return nil
+	}
+
+	renewed, err := subscriber.RenewSubscription(ctx, subscription.ID, now.Add(s.CalendarSubscriptions.Lifetime))
+	if errors.Is(err, ErrCalendarSubscriptionNotFound) {
+		if _, err = s.DB.DeleteCalendarSubscription(ctx, subscription.ID); err != nil {
+			return fmt.Errorf("failed to delete lapsed calendar subscription: %w", err)
+		}
+		return s.subscribeCalendar(ctx, subscription.UserID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.DB.UpdateCalendarSubscriptionExpiresAt(ctx, database.UpdateCalendarSubscriptionExpiresAtParams{
+		ExpiresAt: renewed.ExpiresAt,
+		ID:        subscription.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to save renewed calendar subscription: %w", err)
+	}
+	return nil
+}
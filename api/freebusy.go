@@ -0,0 +1,507 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/scheduler"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidSchedulingBody is returned when a scheduling request can't be used to find slots.
+var ErrInvalidSchedulingBody = errors.New("invalid scheduling request body")
+
+// defaultWorkingHours are used for participants when scheduling in the 'work' activity domain.
+func defaultWorkingHours() scheduler.WorkingHours {
+	return scheduler.WorkingHours{
+		Start: scheduler.NewTimeOfDay(9, 0),
+		End:   scheduler.NewTimeOfDay(17, 0),
+	}
+}
+
+// attendeeFreeBusy stores the busy periods of a meeting participant.
+type attendeeFreeBusy struct {
+	attendee AttendeeBase
+	busy     []TimeInterval
+	// known is false when the participant's calendar could not be read.
+	known bool
+	// preferences are nil if the participant isn't a Slotify user or hasn't set any.
+	preferences *schedulingPreferences
+	// location is the participant's time zone, nil if they aren't a Slotify user.
+	location *time.Location
+}
+
+// parseISODuration parses an ISO 8601 duration such as 'PT1H30M'.
+func parseISODuration(d string) (time.Duration, error) {
+	isoDuration, err := serialization.ParseISODuration(d)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse duration %s as ISO Duration: %w", d, err)
+	}
+
+	return time.Duration(isoDuration.GetWeeks())*7*24*time.Hour +
+		time.Duration(isoDuration.GetDays())*24*time.Hour +
+		time.Duration(isoDuration.GetHours())*time.Hour +
+		time.Duration(isoDuration.GetMinutes())*time.Minute +
+		time.Duration(isoDuration.GetSeconds())*time.Second, nil
+}
+
+// mergeTimeIntervals sorts intervals and merges those that overlap or touch.
+func mergeTimeIntervals(intervals []TimeInterval) []TimeInterval {
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b TimeInterval) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	merged := []TimeInterval{}
+	for _, i := range sorted {
+		if len(merged) > 0 && !i.Start.After(merged[len(merged)-1].End) {
+			if i.End.After(merged[len(merged)-1].End) {
+				merged[len(merged)-1].End = i.End
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return merged
+}
+
+// toSchedulerParticipant converts a participant's free/busy and preferences into a scheduler.Participant.
+// Working hours are only applied if workDomain is set, the participant's preferred working hours
+// are used if they have any and otherwise 9 to 5. Either is in their own time zone.
+func toSchedulerParticipant(a attendeeFreeBusy, workDomain bool) scheduler.Participant {
+	busy := make([]scheduler.Interval, 0, len(a.busy))
+	for _, b := range a.busy {
+		busy = append(busy, scheduler.Interval{Start: b.Start, End: b.End})
+	}
+
+	p := scheduler.Participant{
+		ID:       string(a.attendee.EmailAddress.Address),
+		Required: a.attendee.AttendeeType == Required,
+		Unknown:  !a.known,
+		Busy:     busy,
+		Location: a.location,
+	}
+
+	if a.preferences == nil {
+		if workDomain {
+			workingHours := defaultWorkingHours()
+			p.WorkingHours = &workingHours
+		}
+		return p
+	}
+
+	p.Breaks = []scheduler.DailyWindow{a.preferences.lunch}
+	p.MaxMeetingsPerDay = a.preferences.maxMeetingsPerDay
+	if workDomain {
+		p.WorkingHours = &a.preferences.workingHours
+	}
+	return p
+}
+
+// newSchedulerRequest converts a scheduling request body and everyone's free/busy into a scheduler.Request.
+func newSchedulerRequest(body SchedulingSlotsBodySchema,
+	organiser attendeeFreeBusy,
+	attendees []attendeeFreeBusy,
+) (scheduler.Request, error) {
+	duration, err := parseISODuration(body.MeetingDuration)
+	if err != nil {
+		return scheduler.Request{}, err
+	}
+
+	var bufferBefore, bufferAfter time.Duration
+	if body.BufferBefore != nil {
+		if bufferBefore, err = parseISODuration(*body.BufferBefore); err != nil {
+			return scheduler.Request{}, err
+		}
+	}
+	if body.BufferAfter != nil {
+		if bufferAfter, err = parseISODuration(*body.BufferAfter); err != nil {
+			return scheduler.Request{}, err
+		}
+	}
+
+	// Like MSFT findMeetingTimes, only the 'work' activity domain is limited to working hours
+	workDomain := body.TimeConstraint.ActivityDomain == nil || *body.TimeConstraint.ActivityDomain == "work"
+
+	windows := make([]scheduler.Interval, 0, len(body.TimeConstraint.TimeSlots))
+	for _, ts := range body.TimeConstraint.TimeSlots {
+		windows = append(windows, scheduler.Interval{Start: ts.Start, End: ts.End})
+	}
+
+	schedulerAttendees := make([]scheduler.Participant, 0, len(attendees))
+	for _, a := range attendees {
+		schedulerAttendees = append(schedulerAttendees, toSchedulerParticipant(a, workDomain))
+	}
+
+	req := scheduler.Request{
+		Windows:               windows,
+		Duration:              duration,
+		BufferBefore:          bufferBefore,
+		BufferAfter:           bufferAfter,
+		Organiser:             toSchedulerParticipant(organiser, workDomain),
+		OrganiserOptional:     body.IsOrganizerOptional,
+		Attendees:             schedulerAttendees,
+		MinAttendeePercentage: body.MinimumAttendeePercentage,
+	}
+	if body.MaxCandidates != nil {
+		req.MaxCandidates = int(*body.MaxCandidates)
+	}
+	if body.Recurrence != nil {
+		if err = validateRecurrence(*body.Recurrence); err != nil {
+			return scheduler.Request{}, err
+		}
+		recurrence := toSchedulerRecurrence(*body.Recurrence)
+		req.Recurrence = &recurrence
+	}
+
+	return req, nil
+}
+
+// toFreeBusyStatus maps a scheduler availability onto the MSFT free/busy statuses.
+func toFreeBusyStatus(a scheduler.Availability) FreeBusyStatus {
+	switch a {
+	case scheduler.AvailabilityFree:
+		return FreeBusyStatusFree
+	case scheduler.AvailabilityBusy:
+		return FreeBusyStatusBusy
+	case scheduler.AvailabilityOutsideWorkingHours:
+		// MSFT has no status for this, it reports them as busy too
+		return FreeBusyStatusBusy
+	case scheduler.AvailabilityOverMeetingLimit:
+		// The participant is free but would rather not have another meeting that day
+		return FreeBusyStatusTentative
+	case scheduler.AvailabilityUnknown:
+		return FreeBusyStatusUnknown
+	default:
+		return FreeBusyStatusUnknown
+	}
+}
+
+// toEmptySuggestionsReason maps a scheduler empty reason onto the MSFT empty suggestions reasons.
+func toEmptySuggestionsReason(r scheduler.EmptyReason) EmptySuggestionsReason {
+	switch r {
+	case scheduler.EmptyReasonOrganiserUnavailable:
+		return EmptySuggestionsReasonOrganizerUnavailable
+	case scheduler.EmptyReasonAttendeesUnavailable:
+		return EmptySuggestionsReasonAttendeesUnavailable
+	case scheduler.EmptyReasonAttendeesUnavailableOrUnknown:
+		return EmptySuggestionsReasonAttendeesUnavailableOrUnknown
+	case scheduler.EmptyReasonNone, scheduler.EmptyReasonNoCandidates:
+		return EmptySuggestionsReasonUnknown
+	default:
+		return EmptySuggestionsReasonUnknown
+	}
+}
+
+// newSchedulingSlotsResponse converts scheduler slots into the same shape as MSFT findMeetingTimes,
+// with times in loc.
+func newSchedulingSlotsResponse(res scheduler.Result,
+	attendees []attendeeFreeBusy,
+	loc *time.Location,
+) SchedulingSlotsSuccessResponseBody {
+	suggestions := make([]MeetingTimeSuggestion, 0, len(res.Slots))
+	for i, slot := range res.Slots {
+		availabilities := make([]AttendeeAvailability, 0, len(slot.Attendees))
+		free := 0
+		for j, a := range slot.Attendees {
+			if a.Availability == scheduler.AvailabilityFree {
+				free++
+			}
+			availabilities = append(availabilities, AttendeeAvailability{
+				Attendee:     attendees[j].attendee,
+				Availability: toFreeBusyStatus(a.Availability),
+			})
+		}
+
+		confidence := slot.Score
+		order := int32(i + 1) //nolint: gosec // number of suggestions is small
+		organiserAvailability := string(toFreeBusyStatus(slot.OrganiserAvailability))
+		reason := fmt.Sprintf("%d of %d attendees are available.", free, len(slot.Attendees))
+		suggestions = append(suggestions, MeetingTimeSuggestion{
+			AttendeeAvailability:  &availabilities,
+			Confidence:            &confidence,
+			Locations:             &[]Location{},
+			MeetingTimeSlot:       &MeetingTimeSlot{Start: slot.Start.In(loc), End: slot.End.In(loc)},
+			Order:                 &order,
+			OrganizerAvailability: &organiserAvailability,
+			SuggestionReason:      &reason,
+		})
+	}
+
+	resp := SchedulingSlotsSuccessResponseBody{
+		MeetingTimeSuggestions: &suggestions,
+	}
+	if res.EmptyReason != scheduler.EmptyReasonNone {
+		reason := toEmptySuggestionsReason(res.EmptyReason)
+		resp.EmptySuggestionsReason = &reason
+	}
+
+	return resp
+}
+
+// findMeetingTimesFromFreeBusy suggests meeting times from the free/busy of every participant with
+// the native scheduler, returning them in the same shape as MSFT findMeetingTimes in body's time zone.
+// The organiser is taken to be in body's time zone if theirs isn't known.
+// An ErrInvalidSchedulingBody error is returned if body can't be used to find slots.
+func findMeetingTimesFromFreeBusy(body SchedulingSlotsBodySchema,
+	organiser attendeeFreeBusy,
+	attendees []attendeeFreeBusy,
+) (SchedulingSlotsSuccessResponseBody, error) {
+	loc := time.UTC
+	if body.TimeZone != nil {
+		var err error
+		if loc, err = parseTimeZone(*body.TimeZone); err != nil {
+			return SchedulingSlotsSuccessResponseBody{}, fmt.Errorf("%w: %w", ErrInvalidSchedulingBody, err)
+		}
+	}
+	if organiser.location == nil {
+		organiser.location = loc
+	}
+
+	req, err := newSchedulerRequest(body, organiser, attendees)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, fmt.Errorf("%w: %w", ErrInvalidSchedulingBody, err)
+	}
+
+	res, err := scheduler.FindSlots(req)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, fmt.Errorf("%w: %w", ErrInvalidSchedulingBody, err)
+	}
+
+	return newSchedulingSlotsResponse(res, attendees, loc), nil
+}
+
+// timeConstraintRange returns the earliest start and latest end of the request's time slots.
+func timeConstraintRange(body SchedulingSlotsBodySchema) (time.Time, time.Time, error) {
+	if len(body.TimeConstraint.TimeSlots) == 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: time constraint has no time slots", ErrInvalidSchedulingBody)
+	}
+
+	start := body.TimeConstraint.TimeSlots[0].Start
+	end := body.TimeConstraint.TimeSlots[0].End
+	for _, ts := range body.TimeConstraint.TimeSlots[1:] {
+		if ts.Start.Before(start) {
+			start = ts.Start
+		}
+		if ts.End.After(end) {
+			end = ts.End
+		}
+	}
+	return start, end, nil
+}
+
+// needsNativeScheduler checks if the organiser or any attendee uses Google Calendar or has scheduling
+// preferences, MSFT findMeetingTimes can see neither.
+func (s Server) needsNativeScheduler(ctx context.Context, organiserID uint32,
+	body SchedulingSlotsBodySchema,
+) (bool, error) {
+	userIDs := []uint32{organiserID}
+	for _, a := range body.Attendees {
+		u, err := s.DB.GetUserByEmail(ctx, string(a.EmailAddress.Address))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return false, fmt.Errorf("failed to get attendee by email: %w", err)
+		}
+		userIDs = append(userIDs, u.ID)
+	}
+
+	for _, id := range userIDs {
+		provider, err := getUserCalendarProvider(ctx, s.DB, id)
+		if err != nil {
+			return false, err
+		}
+		if provider == database.UserIdentityProviderGoogle {
+			return true, nil
+		}
+
+		if _, err = s.DB.GetUserPreferences(ctx, id); err == nil {
+			return true, nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("failed to get user preferences: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// findMeetingTimes suggests meeting times with the slot finder chosen in body. The native scheduler is
+// always used if it is needed to see every participant's calendar or preferences, or to check every
+// occurrence of a recurring meeting. Slots are found and returned in body's time zone, or the
+// organiser's if it has none.
+// An ErrInvalidSchedulingBody error is returned if body can't be used to find slots.
+func (s Server) findMeetingTimes(ctx context.Context, organiserID uint32,
+	body SchedulingSlotsBodySchema,
+) (SchedulingSlotsSuccessResponseBody, error) {
+	slots, _, err := s.findMeetingTimesWithFreeBusy(ctx, organiserID, body)
+	return slots, err
+}
+
+// findMeetingTimesWithFreeBusy is findMeetingTimes, but also returns the free/busy of the attendees followed
+// by the organiser if the native scheduler read it, so the slots can be scored without reading every calendar
+// again. The free/busy covers scoringMargin either side of the time constraint. It is nil if the calendar
+// provider found the slots.
+func (s Server) findMeetingTimesWithFreeBusy(ctx context.Context, organiserID uint32,
+	body SchedulingSlotsBodySchema,
+) (SchedulingSlotsSuccessResponseBody, []attendeeFreeBusy, error) {
+	loc, err := requestLocation(ctx, s.DB, organiserID, body.TimeZone)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTimeZone) {
+			return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("%w: %w", ErrInvalidSchedulingBody, err)
+		}
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("failed to get organiser time zone: %w", err)
+	}
+	timeZone := loc.String()
+	body.TimeZone = &timeZone
+
+	native, err := s.needsNativeScheduler(ctx, organiserID, body)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, err
+	}
+
+	if native || body.Recurrence != nil || (body.SlotFinder != nil && *body.SlotFinder == Native) {
+		return s.findMeetingTimesNative(ctx, organiserID, body)
+	}
+
+	calendar, err := s.CalendarProvider(ctx, organiserID)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("failed to create calendar provider: %w", err)
+	}
+	slots, err := calendar.FindMeetingTimes(ctx, body)
+	return slots, nil, err
+}
+
+// findMeetingTimesNative finds meeting times with the native scheduler, reading the free/busy of every
+// participant through their own calendar provider so Microsoft and Google users can be scheduled together.
+// Attendees who aren't Slotify users, or whose calendar can't be read, are treated as unknown.
+// Slots outside a participant's preferred working hours or in their lunch break are excluded, or
+// down-ranked for optional attendees. Slots for a recurring meeting must suit every occurrence checked.
+// The free/busy read is returned with the slots, see findMeetingTimesWithFreeBusy.
+func (s Server) findMeetingTimesNative(ctx context.Context, organiserID uint32,
+	body SchedulingSlotsBodySchema,
+) (SchedulingSlotsSuccessResponseBody, []attendeeFreeBusy, error) {
+	start, end, err := timeConstraintRange(body)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, err
+	}
+	// Checked before fetching anyone's calendar over the whole range
+	if end.Sub(start) > scheduler.MaxSearchSpan {
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("%w: %w", ErrInvalidSchedulingBody,
+			scheduler.ErrSearchTooLong)
+	}
+	if body.Recurrence != nil {
+		if err = validateRecurrence(*body.Recurrence); err != nil {
+			return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("%w: %w", ErrInvalidSchedulingBody, err)
+		}
+		// Later occurrences need free/busy after the time constraint
+		end = seriesFreeBusyEnd(toSchedulerRecurrence(*body.Recurrence), end)
+	}
+	start, end = start.Add(-scoringMargin), end.Add(scoringMargin)
+
+	owner, err := s.DB.GetUserByID(ctx, organiserID)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("failed to get organiser: %w", err)
+	}
+
+	organiserCalendar, err := s.CalendarProvider(ctx, organiserID)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("failed to create organiser calendar provider: %w", err)
+	}
+
+	forceRefresh := body.ForceRefresh != nil && *body.ForceRefresh
+	if forceRefresh {
+		if err = RefreshCalendar(ctx, organiserCalendar); err != nil {
+			s.Logger.Warn("failed to refresh organiser calendar", zap.Uint32("user_id", organiserID), zap.Error(err))
+		}
+	}
+
+	organiserBusy, err := organiserCalendar.FreeBusy(ctx, start, end)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("failed to get organiser free busy: %w", err)
+	}
+	organiserPrefs, err := getSchedulingPreferences(ctx, s.DB, organiserID)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("failed to get organiser preferences: %w", err)
+	}
+	organiserLoc, err := getUserLocation(ctx, s.DB, organiserID)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, fmt.Errorf("failed to get organiser time zone: %w", err)
+	}
+	organiser := attendeeFreeBusy{
+		attendee:    organiserAttendee(owner),
+		busy:        organiserBusy,
+		known:       true,
+		preferences: organiserPrefs,
+		location:    organiserLoc,
+	}
+
+	attendees := s.getAttendeesFreeBusy(ctx, body.Attendees, start, end, forceRefresh)
+
+	slots, err := findMeetingTimesFromFreeBusy(body, organiser, attendees)
+	if err != nil {
+		return SchedulingSlotsSuccessResponseBody{}, nil, err
+	}
+	return slots, append(attendees, organiser), nil
+}
+
+// getAttendeeSchedulingDetails gets the preferences and time zone of an attendee who is a Slotify user, which
+// are used even if their calendar can't be read. The attendee is returned as unknown, with their user id,
+// false is returned if they aren't a Slotify user.
+func (s Server) getAttendeeSchedulingDetails(ctx context.Context, a AttendeeBase) (attendeeFreeBusy, uint32, bool) {
+	logger := s.Logger.With(zap.String("attendee_email", string(a.EmailAddress.Address)))
+	unknown := attendeeFreeBusy{attendee: a}
+
+	u, err := s.DB.GetUserByEmail(ctx, string(a.EmailAddress.Address))
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("failed to get attendee by email", zap.Error(err))
+		}
+		return unknown, 0, false
+	}
+
+	if unknown.preferences, err = getSchedulingPreferences(ctx, s.DB, u.ID); err != nil {
+		logger.Error("failed to get attendee preferences", zap.Error(err))
+	}
+	if unknown.location, err = parseTimeZone(u.TimeZone); err != nil {
+		logger.Error("failed to load attendee time zone", zap.Error(err))
+	}
+	return unknown, u.ID, true
+}
+
+// getAttendeeFreeBusy gets the busy periods of unknown, an attendee from getAttendeeSchedulingDetails, from
+// their calendar provider, refreshing any cached events first if forceRefresh is true. unknown is returned
+// if their calendar can't be read.
+func (s Server) getAttendeeFreeBusy(ctx context.Context, unknown attendeeFreeBusy, userID uint32,
+	start, end time.Time,
+	forceRefresh bool,
+) attendeeFreeBusy {
+	logger := s.Logger.With(zap.String("attendee_email", string(unknown.attendee.EmailAddress.Address)))
+
+	calendar, err := s.CalendarProvider(ctx, userID)
+	if err != nil {
+		logger.Error("failed to create attendee calendar provider", zap.Error(err))
+		return unknown
+	}
+
+	if forceRefresh {
+		if err = RefreshCalendar(ctx, calendar); err != nil {
+			logger.Warn("failed to refresh attendee calendar", zap.Error(err))
+		}
+	}
+
+	busy, err := calendar.FreeBusy(ctx, start, end)
+	if err != nil {
+		logger.Error("failed to get attendee free busy", zap.Error(err))
+		return unknown
+	}
+
+	known := unknown
+	known.busy, known.known = busy, true
+	return known
+}
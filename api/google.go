@@ -0,0 +1,364 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/tokencrypt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	GoogleAuthURL          = "https://accounts.google.com/o/oauth2/auth"
+	GoogleTokenURL         = "https://oauth2.googleapis.com/token"
+	GoogleUserInfoURL      = "https://openidconnect.googleapis.com/v1/userinfo"
+	GoogleCalendarBaseURL  = "https://www.googleapis.com/calendar/v3"
+	GoogleDirectoryBaseURL = "https://admin.googleapis.com/admin/directory/v1"
+
+	// googleHTTPTimeout is the timeout for a single request to a Google API.
+	googleHTTPTimeout = 30 * time.Second
+)
+
+// ErrGoogleNotConfigured is returned when a Google user is used but Google has not been configured.
+var ErrGoogleNotConfigured = errors.New("google oauth client has not been configured")
+
+// GoogleConfig stores details of our Google OAuth client and the Google API endpoints to use.
+// The endpoints can be pointed at a stub server in tests.
+type GoogleConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	AuthURL          string
+	TokenURL         string
+	UserInfoURL      string
+	CalendarBaseURL  string
+	DirectoryBaseURL string
+
+	// TokenCipher encrypts Google tokens stored in the database.
+	TokenCipher *tokencrypt.Cipher
+	// HTTPClient is used for all requests to Google, http.DefaultClient is used if nil.
+	HTTPClient *http.Client
+}
+
+// GoogleTokenResult stores the user details and tokens from a Google login.
+type GoogleTokenResult struct {
+	Subject   string
+	Email     string
+	FirstName string
+	LastName  string
+	Token     *oauth2.Token
+}
+
+// googleUserInfo is the response of the Google OpenID Connect userinfo endpoint.
+type googleUserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+// NewGoogleConfigFromEnv creates a GoogleConfig with the production Google endpoints.
+func NewGoogleConfigFromEnv() (*GoogleConfig, error) {
+	clientID, present := os.LookupEnv(GoogleClientIDEnvName)
+	if !present {
+		return nil, fmt.Errorf("failed to get %s env value", GoogleClientIDEnvName)
+	}
+
+	clientSecret, present := os.LookupEnv(GoogleClientSecretEnvName)
+	if !present {
+		return nil, fmt.Errorf("failed to get %s env value", GoogleClientSecretEnvName)
+	}
+
+	backendURL, present := os.LookupEnv("BACKEND_URL")
+	if !present {
+		return nil, errors.New("failed to get BACKEND_URL env value")
+	}
+
+	tokenCipher, err := tokencrypt.NewFromEnv(GoogleTokenEncryptionKeyEnvName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create google token cipher: %w", err)
+	}
+
+	return &GoogleConfig{
+		ClientID:         clientID,
+		ClientSecret:     clientSecret,
+		RedirectURL:      fmt.Sprintf("%s/api/auth/google/callback", backendURL),
+		AuthURL:          GoogleAuthURL,
+		TokenURL:         GoogleTokenURL,
+		UserInfoURL:      GoogleUserInfoURL,
+		CalendarBaseURL:  GoogleCalendarBaseURL,
+		DirectoryBaseURL: GoogleDirectoryBaseURL,
+		TokenCipher:      tokenCipher,
+	}, nil
+}
+
+// getGoogleScopes returns the scopes a Google user has to consent to.
+func getGoogleScopes() []string {
+	return []string{
+		"openid", "email", "profile",
+		"https://www.googleapis.com/auth/calendar",
+		"https://www.googleapis.com/auth/admin.directory.resource.calendar.readonly",
+	}
+}
+
+// httpClient returns the http client to use for Google requests.
+func (c *GoogleConfig) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{Timeout: googleHTTPTimeout}
+}
+
+// oauth2Config returns the OAuth2 config of our Google client.
+func (c *GoogleConfig) oauth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		RedirectURL:  c.RedirectURL,
+		Scopes:       getGoogleScopes(),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   c.AuthURL,
+			TokenURL:  c.TokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+// oauth2Context makes the oauth2 package use our http client.
+func (c *GoogleConfig) oauth2Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, c.httpClient())
+}
+
+// googleAuthoriseByCode will exchange a authorisation code for Google tokens, following OAuth2.
+// The user's details are then fetched from the userinfo endpoint.
+func googleAuthoriseByCode(ctx context.Context,
+	googleCfg *GoogleConfig,
+	authCode string,
+) (GoogleTokenResult, error) {
+	if googleCfg == nil {
+		return GoogleTokenResult{}, ErrGoogleNotConfigured
+	}
+
+	tok, err := googleCfg.oauth2Config().Exchange(googleCfg.oauth2Context(ctx), authCode)
+	if err != nil {
+		return GoogleTokenResult{}, fmt.Errorf("failed to exchange google auth code: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleCfg.UserInfoURL, nil)
+	if err != nil {
+		return GoogleTokenResult{}, fmt.Errorf("failed to create userinfo request: %w", err)
+	}
+	tok.SetAuthHeader(req)
+
+	resp, err := googleCfg.httpClient().Do(req)
+	if err != nil {
+		return GoogleTokenResult{}, fmt.Errorf("failed to get google userinfo: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return GoogleTokenResult{}, fmt.Errorf("google userinfo returned status %d", resp.StatusCode)
+	}
+
+	var userInfo googleUserInfo
+	if err = json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
+		return GoogleTokenResult{}, fmt.Errorf("failed to decode google userinfo: %w", err)
+	}
+
+	if userInfo.Subject == "" || userInfo.Email == "" {
+		return GoogleTokenResult{}, errors.New("google userinfo is missing sub or email")
+	}
+
+	if !userInfo.EmailVerified {
+		return GoogleTokenResult{}, errors.New("google email address is not verified")
+	}
+
+	firstName := userInfo.GivenName
+	lastName := userInfo.FamilyName
+	// If the userinfo doesn't contain given and family names, then attempt to
+	// manually split Name
+	if firstName == "" && lastName == "" {
+		firstName, lastName = splitName(userInfo.Name)
+	}
+
+	return GoogleTokenResult{
+		Subject:   userInfo.Subject,
+		Email:     userInfo.Email,
+		FirstName: firstName,
+		LastName:  lastName,
+		Token:     tok,
+	}, nil
+}
+
+// getOrInsertUserByGoogleClaims will get a user by their Google email, or if first time log in,
+// it will create a new user. The Google identity and tokens are stored for the user.
+func getOrInsertUserByGoogleClaims(ctx context.Context,
+	qtx *database.Queries, googleCfg *GoogleConfig, googleTokenRes GoogleTokenResult,
+) (database.User, error) {
+	// Double the timeout due to more db operations
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	u, err := getOrInsertUserByEmail(ctx, qtx,
+		googleTokenRes.Email, googleTokenRes.FirstName, googleTokenRes.LastName)
+	if err != nil {
+		return database.User{}, err
+	}
+
+	if err = upsertUserIdentity(ctx, qtx, u.ID,
+		database.UserIdentityProviderGoogle, googleTokenRes.Subject); err != nil {
+		return database.User{}, err
+	}
+
+	if err = storeGoogleToken(ctx, qtx, googleCfg, u.ID, googleTokenRes.Token); err != nil {
+		return database.User{}, err
+	}
+
+	return u, nil
+}
+
+// storeGoogleToken encrypts and stores a user's Google token.
+// Google only returns a refresh token on first consent, so an existing one is kept if tok has none.
+func storeGoogleToken(ctx context.Context, q *database.Queries, googleCfg *GoogleConfig,
+	userID uint32, tok *oauth2.Token,
+) error {
+	if tok.RefreshToken == "" {
+		existing, err := loadGoogleToken(ctx, q, googleCfg, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to load existing google token: %w", err)
+		}
+		if existing != nil {
+			withRefresh := *tok
+			withRefresh.RefreshToken = existing.RefreshToken
+			tok = &withRefresh
+		}
+	}
+
+	tokJSON, err := json.Marshal(tok)
+	if err != nil {
+		return fmt.Errorf("failed to marshal google token: %w", err)
+	}
+
+	tokenData, err := googleCfg.TokenCipher.Encrypt(tokJSON)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt google token: %w", err)
+	}
+
+	rowsAffected, err := q.UpdateUserIdentityTokenData(ctx, database.UpdateUserIdentityTokenDataParams{
+		TokenData: tokenData,
+		UserID:    userID,
+		Provider:  database.UserIdentityProviderGoogle,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update google token: %w", err)
+	}
+
+	// 0 rows are affected if the token hasn't changed
+	if rowsAffected > 1 {
+		return fmt.Errorf("failed to update google token: %w", database.WrongNumberSQLRowsError{
+			ActualRows:   rowsAffected,
+			ExpectedRows: []int64{0, 1},
+		})
+	}
+
+	return nil
+}
+
+// loadGoogleToken loads and decrypts a user's Google token, sql.ErrNoRows is returned if there is none.
+func loadGoogleToken(ctx context.Context, q *database.Queries, googleCfg *GoogleConfig,
+	userID uint32,
+) (*oauth2.Token, error) {
+	identity, err := q.GetUserIdentity(ctx, database.GetUserIdentityParams{
+		UserID:   userID,
+		Provider: database.UserIdentityProviderGoogle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get google identity: %w", err)
+	}
+
+	if len(identity.TokenData) == 0 {
+		return nil, fmt.Errorf("google identity has no token: %w", sql.ErrNoRows)
+	}
+
+	tokJSON, err := googleCfg.TokenCipher.Decrypt(identity.TokenData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt google token: %w", err)
+	}
+
+	var tok oauth2.Token
+	if err = json.Unmarshal(tokJSON, &tok); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal google token: %w", err)
+	}
+
+	return &tok, nil
+}
+
+// persistingTokenSource stores refreshed Google tokens so they survive restarts.
+type persistingTokenSource struct {
+	mu        sync.Mutex
+	source    oauth2.TokenSource
+	last      *oauth2.Token
+	db        *database.Database
+	googleCfg *GoogleConfig
+	userID    uint32
+}
+
+func (ts *persistingTokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	tok, err := ts.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get google token: %w", err)
+	}
+
+	if ts.last == nil || tok.AccessToken != ts.last.AccessToken {
+		ctx, cancel := context.WithTimeout(context.Background(), database.DatabaseTimeout)
+		defer cancel()
+
+		if err = storeGoogleToken(ctx, &ts.db.Queries, ts.googleCfg, ts.userID, tok); err != nil {
+			return nil, fmt.Errorf("failed to store refreshed google token: %w", err)
+		}
+		ts.last = tok
+	}
+
+	return tok, nil
+}
+
+// createGoogleHTTPClient creates a http client that authenticates requests as the user.
+func createGoogleHTTPClient(ctx context.Context, googleCfg *GoogleConfig,
+	db *database.Database, userID uint32,
+) (*http.Client, error) {
+	if googleCfg == nil {
+		return nil, ErrGoogleNotConfigured
+	}
+
+	tok, err := loadGoogleToken(ctx, &db.Queries, googleCfg, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load google token: %w", err)
+	}
+
+	oauthCtx := googleCfg.oauth2Context(ctx)
+	ts := &persistingTokenSource{
+		source:    googleCfg.oauth2Config().TokenSource(oauthCtx, tok),
+		last:      tok,
+		db:        db,
+		googleCfg: googleCfg,
+		userID:    userID,
+	}
+
+	return oauth2.NewClient(oauthCtx, ts), nil
+}
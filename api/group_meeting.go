@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ErrInvalidGroupMeeting is returned when a group meeting request can't be scheduled.
+var ErrInvalidGroupMeeting = errors.New("invalid group meeting")
+
+// groupMembersPageSize is the number of members read at a time when expanding a group into attendees.
+const groupMembersPageSize = 100
+
+// listSlotifyGroupMembers gets every member of a SlotifyGroup, ordered by id.
+func listSlotifyGroupMembers(ctx context.Context, db *database.Database,
+	slotifyGroupID uint32,
+) ([]database.GetAllSlotifyGroupMembersRow, error) {
+	var members []database.GetAllSlotifyGroupMembersRow
+	var lastID uint32
+	for {
+		page, err := db.GetAllSlotifyGroupMembers(ctx, database.GetAllSlotifyGroupMembersParams{
+			ID:     slotifyGroupID,
+			LastID: lastID,
+			Limit:  groupMembersPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get slotify group members: %w", err)
+		}
+		members = append(members, page...)
+		if len(page) < groupMembersPageSize {
+			return members, nil
+		}
+		lastID = page[len(page)-1].ID
+	}
+}
+
+// groupSchedulingBody converts a group meeting request into a slot search over the members of the group.
+// Members are attendees of the type they have in the group, and the organiser is optional if theirs is
+// optional. The quorum is the percentage of attendees that must be free. The group's default meeting duration
+// is used if the request doesn't give one. The native scheduler is always used, MSFT findMeetingTimes
+// suggests slots required members are busy in.
+func groupSchedulingBody(sg database.SlotifyGroup, organiserID uint32, body SlotifyGroupMeetingBody,
+	members []database.GetAllSlotifyGroupMembersRow,
+) (SchedulingSlotsBodySchema, error) {
+	if p := body.MinimumAttendeePercentage; p != nil && (*p < 0 || *p > 100) {
+		return SchedulingSlotsBodySchema{}, fmt.Errorf("%w: minimum attendee percentage must be between 0 and 100",
+			ErrInvalidGroupMeeting)
+	}
+
+	slotFinder := Native
+	schedulingBody := SchedulingSlotsBodySchema{
+		Attendees:                 []AttendeeBase{},
+		MeetingDuration:           groupMeetingDuration(body.MeetingDuration, sg),
+		TimeConstraint:            body.TimeConstraint,
+		MinimumAttendeePercentage: body.MinimumAttendeePercentage,
+		Recurrence:                body.Event.Recurrence,
+		SlotifyGroupID:            &sg.ID,
+		SlotFinder:                &slotFinder,
+		TimeZone:                  body.Event.TimeZone,
+	}
+	if body.Event.Subject != nil {
+		schedulingBody.MeetingName = *body.Event.Subject
+	}
+
+	for _, m := range members {
+		attendeeType := AttendeeType(m.AttendeeType)
+		if m.ID == organiserID {
+			schedulingBody.IsOrganizerOptional = attendeeType == Optional
+			continue
+		}
+		schedulingBody.Attendees = append(schedulingBody.Attendees, AttendeeBase{
+			AttendeeType: attendeeType,
+			EmailAddress: EmailAddress{
+				Address: openapi_types.Email(m.Email),
+				Name:    fmt.Sprintf("%s %s", m.FirstName, m.LastName),
+			},
+		})
+	}
+	if len(schedulingBody.Attendees) == 0 {
+		return SchedulingSlotsBodySchema{}, fmt.Errorf("%w: the group has no other members", ErrInvalidGroupMeeting)
+	}
+
+	return schedulingBody, nil
+}
+
+// bestSuggestion returns the highest ranked suggestion with a time slot, false is returned if there is none.
+func bestSuggestion(resp SchedulingSlotsSuccessResponseBody) (MeetingTimeSuggestion, bool) {
+	if resp.MeetingTimeSuggestions == nil {
+		return MeetingTimeSuggestion{}, false
+	}
+	for _, suggestion := range *resp.MeetingTimeSuggestions {
+		if suggestion.MeetingTimeSlot != nil {
+			return suggestion, true
+		}
+	}
+	return MeetingTimeSuggestion{}, false
+}
+
+// groupMeetingEvent sets the time of a group meeting to that of suggestion and adds the members of the group,
+// other than the organiser, to its attendees.
+func groupMeetingEvent(event CalendarEvent, organiserID uint32, suggestion MeetingTimeSuggestion,
+	members []database.GetAllSlotifyGroupMembersRow,
+) CalendarEvent {
+	start := suggestion.MeetingTimeSlot.Start.UTC().Format(CalendarEventTimeLayout)
+	end := suggestion.MeetingTimeSlot.End.UTC().Format(CalendarEventTimeLayout)
+	event.StartTime, event.EndTime = &start, &end
+
+	attendees := make(map[openapi_types.Email]struct{}, len(event.Attendees))
+	for _, a := range event.Attendees {
+		attendees[a.Email] = struct{}{}
+	}
+	event.Attendees = append([]Attendee{}, event.Attendees...)
+	for _, m := range members {
+		email := openapi_types.Email(m.Email)
+		if _, ok := attendees[email]; ok || m.ID == organiserID {
+			continue
+		}
+		attendeeType := AttendeeType(m.AttendeeType)
+		attendees[email] = struct{}{}
+		event.Attendees = append(event.Attendees, Attendee{Email: email, AttendeeType: &attendeeType})
+	}
+	if event.Locations == nil {
+		event.Locations = []Location{}
+	}
+
+	return event
+}
+
+// enqueueGroupMeetingScheduled enqueues a notification to the members of a group, other than the organiser,
+// that a meeting was scheduled for the group.
+func enqueueGroupMeetingScheduled(ctx context.Context, db *database.Database, slotifyGroupID uint32,
+	organiserID uint32,
+	members []database.GetAllSlotifyGroupMembersRow,
+	event CalendarEvent,
+	slot MeetingTimeSlot,
+) error {
+	sg, err := db.GetSlotifyGroupByID(ctx, slotifyGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group by id: %w", err)
+	}
+
+	scheduled := notification.GroupMeetingScheduled{
+		SlotifyGroupID: slotifyGroupID,
+		GroupName:      sg.Name,
+		OrganiserID:    organiserID,
+		Start:          slot.Start.UTC(),
+		End:            slot.End.UTC(),
+	}
+	if event.Id != nil {
+		scheduled.EventID = *event.Id
+	}
+	if event.Subject != nil {
+		scheduled.Subject = *event.Subject
+	}
+	notif, err := notification.NewNotificationParams(scheduled, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	memberIDs := make([]uint32, 0, len(members))
+	for _, m := range members {
+		if m.ID != organiserID {
+			memberIDs = append(memberIDs, m.ID)
+		}
+	}
+	key := notification.IdempotencyKey(notif.Kind, slotifyGroupID, scheduled.EventID)
+	if err = notification.Enqueue(ctx, db, key, memberIDs, notif); err != nil {
+		return fmt.Errorf("failed to enqueue notification to members: %w", err)
+	}
+	return nil
+}
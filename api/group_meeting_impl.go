@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// (POST /api/slotify-groups/{slotifyGroupID}/meetings) Schedule a meeting for every member of a slotifyGroup.
+// nolint: funlen // by 3 lines
+func (s Server) PostAPISlotifyGroupsSlotifyGroupIDMeetings(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Minute*3)
+	defer cancel()
+
+	var body PostAPISlotifyGroupsSlotifyGroupIDMeetingsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID,
+		actionScheduleGroupMeeting); !ok {
+		return
+	}
+
+	sg, err := s.DB.GetSlotifyGroupByID(ctx, slotifyGroupID)
+	if err != nil {
+		logger.Error("failed to get slotify group", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to schedule meeting")
+		return
+	}
+	applySlotifyGroupEventDefaults(&body.Event, sg)
+
+	// The meeting is created in the organiser's time zone unless the request says otherwise
+	loc, err := requestLocation(ctx, s.DB, userID, body.Event.TimeZone)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTimeZone) {
+			logger.Error("invalid group meeting time zone", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.Error("failed to get user's time zone", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get user's time zone")
+		return
+	}
+	timeZone := loc.String()
+	body.Event.TimeZone = &timeZone
+
+	members, err := listSlotifyGroupMembers(ctx, s.DB, slotifyGroupID)
+	if err != nil {
+		logger.Error("failed to get group members", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to schedule meeting")
+		return
+	}
+
+	schedulingBody, err := groupSchedulingBody(sg, userID, body, members)
+	if err != nil {
+		logger.Error("invalid group meeting", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	slots, freeBusy, err := s.findMeetingTimesWithFreeBusy(ctx, userID, schedulingBody)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSchedulingBody) {
+			logger.Error("invalid group meeting scheduling request", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.Error("failed to find meeting times", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to find meeting times")
+		return
+	}
+	slots = generateRatingsForSlots(ctx, s, userID, slots, schedulingBody, freeBusy)
+
+	suggestion, ok := bestSuggestion(slots)
+	if !ok {
+		logger.Info("no slot suits enough of the group")
+		SetHeaderAndWriteResponse(w, http.StatusConflict, slots)
+		return
+	}
+
+	calendar, err := s.CalendarProvider(ctx, userID)
+	if err != nil {
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
+		return
+	}
+
+	event := groupMeetingEvent(body.Event, userID, suggestion, members)
+	createdEvent, err := s.createCalendarEvent(ctx, logger, calendar, userID, event)
+	if err != nil {
+		logger.Error("failed to create group meeting", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to create event")
+		return
+	}
+
+	if err = enqueueGroupMeetingScheduled(ctx, s.DB, slotifyGroupID, userID, members, createdEvent,
+		*suggestion.MeetingTimeSlot); err != nil {
+		// dont return an error because the meeting was created
+		logger.Error("failed to enqueue group meeting notifications", zap.Error(err))
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusCreated, SlotifyGroupMeeting{
+		Event:      createdEvent,
+		Suggestion: suggestion,
+	})
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+)
+
+// getOrInsertUserByEmail will get a user by their email, or if first time log in,
+// it will create a new user.
+func getOrInsertUserByEmail(ctx context.Context,
+	qtx *database.Queries, email, firstName, lastName string,
+) (database.User, error) {
+	count, err := qtx.CountUserByEmail(ctx, email)
+	if err != nil {
+		return database.User{}, fmt.Errorf("failed to get user count by claim email: %w", err)
+	}
+
+	// User doesn't exist, first time signing so sign up
+	if count == 0 {
+		dbParams := database.CreateUserParams{
+			Email:     email,
+			FirstName: firstName,
+			LastName:  lastName,
+		}
+		_, err = qtx.CreateUser(ctx, dbParams)
+		if err != nil {
+			return database.User{}, fmt.Errorf("failed to create user for claim email: %w", err)
+		}
+	}
+
+	var u database.User
+	u, err = qtx.GetUserByEmail(ctx, email)
+	if err != nil {
+		return database.User{}, fmt.Errorf("failed to get user with claim email: %w", err)
+	}
+
+	return u, nil
+}
+
+// upsertUserIdentity links a user to their account at an identity provider.
+func upsertUserIdentity(ctx context.Context, qtx *database.Queries, userID uint32,
+	provider database.UserIdentityProvider, subject string,
+) error {
+	rowsAffected, err := qtx.UpsertUserIdentity(ctx, database.UpsertUserIdentityParams{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert %s user identity: %w", provider, err)
+	}
+
+	// MySQL reports 1 row for an insert, 2 for an update and 0 if nothing changed
+	//nolint: mnd // see above
+	if rowsAffected > 2 {
+		return fmt.Errorf("failed to upsert %s user identity: %w", provider, database.WrongNumberSQLRowsError{
+			ActualRows:   rowsAffected,
+			ExpectedRows: []int64{0, 1, 2},
+		})
+	}
+
+	return nil
+}
+
+// getUserCalendarProvider returns which provider backs a user's calendar.
+// Users who have logged in with Google use Google Calendar, everyone else uses Microsoft.
+func getUserCalendarProvider(ctx context.Context, db *database.Database,
+	userID uint32,
+) (database.UserIdentityProvider, error) {
+	ctx, cancel := context.WithTimeout(ctx, database.DatabaseTimeout)
+	defer cancel()
+
+	identity, err := db.GetUserIdentity(ctx, database.GetUserIdentityParams{
+		UserID:   userID,
+		Provider: database.UserIdentityProviderGoogle,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.UserIdentityProviderMicrosoft, nil
+		}
+		return "", fmt.Errorf("failed to get google user identity: %w", err)
+	}
+
+	if len(identity.TokenData) == 0 {
+		return database.UserIdentityProviderMicrosoft, nil
+	}
+
+	return database.UserIdentityProviderGoogle, nil
+}
@@ -15,6 +15,10 @@ const (
 	TenantIDEnvName       = "MICROSOFT_TENANT_ID"
 	ClientIDEnvName       = "MICROSOFT_CLIENT_ID"
 	ClientSecretEnvName   = "MICROSOFT_CLIENT_SECRET"
+
+	GoogleClientIDEnvName           = "GOOGLE_CLIENT_ID"
+	GoogleClientSecretEnvName       = "GOOGLE_CLIENT_SECRET"
+	GoogleTokenEncryptionKeyEnvName = "GOOGLE_TOKEN_ENCRYPTION_KEY"
 )
 
 var ErrUnmarshalBody = errors.New("failed to unmarshal request body correctly")
@@ -79,39 +79,53 @@ func checkIfUsersInGroup(p checkIfUsersInGroupParams) error {
 	return nil
 }
 
-type sendPostInviteNotificationParams struct {
+type enqueuePostInviteNotificationsParams struct {
 	ctx             context.Context
+	inviteID        uint32
+	slotifyGroupID  uint32
 	toUserID        uint32
 	fromUserID      uint32
-	notifService    notification.Service
-	logger          *logger.Logger
-	db              *database.Database
+	qtx             *database.Queries
 	groupName       string
 	toUserFirstName string
 	toUserLastName  string
 }
 
-func sendPostInviteNotification(p sendPostInviteNotificationParams) {
+// enqueuePostInviteNotifications enqueues notifications about a new invite to the users it is to and from,
+// in the transaction of qtx.
+func enqueuePostInviteNotifications(p enqueuePostInviteNotificationsParams) error {
 	// Create notification to user who has been invited
-	if err := p.notifService.SendNotification(p.ctx, p.logger, p.db,
-		[]uint32{p.toUserID}, database.CreateNotificationParams{
-			Message: fmt.Sprintf("You have a new invite to team %s!", p.groupName),
-			Created: time.Now(),
-		}); err != nil {
-		p.logger.Errorf("invite api: failed to send notification to toUser",
-			zap.Error(err))
+	toUserNotif, err := notification.NewNotificationParams(notification.InviteReceived{
+		InviteID:       p.inviteID,
+		SlotifyGroupID: p.slotifyGroupID,
+		GroupName:      p.groupName,
+		FromUserID:     p.fromUserID,
+	}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create notification to toUser: %w", err)
+	}
+	if err = notification.Enqueue(p.ctx, p.qtx, notification.IdempotencyKey(toUserNotif.Kind, p.inviteID),
+		[]uint32{p.toUserID}, toUserNotif); err != nil {
+		return fmt.Errorf("failed to enqueue notification to toUser: %w", err)
 	}
 
 	// Create notification to user who created the invite
-	if err := p.notifService.SendNotification(p.ctx, p.logger, p.db,
-		[]uint32{p.fromUserID}, database.CreateNotificationParams{
-			Message: fmt.Sprintf(
-				"You successfully created an invite on behalf of team %s to %s %s!",
-				p.groupName, p.toUserFirstName, p.toUserLastName), Created: time.Now(),
-		}); err != nil {
-		p.logger.Errorf("invite api: failed to send notification to fromUser",
-			zap.Error(err))
+	fromUserNotif, err := notification.NewNotificationParams(notification.InviteSent{
+		InviteID:        p.inviteID,
+		SlotifyGroupID:  p.slotifyGroupID,
+		GroupName:       p.groupName,
+		ToUserID:        p.toUserID,
+		ToUserFirstName: p.toUserFirstName,
+		ToUserLastName:  p.toUserLastName,
+	}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create notification to fromUser: %w", err)
+	}
+	if err = notification.Enqueue(p.ctx, p.qtx, notification.IdempotencyKey(fromUserNotif.Kind, p.inviteID),
+		[]uint32{p.fromUserID}, fromUserNotif); err != nil {
+		return fmt.Errorf("failed to enqueue notification to fromUser: %w", err)
 	}
+	return nil
 }
 
 type validateAndUpdateInviteStatusParams struct {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -43,6 +44,11 @@ func (s Server) PostAPIInvites(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, invitesCreateBody.SlotifyGroupID,
+		actionInviteMembers); !ok {
+		return
+	}
+
 	var u database.User
 	if u, err = s.DB.GetUserByID(ctx, userID); err != nil {
 		logger.Errorf("invite api: failed to get user by id", zap.Error(err))
@@ -83,9 +89,24 @@ func (s Server) PostAPIInvites(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:      invitesCreateBody.CreatedAt,
 	}
 
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create invite")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
 	var inviteID int64
 	err = retry.Do(func() error {
-		if inviteID, err = s.DB.CreateInvite(ctx, params); err != nil {
+		if inviteID, err = qtx.CreateInvite(ctx, params); err != nil {
 			return fmt.Errorf("failed to create invite: %w", err)
 		}
 		return nil
@@ -96,17 +117,28 @@ func (s Server) PostAPIInvites(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sendPostInviteNotification(sendPostInviteNotificationParams{
-		ctx:             ctx,
+	if err = enqueuePostInviteNotifications(enqueuePostInviteNotificationsParams{
+		ctx: ctx,
+		//nolint: gosec // id is unsigned 32 bit int
+		inviteID:        uint32(inviteID),
+		slotifyGroupID:  invitesCreateBody.SlotifyGroupID,
 		toUserID:        invitesCreateBody.ToUserID,
 		fromUserID:      userID,
-		notifService:    s.NotificationService,
-		logger:          s.Logger,
-		db:              s.DB,
+		qtx:             qtx,
 		groupName:       g.Name,
 		toUserFirstName: toUser.FirstName,
 		toUserLastName:  toUser.LastName,
-	})
+	}); err != nil {
+		logger.Error("failed to enqueue invite notifications", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create invite")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create invite")
+		return
+	}
 
 	createdInvite := InvitesGroup{
 		CreatedAt:         invitesCreateBody.CreatedAt,
@@ -346,9 +378,8 @@ func (s Server) PatchAPIInvitesInviteIDAccept(w http.ResponseWriter, r *http.Req
 		ctx:            ctx,
 		userID:         userID,
 		slotifyGroupID: invite.SlotifyGroupID,
-		l:              s.Logger,
+		role:           Member,
 		qtx:            qtx,
-		notifService:   s.NotificationService,
 	}
 
 	if err = AddUserToSlotifyGroup(addUserParams); err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/SlotifyApp/slotify-backend/jwt"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
 
 	chi_middleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/httprate"
@@ -35,8 +36,10 @@ type RequestIDCtxKey struct{}
 func AuthMiddleware(next http.Handler) http.Handler {
 	// Paths to ignore this
 	excludedPaths := map[string]bool{
-		"/api/auth/callback": true, // http cookie is not set before logging in ie. during OAuth flow
-		"/api/healthcheck":   true, // http cookie doesn't need to present for a healthcheck
+		"/api/auth/callback":        true, // http cookie is not set before logging in ie. during OAuth flow
+		"/api/auth/google/callback": true, // same as above, for Google login
+		"/api/healthcheck":          true, // http cookie doesn't need to present for a healthcheck
+		"/api/webhooks/calendar":    true, // called by Microsoft Graph, which checks the subscription client state
 	}
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -82,8 +85,18 @@ func AuthMiddleware(next http.Handler) http.Handler {
 }
 
 func RequestIDMiddleware(next http.Handler) http.Handler {
+	// Paths called by third parties rather than the frontend, or that can't send the header, a request id is
+	// generated for them
+	generatedIDPaths := map[string]bool{
+		"/api/webhooks/calendar": true,
+		"/api/events/ws":         true, // browsers can't set headers on a WebSocket handshake
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		reqID := r.Header.Get(ReqHeader)
+		if reqID == "" && generatedIDPaths[r.URL.Path] {
+			reqID = uuid.NewString()
+		}
 		if reqID == "" {
 			sendError(w, http.StatusInternalServerError,
 				fmt.Sprintf("failed to get %s header", ReqHeader))
@@ -99,10 +112,12 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 // JWTMiddleware parses and validates the access token, and stores the userID in the request context.
 func JWTMiddleware(next http.Handler) http.Handler {
 	excludedPaths := map[string]bool{
-		"/api/auth/callback": true,
-		"/api/healthcheck":   true,
-		"/api/users/logout":  true,
-		"/api/refresh":       true,
+		"/api/auth/callback":        true,
+		"/api/auth/google/callback": true,
+		"/api/healthcheck":          true,
+		"/api/users/logout":         true,
+		"/api/refresh":              true,
+		"/api/webhooks/calendar":    true,
 	}
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
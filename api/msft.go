@@ -14,6 +14,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
 	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/msalcache"
 	"github.com/coreos/go-oidc/v3/oidc"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
 )
@@ -77,8 +78,9 @@ func getMSFTEntraValues() (MSFTEntraValues, error) {
 	}, nil
 }
 
-// createMSALClient creates a new confiential MSAL Client.
-func createMSALClient() (confidential.Client, error) {
+// createMSALClient creates a new confiential MSAL Client, whose token cache is persisted
+// (encrypted) in the database so that it survives restarts.
+func createMSALClient(db *database.Database) (confidential.Client, error) {
 	msftEntraVals, err := getMSFTEntraValues()
 	if err != nil {
 		return confidential.Client{}, fmt.Errorf("failed to get msft entra values: %w", err)
@@ -88,8 +90,14 @@ func createMSALClient() (confidential.Client, error) {
 		return confidential.Client{}, fmt.Errorf("failed to create new cred from secret: %w", err)
 	}
 
+	tokenCache, err := msalcache.NewDBCacheFromEnv(db)
+	if err != nil {
+		return confidential.Client{}, fmt.Errorf("failed to create msal token cache: %w", err)
+	}
+
 	// Create new MSAL Client
-	c, err := confidential.New(fmt.Sprintf(MicrosoftLogin, msftEntraVals.TenantID), msftEntraVals.ClientID, cred)
+	c, err := confidential.New(fmt.Sprintf(MicrosoftLogin, msftEntraVals.TenantID), msftEntraVals.ClientID, cred,
+		confidential.WithCache(tokenCache))
 	if err != nil {
 		return confidential.Client{}, fmt.Errorf("failed to create new confidential client: %w", err)
 	}
@@ -264,29 +272,14 @@ func getOrInsertUserByClaimEmail(ctx context.Context,
 	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
-	count, err := qtx.CountUserByEmail(ctx, email)
+	u, err := getOrInsertUserByEmail(ctx, qtx, email, msftTokenRes.FirstName, msftTokenRes.LastName)
 	if err != nil {
-		return database.User{}, fmt.Errorf("failed to get user count by claim email: %w", err)
+		return database.User{}, err
 	}
 
-	// User doesn't exist, first time signing so sign up
-	if count == 0 {
-		dbParams := database.CreateUserParams{
-			Email:     email,
-			FirstName: msftTokenRes.FirstName,
-			LastName:  msftTokenRes.LastName,
-		}
-		_, err = qtx.CreateUser(ctx, dbParams)
-		if err != nil {
-			return database.User{}, fmt.Errorf("failed to create user for claim email: %w", err)
-		}
-	}
-
-	var u database.User
-
-	u, err = qtx.GetUserByEmail(ctx, email)
-	if err != nil {
-		return database.User{}, fmt.Errorf("failed to get user with claim email: %w", err)
+	if err = upsertUserIdentity(ctx, qtx, u.ID,
+		database.UserIdentityProviderMicrosoft, msftTokenRes.HomeAccountID); err != nil {
+		return database.User{}, err
 	}
 
 	// Update user's home account id so it can be used when asking for a MSFT access token
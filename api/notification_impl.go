@@ -2,14 +2,32 @@ package api
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 )
 
+const (
+	// NotificationsLimitMax is the max number of notifications in a page of the inbox, or marked as read at once.
+	NotificationsLimitMax = 50
+	// webSocketReadLimit is the max size of a message from a WebSocket client, they only send acks and pings.
+	webSocketReadLimit = 4096
+)
+
 // (GET /api/events), HTTP SSE route.
-func (s Server) RenderEvent(w http.ResponseWriter, r *http.Request) {
+func (s Server) RenderEvent(w http.ResponseWriter, r *http.Request, params RenderEventParams) {
 	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
 	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
 
@@ -19,29 +37,227 @@ func (s Server) RenderEvent(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 
-	// Check if the response writer implements the flush interface
-	// if so, flush our event-stream headers
-	f, ok := w.(http.Flusher)
+	// The service tells the browser how long to wait before reconnecting, it resends the last event id when
+	// it does
+	var err error
+	if params.LastEventID != nil {
+		err = s.NotificationService.ResumeUserClient(r.Context(), s.Logger, s.DB, userID, w, *params.LastEventID)
+	} else {
+		err = s.NotificationService.RegisterUserClient(s.Logger, userID, w)
+	}
 
-	if !ok {
+	switch {
+	case errors.Is(err, notification.ErrTooManyClients):
+		logger.Warn("failed to register user client", zap.Error(err))
+		sendError(w, http.StatusTooManyRequests, "user has too many connected clients")
+		return
+	case errors.Is(err, notification.ErrNotifClientNotFlusher):
 		logger.Error("failed to flush event stream headers, responsewriter did not implement Flusher interface")
 		sendError(w, http.StatusInternalServerError, "failed to flush event stream headers")
 		return
+	case err != nil && params.LastEventID != nil:
+		// The client is still registered if replaying fails, it just misses some notifications
+		logger.Error("failed to replay missed notifications", zap.Error(err))
+	case err != nil:
+		logger.Error("failed to register user client", zap.Error(err))
+		sendError(w, http.StatusUnauthorized, "failed to register user client")
+		return
 	}
 
-	f.Flush()
+	heartbeat := time.NewTicker(notification.HeartbeatInterval)
+	defer heartbeat.Stop()
 
-	if err := s.NotificationService.RegisterUserClient(s.Logger, userID, w); err != nil {
-		logger.Errorf("failed to register user client", zap.Error(err))
-		sendError(w, http.StatusUnauthorized, "failed to register user client")
+	// Block until the request is 'done', eg. client navigates away
+	for {
+		select {
+		case <-r.Context().Done():
+			logger.Infof("userID %d disconnected", userID)
+			s.NotificationService.DeleteUserConn(s.Logger, userID, w)
+			return
+		case <-heartbeat.C:
+			err = s.NotificationService.SendHeartbeat(w)
+			if errors.Is(err, notification.ErrNotifClientNotRegistered) {
+				// The client fell behind or failed a write, the browser reconnects and is replayed what it missed
+				logger.Infof("userID %d client was removed, closing stream", userID)
+				s.NotificationService.DeleteUserConn(s.Logger, userID, w)
+				return
+			}
+			if err != nil {
+				logger.Warn("failed to send heartbeat", zap.Error(err))
+			}
+		}
+	}
+}
+
+// (GET /api/events/ws), WebSocket route. An alternative to the SSE route for clients behind proxies that
+// buffer event streams.
+func (s Server) RenderEventWebSocket(w http.ResponseWriter, r *http.Request, params RenderEventWebSocketParams) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	upgrader := websocket.Upgrader{CheckOrigin: checkWebSocketOrigin}
+
+	// The upgrader replies with an error itself if the handshake fails
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Warn("failed to upgrade to websocket", zap.Error(err))
 		return
 	}
+	defer conn.Close()
 
-	// Block until the request is 'done', eg. client navigates away
-	<-r.Context().Done()
+	client := notification.NewWebSocketClient(conn)
+
+	if params.LastEventID != nil {
+		// The client is still registered if replaying fails, it just misses some notifications
+		err = s.NotificationService.ResumeUserClient(r.Context(), s.Logger, s.DB, userID, client,
+			*params.LastEventID)
+		if errors.Is(err, notification.ErrTooManyClients) {
+			logger.Warn("failed to register user client", zap.Error(err))
+			closeWebSocket(logger, conn, err)
+			return
+		}
+		if err != nil {
+			logger.Error("failed to replay missed notifications", zap.Error(err))
+		}
+	} else if err = s.NotificationService.RegisterUserClient(s.Logger, userID, client); err != nil {
+		logger.Error("failed to register user client", zap.Error(err))
+		closeWebSocket(logger, conn, err)
+		return
+	}
+	defer s.NotificationService.DeleteUserConn(s.Logger, userID, client)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		heartbeat := time.NewTicker(notification.HeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-heartbeat.C:
+				err := s.NotificationService.SendHeartbeat(client)
+				if errors.Is(err, notification.ErrNotifClientNotRegistered) {
+					// The client fell behind or failed a write, closing the connection stops reading from it
+					logger.Infof("userID %d client was removed, closing connection", userID)
+					_ = conn.Close()
+					return
+				}
+				if err != nil {
+					logger.Warn("failed to send heartbeat", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	s.readWebSocketClient(r.Context(), logger, userID, conn, client)
 
 	logger.Infof("userID %d disconnected", userID)
-	s.NotificationService.DeleteUserConn(s.Logger, userID, w)
+}
+
+// closeWebSocket closes a WebSocket client that couldn't be registered, telling it to try again later if its
+// user has too many clients.
+func closeWebSocket(logger *zap.SugaredLogger, conn *websocket.Conn, err error) {
+	code, reason := websocket.CloseInternalServerErr, "failed to register user client"
+	if errors.Is(err, notification.ErrTooManyClients) {
+		code, reason = websocket.CloseTryAgainLater, "user has too many connected clients"
+	}
+
+	if err = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(time.Second)); err != nil {
+		logger.Warn("failed to close websocket", zap.Error(err))
+	}
+}
+
+// readWebSocketClient handles the messages a WebSocket client sends until it disconnects, or answers nothing
+// for notification.WebSocketPongWait.
+func (s Server) readWebSocketClient(ctx context.Context, logger *zap.SugaredLogger, userID uint32,
+	conn *websocket.Conn, client *notification.WebSocketClient,
+) {
+	alive := func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(notification.WebSocketPongWait))
+	}
+	conn.SetReadLimit(webSocketReadLimit)
+	conn.SetPongHandler(alive)
+
+	for {
+		if err := alive(""); err != nil {
+			logger.Warn("failed to set websocket read deadline", zap.Error(err))
+			return
+		}
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Warn("websocket closed unexpectedly", zap.Error(err))
+			}
+			return
+		}
+
+		var msg notification.WebSocketMessage
+		if err = json.Unmarshal(data, &msg); err != nil {
+			logger.Warn("failed to decode websocket message", zap.Error(err))
+			continue
+		}
+
+		switch msg.Type {
+		case notification.WebSocketMessageAck:
+			s.ackWebSocketNotification(ctx, logger, userID, msg.ID)
+		case notification.WebSocketMessagePing:
+			if err = client.WriteMessage(notification.WebSocketMessage{
+				Type: notification.WebSocketMessagePong,
+			}); err != nil {
+				logger.Warn("failed to send pong", zap.Error(err))
+			}
+		case notification.WebSocketMessageNotification, notification.WebSocketMessagePong:
+			logger.Warnf("unexpected websocket message type %q from client", msg.Type)
+		default:
+			logger.Warnf("unknown websocket message type %q", msg.Type)
+		}
+	}
+}
+
+// ackWebSocketNotification marks a notification a WebSocket client has acked as read.
+func (s Server) ackWebSocketNotification(ctx context.Context, logger *zap.SugaredLogger, userID uint32,
+	notificationID uint32,
+) {
+	ctx, cancel := context.WithTimeout(ctx, database.DatabaseTimeout)
+	defer cancel()
+
+	// Acking a notification that is already read, or isn't the user's, does nothing
+	if _, err := s.DB.MarkNotificationAsRead(ctx, database.MarkNotificationAsReadParams{
+		UserID:         userID,
+		NotificationID: notificationID,
+	}); err != nil {
+		logger.Error("failed to mark acked notification as read", zap.Error(err))
+	}
+}
+
+// checkWebSocketOrigin allows WebSocket connections from the frontend and from the API's own origin.
+// Requests without an origin aren't from browsers, so aren't at risk of cross-site hijacking.
+func checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(originURL.Host, r.Host) {
+		return true
+	}
+
+	frontendURL, err := url.Parse(os.Getenv(FrontendURLEnvName))
+	if err != nil || frontendURL.Host == "" {
+		return false
+	}
+	return strings.EqualFold(originURL.Scheme, frontendURL.Scheme) && strings.EqualFold(originURL.Host, frontendURL.Host)
 }
 
 // (PATCH /api/notifications/{notificationID}/read). Mark notifications as being read.
@@ -79,8 +295,150 @@ func (s Server) PatchAPINotificationsNotificationIDRead(w http.ResponseWriter, r
 	SetHeaderAndWriteResponse(w, http.StatusOK, "Marked notification as read.")
 }
 
-// (GET /api/notifications/{notificationID}/read).
-func (s Server) GetAPIUsersMeNotifications(w http.ResponseWriter, r *http.Request) {
+// (GET /api/users/me/notifications). Get a page of the user's notifications, newest first.
+func (s Server) GetAPIUsersMeNotifications(w http.ResponseWriter, r *http.Request,
+	params GetAPIUsersMeNotificationsParams,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	if params.Limit <= 0 {
+		sendError(w, http.StatusBadRequest, "limit must be positive")
+		return
+	}
+	notifsLimit := min(params.Limit, NotificationsLimitMax)
+
+	// Pages go back in time, so the first page starts after the newest notification
+	var lastID uint32 = math.MaxUint32
+	if params.PageToken != nil && *params.PageToken != 0 {
+		lastID = *params.PageToken
+	}
+
+	status := Unread
+	if params.Status != nil {
+		status = *params.Status
+	}
+
+	var isRead sql.NullBool
+	switch status {
+	case Unread:
+		isRead = sql.NullBool{Bool: false, Valid: true}
+	case Read:
+		isRead = sql.NullBool{Bool: true, Valid: true}
+	case All:
+	default:
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("invalid notification status %q", status))
+		return
+	}
+
+	notifs, err := s.DB.ListUserNotifications(ctx, database.ListUserNotificationsParams{
+		UserID: userID,
+		LastID: lastID,
+		IsRead: isRead,
+		Limit:  notifsLimit,
+	})
+	if err != nil {
+		logger.Error("failed to get user notifications", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "failed to get user notifications from db")
+		return
+	}
+
+	var nextPageToken uint32
+	if len(notifs) == int(notifsLimit) {
+		nextPageToken = notifs[len(notifs)-1].ID
+	}
+
+	response := struct {
+		Notifications []database.ListUserNotificationsRow `json:"notifications"`
+		NextPageToken uint32                              `json:"nextPageToken"`
+	}{
+		Notifications: notifs,
+		NextPageToken: nextPageToken,
+	}
+	SetHeaderAndWriteResponse(w, http.StatusOK, response)
+}
+
+// (PATCH /api/users/me/notifications/read). Mark many notifications as read, all unread ones if no ids are given.
+func (s Server) PatchAPIUsersMeNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	var body PatchAPIUsersMeNotificationsReadJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*database.DatabaseTimeout)
+	defer cancel()
+
+	if body.NotificationIDs == nil {
+		rows, err := s.DB.MarkAllNotificationsAsRead(ctx, userID)
+		if err != nil {
+			logger.Error("failed to mark all notifications as read", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "Failed to mark notifications as read.")
+			return
+		}
+
+		SetHeaderAndWriteResponse(w, http.StatusOK, fmt.Sprintf("Marked %d notifications as read.", rows))
+		return
+	}
+
+	if len(*body.NotificationIDs) > NotificationsLimitMax {
+		sendError(w, http.StatusBadRequest,
+			fmt.Sprintf("At most %d notifications can be marked as read at once.", NotificationsLimitMax))
+		return
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to mark notifications as read.")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	// Notifications that aren't the user's, or are already read, are skipped
+	var marked int64
+	for _, notificationID := range *body.NotificationIDs {
+		var rows int64
+		if rows, err = qtx.MarkNotificationAsRead(ctx, database.MarkNotificationAsReadParams{
+			UserID:         userID,
+			NotificationID: notificationID,
+		}); err != nil {
+			logger.Error("failed to mark notification as read", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "Failed to mark notifications as read.")
+			return
+		}
+		marked += rows
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to mark notifications as read.")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, fmt.Sprintf("Marked %d notifications as read.", marked))
+}
+
+// (GET /api/users/me/notifications/unread-count). Get how many notifications the user hasn't read.
+func (s Server) GetAPIUsersMeNotificationsUnreadCount(w http.ResponseWriter, r *http.Request) {
 	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
 	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
 
@@ -89,12 +447,41 @@ func (s Server) GetAPIUsersMeNotifications(w http.ResponseWriter, r *http.Reques
 	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
 	defer cancel()
 
-	notifs, err := s.DB.GetUnreadUserNotifications(ctx, userID)
+	count, err := s.DB.CountUnreadUserNotifications(ctx, userID)
 	if err != nil {
-		logger.Error("failed to get unread user notifications")
-		sendError(w, http.StatusInternalServerError, "failed to get unread user notifications from db")
+		logger.Error("failed to count unread notifications", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to count unread notifications.")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, UnreadNotificationCount{Count: count})
+}
+
+// (DELETE /api/notifications/{notificationID}). Delete a notification from the user's inbox.
+func (s Server) DeleteAPINotificationsNotificationID(w http.ResponseWriter, r *http.Request, notificationID uint32) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	// Only the user's link to the notification is deleted, other users may have been sent it too
+	rows, err := s.DB.DeleteUserNotification(ctx, database.DeleteUserNotificationParams{
+		UserID:         userID,
+		NotificationID: notificationID,
+	})
+	if err != nil {
+		logger.Error("failed to delete notification", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to delete notification.")
+		return
+	}
+
+	if rows != 1 {
+		sendError(w, http.StatusNotFound, "Notification not found.")
 		return
 	}
 
-	SetHeaderAndWriteResponse(w, http.StatusOK, notifs)
+	SetHeaderAndWriteResponse(w, http.StatusOK, "Deleted notification.")
 }
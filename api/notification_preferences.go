@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidNotificationPreferences is returned when notification preferences can't be saved.
+var ErrInvalidNotificationPreferences = errors.New("invalid notification preferences")
+
+// validateNotificationChannels checks each kind is given one valid channel.
+func validateNotificationChannels(channels []NotificationChannelPreference) error {
+	seen := make(map[NotificationKind]struct{}, len(channels))
+	for _, c := range channels {
+		if !notification.Kind(c.Kind).Valid() {
+			return fmt.Errorf("%w: invalid notification kind '%s'", ErrInvalidNotificationPreferences, c.Kind)
+		}
+		if !notification.Channel(c.Channel).Valid() {
+			return fmt.Errorf("%w: invalid notification channel '%s'", ErrInvalidNotificationPreferences, c.Channel)
+		}
+		if _, ok := seen[c.Kind]; ok {
+			return fmt.Errorf("%w: notification kind '%s' is given more than one channel",
+				ErrInvalidNotificationPreferences, c.Kind)
+		}
+		seen[c.Kind] = struct{}{}
+	}
+	return nil
+}
+
+// validateNotificationPreferences checks a user's notification preferences can be saved.
+func validateNotificationPreferences(prefs NotificationPreferences) error {
+	if err := validateNotificationChannels(prefs.Channels); err != nil {
+		return err
+	}
+	if prefs.QuietHours == nil {
+		return nil
+	}
+	// Quiet hours are in the user's time zone, which is always valid, so only their times are checked
+	if _, err := notification.NewQuietHours(database.GetNotificationQuietHoursRow{
+		StartTime: prefs.QuietHours.Start,
+		EndTime:   prefs.QuietHours.End,
+		TimeZone:  time.UTC.String(),
+	}); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidNotificationPreferences, err)
+	}
+	return nil
+}
+
+// toNotificationChannelPreference converts a DB kind and channel into their API form.
+func toNotificationChannelPreference(kind, channel string) NotificationChannelPreference {
+	return NotificationChannelPreference{
+		Kind:    NotificationKind(kind),
+		Channel: NotificationChannel(channel),
+	}
+}
+
+// getNotificationPreferences gets a user's notification preferences, kinds they haven't chosen a channel for
+// are left out.
+func getNotificationPreferences(ctx context.Context, db *database.Database,
+	userID uint32,
+) (NotificationPreferences, error) {
+	dbPrefs, err := db.ListNotificationPreferences(ctx, userID)
+	if err != nil {
+		return NotificationPreferences{}, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	prefs := NotificationPreferences{Channels: []NotificationChannelPreference{}}
+	for _, p := range dbPrefs {
+		prefs.Channels = append(prefs.Channels, toNotificationChannelPreference(p.Kind, p.Channel))
+	}
+
+	qh, err := db.GetNotificationQuietHours(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return prefs, nil
+		}
+		return NotificationPreferences{}, fmt.Errorf("failed to get notification quiet hours: %w", err)
+	}
+	prefs.QuietHours = &NotificationQuietHours{
+		Start: formatTimeOfDay(qh.StartTime),
+		End:   formatTimeOfDay(qh.EndTime),
+	}
+	return prefs, nil
+}
+
+// replaceNotificationPreferences replaces all of a user's notification preferences, which must be valid.
+func replaceNotificationPreferences(ctx context.Context, logger *zap.SugaredLogger, db *database.Database,
+	userID uint32,
+	prefs NotificationPreferences,
+) error {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start db transaction: %w", err)
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := db.WithTx(tx)
+
+	if _, err = qtx.DeleteNotificationPreferences(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete notification preferences: %w", err)
+	}
+	for _, c := range prefs.Channels {
+		if _, err = qtx.CreateNotificationPreference(ctx, database.CreateNotificationPreferenceParams{
+			UserID:  userID,
+			Kind:    string(c.Kind),
+			Channel: string(c.Channel),
+		}); err != nil {
+			return fmt.Errorf("failed to create notification preference: %w", err)
+		}
+	}
+
+	if prefs.QuietHours == nil {
+		if _, err = qtx.DeleteNotificationQuietHours(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete notification quiet hours: %w", err)
+		}
+	} else {
+		if _, err = qtx.UpsertNotificationQuietHours(ctx, database.UpsertNotificationQuietHoursParams{
+			UserID:    userID,
+			StartTime: prefs.QuietHours.Start,
+			EndTime:   prefs.QuietHours.End,
+		}); err != nil {
+			return fmt.Errorf("failed to save notification quiet hours: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit db transaction: %w", err)
+	}
+	return nil
+}
+
+// getSlotifyGroupNotificationPreferences gets a SlotifyGroup's default notification channels.
+func getSlotifyGroupNotificationPreferences(ctx context.Context, db *database.Database,
+	slotifyGroupID uint32,
+) (SlotifyGroupNotificationPreferences, error) {
+	dbPrefs, err := db.ListSlotifyGroupNotificationPreferences(ctx, slotifyGroupID)
+	if err != nil {
+		return SlotifyGroupNotificationPreferences{},
+			fmt.Errorf("failed to get slotify group notification preferences: %w", err)
+	}
+
+	prefs := SlotifyGroupNotificationPreferences{Channels: []NotificationChannelPreference{}}
+	for _, p := range dbPrefs {
+		prefs.Channels = append(prefs.Channels, toNotificationChannelPreference(p.Kind, p.Channel))
+	}
+	return prefs, nil
+}
+
+// replaceSlotifyGroupNotificationPreferences replaces all of a SlotifyGroup's default notification channels,
+// which must be valid.
+func replaceSlotifyGroupNotificationPreferences(ctx context.Context, logger *zap.SugaredLogger,
+	db *database.Database,
+	slotifyGroupID uint32,
+	prefs SlotifyGroupNotificationPreferences,
+) error {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start db transaction: %w", err)
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := db.WithTx(tx)
+
+	if _, err = qtx.DeleteSlotifyGroupNotificationPreferences(ctx, slotifyGroupID); err != nil {
+		return fmt.Errorf("failed to delete slotify group notification preferences: %w", err)
+	}
+	for _, c := range prefs.Channels {
+		if _, err = qtx.CreateSlotifyGroupNotificationPreference(ctx,
+			database.CreateSlotifyGroupNotificationPreferenceParams{
+				SlotifyGroupID: slotifyGroupID,
+				Kind:           string(c.Kind),
+				Channel:        string(c.Channel),
+			}); err != nil {
+			return fmt.Errorf("failed to create slotify group notification preference: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit db transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"go.uber.org/zap"
+)
+
+// (GET /api/users/me/notification-preferences).
+func (s Server) GetAPIUsersMeNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	prefs, err := getNotificationPreferences(ctx, s.DB, userID)
+	if err != nil {
+		logger.Error("failed to get notification preferences", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get notification preferences")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, prefs)
+}
+
+// (PUT /api/users/me/notification-preferences).
+func (s Server) PutAPIUsersMeNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPIUsersMeNotificationPreferencesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	if err := validateNotificationPreferences(body); err != nil {
+		logger.Error("invalid notification preferences", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := replaceNotificationPreferences(ctx, logger, s.DB, userID, body); err != nil {
+		logger.Error("failed to save notification preferences", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to save notification preferences")
+		return
+	}
+
+	prefs, err := getNotificationPreferences(ctx, s.DB, userID)
+	if err != nil {
+		logger.Error("failed to get notification preferences", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get notification preferences")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, prefs)
+}
+
+// (GET /api/slotify-groups/{slotifyGroupID}/notification-preferences).
+func (s Server) GetAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID,
+		actionViewNotificationPreferences); !ok {
+		return
+	}
+
+	prefs, err := getSlotifyGroupNotificationPreferences(ctx, s.DB, slotifyGroupID)
+	if err != nil {
+		logger.Error("failed to get slotify group notification preferences", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get notification preferences")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, prefs)
+}
+
+// (PUT /api/slotify-groups/{slotifyGroupID}/notification-preferences).
+func (s Server) PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferencesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	if err := validateNotificationChannels(body.Channels); err != nil {
+		logger.Error("invalid notification preferences", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID,
+		actionEditGroupSettings); !ok {
+		return
+	}
+
+	if err := replaceSlotifyGroupNotificationPreferences(ctx, logger, s.DB, slotifyGroupID, body); err != nil {
+		logger.Error("failed to save slotify group notification preferences", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to save notification preferences")
+		return
+	}
+
+	prefs, err := getSlotifyGroupNotificationPreferences(ctx, s.DB, slotifyGroupID)
+	if err != nil {
+		logger.Error("failed to get slotify group notification preferences", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get notification preferences")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, prefs)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/microsoft/kiota-abstractions-go/serialization"
 	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -49,7 +50,7 @@ func parseMSFTAttendees(e graphmodels.Eventable) ([]Attendee, error) {
 }
 
 // parseCalendarEventToMSFTEvent parses CalendarEvent to create a MSFT Event.
-func parseCalendarEventToMSFTEvent(eventRequest CalendarEvent) *graphmodels.Event {
+func parseCalendarEventToMSFTEvent(eventRequest CalendarEvent) (*graphmodels.Event, error) {
 	event := graphmodels.NewEvent()
 	event.SetSubject(eventRequest.Subject)
 
@@ -59,23 +60,46 @@ func parseCalendarEventToMSFTEvent(eventRequest CalendarEvent) *graphmodels.Even
 	body.SetContent(eventRequest.Body)
 	event.SetBody(body)
 
-	timeZone := "UTC"
+	loc, err := calendarEventLocation(eventRequest)
+	if err != nil {
+		return nil, err
+	}
 
-	start := graphmodels.NewDateTimeTimeZone()
-	start.SetDateTime(eventRequest.StartTime)
-	start.SetTimeZone(&timeZone)
-	event.SetStart(start)
+	var startTime time.Time
+	if eventRequest.StartTime != nil {
+		if startTime, err = ParseCalendarEventTime(*eventRequest.StartTime); err != nil {
+			return nil, err
+		}
+		event.SetStart(toGraphDateTimeTimeZone(startTime, loc))
+	}
+
+	if eventRequest.EndTime != nil {
+		endTime, err := ParseCalendarEventTime(*eventRequest.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		event.SetEnd(toGraphDateTimeTimeZone(endTime, loc))
+	}
 
-	end := graphmodels.NewDateTimeTimeZone()
-	end.SetDateTime(eventRequest.EndTime)
-	end.SetTimeZone(&timeZone)
-	event.SetEnd(end)
+	if eventRequest.Recurrence != nil {
+		if eventRequest.StartTime == nil {
+			return nil, errors.New("recurring event is missing a start time")
+		}
+		event.SetRecurrence(parseRecurrenceToMSFT(*eventRequest.Recurrence, startTime.In(loc)))
+	}
 
 	// is location required and roomtype is not a property of location in graph
-	var location *graphmodels.Location
 	if len(eventRequest.Locations) > 0 {
-		location = graphmodels.NewLocation()
+		location := graphmodels.NewLocation()
 		location.SetDisplayName(eventRequest.Locations[0].Name)
+		location.SetLocationEmailAddress(eventRequest.Locations[0].EmailAddress)
+		event.SetLocation(location)
+	}
+
+	if eventRequest.IsOnlineMeeting != nil && *eventRequest.IsOnlineMeeting {
+		provider := graphmodels.TEAMSFORBUSINESS_ONLINEMEETINGPROVIDERTYPE
+		event.SetIsOnlineMeeting(eventRequest.IsOnlineMeeting)
+		event.SetOnlineMeetingProvider(&provider)
 	}
 
 	var attendees []graphmodels.Attendeeable
@@ -115,7 +139,120 @@ func parseCalendarEventToMSFTEvent(eventRequest CalendarEvent) *graphmodels.Even
 	transactionID := uuid.New().String()
 	event.SetTransactionId(&transactionID)
 
-	return event
+	return event, nil
+}
+
+// parseRecurrenceToMSFT converts a recurrence of a series first starting at start into a MSFT patterned
+// recurrence, see [MSFT Patterned Recurrence]. Occurrences are at the same time of day in start's time zone.
+//
+// [MSFT Patterned Recurrence]: https://learn.microsoft.com/en-us/graph/api/resources/patternedrecurrence?view=graph-rest-1.0
+func parseRecurrenceToMSFT(r Recurrence, start time.Time) *graphmodels.PatternedRecurrence {
+	pattern := graphmodels.NewRecurrencePattern()
+
+	interval := int32(1)
+	if r.Interval != nil {
+		interval = *r.Interval
+	}
+	pattern.SetInterval(&interval)
+
+	var patternType graphmodels.RecurrencePatternType
+	switch r.Pattern {
+	case Daily:
+		patternType = graphmodels.DAILY_RECURRENCEPATTERNTYPE
+	case Weekly:
+		patternType = graphmodels.WEEKLY_RECURRENCEPATTERNTYPE
+
+		// MSFT and time.Weekday both number the days from Sunday
+		days := []graphmodels.DayOfWeek{}
+		if r.DaysOfWeek != nil {
+			for _, d := range *r.DaysOfWeek {
+				if weekday, ok := weekdays[d]; ok {
+					days = append(days, graphmodels.DayOfWeek(weekday))
+				}
+			}
+		}
+		if len(days) == 0 {
+			days = append(days, graphmodels.DayOfWeek(start.Weekday()))
+		}
+		pattern.SetDaysOfWeek(days)
+	case Monthly:
+		patternType = graphmodels.ABSOLUTEMONTHLY_RECURRENCEPATTERNTYPE
+
+		//nolint: gosec // day of the month is at most 31
+		dayOfMonth := int32(start.Day())
+		pattern.SetDayOfMonth(&dayOfMonth)
+	}
+	pattern.SetTypeEscaped(&patternType)
+
+	recurrenceRange := graphmodels.NewRecurrenceRange()
+	recurrenceRange.SetStartDate(serialization.NewDateOnly(start))
+	timeZone := graphTimeZoneName(start.Location())
+	recurrenceRange.SetRecurrenceTimeZone(&timeZone)
+
+	var rangeType graphmodels.RecurrenceRangeType
+	switch {
+	case r.Occurrences != nil:
+		rangeType = graphmodels.NUMBERED_RECURRENCERANGETYPE
+		recurrenceRange.SetNumberOfOccurrences(r.Occurrences)
+	case r.EndDate != nil:
+		rangeType = graphmodels.ENDDATE_RECURRENCERANGETYPE
+		recurrenceRange.SetEndDate(serialization.NewDateOnly(r.EndDate.Time))
+	default:
+		rangeType = graphmodels.NOEND_RECURRENCERANGETYPE
+	}
+	recurrenceRange.SetTypeEscaped(&rangeType)
+
+	recurrence := graphmodels.NewPatternedRecurrence()
+	recurrence.SetPattern(pattern)
+	recurrence.SetRangeEscaped(recurrenceRange)
+	return recurrence
+}
+
+// parseMSFTRecurrence converts a MSFT patterned recurrence into a Recurrence, nil is returned for
+// patterns Slotify can't represent, eg. "the first Monday of every month".
+func parseMSFTRecurrence(r graphmodels.PatternedRecurrenceable) *Recurrence {
+	if r == nil || r.GetPattern() == nil || r.GetPattern().GetTypeEscaped() == nil {
+		return nil
+	}
+	pattern := r.GetPattern()
+
+	recurrence := Recurrence{Interval: pattern.GetInterval()}
+	switch *pattern.GetTypeEscaped() {
+	case graphmodels.DAILY_RECURRENCEPATTERNTYPE:
+		recurrence.Pattern = Daily
+	case graphmodels.WEEKLY_RECURRENCEPATTERNTYPE:
+		recurrence.Pattern = Weekly
+
+		days := make([]Weekday, 0, len(pattern.GetDaysOfWeek()))
+		for _, d := range pattern.GetDaysOfWeek() {
+			days = append(days, Weekday(d.String()))
+		}
+		recurrence.DaysOfWeek = &days
+	case graphmodels.ABSOLUTEMONTHLY_RECURRENCEPATTERNTYPE:
+		recurrence.Pattern = Monthly
+	case graphmodels.RELATIVEMONTHLY_RECURRENCEPATTERNTYPE, graphmodels.ABSOLUTEYEARLY_RECURRENCEPATTERNTYPE,
+		graphmodels.RELATIVEYEARLY_RECURRENCEPATTERNTYPE:
+		return nil
+	default:
+		return nil
+	}
+
+	recurrenceRange := r.GetRangeEscaped()
+	if recurrenceRange == nil || recurrenceRange.GetTypeEscaped() == nil {
+		return &recurrence
+	}
+	switch *recurrenceRange.GetTypeEscaped() {
+	case graphmodels.NUMBERED_RECURRENCERANGETYPE:
+		recurrence.Occurrences = recurrenceRange.GetNumberOfOccurrences()
+	case graphmodels.ENDDATE_RECURRENCERANGETYPE:
+		if recurrenceRange.GetEndDate() != nil {
+			if endDate, err := time.Parse(time.DateOnly, recurrenceRange.GetEndDate().String()); err == nil {
+				recurrence.EndDate = &openapi_types.Date{Time: endDate}
+			}
+		}
+	case graphmodels.NOEND_RECURRENCERANGETYPE:
+	}
+	return &recurrence
 }
 
 // getFreeBusyStatus attempts to convert a string into a AttendeeType, if none match use FreeBusyStatusUnknown.
@@ -175,21 +312,14 @@ func processMSFTTimeSlot(s graphmodels.MeetingTimeSuggestionable) *MeetingTimeSl
 		return nil
 	}
 	processedMeetingTimeSlot := MeetingTimeSlot{}
-	start := timeSlot.GetStart()
-	end := timeSlot.GetEnd()
-
-	layout := "2006-01-02T15:04:05.0000000"
 
-	if start != nil && start.GetDateTime() != nil {
-		if t, err := time.Parse(layout, *start.GetDateTime()); err == nil {
-			processedMeetingTimeSlot.Start = t
-		}
+	// Times are in the time zone asked for with the Prefer header
+	if t, err := parseGraphDateTimeTimeZone(timeSlot.GetStart()); err == nil {
+		processedMeetingTimeSlot.Start = t
 	}
 
-	if end != nil && end.GetDateTime() != nil {
-		if t, err := time.Parse(layout, *end.GetDateTime()); err == nil {
-			processedMeetingTimeSlot.End = t
-		}
+	if t, err := parseGraphDateTimeTimeZone(timeSlot.GetEnd()); err == nil {
+		processedMeetingTimeSlot.End = t
 	}
 
 	return &processedMeetingTimeSlot
@@ -299,10 +429,11 @@ func parseMSFTLocations(msftLocations []graphmodels.Locationable) []Location {
 		}
 
 		parsedLoc := Location{
-			Id:       l.GetUniqueId(),
-			Name:     l.GetDisplayName(),
-			Street:   street,
-			RoomType: &roomType,
+			EmailAddress: l.GetLocationEmailAddress(),
+			Id:           l.GetUniqueId(),
+			Name:         l.GetDisplayName(),
+			Street:       street,
+			RoomType:     &roomType,
 		}
 
 		locations = append(locations, parsedLoc)
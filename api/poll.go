@@ -0,0 +1,655 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidPoll is returned when a poll can't be created, voted on or closed as asked.
+	ErrInvalidPoll = errors.New("invalid poll")
+	// ErrPollNotFound is returned when a poll doesn't exist or the user isn't one of its voters.
+	ErrPollNotFound = errors.New("poll not found")
+	// ErrPollClosed is returned when a poll that has closed, or is being closed, is voted on or closed again.
+	ErrPollClosed = errors.New("poll has closed")
+	// ErrPollMeetingNotCreated is returned when a poll can't be closed because its meeting couldn't be created
+	// in the organiser's calendar, the poll isn't closed.
+	ErrPollMeetingNotCreated = errors.New("failed to create the poll's meeting")
+)
+
+const (
+	// PollCloseLease is how long closing a poll may take before it can be closed again, eg. if the replica
+	// closing it crashed.
+	PollCloseLease = 2 * time.Minute
+
+	// pollCloseBatchSize is the most polls closed by one ClosePollsPastDeadline.
+	pollCloseBatchSize = 50
+	// pollCloseRetryDelay is how long ClosePollsPastDeadline waits to close a poll again after failing to,
+	// it doubles after each failed attempt.
+	pollCloseRetryDelay = 5 * time.Minute
+	// maxPollCloseAttempts is how many times ClosePollsPastDeadline tries to close a poll before marking it
+	// failed, so polls that can't be closed don't keep filling its batches.
+	maxPollCloseAttempts = 5
+)
+
+// pollVoteWeights are how much a vote for a slot counts towards it being chosen when its poll is closed.
+// nolint: gochecknoglobals // immutable map, wont change at runtime
+var pollVoteWeights = map[PollVoteChoice]int{
+	Yes:   2,
+	Maybe: 1,
+	No:    0,
+}
+
+// validatePollCreateBody checks the parts of a new poll the openapi spec can't.
+func validatePollCreateBody(body PollCreateBody, now time.Time) error {
+	if !body.Deadline.After(now) {
+		return fmt.Errorf("%w: deadline must be in the future", ErrInvalidPoll)
+	}
+	for _, o := range body.Options {
+		if !o.EndTime.After(o.StartTime) {
+			return fmt.Errorf("%w: a slot must end after it starts", ErrInvalidPoll)
+		}
+	}
+	if body.Event.Recurrence != nil {
+		if err := validateRecurrence(*body.Event.Recurrence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pollVoterIDs returns the ids of the users who can vote on a new poll, the organiser first then the attendees
+// and the members of the group. The errors of authoriseSlotifyGroupAction are returned if the organiser can't
+// create a poll for the group.
+func pollVoterIDs(ctx context.Context, db *database.Database, organiserID uint32, body PollCreateBody,
+) ([]uint32, error) {
+	voterIDs := []uint32{organiserID}
+	seen := map[uint32]struct{}{organiserID: {}}
+	add := func(id uint32) {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			voterIDs = append(voterIDs, id)
+		}
+	}
+
+	if body.SlotifyGroupID != nil {
+		if _, err := authoriseSlotifyGroupAction(ctx, &db.Queries, organiserID, *body.SlotifyGroupID,
+			actionCreateGroupPoll); err != nil {
+			return nil, err
+		}
+		members, err := db.GetAllSlotifyGroupMembersExcept(ctx, database.GetAllSlotifyGroupMembersExceptParams{
+			SlotifyGroupID: *body.SlotifyGroupID,
+			UserID:         organiserID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get slotify group members: %w", err)
+		}
+		for _, id := range members {
+			add(id)
+		}
+	}
+
+	if body.Attendees != nil {
+		for _, id := range *body.Attendees {
+			if _, err := db.GetUserByID(ctx, id); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, fmt.Errorf("%w: user %d does not exist", ErrInvalidPoll, id)
+				}
+				return nil, fmt.Errorf("failed to get attendee: %w", err)
+			}
+			add(id)
+		}
+	}
+
+	if len(voterIDs) == 1 {
+		return nil, fmt.Errorf("%w: a poll needs attendees or a group to vote on it", ErrInvalidPoll)
+	}
+	return voterIDs, nil
+}
+
+// createPoll saves a new poll in the transaction of qtx, returning its id. The event gets the defaults of the
+// poll's group.
+func createPoll(ctx context.Context, qtx *database.Queries, organiserID uint32, body PollCreateBody,
+	voterIDs []uint32, now time.Time,
+) (uint32, error) {
+	if body.SlotifyGroupID != nil {
+		sg, err := qtx.GetSlotifyGroupByID(ctx, *body.SlotifyGroupID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get slotify group: %w", err)
+		}
+		applySlotifyGroupEventDefaults(&body.Event, sg)
+	}
+
+	event, err := json.Marshal(body.Event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode poll event as json: %w", err)
+	}
+
+	var slotifyGroupID sql.NullInt32
+	if body.SlotifyGroupID != nil {
+		//nolint: gosec // id is unsigned 32 bit int
+		slotifyGroupID = sql.NullInt32{Int32: int32(*body.SlotifyGroupID), Valid: true}
+	}
+
+	pollID, err := qtx.CreatePoll(ctx, database.CreatePollParams{
+		OrganiserID:    organiserID,
+		SlotifyGroupID: slotifyGroupID,
+		Event:          event,
+		Deadline:       body.Deadline.UTC(),
+		CreatedAt:      now,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	for _, o := range body.Options {
+		if _, err = qtx.CreatePollOption(ctx, database.CreatePollOptionParams{
+			//nolint: gosec // id is unsigned 32 bit int
+			PollID:    uint32(pollID),
+			StartTime: o.StartTime.UTC(),
+			EndTime:   o.EndTime.UTC(),
+		}); err != nil {
+			return 0, fmt.Errorf("failed to create poll option: %w", err)
+		}
+	}
+
+	for _, userID := range voterIDs {
+		if _, err = qtx.CreatePollVoter(ctx, database.CreatePollVoterParams{
+			//nolint: gosec // id is unsigned 32 bit int
+			PollID: uint32(pollID),
+			UserID: userID,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to add poll voter: %w", err)
+		}
+	}
+
+	//nolint: gosec // id is unsigned 32 bit int
+	return uint32(pollID), nil
+}
+
+// getPoll gets a poll with its options, voters and votes.
+func getPoll(ctx context.Context, q *database.Queries, p database.Poll) (Poll, error) {
+	options, err := q.ListPollOptions(ctx, p.ID)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to list poll options: %w", err)
+	}
+	voterIDs, err := q.ListPollVoters(ctx, p.ID)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to list poll voters: %w", err)
+	}
+	votes, err := q.ListPollVotes(ctx, p.ID)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to list poll votes: %w", err)
+	}
+
+	var event CalendarEvent
+	if err = json.Unmarshal(p.Event, &event); err != nil {
+		return Poll{}, fmt.Errorf("failed to decode poll event: %w", err)
+	}
+
+	poll := Poll{
+		Id:          p.ID,
+		OrganiserID: p.OrganiserID,
+		Event:       event,
+		Deadline:    p.Deadline,
+		Status:      PollStatus(p.Status),
+		Options:     make([]PollOption, 0, len(options)),
+		Voters:      voterIDs,
+		Votes:       make([]PollVote, 0, len(votes)),
+		CreatedAt:   p.CreatedAt,
+	}
+	if p.SlotifyGroupID.Valid {
+		//nolint: gosec // id is unsigned 32 bit int
+		slotifyGroupID := uint32(p.SlotifyGroupID.Int32)
+		poll.SlotifyGroupID = &slotifyGroupID
+	}
+	if p.ChosenOptionID.Valid {
+		//nolint: gosec // id is unsigned 32 bit int
+		chosenOptionID := uint32(p.ChosenOptionID.Int32)
+		poll.ChosenOptionID = &chosenOptionID
+	}
+	if p.EventID.Valid {
+		poll.EventID = &p.EventID.String
+	}
+
+	for _, o := range options {
+		poll.Options = append(poll.Options, PollOption{Id: o.ID, StartTime: o.StartTime, EndTime: o.EndTime})
+	}
+	for _, v := range votes {
+		poll.Votes = append(poll.Votes, PollVote{OptionID: v.OptionID, UserID: v.UserID, Vote: PollVoteChoice(v.Vote)})
+	}
+	tallyPollVotes(poll.Options, poll.Votes)
+
+	return poll, nil
+}
+
+// getVoterPoll gets a poll userID can vote on, an ErrPollNotFound error is returned if there is no such poll.
+func getVoterPoll(ctx context.Context, q *database.Queries, pollID uint32, userID uint32) (Poll, error) {
+	p, err := q.GetPollByID(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Poll{}, ErrPollNotFound
+		}
+		return Poll{}, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	count, err := q.CheckPollVoter(ctx, database.CheckPollVoterParams{PollID: pollID, UserID: userID})
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to check if user is a poll voter: %w", err)
+	}
+	if count != 1 {
+		return Poll{}, ErrPollNotFound
+	}
+
+	return getPoll(ctx, q, p)
+}
+
+// tallyPollVotes counts the votes for each of the options.
+func tallyPollVotes(options []PollOption, votes []PollVote) {
+	for i := range options {
+		options[i].Yes, options[i].Maybe, options[i].No = 0, 0, 0
+		for _, v := range votes {
+			if v.OptionID != options[i].Id {
+				continue
+			}
+			switch v.Vote {
+			case Yes:
+				options[i].Yes++
+			case Maybe:
+				options[i].Maybe++
+			case No:
+				options[i].No++
+			}
+		}
+	}
+}
+
+// toPollOptionTallies converts tallied options into their notification form.
+func toPollOptionTallies(options []PollOption) []notification.PollOptionTally {
+	tallies := make([]notification.PollOptionTally, 0, len(options))
+	for _, o := range options {
+		tallies = append(tallies, notification.PollOptionTally{
+			OptionID: o.Id,
+			Yes:      o.Yes,
+			Maybe:    o.Maybe,
+			No:       o.No,
+		})
+	}
+	return tallies
+}
+
+// bestPollOption returns the option with the most support from tallied options, yes votes count twice as
+// much as maybes. Ties go to the option fewer voters can't make, then to the earliest. False is returned if
+// there are no options.
+func bestPollOption(options []PollOption) (PollOption, bool) {
+	if len(options) == 0 {
+		return PollOption{}, false
+	}
+
+	score := func(o PollOption) int {
+		return o.Yes*pollVoteWeights[Yes] + o.Maybe*pollVoteWeights[Maybe] + o.No*pollVoteWeights[No]
+	}
+	best := options[0]
+	for _, o := range options[1:] {
+		switch {
+		case score(o) != score(best):
+			if score(o) > score(best) {
+				best = o
+			}
+		case o.No != best.No:
+			if o.No < best.No {
+				best = o
+			}
+		case o.StartTime.Before(best.StartTime):
+			best = o
+		}
+	}
+	return best, true
+}
+
+// pollSubject returns the subject of a poll's meeting, empty if it has none.
+func pollSubject(poll Poll) string {
+	if poll.Event.Subject == nil {
+		return ""
+	}
+	return *poll.Event.Subject
+}
+
+// votePoll saves userID's votes on an open poll in the transaction of qtx, and notifies its other voters with
+// the new tally. The poll is returned with the votes. An ErrPollClosed error is returned if the poll has closed,
+// is being closed or its meeting has already been chosen.
+func votePoll(ctx context.Context, qtx *database.Queries, poll Poll, userID uint32, votes []PollVoteBody,
+	now time.Time,
+) (Poll, error) {
+	// The poll is locked until the votes are committed, so they are either counted when it closes or rejected
+	open, err := qtx.LockPollForVote(ctx, database.LockPollForVoteParams{ID: poll.Id, Now: now})
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to lock poll: %w", err)
+	}
+	if open != 1 {
+		return Poll{}, ErrPollClosed
+	}
+
+	optionIDs := make(map[uint32]struct{}, len(poll.Options))
+	for _, o := range poll.Options {
+		optionIDs[o.Id] = struct{}{}
+	}
+
+	for _, v := range votes {
+		if _, ok := optionIDs[v.OptionID]; !ok {
+			return Poll{}, fmt.Errorf("%w: slot %d is not in the poll", ErrInvalidPoll, v.OptionID)
+		}
+		if _, ok := pollVoteWeights[v.Vote]; !ok {
+			return Poll{}, fmt.Errorf("%w: unknown vote %s", ErrInvalidPoll, v.Vote)
+		}
+		if _, err := qtx.UpsertPollVote(ctx, database.UpsertPollVoteParams{
+			OptionID:  v.OptionID,
+			UserID:    userID,
+			Vote:      database.PollVoteVote(v.Vote),
+			UpdatedAt: now,
+		}); err != nil {
+			return Poll{}, fmt.Errorf("failed to save poll vote: %w", err)
+		}
+	}
+
+	dbVotes, err := qtx.ListPollVotes(ctx, poll.Id)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to list poll votes: %w", err)
+	}
+	poll.Votes = make([]PollVote, 0, len(dbVotes))
+	for _, v := range dbVotes {
+		poll.Votes = append(poll.Votes, PollVote{OptionID: v.OptionID, UserID: v.UserID, Vote: PollVoteChoice(v.Vote)})
+	}
+	tallyPollVotes(poll.Options, poll.Votes)
+
+	notifyIDs := make([]uint32, 0, len(poll.Voters))
+	for _, id := range poll.Voters {
+		if id != userID {
+			notifyIDs = append(notifyIDs, id)
+		}
+	}
+	notif, err := notification.NewNotificationParams(notification.PollVoteCast{
+		PollID:  poll.Id,
+		UserID:  userID,
+		Subject: pollSubject(poll),
+		Tally:   toPollOptionTallies(poll.Options),
+	}, now)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to create poll vote notification: %w", err)
+	}
+	// Every vote is streamed, so they don't share a key
+	if err = notification.Enqueue(ctx, qtx, "", notifyIDs, notif); err != nil {
+		return Poll{}, fmt.Errorf("failed to enqueue poll vote notification: %w", err)
+	}
+
+	return poll, nil
+}
+
+// pollEvent returns the meeting to create for a poll in option, its voters are added to the attendees.
+func pollEvent(ctx context.Context, db *database.Database, poll Poll, option PollOption) (CalendarEvent, error) {
+	event := poll.Event
+	start := option.StartTime.UTC().Format(CalendarEventTimeLayout)
+	end := option.EndTime.UTC().Format(CalendarEventTimeLayout)
+	event.StartTime, event.EndTime = &start, &end
+
+	attendees := make(map[openapi_types.Email]struct{}, len(event.Attendees))
+	for _, a := range event.Attendees {
+		attendees[a.Email] = struct{}{}
+	}
+	event.Attendees = append([]Attendee{}, event.Attendees...)
+	for _, voterID := range poll.Voters {
+		if voterID == poll.OrganiserID {
+			continue
+		}
+		u, err := db.GetUserByID(ctx, voterID)
+		if err != nil {
+			return CalendarEvent{}, fmt.Errorf("failed to get poll voter: %w", err)
+		}
+		email := openapi_types.Email(u.Email)
+		if _, ok := attendees[email]; ok {
+			continue
+		}
+		attendeeType := Required
+		attendees[email] = struct{}{}
+		event.Attendees = append(event.Attendees, Attendee{Email: email, AttendeeType: &attendeeType})
+	}
+
+	return event, nil
+}
+
+// closePoll closes an open or failed poll, creating its meeting in the organiser's calendar in the option with
+// id optionID, or the best option if it is nil. If an earlier attempt to close the poll created the meeting,
+// it is kept instead. An ErrPollClosed error is returned if the poll has already closed or is being closed
+// elsewhere.
+func (s Server) closePoll(ctx context.Context, logger *zap.SugaredLogger, pollID uint32, optionID *uint32,
+) (Poll, error) {
+	now := time.Now().UTC()
+
+	// Claim the poll so it isn't closed twice, eg. by its organiser and the cron job
+	claimed, err := s.DB.ClaimPollClose(ctx, database.ClaimPollCloseParams{
+		ClosingUntil: sql.NullTime{Time: now.Add(PollCloseLease), Valid: true},
+		ID:           pollID,
+		Now:          sql.NullTime{Time: now, Valid: true},
+	})
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to claim poll to close: %w", err)
+	}
+	if claimed != 1 {
+		return Poll{}, ErrPollClosed
+	}
+
+	poll, err := s.closeClaimedPoll(ctx, logger, pollID, optionID, now)
+	if err != nil {
+		if _, releaseErr := s.DB.ReleasePollClose(ctx, pollID); releaseErr != nil {
+			logger.Error("failed to release poll after failing to close it", zap.Error(releaseErr))
+		}
+		return Poll{}, err
+	}
+	return poll, nil
+}
+
+// closeClaimedPoll is closePoll once the poll has been claimed.
+func (s Server) closeClaimedPoll(ctx context.Context, logger *zap.SugaredLogger, pollID uint32, optionID *uint32,
+	now time.Time,
+) (Poll, error) {
+	p, err := s.DB.GetPollByID(ctx, pollID)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to get poll: %w", err)
+	}
+	poll, err := getPoll(ctx, &s.DB.Queries, p)
+	if err != nil {
+		return Poll{}, err
+	}
+
+	option, eventID, err := s.pollMeeting(ctx, logger, poll, optionID)
+	if err != nil {
+		return Poll{}, err
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to start db transaction: %w", err)
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	rows, err := qtx.ClosePoll(ctx, database.ClosePollParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		ChosenOptionID: sql.NullInt32{Int32: int32(option.Id), Valid: true},
+		EventID:        eventID,
+		ClosedAt:       sql.NullTime{Time: now, Valid: true},
+		ID:             pollID,
+	})
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to close poll: %w", err)
+	}
+	if rows != 1 {
+		return Poll{}, fmt.Errorf("failed to close poll: %w",
+			database.WrongNumberSQLRowsError{ActualRows: rows, ExpectedRows: []int64{1}})
+	}
+
+	notif, err := notification.NewNotificationParams(notification.PollClosed{
+		PollID:   pollID,
+		OptionID: option.Id,
+		EventID:  eventID.String,
+		Subject:  pollSubject(poll),
+		Start:    option.StartTime,
+		End:      option.EndTime,
+	}, now)
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to create poll closed notification: %w", err)
+	}
+	if err = notification.Enqueue(ctx, qtx, notification.IdempotencyKey(notif.Kind, pollID), poll.Voters,
+		notif); err != nil {
+		return Poll{}, fmt.Errorf("failed to enqueue poll closed notification: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return Poll{}, fmt.Errorf("failed to commit db transaction: %w", err)
+	}
+
+	poll.Status = Closed
+	poll.ChosenOptionID = &option.Id
+	if eventID.Valid {
+		poll.EventID = &eventID.String
+	}
+	return poll, nil
+}
+
+// pollMeeting creates a claimed poll's meeting in the option with id optionID, or the best option if it is nil,
+// and returns the option and the meeting's event id. The meeting is recorded on the poll as soon as it is
+// created, so if closing the poll then fails the meeting that was created is returned instead of creating
+// another.
+func (s Server) pollMeeting(ctx context.Context, logger *zap.SugaredLogger, poll Poll, optionID *uint32,
+) (PollOption, sql.NullString, error) {
+	if poll.ChosenOptionID != nil {
+		for _, o := range poll.Options {
+			if o.Id == *poll.ChosenOptionID {
+				logger.Info("using the meeting created by an earlier attempt to close the poll")
+				var eventID sql.NullString
+				if poll.EventID != nil {
+					eventID = sql.NullString{String: *poll.EventID, Valid: true}
+				}
+				return o, eventID, nil
+			}
+		}
+		return PollOption{}, sql.NullString{}, fmt.Errorf("%w: meeting's slot is not in the poll", ErrInvalidPoll)
+	}
+
+	option, ok := bestPollOption(poll.Options)
+	if optionID != nil {
+		ok = false
+		for _, o := range poll.Options {
+			if o.Id == *optionID {
+				option, ok = o, true
+			}
+		}
+	}
+	if !ok {
+		return PollOption{}, sql.NullString{}, fmt.Errorf("%w: slot is not in the poll", ErrInvalidPoll)
+	}
+
+	event, err := pollEvent(ctx, s.DB, poll, option)
+	if err != nil {
+		return PollOption{}, sql.NullString{}, err
+	}
+
+	calendar, err := s.CalendarProvider(ctx, poll.OrganiserID)
+	if err != nil {
+		return PollOption{}, sql.NullString{},
+			fmt.Errorf("%w: failed to create calendar provider: %w", ErrPollMeetingNotCreated, err)
+	}
+	createdEvent, err := s.createCalendarEvent(ctx, logger, calendar, poll.OrganiserID, event)
+	if err != nil {
+		return PollOption{}, sql.NullString{}, fmt.Errorf("%w: %w", ErrPollMeetingNotCreated, err)
+	}
+	var eventID sql.NullString
+	if createdEvent.Id != nil {
+		eventID = sql.NullString{String: *createdEvent.Id, Valid: true}
+	}
+
+	if _, err = s.DB.SetPollMeeting(ctx, database.SetPollMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		ChosenOptionID: sql.NullInt32{Int32: int32(option.Id), Valid: true},
+		EventID:        eventID,
+		ID:             poll.Id,
+	}); err != nil {
+		return PollOption{}, sql.NullString{}, fmt.Errorf("failed to record poll meeting: %w", err)
+	}
+	return option, eventID, nil
+}
+
+// retryPollCloseLater records a failed attempt by ClosePollsPastDeadline to close a poll, so it waits before
+// trying again. The poll is marked failed once it has been tried maxPollCloseAttempts times, true is returned
+// if it was.
+func (s Server) retryPollCloseLater(ctx context.Context, pollID uint32, now time.Time) (bool, error) {
+	p, err := s.DB.GetPollByID(ctx, pollID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	attempts := p.CloseAttempts + 1
+	status := database.PollStatusOpen
+	if attempts >= maxPollCloseAttempts {
+		status = database.PollStatusFailed
+	}
+
+	if _, err = s.DB.RetryPollCloseLater(ctx, database.RetryPollCloseLaterParams{
+		CloseAttempts:      attempts,
+		NextCloseAttemptAt: sql.NullTime{Time: now.Add(pollCloseRetryDelay << (attempts - 1)), Valid: true},
+		Status:             status,
+		ID:                 pollID,
+	}); err != nil {
+		return false, fmt.Errorf("failed to record failed poll close: %w", err)
+	}
+	return status == database.PollStatusFailed, nil
+}
+
+// ClosePollsPastDeadline closes the open polls whose deadline has passed, creating their meetings in the best
+// options. A poll that can't be closed stays open and is tried again later, backing off after each attempt,
+// until it is marked failed after maxPollCloseAttempts.
+func (s Server) ClosePollsPastDeadline(ctx context.Context) {
+	now := time.Now().UTC()
+
+	pollIDs, err := s.DB.ListPollsPastDeadline(ctx, database.ListPollsPastDeadlineParams{
+		Now:   now,
+		Limit: pollCloseBatchSize,
+	})
+	if err != nil {
+		s.Logger.Error("failed to list polls past their deadline", zap.Error(err))
+		return
+	}
+
+	for _, pollID := range pollIDs {
+		logger := s.Logger.With(zap.Uint32("poll_id", pollID))
+
+		if _, err = s.closePoll(ctx, logger, pollID, nil); err == nil || errors.Is(err, ErrPollClosed) {
+			continue
+		}
+		logger.Error("failed to close poll past its deadline", zap.Error(err))
+
+		failed, retryErr := s.retryPollCloseLater(ctx, pollID, now)
+		if retryErr != nil {
+			logger.Error("failed to record failed poll close", zap.Error(retryErr))
+			continue
+		}
+		if failed {
+			logger.Error("giving up closing poll past its deadline, its organiser has to close it")
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"go.uber.org/zap"
+)
+
+// (POST /api/polls) Publish a poll of suggested slots for a meeting.
+func (s Server) PostAPIPolls(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 4*database.DatabaseTimeout)
+	defer cancel()
+
+	var body PostAPIPollsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	if err := validatePollCreateBody(body, now); err != nil {
+		logger.Error("invalid poll", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The meeting is created in the organiser's time zone unless the request says otherwise
+	loc, err := requestLocation(ctx, s.DB, userID, body.Event.TimeZone)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTimeZone) {
+			logger.Error("invalid poll event time zone", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.Error("failed to get user's time zone", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get user's time zone")
+		return
+	}
+	timeZone := loc.String()
+	body.Event.TimeZone = &timeZone
+
+	voterIDs, err := pollVoterIDs(ctx, s.DB, userID, body)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPoll) || errors.Is(err, ErrNotSlotifyGroupMember) {
+			logger.Error("invalid poll voters", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, ErrSlotifyGroupForbidden) {
+			logger.Error("member without permission attempted to "+string(actionCreateGroupPoll), zap.Error(err))
+			sendError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		logger.Error("failed to get poll voters", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create poll")
+		return
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create poll")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	pollID, err := createPoll(ctx, qtx, userID, body, voterIDs, now)
+	if err != nil {
+		logger.Error("failed to create poll", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create poll")
+		return
+	}
+
+	var subject string
+	if body.Event.Subject != nil {
+		subject = *body.Event.Subject
+	}
+	notif, err := notification.NewNotificationParams(notification.PollCreated{
+		PollID:      pollID,
+		OrganiserID: userID,
+		Subject:     subject,
+		Deadline:    body.Deadline.UTC(),
+	}, now)
+	if err == nil {
+		err = notification.Enqueue(ctx, qtx, notification.IdempotencyKey(notif.Kind, pollID), voterIDs[1:], notif)
+	}
+	if err != nil {
+		logger.Error("failed to enqueue poll created notification", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create poll")
+		return
+	}
+
+	poll, err := getVoterPoll(ctx, qtx, pollID, userID)
+	if err != nil {
+		logger.Error("failed to get created poll", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create poll")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to create poll")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusCreated, poll)
+}
+
+// (GET /api/polls/me) Get the polls the user can vote on, including those they organised.
+func (s Server) GetAPIPollsMe(w http.ResponseWriter, r *http.Request, params GetAPIPollsMeParams) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 4*database.DatabaseTimeout)
+	defer cancel()
+
+	var status database.NullPollStatus
+	if params.Status != nil {
+		status = database.NullPollStatus{PollStatus: database.PollStatus(*params.Status), Valid: true}
+	}
+
+	dbPolls, err := s.DB.ListUserPolls(ctx, database.ListUserPollsParams{
+		UserID: userID,
+		Status: status,
+	})
+	if err != nil {
+		logger.Error("failed to list user's polls", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get polls")
+		return
+	}
+
+	polls := make([]Poll, 0, len(dbPolls))
+	for _, p := range dbPolls {
+		poll, err := getPoll(ctx, &s.DB.Queries, p)
+		if err != nil {
+			logger.Error("failed to get poll", zap.Uint32("poll_id", p.ID), zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "Failed to get polls")
+			return
+		}
+		polls = append(polls, poll)
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, polls)
+}
+
+// (GET /api/polls/{pollID}) Get a poll by id.
+func (s Server) GetAPIPollsPollID(w http.ResponseWriter, r *http.Request, pollID uint32) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("poll_id", pollID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*database.DatabaseTimeout)
+	defer cancel()
+
+	poll, err := getVoterPoll(ctx, &s.DB.Queries, pollID, userID)
+	if err != nil {
+		if errors.Is(err, ErrPollNotFound) {
+			sendError(w, http.StatusNotFound, "Poll not found.")
+			return
+		}
+		logger.Error("failed to get poll", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get poll")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, poll)
+}
+
+// (PUT /api/polls/{pollID}/votes) Vote on the slots of a poll.
+func (s Server) PutAPIPollsPollIDVotes(w http.ResponseWriter, r *http.Request, pollID uint32) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("poll_id", pollID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPIPollsPollIDVotesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to vote")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	poll, err := getVoterPoll(ctx, qtx, pollID, userID)
+	if err != nil {
+		if errors.Is(err, ErrPollNotFound) {
+			sendError(w, http.StatusNotFound, "Poll not found.")
+			return
+		}
+		logger.Error("failed to get poll", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to vote")
+		return
+	}
+
+	if poll, err = votePoll(ctx, qtx, poll, userID, body.Votes, time.Now().UTC()); err != nil {
+		if errors.Is(err, ErrPollClosed) || errors.Is(err, ErrInvalidPoll) {
+			logger.Error("invalid poll vote", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.Error("failed to vote on poll", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to vote")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to vote")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, poll)
+}
+
+// (POST /api/polls/{pollID}/close) Close a poll, creating the meeting in the organiser's calendar.
+func (s Server) PostAPIPollsPollIDClose(w http.ResponseWriter, r *http.Request, pollID uint32) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("poll_id", pollID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
+	defer cancel()
+
+	var body PostAPIPollsPollIDCloseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	poll, err := getVoterPoll(ctx, &s.DB.Queries, pollID, userID)
+	if err != nil {
+		if errors.Is(err, ErrPollNotFound) {
+			sendError(w, http.StatusNotFound, "Poll not found.")
+			return
+		}
+		logger.Error("failed to get poll", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to close poll")
+		return
+	}
+
+	if poll.OrganiserID != userID {
+		logger.Error("user who isn't the organiser attempted to close poll")
+		sendError(w, http.StatusForbidden, "Only the organiser can close a poll.")
+		return
+	}
+
+	if poll, err = s.closePoll(ctx, logger, pollID, body.OptionID); err != nil {
+		switch {
+		case errors.Is(err, ErrPollClosed) || errors.Is(err, ErrInvalidPoll):
+			logger.Error("invalid poll close", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrPollMeetingNotCreated):
+			logger.Error("failed to create poll meeting", zap.Error(err))
+			sendError(w, http.StatusBadGateway, "Failed to create the meeting")
+		default:
+			logger.Error("failed to close poll", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "Failed to close poll")
+		}
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, poll)
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/scheduler"
+)
+
+// ErrInvalidUserPreferences is returned when user preferences can't be saved or used.
+var ErrInvalidUserPreferences = errors.New("invalid user preferences")
+
+// weekdays maps the openapi weekdays onto time.Weekday.
+// nolint: gochecknoglobals // immutable map, wont change at runtime
+var weekdays = map[Weekday]time.Weekday{
+	Monday:    time.Monday,
+	Tuesday:   time.Tuesday,
+	Wednesday: time.Wednesday,
+	Thursday:  time.Thursday,
+	Friday:    time.Friday,
+	Saturday:  time.Saturday,
+	Sunday:    time.Sunday,
+}
+
+// schedulingPreferences are a user's preferences in the form used by the scheduler, they are in the user's
+// time zone.
+type schedulingPreferences struct {
+	workingHours      scheduler.WorkingHours
+	lunch             scheduler.DailyWindow
+	maxMeetingsPerDay int
+}
+
+// parseTimeOfDay parses an 'HH:MM' time from the API or an 'HH:MM:SS' MySQL TIME.
+func parseTimeOfDay(s string) (time.Time, error) {
+	for _, layout := range []string{"15:04", time.TimeOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: invalid time of day '%s'", ErrInvalidUserPreferences, s)
+}
+
+// parseDailyWindow parses a start and end time of day, the end must be after the start.
+func parseDailyWindow(start, end string) (scheduler.DailyWindow, error) {
+	s, err := parseTimeOfDay(start)
+	if err != nil {
+		return scheduler.DailyWindow{}, err
+	}
+	e, err := parseTimeOfDay(end)
+	if err != nil {
+		return scheduler.DailyWindow{}, err
+	}
+	if !e.After(s) {
+		return scheduler.DailyWindow{},
+			fmt.Errorf("%w: '%s' must be after '%s'", ErrInvalidUserPreferences, end, start)
+	}
+	return scheduler.DailyWindow{
+		Start: scheduler.NewTimeOfDay(s.Hour(), s.Minute()),
+		End:   scheduler.NewTimeOfDay(e.Hour(), e.Minute()),
+	}, nil
+}
+
+// formatTimeOfDay formats a MySQL TIME as 'HH:MM'.
+func formatTimeOfDay(s string) string {
+	t, err := parseTimeOfDay(s)
+	if err != nil {
+		return s
+	}
+	return t.Format("15:04")
+}
+
+// toUpsertUserPreferencesParams validates a preferences body and converts it into DB params.
+func toUpsertUserPreferencesParams(userID uint32,
+	body UserPreferencesBody,
+) (database.UpsertUserPreferencesParams, error) {
+	if _, err := parseDailyWindow(body.WorkingHoursStart, body.WorkingHoursEnd); err != nil {
+		return database.UpsertUserPreferencesParams{}, err
+	}
+	if _, err := parseDailyWindow(body.LunchStartTime, body.LunchEndTime); err != nil {
+		return database.UpsertUserPreferencesParams{}, err
+	}
+	if len(body.WorkingDays) == 0 {
+		return database.UpsertUserPreferencesParams{},
+			fmt.Errorf("%w: at least one working day is required", ErrInvalidUserPreferences)
+	}
+	days := make([]string, 0, len(body.WorkingDays))
+	for _, d := range body.WorkingDays {
+		if _, ok := weekdays[d]; !ok {
+			return database.UpsertUserPreferencesParams{},
+				fmt.Errorf("%w: invalid working day '%s'", ErrInvalidUserPreferences, d)
+		}
+		days = append(days, string(d))
+	}
+
+	var maxMeetings sql.NullInt32
+	if body.MaxMeetingsPerDay != nil {
+		if *body.MaxMeetingsPerDay > math.MaxInt32 {
+			return database.UpsertUserPreferencesParams{},
+				fmt.Errorf("%w: max meetings per day is too large", ErrInvalidUserPreferences)
+		}
+		//nolint: gosec // checked against math.MaxInt32 above
+		maxMeetings = sql.NullInt32{Int32: int32(*body.MaxMeetingsPerDay), Valid: true}
+	}
+
+	return database.UpsertUserPreferencesParams{
+		UserID:            userID,
+		LunchStartTime:    body.LunchStartTime,
+		LunchEndTime:      body.LunchEndTime,
+		WorkingHoursStart: body.WorkingHoursStart,
+		WorkingHoursEnd:   body.WorkingHoursEnd,
+		WorkingDays:       strings.Join(days, ","),
+		MaxMeetingsPerDay: maxMeetings,
+	}, nil
+}
+
+// toUserPreferences converts DB user preferences into their API form.
+func toUserPreferences(p database.GetUserPreferencesRow) UserPreferences {
+	days := []Weekday{}
+	for _, d := range strings.Split(p.WorkingDays, ",") {
+		if d != "" {
+			days = append(days, Weekday(d))
+		}
+	}
+
+	prefs := UserPreferences{
+		UserID:            p.UserID,
+		LunchStartTime:    formatTimeOfDay(p.LunchStartTime),
+		LunchEndTime:      formatTimeOfDay(p.LunchEndTime),
+		WorkingHoursStart: formatTimeOfDay(p.WorkingHoursStart),
+		WorkingHoursEnd:   formatTimeOfDay(p.WorkingHoursEnd),
+		WorkingDays:       days,
+		TimeZone:          p.TimeZone,
+	}
+	if p.MaxMeetingsPerDay.Valid {
+		//nolint: gosec // only non-negative values are stored
+		maxMeetings := uint32(p.MaxMeetingsPerDay.Int32)
+		prefs.MaxMeetingsPerDay = &maxMeetings
+	}
+	return prefs
+}
+
+// newSchedulingPreferences converts DB user preferences into the form used by the scheduler.
+func newSchedulingPreferences(p database.GetUserPreferencesRow) (schedulingPreferences, error) {
+	workingHours, err := parseDailyWindow(p.WorkingHoursStart, p.WorkingHoursEnd)
+	if err != nil {
+		return schedulingPreferences{}, err
+	}
+	lunch, err := parseDailyWindow(p.LunchStartTime, p.LunchEndTime)
+	if err != nil {
+		return schedulingPreferences{}, err
+	}
+
+	days := []time.Weekday{}
+	for _, d := range strings.Split(p.WorkingDays, ",") {
+		if weekday, ok := weekdays[Weekday(d)]; ok {
+			days = append(days, weekday)
+		}
+	}
+
+	prefs := schedulingPreferences{
+		workingHours: scheduler.WorkingHours{
+			Start: workingHours.Start,
+			End:   workingHours.End,
+			Days:  days,
+		},
+		lunch: lunch,
+	}
+	if p.MaxMeetingsPerDay.Valid {
+		prefs.maxMeetingsPerDay = int(p.MaxMeetingsPerDay.Int32)
+	}
+	return prefs, nil
+}
+
+// getSchedulingPreferences gets a user's scheduling preferences, nil is returned if they have none.
+func getSchedulingPreferences(ctx context.Context, db *database.Database,
+	userID uint32,
+) (*schedulingPreferences, error) {
+	p, err := db.GetUserPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			//nolint: nilnil // having no preferences isn't an error
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+
+	prefs, err := newSchedulingPreferences(p)
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"go.uber.org/zap"
+)
+
+// (GET /api/users/me/preferences).
+func (s Server) GetAPIUsersMePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	prefs, err := s.DB.GetUserPreferences(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			logger.Error("preferences api: user has no preferences", zap.Error(err))
+			sendError(w, http.StatusNotFound, "preferences api: user has no preferences")
+		case errors.Is(err, context.DeadlineExceeded):
+			logger.Error("preferences api: query timed out", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "preferences api: query timed out")
+		default:
+			logger.Error("preferences api: failed to get preferences", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "preferences api: failed to get preferences")
+		}
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, toUserPreferences(prefs))
+}
+
+// (PUT /api/users/me/preferences).
+func (s Server) PutAPIUsersMePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPIUsersMePreferencesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	params, err := toUpsertUserPreferencesParams(userID, body)
+	if err != nil {
+		logger.Error("preferences api: invalid preferences", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err = s.DB.UpsertUserPreferences(ctx, params); err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			logger.Error("preferences api: query timed out", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "preferences api: query timed out")
+		default:
+			logger.Error("preferences api: failed to save preferences", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "preferences api: failed to save preferences")
+		}
+		return
+	}
+
+	// Read back with the user's time zone, which the preferences are in
+	prefs, err := s.DB.GetUserPreferences(ctx, userID)
+	if err != nil {
+		logger.Error("preferences api: failed to get saved preferences", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "preferences api: failed to get saved preferences")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, toUserPreferences(prefs))
+}
+
+// (DELETE /api/users/me/preferences).
+func (s Server) DeleteAPIUsersMePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	rowsDeleted, err := s.DB.DeleteUserPreferences(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			logger.Error("preferences api: query timed out", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "preferences api: query timed out")
+		default:
+			logger.Error("preferences api: failed to delete preferences", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "preferences api: failed to delete preferences")
+		}
+		return
+	}
+
+	if rowsDeleted != 1 {
+		err = database.WrongNumberSQLRowsError{
+			ActualRows:   rowsDeleted,
+			ExpectedRows: []int64{1},
+		}
+		logger.Error("preferences api: user has no preferences", zap.Error(err))
+		sendError(w, http.StatusNotFound, "preferences api: user has no preferences")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, "preferences deleted successfully")
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/scheduler"
+)
+
+// ErrInvalidRecurrence is returned when a recurrence can't be used to create a series or find slots.
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// ErrOccurrenceStartRequired is returned when one occurrence of a recurring meeting is rescheduled
+// without saying which.
+var ErrOccurrenceStartRequired = errors.New("occurrence start is required to reschedule one occurrence of a series")
+
+// recurrenceFrequencies maps recurrence patterns onto the frequencies used by the scheduler.
+// nolint: gochecknoglobals // immutable map, wont change at runtime
+var recurrenceFrequencies = map[RecurrencePattern]scheduler.Frequency{
+	Daily:   scheduler.FrequencyDaily,
+	Weekly:  scheduler.FrequencyWeekly,
+	Monthly: scheduler.FrequencyMonthly,
+}
+
+// validateRecurrence checks the parts of a recurrence the openapi spec can't.
+func validateRecurrence(r Recurrence) error {
+	if _, ok := recurrenceFrequencies[r.Pattern]; !ok {
+		return fmt.Errorf("%w: unknown pattern %s", ErrInvalidRecurrence, r.Pattern)
+	}
+	if r.Occurrences != nil && r.EndDate != nil {
+		return fmt.Errorf("%w: only one of occurrences and endDate can be set", ErrInvalidRecurrence)
+	}
+	if r.DaysOfWeek != nil && len(*r.DaysOfWeek) > 0 && r.Pattern != Weekly {
+		return fmt.Errorf("%w: daysOfWeek can only be set for a weekly pattern", ErrInvalidRecurrence)
+	}
+	return nil
+}
+
+// toSchedulerRecurrence converts a recurrence into the form used by the scheduler, at most
+// scheduler.MaxOccurrences are checked.
+func toSchedulerRecurrence(r Recurrence) scheduler.Recurrence {
+	sr := scheduler.Recurrence{Frequency: recurrenceFrequencies[r.Pattern]}
+	if r.Interval != nil {
+		sr.Interval = int(*r.Interval)
+	}
+	if r.DaysOfWeek != nil {
+		for _, d := range *r.DaysOfWeek {
+			if weekday, ok := weekdays[d]; ok {
+				sr.Weekdays = append(sr.Weekdays, weekday)
+			}
+		}
+	}
+	if r.Occurrences != nil {
+		sr.Count = min(int(*r.Occurrences), scheduler.MaxOccurrences)
+	}
+	if r.EndDate != nil {
+		// Occurrences can start at any time on the end date
+		sr.Until = r.EndDate.Time.UTC().AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return sr
+}
+
+// seriesFreeBusyEnd returns when free/busy is needed until to check every occurrence of a series starting
+// before end. It can be after the last occurrence, a monthly series on the 31st skips up to every other month.
+func seriesFreeBusyEnd(r scheduler.Recurrence, end time.Time) time.Time {
+	count := r.Count
+	if count == 0 {
+		count = scheduler.DefaultOccurrences
+	}
+	interval := max(r.Interval, 1)
+
+	var seriesEnd time.Time
+	switch r.Frequency {
+	case scheduler.FrequencyDaily:
+		seriesEnd = end.AddDate(0, 0, count*interval)
+	case scheduler.FrequencyWeekly:
+		seriesEnd = end.AddDate(0, 0, count*interval*7)
+	case scheduler.FrequencyMonthly:
+		seriesEnd = end.AddDate(0, 2*count*interval, 0)
+	}
+	if !r.Until.IsZero() && r.Until.Before(seriesEnd) {
+		seriesEnd = r.Until
+	}
+
+	// Occurrences follow the organiser's time zone, which can move them by up to a day from UTC
+	return seriesEnd.Add(24 * time.Hour)
+}
@@ -2,15 +2,16 @@ package api
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
 	"github.com/avast/retry-go"
-	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
-	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
-	"github.com/microsoftgraph/msgraph-sdk-go/users"
+	"go.uber.org/zap"
 )
 
 // durationToISO formats a positive duration in the ISO 8601 format.
@@ -41,24 +42,26 @@ func durationToISO(duration time.Duration) string {
 
 func createSchedulingRequest(body ReschedulingCheckBodySchema,
 	meetingPref database.Meetingpreferences,
-	msftMeeting graphmodels.Eventable,
+	calendarEvent CalendarEvent,
 ) (SchedulingSlotsBodySchema, error) {
+	if calendarEvent.StartTime == nil || calendarEvent.EndTime == nil {
+		return SchedulingSlotsBodySchema{}, errors.New("calendar event is missing a start or end time")
+	}
+
 	// Create request body for scheduling slots api call
 	newReqBody := SchedulingSlotsBodySchema{}
 
 	newReqBody.IsOrganizerOptional = *body.OldMeeting.IsOrganizerOptional
-	newReqBody.MeetingName = *msftMeeting.GetSubject()
+	if calendarEvent.Subject != nil {
+		newReqBody.MeetingName = *calendarEvent.Subject
+	}
 
 	minimum := 100.0
 	newReqBody.MinimumAttendeePercentage = &minimum
 	newReqBody.Attendees = []AttendeeBase{}
 
 	// parse each attendee to attendeeBase
-	attendees, err := parseMSFTAttendees(msftMeeting)
-	if err != nil {
-		return SchedulingSlotsBodySchema{}, fmt.Errorf("failed to parse msft attendees: %w", err)
-	}
-	for _, a := range attendees {
+	for _, a := range calendarEvent.Attendees {
 		ab := AttendeeBase{
 			AttendeeType: *a.AttendeeType,
 			EmailAddress: EmailAddress{
@@ -69,12 +72,12 @@ func createSchedulingRequest(body ReschedulingCheckBodySchema,
 		newReqBody.Attendees = append(newReqBody.Attendees, ab)
 	}
 
-	startTime, err := time.Parse(time.RFC3339Nano, *msftMeeting.GetStart().GetDateTime()+"Z")
+	startTime, err := ParseCalendarEventTime(*calendarEvent.StartTime)
 	if err != nil {
 		return newReqBody, fmt.Errorf("failed in parse start time: %w", err)
 	}
 
-	endTime, err := time.Parse(time.RFC3339Nano, *msftMeeting.GetEnd().GetDateTime()+"Z")
+	endTime, err := ParseCalendarEventTime(*calendarEvent.EndTime)
 	if err != nil {
 		return newReqBody, fmt.Errorf("failed to parse end time: %w", err)
 	}
@@ -86,52 +89,41 @@ func createSchedulingRequest(body ReschedulingCheckBodySchema,
 	duration := endTime.Sub(startTime)
 	newReqBody.MeetingDuration = durationToISO(duration)
 
-	// Add time contraints
-	timeConstraint := graphmodels.NewTimeConstraint()
-	activityDomain := graphmodels.WORK_ACTIVITYDOMAIN
-	timeConstraint.SetActivityDomain(&activityDomain)
-
-	timeSlots := []graphmodels.TimeSlotable{}
-
-	timeSlot := graphmodels.NewTimeSlot()
-	//nolint: goconst // To make it a constant
-	timeZone := "GMT Standard Time"
-
-	startTimeFormatted := meetingPref.StartDateRange.Format(time.RFC3339Nano)
-	endTimeFormatted := meetingPref.EndDateRange.Format(time.RFC3339Nano)
-
-	start := graphmodels.NewDateTimeTimeZone()
-	start.SetDateTime(&startTimeFormatted)
-	start.SetTimeZone(&timeZone)
-
-	end := graphmodels.NewDateTimeTimeZone()
-	end.SetDateTime(&endTimeFormatted)
-	end.SetTimeZone(&timeZone)
-
-	timeSlot.SetStart(start)
-	timeSlot.SetEnd(end)
-
-	timeSlots = append(timeSlots, timeSlot)
+	// Moving a whole series needs a time that suits every occurrence
+	if body.OldMeeting.Scope != nil && *body.OldMeeting.Scope == Series && calendarEvent.Recurrence != nil {
+		newReqBody.Recurrence = calendarEvent.Recurrence
+	}
 
-	timeConstraint.SetTimeSlots(timeSlots)
+	// Add time contraints
+	activityDomain := "work"
+	newReqBody.TimeConstraint = TimeConstraint{
+		ActivityDomain: &activityDomain,
+		TimeSlots: []MeetingTimeSlot{
+			{Start: meetingPref.StartDateRange, End: meetingPref.EndDateRange},
+		},
+	}
 
 	return newReqBody, nil
 }
 
+// meetingTimesFinder suggests meeting times for a scheduling request.
+type meetingTimesFinder func(ctx context.Context,
+	body SchedulingSlotsBodySchema) (SchedulingSlotsSuccessResponseBody, error)
+
 func checkValidReschedulingSlotExists(ctx context.Context,
-	graph *msgraphsdkgo.GraphServiceClient,
+	findMeetingTimes meetingTimesFinder,
 	body ReschedulingCheckBodySchema,
-	msftMeeting graphmodels.Eventable,
+	calendarEvent CalendarEvent,
 	meetingPref database.Meetingpreferences,
 ) (bool, error) {
 	// Call scheduling function to check for valid slots
-	newRequest, err := createSchedulingRequest(body, meetingPref, msftMeeting)
+	newRequest, err := createSchedulingRequest(body, meetingPref, calendarEvent)
 	if err != nil {
 		return false,
 			fmt.Errorf("failed in creating request body for scheduling request: %w", err)
 	}
 
-	res, err := makeFindMeetingTimesAPICall(ctx, graph, newRequest)
+	res, err := findMeetingTimes(ctx, newRequest)
 	if err != nil {
 		return false,
 			fmt.Errorf("failed in calling find meeting times api: %w", err)
@@ -141,14 +133,14 @@ func checkValidReschedulingSlotExists(ctx context.Context,
 }
 
 func performReschedulingCheckProcess(ctx context.Context,
-	graph *msgraphsdkgo.GraphServiceClient,
+	findMeetingTimes meetingTimesFinder,
 	body ReschedulingCheckBodySchema,
-	msftMeeting graphmodels.Eventable,
+	calendarEvent CalendarEvent,
 	meetingPref database.Meetingpreferences,
 ) (map[string]bool, error) {
 	// Check if the old meeting has valid rescheduling slots
 
-	validSlots, err := checkValidReschedulingSlotExists(ctx, graph, body, msftMeeting, meetingPref)
+	validSlots, err := checkValidReschedulingSlotExists(ctx, findMeetingTimes, body, calendarEvent, meetingPref)
 	if err != nil {
 		return nil,
 			fmt.Errorf("failed to check valid rescheduling slots exists: %w", err)
@@ -227,20 +219,23 @@ func createNewMeetingsAndPrefs(ctx context.Context,
 }
 
 func processNewMeetingInfo(ctx context.Context,
-	graph *msgraphsdkgo.GraphServiceClient,
+	calendar CalendarProvider,
 	s Server,
 	msftMeetingID string,
 	userEmail string,
 ) (database.Meeting, error) {
-	// Fetch meeting data from microsft
-
-	msftMeeting, err := getUsersEvent(ctx, graph, msftMeetingID)
+	// Fetch meeting data from the owner's calendar
+	calendarEvent, err := calendar.GetEventByICalUID(ctx, msftMeetingID)
 	if err != nil {
-		return database.Meeting{}, fmt.Errorf("failed to get meeting data from microsoft: %w", err)
+		return database.Meeting{}, fmt.Errorf("failed to get meeting data from calendar provider: %w", err)
+	}
+
+	if calendarEvent.StartTime == nil {
+		return database.Meeting{}, errors.New("calendar event is missing a start time")
 	}
 
 	var startTime time.Time
-	startTime, err = time.Parse(time.RFC3339Nano, *msftMeeting.GetStart().GetDateTime()+"Z")
+	startTime, err = ParseCalendarEventTime(*calendarEvent.StartTime)
 	if err != nil {
 		return database.Meeting{}, fmt.Errorf("failed to get parse start time: %w", err)
 	}
@@ -259,28 +254,225 @@ func processNewMeetingInfo(ctx context.Context,
 	return meeting, nil
 }
 
-func getUsersEvent(ctx context.Context,
-	graph *msgraphsdkgo.GraphServiceClient,
-	msftID string,
-) (graphmodels.Eventable, error) {
-	queryFilter := "iCalUId eq '" + msftID + "'"
+// toRescheduleScope converts the scope of a reschedule request body into its DB form, the occurrence
+// start is only kept when one occurrence is moved.
+func toRescheduleScope(scope *RescheduleScope,
+	occurrenceStart *time.Time,
+) (database.ReschedulingrequestScope, sql.NullTime) {
+	if scope != nil && *scope == Series {
+		return database.ReschedulingrequestScopeSeries, sql.NullTime{}
+	}
+	if occurrenceStart == nil {
+		return database.ReschedulingrequestScopeOccurrence, sql.NullTime{}
+	}
+	return database.ReschedulingrequestScopeOccurrence, sql.NullTime{Time: occurrenceStart.UTC(), Valid: true}
+}
 
-	// Get old meeting data from microsoft
-	requestConfig := users.ItemEventsRequestBuilderGetRequestConfiguration{
-		QueryParameters: &users.ItemEventsRequestBuilderGetQueryParameters{
-			Filter: &queryFilter,
-		},
+// toRescheduleOldMeeting converts the old meeting of a DB reschedule request into its API form.
+func toRescheduleOldMeeting(meetingID uint32, msftMeetingID string,
+	meetingStartTime, timeRangeStart, timeRangeEnd time.Time,
+	scope database.ReschedulingrequestScope,
+	occurrenceStart sql.NullTime,
+) ReschedulingRequestOldMeeting {
+	oldMeeting := ReschedulingRequestOldMeeting{
+		MeetingId:        meetingID,
+		MsftMeetingID:    msftMeetingID,
+		MeetingStartTime: meetingStartTime,
+		TimeRangeStart:   timeRangeStart,
+		TimeRangeEnd:     timeRangeEnd,
+		Scope:            RescheduleScope(scope),
+	}
+	if occurrenceStart.Valid {
+		oldMeeting.OccurrenceStart = &occurrenceStart.Time
 	}
+	return oldMeeting
+}
 
-	msftMeetingRes, err := graph.Me().Events().Get(ctx, &requestConfig)
+// rescheduleTarget returns the event a reschedule request of event moves: one of its occurrences if
+// it repeats and only an occurrence is moved, the series it is an occurrence of if the series is moved,
+// and otherwise event itself.
+// ErrOccurrenceStartRequired is returned if event repeats and the occurrence to move isn't given.
+func rescheduleTarget(ctx context.Context, calendar CalendarProvider, event CalendarEvent,
+	scope database.ReschedulingrequestScope,
+	occurrenceStart sql.NullTime,
+) (CalendarEvent, error) {
+	switch {
+	case scope == database.ReschedulingrequestScopeSeries && event.SeriesMasterId != nil:
+		return calendar.GetEvent(ctx, *event.SeriesMasterId)
+	case scope == database.ReschedulingrequestScopeOccurrence && event.Recurrence != nil:
+		if !occurrenceStart.Valid || event.Id == nil {
+			return CalendarEvent{}, ErrOccurrenceStartRequired
+		}
+		return calendar.GetEventOccurrence(ctx, *event.Id, occurrenceStart.Time)
+	default:
+		return event, nil
+	}
+}
+
+// checkRescheduleTarget checks the event a new reschedule request of the meeting with msftMeetingID would
+// move can be found in the owner's calendar.
+func checkRescheduleTarget(ctx context.Context, calendar CalendarProvider, msftMeetingID string,
+	scope database.ReschedulingrequestScope,
+	occurrenceStart sql.NullTime,
+) error {
+	event, err := calendar.GetEventByICalUID(ctx, msftMeetingID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get meeting data from microsoft: %w", err)
+		return fmt.Errorf("failed to get meeting data from calendar provider: %w", err)
 	}
 
-	if msftMeetingRes != nil && msftMeetingRes.GetValue() != nil &&
-		len(msftMeetingRes.GetValue()) > 0 {
-		return msftMeetingRes.GetValue()[0], nil
+	if _, err = rescheduleTarget(ctx, calendar, event, scope, occurrenceStart); err != nil {
+		return fmt.Errorf("failed to get event to reschedule: %w", err)
 	}
+	return nil
+}
+
+// sendRescheduleTargetError responds to a request whose checkRescheduleTarget failed with err.
+func sendRescheduleTargetError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrOccurrenceStartRequired):
+		sendError(w, http.StatusBadRequest, ErrOccurrenceStartRequired.Error())
+	case errors.Is(err, ErrCalendarEventNotFound):
+		sendError(w, http.StatusBadRequest, "meeting to reschedule could not be found")
+	default:
+		sendError(w, http.StatusBadGateway, "Failed to get meeting data from calendar provider")
+	}
+}
+
+type createRescheduleRequestParams struct {
+	ctx       context.Context
+	qtx       *database.Queries
+	userID    uint32
+	ownerID   uint32
+	meetingID uint32
+	// scope is whether one occurrence or the whole series is moved, occurrenceStart says which occurrence
+	scope           database.ReschedulingrequestScope
+	occurrenceStart sql.NullTime
+	// placeholder is the meeting asked for to replace the old one, nil if the old one is only moved
+	placeholder *database.CreatePlaceholderMeetingParams
+	attendees   []int
+}
 
-	return nil, errors.New("failed to get meeting data from microsoft: returned empty array")
+// createRescheduleRequest creates a user's request to reschedule a meeting, with the meeting asked for to replace
+// it if any, and enqueues a notification to the meeting's owner, in the transaction of qtx.
+func createRescheduleRequest(p createRescheduleRequestParams) (int64, error) {
+	requestID, err := p.qtx.CreateReschedulingRequest(p.ctx, database.CreateReschedulingRequestParams{
+		RequestedBy:     p.userID,
+		CreatedAt:       time.Now(),
+		Scope:           p.scope,
+		OccurrenceStart: p.occurrenceStart,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create reschedling requested by user: %w", err)
+	}
+
+	if _, err = p.qtx.CreateRequestToMeeting(p.ctx, database.CreateRequestToMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		RequestID: uint32(requestID),
+		MeetingID: p.meetingID,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to create request to meeting link: %w", err)
+	}
+
+	if p.placeholder != nil {
+		placeholder := *p.placeholder
+		//nolint: gosec // id is unsigned 32 bit int
+		placeholder.RequestID = uint32(requestID)
+
+		var placeholderMeeting int64
+		if placeholderMeeting, err = p.qtx.CreatePlaceholderMeeting(p.ctx, placeholder); err != nil {
+			return 0, fmt.Errorf("failed to create placeholder meeting: %w", err)
+		}
+
+		for _, attendee := range p.attendees {
+			if _, err = p.qtx.CreatePlaceholderMeetingAttendee(p.ctx, database.CreatePlaceholderMeetingAttendeeParams{
+				//nolint: gosec // id is unsigned 32 bit int
+				MeetingID: uint32(placeholderMeeting),
+				//nolint: gosec // id is unsigned 32 bit int
+				UserID: uint32(attendee),
+			}); err != nil {
+				return 0, fmt.Errorf("failed to create placeholder meeting attendee: %w", err)
+			}
+		}
+	}
+
+	notif, err := notification.NewNotificationParams(notification.RescheduleRequested{
+		//nolint: gosec // id is unsigned 32 bit int
+		RequestID:   uint32(requestID),
+		MeetingID:   p.meetingID,
+		RequestedBy: p.userID,
+		NewMeeting:  p.placeholder != nil,
+	}, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	if err = notification.Enqueue(p.ctx, p.qtx, notification.IdempotencyKey(notif.Kind, requestID),
+		[]uint32{p.ownerID}, notif); err != nil {
+		return 0, fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+	return requestID, nil
+}
+
+type enqueueRescheduleAcceptedNotificationsParams struct {
+	ctx           context.Context
+	qtx           *database.Queries
+	logger        *zap.SugaredLogger
+	userID        uint32
+	req           database.GetRequestByIDRow
+	calendarEvent CalendarEvent
+	start         time.Time
+	end           time.Time
+}
+
+// enqueueRescheduleAcceptedNotifications enqueues a notification to the owner who accepted a reschedule request,
+// and one to every attendee of the moved meeting, in the transaction of qtx.
+func enqueueRescheduleAcceptedNotifications(p enqueueRescheduleAcceptedNotificationsParams) error {
+	notif, err := notification.NewNotificationParams(notification.RescheduleAccepted{
+		RequestID: p.req.RequestID,
+		MeetingID: p.req.ID,
+		Start:     p.start,
+		End:       p.end,
+	}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create owner notification: %w", err)
+	}
+
+	if err = notification.Enqueue(p.ctx, p.qtx, notification.IdempotencyKey(notif.Kind, p.req.RequestID),
+		[]uint32{p.userID}, notif); err != nil {
+		return fmt.Errorf("failed to enqueue owner notification: %w", err)
+	}
+
+	attendeeIDs := make([]uint32, 0, len(p.calendarEvent.Attendees))
+	for _, a := range p.calendarEvent.Attendees {
+		u, err := p.qtx.GetUserByEmail(p.ctx, string(a.Email))
+		if err != nil {
+			// Attendees outside Slotify aren't notified
+			p.logger.Error("failed to get attendee by email for sending notifications", zap.Error(err))
+			continue
+		}
+		attendeeIDs = append(attendeeIDs, u.ID)
+	}
+
+	rescheduled := notification.MeetingRescheduled{
+		MeetingID: p.req.ID,
+		Start:     p.start,
+		End:       p.end,
+	}
+	if p.calendarEvent.Id != nil {
+		rescheduled.EventID = *p.calendarEvent.Id
+	}
+	if p.calendarEvent.Subject != nil {
+		rescheduled.Subject = *p.calendarEvent.Subject
+	}
+
+	notif, err = notification.NewNotificationParams(rescheduled, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create attendee notification: %w", err)
+	}
+
+	if err = notification.Enqueue(p.ctx, p.qtx, notification.IdempotencyKey(notif.Kind, p.req.RequestID),
+		attendeeIDs, notif); err != nil {
+		return fmt.Errorf("failed to enqueue attendee notification: %w", err)
+	}
+	return nil
 }
@@ -5,15 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/SlotifyApp/slotify-backend/database"
-	"github.com/avast/retry-go"
-	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
-	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
-	"github.com/microsoftgraph/msgraph-sdk-go/users"
+	"github.com/SlotifyApp/slotify-backend/notification"
 	"go.uber.org/zap"
 )
 
@@ -38,27 +34,18 @@ func (s Server) PostAPIRescheduleCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
+	calendar, err := s.CalendarProvider(ctx, userID)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
 		return
 	}
 
-	var graphForOwner *msgraphsdkgo.GraphServiceClient
-	graphForOwner, err = CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
+	// Get old meeting data from the calendar provider
+	calendarEvent, err := calendar.GetEventByICalUID(ctx, body.OldMeeting.MsftMeetingID)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
-		return
-	}
-
-	// Get old meeting data from microsoft
-	msftMeeting, err := getUsersEvent(ctx, graphForOwner,
-		body.OldMeeting.MsftMeetingID)
-	if err != nil {
-		logger.Error("failed to get meeting data from microsoft", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to get meeting data from microsoft")
+		logger.Error("failed to get meeting data from calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to get meeting data from calendar provider")
 		return
 	}
 
@@ -91,16 +78,14 @@ func (s Server) PostAPIRescheduleCheck(w http.ResponseWriter, r *http.Request) {
 
 		dayTime := time.Hour * hoursInAWeek // 1 week : 24 * 7
 
-		var loc *time.Location
-		loc, err = time.LoadLocation(*msftMeeting.GetStart().GetTimeZone())
-		if err != nil {
-			logger.Error("failed to parse start time zone", zap.Error(err))
-			sendError(w, http.StatusBadGateway, "Failed to parse start time zone")
+		if calendarEvent.StartTime == nil {
+			logger.Error("calendar event is missing a start time")
+			sendError(w, http.StatusBadGateway, "Failed to parse start time")
 			return
 		}
 
 		var newStartTime time.Time
-		newStartTime, err = time.ParseInLocation(time.RFC3339Nano, *msftMeeting.GetStart().GetDateTime()+"Z", loc)
+		newStartTime, err = ParseCalendarEventTime(*calendarEvent.StartTime)
 		if err != nil {
 			logger.Error("failed to parse start time", zap.Error(err))
 			sendError(w, http.StatusBadGateway, "Failed to parse start time")
@@ -113,10 +98,16 @@ func (s Server) PostAPIRescheduleCheck(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	respBody, err := performReschedulingCheckProcess(ctx, graph, body, msftMeeting, meetingPref)
+	// The organiser's and attendees' preferences are honoured when looking for a new slot
+	findMeetingTimes := func(ctx context.Context,
+		b SchedulingSlotsBodySchema,
+	) (SchedulingSlotsSuccessResponseBody, error) {
+		return s.findMeetingTimes(ctx, userID, b)
+	}
+	respBody, err := performReschedulingCheckProcess(ctx, findMeetingTimes, body, calendarEvent, meetingPref)
 	if err != nil {
-		logger.Error("failed to make msgraph api call to findMeetings", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to process/send microsoft graph API request for findMeeting")
+		logger.Error("failed to perform rescheduling check", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to find meeting times for rescheduling check")
 		return
 	}
 
@@ -150,26 +141,19 @@ func (s Server) PostAPIRescheduleRequestReplace(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, ownerObj.ID)
+	calendar, err := s.CalendarProvider(ctx, ownerObj.ID)
 	if err != nil {
-		logger.Error("failed to create msgraph client with owner id", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
+		logger.Error("failed to create calendar provider with owner id", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
 		return
 	}
 
-	// Create Rescheduling Request
-	var requestID int64
-	err = retry.Do(func() error {
-		if requestID, err = s.DB.CreateReschedulingRequest(ctx, database.CreateReschedulingRequestParams{
-			RequestedBy: userID,
-			CreatedAt:   time.Now(),
-		}); err != nil {
-			return fmt.Errorf("failed to create reschedling requested by user: %w", err)
-		}
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
-
-	// Attach meeting preferences info to request if it exists
+	scope, occurrenceStart := toRescheduleScope(body.OldMeeting.Scope, body.OldMeeting.OccurrenceStart)
+	if err = checkRescheduleTarget(ctx, calendar, body.OldMeeting.MsftMeetingID, scope, occurrenceStart); err != nil {
+		logger.Error("failed to check the event to reschedule", zap.Error(err))
+		sendRescheduleTargetError(w, err)
+		return
+	}
 
 	// Link request to old meeting
 	var meeting database.Meeting
@@ -179,7 +163,7 @@ func (s Server) PostAPIRescheduleRequestReplace(w http.ResponseWriter, r *http.R
 
 	if errors.Is(err, sql.ErrNoRows) {
 		// Meeting info not in db, so create new meeting info
-		meeting, err = processNewMeetingInfo(ctx, graph, s,
+		meeting, err = processNewMeetingInfo(ctx, calendar, s,
 			body.OldMeeting.MsftMeetingID, string(body.OldMeeting.OwnerEmail))
 		if err != nil {
 			logger.Error("DB Creation Error: ", zap.Error(err))
@@ -192,83 +176,54 @@ func (s Server) PostAPIRescheduleRequestReplace(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Create request to meeting
-	requestToMeetingParams := database.CreateRequestToMeetingParams{
-		//nolint: gosec // id is unsigned 32 bit int
-		RequestID: uint32(requestID),
-		MeetingID: meeting.ID,
-	}
-
-	err = retry.Do(func() error {
-		if _, err = s.DB.CreateRequestToMeeting(ctx, requestToMeetingParams); err != nil {
-			return fmt.Errorf("failed to create request to meeting link: %w", err)
-		}
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
 	if err != nil {
-		logger.Error("DB Creation Error: ", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to create request to meeting link")
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to make rescheduling request")
 		return
 	}
 
-	// Create Placeholder meeting info
-
-	placeholderParams := database.CreatePlaceholderMeetingParams{
-		//nolint: gosec // id is unsigned 32 bit int
-		RequestID:      uint32(requestID),
-		Title:          body.NewMeeting.Title,
-		Location:       body.NewMeeting.Location,
-		Duration:       body.NewMeeting.MeetingDuration,
-		StartDateRange: body.NewMeeting.StartRangeTime,
-		EndDateRange:   body.NewMeeting.EndRangeTime,
-	}
-
-	var placeholderMeeting int64
-	err = retry.Do(func() error {
-		if placeholderMeeting, err = s.DB.CreatePlaceholderMeeting(ctx, placeholderParams); err != nil {
-			return fmt.Errorf("failed to create placeholder meeting: %w", err)
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
 		}
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
+	}()
+
+	requestID, err := createRescheduleRequest(createRescheduleRequestParams{
+		ctx:       ctx,
+		qtx:       s.DB.WithTx(tx),
+		userID:    userID,
+		ownerID:   ownerObj.ID,
+		meetingID: meeting.ID,
+
+		scope:           scope,
+		occurrenceStart: occurrenceStart,
+		placeholder: &database.CreatePlaceholderMeetingParams{
+			Title:          body.NewMeeting.Title,
+			Location:       body.NewMeeting.Location,
+			Duration:       body.NewMeeting.MeetingDuration,
+			StartDateRange: body.NewMeeting.StartRangeTime,
+			EndDateRange:   body.NewMeeting.EndRangeTime,
+		},
+		attendees: body.NewMeeting.Attendees,
+	})
 	if err != nil {
-		logger.Error("DB Creation Error: ", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to create placeholder meeting")
+		logger.Error("failed to make reschedule request", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to make rescheduling request")
 		return
 	}
 
-	// For each attendee, create placeholder attendee row
-	for _, attendee := range body.NewMeeting.Attendees {
-		attendeeParams := database.CreatePlaceholderMeetingAttendeeParams{
-			//nolint: gosec // id is unsigned 32 bit int
-			MeetingID: uint32(placeholderMeeting),
-			//nolint: gosec // id is unsigned 32 bit int
-			UserID: uint32(attendee),
-		}
-
-		err = retry.Do(func() error {
-			if _, err = s.DB.CreatePlaceholderMeetingAttendee(ctx, attendeeParams); err != nil {
-				return fmt.Errorf("failed to create placeholder meeting attendee: %w", err)
-			}
-			return nil
-		}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
-	}
-
-	// Notify user of the request
-	notifParam := database.CreateNotificationParams{
-		Message: "Reschedule request for meeting for a new meeting",
-		Created: time.Now(),
-	}
-
-	err = s.NotificationService.SendNotification(ctx, s.Logger, s.DB, []uint32{ownerObj.ID}, notifParam)
-	if err != nil {
-		logger.Error("Failed to send notification for reschedule request: ", zap.Error(err))
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to make rescheduling request")
+		return
 	}
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, requestID)
 }
 
 // (POST /api/reschedule/request/single).
-// nolint: funlen // 1 line too long
+// nolint: funlen // 8 lines too long
 func (s Server) PostAPIRescheduleRequestSingle(w http.ResponseWriter, r *http.Request) {
 	// Get userid from access token
 	ctx, cancel := context.WithTimeout(r.Context(), time.Minute*3)
@@ -295,27 +250,17 @@ func (s Server) PostAPIRescheduleRequestSingle(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, ownerObj.ID)
+	calendar, err := s.CalendarProvider(ctx, ownerObj.ID)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
 		return
 	}
 
-	// Create Rescheduling Request
-	var requestID int64
-	err = retry.Do(func() error {
-		if requestID, err = s.DB.CreateReschedulingRequest(ctx, database.CreateReschedulingRequestParams{
-			RequestedBy: userID,
-			CreatedAt:   time.Now(),
-		}); err != nil {
-			return fmt.Errorf("failed to create reschedling requested by user: %w", err)
-		}
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
-	if err != nil {
-		logger.Error("failed to make reschedule request", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to make rescheduling request")
+	scope, occurrenceStart := toRescheduleScope(body.Scope, body.OccurrenceStart)
+	if err = checkRescheduleTarget(ctx, calendar, body.MsftMeetingID, scope, occurrenceStart); err != nil {
+		logger.Error("failed to check the event to reschedule", zap.Error(err))
+		sendRescheduleTargetError(w, err)
 		return
 	}
 
@@ -326,7 +271,7 @@ func (s Server) PostAPIRescheduleRequestSingle(w http.ResponseWriter, r *http.Re
 	meeting, err = s.DB.GetMeetingByMSFTID(ctx, body.MsftMeetingID)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		meeting, err = processNewMeetingInfo(ctx, graph, s, body.MsftMeetingID, string(body.OwnerEmail))
+		meeting, err = processNewMeetingInfo(ctx, calendar, s, body.MsftMeetingID, string(body.OwnerEmail))
 		if err != nil {
 			logger.Error("failed to make new meeting info", zap.Error(err))
 			sendError(w, http.StatusBadGateway, "Failed to make new meeting info")
@@ -338,34 +283,39 @@ func (s Server) PostAPIRescheduleRequestSingle(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Create request to meeting
-	requestToMeetingParams := database.CreateRequestToMeetingParams{
-		//nolint: gosec // id is unsigned 32 bit int
-		RequestID: uint32(requestID),
-		MeetingID: meeting.ID,
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to make rescheduling request")
+		return
 	}
 
-	err = retry.Do(func() error {
-		if _, err = s.DB.CreateRequestToMeeting(ctx, requestToMeetingParams); err != nil {
-			return fmt.Errorf("failed to create request to meeting link: %w", err)
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
 		}
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
+	}()
+
+	requestID, err := createRescheduleRequest(createRescheduleRequestParams{
+		ctx:       ctx,
+		qtx:       s.DB.WithTx(tx),
+		userID:    userID,
+		ownerID:   ownerObj.ID,
+		meetingID: meeting.ID,
+
+		scope:           scope,
+		occurrenceStart: occurrenceStart,
+	})
 	if err != nil {
-		logger.Error("DB Creation Error: ", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to create request to meeting link")
+		logger.Error("failed to make reschedule request", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to make rescheduling request")
 		return
 	}
 
-	// Notify user of the request
-	notifParam := database.CreateNotificationParams{
-		Message: "Reschedule request for meeting",
-		Created: time.Now(),
-	}
-
-	err = s.NotificationService.SendNotification(ctx, s.Logger, s.DB, []uint32{ownerObj.ID}, notifParam)
-	if err != nil {
-		logger.Error("Failed to send notification for reschedule request: ", zap.Error(err))
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to make rescheduling request")
+		return
 	}
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, requestID)
@@ -429,13 +379,8 @@ func (s Server) GetAPIRescheduleRequestsMe(w http.ResponseWriter, r *http.Reques
 			RequestedBy: req.RequestedBy,
 			Status:      string(req.Status),
 			NewMeeting:  &newMeeting,
-			OldMeeting: ReschedulingRequestOldMeeting{
-				MeetingId:        req.ID,
-				MsftMeetingID:    req.MsftMeetingID,
-				MeetingStartTime: req.MeetingStartTime,
-				TimeRangeStart:   req.StartDateRange,
-				TimeRangeEnd:     req.EndDateRange,
-			},
+			OldMeeting: toRescheduleOldMeeting(req.ID, req.MsftMeetingID, req.MeetingStartTime,
+				req.StartDateRange, req.EndDateRange, req.Scope, req.OccurrenceStart),
 		})
 	}
 
@@ -479,13 +424,8 @@ func (s Server) GetAPIRescheduleRequestsMe(w http.ResponseWriter, r *http.Reques
 			RequestedBy: req.RequestedBy,
 			Status:      string(req.Status),
 			NewMeeting:  &newMeeting,
-			OldMeeting: ReschedulingRequestOldMeeting{
-				MeetingId:        req.ID,
-				MsftMeetingID:    req.MsftMeetingID,
-				MeetingStartTime: req.MeetingStartTime,
-				TimeRangeStart:   req.StartDateRange,
-				TimeRangeEnd:     req.EndDateRange,
-			},
+			OldMeeting: toRescheduleOldMeeting(req.ID, req.MsftMeetingID, req.MeetingStartTime,
+				req.StartDateRange, req.EndDateRange, req.Scope, req.OccurrenceStart),
 		})
 	}
 
@@ -543,13 +483,8 @@ func (s Server) GetAPIRescheduleRequestRequestID(w http.ResponseWriter, r *http.
 		RequestedBy: req.RequestedBy,
 		Status:      string(req.Status),
 		NewMeeting:  &newMeeting,
-		OldMeeting: ReschedulingRequestOldMeeting{
-			MeetingId:        req.ID,
-			MsftMeetingID:    req.MsftMeetingID,
-			MeetingStartTime: req.MeetingStartTime,
-			TimeRangeStart:   req.StartDateRange,
-			TimeRangeEnd:     req.EndDateRange,
-		},
+		OldMeeting: toRescheduleOldMeeting(req.ID, req.MsftMeetingID, req.MeetingStartTime,
+			req.StartDateRange, req.EndDateRange, req.Scope, req.OccurrenceStart),
 	}
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, response)
@@ -575,42 +510,69 @@ func (s Server) PatchAPIRescheduleRequestRequestIDReject(w http.ResponseWriter,
 		return
 	}
 
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to decline rescheduling request")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
 	// Update status of all requests with the same meeting ID
 
-	_, err = s.DB.UpdateRequestStatusAsRejected(ctx, req.MeetingID)
+	_, err = qtx.UpdateRequestStatusAsRejected(ctx, req.MeetingID)
 	if err != nil {
 		logger.Error("failed to update status of all the requests to declined", zap.Error(err))
 		sendError(w, http.StatusBadGateway, "Failed to update status of all the requests to declined")
 		return
 	}
 
-	// Notify user of the request
-	notifParam := database.CreateNotificationParams{
-		Message: "Reschedule request rejected",
-		Created: time.Now(),
-	}
-
 	// Get request owner
-	requester, err := s.DB.GetOnlyRequestByID(ctx, req.RequestID)
+	requester, err := qtx.GetOnlyRequestByID(ctx, req.RequestID)
 	if err != nil {
 		logger.Error("Failed to get requester user: ", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to get requester user")
+		return
 	}
 
-	err = s.NotificationService.SendNotification(ctx, s.Logger, s.DB, []uint32{requester.RequestedBy}, notifParam)
+	// Notify user of the request
+	notifParam, err := notification.NewNotificationParams(notification.RescheduleRejected{
+		RequestID: req.RequestID,
+		MeetingID: req.MeetingID,
+	}, time.Now())
 	if err != nil {
-		logger.Error("Failed to send notification to requester: ", zap.Error(err))
+		logger.Error("Failed to create reschedule rejected notification: ", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to decline rescheduling request")
+		return
 	}
 
-	err = s.NotificationService.SendNotification(ctx, s.Logger, s.DB, []uint32{userID}, notifParam)
-	if err != nil {
-		logger.Error("Failed to send notification to requester: ", zap.Error(err))
+	for _, notifyID := range []uint32{requester.RequestedBy, userID} {
+		if err = notification.Enqueue(ctx, qtx, notification.IdempotencyKey(notifParam.Kind, req.RequestID, notifyID),
+			[]uint32{notifyID}, notifParam); err != nil {
+			logger.Error("Failed to enqueue reschedule rejected notification: ", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "Failed to decline rescheduling request")
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to decline rescheduling request")
+		return
 	}
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, "Successfully declined rescheduling request")
 }
 
 // (PATCH /api/reschedule/request/{requestID}/accept).
-// nolint: funlen // 1 statement too long
+// nolint: funlen // 3 statements too long
 func (s Server) PatchAPIRescheduleRequestRequestIDAccept(w http.ResponseWriter, r *http.Request, parRequestID uint32) {
 	// Get userid from access token
 	ctx, cancel := context.WithTimeout(r.Context(), time.Minute*3)
@@ -628,10 +590,10 @@ func (s Server) PatchAPIRescheduleRequestRequestIDAccept(w http.ResponseWriter,
 		return
 	}
 
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
+	calendar, err := s.CalendarProvider(ctx, userID)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
 		return
 	}
 
@@ -643,72 +605,74 @@ func (s Server) PatchAPIRescheduleRequestRequestIDAccept(w http.ResponseWriter,
 		return
 	}
 
-	queryFilter := "iCalUId eq '" + req.MsftMeetingID + "'"
-
-	// Get old meeting data from microsoft
-	requestConfig := users.ItemEventsRequestBuilderGetRequestConfiguration{
-		QueryParameters: &users.ItemEventsRequestBuilderGetQueryParameters{
-			Filter: &queryFilter,
-		},
+	// Get old meeting data from the calendar provider
+	meetingEvent, err := calendar.GetEventByICalUID(ctx, req.MsftMeetingID)
+	if err != nil || meetingEvent.Id == nil {
+		logger.Error("failed to get meeting data from calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to get meeting data from calendar provider")
+		return
 	}
 
-	var msftMeetingRes graphmodels.EventCollectionResponseable
-	msftMeetingRes, err = graph.Me().Events().Get(ctx, &requestConfig)
-	if err != nil {
-		logger.Error("failed to get meeting data from microsoft", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to get meeting data from microsoft")
+	// Only one occurrence is moved unless the request is for the whole series
+	calendarEvent, err := rescheduleTarget(ctx, calendar, meetingEvent, req.Scope, req.OccurrenceStart)
+	if err != nil || calendarEvent.Id == nil {
+		logger.Error("failed to get event to reschedule from calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to get meeting data from calendar provider")
 		return
 	}
+	movedOccurrence := calendarEvent.SeriesMasterId != nil
 
-	var msftMeeting graphmodels.Eventable
-	if msftMeetingRes != nil && msftMeetingRes.GetValue() != nil &&
-		len(msftMeetingRes.GetValue()) > 0 {
-		msftMeeting = msftMeetingRes.GetValue()[0]
-	} else {
-		logger.Error("failed to get meeting data from microsoft", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to get meeting data from microsoft")
+	// A series keeps its new local time across DST changes in the owner's time zone
+	loc, err := getUserLocation(ctx, s.DB, userID)
+	if err != nil {
+		logger.Error("failed to get user's time zone", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get user's time zone")
 		return
 	}
 
-	// Update time of calendar event in microsoft
-	requestBody := graphmodels.NewEvent()
-
-	timeZone := "GMT Standard Time"
-
-	start := graphmodels.NewDateTimeTimeZone()
-	startTime := body.NewStartTime.Format(time.RFC3339Nano)
-	start.SetDateTime(&startTime)
-	start.SetTimeZone(&timeZone)
-	requestBody.SetStart(start)
-
-	end := graphmodels.NewDateTimeTimeZone()
-	endTime := body.NewEndTime.Format(time.RFC3339Nano)
-	end.SetDateTime(&endTime)
-	end.SetTimeZone(&timeZone)
-	requestBody.SetEnd(end)
-
-	// Make the microsoft API call
-	_, err = graph.Me().Events().ByEventId(*msftMeeting.GetId()).Patch(ctx, requestBody, nil)
+	// Update time of calendar event
+	err = calendar.PatchEvent(ctx, *calendarEvent.Id, CalendarEventPatch{
+		Start:    &body.NewStartTime,
+		End:      &body.NewEndTime,
+		Location: loc,
+	})
 	if err != nil {
-		logger.Error("failed to update event in microsoft", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to update event in microsoft")
+		logger.Error("failed to update event in calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to update event in calendar provider")
 		return
 	}
 
-	// Update time in meeting db
-	_, err = s.DB.UpdateMeetingStartTime(ctx, database.UpdateMeetingStartTimeParams{
-		MeetingStartTime: body.NewStartTime,
-		ID:               req.ID,
-	})
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
 	if err != nil {
-		logger.Error("failed to update new start time of meeting", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to update new start time of meeting")
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to accept rescheduling request")
 		return
 	}
 
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	// Update time in meeting db, the series still starts when it did if only an occurrence moved
+	if !movedOccurrence {
+		_, err = qtx.UpdateMeetingStartTime(ctx, database.UpdateMeetingStartTimeParams{
+			MeetingStartTime: body.NewStartTime,
+			ID:               req.ID,
+		})
+		if err != nil {
+			logger.Error("failed to update new start time of meeting", zap.Error(err))
+			sendError(w, http.StatusBadGateway, "Failed to update new start time of meeting")
+			return
+		}
+	}
+
 	// Update status of all requests with the same meeting ID
 
-	rows, err := s.DB.UpdateRequestStatusAsAccepted(ctx, req.ID)
+	rows, err := qtx.UpdateRequestStatusAsAccepted(ctx, req.ID)
 	if err != nil {
 		logger.Error("failed to update status of all the requests to accepted", zap.Error(err))
 		sendError(w, http.StatusBadGateway, "Failed to update status of all the requests to accepted")
@@ -723,44 +687,25 @@ func (s Server) PatchAPIRescheduleRequestRequestIDAccept(w http.ResponseWriter,
 		return
 	}
 
-	notifparam := database.CreateNotificationParams{
-		Message: "You have successfully rescheduled",
-		Created: time.Now(),
-	}
-
-	// Notify Owner
-	err = s.NotificationService.SendNotification(ctx, s.Logger, s.DB, []uint32{userID}, notifparam)
-	if err != nil {
-		logger.Error("failed to send accepted request notification to owner", zap.Error(err))
-	}
-
-	// Notify all attendees
-
-	var attendees []Attendee
-	if attendees, err = parseMSFTAttendees(msftMeeting); err != nil {
-		logger.Error("failed to parse msft attendees", zap.Error(err))
-		sendError(w, http.StatusBadRequest, "failed to parse the msft attendees received")
+	if err = enqueueRescheduleAcceptedNotifications(enqueueRescheduleAcceptedNotificationsParams{
+		ctx:           ctx,
+		qtx:           qtx,
+		logger:        logger,
+		userID:        userID,
+		req:           req,
+		calendarEvent: calendarEvent,
+		start:         body.NewStartTime,
+		end:           body.NewEndTime,
+	}); err != nil {
+		logger.Error("failed to enqueue accepted request notifications", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to accept rescheduling request")
 		return
 	}
-	attendeeIDs := make([]uint32, 0)
-	for _, a := range attendees {
-		var u database.User
-		// don't send error, we only need the id for notifications, just log
-		if u, err = s.DB.GetUserByEmail(ctx, string(a.Email)); err != nil {
-			logger.Error("failed to get user by email in PatchAPIReschedule for sending notifications",
-				zap.Error(err))
-		}
-		attendeeIDs = append(attendeeIDs, u.ID)
-	}
-
-	newNotifparam := database.CreateNotificationParams{
-		Message: "Meeting x has been rescheduled",
-		Created: time.Now(),
-	}
 
-	err = s.NotificationService.SendNotification(ctx, s.Logger, s.DB, attendeeIDs, newNotifparam)
-	if err != nil {
-		logger.Error("failed to send accepted request notification to all attendees", zap.Error(err))
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to accept rescheduling request")
+		return
 	}
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, "Successfully accepted rescheduling request")
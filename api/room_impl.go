@@ -6,8 +6,6 @@ import (
 	"time"
 
 	"go.uber.org/zap"
-
-	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // (GET /api/rooms/all).
@@ -20,46 +18,19 @@ func (s Server) GetAPIRoomsAll(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
 	defer cancel()
 
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
+	calendar, err := s.CalendarProvider(ctx, userID)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
+		logger.Error("failed to create calendar provider", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to connect to calendar provider")
 		return
 	}
 
-	graphRoom, err := graph.Places().GraphRoom().Get(ctx, nil)
+	rooms, err := calendar.ListRooms(ctx)
 	if err != nil {
-		logger.Error("failed to get response from msgraph", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to get response from msgraph for list of rooms")
-		return
-	}
-
-	rooms := graphRoom.GetValue()
-	if rooms == nil {
-		logger.Error("graph room GetValue was nil", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to get response from msgraph for list of rooms")
+		logger.Error("failed to list rooms", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to get list of rooms")
 		return
 	}
 
-	parsedRooms := make([]Room, 0)
-	for _, r := range rooms {
-		email := r.GetEmailAddress()
-		if email == nil {
-			logger.Error("email for room was nil")
-			sendError(w, http.StatusBadRequest,
-				"Failed to get room's email address")
-			return
-		}
-
-		displayName := "placeholder name"
-		if r.GetDisplayName() != nil {
-			displayName = *r.GetDisplayName()
-		}
-		parsedRooms = append(parsedRooms, Room{
-			Email: openapi_types.Email(*email),
-			Name:  displayName,
-		})
-	}
-
-	SetHeaderAndWriteResponse(w, http.StatusOK, parsedRooms)
+	SetHeaderAndWriteResponse(w, http.StatusOK, rooms)
 }
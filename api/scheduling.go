@@ -1,18 +1,12 @@
 package api
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"time"
 
-	"github.com/SlotifyApp/slotify-backend/database"
-	"github.com/avast/retry-go"
 	abstractions "github.com/microsoft/kiota-abstractions-go"
-	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
 	graphusers "github.com/microsoftgraph/msgraph-sdk-go/users"
-	"github.com/oapi-codegen/runtime/types"
-	"go.uber.org/zap"
 
 	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
 
@@ -78,8 +72,8 @@ func ProcessFindMeetingsResponse(res graphmodels.MeetingTimeSuggestionsResultabl
 	return resp, nil
 }
 
-// processReqBodyTimeConstraints will transform time constraints into msgraph TimeSlotable.
-func processReqBodyTimeConstraints(body *SchedulingSlotsBodySchema) *graphmodels.TimeConstraint {
+// processReqBodyTimeConstraints will transform time constraints into msgraph TimeSlotable, in loc.
+func processReqBodyTimeConstraints(body *SchedulingSlotsBodySchema, loc *time.Location) *graphmodels.TimeConstraint {
 	// TODO: See if user wants to respsect user working hours and then set time constraint.
 	// See recent meeting notes
 	timeConstraint := graphmodels.NewTimeConstraint()
@@ -90,21 +84,8 @@ func processReqBodyTimeConstraints(body *SchedulingSlotsBodySchema) *graphmodels
 	timeSlots := []graphmodels.TimeSlotable{}
 	for _, ts := range body.TimeConstraint.TimeSlots {
 		timeSlot := graphmodels.NewTimeSlot()
-		timeZone := "GMT Standard Time"
-
-		startTimeFormatted := ts.Start.Format(time.RFC3339Nano)
-		endTimeFormatted := ts.End.Format(time.RFC3339Nano)
-
-		start := graphmodels.NewDateTimeTimeZone()
-		start.SetDateTime(&startTimeFormatted)
-		start.SetTimeZone(&timeZone)
-
-		end := graphmodels.NewDateTimeTimeZone()
-		end.SetDateTime(&endTimeFormatted)
-		end.SetTimeZone(&timeZone)
-
-		timeSlot.SetStart(start)
-		timeSlot.SetEnd(end)
+		timeSlot.SetStart(toGraphDateTimeTimeZone(ts.Start, loc))
+		timeSlot.SetEnd(toGraphDateTimeTimeZone(ts.End, loc))
 
 		timeSlots = append(timeSlots, timeSlot)
 	}
@@ -175,10 +156,19 @@ func processReqBodyLocationConstraint(body *SchedulingSlotsBodySchema) *graphmod
 }
 
 // CreateSchedulingGraphReqBody will create the header and body for the findMeeting graph endpoint.
+// Times are sent and suggested in the body's time zone, UTC if it has none.
 func CreateSchedulingGraphReqBody(body *SchedulingSlotsBodySchema) (SchedulingGraphReq, error) {
+	loc := time.UTC
+	if body.TimeZone != nil {
+		var err error
+		if loc, err = parseTimeZone(*body.TimeZone); err != nil {
+			return SchedulingGraphReq{}, err
+		}
+	}
+
 	// Create graph headers
 	headers := abstractions.NewRequestHeaders()
-	headers.Add("Prefer", "outlook.timezone=\"GMT Standard Time\"")
+	headers.Add("Prefer", fmt.Sprintf("outlook.timezone=\"%s\"", graphTimeZoneName(loc)))
 
 	configuration := &graphusers.ItemFindMeetingTimesRequestBuilderPostRequestConfiguration{
 		Headers: headers,
@@ -196,7 +186,7 @@ func CreateSchedulingGraphReqBody(body *SchedulingSlotsBodySchema) (SchedulingGr
 	graphRequestBody.SetLocationConstraint(lc)
 
 	// Set time constraints
-	tc := processReqBodyTimeConstraints(body)
+	tc := processReqBodyTimeConstraints(body, loc)
 	graphRequestBody.SetTimeConstraint(tc)
 
 	// Set meeting duration
@@ -227,170 +217,3 @@ func CreateSchedulingGraphReqBody(body *SchedulingSlotsBodySchema) (SchedulingGr
 		reqBody: graphRequestBody,
 	}, nil
 }
-
-func makeFindMeetingTimesAPICall(ctx context.Context,
-	graph *msgraphsdkgo.GraphServiceClient,
-	body SchedulingSlotsBodySchema) (
-	SchedulingSlotsSuccessResponseBody, error,
-) {
-	// Get custom graph request header and config
-	graphConfigAndBody, err := CreateSchedulingGraphReqBody(&body)
-	if err != nil {
-		return SchedulingSlotsSuccessResponseBody{},
-			fmt.Errorf("failed to create graph req body for findMeetings: %w", err)
-	}
-
-	// Attempt to call FindMeetingTimes 3 times
-	var findMeetingTimes graphmodels.MeetingTimeSuggestionsResultable
-	err = retry.Do(func() error {
-		findMeetingTimes, err = graph.Me().FindMeetingTimes().Post(ctx, graphConfigAndBody.reqBody, graphConfigAndBody.config)
-		if err != nil {
-			return fmt.Errorf("failed to make msgraph findMeeting API call: %w", err)
-		}
-
-		return nil
-	}, retry.Attempts(3), retry.Delay(time.Millisecond*500))
-	if err != nil {
-		return SchedulingSlotsSuccessResponseBody{},
-			fmt.Errorf("failed msft find meeting times after 3 retries: %w", err)
-	}
-
-	// Process MSFT resp into our own types
-	respBody, err := ProcessFindMeetingsResponse(findMeetingTimes)
-	if err != nil {
-		return SchedulingSlotsSuccessResponseBody{},
-			fmt.Errorf("failed to process msft findMeeting response: %w", err)
-	}
-
-	return respBody, nil
-}
-
-func getUserWorkingHours(s Server,
-	calendarEvent []CalendarEvent,
-	userWorkingHours map[string]float64,
-) map[string]float64 {
-	// Calculate working hours for each day
-	for _, event := range calendarEvent {
-		startTime, err := time.Parse(time.RFC3339, *event.StartTime+"Z")
-		if err != nil {
-			s.Logger.Error("failed to parse calendar start time", zap.Error(err))
-			continue
-		}
-
-		var endTime time.Time
-		endTime, err = time.Parse(time.RFC3339, *event.EndTime+"Z")
-		if err != nil {
-			s.Logger.Error("failed to parse calendar end time", zap.Error(err))
-			continue
-		}
-
-		duration := endTime.Sub(startTime).Minutes()
-
-		val, ok := userWorkingHours[startTime.Format("2006-01-02")]
-
-		if !ok {
-			userWorkingHours[startTime.Format("2006-01-02")] = duration
-		} else {
-			userWorkingHours[startTime.Format("2006-01-02")] = val + duration
-		}
-	}
-
-	return userWorkingHours
-}
-
-func generateRatingsForSlots(ctx context.Context,
-	s Server,
-	ownerID uint32,
-	possibleSlots SchedulingSlotsSuccessResponseBody,
-	body SchedulingSlotsBodySchema,
-) SchedulingSlotsSuccessResponseBody {
-	// For each attendee, get their calendar data to calculate how many hours of meetings they have each day
-	// then update the running average
-	const workingMinutes = float64(540) // 9 hours * 60 minutes
-	response := possibleSlots
-
-	tempBody := body
-
-	ownerUser, err := s.DB.GetUserByID(ctx, ownerID)
-	if err != nil {
-		s.Logger.Error("failed to create msgraph client", zap.Error(err))
-	} else {
-		tempBody.Attendees = append(tempBody.Attendees, AttendeeBase{
-			AttendeeType: "required",
-			EmailAddress: EmailAddress{
-				Address: types.Email(ownerUser.Email),
-				Name:    ownerUser.FirstName,
-			},
-		})
-	}
-
-	proAttende := processReqBodyAttendees(&tempBody)
-
-	for no, a := range proAttende {
-		var aEmailAdd string
-		if a.GetEmailAddress() != nil || a.GetEmailAddress().GetAddress() != nil {
-			aEmailAdd = *a.GetEmailAddress().GetAddress()
-		} else {
-			continue
-		}
-
-		// Get user ID
-		var user database.User
-		user, err = s.DB.GetUserByEmail(ctx, aEmailAdd)
-		if err != nil {
-			s.Logger.Error("failed to create msgraph client", zap.Error(err))
-			continue
-		}
-
-		// Fetch calendar for user
-		var graph *msgraphsdkgo.GraphServiceClient
-		graph, err = CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, user.ID)
-		if err != nil {
-			s.Logger.Error("failed to create msgraph client", zap.Error(err))
-			continue
-		}
-		userWorkingHours := map[string]float64{}
-
-		// Make call to API route and parse events
-		var calendarEvent []CalendarEvent
-		calendarEvent, err = makeCalendarMeAPICall(graph,
-			body.TimeConstraint.TimeSlots[0].Start,
-			body.TimeConstraint.TimeSlots[0].End)
-		if err != nil {
-			s.Logger.Error("failed to make calendar me msgraph api call", zap.Error(err))
-			continue
-		}
-
-		userWorkingHours = getUserWorkingHours(s, calendarEvent, userWorkingHours)
-
-		// Update running average of confidence
-		for idx, slot := range *possibleSlots.MeetingTimeSuggestions {
-			dur := slot.MeetingTimeSlot.End.Sub(slot.MeetingTimeSlot.End)
-			totalTime, ok := userWorkingHours[slot.MeetingTimeSlot.Start.Format("2006-01-02")]
-
-			if !ok {
-				totalTime = 0
-			}
-
-			remainingMinutes := workingMinutes - dur.Minutes() - totalTime
-			var personalScore float64
-			if remainingMinutes > 0 {
-				//nolint: mnd // magic number 100 for converting to percentage from decimal
-				personalScore = (remainingMinutes / workingMinutes) * 100
-			} else {
-				personalScore = 0
-			}
-
-			if no == 0 {
-				(*response.MeetingTimeSuggestions)[idx].Confidence = &personalScore
-			} else {
-				// running average
-				curr := *(*response.MeetingTimeSuggestions)[idx].Confidence * float64(no)
-				updatedConfidence := (personalScore + curr) / float64(no+1)
-				(*response.MeetingTimeSuggestions)[idx].Confidence = &updatedConfidence
-			}
-		}
-	}
-
-	return response
-}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -21,31 +22,28 @@ func (s Server) PostAPISchedulingSlots(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	var body SchedulingSlotsBodySchema
-	var err error
-	if err = json.NewDecoder(r.Body).Decode(&body); err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		// TODO: Add zap log for body
 		logger.Error(ErrUnmarshalBody, zap.Error(err))
 		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
 		return
 	}
 
-	graph, err := CreateMSFTGraphClient(ctx, s.MSALClient, s.DB, userID)
+	respBody, freeBusy, err := s.findMeetingTimesWithFreeBusy(ctx, userID, body)
 	if err != nil {
-		logger.Error("failed to create msgraph client", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to connect to microsoft graph API")
-		return
-	}
-
-	respBody, err := makeFindMeetingTimesAPICall(ctx, graph, body)
-	if err != nil {
-		logger.Error("failed to make msgraph api call to findMeetings", zap.Error(err))
-		sendError(w, http.StatusBadGateway, "Failed to process/send microsoft graph API request for findMeeting")
+		if errors.Is(err, ErrInvalidSchedulingBody) {
+			logger.Error("invalid scheduling request body", zap.Error(err))
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logger.Error("failed to find meeting times", zap.Error(err))
+		sendError(w, http.StatusBadGateway, "Failed to find meeting times")
 		return
 	}
 
 	// Enter data for rating function
 	// nolint: revive // asks to remove var declaration but am not using var declaration
-	newRespBody := generateRatingsForSlots(ctx, s, userID, respBody, body)
+	newRespBody := generateRatingsForSlots(ctx, s, userID, respBody, body, freeBusy)
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, newRespBody)
 }
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/scheduler"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"go.uber.org/zap"
+)
+
+// scoringMargin is how far either side of the time constraint meetings count towards meeting load.
+const scoringMargin = 24 * time.Hour
+
+// defaultScoringWeights are used for SlotifyGroups that haven't set their own.
+func defaultScoringWeights() ScoringWeights {
+	w := scheduler.DefaultWeights()
+	return ScoringWeights{
+		MeetingLoad:         w[scheduler.FactorMeetingLoad],
+		BackToBack:          w[scheduler.FactorBackToBack],
+		TimeOfDay:           w[scheduler.FactorTimeOfDay],
+		PreferredRooms:      w[scheduler.FactorPreferredRooms],
+		TimeZoneFairness:    w[scheduler.FactorTimeZoneFairness],
+		PreferredRoomEmails: []openapi_types.Email{},
+	}
+}
+
+// toScoringWeights converts DB scoring weights into their API form.
+func toScoringWeights(w database.SlotifyGroupScoringWeights) ScoringWeights {
+	rooms := []openapi_types.Email{}
+	for _, r := range strings.Split(w.PreferredRoomEmails, ",") {
+		if r != "" {
+			rooms = append(rooms, openapi_types.Email(r))
+		}
+	}
+
+	return ScoringWeights{
+		MeetingLoad:         w.MeetingLoad,
+		BackToBack:          w.BackToBack,
+		TimeOfDay:           w.TimeOfDay,
+		PreferredRooms:      w.PreferredRooms,
+		TimeZoneFairness:    w.TimeZoneFairness,
+		PreferredRoomEmails: rooms,
+	}
+}
+
+// newScorer creates a slot scorer from scoring weights.
+func newScorer(w ScoringWeights) scheduler.Scorer {
+	rooms := make([]string, 0, len(w.PreferredRoomEmails))
+	for _, r := range w.PreferredRoomEmails {
+		rooms = append(rooms, string(r))
+	}
+
+	return scheduler.Scorer{
+		Weights: scheduler.Weights{
+			scheduler.FactorMeetingLoad:      w.MeetingLoad,
+			scheduler.FactorBackToBack:       w.BackToBack,
+			scheduler.FactorTimeOfDay:        w.TimeOfDay,
+			scheduler.FactorPreferredRooms:   w.PreferredRooms,
+			scheduler.FactorTimeZoneFairness: w.TimeZoneFairness,
+		},
+		PreferredRooms: rooms,
+	}
+}
+
+// toUpsertScoringWeightsParams validates scoring weights and converts them into DB params.
+func toUpsertScoringWeightsParams(slotifyGroupID uint32,
+	w ScoringWeights,
+) (database.UpsertSlotifyGroupScoringWeightsParams, error) {
+	if err := newScorer(w).Weights.Validate(); err != nil {
+		return database.UpsertSlotifyGroupScoringWeightsParams{}, err
+	}
+
+	rooms := make([]string, 0, len(w.PreferredRoomEmails))
+	for _, r := range w.PreferredRoomEmails {
+		if strings.Contains(string(r), ",") {
+			return database.UpsertSlotifyGroupScoringWeightsParams{},
+				fmt.Errorf("invalid preferred room email '%s'", r)
+		}
+		rooms = append(rooms, string(r))
+	}
+
+	return database.UpsertSlotifyGroupScoringWeightsParams{
+		SlotifyGroupID:      slotifyGroupID,
+		MeetingLoad:         w.MeetingLoad,
+		BackToBack:          w.BackToBack,
+		TimeOfDay:           w.TimeOfDay,
+		PreferredRooms:      w.PreferredRooms,
+		TimeZoneFairness:    w.TimeZoneFairness,
+		PreferredRoomEmails: strings.Join(rooms, ","),
+	}, nil
+}
+
+// getSlotifyGroupScoringWeights gets a SlotifyGroup's scoring weights, or the defaults if it has none.
+func getSlotifyGroupScoringWeights(ctx context.Context, db *database.Database,
+	slotifyGroupID uint32,
+) (ScoringWeights, error) {
+	w, err := db.GetSlotifyGroupScoringWeights(ctx, slotifyGroupID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return defaultScoringWeights(), nil
+		}
+		return ScoringWeights{}, fmt.Errorf("failed to get slotify group scoring weights: %w", err)
+	}
+	return toScoringWeights(w), nil
+}
+
+// toFactorScores converts scheduler factor scores into their API form.
+func toFactorScores(factors []scheduler.FactorScore) []FactorScore {
+	scores := make([]FactorScore, 0, len(factors))
+	for _, f := range factors {
+		scores = append(scores, FactorScore{
+			Factor: ScoreFactor(f.Factor),
+			Weight: f.Weight,
+			Score:  f.Score,
+		})
+	}
+	return scores
+}
+
+// toSlotScoreBreakdown converts a scheduler slot score into its API form.
+func toSlotScoreBreakdown(score scheduler.SlotScore) SlotScoreBreakdown {
+	attendees := make([]AttendeeScore, 0, len(score.Participants))
+	for _, p := range score.Participants {
+		attendees = append(attendees, AttendeeScore{
+			Email:   openapi_types.Email(p.ID),
+			Score:   p.Score,
+			Factors: toFactorScores(p.Factors),
+		})
+	}
+
+	return SlotScoreBreakdown{
+		Score:     score.Score,
+		Factors:   toFactorScores(score.Factors),
+		Attendees: attendees,
+	}
+}
+
+// organiserAttendee is the organiser u as a required attendee, so they can be scored like everyone else.
+func organiserAttendee(u database.User) AttendeeBase {
+	return AttendeeBase{
+		AttendeeType: Required,
+		EmailAddress: EmailAddress{
+			Address: openapi_types.Email(u.Email),
+			Name:    u.FirstName,
+		},
+	}
+}
+
+// getScoringFreeBusy reads the attendees' and then the organiser's free/busy for scoring slots found by a
+// calendar provider, which doesn't return the free/busy it used.
+func (s Server) getScoringFreeBusy(ctx context.Context,
+	ownerID uint32,
+	body SchedulingSlotsBodySchema,
+	start, end time.Time,
+) []attendeeFreeBusy {
+	attendees := slices.Clone(body.Attendees)
+
+	owner, err := s.DB.GetUserByID(ctx, ownerID)
+	if err != nil {
+		s.Logger.Error("failed to get organiser", zap.Uint32("user_id", ownerID), zap.Error(err))
+	} else {
+		attendees = append(attendees, organiserAttendee(owner))
+	}
+
+	// Any refresh was done when the slots were found
+	return s.getAttendeesFreeBusy(ctx, attendees, start, end, false)
+}
+
+// compareSuggestions orders suggestions best first, by confidence, the percentage of attendees who are
+// available, and then by their score so the factors only choose between equally available slots.
+func compareSuggestions(a, b MeetingTimeSuggestion) int {
+	var aConfidence, bConfidence, aScore, bScore float64
+	if a.Confidence != nil {
+		aConfidence = *a.Confidence
+	}
+	if b.Confidence != nil {
+		bConfidence = *b.Confidence
+	}
+	if a.ScoreBreakdown != nil {
+		aScore = a.ScoreBreakdown.Score
+	}
+	if b.ScoreBreakdown != nil {
+		bScore = b.ScoreBreakdown.Score
+	}
+	if c := cmp.Compare(bConfidence, aConfidence); c != 0 {
+		return c
+	}
+	return cmp.Compare(bScore, aScore)
+}
+
+// generateRatingsForSlots scores every suggestion with the weights of body's SlotifyGroup, setting each
+// suggestion's score breakdown, and orders them best first with compareSuggestions. freeBusy is the
+// attendees' and then the organiser's free/busy from findMeetingTimesWithFreeBusy, it is read again if nil.
+func generateRatingsForSlots(ctx context.Context,
+	s Server,
+	ownerID uint32,
+	possibleSlots SchedulingSlotsSuccessResponseBody,
+	body SchedulingSlotsBodySchema,
+	freeBusy []attendeeFreeBusy,
+) SchedulingSlotsSuccessResponseBody {
+	logger := s.Logger.With(zap.Uint32("user_id", ownerID))
+
+	if possibleSlots.MeetingTimeSuggestions == nil || len(*possibleSlots.MeetingTimeSuggestions) == 0 {
+		return possibleSlots
+	}
+
+	weights := defaultScoringWeights()
+	if body.SlotifyGroupID != nil {
+		_, err := authoriseSlotifyGroupAction(ctx, &s.DB.Queries, ownerID, *body.SlotifyGroupID,
+			actionViewScoringWeights)
+		if err == nil {
+			weights, err = getSlotifyGroupScoringWeights(ctx, s.DB, *body.SlotifyGroupID)
+		}
+		if err != nil {
+			logger.Error("failed to get slotify group scoring weights, using defaults",
+				zap.Uint32("slotify_group_id", *body.SlotifyGroupID), zap.Error(err))
+			weights = defaultScoringWeights()
+		}
+	}
+	scorer := newScorer(weights)
+
+	if freeBusy == nil {
+		start, end, err := timeConstraintRange(body)
+		if err != nil {
+			logger.Error("failed to get time constraint range for rating slots", zap.Error(err))
+			return possibleSlots
+		}
+		// Meetings on the whole days either side of the time constraint count towards meeting load
+		freeBusy = s.getScoringFreeBusy(ctx, ownerID, body, start.Add(-scoringMargin), end.Add(scoringMargin))
+	}
+	participants := make([]scheduler.Participant, 0, len(freeBusy))
+	for _, a := range freeBusy {
+		participants = append(participants, toSchedulerParticipant(a, true))
+	}
+
+	suggestions := slices.Clone(*possibleSlots.MeetingTimeSuggestions)
+	for i, suggestion := range suggestions {
+		if suggestion.MeetingTimeSlot == nil {
+			continue
+		}
+
+		rooms := []string{}
+		if suggestion.Locations != nil {
+			for _, l := range *suggestion.Locations {
+				if l.EmailAddress != nil {
+					rooms = append(rooms, *l.EmailAddress)
+				}
+			}
+		}
+
+		slot := scheduler.Interval{Start: suggestion.MeetingTimeSlot.Start, End: suggestion.MeetingTimeSlot.End}
+		breakdown := toSlotScoreBreakdown(scorer.Score(slot, rooms, participants))
+		suggestions[i].ScoreBreakdown = &breakdown
+	}
+
+	slices.SortStableFunc(suggestions, compareSuggestions)
+	for i := range suggestions {
+		order := int32(i + 1) //nolint: gosec // number of suggestions is small
+		suggestions[i].Order = &order
+	}
+
+	response := possibleSlots
+	response.MeetingTimeSuggestions = &suggestions
+	return response
+}
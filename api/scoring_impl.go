@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"go.uber.org/zap"
+)
+
+// (GET /api/slotify-groups/{slotifyGroupID}/scoring-weights).
+func (s Server) GetAPISlotifyGroupsSlotifyGroupIDScoringWeights(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID,
+		actionViewScoringWeights); !ok {
+		return
+	}
+
+	weights, err := getSlotifyGroupScoringWeights(ctx, s.DB, slotifyGroupID)
+	if err != nil {
+		logger.Error("failed to get scoring weights", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to get scoring weights")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, weights)
+}
+
+// (PUT /api/slotify-groups/{slotifyGroupID}/scoring-weights).
+func (s Server) PutAPISlotifyGroupsSlotifyGroupIDScoringWeights(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPISlotifyGroupsSlotifyGroupIDScoringWeightsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	params, err := toUpsertScoringWeightsParams(slotifyGroupID, body)
+	if err != nil {
+		logger.Error("invalid scoring weights", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID,
+		actionEditGroupSettings); !ok {
+		return
+	}
+
+	if _, err = s.DB.UpsertSlotifyGroupScoringWeights(ctx, params); err != nil {
+		logger.Error("failed to save scoring weights", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to save scoring weights")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, toScoringWeights(database.SlotifyGroupScoringWeights(params)))
+}
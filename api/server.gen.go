@@ -56,6 +56,13 @@ const (
 	FreeBusyStatusWorkingElsewhere FreeBusyStatus = "workingElsewhere"
 )
 
+// Defines values for GraphChangeNotificationChangeType.
+const (
+	Created GraphChangeNotificationChangeType = "created"
+	Deleted GraphChangeNotificationChangeType = "deleted"
+	Updated GraphChangeNotificationChangeType = "updated"
+)
+
 // Defines values for InviteStatus.
 const (
 	InviteStatusAccepted InviteStatus = "accepted"
@@ -78,6 +85,108 @@ const (
 	StreetAddress   LocationRoomType = "streetAddress"
 )
 
+// Defines values for NotificationChannel.
+const (
+	Email       NotificationChannel = "email"
+	EmailDigest NotificationChannel = "email_digest"
+	InApp       NotificationChannel = "in_app"
+	None        NotificationChannel = "none"
+)
+
+// Defines values for NotificationKind.
+const (
+	GroupJoined           NotificationKind = "group_joined"
+	GroupMeetingScheduled NotificationKind = "group_meeting_scheduled"
+	GroupMemberJoined     NotificationKind = "group_member_joined"
+	GroupMemberLeft       NotificationKind = "group_member_left"
+	GroupRenamed          NotificationKind = "group_renamed"
+	InviteReceived        NotificationKind = "invite_received"
+	InviteSent            NotificationKind = "invite_sent"
+	MeetingCreated        NotificationKind = "meeting_created"
+	MeetingMoved          NotificationKind = "meeting_moved"
+	MeetingRescheduled    NotificationKind = "meeting_rescheduled"
+	Message               NotificationKind = "message"
+	PollClosed            NotificationKind = "poll_closed"
+	PollCreated           NotificationKind = "poll_created"
+	PollVoteCast          NotificationKind = "poll_vote_cast"
+	RescheduleAccepted    NotificationKind = "reschedule_accepted"
+	RescheduleRejected    NotificationKind = "reschedule_rejected"
+	RescheduleRequested   NotificationKind = "reschedule_requested"
+)
+
+// Defines values for NotificationReadStatus.
+const (
+	All    NotificationReadStatus = "all"
+	Read   NotificationReadStatus = "read"
+	Unread NotificationReadStatus = "unread"
+)
+
+// Defines values for PollStatus.
+const (
+	Closed PollStatus = "closed"
+	Failed PollStatus = "failed"
+	Open   PollStatus = "open"
+)
+
+// Defines values for PollVoteChoice.
+const (
+	Maybe PollVoteChoice = "maybe"
+	No    PollVoteChoice = "no"
+	Yes   PollVoteChoice = "yes"
+)
+
+// Defines values for RecurrencePattern.
+const (
+	Daily   RecurrencePattern = "daily"
+	Monthly RecurrencePattern = "monthly"
+	Weekly  RecurrencePattern = "weekly"
+)
+
+// Defines values for RescheduleScope.
+const (
+	Occurrence RescheduleScope = "occurrence"
+	Series     RescheduleScope = "series"
+)
+
+// Defines values for SchedulingSlotsBodySchemaSlotFinder.
+const (
+	Msgraph SchedulingSlotsBodySchemaSlotFinder = "msgraph"
+	Native  SchedulingSlotsBodySchemaSlotFinder = "native"
+)
+
+// Defines values for ScoreFactor.
+const (
+	BackToBack       ScoreFactor = "backToBack"
+	MeetingLoad      ScoreFactor = "meetingLoad"
+	PreferredRooms   ScoreFactor = "preferredRooms"
+	TimeOfDay        ScoreFactor = "timeOfDay"
+	TimeZoneFairness ScoreFactor = "timeZoneFairness"
+)
+
+// Defines values for SlotifyGroupRole.
+const (
+	Admin  SlotifyGroupRole = "admin"
+	Member SlotifyGroupRole = "member"
+	Owner  SlotifyGroupRole = "owner"
+)
+
+// Defines values for SlotifyGroupVisibility.
+const (
+	Discoverable SlotifyGroupVisibility = "discoverable"
+	Private      SlotifyGroupVisibility = "private"
+)
+
+// Defines values for Weekday.
+const (
+	Friday    Weekday = "friday"
+	Monday    Weekday = "monday"
+	Saturday  Weekday = "saturday"
+	Sunday    Weekday = "sunday"
+	Thursday  Weekday = "thursday"
+	Tuesday   Weekday = "tuesday"
+	Wednesday Weekday = "wednesday"
+)
+
 // Attendee Maps roughly to [MSFT Attendee](https://learn.microsoft.com/en-us/graph/api/resources/attendee?view=graph-rest-1.0#properties)
 type Attendee struct {
 	// AttendeeType Maps directly to [MSFT Attendee->type](https://learn.microsoft.com/en-us/graph/api/resources/attendee?view=graph-rest-1.0)
@@ -107,18 +216,32 @@ type AttendeeBase struct {
 	EmailAddress EmailAddress `json:"emailAddress"`
 }
 
+// AttendeeScore defines model for AttendeeScore.
+type AttendeeScore struct {
+	Email   openapi_types.Email `json:"email"`
+	Factors []FactorScore       `json:"factors"`
+
+	// Score Weighted mean of factors.
+	Score float64 `json:"score"`
+}
+
 // AttendeeType Maps directly to [MSFT Attendee->type](https://learn.microsoft.com/en-us/graph/api/resources/attendee?view=graph-rest-1.0)
 type AttendeeType string
 
 // CalendarEvent Maps roughly to [MSFT event](https://learn.microsoft.com/en-us/graph/api/resources/event?view=graph-rest-1.0#properties)
 type CalendarEvent struct {
-	Attendees   []Attendee `json:"attendees"`
-	Body        *string    `json:"body,omitempty"`
-	Created     *time.Time `json:"created,omitempty"`
-	EndTime     *string    `json:"endTime"`
-	ICalUId     *string    `json:"iCalUId,omitempty"`
-	Id          *string    `json:"id,omitempty"`
-	IsCancelled *bool      `json:"isCancelled,omitempty"`
+	Attendees []Attendee `json:"attendees"`
+	Body      *string    `json:"body,omitempty"`
+	Created   *time.Time `json:"created,omitempty"`
+
+	// EndTime RFC3339 end time, with its UTC offset. Events are returned in the time zone asked for.
+	EndTime     *string `json:"endTime"`
+	ICalUId     *string `json:"iCalUId,omitempty"`
+	Id          *string `json:"id,omitempty"`
+	IsCancelled *bool   `json:"isCancelled,omitempty"`
+
+	// IsOnlineMeeting Create the event as a Teams meeting. Only Microsoft calendars create Teams meetings.
+	IsOnlineMeeting *bool `json:"isOnlineMeeting,omitempty"`
 
 	// JoinURL Maps roughly to [MSFT OnlineMeetingInfo->joinURL](https://learn.microsoft.com/en-us/graph/api/resources/onlinemeetinginfo?view=graph-rest-1.0#json-representation)
 	JoinURL   *string    `json:"joinURL"`
@@ -126,9 +249,23 @@ type CalendarEvent struct {
 
 	// Organizer Maps roughly to [MSFT Recipient->emailAddress](https://learn.microsoft.com/en-us/graph/api/resources/recipient?view=graph-rest-1.0)
 	Organizer *openapi_types.Email `json:"organizer,omitempty"`
-	StartTime *string              `json:"startTime"`
-	Subject   *string              `json:"subject,omitempty"`
-	WebLink   *string              `json:"webLink,omitempty"`
+
+	// OriginalStartTime The start time an occurrence of a recurring meeting was originally scheduled for, in the same format as startTime.
+	OriginalStartTime *string `json:"originalStartTime,omitempty"`
+
+	// Recurrence How a meeting repeats, roughly maps to [MSFT Patterned Recurrence](https://learn.microsoft.com/en-us/graph/api/resources/patternedrecurrence?view=graph-rest-1.0). The series has no end if neither occurrences nor endDate are set, only one of them can be set.
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+
+	// SeriesMasterId The id of the recurring meeting this event is an occurrence of.
+	SeriesMasterId *string `json:"seriesMasterId,omitempty"`
+
+	// StartTime RFC3339 start time, with its UTC offset. Events are returned in the time zone asked for.
+	StartTime *string `json:"startTime"`
+	Subject   *string `json:"subject,omitempty"`
+
+	// TimeZone IANA or Windows time zone name the event is created in, a recurring event repeats at the same local time in it across DST changes. Defaults to the user's time zone.
+	TimeZone *string `json:"timeZone,omitempty"`
+	WebLink  *string `json:"webLink,omitempty"`
 }
 
 // EmailAddress directly maps to MSFT Email Address, see info here:[MSFT EmailAddress Struct Docs](https://learn.microsoft.com/en-us/graph/api/resources/emailaddress?view=graph-rest-1.0)
@@ -140,9 +277,59 @@ type EmailAddress struct {
 // EmptySuggestionsReason Maps directly to [MSFT emptySuggestionsReason](https://learn.microsoft.com/en-us/graph/api/resources/meetingtimesuggestionsresult?view=graph-rest-1.0)
 type EmptySuggestionsReason string
 
+// FactorScore defines model for FactorScore.
+type FactorScore struct {
+	// Factor A factor that makes a slot better or worse for its participants.
+	Factor ScoreFactor `json:"factor"`
+
+	// Score Score out of 100.
+	Score float64 `json:"score"`
+
+	// Weight How much the factor counts towards the score.
+	Weight float64 `json:"weight"`
+}
+
 // FreeBusyStatus Maps directly to [MSFT freebusyStatus](https://learn.microsoft.com/en-us/graph/api/resources/attendeeavailability?view=graph-rest-1.0)
 type FreeBusyStatus string
 
+// GraphChangeNotification Maps to [MSFT changeNotification](https://learn.microsoft.com/en-us/graph/api/resources/changenotification?view=graph-rest-1.0)
+type GraphChangeNotification struct {
+	ChangeType   GraphChangeNotificationChangeType `json:"changeType"`
+	ClientState  *string                           `json:"clientState,omitempty"`
+	Resource     *string                           `json:"resource,omitempty"`
+	ResourceData *struct {
+		Id *string `json:"id,omitempty"`
+	} `json:"resourceData,omitempty"`
+	SubscriptionExpirationDateTime *time.Time `json:"subscriptionExpirationDateTime,omitempty"`
+	SubscriptionId                 string     `json:"subscriptionId"`
+	TenantId                       *string    `json:"tenantId,omitempty"`
+}
+
+// GraphChangeNotificationChangeType defines model for GraphChangeNotification.ChangeType.
+type GraphChangeNotificationChangeType string
+
+// GraphChangeNotificationCollection defines model for GraphChangeNotificationCollection.
+type GraphChangeNotificationCollection struct {
+	Value []GraphChangeNotification `json:"value"`
+}
+
+// InboxNotification defines model for InboxNotification.
+type InboxNotification struct {
+	Created time.Time `json:"created"`
+	Id      uint32    `json:"id"`
+	IsRead  bool      `json:"isRead"`
+
+	// Kind The kind of a notification, it is also the name of its server-sent event. message notifications only have a message and are sent as calendar_notification events.
+	Kind    NotificationKind `json:"kind"`
+	Message string           `json:"message"`
+
+	// Payload Structured fields of the notification, which depend on its kind and version, eg. invite_received has inviteID, slotifyGroupID, groupName and fromUserID. Null for message notifications.
+	Payload *map[string]interface{} `json:"payload"`
+
+	// Version Version of the payload schema for the kind.
+	Version uint32 `json:"version"`
+}
+
 // InviteCreate Invite create request body
 type InviteCreate struct {
 	CreatedAt      time.Time          `json:"createdAt"`
@@ -205,10 +392,12 @@ type InvitesMe struct {
 
 // Location Maps roughly to [MSFT Location](https://learn.microsoft.com/en-us/graph/api/resources/location?view=graph-rest-1.0)
 type Location struct {
-	Id       *string           `json:"id,omitempty"`
-	Name     *string           `json:"name,omitempty"`
-	RoomType *LocationRoomType `json:"roomType"`
-	Street   *string           `json:"street"`
+	// EmailAddress Email address of the room, if the location is a room.
+	EmailAddress *string           `json:"emailAddress,omitempty"`
+	Id           *string           `json:"id,omitempty"`
+	Name         *string           `json:"name,omitempty"`
+	RoomType     *LocationRoomType `json:"roomType"`
+	Street       *string           `json:"street"`
 }
 
 // LocationRoomType defines model for Location.RoomType.
@@ -259,14 +448,72 @@ type MeetingTimeSuggestion struct {
 	MeetingTimeSlot       *MeetingTimeSlot `json:"meetingTimeSlot,omitempty"`
 	Order                 *int32           `json:"order,omitempty"`
 	OrganizerAvailability *string          `json:"organizerAvailability,omitempty"`
-	SuggestionReason      *string          `json:"suggestionReason,omitempty"`
+
+	// ScoreBreakdown Explains how a suggestion was scored. Suggestions are ranked by confidence, the percentage of attendees who are available, and then by this score.
+	ScoreBreakdown   *SlotScoreBreakdown `json:"scoreBreakdown,omitempty"`
+	SuggestionReason *string             `json:"suggestionReason,omitempty"`
 }
 
 // Notification defines model for Notification.
 type Notification struct {
 	Created time.Time `json:"created"`
 	Id      uint32    `json:"id"`
-	Message string    `json:"message"`
+
+	// Kind The kind of a notification, it is also the name of its server-sent event. message notifications only have a message and are sent as calendar_notification events.
+	Kind    NotificationKind `json:"kind"`
+	Message string           `json:"message"`
+
+	// Payload Structured fields of the notification, which depend on its kind and version, eg. invite_received has inviteID, slotifyGroupID, groupName and fromUserID. Null for message notifications.
+	Payload *map[string]interface{} `json:"payload"`
+
+	// Version Version of the payload schema for the kind.
+	Version uint32 `json:"version"`
+}
+
+// NotificationChannel How a user is notified of a kind of notification. in_app notifications are stored and sent live, email notifications are stored and emailed, email_digest notifications are stored and sent in a daily email digest, none mutes the kind. During quiet hours in_app notifications are not sent live and email notifications wait for the digest.
+type NotificationChannel string
+
+// NotificationChannelPreference defines model for NotificationChannelPreference.
+type NotificationChannelPreference struct {
+	// Channel How a user is notified of a kind of notification. in_app notifications are stored and sent live, email notifications are stored and emailed, email_digest notifications are stored and sent in a daily email digest, none mutes the kind. During quiet hours in_app notifications are not sent live and email notifications wait for the digest.
+	Channel NotificationChannel `json:"channel"`
+
+	// Kind The kind of a notification, it is also the name of its server-sent event. message notifications only have a message and are sent as calendar_notification events.
+	Kind NotificationKind `json:"kind"`
+}
+
+// NotificationKind The kind of a notification, it is also the name of its server-sent event. message notifications only have a message and are sent as calendar_notification events.
+type NotificationKind string
+
+// NotificationPreferences Notification preferences of a user, kinds without a channel use the most permissive default of the user's slotifyGroups, or in_app.
+type NotificationPreferences struct {
+	Channels []NotificationChannelPreference `json:"channels"`
+
+	// QuietHours Daily window in the user's time zone during which notifications are stored but not sent live, it runs over midnight if end is before start.
+	QuietHours *NotificationQuietHours `json:"quietHours,omitempty"`
+}
+
+// NotificationQuietHours Daily window in the user's time zone during which notifications are stored but not sent live, it runs over midnight if end is before start.
+type NotificationQuietHours struct {
+	// End End of quiet hours, HH:MM.
+	End string `json:"end"`
+
+	// Start Start of quiet hours, HH:MM.
+	Start string `json:"start"`
+}
+
+// NotificationReadStatus Filters the inbox by whether notifications have been read.
+type NotificationReadStatus string
+
+// NotificationsAndPagination defines model for NotificationsAndPagination.
+type NotificationsAndPagination struct {
+	NextPageToken uint32              `json:"nextPageToken"`
+	Notifications []InboxNotification `json:"notifications"`
+}
+
+// NotificationsReadBody Notifications to mark as read, all of the user's unread notifications if notificationIDs is not set.
+type NotificationsReadBody struct {
+	NotificationIDs *[]uint32 `json:"notificationIDs,omitempty"`
 }
 
 // PhysicalAddress Maps directly to [MSFT physicalAddress](https://learn.microsoft.com/en-us/graph/api/resources/locationconstraintitem?view=graph-rest-1.0)
@@ -287,6 +534,120 @@ type PhysicalAddress struct {
 	Street *string `json:"street,omitempty"`
 }
 
+// Poll A poll for attendees to vote on which of some suggested slots a meeting should be in.
+type Poll struct {
+	// ChosenOptionID The slot the meeting was created in once the poll is closed.
+	ChosenOptionID *uint32   `json:"chosenOptionID"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	// Deadline When the poll is closed if the organiser has not closed it.
+	Deadline time.Time `json:"deadline"`
+
+	// Event Maps roughly to [MSFT event](https://learn.microsoft.com/en-us/graph/api/resources/event?view=graph-rest-1.0#properties)
+	Event CalendarEvent `json:"event"`
+
+	// EventID The id of the meeting created in the organiser's calendar once the poll is closed.
+	EventID     *string      `json:"eventID"`
+	Id          uint32       `json:"id"`
+	Options     []PollOption `json:"options"`
+	OrganiserID uint32       `json:"organiserID"`
+
+	// SlotifyGroupID The group the poll was published to, if any.
+	SlotifyGroupID *uint32 `json:"slotifyGroupID"`
+
+	// Status The status of a poll, an open poll can be voted on until it is closed. A poll is failed if its meeting couldn't be created after its deadline, its organiser can still close it.
+	Status PollStatus `json:"status"`
+
+	// Voters The ids of the users who can vote, including the organiser.
+	Voters []uint32   `json:"voters"`
+	Votes  []PollVote `json:"votes"`
+}
+
+// PollCloseBody Poll close request body.
+type PollCloseBody struct {
+	// OptionID The slot to create the meeting in, the one with the most support if not set.
+	OptionID *uint32 `json:"optionID,omitempty"`
+}
+
+// PollCreateBody Poll create request body. Voters are the attendees, the members of the group and the organiser.
+type PollCreateBody struct {
+	// Attendees The ids of the users to vote.
+	Attendees *[]uint32 `json:"attendees,omitempty"`
+	Deadline  time.Time `json:"deadline"`
+
+	// Event The meeting created in the organiser's calendar when the poll is closed, its start and end times are those of the chosen slot. Voters are added to its attendees. The default location and Teams setting of the group are used as for group meetings.
+	Event   CalendarEvent    `json:"event"`
+	Options []PollOptionBody `json:"options"`
+
+	// SlotifyGroupID A group whose members vote.
+	SlotifyGroupID *uint32 `json:"slotifyGroupID,omitempty"`
+}
+
+// PollOption A slot suggested in a poll, with how many voters voted each way for it.
+type PollOption struct {
+	EndTime   time.Time `json:"endTime"`
+	Id        uint32    `json:"id"`
+	Maybe     int       `json:"maybe"`
+	No        int       `json:"no"`
+	StartTime time.Time `json:"startTime"`
+	Yes       int       `json:"yes"`
+}
+
+// PollOptionBody A slot to suggest in a poll.
+type PollOptionBody struct {
+	EndTime   time.Time `json:"endTime"`
+	StartTime time.Time `json:"startTime"`
+}
+
+// PollStatus The status of a poll, an open poll can be voted on until it is closed. A poll is failed if its meeting couldn't be created after its deadline, its organiser can still close it.
+type PollStatus string
+
+// PollVote A vote on a slot suggested in a poll.
+type PollVote struct {
+	OptionID uint32 `json:"optionID"`
+	UserID   uint32 `json:"userID"`
+
+	// Vote Whether a voter can make a suggested slot.
+	Vote PollVoteChoice `json:"vote"`
+}
+
+// PollVoteBody defines model for PollVoteBody.
+type PollVoteBody struct {
+	OptionID uint32 `json:"optionID"`
+
+	// Vote Whether a voter can make a suggested slot.
+	Vote PollVoteChoice `json:"vote"`
+}
+
+// PollVoteChoice Whether a voter can make a suggested slot.
+type PollVoteChoice string
+
+// PollVotesBody Votes on the slots of a poll, replacing the user's earlier votes on those slots.
+type PollVotesBody struct {
+	Votes []PollVoteBody `json:"votes"`
+}
+
+// Recurrence How a meeting repeats, roughly maps to [MSFT Patterned Recurrence](https://learn.microsoft.com/en-us/graph/api/resources/patternedrecurrence?view=graph-rest-1.0). The series has no end if neither occurrences nor endDate are set, only one of them can be set.
+type Recurrence struct {
+	// DaysOfWeek The days a weekly meeting is on, the day of the first occurrence if omitted.
+	DaysOfWeek *[]Weekday `json:"daysOfWeek,omitempty"`
+
+	// EndDate The last date an occurrence can be on, in UTC.
+	EndDate *openapi_types.Date `json:"endDate,omitempty"`
+
+	// Interval The number of days, weeks or months between occurrences.
+	Interval *int32 `json:"interval,omitempty"`
+
+	// Occurrences The number of occurrences in the series, including the first.
+	Occurrences *int32 `json:"occurrences,omitempty"`
+
+	// Pattern How often a meeting repeats, 'monthly' repeats on the day of the month of the first occurrence.
+	Pattern RecurrencePattern `json:"pattern"`
+}
+
+// RecurrencePattern How often a meeting repeats, 'monthly' repeats on the day of the month of the first occurrence.
+type RecurrencePattern string
+
 // RescheduleRequest Reschedule request object
 type RescheduleRequest struct {
 	NewMeeting *ReschedulingRequestNewMeeting `json:"newMeeting,omitempty"`
@@ -305,6 +666,9 @@ type RescheduleRequest struct {
 	Status string `json:"status"`
 }
 
+// RescheduleScope Whether a reschedule request moves one occurrence of a recurring meeting or the whole series. Meetings that don't repeat are moved either way.
+type RescheduleScope string
+
 // ReschedulingCheckBodySchema Request body of the details of the two meetings
 type ReschedulingCheckBodySchema struct {
 	NewMeeting *struct {
@@ -329,6 +693,9 @@ type ReschedulingCheckBodySchema struct {
 
 		// OwnerEmail The email of the owner of the old meeting
 		OwnerEmail openapi_types.Email `json:"ownerEmail"`
+
+		// Scope Whether a reschedule request moves one occurrence of a recurring meeting or the whole series. Meetings that don't repeat are moved either way.
+		Scope *RescheduleScope `json:"scope,omitempty"`
 	} `json:"oldMeeting"`
 }
 
@@ -366,8 +733,14 @@ type ReschedulingRequestBodySchema struct {
 		// MsftMeetingID The microsoft iCalUId meeting ID of the old meeting
 		MsftMeetingID string `json:"msftMeetingID"`
 
+		// OccurrenceStart The original start time of the occurrence to move, required if the meeting repeats and scope is occurrence.
+		OccurrenceStart *time.Time `json:"occurrenceStart,omitempty"`
+
 		// OwnerEmail The email of the owner of the old meeting
 		OwnerEmail openapi_types.Email `json:"ownerEmail"`
+
+		// Scope Whether a reschedule request moves one occurrence of a recurring meeting or the whole series. Meetings that don't repeat are moved either way.
+		Scope *RescheduleScope `json:"scope,omitempty"`
 	} `json:"oldMeeting"`
 }
 
@@ -402,6 +775,12 @@ type ReschedulingRequestOldMeeting struct {
 	// MsftMeetingID The microsoft meeting ID of the old meeting
 	MsftMeetingID string `json:"msftMeetingID"`
 
+	// OccurrenceStart The original start time of the occurrence to move, if only one occurrence of a recurring meeting is moved.
+	OccurrenceStart *time.Time `json:"occurrenceStart,omitempty"`
+
+	// Scope Whether a reschedule request moves one occurrence of a recurring meeting or the whole series. Meetings that don't repeat are moved either way.
+	Scope RescheduleScope `json:"scope"`
+
 	// TimeRangeEnd The start of the meeting denoted in *ISO 8601* format In the format: yyyy-mm-ddThh:mm:ssZ Where lowercase letters are replaced by their numerical values
 	TimeRangeEnd time.Time `json:"timeRangeEnd"`
 
@@ -412,8 +791,14 @@ type ReschedulingRequestOldMeeting struct {
 // ReschedulingRequestSingleBodySchema Request body of the details of the old meeting
 type ReschedulingRequestSingleBodySchema struct {
 	// MsftMeetingID The microsoft iCalUId meeting ID of the old meeting
-	MsftMeetingID string              `json:"msftMeetingID"`
-	OwnerEmail    openapi_types.Email `json:"ownerEmail"`
+	MsftMeetingID string `json:"msftMeetingID"`
+
+	// OccurrenceStart The original start time of the occurrence to move, required if the meeting repeats and scope is occurrence.
+	OccurrenceStart *time.Time          `json:"occurrenceStart,omitempty"`
+	OwnerEmail      openapi_types.Email `json:"ownerEmail"`
+
+	// Scope Whether a reschedule request moves one occurrence of a recurring meeting or the whole series. Meetings that don't repeat are moved either way.
+	Scope *RescheduleScope `json:"scope,omitempty"`
 }
 
 // Room defines model for Room.
@@ -424,8 +809,17 @@ type Room struct {
 
 // SchedulingSlotsBodySchema Roughly maps to [MSFT Find Meeting Schema](https://learn.microsoft.com/en-us/graph/api/user-findmeetingtimes?view=graph-rest-1.0&tabs=http#request-body)
 type SchedulingSlotsBodySchema struct {
-	Attendees           []AttendeeBase `json:"attendees"`
-	IsOrganizerOptional bool           `json:"isOrganizerOptional"`
+	Attendees []AttendeeBase `json:"attendees"`
+
+	// BufferAfter Free time required after the meeting, denoted in **ISO 8601** format. Only used by the 'native' slot finder.
+	BufferAfter *string `json:"bufferAfter,omitempty"`
+
+	// BufferBefore Free time required before the meeting, denoted in **ISO 8601** format. Only used by the 'native' slot finder.
+	BufferBefore *string `json:"bufferBefore,omitempty"`
+
+	// ForceRefresh Read calendars from the calendar provider instead of the cache, refreshing the cache.
+	ForceRefresh        *bool `json:"forceRefresh,omitempty"`
+	IsOrganizerOptional bool  `json:"isOrganizerOptional"`
 
 	// LocationConstraint Maps directly to [MSFT locationConstraint](https://learn.microsoft.com/en-us/graph/api/resources/locationconstraint?view=graph-rest-1.0)
 	LocationConstraint LocationConstraint `json:"locationConstraint"`
@@ -441,10 +835,25 @@ type SchedulingSlotsBodySchema struct {
 	MeetingName               string   `json:"meetingName"`
 	MinimumAttendeePercentage *float64 `json:"minimumAttendeePercentage,omitempty"`
 
+	// Recurrence How a meeting repeats, roughly maps to [MSFT Patterned Recurrence](https://learn.microsoft.com/en-us/graph/api/resources/patternedrecurrence?view=graph-rest-1.0). The series has no end if neither occurrences nor endDate are set, only one of them can be set.
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+
+	// SlotFinder Which slot finder to use. 'msgraph' delegates to MSFT findMeetingTimes, 'native' uses Slotify's own scheduler with every participant's free/busy. Scheduling with Google users always uses 'native'.
+	SlotFinder *SchedulingSlotsBodySchemaSlotFinder `json:"slotFinder,omitempty"`
+
+	// SlotifyGroupID SlotifyGroup whose scoring weights are used to rank the suggestions, default weights are used if omitted.
+	SlotifyGroupID *uint32 `json:"slotifyGroupID,omitempty"`
+
 	// TimeConstraint Maps directly to [MSFT timeConstraint](https://learn.microsoft.com/en-us/graph/api/resources/timeconstraint?view=graph-rest-1.0)
 	TimeConstraint TimeConstraint `json:"timeConstraint"`
+
+	// TimeZone IANA or Windows time zone name the slots are found and returned in. Defaults to the organiser's time zone.
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
+// SchedulingSlotsBodySchemaSlotFinder Which slot finder to use. 'msgraph' delegates to MSFT findMeetingTimes, 'native' uses Slotify's own scheduler with every participant's free/busy. Scheduling with Google users always uses 'native'.
+type SchedulingSlotsBodySchemaSlotFinder string
+
 // SchedulingSlotsSuccessResponseBody Maps roughly to [MSFT meetingTimeSuggestionsResult](https://learn.microsoft.com/en-us/graph/api/resources/meetingtimesuggestionsresult?view=graph-rest-1.0)
 type SchedulingSlotsSuccessResponseBody struct {
 	// EmptySuggestionsReason Maps directly to [MSFT emptySuggestionsReason](https://learn.microsoft.com/en-us/graph/api/resources/meetingtimesuggestionsresult?view=graph-rest-1.0)
@@ -452,10 +861,49 @@ type SchedulingSlotsSuccessResponseBody struct {
 	MeetingTimeSuggestions *[]MeetingTimeSuggestion `json:"meetingTimeSuggestions,omitempty"`
 }
 
+// ScoreFactor A factor that makes a slot better or worse for its participants.
+type ScoreFactor string
+
+// ScoringWeights How much each factor counts when scoring slots for a SlotifyGroup.
+type ScoringWeights struct {
+	BackToBack          float64               `json:"backToBack"`
+	MeetingLoad         float64               `json:"meetingLoad"`
+	PreferredRoomEmails []openapi_types.Email `json:"preferredRoomEmails"`
+	PreferredRooms      float64               `json:"preferredRooms"`
+	TimeOfDay           float64               `json:"timeOfDay"`
+	TimeZoneFairness    float64               `json:"timeZoneFairness"`
+}
+
+// SlotScoreBreakdown Explains how a suggestion was scored. Suggestions are ranked by confidence, the percentage of attendees who are available, and then by this score.
+type SlotScoreBreakdown struct {
+	Attendees []AttendeeScore `json:"attendees"`
+
+	// Factors Attendee factors averaged over attendees, plus the slot-wide factors.
+	Factors []FactorScore `json:"factors"`
+
+	// Score Weighted mean of factors.
+	Score float64 `json:"score"`
+}
+
 // SlotifyGroup defines model for SlotifyGroup.
 type SlotifyGroup struct {
-	Id   uint32 `json:"id"`
-	Name string `json:"name"`
+	// AvatarColour Hex colour of the group's avatar, unset if the group hasn't chosen one.
+	AvatarColour *string `json:"avatarColour,omitempty"`
+
+	// DefaultIsOnlineMeeting Whether the group's meetings are Teams meetings when one doesn't say.
+	DefaultIsOnlineMeeting bool `json:"defaultIsOnlineMeeting"`
+
+	// DefaultLocation Location or room of the group's meetings when one doesn't give its own locations.
+	DefaultLocation *Location `json:"defaultLocation,omitempty"`
+
+	// DefaultMeetingDuration Length of the group's meetings when a group meeting doesn't give one, denoted in **ISO 8601** format.
+	DefaultMeetingDuration *string `json:"defaultMeetingDuration,omitempty"`
+	Description            string  `json:"description"`
+	Id                     uint32  `json:"id"`
+	Name                   string  `json:"name"`
+
+	// Visibility Whether users outside a slotifyGroup can find it.
+	Visibility SlotifyGroupVisibility `json:"visibility"`
 }
 
 // SlotifyGroupCreate defines model for SlotifyGroupCreate.
@@ -463,18 +911,88 @@ type SlotifyGroupCreate struct {
 	Name string `json:"name"`
 }
 
+// SlotifyGroupMeeting A meeting scheduled for a slotifyGroup and the slot it was scheduled in.
+type SlotifyGroupMeeting struct {
+	// Event Maps roughly to [MSFT event](https://learn.microsoft.com/en-us/graph/api/resources/event?view=graph-rest-1.0#properties)
+	Event CalendarEvent `json:"event"`
+
+	// Suggestion Maps roughly to [MSFT meetingTimeSuggestion](https://learn.microsoft.com/en-us/graph/api/resources/meetingtimesuggestion?view=graph-rest-1.0)
+	Suggestion MeetingTimeSuggestion `json:"suggestion"`
+}
+
+// SlotifyGroupMeetingBody Group meeting request body. Every member of the group is invited, as a required or optional attendee depending on their attendee type in the group.
+type SlotifyGroupMeetingBody struct {
+	// Event The meeting created in the organiser's calendar, its start and end times are those of the best slot found. The group's members are added to its attendees. The group's default location and Teams setting are used if it has no locations or doesn't say whether it is a Teams meeting.
+	Event CalendarEvent `json:"event"`
+
+	// MeetingDuration The length of the meeting, denoted in **ISO 8601** format. Defaults to the group's default meeting duration, or 30 minutes.
+	MeetingDuration *string `json:"meetingDuration,omitempty"`
+
+	// MinimumAttendeePercentage Quorum, the percentage of the group's other members who must be free for a slot to be chosen. Defaults to 50.
+	MinimumAttendeePercentage *float64 `json:"minimumAttendeePercentage,omitempty"`
+
+	// TimeConstraint Maps directly to [MSFT timeConstraint](https://learn.microsoft.com/en-us/graph/api/resources/timeconstraint?view=graph-rest-1.0)
+	TimeConstraint TimeConstraint `json:"timeConstraint"`
+}
+
+// SlotifyGroupMemberAttendeeType Whether a member is a required or optional attendee of the group's meetings, members can't be resources.
+type SlotifyGroupMemberAttendeeType struct {
+	// AttendeeType Maps directly to [MSFT Attendee->type](https://learn.microsoft.com/en-us/graph/api/resources/attendee?view=graph-rest-1.0)
+	AttendeeType AttendeeType `json:"attendeeType"`
+}
+
+// SlotifyGroupMemberRole A member's role in a slotifyGroup. Giving another member the owner role transfers ownership to them, the previous owner becomes an admin.
+type SlotifyGroupMemberRole struct {
+	// Role A member's role in a slotifyGroup. Owners can do anything, including deleting the group and changing roles. Admins can invite users, remove members and change the group's settings. Members can schedule the group's meetings.
+	Role SlotifyGroupRole `json:"role"`
+}
+
+// SlotifyGroupNotificationPreferences Default notification channels for members of a slotifyGroup, members' own preferences take precedence.
+type SlotifyGroupNotificationPreferences struct {
+	Channels []NotificationChannelPreference `json:"channels"`
+}
+
+// SlotifyGroupRole A member's role in a slotifyGroup. Owners can do anything, including deleting the group and changing roles. Admins can invite users, remove members and change the group's settings. Members can schedule the group's meetings.
+type SlotifyGroupRole string
+
+// SlotifyGroupUpdate Group settings to change, settings that are left out are kept. An empty avatarColour, defaultMeetingDuration or defaultLocation name clears the setting.
+type SlotifyGroupUpdate struct {
+	AvatarColour           *string `json:"avatarColour,omitempty"`
+	DefaultIsOnlineMeeting *bool   `json:"defaultIsOnlineMeeting,omitempty"`
+
+	// DefaultLocation Maps roughly to [MSFT Location](https://learn.microsoft.com/en-us/graph/api/resources/location?view=graph-rest-1.0)
+	DefaultLocation        *Location `json:"defaultLocation,omitempty"`
+	DefaultMeetingDuration *string   `json:"defaultMeetingDuration,omitempty"`
+	Description            *string   `json:"description,omitempty"`
+	Name                   *string   `json:"name,omitempty"`
+
+	// Visibility Whether users outside a slotifyGroup can find it.
+	Visibility *SlotifyGroupVisibility `json:"visibility,omitempty"`
+}
+
+// SlotifyGroupVisibility Whether users outside a slotifyGroup can find it.
+type SlotifyGroupVisibility string
+
 // TimeConstraint Maps directly to [MSFT timeConstraint](https://learn.microsoft.com/en-us/graph/api/resources/timeconstraint?view=graph-rest-1.0)
 type TimeConstraint struct {
 	ActivityDomain *string           `json:"activityDomain,omitempty"`
 	TimeSlots      []MeetingTimeSlot `json:"timeSlots"`
 }
 
+// UnreadNotificationCount defines model for UnreadNotificationCount.
+type UnreadNotificationCount struct {
+	Count int64 `json:"count"`
+}
+
 // User defines model for User.
 type User struct {
 	Email     openapi_types.Email `json:"email"`
 	FirstName string              `json:"firstName"`
 	Id        uint32              `json:"id"`
 	LastName  string              `json:"lastName"`
+
+	// TimeZone The user's IANA time zone name, UTC unless they have set one.
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // UserCreate defines model for UserCreate.
@@ -484,12 +1002,63 @@ type UserCreate struct {
 	LastName  string              `json:"lastName"`
 }
 
+// UserPreferences defines model for UserPreferences.
+type UserPreferences struct {
+	// LunchEndTime End of the daily lunch break, HH:MM.
+	LunchEndTime string `json:"lunchEndTime"`
+
+	// LunchStartTime Start of the daily lunch break, HH:MM.
+	LunchStartTime string `json:"lunchStartTime"`
+
+	// MaxMeetingsPerDay Meetings per day after which the user is down-ranked in scheduling, unlimited if omitted.
+	MaxMeetingsPerDay *uint32 `json:"maxMeetingsPerDay,omitempty"`
+
+	// TimeZone The user's IANA time zone the times are in, set with PUT /api/users/me/time-zone.
+	TimeZone    string    `json:"timeZone"`
+	UserID      uint32    `json:"userID"`
+	WorkingDays []Weekday `json:"workingDays"`
+
+	// WorkingHoursEnd End of the working day, HH:MM.
+	WorkingHoursEnd string `json:"workingHoursEnd"`
+
+	// WorkingHoursStart Start of the working day, HH:MM.
+	WorkingHoursStart string `json:"workingHoursStart"`
+}
+
+// UserPreferencesBody Scheduling preferences of a user, times are wall clock times in the user's time zone.
+type UserPreferencesBody struct {
+	// LunchEndTime End of the daily lunch break, HH:MM.
+	LunchEndTime string `json:"lunchEndTime"`
+
+	// LunchStartTime Start of the daily lunch break, HH:MM.
+	LunchStartTime string `json:"lunchStartTime"`
+
+	// MaxMeetingsPerDay Meetings per day after which the user is down-ranked in scheduling, unlimited if omitted.
+	MaxMeetingsPerDay *uint32   `json:"maxMeetingsPerDay,omitempty"`
+	WorkingDays       []Weekday `json:"workingDays"`
+
+	// WorkingHoursEnd End of the working day, HH:MM.
+	WorkingHoursEnd string `json:"workingHoursEnd"`
+
+	// WorkingHoursStart Start of the working day, HH:MM.
+	WorkingHoursStart string `json:"workingHoursStart"`
+}
+
+// UserTimeZoneBody defines model for UserTimeZoneBody.
+type UserTimeZoneBody struct {
+	// TimeZone IANA or Windows time zone name, Windows names are stored as their IANA equivalent.
+	TimeZone string `json:"timeZone"`
+}
+
 // UsersAndPagination defines model for UsersAndPagination.
 type UsersAndPagination struct {
 	NextPageToken uint32 `json:"nextPageToken"`
 	Users         []User `json:"users"`
 }
 
+// Weekday defines model for Weekday.
+type Weekday string
+
 // RescheduleRequests defines model for RescheduleRequests.
 type RescheduleRequests struct {
 	Pending   []RescheduleRequest `json:"pending"`
@@ -505,6 +1074,12 @@ type GetAPIAuthCallbackParams struct {
 	State string `form:"state" json:"state"`
 }
 
+// GetAPIAuthGoogleCallbackParams defines parameters for GetAPIAuthGoogleCallback.
+type GetAPIAuthGoogleCallbackParams struct {
+	Code  string `form:"code" json:"code"`
+	State string `form:"state" json:"state"`
+}
+
 // GetAPICalendarEventParams defines parameters for GetAPICalendarEvent.
 type GetAPICalendarEventParams struct {
 	MsftID    string `form:"msftID" json:"msftID"`
@@ -515,12 +1090,36 @@ type GetAPICalendarEventParams struct {
 type GetAPICalendarMeParams struct {
 	Start time.Time `form:"start" json:"start"`
 	End   time.Time `form:"end" json:"end"`
+
+	// ForceRefresh Read the calendar from the calendar provider instead of the cache, refreshing the cache.
+	ForceRefresh *bool `form:"forceRefresh,omitempty" json:"forceRefresh,omitempty"`
+
+	// TimeZone IANA or Windows time zone name event times are returned in. Defaults to the time zone of the user making the request.
+	TimeZone *string `form:"timeZone,omitempty" json:"timeZone,omitempty"`
 }
 
 // GetAPICalendarUserIDParams defines parameters for GetAPICalendarUserID.
 type GetAPICalendarUserIDParams struct {
 	Start time.Time `form:"start" json:"start"`
 	End   time.Time `form:"end" json:"end"`
+
+	// ForceRefresh Read the calendar from the calendar provider instead of the cache, refreshing the cache.
+	ForceRefresh *bool `form:"forceRefresh,omitempty" json:"forceRefresh,omitempty"`
+
+	// TimeZone IANA or Windows time zone name event times are returned in. Defaults to the time zone of the user making the request.
+	TimeZone *string `form:"timeZone,omitempty" json:"timeZone,omitempty"`
+}
+
+// RenderEventParams defines parameters for RenderEvent.
+type RenderEventParams struct {
+	// LastEventID The id of the last event received, sent by the browser when it reconnects. Notifications sent after it are replayed.
+	LastEventID *uint32 `json:"Last-Event-ID,omitempty"`
+}
+
+// RenderEventWebSocketParams defines parameters for RenderEventWebSocket.
+type RenderEventWebSocketParams struct {
+	// LastEventID The id of the last notification received before reconnecting. Notifications sent after it are replayed.
+	LastEventID *uint32 `form:"lastEventID,omitempty" json:"lastEventID,omitempty"`
 }
 
 // GetAPIInvitesMeParams defines parameters for GetAPIInvitesMe.
@@ -560,6 +1159,12 @@ type GetAPIMSFTUsersSearchParams struct {
 	Limit    int     `form:"limit" json:"limit"`
 }
 
+// GetAPIPollsMeParams defines parameters for GetAPIPollsMe.
+type GetAPIPollsMeParams struct {
+	// Status Poll status
+	Status *PollStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
 // GetAPISlotifyGroupsMeParams defines parameters for GetAPISlotifyGroupsMe.
 type GetAPISlotifyGroupsMeParams struct {
 	PageToken *uint32 `form:"pageToken,omitempty" json:"pageToken,omitempty"`
@@ -591,6 +1196,20 @@ type GetAPIUsersParams struct {
 	Name *string `form:"name,omitempty" json:"name,omitempty"`
 }
 
+// GetAPIUsersMeNotificationsParams defines parameters for GetAPIUsersMeNotifications.
+type GetAPIUsersMeNotificationsParams struct {
+	PageToken *uint32 `form:"pageToken,omitempty" json:"pageToken,omitempty"`
+	Limit     int32   `form:"limit" json:"limit"`
+
+	// Status Defaults to unread.
+	Status *NotificationReadStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// PostAPIWebhooksCalendarParams defines parameters for PostAPIWebhooksCalendar.
+type PostAPIWebhooksCalendarParams struct {
+	ValidationToken *string `form:"validationToken,omitempty" json:"validationToken,omitempty"`
+}
+
 // PostAPICalendarMeJSONRequestBody defines body for PostAPICalendarMe for application/json ContentType.
 type PostAPICalendarMeJSONRequestBody = CalendarEvent
 
@@ -600,6 +1219,15 @@ type PostAPIInvitesJSONRequestBody = InviteCreate
 // PatchAPIInvitesInviteIDJSONRequestBody defines body for PatchAPIInvitesInviteID for application/json ContentType.
 type PatchAPIInvitesInviteIDJSONRequestBody PatchAPIInvitesInviteIDJSONBody
 
+// PostAPIPollsJSONRequestBody defines body for PostAPIPolls for application/json ContentType.
+type PostAPIPollsJSONRequestBody = PollCreateBody
+
+// PostAPIPollsPollIDCloseJSONRequestBody defines body for PostAPIPollsPollIDClose for application/json ContentType.
+type PostAPIPollsPollIDCloseJSONRequestBody = PollCloseBody
+
+// PutAPIPollsPollIDVotesJSONRequestBody defines body for PutAPIPollsPollIDVotes for application/json ContentType.
+type PutAPIPollsPollIDVotesJSONRequestBody = PollVotesBody
+
 // PostAPIRescheduleCheckJSONRequestBody defines body for PostAPIRescheduleCheck for application/json ContentType.
 type PostAPIRescheduleCheckJSONRequestBody = ReschedulingCheckBodySchema
 
@@ -621,14 +1249,50 @@ type PostAPISchedulingSlotsJSONRequestBody = SchedulingSlotsBodySchema
 // PostAPISlotifyGroupsJSONRequestBody defines body for PostAPISlotifyGroups for application/json ContentType.
 type PostAPISlotifyGroupsJSONRequestBody = SlotifyGroupCreate
 
+// PatchAPISlotifyGroupsSlotifyGroupIDJSONRequestBody defines body for PatchAPISlotifyGroupsSlotifyGroupID for application/json ContentType.
+type PatchAPISlotifyGroupsSlotifyGroupIDJSONRequestBody = SlotifyGroupUpdate
+
+// PostAPISlotifyGroupsSlotifyGroupIDMeetingsJSONRequestBody defines body for PostAPISlotifyGroupsSlotifyGroupIDMeetings for application/json ContentType.
+type PostAPISlotifyGroupsSlotifyGroupIDMeetingsJSONRequestBody = SlotifyGroupMeetingBody
+
+// PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferencesJSONRequestBody defines body for PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences for application/json ContentType.
+type PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferencesJSONRequestBody = SlotifyGroupNotificationPreferences
+
+// PutAPISlotifyGroupsSlotifyGroupIDScoringWeightsJSONRequestBody defines body for PutAPISlotifyGroupsSlotifyGroupIDScoringWeights for application/json ContentType.
+type PutAPISlotifyGroupsSlotifyGroupIDScoringWeightsJSONRequestBody = ScoringWeights
+
+// PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeTypeJSONRequestBody defines body for PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType for application/json ContentType.
+type PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeTypeJSONRequestBody = SlotifyGroupMemberAttendeeType
+
+// PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRoleJSONRequestBody defines body for PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole for application/json ContentType.
+type PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRoleJSONRequestBody = SlotifyGroupMemberRole
+
 // PostAPIUsersJSONRequestBody defines body for PostAPIUsers for application/json ContentType.
 type PostAPIUsersJSONRequestBody = UserCreate
 
+// PutAPIUsersMeNotificationPreferencesJSONRequestBody defines body for PutAPIUsersMeNotificationPreferences for application/json ContentType.
+type PutAPIUsersMeNotificationPreferencesJSONRequestBody = NotificationPreferences
+
+// PatchAPIUsersMeNotificationsReadJSONRequestBody defines body for PatchAPIUsersMeNotificationsRead for application/json ContentType.
+type PatchAPIUsersMeNotificationsReadJSONRequestBody = NotificationsReadBody
+
+// PutAPIUsersMePreferencesJSONRequestBody defines body for PutAPIUsersMePreferences for application/json ContentType.
+type PutAPIUsersMePreferencesJSONRequestBody = UserPreferencesBody
+
+// PutAPIUsersMeTimeZoneJSONRequestBody defines body for PutAPIUsersMeTimeZone for application/json ContentType.
+type PutAPIUsersMeTimeZoneJSONRequestBody = UserTimeZoneBody
+
+// PostAPIWebhooksCalendarJSONRequestBody defines body for PostAPIWebhooksCalendar for application/json ContentType.
+type PostAPIWebhooksCalendarJSONRequestBody = GraphChangeNotificationCollection
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Auth route for authorisation code flow.
 	// (GET /api/auth/callback)
 	GetAPIAuthCallback(w http.ResponseWriter, r *http.Request, params GetAPIAuthCallbackParams)
+	// Google auth route for authorisation code flow.
+	// (GET /api/auth/google/callback)
+	GetAPIAuthGoogleCallback(w http.ResponseWriter, r *http.Request, params GetAPIAuthGoogleCallbackParams)
 	// Get calendar event by microsoft id.
 	// (GET /api/calendar/event)
 	GetAPICalendarEvent(w http.ResponseWriter, r *http.Request, params GetAPICalendarEventParams)
@@ -643,7 +1307,10 @@ type ServerInterface interface {
 	GetAPICalendarUserID(w http.ResponseWriter, r *http.Request, userID uint32, params GetAPICalendarUserIDParams)
 	// Subscribe to notifications eventstream.
 	// (GET /api/events)
-	RenderEvent(w http.ResponseWriter, r *http.Request)
+	RenderEvent(w http.ResponseWriter, r *http.Request, params RenderEventParams)
+	// Subscribe to notifications over a WebSocket.
+	// (GET /api/events/ws)
+	RenderEventWebSocket(w http.ResponseWriter, r *http.Request, params RenderEventWebSocketParams)
 	// Healthcheck route.
 	// (GET /api/healthcheck)
 	GetAPIHealthcheck(w http.ResponseWriter, r *http.Request)
@@ -683,9 +1350,27 @@ type ServerInterface interface {
 	// Get users from Microsoft based on name and email
 	// (GET /api/msft-users/search)
 	GetAPIMSFTUsersSearch(w http.ResponseWriter, r *http.Request, params GetAPIMSFTUsersSearchParams)
+	// Delete a notification from the current user's inbox.
+	// (DELETE /api/notifications/{notificationID})
+	DeleteAPINotificationsNotificationID(w http.ResponseWriter, r *http.Request, notificationID uint32)
 	// Mark a notification as being read.
 	// (PATCH /api/notifications/{notificationID}/read)
 	PatchAPINotificationsNotificationIDRead(w http.ResponseWriter, r *http.Request, notificationID uint32)
+	// Publish a poll of suggested slots for a meeting.
+	// (POST /api/polls)
+	PostAPIPolls(w http.ResponseWriter, r *http.Request)
+	// Get the polls the logged in user can vote on, including those they organised.
+	// (GET /api/polls/me)
+	GetAPIPollsMe(w http.ResponseWriter, r *http.Request, params GetAPIPollsMeParams)
+	// Get a poll by id.
+	// (GET /api/polls/{pollID})
+	GetAPIPollsPollID(w http.ResponseWriter, r *http.Request, pollID uint32)
+	// Close a poll, creating the meeting in the organiser's calendar.
+	// (POST /api/polls/{pollID}/close)
+	PostAPIPollsPollIDClose(w http.ResponseWriter, r *http.Request, pollID uint32)
+	// Vote on the slots of a poll.
+	// (PUT /api/polls/{pollID}/votes)
+	PutAPIPollsPollIDVotes(w http.ResponseWriter, r *http.Request, pollID uint32)
 	// Refresh Slotify access token and refresh token.
 	// (POST /api/refresh)
 	PostAPIRefresh(w http.ResponseWriter, r *http.Request)
@@ -734,15 +1419,42 @@ type ServerInterface interface {
 	// Get a slotifyGroup by id.
 	// (GET /api/slotify-groups/{slotifyGroupID})
 	GetAPISlotifyGroupsSlotifyGroupID(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
+	// Update the settings of a slotifyGroup.
+	// (PATCH /api/slotify-groups/{slotifyGroupID})
+	PatchAPISlotifyGroupsSlotifyGroupID(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
 	// Get all invites for a slotify group
 	// (GET /api/slotify-groups/{slotifyGroupID}/invites)
 	GetAPISlotifyGroupsSlotifyGroupIDInvites(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32, params GetAPISlotifyGroupsSlotifyGroupIDInvitesParams)
 	// Have a member leave from a slotify group
 	// (DELETE /api/slotify-groups/{slotifyGroupID}/leave/me)
 	DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
+	// Schedule a meeting for every member of a slotifyGroup in the best slot found.
+	// (POST /api/slotify-groups/{slotifyGroupID}/meetings)
+	PostAPISlotifyGroupsSlotifyGroupIDMeetings(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
+	// Get a slotifyGroup's default notification channels.
+	// (GET /api/slotify-groups/{slotifyGroupID}/notification-preferences)
+	GetAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
+	// Replace a slotifyGroup's default notification channels.
+	// (PUT /api/slotify-groups/{slotifyGroupID}/notification-preferences)
+	PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
+	// Get a slotifyGroup's slot scoring weights.
+	// (GET /api/slotify-groups/{slotifyGroupID}/scoring-weights)
+	GetAPISlotifyGroupsSlotifyGroupIDScoringWeights(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
+	// Set a slotifyGroup's slot scoring weights.
+	// (PUT /api/slotify-groups/{slotifyGroupID}/scoring-weights)
+	PutAPISlotifyGroupsSlotifyGroupIDScoringWeights(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32)
 	// Get all members of a slotifyGroup.
 	// (GET /api/slotify-groups/{slotifyGroupID}/users)
 	GetAPISlotifyGroupsSlotifyGroupIDUsers(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32, params GetAPISlotifyGroupsSlotifyGroupIDUsersParams)
+	// Remove a member from a slotifyGroup.
+	// (DELETE /api/slotify-groups/{slotifyGroupID}/users/{userID})
+	DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32, userID uint32)
+	// Set whether a member is a required or optional attendee of the slotifyGroup's meetings.
+	// (PUT /api/slotify-groups/{slotifyGroupID}/users/{userID}/attendee-type)
+	PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32, userID uint32)
+	// Promote or demote a member of a slotifyGroup, or transfer ownership of it.
+	// (PUT /api/slotify-groups/{slotifyGroupID}/users/{userID}/role)
+	PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32, userID uint32)
 	// Search for users with by email and name. MUST provide one of the query params. Returns a max of 10 searches.
 	// (GET /api/users)
 	GetAPIUsers(w http.ResponseWriter, r *http.Request, params GetAPIUsersParams)
@@ -755,15 +1467,42 @@ type ServerInterface interface {
 	// Logout user.
 	// (POST /api/users/me/logout)
 	PostAPIUsersMeLogout(w http.ResponseWriter, r *http.Request)
-	// Get user's unread notifications.
+	// Get current user's notification preferences.
+	// (GET /api/users/me/notification-preferences)
+	GetAPIUsersMeNotificationPreferences(w http.ResponseWriter, r *http.Request)
+	// Replace the current user's notification preferences.
+	// (PUT /api/users/me/notification-preferences)
+	PutAPIUsersMeNotificationPreferences(w http.ResponseWriter, r *http.Request)
+	// Get a page of the current user's notifications.
 	// (GET /api/users/me/notifications)
-	GetAPIUsersMeNotifications(w http.ResponseWriter, r *http.Request)
+	GetAPIUsersMeNotifications(w http.ResponseWriter, r *http.Request, params GetAPIUsersMeNotificationsParams)
+	// Mark many of the current user's notifications as read.
+	// (PATCH /api/users/me/notifications/read)
+	PatchAPIUsersMeNotificationsRead(w http.ResponseWriter, r *http.Request)
+	// Get how many notifications the current user hasn't read.
+	// (GET /api/users/me/notifications/unread-count)
+	GetAPIUsersMeNotificationsUnreadCount(w http.ResponseWriter, r *http.Request)
+	// Delete the current user's scheduling preferences.
+	// (DELETE /api/users/me/preferences)
+	DeleteAPIUsersMePreferences(w http.ResponseWriter, r *http.Request)
+	// Get current user's scheduling preferences.
+	// (GET /api/users/me/preferences)
+	GetAPIUsersMePreferences(w http.ResponseWriter, r *http.Request)
+	// Create or replace the current user's scheduling preferences.
+	// (PUT /api/users/me/preferences)
+	PutAPIUsersMePreferences(w http.ResponseWriter, r *http.Request)
+	// Set the current user's time zone.
+	// (PUT /api/users/me/time-zone)
+	PutAPIUsersMeTimeZone(w http.ResponseWriter, r *http.Request)
 	// Delete a user by id.
 	// (DELETE /api/users/{userID})
 	DeleteAPIUsersUserID(w http.ResponseWriter, r *http.Request, userID uint32)
 	// Get a user by id.
 	// (GET /api/users/{userID})
 	GetAPIUsersUserID(w http.ResponseWriter, r *http.Request, userID uint32)
+	// Receive Microsoft Graph change notifications for users' calendars.
+	// (POST /api/webhooks/calendar)
+	PostAPIWebhooksCalendar(w http.ResponseWriter, r *http.Request, params PostAPIWebhooksCalendarParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -824,46 +1563,46 @@ func (siw *ServerInterfaceWrapper) GetAPIAuthCallback(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetAPICalendarEvent operation middleware
-func (siw *ServerInterfaceWrapper) GetAPICalendarEvent(w http.ResponseWriter, r *http.Request) {
+// GetAPIAuthGoogleCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIAuthGoogleCallback(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAPICalendarEventParams
+	var params GetAPIAuthGoogleCallbackParams
 
-	// ------------- Required query parameter "msftID" -------------
+	// ------------- Required query parameter "code" -------------
 
-	if paramValue := r.URL.Query().Get("msftID"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("code"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "msftID"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "code"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "msftID", r.URL.Query(), &params.MsftID)
+	err = runtime.BindQueryParameter("form", true, true, "code", r.URL.Query(), &params.Code)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "msftID", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
 		return
 	}
 
-	// ------------- Required query parameter "isICalUId" -------------
+	// ------------- Required query parameter "state" -------------
 
-	if paramValue := r.URL.Query().Get("isICalUId"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("state"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "isICalUId"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "state"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "isICalUId", r.URL.Query(), &params.IsICalUId)
+	err = runtime.BindQueryParameter("form", true, true, "state", r.URL.Query(), &params.State)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isICalUId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAPICalendarEvent(w, r, params)
+		siw.Handler.GetAPIAuthGoogleCallback(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -873,8 +1612,57 @@ func (siw *ServerInterfaceWrapper) GetAPICalendarEvent(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// GetAPICalendarMe operation middleware
-func (siw *ServerInterfaceWrapper) GetAPICalendarMe(w http.ResponseWriter, r *http.Request) {
+// GetAPICalendarEvent operation middleware
+func (siw *ServerInterfaceWrapper) GetAPICalendarEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAPICalendarEventParams
+
+	// ------------- Required query parameter "msftID" -------------
+
+	if paramValue := r.URL.Query().Get("msftID"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "msftID"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "msftID", r.URL.Query(), &params.MsftID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "msftID", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "isICalUId" -------------
+
+	if paramValue := r.URL.Query().Get("isICalUId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "isICalUId"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "isICalUId", r.URL.Query(), &params.IsICalUId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isICalUId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPICalendarEvent(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAPICalendarMe operation middleware
+func (siw *ServerInterfaceWrapper) GetAPICalendarMe(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -911,6 +1699,22 @@ func (siw *ServerInterfaceWrapper) GetAPICalendarMe(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// ------------- Optional query parameter "forceRefresh" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "forceRefresh", r.URL.Query(), &params.ForceRefresh)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "forceRefresh", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "timeZone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timeZone", r.URL.Query(), &params.TimeZone)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "timeZone", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetAPICalendarMe(w, r, params)
 	}))
@@ -983,6 +1787,22 @@ func (siw *ServerInterfaceWrapper) GetAPICalendarUserID(w http.ResponseWriter, r
 		return
 	}
 
+	// ------------- Optional query parameter "forceRefresh" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "forceRefresh", r.URL.Query(), &params.ForceRefresh)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "forceRefresh", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "timeZone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timeZone", r.URL.Query(), &params.TimeZone)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "timeZone", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetAPICalendarUserID(w, r, userID, params)
 	}))
@@ -997,8 +1817,61 @@ func (siw *ServerInterfaceWrapper) GetAPICalendarUserID(w http.ResponseWriter, r
 // RenderEvent operation middleware
 func (siw *ServerInterfaceWrapper) RenderEvent(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RenderEventParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID uint32
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenderEvent(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenderEventWebSocket operation middleware
+func (siw *ServerInterfaceWrapper) RenderEventWebSocket(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RenderEventWebSocketParams
+
+	// ------------- Optional query parameter "lastEventID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lastEventID", r.URL.Query(), &params.LastEventID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lastEventID", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RenderEvent(w, r)
+		siw.Handler.RenderEventWebSocket(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1372,6 +2245,31 @@ func (siw *ServerInterfaceWrapper) GetAPIMSFTUsersSearch(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
+// DeleteAPINotificationsNotificationID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPINotificationsNotificationID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "notificationID" -------------
+	var notificationID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "notificationID", mux.Vars(r)["notificationID"], &notificationID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "notificationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAPINotificationsNotificationID(w, r, notificationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PatchAPINotificationsNotificationIDRead operation middleware
 func (siw *ServerInterfaceWrapper) PatchAPINotificationsNotificationIDRead(w http.ResponseWriter, r *http.Request) {
 
@@ -1397,6 +2295,122 @@ func (siw *ServerInterfaceWrapper) PatchAPINotificationsNotificationIDRead(w htt
 	handler.ServeHTTP(w, r)
 }
 
+// PostAPIPolls operation middleware
+func (siw *ServerInterfaceWrapper) PostAPIPolls(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAPIPolls(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAPIPollsMe operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIPollsMe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAPIPollsMeParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPIPollsMe(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAPIPollsPollID operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIPollsPollID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "pollID" -------------
+	var pollID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pollID", mux.Vars(r)["pollID"], &pollID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pollID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPIPollsPollID(w, r, pollID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAPIPollsPollIDClose operation middleware
+func (siw *ServerInterfaceWrapper) PostAPIPollsPollIDClose(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "pollID" -------------
+	var pollID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pollID", mux.Vars(r)["pollID"], &pollID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pollID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAPIPollsPollIDClose(w, r, pollID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPIPollsPollIDVotes operation middleware
+func (siw *ServerInterfaceWrapper) PutAPIPollsPollIDVotes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "pollID" -------------
+	var pollID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pollID", mux.Vars(r)["pollID"], &pollID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pollID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAPIPollsPollIDVotes(w, r, pollID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostAPIRefresh operation middleware
 func (siw *ServerInterfaceWrapper) PostAPIRefresh(w http.ResponseWriter, r *http.Request) {
 
@@ -1726,6 +2740,31 @@ func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupID(w http.Resp
 	handler.ServeHTTP(w, r)
 }
 
+// PatchAPISlotifyGroupsSlotifyGroupID operation middleware
+func (siw *ServerInterfaceWrapper) PatchAPISlotifyGroupsSlotifyGroupID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchAPISlotifyGroupsSlotifyGroupID(w, r, slotifyGroupID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetAPISlotifyGroupsSlotifyGroupIDInvites operation middleware
 func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupIDInvites(w http.ResponseWriter, r *http.Request) {
 
@@ -1810,8 +2849,8 @@ func (siw *ServerInterfaceWrapper) DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(w ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetAPISlotifyGroupsSlotifyGroupIDUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupIDUsers(w http.ResponseWriter, r *http.Request) {
+// PostAPISlotifyGroupsSlotifyGroupIDMeetings operation middleware
+func (siw *ServerInterfaceWrapper) PostAPISlotifyGroupsSlotifyGroupIDMeetings(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -1824,25 +2863,150 @@ func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupIDUsers(w http
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAPISlotifyGroupsSlotifyGroupIDUsersParams
-
-	// ------------- Optional query parameter "pageToken" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAPISlotifyGroupsSlotifyGroupIDMeetings(w, r, slotifyGroupID)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "pageToken", r.URL.Query(), &params.PageToken)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageToken", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Required query parameter "limit" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+// GetAPISlotifyGroupsSlotifyGroupIDNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w http.ResponseWriter, r *http.Request) {
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "limit"})
-		return
-	}
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w, r, slotifyGroupID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(w, r, slotifyGroupID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAPISlotifyGroupsSlotifyGroupIDScoringWeights operation middleware
+func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupIDScoringWeights(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPISlotifyGroupsSlotifyGroupIDScoringWeights(w, r, slotifyGroupID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPISlotifyGroupsSlotifyGroupIDScoringWeights operation middleware
+func (siw *ServerInterfaceWrapper) PutAPISlotifyGroupsSlotifyGroupIDScoringWeights(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAPISlotifyGroupsSlotifyGroupIDScoringWeights(w, r, slotifyGroupID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAPISlotifyGroupsSlotifyGroupIDUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupIDUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAPISlotifyGroupsSlotifyGroupIDUsersParams
+
+	// ------------- Optional query parameter "pageToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageToken", r.URL.Query(), &params.PageToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageToken", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "limit"})
+		return
+	}
 
 	err = runtime.BindQueryParameter("form", true, true, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
@@ -1877,6 +3041,108 @@ func (siw *ServerInterfaceWrapper) GetAPISlotifyGroupsSlotifyGroupIDUsers(w http
 	handler.ServeHTTP(w, r)
 }
 
+// DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", mux.Vars(r)["userID"], &userID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID(w, r, slotifyGroupID, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType operation middleware
+func (siw *ServerInterfaceWrapper) PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", mux.Vars(r)["userID"], &userID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType(w, r, slotifyGroupID, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole operation middleware
+func (siw *ServerInterfaceWrapper) PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slotifyGroupID" -------------
+	var slotifyGroupID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slotifyGroupID", mux.Vars(r)["slotifyGroupID"], &slotifyGroupID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slotifyGroupID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", mux.Vars(r)["userID"], &userID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole(w, r, slotifyGroupID, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetAPIUsers operation middleware
 func (siw *ServerInterfaceWrapper) GetAPIUsers(w http.ResponseWriter, r *http.Request) {
 
@@ -1954,11 +3220,159 @@ func (siw *ServerInterfaceWrapper) PostAPIUsersMeLogout(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
+// GetAPIUsersMeNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIUsersMeNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPIUsersMeNotificationPreferences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPIUsersMeNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) PutAPIUsersMeNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAPIUsersMeNotificationPreferences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetAPIUsersMeNotifications operation middleware
 func (siw *ServerInterfaceWrapper) GetAPIUsersMeNotifications(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAPIUsersMeNotificationsParams
+
+	// ------------- Optional query parameter "pageToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageToken", r.URL.Query(), &params.PageToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageToken", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "limit"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPIUsersMeNotifications(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchAPIUsersMeNotificationsRead operation middleware
+func (siw *ServerInterfaceWrapper) PatchAPIUsersMeNotificationsRead(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchAPIUsersMeNotificationsRead(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAPIUsersMeNotificationsUnreadCount operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIUsersMeNotificationsUnreadCount(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPIUsersMeNotificationsUnreadCount(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAPIUsersMePreferences operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPIUsersMePreferences(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAPIUsersMePreferences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAPIUsersMePreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetAPIUsersMePreferences(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPIUsersMePreferences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPIUsersMePreferences operation middleware
+func (siw *ServerInterfaceWrapper) PutAPIUsersMePreferences(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAPIUsersMeNotifications(w, r)
+		siw.Handler.PutAPIUsersMePreferences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAPIUsersMeTimeZone operation middleware
+func (siw *ServerInterfaceWrapper) PutAPIUsersMeTimeZone(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAPIUsersMeTimeZone(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2018,6 +3432,33 @@ func (siw *ServerInterfaceWrapper) GetAPIUsersUserID(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
+// PostAPIWebhooksCalendar operation middleware
+func (siw *ServerInterfaceWrapper) PostAPIWebhooksCalendar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAPIWebhooksCalendarParams
+
+	// ------------- Optional query parameter "validationToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "validationToken", r.URL.Query(), &params.ValidationToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validationToken", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAPIWebhooksCalendar(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -2133,6 +3574,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/auth/callback", wrapper.GetAPIAuthCallback).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/auth/google/callback", wrapper.GetAPIAuthGoogleCallback).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/calendar/event", wrapper.GetAPICalendarEvent).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/calendar/me", wrapper.GetAPICalendarMe).Methods("GET")
@@ -2143,6 +3586,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/events", wrapper.RenderEvent).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/events/ws", wrapper.RenderEventWebSocket).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/healthcheck", wrapper.GetAPIHealthcheck).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/invites", wrapper.PostAPIInvites).Methods("POST")
@@ -2169,8 +3614,20 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/msft-users/search", wrapper.GetAPIMSFTUsersSearch).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/notifications/{notificationID}", wrapper.DeleteAPINotificationsNotificationID).Methods("DELETE")
+
 	r.HandleFunc(options.BaseURL+"/api/notifications/{notificationID}/read", wrapper.PatchAPINotificationsNotificationIDRead).Methods("PATCH")
 
+	r.HandleFunc(options.BaseURL+"/api/polls", wrapper.PostAPIPolls).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/polls/me", wrapper.GetAPIPollsMe).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/polls/{pollID}", wrapper.GetAPIPollsPollID).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/polls/{pollID}/close", wrapper.PostAPIPollsPollIDClose).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/polls/{pollID}/votes", wrapper.PutAPIPollsPollIDVotes).Methods("PUT")
+
 	r.HandleFunc(options.BaseURL+"/api/refresh", wrapper.PostAPIRefresh).Methods("POST")
 
 	r.HandleFunc(options.BaseURL+"/api/reschedule/check", wrapper.PostAPIRescheduleCheck).Methods("POST")
@@ -2203,12 +3660,30 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}", wrapper.GetAPISlotifyGroupsSlotifyGroupID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}", wrapper.PatchAPISlotifyGroupsSlotifyGroupID).Methods("PATCH")
+
 	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/invites", wrapper.GetAPISlotifyGroupsSlotifyGroupIDInvites).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/leave/me", wrapper.DeleteSlotifyGroupsSlotifyGroupIDLeaveMe).Methods("DELETE")
 
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/meetings", wrapper.PostAPISlotifyGroupsSlotifyGroupIDMeetings).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/notification-preferences", wrapper.GetAPISlotifyGroupsSlotifyGroupIDNotificationPreferences).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/notification-preferences", wrapper.PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/scoring-weights", wrapper.GetAPISlotifyGroupsSlotifyGroupIDScoringWeights).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/scoring-weights", wrapper.PutAPISlotifyGroupsSlotifyGroupIDScoringWeights).Methods("PUT")
+
 	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/users", wrapper.GetAPISlotifyGroupsSlotifyGroupIDUsers).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/users/{userID}", wrapper.DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/users/{userID}/attendee-type", wrapper.PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/api/slotify-groups/{slotifyGroupID}/users/{userID}/role", wrapper.PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole).Methods("PUT")
+
 	r.HandleFunc(options.BaseURL+"/api/users", wrapper.GetAPIUsers).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/users", wrapper.PostAPIUsers).Methods("POST")
@@ -2217,123 +3692,253 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/users/me/logout", wrapper.PostAPIUsersMeLogout).Methods("POST")
 
+	r.HandleFunc(options.BaseURL+"/api/users/me/notification-preferences", wrapper.GetAPIUsersMeNotificationPreferences).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/users/me/notification-preferences", wrapper.PutAPIUsersMeNotificationPreferences).Methods("PUT")
+
 	r.HandleFunc(options.BaseURL+"/api/users/me/notifications", wrapper.GetAPIUsersMeNotifications).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/users/me/notifications/read", wrapper.PatchAPIUsersMeNotificationsRead).Methods("PATCH")
+
+	r.HandleFunc(options.BaseURL+"/api/users/me/notifications/unread-count", wrapper.GetAPIUsersMeNotificationsUnreadCount).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/users/me/preferences", wrapper.DeleteAPIUsersMePreferences).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/users/me/preferences", wrapper.GetAPIUsersMePreferences).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/users/me/preferences", wrapper.PutAPIUsersMePreferences).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/api/users/me/time-zone", wrapper.PutAPIUsersMeTimeZone).Methods("PUT")
+
 	r.HandleFunc(options.BaseURL+"/api/users/{userID}", wrapper.DeleteAPIUsersUserID).Methods("DELETE")
 
 	r.HandleFunc(options.BaseURL+"/api/users/{userID}", wrapper.GetAPIUsersUserID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/webhooks/calendar", wrapper.PostAPIWebhooksCalendar).Methods("POST")
+
 	return r
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xd/W7buJZ/FUJ3gU4Lx07b2YuLABeLNu3MBGjaIkmxwM4Mbmnp2OZUIj0kldS3CLD7",
-	"zz7APuLuiyz4IYmSKImynTRN819iiV/n/M4HDw+PvkQxy9aMApUiOvoScRBrRgXof85AxCtI8hTO4M8c",
-	"hHklZlQClepPvF6nJMaSMDr7QzCqflNNMqz+WnO2Bi6J6WwNNCF0qf4kEjL9279wWERH0V9m1SRmpr2Y",
-	"tQaPrieR3KwhOoow53ij/q9Nd1/d6n7/zAmHJDr6tZy4O9rvZRs2/wNiGV2rVgmImJO1Ikd0FL1IUyRX",
-	"gHg5IuKWjGjBuH4W55wDlSgXwNVEzs2bhC7PUybFeR7HIMSZHXcU9fuI0D/MS5ZsfAuqWiGcLhkncpUh",
-	"DjLnVOjVXOKUJEiSDJBQ/SJME/WAcEWENcSSXALiWBK6FFO13g8U53LFOPknJK85Z1zNvEFGPTck2Seg",
-	"iAiUESHUFBhHhOoRNcfs0lT7F1ICTQDafZ3itUCc5ctVukGSoV9Pz3+6QMX7v/+wknItjmazFDCn04zE",
-	"nAm2kNOYZTOgB7mYLTler2Z4TWYcBMt5DGKGbft/uyRw9Xf9xgEHIQ+eTg//UgnB42jSEImi4YWGUj/L",
-	"XrjvXk8iyDBJVaMF4xmW0ZH9pQSmkFyh1hGSc4llrgcGmmcK2pRRiCYR40tMyT+BR5MIqMSKTelGEX4t",
-	"IYkmEa7+TCBOCdV/UiYNZBJIlETQPE3xPIXoSPIcWhNpiJWZbluQJiX/XlxikuI5SYnchPISe9ruylfs",
-	"9OXjcTdjQ5n6EgvNVNxYcV/bnzjAy1xsLFeb5K11Nalm9LtDYD1si7AJ4RDLdIMyReEWZVWjXSk6xwLG",
-	"UXJrEXmRJBzEoFl47b7rhWrxcFKfUx+Ai0l7gFsSuaWFDn7LDw+fg+r0JhTSYy3jRvzLNU4ipmeHU2Pl",
-	"dD/O0ipdcoxToAnmry+tEQoRSlAvb7sY3Xhr1RruGJRWw+NmzJVFPPrSJkfMASu96OrhBEs4UEbQp4uB",
-	"Jhfq0dGXIXU5icgxTj+cJN6BScfP4hjTGNIU3OdzxlLAVL3wByP0w9mbUM69o0rZnwIok31CF8yi03az",
-	"LU+Z7jYz3RK6YF7+Kp/mgMOagzBWiVHF6EG6pcw4ReG8f2Nb+HhfGcdAmp1BTNYEqLS0crXHtgTjRZ9d",
-	"Ij3sBQiJuQzGnsiNNvOB7Armbwj95HnWtEGlELpM8WnM1w1VPWCRNJ11G2QbTZAAQApKaAUcjn6tXrFv",
-	"oHPJ81iiVyzemg2auNj0F2i/qjUNs4hiw50BqpaGSL/vJ+dabs7z5RKEpvkZYLs/CDJF4G2+LdGsmCuN",
-	"KKo+OYg8lUMWqkTQB2odmhQcC+z+/I5/oJ8ou6Iu2OrNSlGu/5zbdj571/CyQim44ADzstltOKAFxdTI",
-	"CluFHx9NIjURtXq2iCbRFeOfCF2+TgVcKUkZWP8JvSQSjrWZa6/ePEXGCha7W6StZVMQrKV8IUfYys9r",
-	"wjev7Mi1Nr7XMxACL8GrstR2lCw2P3OWr09e1brLCZXPn1UdEiphaXbjkn0QwEPfb4hpY0int2qqE4cs",
-	"tfX6hNoQuwuIlhXCPHYkyLt100Ppv4roRjfzhV5Ce8QzWAAHGoNAGJ2b1SI7jZ8YRxiZhrePhAVnmaL1",
-	"6/A9ctHkJ8KFfOvXw9Vbb3DPS0STIBxlvbgtud3nvtSQUeJ2xOpNg/61m3d6Vt7cNFU8c2jiYr/OJh8P",
-	"PBSvr6499dZESyK6wtYtXwbt4gVN3uMlocYtbAUzzXrCHcyaJHmcTAqf5Xu8hAv2CehW6qaYUbOvnpWe",
-	"wiihvguCXJ+seqxDp6hA9paC3tXtQr2EKM56Z+fKRFdPKe7paAeNUR/PdIQqEes1hP452zfQUr3SOeVt",
-	"FFOggmiqhdaUfTpkQGcEqoByHxi41Sve39bBK/zUsL1Ex56fdmlszlhWhKAKdyCBBc5T5W3EjFpRP2Ms",
-	"iybRimVQBbnmuSAUhKh+WQI7ZownSilqRSMkB5DVCysmwQaQJM45ptK64ulL25laEhMSl1u93wO282aY",
-	"gF3rdQ9HjxkVkmPSGbRq+/Bpq+mubI7LngIZLs5KefEFc7YPdFRrOpGQ+SyS3aq5EtGcQBi99QCB8pR6",
-	"W++P7IpGo/ftffR8v9oIEld4vp5ECRHrFG86/ahiVs2Qh+/YhqWXrVMQDxdcreoO7+9jUi7OpwIVH0qH",
-	"fxf90/RPkr6AhRpVqez2oCPOuRa9/msa7rkWzoRjU8rW3tmbCMcFyUC5TMEaJqu32xbnOrKSskClAnRE",
-	"uFoHDUNfb5KRJlHRwxDVyrhQqKbIfI33GqEadz7VlNJRRw61xh5drEw1SZSprrOC5SaAZd+neTY3DuJ+",
-	"I+BZG959XTWlQcfQEyPb5dy7ndsySNeh+NwYdcGrKr4Z4BK8Ve4kiTt2dqNPdEiy+0bfpytbMSKvEDUN",
-	"UKjqWdfbfQ0TG3uP9S9WgNSTqY/WMcup5Jt3/AyWXmWhW5uXEOOI69em6EQ+UvvZBQc4MJxCplN0idMc",
-	"JjofBz7jbJ3CBP0WfaBEQoLUxgXEb5F3LsaRPWYJ+KdhnqOYJTDt2kF1NNWPOhoVvrCvlXrmaabg1U57",
-	"8mz9m3lKyOKsyTkKV1bIg7OtCF3agd9WjZW0p8n2Xb2rGlsJAiH/YQSyTZ9iUWaTGSCvtgEk/8AdJNep",
-	"TtLp+woLlOEE3BF6tUc1xrxDGHT44OQVYgs90hq4YBRdrRgq2zbSzAKXJzoiyQUCc1GM2c5gGzT+DjMa",
-	"i3Q24zUC17DgU3UuAI5XEH96yZLNeZnv1gRzdRZRLCMBiUlarkpescKNEAMQ78ktaCSrKZupBsA2+c89",
-	"Ah3lExQpQR3G+FXOOyIVin0p0KVcFQu1TSYoAcoUXAhFT56cnL9Df/vr4dMnT5BByxQdoNdGBx79RhE6",
-	"QE+ePEUrlvMnT9D//vf/oI+P3l88/eXRx+LhM/1QTNDzQ5QRmksQzpvPfnl+eKpePlD/PvqIiEkSTOzM",
-	"UQKCLCmWjKuRPz66ePQRCVhjrpQuUlJj8geVjFWEMu/+8ugj+kGP/li/9PHRqfrFzuIxEmuIyWJj+Kw6",
-	"KEZVzU8WiGVESkgmFhc6JlLNjAj05EltUT+oFen1PJ7+RvUBiiZUdBTZlXrD6kSmHhWvdkEN3gzKk+mq",
-	"zX7nLNQrNHX92gwvvCt8rXdF+o+erAkRHS1wKqCZBEoWqPTQUMJAIMokoqC0EENzrQ45aMJSJEAiyXOY",
-	"Kpqr5VZNLRoYTTeFTiO0DtdPAGukJ1GRx4l+ZGIhi8yUV345KN0YZHNpis4dhcrSpJsLk4hd0c74sxpC",
-	"bxbLvtTL/o6Htq8NftcXV5uGJ/e43naEHrVq0qR61vVpSx++rlKXvAaDywaoa/qmVDdW26ATw23z3xHa",
-	"bDabgyw7SJKL1eooy46E+A/07wpLKGVXwGMslF6TErhAmCtLtE5xDAmab2yOMc0z4MqpNV6d0IIaZoYp",
-	"XJ27+TH3bIENgNRWO3F5G4iXb8ryGg9KOyKlBa63dVysNeaSxGSNqRT+nICGPQaanGG6hHsrGmnnmYj2",
-	"NOzTQXu2i+eiqGRtsTvtXteWy/vNllv2LRwctMjbEIKxbsiDHe+w444mnGxh1N+GKtIOrdi5+QzcX3ac",
-	"1j1ozgfNef81p6MtXSVaw3yL2oGC/a5PmVpd0xEDG1KbYWFs/fZ99pdHbi5HGyM1qmb7a5rcUxmzCzwv",
-	"DhDv946qaeYrMfTIS4s8DUAEqoFzQpcp7LgVqwP16ztmN+leTSKd6bRLigEdlUDQme7QuH/dy0N78l2/",
-	"jPkToQmyC0em6bgjPOXYHSwITdyzb9+53W/54eGzv0o8F39X/f/FxuwPFKb2ee2uK97dEaTsToaqZ3qN",
-	"S4bSmh9/PsY0IQm2Cb4BXtNDUP7bCcpbwvuTX+NcSJahBYFUz4MINW4uIClLNrw4QRlLwKseMkJJlmcF",
-	"pt8Dj4FKe+YfkLehqBKO34v6232373xS5BUZny/rUqw1xwD15isvsUuaj+opT+Vt3kdrGoyu63X9V8y9",
-	"rRoJNtXzYBXqT6TylTVpc8rJre5K+Ava6+8hB9CdS3XhrBEcDhqnc4iLlnwFJcvUIb9Lmt7YDGAcS3JJ",
-	"5OYVyzCh/ns5Ns9qO7zYBK3eAjjVCD6a3nDaZjgGwxM8NQ5HZ3mqhXbh8lvIUlXzH7rXtMU9pIk+PglH",
-	"3wfhO0hpLM50OXyX6Vrfnlkwz3nQ+xMlwDHLspySuHBcykSW4vwJXRG5QqelDEdlgKhQSOjF+5NoEl0C",
-	"F6brp9PD6aHeuqyB4jWJjqLn+qdJtMZypSmgZR/ncjWLcZrOcayvyy9N7paiuGbASRIdRT+DfPH+5EUu",
-	"V8fFq6ojjjOQmrC/fomU7Ed/5sA3hQY9imKWQOSSzYRZqwpQLfz4+zGJaGM6+r1ReOv54bM2A6zVX+Qp",
-	"UnSIJtEKcGKR8qY3NPrh7I3iHQejitXfeCGBI1HvE6gk1aFEOd3KDVSKWulpFuN0xYQ8en54eDhLsFjN",
-	"GeaJL1VO15LKswzzjUJRLlfKK5E66oBssSphPFLFALRI2dVU41ezPLb1UUzZkgGe14upBDFdbXj1Tndn",
-	"thNxYuuLBHRW3TZo8v7Z4eHeypLV6eGrQFbyP92gJTN101BBc1NnRl/C+NHMqt76JU7K/DX9ztOuCZUr",
-	"nLULlF1Pon8duWZPPmZjXSwDuVJ7+SugEl1xpkP3EjjFaboxYz67hTG1MsQUwWczttZ9dZH4GWSD4mi+",
-	"cQNBiU8cjEULkIVTCBMEYSNn3dANC+P5ezd3F3bse1dRCTKnDZlp2dUHGbqjMoQ14R+JJumNoUFLcgnU",
-	"hEk4pkuYFjnnbQF6z0RLgniVqHOT6rkuIdctwD+9PdugHzgeQrpBxcWJBzDfJJjNnghhROGqAWafJfiS",
-	"6yoo14H2oKyZcj9swqSJwbfmBMo5L9H6WDKkiDMxM1HbimoieUGSgLl0l494sE4PAt1nnUy0nsSjzVQh",
-	"8ZZXlZg39LWQeJ4SsdKlRoTkgDMUM0oh1rsrvQGMQVcMBpxqgUL5Wh/LIDxnuUQcaAL6GpfE4pNAlwSj",
-	"c+CXwA/O1Ypfm5n+cH7++vFUF7l09cyZbl3svQZkQcJnaVZ0YKbaV+o6wXLQntVuIXpis5560oo6ktCc",
-	"5aKgF1sgYRYs1IINyaf1vfYxjldwcMyo5MyT/PaWoRjHGiZEIJym7Ko87SDFQNOod4cZHZd880Rikksi",
-	"wJwzxSlR85TMJPfrnyqWszXQgJEUTw78tVUvVoBOT05fI9XQKNRyDfqsq8nG/uEa0YDzfK4Gm4NaAHUY",
-	"KCzlbZelCKwAp3IVr2Aw/POL8+aOinlQLzhjOf5SQwm4L+kAiLMsp/pRr0d6UtYkugl3tFaa7pa90Xo9",
-	"pzaJzawSpWhtTR7XMb15+/Xj4Y+eiFy9sg+TaMFymjj2bqTN6nYDzaJbiBkOA1SlqVoeX3+9u67Ipn44",
-	"hqlltaJbcZCq9QY4Rz8zqa83WItsiVrf82j7vi5C5tOvBDXltbsIa/sXaVrOX9malC2XJglCLe7//vO/",
-	"rCyL2lqacPpSlI26NlNIwRzO1JH1Sv9egeukqjXVC7G2a25lWTLriPj9c6eW1Vf10AcNgSFM4i7tDqgp",
-	"K9n71k9msZVG1vEULOOVx3ypn79RvGxnZ5tJxIGFKooX/QdzQ+Z4z2j+oElcQ7OdXw3V0/sFa7Nqx9Ew",
-	"GzxthQv29OjNmakPqzEwShjMldFvT4U+3S/oio+k+PTnPUOaWaqFlvEh7aIxTRBOEpRBNjexq1oRyV7D",
-	"PbNViccj8JVt+L1D0NLhO0BgsVLHhhfAysRCHmi0iYEdRlngTgwhp0wKqddC9e007KPwlIkb2UxUtfsC",
-	"NhON1QmUKTHTwbQVIL0+5JAnAEjoB5gup5Pis1zOmXBFv8e7xmXvVIy0iZD5xiWcmHrxObwJriBqz/L2",
-	"AZx24uC43WYLL3dgqxC0zWxOXO2cTYDT/fpeB6++LE39/utgllUF/0eapSaY+o6AluUg22dt7TNzx9E8",
-	"fiA1F9cGz16B0VLdu1uib0H51HJvvDCelcmaY8D8waZj9kfkupC8Pwh3nMxS+Cz1F4m2aJuSjASeGd9Y",
-	"oKadg1t8X2mwOPa43NuyzO4+82/bKrkRkiwFbxchf75f0XPs0qQlSSRBRFRfF304DtZ21Ozu9M3RlvJp",
-	"qp1QLdOhWG5fyB9k+ivItMdw/4RJakrJLcE4ZgLpr2iUgHuQR6j88jZx2mI4E4C5iauESOO5eXvA2Ju3",
-	"qg2i9qcrpaAHnqAYUzQH5zMqEyRybv5g1VdbvGd3xTTumDdwi4riQTE8KIaxisFHGDTHAhLEqBZBHa01",
-	"klcqi1ouy+yL+6/aM3DASUB81k1pEm9rfZypHvxmvr4rqA99509Qa+mKuT2AorXUrr1LgkvZamM7/Trw",
-	"rwHwFPNPCNfWj7BAc1AdKBA5biKHBQexGswhOrPv3XQUvcZJOzlIENa/IqlV4g68rNHJrqn8vpo7iBZP",
-	"O775pUa04v7irEwqG6Be0eC4TC3bfyZWX2XuGzgJbnxGANOXUK3TU09JjYnIwlQm1oWAbHza1k8RKbP5",
-	"rI1yNfoC6QrTJSDJvDWIiaiq7J0yDifZmnGJfXfLL6pZ2MNZW6FtgTLGAZGiKZIrTLsr5/R8fmkA1Roy",
-	"kLjl3EX5zcgf9606eg4GzK2xBEv8+FtL066nvOnsSNIqc1T4vdxBpU+GLXlmti7VCGm2lZ3ObMObF+t2",
-	"4d+bS/Eo6qEMKuny8wfMxXRTch7AvVs+Z7HOXirb6wCOYpv2Ql7o4mXjEW+Knt0e4FtF1h5gf79hfxaE",
-	"9vkGESkgXfSC/Iv9Y/j40KPZbcvx54jtr7X0HiVyZ6SvutkKkszy80WDd8dAdn275kEytpKMIu7YpqhQ",
-	"8lCsniTTUKEITz7slo9t8xC/qpTciulqfdTjBu7IjNtW4yJZssYAExzQU76vGZMesG0pMXHKBGxtTI51",
-	"6+/GogyiszD2mqoP14pvNjxtL2A4n8sLRz3L1sXdnrE75AL6RRffu6V4qOLxIJ3jqnjY4msF+Asqhhst",
-	"Djo+uYubdwbFl0i/VdN1s+cVijrfj2Nl0NDpWA04VAEZ2C0gdmdi95Oq3dHD9nP/28/i7KYq6qmPenR5",
-	"CSfHu/zo5BWtJXxzxjIxw2k6hAr13os0jW7jMof+VkFAmv5Xy8HQVHvIwfCjdM2EIHOFUUUlB2uVbp6J",
-	"ompzr0vbKKZ+Q6Hv7i9ShAe8+2HWXxT+QStupxVPEsjWTA1tqrRM0B+59tFkzqlAeL3mbM2Jcu10ASVz",
-	"1o3TEs7mOzhqkRBLXWwJaxXqQtakKTjX6/oB69RxvzG4tmvF3/JOplY538dG53mxhem6MnUriBfuhHa+",
-	"ite9d3DH6QTRsAdWQ1FoUdiyVkg00l3/pi6HjC7QLmoSGeqA1BE+kEMqGkI/Ppe0cd2wjpdv5rJhY9o9",
-	"twwbAvHFpWBoQZuakJzXOhi/Va4pCMmQHd27TxbNsb6NQje1JY6FVK9CJcnjm6vY9XPzDqO3sE19QsU2",
-	"eBKsZPeNn84gy90Czy5GXumsAUzdCh72ElSxZT99IArUWW5hwO1QV1UM/MrF3yZb4b0qacZZdhvgn3w/",
-	"flBoOcbGJ256PA1s7i52VNK7a0UaB0vo4XrVnXCpTQFfwqz4HF6fy9EjuW9UJ6ewu9lY4Uuo6mDruWlx",
-	"mt4LT6T8qHotYJfCQrrEKDh4hxH4i+ILLko+VWzaHoYhd4Z7ADiyRoE7+oOmDul6sMZRV918e8vz61Qm",
-	"8Xz17LaD6DdUtaCx//gaJQtCKgV0xINCpD1IpN9jLglOzZVGNWTx/QRzg1iZxg4PcRiYk67B9EXKMWPd",
-	"jQJhHdeBO8ShVhDMFPpvlAWb7rxvPnm1n/j7Lhuf85J59vqsDozPNxZSmCaa31N0+uH8Aq05uyQJIEah",
-	"sCW1ml/orIi9owx/Vq88PbT4ADH8FZ8C8zcRNXe+YHnL0XIDPD/QhqLjOwCrO0rdCMaZQgmDQWnNnN1r",
-	"sm1DqZ+Lb6Hsn0q3Hyl1Q6KPBEpAYpIKDz9mKVuyXA6eOVnGvDFv3+qtb1u5nOUSMYrmOP4ErTWbeXWB",
-	"rn79PgyCtbv2t5KP8LZxq33Ul3x8V+On39Xd+KI4wyOBcsoB12nRxr77mazBcwANiq4vZYV+b6ov3n83",
-	"PjkVHOffi6LUnZQKcqyaK6PyupugaPxeuHjHvxp2B03fVkd9dbbqF/R3mAyzcp76vj78t8O/HUZqU1E9",
-	"F0czJfRTO7WpwFiupglcRte/X/9/AAAA///5KS33ncUAAA==",
+	"H4sIAAAAAAAC/+y963Ibt7Yg/Coo7lOlOEVRsp3s72xVnTqlyHaiOnbsY8lJfSfbk4DdiyS2mgA3gBbN",
+	"eFI182ceYB5x5kWmFi7d6G40u0mRujj6ZZmN68K6Y2Gtz4NEzBeCA9dqcPJ5IEEtBFdg/vMeVDKDNM/g",
+	"PfwzB2WbJIJr4Br/pItFxhKqmeBH/1CC42/YZU7xr4UUC5Ca2cEWwFPGp/gn0zA3v/2LhMngZPCXo3IR",
+	"R7a/OmpMPvhjONCrBQxOBlRKusL/V5a7q2HNuP/MmYR0cPJLsfBwto9FHzH+ByR68Af2SkElki0QHIOT",
+	"wWmWET0DIosZiXRgJBMhzbcklxK4JrkCiQu5sC0Zn15kQquLPElAqfdu3o2gvw4I66f5TqSr2IbKXoRm",
+	"UyGZns2JBJ1LrsxurmnGUqLZHIjCcQnlKX5gEoGwgESzayCSasanaoT7/cBprmdCst8hfSmlkLjyGhjN",
+	"2ogWV8AJU2TOlMIlCEkYNzOaE3Nbw/6nWgNPAZpjvaELRaTIp7NsRbQgv7y5eHVJfPuPX820XqiTo6MM",
+	"qOSjOUukUGKiR4mYHwE/zNXRVNLF7Igu2JEEJXKZgDqirv+/XzNY/ptpcShB6cOno+O/lETwZDCskYTv",
+	"eGlQaf2RnYZt/xgOYE5Zhp0mQs6pHpy4XwrEVFoi1gZEcqGpzs3EwPM5ojYXHAbDgZBTytnvIAfDAXBN",
+	"8ZiyFQJ+oSEdDAe0/DOFJGPc/MmFtiiTQooUwfMso+MMBida5tBYSI2s7HKbhDQszu/0mrKMjlnG9Krv",
+	"WdJI35ueKw3Gip1x+8H2PdTvqDKHSms7Xtf3lQT4Llcrd6p18FaGGpYr+hgA2EzbAGzKJCQ6W5E5QrgB",
+	"Wex0U4iOqYLNILk1iZymqQTVKRZehm2jqOo/DqtrWofAF4mQ0JSEG5DuhCZayP7S7ZVpb+eNiEvlF1Q9",
+	"8Z+BTWcaUjIHyomYEDftaDAs15iKHGm7GJTn8zHIOKwGfqpyB+vg5A83QuAFMja49eHf8+Pj54CD7oNx",
+	"PxkMCzZZ7G84EGZ1NLPagBln8DFycGc0A55S+fLaCes+zAuw8babMZ23FkH9UayQrhH8GqPmcPK5CY5E",
+	"AtWQVpA+pRoONZtDDPGBp5f4qQG596/Onj9//jcC3GoaQ7JkekaYVuTD5RkRk4kCPSIG7opQCU5DgZQw",
+	"brQU7EV+FxwIVVeQoi42GnRKr+GAndHsw3ka3R9r+VmdUZ5AlkH4fSxEBpTbBm85itM3ANopx9XtnhnA",
+	"mXWbAyZUEUougc4VmdtOI/KWZyvyxuMJSRzuKWLBXm1uqLq5kn8Ixj+8f90XVSvLPucT4cjRDbMtEgsz",
+	"rFsp4xMRRWhUdg8lLCQoq64I/qTPCWbCasv9kf216xFD9lJr6gmz95CwBQOuHaxCsbItwKQfs42HdcsY",
+	"IdmUcZpdaCp1nOguZ0AUfrbEg0IisaZLAigvKJGA/0e93B0eWVJF/NDZingbyNDb0NOionMgdomI2cov",
+	"YRTXY/2c3UZe0RKlHkgG6g1VGuR5Gt8dS3Ef1lyr70TPmHLUx1Rj89GlqnZYegZWwvP2WJjKreyN8Soc",
+	"878Ej6z4/PTHUzS3fmY8FUsVzM7x+ErexDzHwYUOK1hhG0hYAMU96fL0kSgzOybjhGlCEfMVeXFxSZIZ",
+	"5VNQI/ICJjTPtFFHsSfaywfBSnD38InOF7j5wekcJEvo0Y+w/PX/F/IqdkJLGL9m/CoCiroiXUjIkIHE",
+	"1JmXNX2zQ602PMH0Ia7TkChAKEwEmYGEk1/KJq4FudAyTzR5IZKtWYZhBNSO11MJL/fUzU4QJ3pAtdCm",
+	"Tfs4OBd6dZFPp6AMzN8DdU6OXnoiRLtvCzTHCxDhVDmmBJVnukt9LDDoA3dWmdGjYz+/lR/4FRdLHiJb",
+	"tVshdqo/565fTBkNrYKGNWIV9G6PkZBgx1ljSJhWROQamenT4+NeBgTSItofzeF+EEsyz5OZIXm7TpKI",
+	"nBs+sKQytW4ns5htbBW39WIBfl8xbKyZ232xcCIBxkW32/BEeKzDmREC3qEzGA5wIYPhQIgJ7lnIK8an",
+	"LzMFyxnILhz6Hic6M/z4R6HZxDkeWwBR7D9p9NgWBnYkHozUj3nZft7E9NDxBslwkC9S91cKGWhIo9tP",
+	"MgZc4ylCVHr6da79+ILqiGM8ajv8EUFBlY8LQL/8tGDSQOEF1eA1jX62VThOiz2jgVOuz9NuRl4bbBjC",
+	"O0ZHLXh0JrIMEo9RVQBd0yyH3ip7ywSdbn47S2zJ53wsPtWRnmbZ28ng5Jf1i6mtoHH0KJWipmFtca5h",
+	"c3UfG7cOJCQRVKmo15YY7mNkd3TNNFjbMqLvma/eeHR3FsTY9g3ismR0qjew7BFzVy/czJU+seZzUIpO",
+	"43SFlwxssvpeinxx/qIyXM64fv6sHJBxDVMrbbT4oED2bV9H9+qUwWjlUocBWCr7jSMXArtNqrijUPZz",
+	"oFJEHfJmKvOXv7OKsTI7pjJbiJgnMAFj2qCf4cLulrhlvBKSUGI73j4mTKSYI6xfbuA+dV1eMan0j3HF",
+	"tGz1mq5pxAwI+mPZWrwtTnsd76hgRoG3G+xei+69a9Gx8xr+B2cWwCTE/eoxxc4gAvHq7ppLbyy0AGJI",
+	"bO30ZbFdnfL0HUWPRFzQ2P309w6FY8c8RBw+6Xd0Cpd4d7kVu/Erqo+1ZqdvYCOivg+EXF0sfjYii3jM",
+	"3pLQ24adYCPju1g31GvaPVJG1wx0A45Rnc8OREoSWysI42t2LcgUm7QueRvG1JNB1NlCY8kxHtLBM3qy",
+	"gMKJ29NP+1rczFLJxCb2Caz1Gln/kPOWFC5KIeZDwux//GzGN2k+RT2SLTcUvE0w4EB1oym1XjgEuOCO",
+	"o7wXYj4YDmZiDuUN6ThXjINS5S9TEGdCyBR5r+FnSksAXTaYCQ3uVk3TXFKunQsk+84NhpATStMCWB/7",
+	"eDzNNLiJ7uiEVsQ5E1xpSRnXve3+rNH1ptiUFCP1wys0GTxZxq6etr8MKfd0rmEevWe2LrKQ8CIGTg94",
+	"mwl6km0W7b07sCOMNvaXroPnu9lKsaTEZwy4YmqR0VWruuZXVXc1R10O2XUjhKbDzAynj48xLDYX47R4",
+	"DoVd0cPL0ddbzNJ1jmKcFSXDzSIt1qrJWX8F2essgegqekdXbz3L6MJBzaw3h5lX+22L58ajnYmeTAX4",
+	"Bnf45oarb/M6GHk68CN0Qa3wx/flFPNY553eDGwW3FSn0o3iMCqdI7wYRTVL/a1pD2/8bm/J5030XjdU",
+	"nRrM/XQKsrL2dh26uBxpYXwBciZCwncS6FWKLu+u649M6Itqj1LMMcHLu6keakXdlRi1v/qTGUv7WhhX",
+	"jKddOw0X9x/YvsOXsaCrTFgfJk1TZqOj3gU7stpW7Z7I3GLmEq+uGWRpodmGvsshWc5YMiMpoDeLoIar",
+	"FcE9mLjia5DKtILpiFhD41cJCbBrSMmMKuKNjyGpOu2G1ghChmwG8obF+YsR+THPMhOc7bZcWZBac8de",
+	"nq5bV5MX/WQ/+K06wBEL+CIiHPdXucvq7SpIYy7IgTv1cl3lkX3swE10onPI4vdy1quMRocFEaQ2EMSc",
+	"j5hUAIfn8ytdLKrQNJENSgvEAjwHBVyTjF3D0Br+61ubJpC6tr+mDCmxxwTGIZ5Slq3cLLbnkHDBgcxz",
+	"Dao8B/IiN9EL/8wZaDITuVTtW+FCl3so11hruaRMF4dt5x4Fjl07+mBY6BHh9hD9BI8HHEYO7p30bp8I",
+	"lynPti83cKNuz0hqCOvw0q+kCxv/w83ZjN3xKEdr7IPZeJ1M2ZgRE6wiJoaLKJDXIA/NcZnQlFGc4onA",
+	"0LoZxQMtWuDRGtRy8Xg+3u7XsKsdVoVnWxJnjV2Vv+CQg+HAMKhfMZYO0uK/c0AJ3fJrBhM9KKTtryX1",
+	"+1/m4rry//JVSmpVffe/X919T/3n4Mah0hiPyvy6EFkWzGv+ey00/JpQpf0PSSZUZfF2MeFS7BcJeFxp",
+	"J6qXOB5xnoQNyaJsaZEF+dfQII8yAVgi14QSh4741WDNXChNFiDNs5NrIM4H4rm4u1oLJYwa2rcpSMij",
+	"6HU0h6y/crWesCMal2FWPyCv2mTo/yx71Qm1WHIXif5nZebqWbwwLHdpQsh8GFs9iouklt1aud/Ky8e5",
+	"rjJbQ+oyR3K9BknmLOUYz4EeMtQcmCJjmAjpYhhHbVZNzfNmmUrA+ofkhx9O3rypxpod/38nx8dGqGoN",
+	"Ejv+t69+OX768Zfjw799/O/Pfjk+fP7xyckvx4ff2p/+Za2lVFeTqNR9FvHs2Y0XUb/nNCsycdidx45X",
+	"020XmK9YpkFakWrun8l4RZYz0DOQtRM2XHYMwIkEmoaMM+f4i+E85h+aZZ2MoeuuZ4u7GfPiqZxgg2ui",
+	"evxAVyxCdZ4+lz+VreOBfOdC8ds5oonSmVN5hTIMITskNMtqjM2CvnZSrKrenb9QTg8kGLjaIK9a2wrc",
+	"+kC9AarG7uuetL4+lEW13134CpPo4zZUa/BL1JVvQuDk6q18D9OopWF620YoiqRpNiLn+gCvCCYS4NDC",
+	"ndhBiYl9GRq11DGVIfn74ANnGlKCpA3q74PoWqxH/kykLVHj9jtJRAptgdK6PeBct3XyTv1YL/wW6YaI",
+	"805kEXPmlKBuYnZfxGQijqD2gmanFUdiQpSYA3FGP6T+jWsRJ65mIs9SMkZOFxP8QgF/awOlXrQsPhM2",
+	"MjoMoi9DqongidVKzIKZIlafituLLZZqQFhbXPWmQNOMxaLEf54Bj6zNX1NZz4wCaWxz5BX+u64sf+3s",
+	"4F9TrWO31adXvtf5i67Afw/zAN6VhR+Umv66g+h+R9TbX2Mfm/UXNIjeFsHa36tsEPoUC7BqAtDeKBew",
+	"QIxd5OOMqRmkRAtzT0n5alsc7XcljTsvI2WQcKWKL5elKhRxiixngiSUG2ofEsaTLE/tq4/g7HH1N5BZ",
+	"dkmbHeRPQkOnomCcPuHBehoJKDW4J/cIVYDIL6zrCh0XdIYoHlcr8LMlgUq4YJMJih7sT/jIw5AoGR+a",
+	"/wsO9rVMYZupfLEQUjulxGsgvdxn8W2aydftsxkXOSI/GXgaKwVXVsiRodsFGukF4lmScckRqki25nVm",
+	"D1x2QuvG2Bpy+Q05c7+42BqP/jiM7G4TfryMC5+hdfYYMwrB7R+N+oNChHUAtOLZoGDlNGmaGj5mRirO",
+	"Y0QuZ6UvoAjBwDnsY0sF2iy+euLSHFSKCjdqG/bX8l3mjVi+zdoxHMzpp3Pb89nxcDBn3P3vafOYu9j7",
+	"qVvh0gDKI7HHsI091J4zlVyowLM2nvN2Eb/XO7W8otTFjFsXD989ppvh0xHKV8QyOvNPSoAmM7KkKwN9",
+	"FvcEbBZL31+Yz+lqHF6hVMzK+O+VV4T91rMCFRsrJjbK0cvn1nYAv1izsvVHE+eTpwUrdydUns8OYL4x",
+	"WGK+jdrG23bZ5tfwBkrunIkW9SgnYgHc/M8oFWNwmCc4yblmmXNKO3WRnBYMa2KuM1CKIaMpeB9aFPxA",
+	"40CeD9KJBmlaefKxbK7UsHFmpVkhk1nligFXOBgOClesnTnqUCn0kMgJe+uItpLievHfh2byjbTVa7fS",
+	"PprV2UywBBqoUSywmNsN24YfOJangZvtdber71y0GyJmxhnfHLWc0+DSnF4BocERGykZoNQaptFEJhXn",
+	"GeYTIpSe+fRRAWVJWGQ08Xq5c04BlRkDSa7LrkK5zk3k204FL8RquyCtPyQy88Rg/77ygD12p+rp3j2U",
+	"HhaxK9VcPO+snxdSUg65rfNq4ccqn9dHPVdW5bFv6Z0Nb73rE8KBGZwp38XjV4mfMRDYXZbpob1NE9wr",
+	"XXPPI1Fp/ztvnFhKV+rt5GeAqzgDxu+EkiXAVbYqYMcQFazendKVm8oFfZcrxHWLOdPaWu29cAJXktJo",
+	"lI3banydJkw8NZCoJA9wu8fVMo5v/xuekHhcuQZ5TZ0jy0bkIlI257UxPQgBhNTQAArlBJkLrmd4K6KX",
+	"AOGSqil/PLOaM87m+TxE/dBLUfaOb79cRoggPgOEQai64W1Oa4u1FBcgffNDOEpqELEfaD0Zvyuna1Kz",
+	"mGjgMZo+MODPVgf+J8/1AnQ1Tdpw19JKEZWNN2vmETOSAYLIDh9lws30h5HHIvV8hcTtveHYh2WQuKZX",
+	"1kXGp27iH8vOiERZuv1Qb8vO7hhB6V9ZS7iA39T5ixC/1kjm4kb8V9ridDY3mDoYG71gc5pCb89mOce4",
+	"5TrAhNucvyjCh0Aq45sWpOhbSzfZc3uql4obz2TZqWsHh1HbZOCWqgC4ggtx8vOruEjEAipsMGBGg2Gr",
+	"ZtPcB8HwCGVFU2dmGxe8s5yJzPOvEXErxptOqkkqUGm35G3EH46fEicnl3RVJeHKqu2I66mX8enZDJIr",
+	"1E4uipSfdTouHVT+BFPQlGXFgeql8LtSHdTd2zF1iiLRgM7lPy3bDjeLbPVZEVtCSl/ksuVZj5G5wKcl",
+	"B3VdhiQFLpyR8vXX5xdvyb/+9fjp11+7HEQjckhe2guwk79zQg7J118/NffuX39N/s//+t/kt4N3l09/",
+	"OPjNf3zmL+WfH5M547kGFbR89sPz4zfY+BD/e/AbqibmFNzKSQqKTTnVQuLMvx1cHvxGFCyopBqU1xhc",
+	"RtUSULbtDwe/ka/M7E9Mo98O3uAvbhVPiFpAgi++inxBflbsfl4oP05Rcp6sYmVMka+/rmzqK9yR2c8T",
+	"h7xF+IHbafQNKtNZRCvysVjB2XSyEjtU8/iDTCpRflEVLfVHMm99xPBbn9kv5CcTmqlG9Cpqjr4XSQXY",
+	"6ywO1k84NpJAggEsJwo00TKHETm32y27OmwwSrFj504pKtD1CmBBzCKiSdvmaqJ9DrYWn3phBhCXv65g",
+	"Y6UsEVnafgrDgVjy1seaOIUNc/RjYeP4wN2PMJRn6L0UAMf/63hSBUpl+ZG0zdW+fUVPqXvYLLlVPtzg",
+	"oy/bshmWidVql5AhnyrYlM+Udm6xxP7vhKxWq9XhfH6Yppez2cl8fqLUf5GfEQdJJpYgE6qQH+rCp22N",
+	"aYykWrn0zDy3KbMyGwqgDIH301w4LHtljnugG6xH5oS7HYZn2xNfHpTEdjHeaSi5q30DrXRBpWYJW1Cu",
+	"Va9bJuDpe5Mr5ksljaz14fFl+HC3Sw7eRONBKDkZHrOmW+4cvuxjuWWdJMCDBnhrRLCp+nJL8r+wTi7i",
+	"cao4j0/1GeYJ9cMX/U3EocCAXQ9EHx5Uc5LY9xoo3o07r/R89D7kP5HSErD94RYazI99pUaLCGh1TvT0",
+	"P7TEAD2KiUcx8eWLiUA0hBKjgvMNaPck7LfrJIfjNS0+0i4Z0S+fjWn9JRsHG1rg90Lyson1OPTzdTJl",
+	"uqX9Be924tDmnzYo/rLtnd+D5ydug2vO8ksylesqTclyIryhAZ4aQni86sn6LhifZnBDW7tKnI+a91aa",
+	"933Un4cDk7XqJuli+EbJYFpT19QKsa1F2GgkyCt8++w2TmzXzQJBUHM/nDCehnlMYgEgWLPi2V81Hat/",
+	"w/H/4m7ODpGAdllXpu3WZ5xPJiBPJzpWcOOVBHfLUaC3DZPb6PLH1FHJVcEMyQE3qbMPbIgbQglk8/Lj",
+	"6bfRqw+74O/My9NeK3aPVO9wyRMhE3gPEwlqFmOZNA2Ky5g0iDil/4kspLhmKUjCuNLY2MdW02RmOI8Z",
+	"2Md4mF/jxWharmXak5hVM7RtlsTMBU2fUZ4yZGyqZ66Zx2vIh3MN6QAfz42Z5EqLuc1EM7TVXpiyROUT",
+	"dZyek7lIISoKXESS51/vQCbAtcuX0yPf0tZlbTKhXxn6roZAzJVh3JH4B3zGGLAFlCK5ghE5cF0OSAoZ",
+	"TM2p+wIl2DRIyqSGJYfJFSifTfZAoROtqPIjbRQ+XINchRcDB8o8Pz3CSgQjUko/2/x7IaaZf8tCsyWG",
+	"95lJ/JTVqIlyo/ZzNGSi643DRfDdPXRQibBpCExVCFU+29CCSMqvbNxaWQVkWOBto0c1yLDXUxw2h/7c",
+	"7LLa+qZlfNyLVon2RO7yLAW1h5p1eMLHOG3FeBSjR6iP04WtCNi/3E5MDER5fsyzEpJ8A6w9dLFYUdyb",
+	"5JfDkfJM32YBmrp221ZPZ31hzGivWma38ntvfS+ewa/Xc/+wGE3kZYKrFGMCsTB0Xfk3CmNj9yINLIVU",
+	"4F4BqcrNZTVhj1nia2GyToxpcnUpvqPJlcOnt5MX1JZEgAlICSlaFWpQ0uAryiSv5sosGdOF5TK29qZa",
+	"U/zGPFqqVr8xz908m7JEOzG5+UNu1oyAD3YQFU1FbO1xREyFwNi8dwVGxi5ryQPRns2+Zg/UoL75ksoj",
+	"3K5v5YQ3HaJuvu4K0+KQjrK7ZkrDZg6cT4uMMszNYl4nlIzHBLgiAuIjpoD6rUuJ8itrkJS5J4f+Xsjp",
+	"RsbfWIQZYBQr9iwKWg3961hu7RqmyipPuzI0W6vkBkV3a6zF9XTUqAi9BkmnkNq8Q8Gj30WWq0KiHi5Z",
+	"CmFN3QdSyrdewrcr0C5kPpGrxGuqqTwTmcgjXPsH+EQS863yZBYTlph+Q5JzBZqw4CO+QsEwW/d0t6F5",
+	"/OWbV9/89eW31bxIf8EESKeHr+jh5OPnv/7xL/EkF0bLOe8qzuojisPl+uAcg8/VwquWbZs8VwLMyhVd",
+	"xQ1gt4IwgXa/h9VFj+ab6tfFRaI0aeLrgG5f5pRdg31iuOTFfaQaBQt902ULv67YwfEpafVBdHV6waHT",
+	"bK5Zgy22YGVhn2/ypLc1ef41U6xfFfeQZn4qe7UnwK6uvzJVK+J2EWtZCKoWT9bLydnu3AymaKWh0+K4",
+	"KxVincrmuxfJEvBHwrSTP75DLN/OdrliVCWZ9Bb6a8ub92DcnqCKGxzfVyikmoPipbG27TP9CqWhO8Mm",
+	"mMQcX6ZMhFsi8gJf0ryQXy7jLk5gHyaxUrYRXLoPU57G1cy7zQWxQc6HMcLOOkTQ3LUvHEveZNMddCWB",
+	"8O17JIMI3QJM+0eUvoN5mRdIhiJNnstjWqv6vW8XZN3Ur++z4NJucpP1snTV1Znx0x829p1Vt/KfuZD5",
+	"PKZHhqsTBmL+7FCvnOfKPJ+fSICAs7hQfas7VHf7bUvd0jn95J4eHh/3sA+29+K08JA+PowKH8HVeND6",
+	"Wi5tb6Ic22Dd3KFFhg8LqCfUZSwovBftartfVh+F3bRt8xe1FpxsguS9yCAuiPDrgSJSZGDTGIQyaES+",
+	"Z9eGjHmIZUEAoemnJeVqgnAwP6oZWzgS8sgr4ZqJ3H0nY0iE4U6c0HQek2RSZJ0wCndp9leHkxmkCz69",
+	"M+s6cqnWmvR5Yl0C9SLlURWMBZ4cGHUyTMurMc/AQkICqb9yvt3kuZskvm1AfBuMervkjmJIKjBNmp4Z",
+	"5ly+hjZFaf2VWZk4Cpc2xZ9xZDUip4g6diBXLsx40YdEAl77l9LM94UKETsJZd4xFkRcKFhReq+kFMFt",
+	"mNowc2Z9rzhI3O0V7P+DKb3bpuT4NSH12CUPg99m7mFlBhNtKk7jf65goUfklNvi3yQ0OQsnfc1UMUK3",
+	"am1Zf3iSAXWJa92sES5Ws2nD/Ls1Q/PJv29oavYyCvtW5Wg30zY3mOb0k7XmUBA++2ZNcMSc8aLlcE9W",
+	"0lq6/KkyQ1z02dsmkWvFUqibHUgFeANWS6GzkOzaZmdImUrQ+WMCmGP4ftnQBHplpq3K+psU99m0bhhN",
+	"NLtmevVCzCmLW8jaVWfZztnvyrqs5bzlDDHW+8EkJa7wdvSLR6oc+J/D6/y/ftOdqsx2jM6930JT/Z0P",
+	"2bpqte23gJdl+hxzIVi9BRxiFhKS8wyUYX2u9oEC3XSvvcwRBEevBU+NJ6JHCa+Ni2MhtNu8Ew+huBeu",
+	"v6ZI9TOKax1dFqI6CLY5Zh+IYS1bxo1Qtffv7z5ckiIoDK8cDQM5/H3To98scVcNxq5rgMORqudN0Mbd",
+	"JUGEQUv9hxIUS2oTpiVX7seWKgVNNSDLeTJrfePrCgnYBC9YBME0J2O8domXFPjbDkoKmEnWxN5fhJHG",
+	"t7iuOf3kU2W8A+lu32oi0X0nC5AmJ44N5bNpuP2JoKWKl1aH7raJFeqqUaBznrE501uFYCyFvEItia76",
+	"C7ggNdTalJtubFMk4+WashMmwYhtiyDY33mEC7roKENxS4uqMYQaJg+r5BbbQRPM1UNt49SXjuPEE/pt",
+	"G1kzLH7n1PMaX5VKOfeqGQH3fI1ezNpNxkuqEJSYiB8dnCm57BN+v4Z92t3upUKGkRy9qeaDsr3W6oJ2",
+	"yD5FMDwRBiWL54Kn5upc56DsX0tIuf9bz3Lp/pxIZv9QVOfS/Zmb3h+jxQQYn4iI0f/u3BisYj7POUt8",
+	"7GRhSpdXXihx3xSK/KB4cOYtGHL67jyo23YyeDo6Hh3bJMHA6YINTgbPzU+G2mYG2kZ801zPjhKaZWMX",
+	"6jG11RLwdG0VjhStbNCn785Pcz07801xIEnnYNOn//J5wHDef+YgV/7+6WSQiBQG4RHZZ5uqiFtv4GV8",
+	"HFv6YZOBPmJjG45lNvv8+FmEW9m4rUmeEYTDYDiYAU0dVr5e+9Tyw/vXeHYSrD2Gf1vRo6pjAtesfNFd",
+	"LLekWLTW0FgTCc1mQumT58fHx0cpVbOxoDKNFaf4w1w+zedUrhCLcj0jUuTauaxzPROSKednExhOkInl",
+	"yNBKeeRTEz+5ycnbiMvH878H5z8cfHN8bI1Wrv0F2mKRuZmO/uGC9drBU3fVDOzpEo0MU9lcwiZV0RjI",
+	"gkoFprjnt7ue9ULMwbgxyRK4JkspzPtiDZLTLFvZOZ/dwpyGw1JO4JOd2zDUKp05CNFNyc1fNh4Vd51r",
+	"CK16wdmLxvA50/mLjYijhcqYOrevz/oMVtYFr5Pasw2xZIML38hpFuSWrchUaKvwe5i7yoqDkmKqvb+j",
+	"aZGe0LR52ragYodHH7g7898hfSmlkI+UAboGcYzEC940pjFymENPWngD/QjB16FrR91+L1Pjo9sq4zcf",
+	"O/JOqvIwamdPpWKbqDzaCldfz2LXIPLhhk8ELBqUHpO1TwPKvkGJEozH9ptyNNq2rcJ+2UQj2JRN9bJS",
+	"GkFCNXPlkX/dU/5Fve+uBnoXBoJxhdziqcTrxZEvaNdkXu+EanAvWSZ026dorHKnPxoI//T25LL5ECjD",
+	"2coHZD0i816R2V6BEEo4LGvIHJPCn60P/Y+esviD97g/yuNHeXwzedzYwY82mUiQ8sJMqwVBxHQzofeq",
+	"nKi4AOqBB+2XSo+awSMzXacZ2HfpLNlYRfDc1rYLWGxNVipNbRVM8/xQS6BzkgjOIcEW1s9kyv8TCTQz",
+	"zIzkJiBKEToWuSYSeArSkCdVV4pcM0ouQF6DPLzAHb+0K/3q4uLlE6TaKo9/b3q3+BzW1UE11Uosa3Er",
+	"TIe21rpLMTGWYqnAFd1jppXdlxqRaoFp08vXqyrTDa1czRWc2zrnSup/TZU+NKs+NExgx0Sv4ZO2R3do",
+	"z6SKdPX6M5puEmEYC0hq4u0pooFmPMcYUIcYYkKUPVkDMotbo6rv8gyFzuGZ4FqKLFba24gll7SLZpju",
+	"yScwYH6i0XruPTgrEDRys5FeMwU2Ii7JmBE9wmYoNz+VuC0WwHvMhGdyGI9LRox8c/7mpX1uICbhHnB7",
+	"jWNcPx1O+M2zv+2W/xR5L2kmgaYrE8+PkHBx4kHdGwcbSB3g1KjGky7yMQ49NnmaqsXWLS64Tda4z9Gy",
+	"LwP6GcYXIrkC3cKDKnPaSuBuqWQMM4yAW0jxiYGLvLS5bWI4S96AUnTqNJJiWv9rUUXq2nTnKS5ugUg7",
+	"kU6lkSvy9FuikKmkLmA1EwXilat3TwEdKlKuliBNKn5DAxP7GsENU2Tf3/bAho3ZiwLXRTlaLVeETinj",
+	"JKOmdJoret7KlwvobMGgwwMr+LRPIVRwZIxa3Zwn1zQ/nO+lq6F9Q4b81KosNUG+ZDqx1aLN/kpcXUih",
+	"RSKyUatKFBb0Yeb0sXIdzVgaDDOjPFUzegWjm6lN3xw/X5eCrViA5b0b0Lh9PlyuOCD0GdBMzxIs9tJh",
+	"zv0QtLyh8tvJ+4K5An9AbcNhI3O5EmzLBqmbxa31uJy7dvtxt9jRXYDhLXtb3M7sa+kIiM98uc8ioj90",
+	"vOzfRogi+yshxyxNgQ8Lbhq8bvAPNIvwaf+azVCEkeF25G9a8/C4Jw7cv8kLrJUNLY52B4oFZwMXuy8v",
+	"3JHF7i5qdrs7MF/gqu3623zcBF18jfvbMW/L/fYwbb+3nM/jhANq1VtoROXCx/CMbgOJI6iG/q4Qw5rW",
+	"YZYV60c9IhPTqQ0xxM393//xPx2XUJW91NHps/3D+eHMOx5oYtYL83uJXOeuUxeKNR0rjkto4czIuHeF",
+	"lePfqX+lU8RYwKTh1m6bAUZwx1H2rvmT3WzJ610ty2QWEYz48wPFl+0keD1zubEkuoP1fcOPUZu8S9Dv",
+	"GJvtU7cKNrv1VbB69GWhtd11oMJY95yRwv541vDNI2qKa7mXdRsQgy3K9fBY6NPdIp0Fg5FcDf75hWGa",
+	"3apDLZf0ws6EDgSapsVrbeFVVJ++Yh0CppBkjMPmGPjCdfyzo6CDw58AA/1OAxnuEQuj+Q4NtqkOCwPf",
+	"fn5vG3ZgThE57i0mOi9wpWZpuE93HEVTbK2PMVHbnSJzJDN/U2n2RwLw9EAk8hWMpqMhno3xDs0j8Hty",
+	"01u1e3XDVceQ8SoEnBpF8bPbCC5R1EXB7AJxOrI9dlqbDXy5B6ZCLzOzvnC0nO2tjU31bG+IW87q89Rm",
+	"Nf6j95H5NMgbi6U6Mq27wJ8Wk2wf2r/LeOOA88QRqb65JvLsFDEarPvmkughMB+WdqHxUfF6bBNkNm/Z",
+	"Oj1ybZi8OxRuiWnCZ2uvGb8abNHXvGHtp+PtzVHTfBRodnPyua1wX/UVuNpIQdjpg8CIFY49qy7JgvBu",
+	"7CnfHekFcmnYoCSWunR+qMY8BvM4OVpJN1UDWZ3t9OUyLYzl9on8kabvgKYjgvsVZZm9pp6CdomDTChp",
+	"gXCP9AilXt4ETpMMjxRQmcz6UuOFbd0h7G2r0kA0+nTJFFxmtIRykxeSSaXdQ32VS/uHkMTneone3fll",
+	"3DNt4BYZxSNjeGQMmzKGGGDImCpIic+6ZzL2GsormEUlVOboc/jfvnerlQioHysDtMj3qjnA610extVp",
+	"uOx9G7UhVEuLdnQ3eB+9W62Co3wCEvhZTNzCWHwa9cW+Iwk07XE7sAYB8XXKl4iElacOubv+5JVo6T8R",
+	"Er6h8qqOghRja5lJ405D38hCZFl3ZNw702o/cXE4tg2ZMsPecmQczr4uIg6pFmH0xQTE7eD26V1uYr0J",
+	"tZDB9wS24gCklQJRZfb4CrZ1u/0NunVHvr0z57LDuDcc8Haj3iz69buCCM7cwHGHUnYHSPE92BXapeFf",
+	"1Ug2YwJdCw1E8DDntC2SYNJ/+goLDf509Bn/6b57MHjzzjTd/NYBp4g7aBd+xDsVgttwMY82LRxsp/LQ",
+	"UOOuL7qtg98sv+7Vr2LGkXkg0U+QWQw5Mx3uJ5rsScrijvsL2f2j55l91LK1jPX37MUA+MjGvpR5sgf5",
+	"a+pfV0rBGKZmJnRYunfqKKzsNqeATZIQll+ph6cHyx3a5j7WwXVYV/GmnQCRt1tNMo/RX14jv59M8z8T",
+	"+Zkd3yfy+8nU5LlPRHdbYuUnq4cEdYjNXQ5uJ0BwWRanXytUyvwL+41vq1i5bnGQEmp+tZn3bnIAFQi5",
+	"Pfkir5VJXKlm20DXXiRI8OlHj4qHZB3Q8x3OiudkuyfAYhbGp2YeHP3Cdt0DOdZKA1D+HZT7jGRixjnd",
+	"I1MJ5qGkixzz9eoKs8ow5qz8UhQsIVpEyzsy9SMsXZrrN0LC+XwhpKax+hCX5Spc2LSdg03IXEggzHfF",
+	"h7m8vpRBLMtIt+u8gtUGZQCxywPLG3j7yFm5JmTP5iTAR/lPHlr6i6q0R4D6Mw3xxt1IyQArYzTswHNk",
+	"3u0msAE1v7c937uO+yfr9+X4+yPsehXbLiZtluTK+UOlxlFIOY/IfbOXln6fa6HsvFIBYxutRXnF+DTb",
+	"AuMvbL9bQ3g73yPa/2nQ/n0vbEd/iVaQTdYi+Wf3R7dzLcLZXc/Nrbhg2QHptgb5ymCme+uDa8CnOycX",
+	"6BZoPFLGDdyFWRaBqEJ68Luv+BHXE0X/Z4Ht9LHtC8E7pZJbEV0WMpuKrn2a1dQ/Y6wcQFD/+kt9yxhB",
+	"ti0ppvDEbyVMtnTLP1SJ0omdXtgbqD6ma9xv4JgL3wkylPbHejFf+MiwTS1kj/p+iD+7pHjMTP1InZtl",
+	"pnZJ5jzyeyj2F1oSjH/yJmreezvEAxZd+72v+IdNcvgnUawsNrQqVh0KVY8gqQYitr+RXg+q5kCP5ufu",
+	"zU9/d1PW5DNXPfWoYJNwFj05S155ii2FmKsjmmVdWIHtTs0d+f6j13CyPtFrd/Y6wkDt8XVEHEsXQik2",
+	"zsBCKcC1kjcfKV95fa1KW1Y+tnXU96MV1mbZyuG9Hs1qMzj59d59f+SKDxHbz1OYLwQuwuaFHZJ/5Eq7",
+	"8hWK0MVCioVkJmaKzX04CM2K4W3RXAQxJNqk0DcBUxWCsUESQdqd9eQSRGnvjViCOe4m52y4guiBBt+9",
+	"AdUWjHQr9FYJnr9xip52yyWcpxWJuvW/Chb1LXFX5BDdNLn2g0oasXElaVWhyL7qTxXDO96WqhrRb/7G",
+	"tJaGqIovDyYJUW3Za7IP1QjicwjBvo8xK0RyURlgc0O9wiC0IG72qJWu6nM9jFeclS1uGxkaZagsfXJf",
+	"n0StyxH+fT1rUvy5Z2UWb94Pe7PvXWNmq/PofqHlTdQH5IYd2LpT5taGDzt85tKCRIVDspbZq8xCgyhg",
+	"E/ZQ6curQIpRf8xUqpCAx58264N4p+ZekXFdbtE94ON+dWmbZfi2I/i7iMFnfN4t+wZtPVVCulonLq1t",
+	"5Jy/DN6+yzzUZn0FBCc18u6v5oTVS7YTJ2VZkzuuIzHcSpCV1RGkmN8GFxn+eUynvjVj1ClP39Ep4770",
+	"XKtxQq0gainKcUeGSWu9l85qHLSawLs/1WZAr8EZ8eutlDWU+xoHeQM3F8Ezeg1lSTKzNkNOoy/CeCmK",
+	"41VuGDKY6BAY/gTvMQb+gOdSiNnymLZHQ3/btJlPsoqFb/wYN0LDL0T/c8C4i1QlkWVE/ar+PU/lSe+2",
+	"yuDeFb+/7et+qHZ7U55Yo5Qp7oeonGlFgIt8Oovi7/6ebE8Y94lThOx+wO0PuMywYkSVrWZZnlTNnnQq",
+	"8xjPVmWeAW2gh4bphA4XEiYggSc3UUzDDErvggEfBp+5BVOvDUAt+ld4QiQ4oftK/HtxnBwo4mrfV+GB",
+	"j2Q5ZMq6VNpTFHyx+LpfubgWVe/GUbIB9VzQa0h3Tz/ek+Jw70E7UnYSEmeeAm9OsH1FlEoE2giHS2DT",
+	"mb6BZLqwA/3sxvlzC6QqLNpc8bYVcaCvkMzQH7ByJaB1Lrn1T3PBbboDBfpPIJlU1gTVDQTSg0TTfQSG",
+	"NTH0FkVOJ31Y6bKOQjZCfZhSE4fkB/qTS5WLDQitryDpU7VhDV1uWCXmtsnwATm4W4burDLX0s/n2b+b",
+	"2lAGLTo96XsNlt5T3ZhapMddFI3pU6tlyxs4WzziM/7TiDuqZQjEqH1lcutImAv05vKV4L4w6Jzxyke3",
+	"uBGxZ24dvtRXPZvBXEF2DcrGoW507dC86u8TEmWW8cFs8/4rEz1uEy1844vJ/Tbv+/NfxJQ02M79deXe",
+	"6h3+B/dYpmtXO7NdLTn7marXMjfjKEdUa+ApwKFFh89bmgMB+Z66ES9xwEdSvjeXR7iJytncoX+sbTUx",
+	"4wWPwGMpQdA9cqG74EJo6yxnoGcgy+mYchnQEIXQGhRmcJqVJxZZ0YEqniJuz7gQhAG/akuXjFqRTZVs",
+	"M1ViNxWJRtyAwb0X2SNju2eMzZzJnTO0chW1ZOMG99z7c5Htj4OZS1yczKfVNvh/oPBfM/Mji1tXXESK",
+	"ucnOLEkK5i/aeqltaudpSbmagLRgVjO2wIZMB1ytjzunl8/mHZWa0cxWDcNpcGSiBbFF+vAeviVystvz",
+	"MGybDEfYaK77UYO/peJei7+jUnN/yq6B1yvvj278BOX8xd1fC1wUh+cq1BnbfrxyKIV+Ajy+EXnz4eKS",
+	"LKS4ZikQwQsZXimrT977Z6xkTj9hk6fHDj/AXSysC/ryOL8P8YBj383DU4t4cUTremh6A8Rqf/BZe9dm",
+	"dajO953mcFpTeuwVUnih1wiovHvy2+7RYa3mXgqassqtsj+Po0xMhVVku6nmDby2rW+1tJ0rqSRyTQQn",
+	"Y5pcQWPPdl1tSLdtGJfbdHsIzN6QdH9BUHd8O1xDzLaFd90P9ziZ3XP3exLucy9DfEwaid8Fh7vXNnzE",
+	"TaT26Bp0W8sztmAU6uHmJmgo5S98JIsWJOe2mOZO3kqFAMMyrTurQ9h30n5vfCLYUw/24bBEAjG1zkf7",
+	"TuLx8POFEcR7r92vIdJuyuxbIDhGoq4y8L5lhZlnXzWwOk8HC+PWhIAiVJmauI+I2lVReE75qg+eeoh2",
+	"46tloIeJyLneQqx8MN3PTO99WklmmnBmO2UbhzTNa7Gs2GH3Guhd8q2ZWFqMqJ59HTnIjBpHYxtC1CyQ",
+	"zoQuDg92aHv0zo2yH2Oi3aidUSSscNodaYVBlusaHQdZWesWSDdt3pI9iLP1sAO/mNOKmIprjqnbUNy/",
+	"cVg7obsoedkDSaxBuEsb8B4Zfs7/KCSR7SZgKxo1uDTu7BB31hGc4lDsks3hv7D1/vDLT3FXyNWOUQUW",
+	"fEn4dAE6hkHFChtIE4+WXCfUt4079Ndi67KxPZBgP69p7MT3bgYpfO6bes6LzGZmmF4ZzXZyiq2Zy+7H",
+	"Ed7D25StEjFWj9WT7hLGMyGu1JEvrhDehdSiKWiGr9fHqzKxNvle0sWMSGrCk4oKsRNpgJsOiRKoISVC",
+	"XDFQ7tUX1yPy8ww43uzn42IGwlRxVWeHVcBTvOc0oDDHY3yFZDljyYzMc0yrDwSSmcBl0eQKzdBFRhkn",
+	"Gj7pIVEA5Jc3F68uiY0EIRVb8uNXM60X6uQIQ6glH839rkaJmB8BP8zV0RQXcmRjmA4rZs9hChnD9/eH",
+	"HoRPIuFN9vroZ9fC1xnp5xytbbv7en/3Ytecg4Vd1RjOMki8z7Bb7uJpHJmD2fgmLMAPBw9IbbxLHStM",
+	"0jv7zNCwrWexDAw/Vr0WrvKXGXBl0HNGeYpY7nMn0ORqKl3ahP17i749fr7b8S+FsCa7RWFLgkvKfB3p",
+	"cbHjYYXocO81D88U6Sqj2t8xhlcOCeDrtDpbsHPWxinCIA6Kei5G/8QRQV57gshlNjgZIIEifYqEZjOh",
+	"9Mm/Hv/r8eCPYfgdCZgu2MixypGiVM9GKVwP/vj4x/8bAMqH01uAbgEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -5,18 +5,34 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
 	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/email"
 	"github.com/SlotifyApp/slotify-backend/logger"
 	"github.com/SlotifyApp/slotify-backend/notification"
 )
 
+// NotificationRedisURLEnvName is the env variable holding the Redis url notifications are published to,
+// eg. redis://localhost:6379/0.
+const NotificationRedisURLEnvName = "NOTIFICATION_REDIS_URL"
+
+// FrontendURLEnvName is the env variable holding the URL of the frontend, eg. for links in emails.
+const FrontendURLEnvName = "FRONTEND_URL"
+
 type options struct {
-	logger              *logger.Logger
-	msalClient          *confidential.Client
-	initMSALClient      *bool
-	notificationService notification.Service
+	logger                  *logger.Logger
+	msalClient              *confidential.Client
+	initMSALClient          *bool
+	notificationService     notification.Service
+	notificationBroker      notification.Broker
+	emailSender             email.Sender
+	calendarProviderFactory CalendarProviderFactory
+	googleConfig            *GoogleConfig
+	attendeeFetchConfig     *AttendeeFetchConfig
+	calendarCacheConfig     *CalendarCacheConfig
+	calendarSubscriptionCfg *CalendarSubscriptionConfig
 }
 
 type ServerOption func(opts *options) error
@@ -41,6 +57,30 @@ func WithNotificationService(notifService notification.Service) ServerOption {
 	}
 }
 
+// WithNotificationBroker publishes notifications to broker so they reach the clients of every API replica,
+// by default a Redis broker is used if NOTIFICATION_REDIS_URL is set. WithNotificationService takes precedence.
+func WithNotificationBroker(broker notification.Broker) ServerOption {
+	return func(options *options) error {
+		if broker == nil {
+			return errors.New("notification broker must not be nil")
+		}
+		options.notificationBroker = broker
+		return nil
+	}
+}
+
+// WithEmailSender sends notification emails and digests through sender, by default an SMTP sender is used
+// if SMTP_HOST is set. It isn't used if WithNotificationService is set.
+func WithEmailSender(sender email.Sender) ServerOption {
+	return func(options *options) error {
+		if sender == nil {
+			return errors.New("email sender must not be nil")
+		}
+		options.emailSender = sender
+		return nil
+	}
+}
+
 func WithMSALClient(msalClient *confidential.Client) ServerOption {
 	return func(options *options) error {
 		if msalClient == nil {
@@ -51,6 +91,68 @@ func WithMSALClient(msalClient *confidential.Client) ServerOption {
 	}
 }
 
+// WithCalendarProviderFactory sets how calendar providers are created for a user,
+// by default Microsoft Graph is used.
+func WithCalendarProviderFactory(factory CalendarProviderFactory) ServerOption {
+	return func(options *options) error {
+		if factory == nil {
+			return errors.New("calendar provider factory must not be nil")
+		}
+		options.calendarProviderFactory = factory
+		return nil
+	}
+}
+
+// WithGoogleConfig sets the Google OAuth client and API endpoints, by default these are read
+// from the environment if GOOGLE_CLIENT_ID is set.
+func WithGoogleConfig(googleCfg *GoogleConfig) ServerOption {
+	return func(options *options) error {
+		if googleCfg == nil {
+			return errors.New("google config must not be nil")
+		}
+		if googleCfg.TokenCipher == nil {
+			return errors.New("google config token cipher must not be nil")
+		}
+		options.googleConfig = googleCfg
+		return nil
+	}
+}
+
+// WithAttendeeFetchConfig sets the limits on fetching attendee calendars when scheduling, by default
+// these are read from the environment.
+func WithAttendeeFetchConfig(cfg AttendeeFetchConfig) ServerOption {
+	return func(options *options) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		options.attendeeFetchConfig = &cfg
+		return nil
+	}
+}
+
+// WithCalendarCacheConfig sets how calendar events are cached, by default this is read from the environment.
+func WithCalendarCacheConfig(cfg CalendarCacheConfig) ServerOption {
+	return func(options *options) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		options.calendarCacheConfig = &cfg
+		return nil
+	}
+}
+
+// WithCalendarSubscriptionConfig sets how users' calendars are subscribed to, by default this is read
+// from the environment.
+func WithCalendarSubscriptionConfig(cfg CalendarSubscriptionConfig) ServerOption {
+	return func(options *options) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		options.calendarSubscriptionCfg = &cfg
+		return nil
+	}
+}
+
 // WithNotInitMSALClient prevents setting MSAL client if it has not been passed in.
 func WithNotInitMSALClient() ServerOption {
 	return func(options *options) error {
@@ -65,10 +167,24 @@ type Server struct {
 	DB                  *database.Database
 	MSALClient          *confidential.Client
 	NotificationService notification.Service
+	// Emailer is nil if email has not been configured.
+	Emailer *notification.Emailer
+	// GoogleConfig is nil if Google login has not been configured.
+	GoogleConfig *GoogleConfig
+	// CalendarProvider creates a CalendarProvider acting on behalf of a user.
+	CalendarProvider CalendarProviderFactory
+	// AttendeeFetch bounds how attendee calendars are fetched when scheduling.
+	AttendeeFetch AttendeeFetchConfig
+	// CalendarCache configures the cache of events used by CalendarProvider.
+	CalendarCache CalendarCacheConfig
+	// CalendarSubscriptions configures the subscriptions to changes to users' calendars.
+	CalendarSubscriptions CalendarSubscriptionConfig
+	// CalendarChanges holds the calendar changes from the webhook, they are only handled once it is Run.
+	CalendarChanges *CalendarChangeQueue
 }
 
 // NewServerWithContext creates a new server and accepts options.
-func NewServerWithContext(_ context.Context, db *database.Database, serverOpts ...ServerOption) (*Server, error) {
+func NewServerWithContext(ctx context.Context, db *database.Database, serverOpts ...ServerOption) (*Server, error) {
 	var opts options
 	for _, opt := range serverOpts {
 		err := opt(&opts)
@@ -87,6 +203,10 @@ func NewServerWithContext(_ context.Context, db *database.Database, serverOpts .
 	}
 
 	// default to initialising MSAL client unless specified
+	if db == nil {
+		return nil, errors.New("db must be provided")
+	}
+
 	var shouldInitMSALClient bool
 	if opts.initMSALClient == nil {
 		shouldInitMSALClient = true
@@ -97,7 +217,7 @@ func NewServerWithContext(_ context.Context, db *database.Database, serverOpts .
 	var msalClient *confidential.Client
 	if opts.msalClient == nil && shouldInitMSALClient {
 		// Create new msal client
-		c, err := createMSALClient()
+		c, err := createMSALClient(db)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create msal client: %w", err)
 		}
@@ -106,21 +226,108 @@ func NewServerWithContext(_ context.Context, db *database.Database, serverOpts .
 		msalClient = opts.msalClient
 	}
 
+	notificationBroker := opts.notificationBroker
+	if redisURL, present := os.LookupEnv(NotificationRedisURLEnvName); notificationBroker == nil && present {
+		var err error
+		if notificationBroker, err = notification.NewRedisBrokerFromURL(redisURL); err != nil {
+			return nil, fmt.Errorf("failed to create notification broker: %w", err)
+		}
+	}
+
+	emailSender := opts.emailSender
+	if _, present := os.LookupEnv(email.SMTPHostEnvName); emailSender == nil && present {
+		var err error
+		if emailSender, err = email.NewSMTPSenderFromEnv(); err != nil {
+			return nil, fmt.Errorf("failed to create email sender: %w", err)
+		}
+	}
+
+	var emailer *notification.Emailer
+	var notificationOpts []notification.ServiceOption
+	if emailSender != nil {
+		var err error
+		if emailer, err = notification.NewEmailer(emailSender, os.Getenv(FrontendURLEnvName)); err != nil {
+			return nil, fmt.Errorf("failed to create emailer: %w", err)
+		}
+		notificationOpts = append(notificationOpts, notification.WithEmailer(emailer))
+	}
+
 	var notificationService notification.Service
-	if opts.notificationService == nil {
-		notificationService = notification.NewSSENotificationService()
-	} else {
+	switch {
+	case opts.notificationService != nil:
 		notificationService = opts.notificationService
+	case notificationBroker != nil:
+		var err error
+		if notificationService, err = notification.NewBrokerNotificationService(ctx, serverLogger,
+			notificationBroker, notificationOpts...); err != nil {
+			return nil, fmt.Errorf("failed to create notification service: %w", err)
+		}
+	default:
+		notificationService = notification.NewSSENotificationService(notificationOpts...)
 	}
 
-	if db == nil {
-		return nil, errors.New("db must be provided")
+	googleCfg := opts.googleConfig
+	if _, present := os.LookupEnv(GoogleClientIDEnvName); googleCfg == nil && present {
+		var err error
+		if googleCfg, err = NewGoogleConfigFromEnv(); err != nil {
+			return nil, fmt.Errorf("failed to create google config: %w", err)
+		}
+	}
+
+	var calendarProviderFactory CalendarProviderFactory
+	if opts.calendarProviderFactory == nil {
+		calendarProviderFactory = NewCalendarProviderFactory(msalClient, googleCfg, db)
+	} else {
+		calendarProviderFactory = opts.calendarProviderFactory
+	}
+
+	var calendarCacheConfig CalendarCacheConfig
+	if opts.calendarCacheConfig == nil {
+		var err error
+		if calendarCacheConfig, err = NewCalendarCacheConfigFromEnv(); err != nil {
+			return nil, fmt.Errorf("failed to create calendar cache config: %w", err)
+		}
+	} else {
+		calendarCacheConfig = *opts.calendarCacheConfig
+	}
+	if calendarCacheConfig.Enabled {
+		calendarProviderFactory = NewCachedCalendarProviderFactory(calendarProviderFactory, db, serverLogger,
+			calendarCacheConfig)
+	}
+
+	var attendeeFetchConfig AttendeeFetchConfig
+	if opts.attendeeFetchConfig == nil {
+		var err error
+		if attendeeFetchConfig, err = NewAttendeeFetchConfigFromEnv(); err != nil {
+			return nil, fmt.Errorf("failed to create attendee fetch config: %w", err)
+		}
+	} else {
+		attendeeFetchConfig = *opts.attendeeFetchConfig
+	}
+
+	var calendarSubscriptionConfig CalendarSubscriptionConfig
+	if opts.calendarSubscriptionCfg == nil {
+		var err error
+		if calendarSubscriptionConfig, err = NewCalendarSubscriptionConfigFromEnv(); err != nil {
+			return nil, fmt.Errorf("failed to create calendar subscription config: %w", err)
+		}
+	} else {
+		calendarSubscriptionConfig = *opts.calendarSubscriptionCfg
+	}
+
+	server := &Server{
+		Logger:                serverLogger,
+		DB:                    db,
+		MSALClient:            msalClient,
+		NotificationService:   notificationService,
+		Emailer:               emailer,
+		GoogleConfig:          googleCfg,
+		CalendarProvider:      calendarProviderFactory,
+		AttendeeFetch:         attendeeFetchConfig,
+		CalendarCache:         calendarCacheConfig,
+		CalendarSubscriptions: calendarSubscriptionConfig,
 	}
+	server.CalendarChanges = newCalendarChangeQueue(server.handleCalendarChange, serverLogger)
 
-	return &Server{
-		Logger:              serverLogger,
-		DB:                  db,
-		MSALClient:          msalClient,
-		NotificationService: notificationService,
-	}, nil
+	return server, nil
 }
@@ -30,29 +30,27 @@ func (s Server) DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(w http.ResponseWriter,
 	ctx, cancel := context.WithTimeout(r.Context(), 2*database.DatabaseTimeout)
 	defer cancel()
 
-	var count int64
-	var err error
-	if count, err = s.DB.CheckMemberInSlotifyGroup(ctx, database.CheckMemberInSlotifyGroupParams{
-		UserID:         userID,
-		SlotifyGroupID: slotifyGroupID,
-	}); err != nil {
-		logger.Error("failed to get count of member in slotify group", zap.Error(err))
-		sendError(w, http.StatusInternalServerError, "Failed to check if member is in slotify group")
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID, actionLeaveGroup); !ok {
 		return
 	}
 
-	// User not a member of the group
-	if count == 0 {
-		logger.Error("user was not a member of the group, so can't leave", zap.Error(err),
-			zap.Uint32("userID", userID),
-			zap.Uint32("slotifyGroupID", slotifyGroupID),
-		)
-		sendError(w, http.StatusUnauthorized, "You are not a member of the group, so you can't leave")
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to leave slotify group")
 		return
 	}
 
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
 	var memberCount int64
-	if memberCount, err = s.DB.CountSlotifyGroupMembers(ctx, slotifyGroupID); err != nil {
+	if memberCount, err = qtx.CountSlotifyGroupMembers(ctx, slotifyGroupID); err != nil {
 		logger.Error("failed to get member count", zap.Error(err),
 			zap.Uint32("userID", userID),
 			zap.Uint32("slotifyGroupID", slotifyGroupID),
@@ -64,7 +62,7 @@ func (s Server) DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(w http.ResponseWriter,
 	// This user is the only member of the group, so also delete the group
 	if memberCount == 1 {
 		var rowsAffected int64
-		if rowsAffected, err = s.DB.DeleteSlotifyGroupByID(ctx, slotifyGroupID); err != nil {
+		if rowsAffected, err = qtx.DeleteSlotifyGroupByID(ctx, slotifyGroupID); err != nil {
 			logger.Error("failed to delete slotify group", zap.Error(err),
 				zap.Uint32("userID", userID),
 				zap.Uint32("slotifyGroupID", slotifyGroupID),
@@ -84,8 +82,18 @@ func (s Server) DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(w http.ResponseWriter,
 			return
 		}
 	} else {
+		// An owner leaving hands the group over to another member
+		if err = passOnSlotifyGroupOwnership(ctx, qtx, slotifyGroupID, userID); err != nil {
+			logger.Error("failed to pass on slotify group ownership", zap.Error(err),
+				zap.Uint32("userID", userID),
+				zap.Uint32("slotifyGroupID", slotifyGroupID),
+			)
+			sendError(w, http.StatusInternalServerError, "Failed to leave slotify group")
+			return
+		}
+
 		var rowsAffected int64
-		if rowsAffected, err = s.DB.RemoveSlotifyGroupMember(ctx, database.RemoveSlotifyGroupMemberParams{
+		if rowsAffected, err = qtx.RemoveSlotifyGroupMember(ctx, database.RemoveSlotifyGroupMemberParams{
 			UserID:         userID,
 			SlotifyGroupID: slotifyGroupID,
 		}); err != nil {
@@ -97,22 +105,26 @@ func (s Server) DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(w http.ResponseWriter,
 			sendError(w, http.StatusInternalServerError, "Failed to leave slotify group")
 			return
 		}
+
+		if err = enqueueLeaverNotifications(enqueueLeaverNotificationsParams{
+			ctx:            ctx,
+			slotifyGroupID: slotifyGroupID,
+			userID:         userID,
+			qtx:            qtx,
+		}); err != nil {
+			logger.Error("failed to enqueue leaver notifications", zap.Error(err),
+				zap.Uint32("userID", userID),
+				zap.Uint32("slotifyGroupID", slotifyGroupID),
+			)
+			sendError(w, http.StatusInternalServerError, "Failed to leave slotify group")
+			return
+		}
 	}
 
-	p := sendLeaverNotificationsParams{
-		ctx:            ctx,
-		slotifyGroupID: slotifyGroupID,
-		userID:         userID,
-		l:              s.Logger,
-		db:             s.DB,
-		notifService:   s.NotificationService,
-	}
-	// Still successful, just notifications failed
-	if err = sendLeaverNotifications(p); err != nil {
-		logger.Error("failed to send leaver notifications", zap.Error(err),
-			zap.Uint32("userID", userID),
-			zap.Uint32("slotifyGroupID", slotifyGroupID),
-		)
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to leave slotify group")
+		return
 	}
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, "Successfully left the group.")
@@ -167,11 +179,16 @@ func (s Server) GetAPISlotifyGroupsMe(w http.ResponseWriter, r *http.Request, pa
 		nextPageToken = int(slotifyGroups[len(slotifyGroups)-1].ID)
 	}
 
+	groups := make([]SlotifyGroup, 0, len(slotifyGroups))
+	for _, sg := range slotifyGroups {
+		groups = append(groups, toSlotifyGroup(sg))
+	}
+
 	response := struct {
-		SlotifyGroups []database.SlotifyGroup `json:"slotifyGroups"`
-		NextPageToken int                     `json:"nextPageToken"`
+		SlotifyGroups []SlotifyGroup `json:"slotifyGroups"`
+		NextPageToken int            `json:"nextPageToken"`
 	}{
-		SlotifyGroups: slotifyGroups,
+		SlotifyGroups: groups,
 		NextPageToken: nextPageToken,
 	}
 	SetHeaderAndWriteResponse(w, http.StatusOK, response)
@@ -232,9 +249,8 @@ func (s Server) PostAPISlotifyGroups(w http.ResponseWriter, r *http.Request) {
 		userID: userID,
 		//nolint: gosec // id is unsigned 32 bit int
 		slotifyGroupID: uint32(slotifyGroupID),
-		l:              s.Logger,
+		role:           Owner,
 		qtx:            qtx,
-		notifService:   s.NotificationService,
 	}
 
 	if err = AddUserToSlotifyGroup(p); err != nil {
@@ -254,8 +270,9 @@ func (s Server) PostAPISlotifyGroups(w http.ResponseWriter, r *http.Request) {
 
 	SetHeaderAndWriteResponse(w, http.StatusCreated, SlotifyGroup{
 		//nolint: gosec // id is unsigned 32 bit int
-		Id:   uint32(slotifyGroupID),
-		Name: slotifyGroupBody.Name,
+		Id:         uint32(slotifyGroupID),
+		Name:       slotifyGroupBody.Name,
+		Visibility: Private,
 	})
 }
 
@@ -269,20 +286,7 @@ func (s Server) DeleteAPISlotifyGroupsSlotifyGroupID(w http.ResponseWriter, r *h
 	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
 	defer cancel()
 
-	var count int64
-	var err error
-	if count, err = s.DB.CheckMemberInSlotifyGroup(ctx, database.CheckMemberInSlotifyGroupParams{
-		UserID:         userID,
-		SlotifyGroupID: slotifyGroupID,
-	}); err != nil {
-		logger.Error("failed to get count of member in slotify group", zap.Error(err))
-		sendError(w, http.StatusInternalServerError, "Failed to check if member is in slotify group")
-		return
-	}
-
-	if count != 1 {
-		logger.Error("member not part of group attempted to delete", zap.Error(err))
-		sendError(w, http.StatusBadRequest, "You are not a member of the group, you cannot delete it.")
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID, actionDeleteGroup); !ok {
 		return
 	}
 
@@ -355,7 +359,93 @@ func (s Server) GetAPISlotifyGroupsSlotifyGroupID(w http.ResponseWriter, r *http
 		return
 	}
 
-	SetHeaderAndWriteResponse(w, http.StatusOK, slotifyGroup)
+	SetHeaderAndWriteResponse(w, http.StatusOK, toSlotifyGroup(slotifyGroup))
+}
+
+// (PATCH /api/slotify-groups/{slotifyGroupID}) Update the settings of a slotifyGroup.
+func (s Server) PatchAPISlotifyGroupsSlotifyGroupID(w http.ResponseWriter, r *http.Request, slotifyGroupID uint32) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 4*database.DatabaseTimeout)
+	defer cancel()
+
+	var body PatchAPISlotifyGroupsSlotifyGroupIDJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	sg, err := s.DB.GetSlotifyGroupByID(ctx, slotifyGroupID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			sendError(w, http.StatusNotFound,
+				fmt.Sprintf("slotifyGroup api: slotifyGroup with id %d does not exist", slotifyGroupID))
+			return
+		}
+		logger.Error("failed to get slotify group", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to update group")
+		return
+	}
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID,
+		actionEditGroupSettings); !ok {
+		return
+	}
+
+	params, err := toUpdateSlotifyGroupParams(sg, body)
+	if err != nil {
+		logger.Error("invalid group settings", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to update group")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	if _, err = qtx.UpdateSlotifyGroup(ctx, params); err != nil {
+		logger.Error("failed to update slotify group", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to update group")
+		return
+	}
+
+	if params.Name != sg.Name {
+		if err = enqueueGroupRenamed(ctx, qtx, slotifyGroupID, userID, sg.Name, params.Name); err != nil {
+			logger.Error("failed to enqueue group renamed notifications", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "Failed to update group")
+			return
+		}
+	}
+
+	if sg, err = qtx.GetSlotifyGroupByID(ctx, slotifyGroupID); err != nil {
+		logger.Error("failed to get updated slotify group", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to update group")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to update group")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, toSlotifyGroup(sg))
 }
 
 // (GET /api/slotify-groups/{slotifyGroupID}/users).
@@ -491,3 +581,233 @@ func (s Server) GetAPISlotifyGroupsSlotifyGroupIDUsers(w http.ResponseWriter,
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, resp)
 }
+
+// (PUT /api/slotify-groups/{slotifyGroupID}/users/{userID}/attendee-type) Set whether a member is a required or
+// optional attendee of the slotifyGroup's meetings.
+func (s Server) PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+	memberID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID), zap.Uint32("member_id", memberID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeTypeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	if body.AttendeeType != Required && body.AttendeeType != Optional {
+		logger.Error("invalid group attendee type", zap.String("attendee_type", string(body.AttendeeType)))
+		sendError(w, http.StatusBadRequest, "Members can only be required or optional attendees.")
+		return
+	}
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID,
+		actionEditGroupSettings); !ok {
+		return
+	}
+
+	if _, err := getSlotifyGroupRole(ctx, &s.DB.Queries, memberID, slotifyGroupID); err != nil {
+		if errors.Is(err, ErrNotSlotifyGroupMember) {
+			sendError(w, http.StatusNotFound, "User is not a member of the group.")
+			return
+		}
+		logger.Error("failed to check if member is in the group", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to set attendee type")
+		return
+	}
+
+	if _, err := s.DB.SetSlotifyGroupMemberAttendeeType(ctx, database.SetSlotifyGroupMemberAttendeeTypeParams{
+		AttendeeType:   database.UsertoslotifygroupAttendeeType(body.AttendeeType),
+		UserID:         memberID,
+		SlotifyGroupID: slotifyGroupID,
+	}); err != nil {
+		logger.Error("failed to set group attendee type", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to set attendee type")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, body)
+}
+
+// (PUT /api/slotify-groups/{slotifyGroupID}/users/{userID}/role) Promote or demote a member of a slotifyGroup.
+func (s Server) PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+	memberID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID), zap.Uint32("member_id", memberID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	if _, ok := slotifyGroupRoleRank[body.Role]; !ok {
+		logger.Error("invalid group role", zap.String("role", string(body.Role)))
+		sendError(w, http.StatusBadRequest, "Members can only be an owner, admin or member.")
+		return
+	}
+
+	if _, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID, actionChangeRoles); !ok {
+		return
+	}
+
+	if memberID == userID {
+		logger.Error("owner attempted to change their own role")
+		sendError(w, http.StatusBadRequest, "You cannot change your own role, make another member the owner instead.")
+		return
+	}
+
+	if _, err := getSlotifyGroupRole(ctx, &s.DB.Queries, memberID, slotifyGroupID); err != nil {
+		if errors.Is(err, ErrNotSlotifyGroupMember) {
+			sendError(w, http.StatusNotFound, "User is not a member of the group.")
+			return
+		}
+		logger.Error("failed to get role of member", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to set role")
+		return
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to set role")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	// There is only ever one owner, so making a member the owner makes the current owner an admin
+	if body.Role == Owner {
+		err = transferSlotifyGroupOwnership(ctx, qtx, slotifyGroupID, userID, memberID)
+	} else {
+		_, err = qtx.SetSlotifyGroupMemberRole(ctx, database.SetSlotifyGroupMemberRoleParams{
+			Role:           database.UsertoslotifygroupRole(body.Role),
+			UserID:         memberID,
+			SlotifyGroupID: slotifyGroupID,
+		})
+	}
+	if err != nil {
+		logger.Error("failed to set group role", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to set role")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to set role")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, body)
+}
+
+// (DELETE /api/slotify-groups/{slotifyGroupID}/users/{userID}) Remove a member from a slotifyGroup.
+func (s Server) DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID(w http.ResponseWriter, r *http.Request,
+	slotifyGroupID uint32,
+	memberID uint32,
+) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID),
+		zap.Uint32("slotify_group_id", slotifyGroupID), zap.Uint32("member_id", memberID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*database.DatabaseTimeout)
+	defer cancel()
+
+	role, ok := authoriseSlotifyGroupRequest(ctx, w, logger, s.DB, userID, slotifyGroupID, actionRemoveMembers)
+	if !ok {
+		return
+	}
+
+	if memberID == userID {
+		logger.Error("member attempted to remove themselves")
+		sendError(w, http.StatusBadRequest, "You cannot remove yourself, leave the group instead.")
+		return
+	}
+
+	memberRole, err := getSlotifyGroupRole(ctx, &s.DB.Queries, memberID, slotifyGroupID)
+	if err != nil {
+		if errors.Is(err, ErrNotSlotifyGroupMember) {
+			sendError(w, http.StatusNotFound, "User is not a member of the group.")
+			return
+		}
+		logger.Error("failed to get role of member", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	if !outranks(role, memberRole) {
+		logger.Error("member attempted to remove a member of equal or higher role",
+			zap.String("member_role", string(memberRole)))
+		sendError(w, http.StatusForbidden, "You can only remove members with a lower role than yours.")
+		return
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("failed to start db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	if _, err = qtx.RemoveSlotifyGroupMember(ctx, database.RemoveSlotifyGroupMemberParams{
+		UserID:         memberID,
+		SlotifyGroupID: slotifyGroupID,
+	}); err != nil {
+		logger.Error("failed to remove slotify member", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	if err = enqueueLeaverNotifications(enqueueLeaverNotificationsParams{
+		ctx:            ctx,
+		slotifyGroupID: slotifyGroupID,
+		userID:         memberID,
+		qtx:            qtx,
+	}); err != nil {
+		logger.Error("failed to enqueue leaver notifications", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("failed to commit db transaction", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	SetHeaderAndWriteResponse(w, http.StatusOK, "Successfully removed the member from the group.")
+}
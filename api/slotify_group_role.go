@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"go.uber.org/zap"
+)
+
+// ErrNotSlotifyGroupMember is returned when a user uses a SlotifyGroup they aren't a member of.
+var ErrNotSlotifyGroupMember = errors.New("user is not a member of the slotify group")
+
+// ErrSlotifyGroupForbidden is returned when a member's role doesn't allow them to perform an action on their group.
+var ErrSlotifyGroupForbidden = errors.New("your role in the group does not allow this")
+
+// slotifyGroupAction is something a member does to their SlotifyGroup that needs a minimum role.
+type slotifyGroupAction string
+
+const (
+	actionLeaveGroup                  slotifyGroupAction = "leave the group"
+	actionViewScoringWeights          slotifyGroupAction = "see its scoring weights"
+	actionViewNotificationPreferences slotifyGroupAction = "see its notification preferences"
+	actionScheduleGroupMeeting        slotifyGroupAction = "schedule group meetings"
+	actionCreateGroupPoll             slotifyGroupAction = "create group polls"
+	actionInviteMembers               slotifyGroupAction = "invite members"
+	actionEditGroupSettings           slotifyGroupAction = "edit group settings"
+	actionRemoveMembers               slotifyGroupAction = "remove members"
+	actionChangeRoles                 slotifyGroupAction = "change roles"
+	actionDeleteGroup                 slotifyGroupAction = "delete the group"
+)
+
+// slotifyGroupRoleRank orders the roles, a role can do everything a lower ranked role can.
+// nolint: gochecknoglobals // immutable map, wont change at runtime
+var slotifyGroupRoleRank = map[SlotifyGroupRole]int{
+	Member: 0,
+	Admin:  1,
+	Owner:  2,
+}
+
+// slotifyGroupActionRole is the least role needed for each action.
+// nolint: gochecknoglobals // immutable map, wont change at runtime
+var slotifyGroupActionRole = map[slotifyGroupAction]SlotifyGroupRole{
+	actionLeaveGroup:                  Member,
+	actionViewScoringWeights:          Member,
+	actionViewNotificationPreferences: Member,
+	actionScheduleGroupMeeting:        Member,
+	actionCreateGroupPoll:             Member,
+	actionInviteMembers:               Admin,
+	actionEditGroupSettings:           Admin,
+	actionRemoveMembers:               Admin,
+	actionChangeRoles:                 Owner,
+	actionDeleteGroup:                 Owner,
+}
+
+// outranks is whether role a is higher than role b.
+func outranks(a SlotifyGroupRole, b SlotifyGroupRole) bool {
+	return slotifyGroupRoleRank[a] > slotifyGroupRoleRank[b]
+}
+
+// getSlotifyGroupRole gets the role of userID in a group. An ErrNotSlotifyGroupMember error is returned if they
+// aren't a member.
+func getSlotifyGroupRole(ctx context.Context, q *database.Queries, userID uint32,
+	slotifyGroupID uint32,
+) (SlotifyGroupRole, error) {
+	role, err := q.GetSlotifyGroupMemberRole(ctx, database.GetSlotifyGroupMemberRoleParams{
+		UserID:         userID,
+		SlotifyGroupID: slotifyGroupID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotSlotifyGroupMember
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get role of member in slotify group: %w", err)
+	}
+	return SlotifyGroupRole(role), nil
+}
+
+// authoriseSlotifyGroupAction checks userID's role in a group allows them to perform action, and returns the role.
+// An ErrNotSlotifyGroupMember error is returned if they aren't a member, and an ErrSlotifyGroupForbidden error if
+// their role is too low.
+func authoriseSlotifyGroupAction(ctx context.Context, q *database.Queries, userID uint32, slotifyGroupID uint32,
+	action slotifyGroupAction,
+) (SlotifyGroupRole, error) {
+	role, err := getSlotifyGroupRole(ctx, q, userID, slotifyGroupID)
+	if err != nil {
+		return "", err
+	}
+	if outranks(slotifyGroupActionRole[action], role) {
+		return role, fmt.Errorf("%w: only a group %s or higher can %s", ErrSlotifyGroupForbidden,
+			slotifyGroupActionRole[action], action)
+	}
+	return role, nil
+}
+
+// authoriseSlotifyGroupRequest is authoriseSlotifyGroupAction for handlers. If userID can't perform action the
+// error response is sent and false is returned.
+func authoriseSlotifyGroupRequest(ctx context.Context, w http.ResponseWriter, logger *zap.SugaredLogger,
+	db *database.Database,
+	userID uint32,
+	slotifyGroupID uint32,
+	action slotifyGroupAction,
+) (SlotifyGroupRole, bool) {
+	role, err := authoriseSlotifyGroupAction(ctx, &db.Queries, userID, slotifyGroupID, action)
+	switch {
+	case errors.Is(err, ErrNotSlotifyGroupMember):
+		logger.Error("member not part of group attempted to "+string(action), zap.Error(err))
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("You are not a member of the group, you cannot %s.", action))
+		return "", false
+	case errors.Is(err, ErrSlotifyGroupForbidden):
+		logger.Error("member without permission attempted to "+string(action), zap.Error(err))
+		sendError(w, http.StatusForbidden, err.Error())
+		return "", false
+	case err != nil:
+		logger.Error("failed to get role of member in slotify group", zap.Error(err))
+		sendError(w, http.StatusInternalServerError, "Failed to check your role in the group")
+		return "", false
+	}
+	return role, true
+}
+
+// transferSlotifyGroupOwnership makes newOwnerID the owner of a group and demotes the current owner to admin,
+// in the transaction of qtx.
+func transferSlotifyGroupOwnership(ctx context.Context, qtx *database.Queries, slotifyGroupID uint32,
+	ownerID uint32,
+	newOwnerID uint32,
+) error {
+	if _, err := qtx.SetSlotifyGroupMemberRole(ctx, database.SetSlotifyGroupMemberRoleParams{
+		Role:           database.UsertoslotifygroupRoleAdmin,
+		UserID:         ownerID,
+		SlotifyGroupID: slotifyGroupID,
+	}); err != nil {
+		return fmt.Errorf("failed to demote owner: %w", err)
+	}
+	if _, err := qtx.SetSlotifyGroupMemberRole(ctx, database.SetSlotifyGroupMemberRoleParams{
+		Role:           database.UsertoslotifygroupRoleOwner,
+		UserID:         newOwnerID,
+		SlotifyGroupID: slotifyGroupID,
+	}); err != nil {
+		return fmt.Errorf("failed to promote new owner: %w", err)
+	}
+	return nil
+}
+
+// passOnSlotifyGroupOwnership makes another member the owner of a group if userID, who is leaving it, is the
+// owner, in the transaction of qtx. Admins are preferred over members, then the member with the lowest id.
+func passOnSlotifyGroupOwnership(ctx context.Context, qtx *database.Queries, slotifyGroupID uint32,
+	userID uint32,
+) error {
+	role, err := getSlotifyGroupRole(ctx, qtx, userID, slotifyGroupID)
+	if err != nil {
+		return err
+	}
+	if role != Owner {
+		return nil
+	}
+
+	successorID, err := qtx.GetSlotifyGroupSuccessor(ctx, database.GetSlotifyGroupSuccessorParams{
+		SlotifyGroupID: slotifyGroupID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get next owner of slotify group: %w", err)
+	}
+
+	if _, err = qtx.SetSlotifyGroupMemberRole(ctx, database.SetSlotifyGroupMemberRoleParams{
+		Role:           database.UsertoslotifygroupRoleOwner,
+		UserID:         successorID,
+		SlotifyGroupID: slotifyGroupID,
+	}); err != nil {
+		return fmt.Errorf("failed to promote next owner: %w", err)
+	}
+	return nil
+}
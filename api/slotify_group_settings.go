@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+)
+
+// ErrInvalidSlotifyGroupSettings is returned when a SlotifyGroup update has invalid settings.
+var ErrInvalidSlotifyGroupSettings = errors.New("invalid slotify group settings")
+
+const (
+	// slotifyGroupDescriptionMaxLength is the length of the SlotifyGroup description column.
+	slotifyGroupDescriptionMaxLength = 1024
+	// defaultGroupMeetingDuration is the length of a group meeting when neither it nor its group gives one.
+	defaultGroupMeetingDuration = "PT30M"
+)
+
+// toSlotifyGroup converts a database SlotifyGroup into its openapi representation.
+func toSlotifyGroup(sg database.SlotifyGroup) SlotifyGroup {
+	group := SlotifyGroup{
+		Id:                     sg.ID,
+		Name:                   sg.Name,
+		Description:            sg.Description,
+		Visibility:             SlotifyGroupVisibility(sg.Visibility),
+		DefaultIsOnlineMeeting: sg.DefaultIsOnlineMeeting,
+	}
+	if sg.AvatarColour.Valid {
+		group.AvatarColour = &sg.AvatarColour.String
+	}
+	if sg.DefaultMeetingDuration.Valid {
+		group.DefaultMeetingDuration = &sg.DefaultMeetingDuration.String
+	}
+	if sg.DefaultLocationName.Valid {
+		location := Location{Name: &sg.DefaultLocationName.String}
+		if sg.DefaultLocationEmail.Valid {
+			roomType := ConferenceRoom
+			location.EmailAddress, location.RoomType = &sg.DefaultLocationEmail.String, &roomType
+		}
+		group.DefaultLocation = &location
+	}
+	return group
+}
+
+// validAvatarColour is whether colour is a hex colour such as '#4F46E5'.
+func validAvatarColour(colour string) bool {
+	if len(colour) != len("#000000") || colour[0] != '#' {
+		return false
+	}
+	for _, c := range colour[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// toUpdateSlotifyGroupParams applies the settings in body to sg. Settings left out of body are kept, and an empty
+// avatar colour, default meeting duration or default location name clears the setting.
+// An ErrInvalidSlotifyGroupSettings error is returned if any setting is invalid.
+func toUpdateSlotifyGroupParams(sg database.SlotifyGroup, body SlotifyGroupUpdate,
+) (database.UpdateSlotifyGroupParams, error) {
+	params := database.UpdateSlotifyGroupParams{
+		Name:                   sg.Name,
+		Description:            sg.Description,
+		AvatarColour:           sg.AvatarColour,
+		Visibility:             sg.Visibility,
+		DefaultMeetingDuration: sg.DefaultMeetingDuration,
+		DefaultLocationName:    sg.DefaultLocationName,
+		DefaultLocationEmail:   sg.DefaultLocationEmail,
+		DefaultIsOnlineMeeting: sg.DefaultIsOnlineMeeting,
+		ID:                     sg.ID,
+	}
+
+	if body.Name != nil {
+		if params.Name = strings.TrimSpace(*body.Name); params.Name == "" {
+			return params, fmt.Errorf("%w: name can't be empty", ErrInvalidSlotifyGroupSettings)
+		}
+	}
+	if body.Description != nil {
+		if utf8.RuneCountInString(*body.Description) > slotifyGroupDescriptionMaxLength {
+			return params, fmt.Errorf("%w: description can't be longer than %d characters",
+				ErrInvalidSlotifyGroupSettings, slotifyGroupDescriptionMaxLength)
+		}
+		params.Description = *body.Description
+	}
+	if body.AvatarColour != nil {
+		if *body.AvatarColour != "" && !validAvatarColour(*body.AvatarColour) {
+			return params, fmt.Errorf("%w: avatar colour must be a hex colour such as #4F46E5",
+				ErrInvalidSlotifyGroupSettings)
+		}
+		params.AvatarColour = sql.NullString{String: *body.AvatarColour, Valid: *body.AvatarColour != ""}
+	}
+	if body.Visibility != nil {
+		if *body.Visibility != Private && *body.Visibility != Discoverable {
+			return params, fmt.Errorf("%w: visibility must be private or discoverable", ErrInvalidSlotifyGroupSettings)
+		}
+		params.Visibility = database.SlotifygroupVisibility(*body.Visibility)
+	}
+	if body.DefaultMeetingDuration != nil {
+		if d := *body.DefaultMeetingDuration; d != "" {
+			if duration, err := parseISODuration(d); err != nil || duration <= 0 {
+				return params, fmt.Errorf("%w: default meeting duration must be a positive ISO 8601 duration",
+					ErrInvalidSlotifyGroupSettings)
+			}
+		}
+		params.DefaultMeetingDuration = sql.NullString{
+			String: *body.DefaultMeetingDuration,
+			Valid:  *body.DefaultMeetingDuration != "",
+		}
+	}
+	if body.DefaultLocation != nil {
+		var name, email string
+		if body.DefaultLocation.Name != nil {
+			name = *body.DefaultLocation.Name
+		}
+		if body.DefaultLocation.EmailAddress != nil {
+			email = *body.DefaultLocation.EmailAddress
+		}
+		params.DefaultLocationName = sql.NullString{String: name, Valid: name != ""}
+		params.DefaultLocationEmail = sql.NullString{String: email, Valid: name != "" && email != ""}
+	}
+	if body.DefaultIsOnlineMeeting != nil {
+		params.DefaultIsOnlineMeeting = *body.DefaultIsOnlineMeeting
+	}
+
+	return params, nil
+}
+
+// applySlotifyGroupEventDefaults gives event the group's default location if it has no locations, and the group's
+// Teams setting if it doesn't say whether it is a Teams meeting.
+func applySlotifyGroupEventDefaults(event *CalendarEvent, sg database.SlotifyGroup) {
+	if len(event.Locations) == 0 {
+		if location := toSlotifyGroup(sg).DefaultLocation; location != nil {
+			event.Locations = []Location{*location}
+		}
+	}
+	if event.IsOnlineMeeting == nil {
+		isOnlineMeeting := sg.DefaultIsOnlineMeeting
+		event.IsOnlineMeeting = &isOnlineMeeting
+	}
+}
+
+// groupMeetingDuration is the length of a group meeting, the group's default is used if the meeting doesn't
+// give one.
+func groupMeetingDuration(meetingDuration *string, sg database.SlotifyGroup) string {
+	switch {
+	case meetingDuration != nil && *meetingDuration != "":
+		return *meetingDuration
+	case sg.DefaultMeetingDuration.Valid:
+		return sg.DefaultMeetingDuration.String
+	default:
+		return defaultGroupMeetingDuration
+	}
+}
+
+// enqueueGroupRenamed enqueues a notification to the members of a group, other than userID who renamed it,
+// that it was renamed from oldName, in the transaction of qtx.
+func enqueueGroupRenamed(ctx context.Context, qtx *database.Queries, slotifyGroupID uint32, userID uint32,
+	oldName string,
+	newName string,
+) error {
+	members, err := qtx.GetAllSlotifyGroupMembersExcept(ctx, database.GetAllSlotifyGroupMembersExceptParams{
+		SlotifyGroupID: slotifyGroupID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get slotify group members except the renamer: %w", err)
+	}
+
+	u, err := qtx.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	notif, err := notification.NewNotificationParams(notification.GroupRenamed{
+		SlotifyGroupID: slotifyGroupID,
+		OldName:        oldName,
+		GroupName:      newName,
+		UserID:         userID,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+	}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	if err = notification.Enqueue(ctx, qtx, "", members, notif); err != nil {
+		return fmt.Errorf("failed to enqueue rename notification to members: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// graphDateTimeLayout is the layout of MSFT dateTimeTimeZone date times, a wall clock time in the
+// accompanying time zone.
+const graphDateTimeLayout = "2006-01-02T15:04:05.0000000"
+
+// ErrInvalidTimeZone is returned when a time zone is neither an IANA nor a Windows time zone name.
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+// windowsTimeZones maps the Windows time zone names MSFT uses onto IANA time zone names, following
+// the territory independent ('001') mappings in [CLDR Windows Zones].
+//
+// [CLDR Windows Zones]: https://github.com/unicode-org/cldr/blob/main/common/supplemental/windowsZones.xml
+// nolint: gochecknoglobals // immutable map, wont change at runtime
+var windowsTimeZones = map[string]string{
+	"Dateline Standard Time":          "Etc/GMT+12",
+	"UTC-11":                          "Etc/GMT+11",
+	"Aleutian Standard Time":          "America/Adak",
+	"Hawaiian Standard Time":          "Pacific/Honolulu",
+	"Marquesas Standard Time":         "Pacific/Marquesas",
+	"Alaskan Standard Time":           "America/Anchorage",
+	"UTC-09":                          "Etc/GMT+9",
+	"Pacific Standard Time (Mexico)":  "America/Tijuana",
+	"UTC-08":                          "Etc/GMT+8",
+	"Pacific Standard Time":           "America/Los_Angeles",
+	"US Mountain Standard Time":       "America/Phoenix",
+	"Mountain Standard Time (Mexico)": "America/Mazatlan",
+	"Mountain Standard Time":          "America/Denver",
+	"Yukon Standard Time":             "America/Whitehorse",
+	"Central America Standard Time":   "America/Guatemala",
+	"Central Standard Time":           "America/Chicago",
+	"Easter Island Standard Time":     "Pacific/Easter",
+	"Central Standard Time (Mexico)":  "America/Mexico_City",
+	"Canada Central Standard Time":    "America/Regina",
+	"SA Pacific Standard Time":        "America/Bogota",
+	"Eastern Standard Time (Mexico)":  "America/Cancun",
+	"Eastern Standard Time":           "America/New_York",
+	"Haiti Standard Time":             "America/Port-au-Prince",
+	"Cuba Standard Time":              "America/Havana",
+	"US Eastern Standard Time":        "America/Indiana/Indianapolis",
+	"Turks And Caicos Standard Time":  "America/Grand_Turk",
+	"Paraguay Standard Time":          "America/Asuncion",
+	"Atlantic Standard Time":          "America/Halifax",
+	"Venezuela Standard Time":         "America/Caracas",
+	"Central Brazilian Standard Time": "America/Cuiaba",
+	"SA Western Standard Time":        "America/La_Paz",
+	"Pacific SA Standard Time":        "America/Santiago",
+	"Newfoundland Standard Time":      "America/St_Johns",
+	"Tocantins Standard Time":         "America/Araguaina",
+	"E. South America Standard Time":  "America/Sao_Paulo",
+	"SA Eastern Standard Time":        "America/Cayenne",
+	"Argentina Standard Time":         "America/Argentina/Buenos_Aires",
+	"Greenland Standard Time":         "America/Nuuk",
+	"Montevideo Standard Time":        "America/Montevideo",
+	"Magallanes Standard Time":        "America/Punta_Arenas",
+	"Saint Pierre Standard Time":      "America/Miquelon",
+	"Bahia Standard Time":             "America/Bahia",
+	"UTC-02":                          "Etc/GMT+2",
+	"Azores Standard Time":            "Atlantic/Azores",
+	"Cape Verde Standard Time":        "Atlantic/Cape_Verde",
+	"UTC":                             "UTC",
+	"GMT Standard Time":               "Europe/London",
+	"Greenwich Standard Time":         "Atlantic/Reykjavik",
+	"Sao Tome Standard Time":          "Africa/Sao_Tome",
+	"Morocco Standard Time":           "Africa/Casablanca",
+	"W. Europe Standard Time":         "Europe/Berlin",
+	"Central Europe Standard Time":    "Europe/Budapest",
+	"Romance Standard Time":           "Europe/Paris",
+	"Central European Standard Time":  "Europe/Warsaw",
+	"W. Central Africa Standard Time": "Africa/Lagos",
+	"Jordan Standard Time":            "Asia/Amman",
+	"GTB Standard Time":               "Europe/Bucharest",
+	"Middle East Standard Time":       "Asia/Beirut",
+	"Egypt Standard Time":             "Africa/Cairo",
+	"E. Europe Standard Time":         "Europe/Chisinau",
+	"Syria Standard Time":             "Asia/Damascus",
+	"West Bank Standard Time":         "Asia/Hebron",
+	"South Africa Standard Time":      "Africa/Johannesburg",
+	"FLE Standard Time":               "Europe/Kyiv",
+	"Israel Standard Time":            "Asia/Jerusalem",
+	"South Sudan Standard Time":       "Africa/Juba",
+	"Kaliningrad Standard Time":       "Europe/Kaliningrad",
+	"Sudan Standard Time":             "Africa/Khartoum",
+	"Libya Standard Time":             "Africa/Tripoli",
+	"Namibia Standard Time":           "Africa/Windhoek",
+	"Arabic Standard Time":            "Asia/Baghdad",
+	"Turkey Standard Time":            "Europe/Istanbul",
+	"Arab Standard Time":              "Asia/Riyadh",
+	"Belarus Standard Time":           "Europe/Minsk",
+	"Russian Standard Time":           "Europe/Moscow",
+	"E. Africa Standard Time":         "Africa/Nairobi",
+	"Volgograd Standard Time":         "Europe/Volgograd",
+	"Iran Standard Time":              "Asia/Tehran",
+	"Arabian Standard Time":           "Asia/Dubai",
+	"Astrakhan Standard Time":         "Europe/Astrakhan",
+	"Azerbaijan Standard Time":        "Asia/Baku",
+	"Russia Time Zone 3":              "Europe/Samara",
+	"Mauritius Standard Time":         "Indian/Mauritius",
+	"Saratov Standard Time":           "Europe/Saratov",
+	"Georgian Standard Time":          "Asia/Tbilisi",
+	"Caucasus Standard Time":          "Asia/Yerevan",
+	"Afghanistan Standard Time":       "Asia/Kabul",
+	"West Asia Standard Time":         "Asia/Tashkent",
+	"Ekaterinburg Standard Time":      "Asia/Yekaterinburg",
+	"Pakistan Standard Time":          "Asia/Karachi",
+	"Qyzylorda Standard Time":         "Asia/Qyzylorda",
+	"India Standard Time":             "Asia/Kolkata",
+	"Sri Lanka Standard Time":         "Asia/Colombo",
+	"Nepal Standard Time":             "Asia/Kathmandu",
+	"Central Asia Standard Time":      "Asia/Almaty",
+	"Bangladesh Standard Time":        "Asia/Dhaka",
+	"Omsk Standard Time":              "Asia/Omsk",
+	"Myanmar Standard Time":           "Asia/Yangon",
+	"SE Asia Standard Time":           "Asia/Bangkok",
+	"Altai Standard Time":             "Asia/Barnaul",
+	"W. Mongolia Standard Time":       "Asia/Hovd",
+	"North Asia Standard Time":        "Asia/Krasnoyarsk",
+	"N. Central Asia Standard Time":   "Asia/Novosibirsk",
+	"Tomsk Standard Time":             "Asia/Tomsk",
+	"China Standard Time":             "Asia/Shanghai",
+	"North Asia East Standard Time":   "Asia/Irkutsk",
+	"Singapore Standard Time":         "Asia/Singapore",
+	"W. Australia Standard Time":      "Australia/Perth",
+	"Taipei Standard Time":            "Asia/Taipei",
+	"Ulaanbaatar Standard Time":       "Asia/Ulaanbaatar",
+	"Aus Central W. Standard Time":    "Australia/Eucla",
+	"Transbaikal Standard Time":       "Asia/Chita",
+	"Tokyo Standard Time":             "Asia/Tokyo",
+	"North Korea Standard Time":       "Asia/Pyongyang",
+	"Korea Standard Time":             "Asia/Seoul",
+	"Yakutsk Standard Time":           "Asia/Yakutsk",
+	"Cen. Australia Standard Time":    "Australia/Adelaide",
+	"AUS Central Standard Time":       "Australia/Darwin",
+	"E. Australia Standard Time":      "Australia/Brisbane",
+	"AUS Eastern Standard Time":       "Australia/Sydney",
+	"West Pacific Standard Time":      "Pacific/Port_Moresby",
+	"Tasmania Standard Time":          "Australia/Hobart",
+	"Vladivostok Standard Time":       "Asia/Vladivostok",
+	"Lord Howe Standard Time":         "Australia/Lord_Howe",
+	"Bougainville Standard Time":      "Pacific/Bougainville",
+	"Russia Time Zone 10":             "Asia/Srednekolymsk",
+	"Magadan Standard Time":           "Asia/Magadan",
+	"Norfolk Standard Time":           "Pacific/Norfolk",
+	"Sakhalin Standard Time":          "Asia/Sakhalin",
+	"Central Pacific Standard Time":   "Pacific/Guadalcanal",
+	"Russia Time Zone 11":             "Asia/Kamchatka",
+	"New Zealand Standard Time":       "Pacific/Auckland",
+	"UTC+12":                          "Etc/GMT-12",
+	"Fiji Standard Time":              "Pacific/Fiji",
+	"Chatham Islands Standard Time":   "Pacific/Chatham",
+	"UTC+13":                          "Etc/GMT-13",
+	"Tonga Standard Time":             "Pacific/Tongatapu",
+	"Samoa Standard Time":             "Pacific/Apia",
+	"Line Islands Standard Time":      "Pacific/Kiritimati",
+}
+
+// ianaTimeZones maps IANA time zone names back onto the Windows time zones in windowsTimeZones.
+// nolint: gochecknoglobals // built once from windowsTimeZones, wont change at runtime
+var ianaTimeZones = func() map[string]string {
+	m := make(map[string]string, len(windowsTimeZones)+1)
+	for windows, iana := range windowsTimeZones {
+		m[iana] = windows
+	}
+	m["Etc/UTC"] = "UTC"
+	return m
+}()
+
+// parseTimeZone loads an IANA or Windows time zone name, Windows names are loaded as their IANA
+// equivalent. The empty and 'Local' zones time.LoadLocation accepts are rejected.
+func parseTimeZone(name string) (*time.Location, error) {
+	if iana, ok := windowsTimeZones[name]; ok {
+		name = iana
+	}
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidTimeZone, name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidTimeZone, name)
+	}
+	return loc, nil
+}
+
+// graphTimeZoneName returns the Windows name of loc for MSFT Graph, its IANA name is returned if it has
+// no Windows equivalent as Graph accepts those too.
+func graphTimeZoneName(loc *time.Location) string {
+	if windows, ok := ianaTimeZones[loc.String()]; ok {
+		return windows
+	}
+	return loc.String()
+}
+
+// toGraphDateTimeTimeZone converts t into a MSFT dateTimeTimeZone, as a wall clock time in loc (UTC if nil).
+func toGraphDateTimeTimeZone(t time.Time, loc *time.Location) *graphmodels.DateTimeTimeZone {
+	if loc == nil {
+		loc = time.UTC
+	}
+	dateTime := t.In(loc).Format(graphDateTimeLayout)
+	timeZone := graphTimeZoneName(loc)
+
+	dt := graphmodels.NewDateTimeTimeZone()
+	dt.SetDateTime(&dateTime)
+	dt.SetTimeZone(&timeZone)
+	return dt
+}
+
+// parseGraphDateTimeTimeZone parses a MSFT dateTimeTimeZone into a time in its time zone, UTC if it has none.
+func parseGraphDateTimeTimeZone(dt graphmodels.DateTimeTimeZoneable) (time.Time, error) {
+	if dt == nil || dt.GetDateTime() == nil {
+		return time.Time{}, errors.New("msft date time is missing")
+	}
+
+	loc := time.UTC
+	if dt.GetTimeZone() != nil {
+		var err error
+		if loc, err = parseTimeZone(*dt.GetTimeZone()); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	t, err := time.ParseInLocation(graphDateTimeLayout, *dt.GetDateTime(), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse msft date time %s: %w", *dt.GetDateTime(), err)
+	}
+	return t, nil
+}
+
+// calendarEventLocation returns the time zone an event is created in, UTC if it has none.
+func calendarEventLocation(e CalendarEvent) (*time.Location, error) {
+	if e.TimeZone == nil {
+		return time.UTC, nil
+	}
+	return parseTimeZone(*e.TimeZone)
+}
+
+// getUserLocation gets the time zone a user has stored.
+func getUserLocation(ctx context.Context, db *database.Database, userID uint32) (*time.Location, error) {
+	u, err := db.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return parseTimeZone(u.TimeZone)
+}
+
+// requestLocation returns the time zone a request is made in, timeZone if it is set and otherwise the
+// time zone of the user making it. An ErrInvalidTimeZone error is returned if timeZone can't be loaded.
+func requestLocation(ctx context.Context, db *database.Database, userID uint32,
+	timeZone *string,
+) (*time.Location, error) {
+	if timeZone != nil {
+		return parseTimeZone(*timeZone)
+	}
+	return getUserLocation(ctx, db, userID)
+}
+
+// calendarEventIn returns e with its times in loc, so they carry loc's offset rather than UTC's.
+func calendarEventIn(e CalendarEvent, loc *time.Location) (CalendarEvent, error) {
+	for _, t := range []**string{&e.StartTime, &e.EndTime, &e.OriginalStartTime} {
+		if *t == nil {
+			continue
+		}
+		parsed, err := ParseCalendarEventTime(**t)
+		if err != nil {
+			return CalendarEvent{}, err
+		}
+		formatted := parsed.In(loc).Format(time.RFC3339)
+		*t = &formatted
+	}
+	return e, nil
+}
+
+// calendarEventsIn returns events with their times in loc, see calendarEventIn.
+func calendarEventsIn(events []CalendarEvent, loc *time.Location) ([]CalendarEvent, error) {
+	converted := make([]CalendarEvent, 0, len(events))
+	for _, e := range events {
+		c, err := calendarEventIn(e, loc)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, c)
+	}
+	return converted, nil
+}
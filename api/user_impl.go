@@ -201,6 +201,7 @@ func (s Server) GetAPIUsersUserID(w http.ResponseWriter, r *http.Request, userID
 		Email:     openapi_types.Email(dbUser.Email),
 		FirstName: dbUser.FirstName,
 		LastName:  dbUser.LastName,
+		TimeZone:  &dbUser.TimeZone,
 	}
 
 	SetHeaderAndWriteResponse(w, http.StatusOK, u)
@@ -213,6 +214,49 @@ func (s Server) GetAPIUsersMe(w http.ResponseWriter, r *http.Request) {
 	s.GetAPIUsersUserID(w, r, userID)
 }
 
+// (PUT /users/me/time-zone).
+func (s Server) PutAPIUsersMeTimeZone(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+
+	logger := s.Logger.With(zap.String("request_id", reqID), zap.Uint32("user_id", userID))
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	var body PutAPIUsersMeTimeZoneJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error(ErrUnmarshalBody, zap.Error(err))
+		sendError(w, http.StatusBadRequest, ErrUnmarshalBody.Error())
+		return
+	}
+
+	// Windows time zones are stored as their IANA equivalent
+	loc, err := parseTimeZone(body.TimeZone)
+	if err != nil {
+		logger.Error("user api: invalid time zone", zap.Error(err))
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err = s.DB.UpdateUserTimeZone(ctx, database.UpdateUserTimeZoneParams{
+		TimeZone: loc.String(),
+		ID:       userID,
+	}); err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			logger.Error("user api: query timed out", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "user api: query timed out")
+		default:
+			logger.Error("user api: failed to save time zone", zap.Error(err))
+			sendError(w, http.StatusInternalServerError, "user api: failed to save time zone")
+		}
+		return
+	}
+
+	s.GetAPIUsersUserID(w, r, userID)
+}
+
 // (POST /users/logout).
 func (s Server) PostAPIUsersMeLogout(w http.ResponseWriter, r *http.Request) {
 	userID, _ := r.Context().Value(UserIDCtxKey{}).(uint32)
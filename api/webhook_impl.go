@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"go.uber.org/zap"
+)
+
+// (POST /api/webhooks/calendar).
+func (s Server) PostAPIWebhooksCalendar(w http.ResponseWriter, r *http.Request, params PostAPIWebhooksCalendarParams) {
+	reqID, _ := r.Context().Value(RequestIDCtxKey{}).(string)
+	logger := s.Logger.With(zap.String("request_id", reqID))
+
+	// Graph checks the notification URL when a subscription is created, the token has to be echoed back
+	if params.ValidationToken != nil {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(*params.ValidationToken))
+		return
+	}
+
+	var body GraphChangeNotificationCollection
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		logger.Error("failed to parse calendar notifications", zap.Error(ErrUnmarshalBody), zap.Error(err))
+		sendError(w, http.StatusBadRequest, "Invalid request body.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), database.DatabaseTimeout)
+	defer cancel()
+
+	// Graph expects a reply within a few seconds, so changes are only checked here and handled in the background
+	for _, n := range body.Value {
+		userID, ok := s.verifyCalendarNotification(ctx, logger, n)
+		if !ok {
+			continue
+		}
+		if !s.CalendarChanges.enqueue(calendarChange{userID: userID, notification: n, requestID: reqID}) {
+			logger.Error("calendar change queue is full, asking graph to send notifications again later")
+			sendError(w, http.StatusServiceUnavailable, "Too many calendar changes, try again later.")
+			return
+		}
+	}
+
+	// Graph retries anything other than a 2xx, so failures are only logged
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verifyCalendarNotification checks a change notification came from a user's subscription and returns the user,
+// false is returned if it should be ignored.
+func (s Server) verifyCalendarNotification(ctx context.Context, l *zap.SugaredLogger,
+	n GraphChangeNotification,
+) (uint32, bool) {
+	logger := l.With(zap.String("subscription_id", n.SubscriptionId))
+
+	subscription, err := s.DB.GetCalendarSubscription(ctx, n.SubscriptionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("ignoring calendar notification for an unknown subscription")
+		return 0, false
+	}
+	if err != nil {
+		logger.Error("failed to get calendar subscription", zap.Error(err))
+		return 0, false
+	}
+
+	if n.ClientState == nil ||
+		subtle.ConstantTimeCompare([]byte(*n.ClientState), []byte(subscription.ClientState)) != 1 {
+		logger.Warn("ignoring calendar notification with the wrong client state")
+		return 0, false
+	}
+	return subscription.UserID, true
+}
+
+// handleCalendarChange marks the user's cached events as stale and tells anyone affected by the change.
+func (s Server) handleCalendarChange(ctx context.Context, l *zap.SugaredLogger, c calendarChange) {
+	logger := l.With(zap.String("subscription_id", c.notification.SubscriptionId), zap.Uint32("user_id", c.userID))
+
+	if _, err := s.DB.ExpireCalendarSync(ctx, c.userID); err != nil {
+		logger.Error("failed to expire calendar cache", zap.Error(err))
+	}
+
+	n := c.notification
+	if n.ChangeType != Updated || n.ResourceData == nil || n.ResourceData.Id == nil {
+		return
+	}
+
+	if err := s.notifyMeetingMoved(ctx, c.userID, *n.ResourceData.Id); err != nil {
+		logger.Error("failed to notify requesters of moved meeting", zap.Error(err))
+	}
+}
+
+// notifyMeetingMoved tells users with a pending reschedule request for a meeting that its owner has moved it,
+// if eventID is a meeting owned by userID and its start time has changed.
+func (s Server) notifyMeetingMoved(ctx context.Context, userID uint32, eventID string) error {
+	calendar, err := s.CalendarProvider(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create calendar provider: %w", err)
+	}
+
+	event, err := calendar.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event from calendar provider: %w", err)
+	}
+	if event.ICalUId == nil || event.StartTime == nil {
+		return nil
+	}
+
+	meeting, err := s.DB.GetMeetingByMSFTID(ctx, *event.ICalUId)
+	if errors.Is(err, sql.ErrNoRows) {
+		// No one has asked to reschedule this meeting
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get meeting: %w", err)
+	}
+
+	user, err := s.DB.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	// Attendees' copies of the meeting change too, only the owner's is acted on
+	if !strings.EqualFold(meeting.OwnerEmail, user.Email) {
+		return nil
+	}
+
+	start, err := ParseCalendarEventTime(*event.StartTime)
+	if err != nil {
+		return err
+	}
+
+	prefs, err := s.DB.GetMeetingPreferences(ctx, meeting.MeetingPrefID)
+	if err != nil {
+		return fmt.Errorf("failed to get meeting preferences: %w", err)
+	}
+	if start.Equal(prefs.MeetingStartTime.UTC()) {
+		return nil
+	}
+
+	tx, err := s.DB.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start db transaction: %w", err)
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			s.Logger.Error("failed to rollback db transaction", zap.Error(err))
+		}
+	}()
+
+	qtx := s.DB.WithTx(tx)
+
+	if _, err = qtx.UpdateMeetingStartTime(ctx, database.UpdateMeetingStartTimeParams{
+		MeetingStartTime: start,
+		ID:               meeting.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to update meeting start time: %w", err)
+	}
+
+	requesters, err := qtx.ListPendingRescheduleRequesters(ctx, meeting.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list reschedule requesters: %w", err)
+	}
+
+	moved := notification.MeetingMoved{
+		MeetingID: meeting.ID,
+		EventID:   eventID,
+		Start:     start,
+	}
+	if event.Subject != nil {
+		moved.Subject = *event.Subject
+	}
+
+	notif, err := notification.NewNotificationParams(moved, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	// Graph may send the same change more than once
+	key := notification.IdempotencyKey(notif.Kind, meeting.ID, start.Unix())
+	if err = notification.Enqueue(ctx, qtx, key, requesters, notif); err != nil {
+		return fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit db transaction: %w", err)
+	}
+	return nil
+}
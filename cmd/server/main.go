@@ -2,18 +2,25 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"log"
 	"net/http"
+	"os"
 	"time"
+	// Time zones are loaded by name, the host may not have a time zone database
+	_ "time/tzdata"
 
 	"github.com/SlotifyApp/slotify-backend/api"
 	"github.com/SlotifyApp/slotify-backend/cron"
 	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
 	"github.com/gorilla/mux"
 )
 
 const (
 	readHeaderTimeout = 5
+	// metricsAddrEnvName is the env var with the address metrics are served on, they aren't served without it.
+	metricsAddrEnvName = "METRICS_ADDR"
 )
 
 func main() {
@@ -67,5 +74,42 @@ func main() {
 		log.Fatalf("failed to register db cron jobs: %s", err.Error())
 	}
 
+	if err = cron.RegisterCalendarCacheCronJob(server, server.Logger); err != nil {
+		log.Fatalf("failed to register calendar cache cron job: %s", err.Error())
+	}
+
+	if err = cron.RegisterCalendarSubscriptionCronJob(server, server.Logger); err != nil {
+		log.Fatalf("failed to register calendar subscription cron job: %s", err.Error())
+	}
+
+	if err = cron.RegisterPollCronJob(server, server.Logger); err != nil {
+		log.Fatalf("failed to register poll cron job: %s", err.Error())
+	}
+
+	dispatcher, err := notification.NewDispatcher(server.DB, server.NotificationService, server.Logger)
+	if err != nil {
+		log.Fatalf("failed to create notification dispatcher: %s", err.Error())
+	}
+	go dispatcher.Run(ctx)
+	go server.CalendarChanges.Run(ctx)
+
+	if server.Emailer != nil {
+		if err = cron.RegisterNotificationDigestCronJob(server.DB, server.Emailer, server.Logger); err != nil {
+			log.Fatalf("failed to register notification digest cron job: %s", err.Error())
+		}
+	}
+
+	// Metrics are served apart from the API, so they aren't behind its auth or exposed with it
+	if addr, present := os.LookupEnv(metricsAddrEnvName); present {
+		metrics := &http.Server{
+			Handler:           expvar.Handler(),
+			Addr:              addr,
+			ReadHeaderTimeout: readHeaderTimeout * time.Second,
+		}
+		go func() {
+			log.Printf("metrics server stopped: %+v", metrics.ListenAndServe())
+		}()
+	}
+
 	log.Fatal(s.ListenAndServe())
 }
@@ -2,6 +2,8 @@ package cron
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,8 +16,54 @@ import (
 
 const (
 	BATCHSIZE = 50
+
+	// CalendarCacheSyncSpec is how often cached calendar events are synced.
+	CalendarCacheSyncSpec = "@every 1m"
+	// calendarCacheSyncTimeout is the max time for one run of the calendar cache sync.
+	calendarCacheSyncTimeout = 5 * time.Minute
+
+	// CalendarSubscriptionRenewSpec is how often calendar subscriptions close to expiring are renewed.
+	CalendarSubscriptionRenewSpec = "@hourly"
+	// calendarSubscriptionRenewTimeout is the max time for one run of the calendar subscription renewal.
+	calendarSubscriptionRenewTimeout = 10 * time.Minute
+
+	// PollCloseSpec is how often polls past their deadline are closed.
+	PollCloseSpec = "@every 1m"
+	// pollCloseTimeout is the max time for one run of closing polls.
+	pollCloseTimeout = 5 * time.Minute
+
+	// NotificationDigestSpec is when users are emailed a digest of their unread notifications, 8am server time.
+	NotificationDigestSpec = "0 8 * * *"
+	// NotificationDigestPeriod is how far back the notifications in a user's first digest go, later digests
+	// have the notifications since the last one.
+	NotificationDigestPeriod = 24 * time.Hour
+	// notificationDigestMinInterval is the least time between a user's digests, so each replica running the job
+	// doesn't send one.
+	notificationDigestMinInterval = 12 * time.Hour
+	// notificationDigestTimeout is the max time for one run of the notification digest.
+	notificationDigestTimeout = 30 * time.Minute
 )
 
+// CalendarCacheSyncer keeps users' cached calendar events up to date, eg. an api.Server.
+type CalendarCacheSyncer interface {
+	SyncCalendarCaches(ctx context.Context)
+}
+
+// CalendarSubscriptionRenewer renews the subscriptions to changes to users' calendars, eg. an api.Server.
+type CalendarSubscriptionRenewer interface {
+	RenewCalendarSubscriptions(ctx context.Context)
+}
+
+// PollCloser closes the polls whose deadline has passed, eg. an api.Server.
+type PollCloser interface {
+	ClosePollsPastDeadline(ctx context.Context)
+}
+
+// DigestEmailer emails users a digest of their notifications, eg. a notification.Emailer.
+type DigestEmailer interface {
+	EmailDigest(ctx context.Context, user database.User, notifs []database.Notification) error
+}
+
 // RegisterDBCronJobs registers db functions to run at midnight everyday.
 func RegisterDBCronJobs(db *database.Database, l *logger.Logger) error {
 	// Max time for all cron jobs is 5 hours
@@ -38,11 +86,207 @@ func RegisterDBCronJobs(db *database.Database, l *logger.Logger) error {
 		return fmt.Errorf("failed to register expire invites cron job: %w", err)
 	}
 
+	if _, err = c.AddFunc("@midnight", func() {
+		RemoveDeliveredOutboxNotifications(context.Background(), db, l)
+	}); err != nil {
+		return fmt.Errorf("failed to register remove delivered outbox notifications cron job: %w", err)
+	}
+
+	c.Start()
+
+	return nil
+}
+
+// RegisterCalendarCacheCronJob registers syncing cached calendar events to run every minute,
+// a run is skipped if the last one is still going.
+func RegisterCalendarCacheCronJob(syncer CalendarCacheSyncer, l *logger.Logger) error {
+	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
+	if _, err := c.AddFunc(CalendarCacheSyncSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), calendarCacheSyncTimeout)
+		defer cancel()
+
+		l.Debug("running calendar cache sync cron job")
+		syncer.SyncCalendarCaches(ctx)
+	}); err != nil {
+		return fmt.Errorf("failed to register calendar cache sync cron job: %w", err)
+	}
+
+	c.Start()
+
+	return nil
+}
+
+// RegisterCalendarSubscriptionCronJob registers renewing calendar subscriptions to run every hour,
+// a run is skipped if the last one is still going.
+func RegisterCalendarSubscriptionCronJob(renewer CalendarSubscriptionRenewer, l *logger.Logger) error {
+	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
+	if _, err := c.AddFunc(CalendarSubscriptionRenewSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), calendarSubscriptionRenewTimeout)
+		defer cancel()
+
+		l.Debug("running calendar subscription renewal cron job")
+		renewer.RenewCalendarSubscriptions(ctx)
+	}); err != nil {
+		return fmt.Errorf("failed to register calendar subscription renewal cron job: %w", err)
+	}
+
+	c.Start()
+
+	return nil
+}
+
+// RegisterPollCronJob registers closing polls past their deadline to run every minute,
+// a run is skipped if the last one is still going.
+func RegisterPollCronJob(closer PollCloser, l *logger.Logger) error {
+	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
+	if _, err := c.AddFunc(PollCloseSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), pollCloseTimeout)
+		defer cancel()
+
+		l.Debug("running poll close cron job")
+		closer.ClosePollsPastDeadline(ctx)
+	}); err != nil {
+		return fmt.Errorf("failed to register poll close cron job: %w", err)
+	}
+
 	c.Start()
 
 	return nil
 }
 
+// RegisterNotificationDigestCronJob registers emailing users a digest of their unread notifications to run
+// every day.
+func RegisterNotificationDigestCronJob(db *database.Database, emailer DigestEmailer, l *logger.Logger) error {
+	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
+	if _, err := c.AddFunc(NotificationDigestSpec, func() {
+		SendNotificationDigests(context.Background(), db, emailer, l, time.Now())
+	}); err != nil {
+		return fmt.Errorf("failed to register notification digest cron job: %w", err)
+	}
+
+	c.Start()
+
+	return nil
+}
+
+// SendNotificationDigests emails each user a digest of their unread notifications on the email digest channel,
+// created since their last digest and up to now. Each user's digest is claimed before it is sent, so only one
+// replica sends it. A user whose digest fails doesn't stop the others being sent.
+func SendNotificationDigests(ctx context.Context, db *database.Database, emailer DigestEmailer, l *logger.Logger,
+	now time.Time,
+) {
+	ctx, cancel := context.WithTimeout(ctx, notificationDigestTimeout)
+	defer cancel()
+
+	l.Info("running notification digest cron job")
+
+	// Digests are recorded to the second
+	now = now.UTC().Truncate(time.Second)
+	firstSince := now.Add(-NotificationDigestPeriod)
+
+	var rows []database.ListUnreadDigestNotificationsRow
+	err := retry.Do(func() error {
+		var err error
+		if rows, err = db.ListUnreadDigestNotifications(ctx, database.ListUnreadDigestNotificationsParams{
+			FirstSince: firstSince,
+			Now:        now,
+			DueBefore:  now.Add(-notificationDigestMinInterval),
+		}); err != nil {
+			return fmt.Errorf("failed to list unread digest notifications: %w", err)
+		}
+		return nil
+	}, retry.Attempts(5), retry.Delay(time.Second))
+	if err != nil {
+		l.Error("failed to list unread digest notifications AFTER 5 retries", zap.Error(err))
+		return
+	}
+
+	// Rows are ordered by user
+	sent := 0
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end].UserID == rows[start].UserID {
+			end++
+		}
+
+		ok, digestErr := sendNotificationDigest(ctx, db, emailer, rows[start:end], firstSince, now)
+		if digestErr != nil {
+			l.Error("failed to email notification digest", zap.Uint32("user_id", rows[start].UserID),
+				zap.Error(digestErr))
+		}
+		if ok {
+			sent++
+		}
+
+		start = end
+	}
+
+	l.Infof("emailed %d notification digests", sent)
+}
+
+// sendNotificationDigest claims and emails one user's digest of the notifications in rows, returning whether
+// it was sent. A digest claimed by another replica is skipped, and a digest that fails is given back so the
+// notifications are in the next one.
+func sendNotificationDigest(ctx context.Context, db *database.Database, emailer DigestEmailer,
+	rows []database.ListUnreadDigestNotificationsRow,
+	firstSince time.Time,
+	now time.Time,
+) (bool, error) {
+	userID := rows[0].UserID
+	lastSentUntil := firstSince
+	var claimed int64
+	var err error
+	if rows[0].SentUntil.Valid {
+		lastSentUntil = rows[0].SentUntil.Time
+		claimed, err = db.ClaimNotificationDigest(ctx, database.ClaimNotificationDigestParams{
+			SentUntil:     now,
+			UserID:        userID,
+			LastSentUntil: lastSentUntil,
+		})
+	} else {
+		claimed, err = db.CreateNotificationDigest(ctx, database.CreateNotificationDigestParams{
+			UserID:    userID,
+			SentUntil: now,
+		})
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification digest: %w", err)
+	}
+	if claimed != 1 {
+		return false, nil
+	}
+
+	notifs := make([]database.Notification, 0, len(rows))
+	for _, r := range rows {
+		notifs = append(notifs, database.Notification{
+			ID:      r.ID,
+			Message: r.Message,
+			Created: r.Created,
+			Kind:    r.Kind,
+			Version: r.Version,
+			Payload: r.Payload,
+		})
+	}
+	user := database.User{
+		ID:        userID,
+		Email:     rows[0].Email,
+		FirstName: rows[0].FirstName,
+		LastName:  rows[0].LastName,
+	}
+
+	if err = emailer.EmailDigest(ctx, user, notifs); err != nil {
+		if _, releaseErr := db.ClaimNotificationDigest(ctx, database.ClaimNotificationDigestParams{
+			SentUntil:     lastSentUntil,
+			UserID:        userID,
+			LastSentUntil: now,
+		}); releaseErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release notification digest: %w", releaseErr))
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // RemoveWeekOldInvites will delete invites that are a week old from the db.
 // nolint: dupl// It's ok to duplicate this, it's a batch and not much code
 func RemoveWeekOldInvites(ctx context.Context, db *database.Database, l *logger.Logger) {
@@ -133,6 +377,27 @@ func RemoveWeekOldNotifications(ctx context.Context, db *database.Database, l *l
 	}
 }
 
+// RemoveDeliveredOutboxNotifications will delete notifications from the outbox that were delivered a week ago.
+func RemoveDeliveredOutboxNotifications(ctx context.Context, db *database.Database, l *logger.Logger) {
+	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+
+	l.Info("running remove delivered outbox notifications cron job")
+
+	err := retry.Do(func() error {
+		if _, err := db.DeleteDeliveredNotificationOutbox(ctx, sql.NullTime{
+			Time:  time.Now().AddDate(0, 0, -7),
+			Valid: true,
+		}); err != nil {
+			return fmt.Errorf("failed to delete delivered outbox notifications: %w", err)
+		}
+		return nil
+	}, retry.Attempts(5), retry.Delay(time.Second))
+	if err != nil {
+		l.Error("failed to delete delivered outbox notifications AFTER 5 retries", zap.Error(err))
+	}
+}
+
 // ExpireInvites will expire all invites that have passed their expiry date.
 func ExpireInvites(ctx context.Context, db *database.Database, l *logger.Logger) {
 	ctx, cancel := context.WithTimeout(ctx, time.Hour*2)
@@ -2,11 +2,13 @@ package cron_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/SlotifyApp/slotify-backend/cron"
 	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
 	"github.com/SlotifyApp/slotify-backend/testutil"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/stretchr/testify/require"
@@ -106,6 +108,50 @@ func TestRemoveWeekOldNotifications(t *testing.T) {
 	require.Equal(t, 0, newCount, "all week old notifications were deleted")
 }
 
+func TestRemoveDeliveredOutboxNotifications(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute*5)
+	defer cancel()
+
+	l := testutil.NewLogger(t)
+	db := testutil.NewDB(t, ctx)
+
+	u := testutil.InsertUser(t, db.DB)
+
+	// Setup
+	deliveredAt := map[string]time.Time{
+		"week old": time.Now().AddDate(0, 0, -8),
+		"recent":   time.Now().Add(-time.Hour),
+		"pending":  {},
+	}
+	for name, at := range deliveredAt {
+		notif, err := notification.NewNotificationParams(notification.GroupJoined{GroupName: name}, time.Now())
+		require.NoError(t, err)
+
+		err = notification.Enqueue(ctx, db, "", []uint32{u.Id}, notif)
+		require.NoError(t, err, "enqueue test setup failed")
+
+		if at.IsZero() {
+			continue
+		}
+		outbox := testutil.GetOutboxNotifications(t, ctx, db, u.Id)
+		_, err = db.MarkNotificationOutboxDelivered(ctx, database.MarkNotificationOutboxDeliveredParams{
+			DeliveredAt: sql.NullTime{Time: at, Valid: true},
+			ID:          outbox[len(outbox)-1].ID,
+		})
+		require.NoError(t, err, "mark delivered test setup failed")
+	}
+
+	cron.RemoveDeliveredOutboxNotifications(ctx, db, l)
+
+	outbox := testutil.GetOutboxNotifications(t, ctx, db, u.Id)
+	require.Len(t, outbox, 2, "only notifications delivered a week ago were deleted")
+	for _, msg := range outbox {
+		require.NotContains(t, msg.Message, "week old")
+	}
+}
+
 func TestExpireInvites(t *testing.T) {
 	t.Parallel()
 
@@ -145,3 +191,87 @@ func TestExpireInvites(t *testing.T) {
 
 	require.Equal(t, oldCount+expiredInviteCount, newCount, "all relevant invites were set to expired")
 }
+
+// digestRecorder is a cron.DigestEmailer that keeps the digests it is asked to send.
+type digestRecorder struct {
+	digests map[uint32][]database.Notification
+}
+
+func (d *digestRecorder) EmailDigest(_ context.Context, user database.User, notifs []database.Notification) error {
+	d.digests[user.ID] = notifs
+	return nil
+}
+
+func TestSendNotificationDigests(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute*5)
+	defer cancel()
+
+	l := testutil.NewLogger(t)
+	db := testutil.NewDB(t, ctx)
+
+	digestUser := testutil.InsertUser(t, db.DB)
+	inAppUser := testutil.InsertUser(t, db.DB)
+	lapsedUser := testutil.InsertUser(t, db.DB)
+
+	createNotification := func(userID uint32, created time.Time, channel notification.Channel) uint32 {
+		notifID, err := db.CreateNotification(ctx, database.CreateNotificationParams{
+			Message: gofakeit.MovieName(),
+			Created: created,
+		})
+		require.NoError(t, err, "failed to create notification")
+
+		_, err = db.CreateUserNotification(ctx, database.CreateUserNotificationParams{
+			UserID: userID,
+			//nolint: gosec // id is unsigned 32 bit int
+			NotificationID: uint32(notifID),
+			Channel:        string(channel),
+		})
+		require.NoError(t, err, "failed to link user to notification")
+
+		//nolint: gosec // id is unsigned 32 bit int
+		return uint32(notifID)
+	}
+
+	// Setup
+	now := time.Now()
+	first := createNotification(digestUser.Id, now.Add(-2*time.Hour), notification.ChannelEmailDigest)
+	second := createNotification(digestUser.Id, now.Add(-time.Hour), notification.ChannelEmailDigest)
+	// Too old for the digest
+	createNotification(digestUser.Id, now.AddDate(0, 0, -2), notification.ChannelEmailDigest)
+	// Already read
+	read := createNotification(digestUser.Id, now.Add(-time.Hour), notification.ChannelEmailDigest)
+	_, err := db.MarkNotificationAsRead(ctx, database.MarkNotificationAsReadParams{
+		UserID:         digestUser.Id,
+		NotificationID: read,
+	})
+	require.NoError(t, err, "failed to mark notification as read")
+	// Not on the digest channel
+	createNotification(inAppUser.Id, now.Add(-time.Hour), notification.ChannelInApp)
+	// The last digest was sent three days ago, eg. the job didn't run since
+	_, err = db.CreateNotificationDigest(ctx, database.CreateNotificationDigestParams{
+		UserID:    lapsedUser.Id,
+		SentUntil: now.AddDate(0, 0, -3).UTC().Truncate(time.Second),
+	})
+	require.NoError(t, err, "failed to record last digest")
+	lapsed := createNotification(lapsedUser.Id, now.AddDate(0, 0, -2), notification.ChannelEmailDigest)
+
+	emailer := &digestRecorder{digests: map[uint32][]database.Notification{}}
+	cron.SendNotificationDigests(ctx, db, emailer, l, now)
+
+	// Assert
+	require.Len(t, emailer.digests, 2, "only users with unread digest notifications are emailed")
+	notifs := emailer.digests[digestUser.Id]
+	require.Len(t, notifs, 2, "digest has the user's unread notifications from the period")
+	require.Equal(t, first, notifs[0].ID)
+	require.Equal(t, second, notifs[1].ID)
+	notifs = emailer.digests[lapsedUser.Id]
+	require.Len(t, notifs, 1, "digest has the notifications since the last one")
+	require.Equal(t, lapsed, notifs[0].ID)
+
+	// Another replica runs the job at the same time
+	emailer = &digestRecorder{digests: map[uint32][]database.Notification{}}
+	cron.SendNotificationDigests(ctx, db, emailer, l, now.Add(time.Minute))
+	require.Empty(t, emailer.digests, "each user is sent one digest")
+}
@@ -7,6 +7,7 @@ package database
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -55,6 +56,134 @@ func (ns NullInviteStatus) Value() (driver.Value, error) {
 	return string(ns.InviteStatus), nil
 }
 
+type PollStatus string
+
+const (
+	PollStatusOpen   PollStatus = "open"
+	PollStatusClosed PollStatus = "closed"
+	PollStatusFailed PollStatus = "failed"
+)
+
+func (e *PollStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PollStatus(s)
+	case string:
+		*e = PollStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PollStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPollStatus struct {
+	PollStatus PollStatus `json:"pollStatus"`
+	Valid      bool       `json:"valid"` // Valid is true if PollStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPollStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PollStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PollStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPollStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PollStatus), nil
+}
+
+type PollVoteVote string
+
+const (
+	PollVoteVoteYes   PollVoteVote = "yes"
+	PollVoteVoteMaybe PollVoteVote = "maybe"
+	PollVoteVoteNo    PollVoteVote = "no"
+)
+
+func (e *PollVoteVote) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PollVoteVote(s)
+	case string:
+		*e = PollVoteVote(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PollVoteVote: %T", src)
+	}
+	return nil
+}
+
+type NullPollVoteVote struct {
+	PollVoteVote PollVoteVote `json:"pollVoteVote"`
+	Valid        bool         `json:"valid"` // Valid is true if PollVoteVote is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPollVoteVote) Scan(value interface{}) error {
+	if value == nil {
+		ns.PollVoteVote, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PollVoteVote.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPollVoteVote) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PollVoteVote), nil
+}
+
+type ReschedulingrequestScope string
+
+const (
+	ReschedulingrequestScopeOccurrence ReschedulingrequestScope = "occurrence"
+	ReschedulingrequestScopeSeries     ReschedulingrequestScope = "series"
+)
+
+func (e *ReschedulingrequestScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReschedulingrequestScope(s)
+	case string:
+		*e = ReschedulingrequestScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReschedulingrequestScope: %T", src)
+	}
+	return nil
+}
+
+type NullReschedulingrequestScope struct {
+	ReschedulingrequestScope ReschedulingrequestScope `json:"reschedulingrequestScope"`
+	Valid                    bool                     `json:"valid"` // Valid is true if ReschedulingrequestScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReschedulingrequestScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReschedulingrequestScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReschedulingrequestScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReschedulingrequestScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReschedulingrequestScope), nil
+}
+
 type ReschedulingrequestStatus string
 
 const (
@@ -98,6 +227,200 @@ func (ns NullReschedulingrequestStatus) Value() (driver.Value, error) {
 	return string(ns.ReschedulingrequestStatus), nil
 }
 
+type SlotifygroupVisibility string
+
+const (
+	SlotifygroupVisibilityPrivate      SlotifygroupVisibility = "private"
+	SlotifygroupVisibilityDiscoverable SlotifygroupVisibility = "discoverable"
+)
+
+func (e *SlotifygroupVisibility) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SlotifygroupVisibility(s)
+	case string:
+		*e = SlotifygroupVisibility(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SlotifygroupVisibility: %T", src)
+	}
+	return nil
+}
+
+type NullSlotifygroupVisibility struct {
+	SlotifygroupVisibility SlotifygroupVisibility `json:"slotifygroupVisibility"`
+	Valid                  bool                   `json:"valid"` // Valid is true if SlotifygroupVisibility is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSlotifygroupVisibility) Scan(value interface{}) error {
+	if value == nil {
+		ns.SlotifygroupVisibility, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SlotifygroupVisibility.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSlotifygroupVisibility) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SlotifygroupVisibility), nil
+}
+
+type UserIdentityProvider string
+
+const (
+	UserIdentityProviderMicrosoft UserIdentityProvider = "microsoft"
+	UserIdentityProviderGoogle    UserIdentityProvider = "google"
+)
+
+func (e *UserIdentityProvider) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserIdentityProvider(s)
+	case string:
+		*e = UserIdentityProvider(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserIdentityProvider: %T", src)
+	}
+	return nil
+}
+
+type NullUserIdentityProvider struct {
+	UserIdentityProvider UserIdentityProvider `json:"userIdentityProvider"`
+	Valid                bool                 `json:"valid"` // Valid is true if UserIdentityProvider is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserIdentityProvider) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserIdentityProvider, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserIdentityProvider.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserIdentityProvider) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserIdentityProvider), nil
+}
+
+type UsertoslotifygroupAttendeeType string
+
+const (
+	UsertoslotifygroupAttendeeTypeRequired UsertoslotifygroupAttendeeType = "required"
+	UsertoslotifygroupAttendeeTypeOptional UsertoslotifygroupAttendeeType = "optional"
+)
+
+func (e *UsertoslotifygroupAttendeeType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UsertoslotifygroupAttendeeType(s)
+	case string:
+		*e = UsertoslotifygroupAttendeeType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UsertoslotifygroupAttendeeType: %T", src)
+	}
+	return nil
+}
+
+type NullUsertoslotifygroupAttendeeType struct {
+	UsertoslotifygroupAttendeeType UsertoslotifygroupAttendeeType `json:"usertoslotifygroupAttendeeType"`
+	Valid                          bool                           `json:"valid"` // Valid is true if UsertoslotifygroupAttendeeType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUsertoslotifygroupAttendeeType) Scan(value interface{}) error {
+	if value == nil {
+		ns.UsertoslotifygroupAttendeeType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UsertoslotifygroupAttendeeType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUsertoslotifygroupAttendeeType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UsertoslotifygroupAttendeeType), nil
+}
+
+type UsertoslotifygroupRole string
+
+const (
+	UsertoslotifygroupRoleOwner  UsertoslotifygroupRole = "owner"
+	UsertoslotifygroupRoleAdmin  UsertoslotifygroupRole = "admin"
+	UsertoslotifygroupRoleMember UsertoslotifygroupRole = "member"
+)
+
+func (e *UsertoslotifygroupRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UsertoslotifygroupRole(s)
+	case string:
+		*e = UsertoslotifygroupRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UsertoslotifygroupRole: %T", src)
+	}
+	return nil
+}
+
+type NullUsertoslotifygroupRole struct {
+	UsertoslotifygroupRole UsertoslotifygroupRole `json:"usertoslotifygroupRole"`
+	Valid                  bool                   `json:"valid"` // Valid is true if UsertoslotifygroupRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUsertoslotifygroupRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.UsertoslotifygroupRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UsertoslotifygroupRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUsertoslotifygroupRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UsertoslotifygroupRole), nil
+}
+
+type CalendarEventCache struct {
+	UserID    uint32          `json:"userID"`
+	EventID   string          `json:"eventID"`
+	StartTime time.Time       `json:"startTime"`
+	EndTime   time.Time       `json:"endTime"`
+	Busy      bool            `json:"busy"`
+	Event     json.RawMessage `json:"event"`
+}
+
+type CalendarSubscription struct {
+	ID          string    `json:"id"`
+	UserID      uint32    `json:"userID"`
+	ClientState string    `json:"clientState"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+type CalendarSync struct {
+	UserID       uint32    `json:"userID"`
+	DeltaLink    string    `json:"deltaLink"`
+	WindowStart  time.Time `json:"windowStart"`
+	WindowEnd    time.Time `json:"windowEnd"`
+	LastSyncedAt time.Time `json:"lastSyncedAt"`
+	LastReadAt   time.Time `json:"lastReadAt"`
+}
+
 type Invite struct {
 	ID             uint32       `json:"id"`
 	SlotifyGroupID uint32       `json:"slotifyGroupID"`
@@ -123,10 +446,52 @@ type Meetingpreferences struct {
 	EndDateRange     time.Time `json:"endDateRange"`
 }
 
+type MSALTokenCache struct {
+	PartitionKey string    `json:"partitionKey"`
+	CacheData    []byte    `json:"cacheData"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
 type Notification struct {
-	ID      uint32    `json:"id"`
-	Message string    `json:"message"`
-	Created time.Time `json:"created"`
+	ID      uint32          `json:"id"`
+	Message string          `json:"message"`
+	Created time.Time       `json:"created"`
+	Kind    string          `json:"kind"`
+	Version uint32          `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type NotificationDigest struct {
+	UserID    uint32    `json:"userID"`
+	SentUntil time.Time `json:"sentUntil"`
+}
+
+type NotificationOutbox struct {
+	ID             uint32          `json:"id"`
+	IdempotencyKey string          `json:"idempotencyKey"`
+	UserIds        json.RawMessage `json:"userIds"`
+	Message        string          `json:"message"`
+	Created        time.Time       `json:"created"`
+	Kind           string          `json:"kind"`
+	Version        uint32          `json:"version"`
+	Payload        json.RawMessage `json:"payload"`
+	NotificationID sql.NullInt32   `json:"notificationID"`
+	Attempts       uint32          `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	LastError      sql.NullString  `json:"lastError"`
+	DeliveredAt    sql.NullTime    `json:"deliveredAt"`
+}
+
+type NotificationPreferences struct {
+	UserID  uint32 `json:"userID"`
+	Kind    string `json:"kind"`
+	Channel string `json:"channel"`
+}
+
+type NotificationQuietHours struct {
+	UserID    uint32 `json:"userID"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
 }
 
 type Placeholdermeeting struct {
@@ -144,6 +509,41 @@ type Placeholdermeetingattendee struct {
 	UserID    uint32 `json:"userID"`
 }
 
+type Poll struct {
+	ID                 uint32          `json:"id"`
+	OrganiserID        uint32          `json:"organiserID"`
+	SlotifyGroupID     sql.NullInt32   `json:"slotifyGroupID"`
+	Event              json.RawMessage `json:"event"`
+	Deadline           time.Time       `json:"deadline"`
+	Status             PollStatus      `json:"status"`
+	ClosingUntil       sql.NullTime    `json:"closingUntil"`
+	ChosenOptionID     sql.NullInt32   `json:"chosenOptionID"`
+	EventID            sql.NullString  `json:"eventID"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	ClosedAt           sql.NullTime    `json:"closedAt"`
+	CloseAttempts      uint32          `json:"closeAttempts"`
+	NextCloseAttemptAt sql.NullTime    `json:"nextCloseAttemptAt"`
+}
+
+type PollOption struct {
+	ID        uint32    `json:"id"`
+	PollID    uint32    `json:"pollID"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+type PollVote struct {
+	OptionID  uint32       `json:"optionID"`
+	UserID    uint32       `json:"userID"`
+	Vote      PollVoteVote `json:"vote"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+type PollVoter struct {
+	PollID uint32 `json:"pollID"`
+	UserID uint32 `json:"userID"`
+}
+
 type RefreshToken struct {
 	ID      uint32 `json:"id"`
 	UserID  uint32 `json:"userID"`
@@ -157,15 +557,40 @@ type Requesttomeeting struct {
 }
 
 type Reschedulingrequest struct {
-	RequestID   uint32                    `json:"requestID"`
-	RequestedBy uint32                    `json:"requestedBy"`
-	Status      ReschedulingrequestStatus `json:"status"`
-	CreatedAt   time.Time                 `json:"createdAt"`
+	RequestID       uint32                    `json:"requestID"`
+	RequestedBy     uint32                    `json:"requestedBy"`
+	Status          ReschedulingrequestStatus `json:"status"`
+	CreatedAt       time.Time                 `json:"createdAt"`
+	Scope           ReschedulingrequestScope  `json:"scope"`
+	OccurrenceStart sql.NullTime              `json:"occurrenceStart"`
 }
 
 type SlotifyGroup struct {
-	ID   uint32 `json:"id"`
-	Name string `json:"name"`
+	ID                     uint32                 `json:"id"`
+	Name                   string                 `json:"name"`
+	Description            string                 `json:"description"`
+	AvatarColour           sql.NullString         `json:"avatarColour"`
+	Visibility             SlotifygroupVisibility `json:"visibility"`
+	DefaultMeetingDuration sql.NullString         `json:"defaultMeetingDuration"`
+	DefaultLocationName    sql.NullString         `json:"defaultLocationName"`
+	DefaultLocationEmail   sql.NullString         `json:"defaultLocationEmail"`
+	DefaultIsOnlineMeeting bool                   `json:"defaultIsOnlineMeeting"`
+}
+
+type SlotifyGroupNotificationPreferences struct {
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	Kind           string `json:"kind"`
+	Channel        string `json:"channel"`
+}
+
+type SlotifyGroupScoringWeights struct {
+	SlotifyGroupID      uint32  `json:"slotifyGroupID"`
+	MeetingLoad         float64 `json:"meetingLoad"`
+	BackToBack          float64 `json:"backToBack"`
+	TimeOfDay           float64 `json:"timeOfDay"`
+	PreferredRooms      float64 `json:"preferredRooms"`
+	TimeZoneFairness    float64 `json:"timeZoneFairness"`
+	PreferredRoomEmails string  `json:"preferredRoomEmails"`
 }
 
 type User struct {
@@ -174,21 +599,37 @@ type User struct {
 	FirstName         string         `json:"firstName"`
 	LastName          string         `json:"lastName"`
 	MsftHomeAccountID sql.NullString `json:"msftHomeAccountID"`
+	TimeZone          string         `json:"timeZone"`
+}
+
+type UserIdentity struct {
+	ID        uint32               `json:"id"`
+	UserID    uint32               `json:"userID"`
+	Provider  UserIdentityProvider `json:"provider"`
+	Subject   string               `json:"subject"`
+	TokenData []byte               `json:"tokenData"`
 }
 
 type Userpreferences struct {
-	UserID         uint32    `json:"userID"`
-	LunchStartTime time.Time `json:"lunchStartTime"`
-	LunchEndTime   time.Time `json:"lunchEndTime"`
+	UserID            uint32        `json:"userID"`
+	LunchStartTime    string        `json:"lunchStartTime"`
+	LunchEndTime      string        `json:"lunchEndTime"`
+	WorkingHoursStart string        `json:"workingHoursStart"`
+	WorkingHoursEnd   string        `json:"workingHoursEnd"`
+	WorkingDays       string        `json:"workingDays"`
+	MaxMeetingsPerDay sql.NullInt32 `json:"maxMeetingsPerDay"`
 }
 
 type Usertonotification struct {
 	UserID         uint32 `json:"userID"`
 	NotificationID uint32 `json:"notificationID"`
 	IsRead         bool   `json:"isRead"`
+	Channel        string `json:"channel"`
 }
 
 type Usertoslotifygroup struct {
-	UserID         uint32 `json:"userID"`
-	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	UserID         uint32                         `json:"userID"`
+	SlotifyGroupID uint32                         `json:"slotifyGroupID"`
+	AttendeeType   UsertoslotifygroupAttendeeType `json:"attendeeType"`
+	Role           UsertoslotifygroupRole         `json:"role"`
 }
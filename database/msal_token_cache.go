@@ -0,0 +1,11 @@
+package database
+
+import "context"
+
+// MSALTokenCacheDatabase is an interface describing behaviour needed to persist a MSAL token cache.
+type MSALTokenCacheDatabase interface {
+	// GetMSALTokenCache returns the stored (encrypted) cache for a partition key.
+	GetMSALTokenCache(ctx context.Context, partitionKey string) ([]byte, error)
+	// UpsertMSALTokenCache returns the number of affected rows and an error.
+	UpsertMSALTokenCache(ctx context.Context, arg UpsertMSALTokenCacheParams) (int64, error)
+}
@@ -12,12 +12,31 @@ type NotificationDatabase interface {
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (int64, error)
 	// CreateUserNotification returns the number of affected rows and an error.
 	CreateUserNotification(ctx context.Context, arg CreateUserNotificationParams) (int64, error)
+	// ListUserNotificationsAfterID returns a user's notifications created after the notification ID, oldest first.
+	ListUserNotificationsAfterID(ctx context.Context, arg ListUserNotificationsAfterIDParams) ([]Notification, error)
+	// GetNotificationPreferenceChannel returns the channel a user chose for a kind of notification,
+	// sql.ErrNoRows is returned if they haven't chosen one.
+	GetNotificationPreferenceChannel(ctx context.Context, arg GetNotificationPreferenceChannelParams) (string, error)
+	// ListMemberSlotifyGroupNotificationChannels returns the default channels set for a kind of notification by
+	// the SlotifyGroups a user is a member of.
+	ListMemberSlotifyGroupNotificationChannels(ctx context.Context,
+		arg ListMemberSlotifyGroupNotificationChannelsParams) ([]string, error)
+	// GetNotificationQuietHours returns a user's quiet hours and time zone, sql.ErrNoRows is returned if they have none.
+	GetNotificationQuietHours(ctx context.Context, userID uint32) (GetNotificationQuietHoursRow, error)
+	// GetUserByID returns a user, eg. to email them a notification.
+	GetUserByID(ctx context.Context, id uint32) (User, error)
+}
+
+// NotificationRecipient is a user a notification is stored for, and the channel it is delivered on.
+type NotificationRecipient struct {
+	UserID  uint32
+	Channel string
 }
 
 // StoreNotification creates a notification in the 'Notification' table, and
-// links it to a user via the 'UserToNotification' table.
+// links it to each recipient via the 'UserToNotification' table.
 func StoreNotification(ctx context.Context, db NotificationDatabase,
-	userIDs []uint32, notif CreateNotificationParams,
+	recipients []NotificationRecipient, notif CreateNotificationParams,
 ) (*Notification, error) {
 	notifID, err := db.CreateNotification(ctx, notif)
 	if err != nil {
@@ -31,12 +50,13 @@ func StoreNotification(ctx context.Context, db NotificationDatabase,
 		}
 	}
 
-	for _, userID := range userIDs {
+	for _, recipient := range recipients {
 		// Add to user notification table
 		dbParams := CreateUserNotificationParams{
-			UserID: userID,
+			UserID: recipient.UserID,
 			//nolint: gosec // id is unsigned 32 bit int
 			NotificationID: uint32(notifID),
+			Channel:        recipient.Channel,
 		}
 
 		var rows int64
@@ -63,5 +83,8 @@ func StoreNotification(ctx context.Context, db NotificationDatabase,
 		ID:      uint32(notifID),
 		Message: notif.Message,
 		Created: notif.Created,
+		Kind:    notif.Kind,
+		Version: notif.Version,
+		Payload: notif.Payload,
 	}, nil
 }
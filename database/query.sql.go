@@ -8,6 +8,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
@@ -24,16 +25,36 @@ func (q *Queries) AddSlotifyGroup(ctx context.Context, name string) (int64, erro
 }
 
 const addUserToSlotifyGroup = `-- name: AddUserToSlotifyGroup :execrows
-INSERT INTO UserToSlotifyGroup (user_id, slotify_group_id) VALUES (?, ?)
+INSERT INTO UserToSlotifyGroup (user_id, slotify_group_id, role) VALUES (?, ?, ?)
 `
 
 type AddUserToSlotifyGroupParams struct {
-	UserID         uint32 `json:"userID"`
-	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	UserID         uint32                 `json:"userID"`
+	SlotifyGroupID uint32                 `json:"slotifyGroupID"`
+	Role           UsertoslotifygroupRole `json:"role"`
 }
 
 func (q *Queries) AddUserToSlotifyGroup(ctx context.Context, arg AddUserToSlotifyGroupParams) (int64, error) {
-	result, err := q.exec(ctx, q.addUserToSlotifyGroupStmt, addUserToSlotifyGroup, arg.UserID, arg.SlotifyGroupID)
+	result, err := q.exec(ctx, q.addUserToSlotifyGroupStmt, addUserToSlotifyGroup, arg.UserID, arg.SlotifyGroupID, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const batchDeleteIdleCalendarSyncs = `-- name: BatchDeleteIdleCalendarSyncs :execrows
+DELETE FROM CalendarSync
+WHERE last_read_at < ?
+LIMIT ?
+`
+
+type BatchDeleteIdleCalendarSyncsParams struct {
+	LastReadAt time.Time `json:"lastReadAt"`
+	Limit      int32     `json:"limit"`
+}
+
+func (q *Queries) BatchDeleteIdleCalendarSyncs(ctx context.Context, arg BatchDeleteIdleCalendarSyncsParams) (int64, error) {
+	result, err := q.exec(ctx, q.batchDeleteIdleCalendarSyncsStmt, batchDeleteIdleCalendarSyncs, arg.LastReadAt, arg.Limit)
 	if err != nil {
 		return 0, err
 	}
@@ -106,6 +127,105 @@ func (q *Queries) CheckMemberInSlotifyGroup(ctx context.Context, arg CheckMember
 	return count, err
 }
 
+const checkPollVoter = `-- name: CheckPollVoter :one
+SELECT COUNT(*) FROM PollVoter
+WHERE poll_id=? AND user_id=?
+`
+
+type CheckPollVoterParams struct {
+	PollID uint32 `json:"pollID"`
+	UserID uint32 `json:"userID"`
+}
+
+func (q *Queries) CheckPollVoter(ctx context.Context, arg CheckPollVoterParams) (int64, error) {
+	row := q.queryRow(ctx, q.checkPollVoterStmt, checkPollVoter, arg.PollID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const claimNotificationDigest = `-- name: ClaimNotificationDigest :execrows
+UPDATE NotificationDigest SET sent_until=?
+WHERE user_id=? AND sent_until=?
+`
+
+type ClaimNotificationDigestParams struct {
+	SentUntil     time.Time `json:"sentUntil"`
+	UserID        uint32    `json:"userID"`
+	LastSentUntil time.Time `json:"lastSentUntil"`
+}
+
+func (q *Queries) ClaimNotificationDigest(ctx context.Context, arg ClaimNotificationDigestParams) (int64, error) {
+	result, err := q.exec(ctx, q.claimNotificationDigestStmt, claimNotificationDigest, arg.SentUntil, arg.UserID, arg.LastSentUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimNotificationOutbox = `-- name: ClaimNotificationOutbox :execrows
+UPDATE NotificationOutbox SET attempts=attempts+1, next_attempt_at=?
+WHERE id=? AND attempts=? AND delivered_at IS NULL
+`
+
+type ClaimNotificationOutboxParams struct {
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	ID            uint32    `json:"id"`
+	Attempts      uint32    `json:"attempts"`
+}
+
+func (q *Queries) ClaimNotificationOutbox(ctx context.Context, arg ClaimNotificationOutboxParams) (int64, error) {
+	result, err := q.exec(ctx, q.claimNotificationOutboxStmt, claimNotificationOutbox, arg.NextAttemptAt, arg.ID, arg.Attempts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimPollClose = `-- name: ClaimPollClose :execrows
+UPDATE Poll SET closing_until=?
+WHERE id=? AND status IN ('open', 'failed') AND (closing_until IS NULL OR closing_until < ?)
+`
+
+type ClaimPollCloseParams struct {
+	ClosingUntil sql.NullTime `json:"closingUntil"`
+	ID           uint32       `json:"id"`
+	Now          sql.NullTime `json:"now"`
+}
+
+func (q *Queries) ClaimPollClose(ctx context.Context, arg ClaimPollCloseParams) (int64, error) {
+	result, err := q.exec(ctx, q.claimPollCloseStmt, claimPollClose, arg.ClosingUntil, arg.ID, arg.Now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const closePoll = `-- name: ClosePoll :execrows
+UPDATE Poll SET status='closed', chosen_option_id=?, event_id=?, closed_at=?, closing_until=NULL
+WHERE id=? AND status IN ('open', 'failed')
+`
+
+type ClosePollParams struct {
+	ChosenOptionID sql.NullInt32  `json:"chosenOptionID"`
+	EventID        sql.NullString `json:"eventID"`
+	ClosedAt       sql.NullTime   `json:"closedAt"`
+	ID             uint32         `json:"id"`
+}
+
+func (q *Queries) ClosePoll(ctx context.Context, arg ClosePollParams) (int64, error) {
+	result, err := q.exec(ctx, q.closePollStmt, closePoll,
+		arg.ChosenOptionID,
+		arg.EventID,
+		arg.ClosedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const countExpiredInvites = `-- name: CountExpiredInvites :one
 SELECT COUNT(*) FROM Invite
 WHERE status='expired'
@@ -143,6 +263,18 @@ func (q *Queries) CountSlotifyGroupMembers(ctx context.Context, id uint32) (int6
 	return count, err
 }
 
+const countUnreadUserNotifications = `-- name: CountUnreadUserNotifications :one
+SELECT COUNT(*) FROM UserToNotification
+WHERE user_id=? AND is_read=FALSE
+`
+
+func (q *Queries) CountUnreadUserNotifications(ctx context.Context, userID uint32) (int64, error) {
+	row := q.queryRow(ctx, q.countUnreadUserNotificationsStmt, countUnreadUserNotifications, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserByEmail = `-- name: CountUserByEmail :one
 SELECT COUNT(*) FROM User WHERE email=?
 `
@@ -257,22 +389,100 @@ func (q *Queries) CreateMeetingPreferences(ctx context.Context, arg CreateMeetin
 }
 
 const createNotification = `-- name: CreateNotification :execlastid
-INSERT INTO Notification (message, created) VALUES(?, ?)
+INSERT INTO Notification (message, created, kind, version, payload) VALUES(?, ?, ?, ?, ?)
 `
 
 type CreateNotificationParams struct {
-	Message string    `json:"message"`
-	Created time.Time `json:"created"`
+	Message string          `json:"message"`
+	Created time.Time       `json:"created"`
+	Kind    string          `json:"kind"`
+	Version uint32          `json:"version"`
+	Payload json.RawMessage `json:"payload"`
 }
 
 func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (int64, error) {
-	result, err := q.exec(ctx, q.createNotificationStmt, createNotification, arg.Message, arg.Created)
+	result, err := q.exec(ctx, q.createNotificationStmt, createNotification,
+		arg.Message,
+		arg.Created,
+		arg.Kind,
+		arg.Version,
+		arg.Payload,
+	)
 	if err != nil {
 		return 0, err
 	}
 	return result.LastInsertId()
 }
 
+const createNotificationDigest = `-- name: CreateNotificationDigest :execrows
+INSERT IGNORE INTO NotificationDigest (user_id, sent_until) VALUES (?, ?)
+`
+
+type CreateNotificationDigestParams struct {
+	UserID    uint32    `json:"userID"`
+	SentUntil time.Time `json:"sentUntil"`
+}
+
+func (q *Queries) CreateNotificationDigest(ctx context.Context, arg CreateNotificationDigestParams) (int64, error) {
+	result, err := q.exec(ctx, q.createNotificationDigestStmt, createNotificationDigest, arg.UserID, arg.SentUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createNotificationOutbox = `-- name: CreateNotificationOutbox :execrows
+INSERT INTO NotificationOutbox (idempotency_key, user_ids, message, created, kind, version, payload, next_attempt_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE id=id
+`
+
+type CreateNotificationOutboxParams struct {
+	IdempotencyKey string          `json:"idempotencyKey"`
+	UserIds        json.RawMessage `json:"userIds"`
+	Message        string          `json:"message"`
+	Created        time.Time       `json:"created"`
+	Kind           string          `json:"kind"`
+	Version        uint32          `json:"version"`
+	Payload        json.RawMessage `json:"payload"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+}
+
+func (q *Queries) CreateNotificationOutbox(ctx context.Context, arg CreateNotificationOutboxParams) (int64, error) {
+	result, err := q.exec(ctx, q.createNotificationOutboxStmt, createNotificationOutbox,
+		arg.IdempotencyKey,
+		arg.UserIds,
+		arg.Message,
+		arg.Created,
+		arg.Kind,
+		arg.Version,
+		arg.Payload,
+		arg.NextAttemptAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createNotificationPreference = `-- name: CreateNotificationPreference :execrows
+INSERT INTO NotificationPreferences (user_id, kind, channel) VALUES (?, ?, ?)
+`
+
+type CreateNotificationPreferenceParams struct {
+	UserID  uint32 `json:"userID"`
+	Kind    string `json:"kind"`
+	Channel string `json:"channel"`
+}
+
+func (q *Queries) CreateNotificationPreference(ctx context.Context, arg CreateNotificationPreferenceParams) (int64, error) {
+	result, err := q.exec(ctx, q.createNotificationPreferenceStmt, createNotificationPreference, arg.UserID, arg.Kind, arg.Channel)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createPlaceholderMeeting = `-- name: CreatePlaceholderMeeting :execlastid
 INSERT INTO PlaceholderMeeting (request_id, title, location, duration, start_date_range, end_date_range) VALUES (?,?,?,?,?,?)
 `
@@ -318,6 +528,67 @@ func (q *Queries) CreatePlaceholderMeetingAttendee(ctx context.Context, arg Crea
 	return result.LastInsertId()
 }
 
+const createPoll = `-- name: CreatePoll :execlastid
+INSERT INTO Poll (organiser_id, slotify_group_id, event, deadline, created_at) VALUES (?, ?, ?, ?, ?)
+`
+
+type CreatePollParams struct {
+	OrganiserID    uint32          `json:"organiserID"`
+	SlotifyGroupID sql.NullInt32   `json:"slotifyGroupID"`
+	Event          json.RawMessage `json:"event"`
+	Deadline       time.Time       `json:"deadline"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+func (q *Queries) CreatePoll(ctx context.Context, arg CreatePollParams) (int64, error) {
+	result, err := q.exec(ctx, q.createPollStmt, createPoll,
+		arg.OrganiserID,
+		arg.SlotifyGroupID,
+		arg.Event,
+		arg.Deadline,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createPollOption = `-- name: CreatePollOption :execlastid
+INSERT INTO PollOption (poll_id, start_time, end_time) VALUES (?, ?, ?)
+`
+
+type CreatePollOptionParams struct {
+	PollID    uint32    `json:"pollID"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+func (q *Queries) CreatePollOption(ctx context.Context, arg CreatePollOptionParams) (int64, error) {
+	result, err := q.exec(ctx, q.createPollOptionStmt, createPollOption, arg.PollID, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createPollVoter = `-- name: CreatePollVoter :execrows
+INSERT IGNORE INTO PollVoter (poll_id, user_id) VALUES (?, ?)
+`
+
+type CreatePollVoterParams struct {
+	PollID uint32 `json:"pollID"`
+	UserID uint32 `json:"userID"`
+}
+
+func (q *Queries) CreatePollVoter(ctx context.Context, arg CreatePollVoterParams) (int64, error) {
+	result, err := q.exec(ctx, q.createPollVoterStmt, createPollVoter, arg.PollID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createRefreshToken = `-- name: CreateRefreshToken :execrows
 REPLACE INTO RefreshToken (user_id, token) VALUES (?, ?)
 `
@@ -353,22 +624,47 @@ func (q *Queries) CreateRequestToMeeting(ctx context.Context, arg CreateRequestT
 }
 
 const createReschedulingRequest = `-- name: CreateReschedulingRequest :execlastid
-INSERT INTO ReschedulingRequest (requested_by, created_at) VALUES (?, ?)
+INSERT INTO ReschedulingRequest (requested_by, created_at, scope, occurrence_start) VALUES (?, ?, ?, ?)
 `
 
 type CreateReschedulingRequestParams struct {
-	RequestedBy uint32    `json:"requestedBy"`
-	CreatedAt   time.Time `json:"createdAt"`
+	RequestedBy     uint32                   `json:"requestedBy"`
+	CreatedAt       time.Time                `json:"createdAt"`
+	Scope           ReschedulingrequestScope `json:"scope"`
+	OccurrenceStart sql.NullTime             `json:"occurrenceStart"`
 }
 
 func (q *Queries) CreateReschedulingRequest(ctx context.Context, arg CreateReschedulingRequestParams) (int64, error) {
-	result, err := q.exec(ctx, q.createReschedulingRequestStmt, createReschedulingRequest, arg.RequestedBy, arg.CreatedAt)
+	result, err := q.exec(ctx, q.createReschedulingRequestStmt, createReschedulingRequest,
+		arg.RequestedBy,
+		arg.CreatedAt,
+		arg.Scope,
+		arg.OccurrenceStart,
+	)
 	if err != nil {
 		return 0, err
 	}
 	return result.LastInsertId()
 }
 
+const createSlotifyGroupNotificationPreference = `-- name: CreateSlotifyGroupNotificationPreference :execrows
+INSERT INTO SlotifyGroupNotificationPreferences (slotify_group_id, kind, channel) VALUES (?, ?, ?)
+`
+
+type CreateSlotifyGroupNotificationPreferenceParams struct {
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	Kind           string `json:"kind"`
+	Channel        string `json:"channel"`
+}
+
+func (q *Queries) CreateSlotifyGroupNotificationPreference(ctx context.Context, arg CreateSlotifyGroupNotificationPreferenceParams) (int64, error) {
+	result, err := q.exec(ctx, q.createSlotifyGroupNotificationPreferenceStmt, createSlotifyGroupNotificationPreference, arg.SlotifyGroupID, arg.Kind, arg.Channel)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createUser = `-- name: CreateUser :execlastid
 INSERT INTO User (email, first_name, last_name) VALUES (?, ?, ?)
 `
@@ -388,16 +684,73 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (int64,
 }
 
 const createUserNotification = `-- name: CreateUserNotification :execrows
-INSERT INTO UserToNotification (user_id, notification_id, is_read) VALUES(?, ?, FALSE)
+INSERT INTO UserToNotification (user_id, notification_id, is_read, channel) VALUES(?, ?, FALSE, ?)
 `
 
 type CreateUserNotificationParams struct {
 	UserID         uint32 `json:"userID"`
 	NotificationID uint32 `json:"notificationID"`
+	Channel        string `json:"channel"`
 }
 
 func (q *Queries) CreateUserNotification(ctx context.Context, arg CreateUserNotificationParams) (int64, error) {
-	result, err := q.exec(ctx, q.createUserNotificationStmt, createUserNotification, arg.UserID, arg.NotificationID)
+	result, err := q.exec(ctx, q.createUserNotificationStmt, createUserNotification, arg.UserID, arg.NotificationID, arg.Channel)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCalendarEventCache = `-- name: DeleteCalendarEventCache :execrows
+DELETE FROM CalendarEventCache
+WHERE user_id=?
+`
+
+func (q *Queries) DeleteCalendarEventCache(ctx context.Context, userID uint32) (int64, error) {
+	result, err := q.exec(ctx, q.deleteCalendarEventCacheStmt, deleteCalendarEventCache, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCalendarEventCacheEvent = `-- name: DeleteCalendarEventCacheEvent :execrows
+DELETE FROM CalendarEventCache
+WHERE user_id=? AND event_id=?
+`
+
+type DeleteCalendarEventCacheEventParams struct {
+	UserID  uint32 `json:"userID"`
+	EventID string `json:"eventID"`
+}
+
+func (q *Queries) DeleteCalendarEventCacheEvent(ctx context.Context, arg DeleteCalendarEventCacheEventParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteCalendarEventCacheEventStmt, deleteCalendarEventCacheEvent, arg.UserID, arg.EventID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCalendarSubscription = `-- name: DeleteCalendarSubscription :execrows
+DELETE FROM CalendarSubscription WHERE id=?
+`
+
+func (q *Queries) DeleteCalendarSubscription(ctx context.Context, id string) (int64, error) {
+	result, err := q.exec(ctx, q.deleteCalendarSubscriptionStmt, deleteCalendarSubscription, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteDeliveredNotificationOutbox = `-- name: DeleteDeliveredNotificationOutbox :execrows
+DELETE FROM NotificationOutbox
+WHERE delivered_at <= ?
+`
+
+func (q *Queries) DeleteDeliveredNotificationOutbox(ctx context.Context, deliveredAt sql.NullTime) (int64, error) {
+	result, err := q.exec(ctx, q.deleteDeliveredNotificationOutboxStmt, deleteDeliveredNotificationOutbox, deliveredAt)
 	if err != nil {
 		return 0, err
 	}
@@ -416,6 +769,30 @@ func (q *Queries) DeleteInviteByID(ctx context.Context, id uint32) (int64, error
 	return result.RowsAffected()
 }
 
+const deleteNotificationPreferences = `-- name: DeleteNotificationPreferences :execrows
+DELETE FROM NotificationPreferences WHERE user_id=?
+`
+
+func (q *Queries) DeleteNotificationPreferences(ctx context.Context, userID uint32) (int64, error) {
+	result, err := q.exec(ctx, q.deleteNotificationPreferencesStmt, deleteNotificationPreferences, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteNotificationQuietHours = `-- name: DeleteNotificationQuietHours :execrows
+DELETE FROM NotificationQuietHours WHERE user_id=?
+`
+
+func (q *Queries) DeleteNotificationQuietHours(ctx context.Context, userID uint32) (int64, error) {
+	result, err := q.exec(ctx, q.deleteNotificationQuietHoursStmt, deleteNotificationQuietHours, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteRefreshTokenByUserID = `-- name: DeleteRefreshTokenByUserID :execrows
 DELETE FROM RefreshToken WHERE user_id=?
 `
@@ -450,6 +827,18 @@ func (q *Queries) DeleteSlotifyGroupByID(ctx context.Context, id uint32) (int64,
 	return result.RowsAffected()
 }
 
+const deleteSlotifyGroupNotificationPreferences = `-- name: DeleteSlotifyGroupNotificationPreferences :execrows
+DELETE FROM SlotifyGroupNotificationPreferences WHERE slotify_group_id=?
+`
+
+func (q *Queries) DeleteSlotifyGroupNotificationPreferences(ctx context.Context, slotifyGroupID uint32) (int64, error) {
+	result, err := q.exec(ctx, q.deleteSlotifyGroupNotificationPreferencesStmt, deleteSlotifyGroupNotificationPreferences, slotifyGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteUserByID = `-- name: DeleteUserByID :execrows
 DELETE FROM User WHERE id=?
 `
@@ -462,8 +851,51 @@ func (q *Queries) DeleteUserByID(ctx context.Context, id uint32) (int64, error)
 	return result.RowsAffected()
 }
 
+const deleteUserNotification = `-- name: DeleteUserNotification :execrows
+DELETE FROM UserToNotification
+WHERE user_id=? AND notification_id=?
+`
+
+type DeleteUserNotificationParams struct {
+	UserID         uint32 `json:"userID"`
+	NotificationID uint32 `json:"notificationID"`
+}
+
+func (q *Queries) DeleteUserNotification(ctx context.Context, arg DeleteUserNotificationParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteUserNotificationStmt, deleteUserNotification, arg.UserID, arg.NotificationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteUserPreferences = `-- name: DeleteUserPreferences :execrows
+DELETE FROM UserPreferences WHERE user_id=?
+`
+
+func (q *Queries) DeleteUserPreferences(ctx context.Context, userID uint32) (int64, error) {
+	result, err := q.exec(ctx, q.deleteUserPreferencesStmt, deleteUserPreferences, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const expireCalendarSync = `-- name: ExpireCalendarSync :execrows
+UPDATE CalendarSync SET last_synced_at='1970-01-01 00:00:00'
+WHERE user_id=?
+`
+
+func (q *Queries) ExpireCalendarSync(ctx context.Context, userID uint32) (int64, error) {
+	result, err := q.exec(ctx, q.expireCalendarSyncStmt, expireCalendarSync, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getAllRequestsForOwner = `-- name: GetAllRequestsForOwner :many
-SELECT rr.request_id, rr.requested_by, rr.status, rr.created_at, m.msft_meeting_id, m.id, mp.start_date_range, mp.end_date_range, mp.meeting_start_time, pm.meeting_id, pm.title, pm.start_date_range, pm.end_date_range, pm.duration, pm.location  
+SELECT rr.request_id, rr.requested_by, rr.status, rr.created_at, rr.scope, rr.occurrence_start, m.msft_meeting_id, m.id, mp.start_date_range, mp.end_date_range, mp.meeting_start_time, pm.meeting_id, pm.title, pm.start_date_range, pm.end_date_range, pm.duration, pm.location  
 FROM ReschedulingRequest rr 
 JOIN RequestToMeeting rtm ON rr.request_id = rtm.request_id 
 JOIN Meeting m ON rtm.meeting_id = m.id 
@@ -477,6 +909,8 @@ type GetAllRequestsForOwnerRow struct {
 	RequestedBy      uint32                    `json:"requestedBy"`
 	Status           ReschedulingrequestStatus `json:"status"`
 	CreatedAt        time.Time                 `json:"createdAt"`
+	Scope            ReschedulingrequestScope  `json:"scope"`
+	OccurrenceStart  sql.NullTime              `json:"occurrenceStart"`
 	MsftMeetingID    string                    `json:"msftMeetingID"`
 	ID               uint32                    `json:"id"`
 	StartDateRange   time.Time                 `json:"startDateRange"`
@@ -504,6 +938,8 @@ func (q *Queries) GetAllRequestsForOwner(ctx context.Context, ownerEmail string)
 			&i.RequestedBy,
 			&i.Status,
 			&i.CreatedAt,
+			&i.Scope,
+			&i.OccurrenceStart,
 			&i.MsftMeetingID,
 			&i.ID,
 			&i.StartDateRange,
@@ -530,7 +966,7 @@ func (q *Queries) GetAllRequestsForOwner(ctx context.Context, ownerEmail string)
 }
 
 const getAllRequestsResponsesForUserID = `-- name: GetAllRequestsResponsesForUserID :many
-SELECT rr.request_id, rr.requested_by, rr.status, rr.created_at, m.msft_meeting_id, m.id, mp.start_date_range, mp.end_date_range, mp.meeting_start_time, pm.meeting_id, pm.title, pm.start_date_range, pm.end_date_range, pm.duration, pm.location  
+SELECT rr.request_id, rr.requested_by, rr.status, rr.created_at, rr.scope, rr.occurrence_start, m.msft_meeting_id, m.id, mp.start_date_range, mp.end_date_range, mp.meeting_start_time, pm.meeting_id, pm.title, pm.start_date_range, pm.end_date_range, pm.duration, pm.location  
 FROM ReschedulingRequest rr 
 JOIN RequestToMeeting rtm ON rr.request_id = rtm.request_id 
 JOIN Meeting m ON rtm.meeting_id = m.id 
@@ -544,6 +980,8 @@ type GetAllRequestsResponsesForUserIDRow struct {
 	RequestedBy      uint32                    `json:"requestedBy"`
 	Status           ReschedulingrequestStatus `json:"status"`
 	CreatedAt        time.Time                 `json:"createdAt"`
+	Scope            ReschedulingrequestScope  `json:"scope"`
+	OccurrenceStart  sql.NullTime              `json:"occurrenceStart"`
 	MsftMeetingID    string                    `json:"msftMeetingID"`
 	ID               uint32                    `json:"id"`
 	StartDateRange   time.Time                 `json:"startDateRange"`
@@ -571,6 +1009,8 @@ func (q *Queries) GetAllRequestsResponsesForUserID(ctx context.Context, requeste
 			&i.RequestedBy,
 			&i.Status,
 			&i.CreatedAt,
+			&i.Scope,
+			&i.OccurrenceStart,
 			&i.MsftMeetingID,
 			&i.ID,
 			&i.StartDateRange,
@@ -597,7 +1037,7 @@ func (q *Queries) GetAllRequestsResponsesForUserID(ctx context.Context, requeste
 }
 
 const getAllSlotifyGroupMembers = `-- name: GetAllSlotifyGroupMembers :many
-SELECT u.id, u.email, u.first_name, u.last_name FROM SlotifyGroup sg
+SELECT u.id, u.email, u.first_name, u.last_name, utsg.attendee_type FROM SlotifyGroup sg
 JOIN UserToSlotifyGroup utsg ON sg.id=utsg.slotify_group_id
 JOIN User u ON u.id=utsg.user_id 
 WHERE sg.id=?
@@ -613,10 +1053,11 @@ type GetAllSlotifyGroupMembersParams struct {
 }
 
 type GetAllSlotifyGroupMembersRow struct {
-	ID        uint32 `json:"id"`
-	Email     string `json:"email"`
-	FirstName string `json:"firstName"`
-	LastName  string `json:"lastName"`
+	ID           uint32                         `json:"id"`
+	Email        string                         `json:"email"`
+	FirstName    string                         `json:"firstName"`
+	LastName     string                         `json:"lastName"`
+	AttendeeType UsertoslotifygroupAttendeeType `json:"attendeeType"`
 }
 
 func (q *Queries) GetAllSlotifyGroupMembers(ctx context.Context, arg GetAllSlotifyGroupMembersParams) ([]GetAllSlotifyGroupMembersRow, error) {
@@ -633,6 +1074,7 @@ func (q *Queries) GetAllSlotifyGroupMembers(ctx context.Context, arg GetAllSloti
 			&i.Email,
 			&i.FirstName,
 			&i.LastName,
+			&i.AttendeeType,
 		); err != nil {
 			return nil, err
 		}
@@ -682,28 +1124,93 @@ func (q *Queries) GetAllSlotifyGroupMembersExcept(ctx context.Context, arg GetAl
 	return items, nil
 }
 
-const getInviteByID = `-- name: GetInviteByID :one
-SELECT id, slotify_group_id, from_user_id, to_user_id, message, status, expiry_date, created_at FROM Invite
+const getCalendarSubscription = `-- name: GetCalendarSubscription :one
+SELECT id, user_id, client_state, expires_at FROM CalendarSubscription
 WHERE id=?
 `
 
-func (q *Queries) GetInviteByID(ctx context.Context, id uint32) (Invite, error) {
-	row := q.queryRow(ctx, q.getInviteByIDStmt, getInviteByID, id)
-	var i Invite
+func (q *Queries) GetCalendarSubscription(ctx context.Context, id string) (CalendarSubscription, error) {
+	row := q.queryRow(ctx, q.getCalendarSubscriptionStmt, getCalendarSubscription, id)
+	var i CalendarSubscription
 	err := row.Scan(
 		&i.ID,
-		&i.SlotifyGroupID,
-		&i.FromUserID,
-		&i.ToUserID,
-		&i.Message,
-		&i.Status,
-		&i.ExpiryDate,
-		&i.CreatedAt,
+		&i.UserID,
+		&i.ClientState,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
 
-const getMeetingByID = `-- name: GetMeetingByID :one
+const getCalendarSubscriptionByUserID = `-- name: GetCalendarSubscriptionByUserID :one
+SELECT id, user_id, client_state, expires_at FROM CalendarSubscription
+WHERE user_id=?
+`
+
+func (q *Queries) GetCalendarSubscriptionByUserID(ctx context.Context, userID uint32) (CalendarSubscription, error) {
+	row := q.queryRow(ctx, q.getCalendarSubscriptionByUserIDStmt, getCalendarSubscriptionByUserID, userID)
+	var i CalendarSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ClientState,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getCalendarSync = `-- name: GetCalendarSync :one
+SELECT user_id, delta_link, window_start, window_end, last_synced_at, last_read_at FROM CalendarSync
+WHERE user_id=?
+`
+
+func (q *Queries) GetCalendarSync(ctx context.Context, userID uint32) (CalendarSync, error) {
+	row := q.queryRow(ctx, q.getCalendarSyncStmt, getCalendarSync, userID)
+	var i CalendarSync
+	err := row.Scan(
+		&i.UserID,
+		&i.DeltaLink,
+		&i.WindowStart,
+		&i.WindowEnd,
+		&i.LastSyncedAt,
+		&i.LastReadAt,
+	)
+	return i, err
+}
+
+const getInviteByID = `-- name: GetInviteByID :one
+SELECT id, slotify_group_id, from_user_id, to_user_id, message, status, expiry_date, created_at FROM Invite
+WHERE id=?
+`
+
+func (q *Queries) GetInviteByID(ctx context.Context, id uint32) (Invite, error) {
+	row := q.queryRow(ctx, q.getInviteByIDStmt, getInviteByID, id)
+	var i Invite
+	err := row.Scan(
+		&i.ID,
+		&i.SlotifyGroupID,
+		&i.FromUserID,
+		&i.ToUserID,
+		&i.Message,
+		&i.Status,
+		&i.ExpiryDate,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMSALTokenCache = `-- name: GetMSALTokenCache :one
+SELECT cache_data FROM MSALTokenCache
+WHERE partition_key=?
+`
+
+func (q *Queries) GetMSALTokenCache(ctx context.Context, partitionKey string) ([]byte, error) {
+	row := q.queryRow(ctx, q.getMSALTokenCacheStmt, getMSALTokenCache, partitionKey)
+	var cache_data []byte
+	err := row.Scan(&cache_data)
+	return cache_data, err
+}
+
+const getMeetingByID = `-- name: GetMeetingByID :one
 SELECT id, meeting_pref_id, owner_email, msft_meeting_id FROM Meeting
 WHERE id=?
 `
@@ -766,8 +1273,68 @@ func (q *Queries) GetMeetingPreferences(ctx context.Context, id uint32) (Meeting
 	return i, err
 }
 
+const getNotificationByID = `-- name: GetNotificationByID :one
+SELECT id, message, created, kind, version, payload FROM Notification WHERE id=?
+`
+
+func (q *Queries) GetNotificationByID(ctx context.Context, id uint32) (Notification, error) {
+	row := q.queryRow(ctx, q.getNotificationByIDStmt, getNotificationByID, id)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.Message,
+		&i.Created,
+		&i.Kind,
+		&i.Version,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const getNotificationPreferenceChannel = `-- name: GetNotificationPreferenceChannel :one
+SELECT channel FROM NotificationPreferences
+WHERE user_id=? AND kind=?
+`
+
+type GetNotificationPreferenceChannelParams struct {
+	UserID uint32 `json:"userID"`
+	Kind   string `json:"kind"`
+}
+
+func (q *Queries) GetNotificationPreferenceChannel(ctx context.Context, arg GetNotificationPreferenceChannelParams) (string, error) {
+	row := q.queryRow(ctx, q.getNotificationPreferenceChannelStmt, getNotificationPreferenceChannel, arg.UserID, arg.Kind)
+	var channel string
+	err := row.Scan(&channel)
+	return channel, err
+}
+
+const getNotificationQuietHours = `-- name: GetNotificationQuietHours :one
+SELECT nqh.user_id, nqh.start_time, nqh.end_time, u.time_zone FROM NotificationQuietHours nqh
+JOIN User u ON nqh.user_id=u.id
+WHERE nqh.user_id=?
+`
+
+type GetNotificationQuietHoursRow struct {
+	UserID    uint32 `json:"userID"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	TimeZone  string `json:"timeZone"`
+}
+
+func (q *Queries) GetNotificationQuietHours(ctx context.Context, userID uint32) (GetNotificationQuietHoursRow, error) {
+	row := q.queryRow(ctx, q.getNotificationQuietHoursStmt, getNotificationQuietHours, userID)
+	var i GetNotificationQuietHoursRow
+	err := row.Scan(
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
 const getOnlyRequestByID = `-- name: GetOnlyRequestByID :one
-SELECT request_id, requested_by, status, created_at FROM ReschedulingRequest
+SELECT request_id, requested_by, status, created_at, scope, occurrence_start FROM ReschedulingRequest
 WHERE request_id=?
 `
 
@@ -779,6 +1346,8 @@ func (q *Queries) GetOnlyRequestByID(ctx context.Context, requestID uint32) (Res
 		&i.RequestedBy,
 		&i.Status,
 		&i.CreatedAt,
+		&i.Scope,
+		&i.OccurrenceStart,
 	)
 	return i, err
 }
@@ -812,6 +1381,31 @@ func (q *Queries) GetPlaceholderMeetingAttendeesByMeetingID(ctx context.Context,
 	return items, nil
 }
 
+const getPollByID = `-- name: GetPollByID :one
+SELECT id, organiser_id, slotify_group_id, event, deadline, status, closing_until, chosen_option_id, event_id, created_at, closed_at, close_attempts, next_close_attempt_at FROM Poll WHERE id=?
+`
+
+func (q *Queries) GetPollByID(ctx context.Context, id uint32) (Poll, error) {
+	row := q.queryRow(ctx, q.getPollByIDStmt, getPollByID, id)
+	var i Poll
+	err := row.Scan(
+		&i.ID,
+		&i.OrganiserID,
+		&i.SlotifyGroupID,
+		&i.Event,
+		&i.Deadline,
+		&i.Status,
+		&i.ClosingUntil,
+		&i.ChosenOptionID,
+		&i.EventID,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.CloseAttempts,
+		&i.NextCloseAttemptAt,
+	)
+	return i, err
+}
+
 const getRefreshTokenByUserID = `-- name: GetRefreshTokenByUserID :one
 SELECT id, user_id, token, revoked FROM RefreshToken WHERE user_id=?
 `
@@ -829,7 +1423,7 @@ func (q *Queries) GetRefreshTokenByUserID(ctx context.Context, userID uint32) (R
 }
 
 const getRequestByID = `-- name: GetRequestByID :one
-SELECT rr.request_id, rr.requested_by, rr.status, rr.created_at, m.msft_meeting_id, m.id, mp.start_date_range, mp.end_date_range, mp.meeting_start_time, pm.meeting_id, pm.title, pm.start_date_range, pm.end_date_range, pm.duration, pm.location 
+SELECT rr.request_id, rr.requested_by, rr.status, rr.created_at, rr.scope, rr.occurrence_start, m.msft_meeting_id, m.id, mp.start_date_range, mp.end_date_range, mp.meeting_start_time, pm.meeting_id, pm.title, pm.start_date_range, pm.end_date_range, pm.duration, pm.location 
 FROM ReschedulingRequest rr 
 JOIN RequestToMeeting rtm ON rr.request_id = rtm.request_id 
 JOIN Meeting m ON rtm.meeting_id = m.id 
@@ -843,6 +1437,8 @@ type GetRequestByIDRow struct {
 	RequestedBy      uint32                    `json:"requestedBy"`
 	Status           ReschedulingrequestStatus `json:"status"`
 	CreatedAt        time.Time                 `json:"createdAt"`
+	Scope            ReschedulingrequestScope  `json:"scope"`
+	OccurrenceStart  sql.NullTime              `json:"occurrenceStart"`
 	MsftMeetingID    string                    `json:"msftMeetingID"`
 	ID               uint32                    `json:"id"`
 	StartDateRange   time.Time                 `json:"startDateRange"`
@@ -864,6 +1460,8 @@ func (q *Queries) GetRequestByID(ctx context.Context, requestID uint32) (GetRequ
 		&i.RequestedBy,
 		&i.Status,
 		&i.CreatedAt,
+		&i.Scope,
+		&i.OccurrenceStart,
 		&i.MsftMeetingID,
 		&i.ID,
 		&i.StartDateRange,
@@ -880,18 +1478,84 @@ func (q *Queries) GetRequestByID(ctx context.Context, requestID uint32) (GetRequ
 }
 
 const getSlotifyGroupByID = `-- name: GetSlotifyGroupByID :one
-SELECT id, name FROM SlotifyGroup WHERE id=?
+SELECT id, name, description, avatar_colour, visibility, default_meeting_duration, default_location_name, default_location_email, default_is_online_meeting FROM SlotifyGroup WHERE id=?
 `
 
 func (q *Queries) GetSlotifyGroupByID(ctx context.Context, id uint32) (SlotifyGroup, error) {
 	row := q.queryRow(ctx, q.getSlotifyGroupByIDStmt, getSlotifyGroupByID, id)
 	var i SlotifyGroup
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.AvatarColour,
+		&i.Visibility,
+		&i.DefaultMeetingDuration,
+		&i.DefaultLocationName,
+		&i.DefaultLocationEmail,
+		&i.DefaultIsOnlineMeeting,
+	)
+	return i, err
+}
+
+const getSlotifyGroupMemberRole = `-- name: GetSlotifyGroupMemberRole :one
+SELECT role FROM UserToSlotifyGroup
+WHERE user_id=? AND slotify_group_id=?
+`
+
+type GetSlotifyGroupMemberRoleParams struct {
+	UserID         uint32 `json:"userID"`
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+}
+
+func (q *Queries) GetSlotifyGroupMemberRole(ctx context.Context, arg GetSlotifyGroupMemberRoleParams) (UsertoslotifygroupRole, error) {
+	row := q.queryRow(ctx, q.getSlotifyGroupMemberRoleStmt, getSlotifyGroupMemberRole, arg.UserID, arg.SlotifyGroupID)
+	var role UsertoslotifygroupRole
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getSlotifyGroupScoringWeights = `-- name: GetSlotifyGroupScoringWeights :one
+SELECT slotify_group_id, meeting_load, back_to_back, time_of_day, preferred_rooms, time_zone_fairness, preferred_room_emails FROM SlotifyGroupScoringWeights
+WHERE slotify_group_id=?
+`
+
+func (q *Queries) GetSlotifyGroupScoringWeights(ctx context.Context, slotifyGroupID uint32) (SlotifyGroupScoringWeights, error) {
+	row := q.queryRow(ctx, q.getSlotifyGroupScoringWeightsStmt, getSlotifyGroupScoringWeights, slotifyGroupID)
+	var i SlotifyGroupScoringWeights
+	err := row.Scan(
+		&i.SlotifyGroupID,
+		&i.MeetingLoad,
+		&i.BackToBack,
+		&i.TimeOfDay,
+		&i.PreferredRooms,
+		&i.TimeZoneFairness,
+		&i.PreferredRoomEmails,
+	)
 	return i, err
 }
 
+const getSlotifyGroupSuccessor = `-- name: GetSlotifyGroupSuccessor :one
+SELECT user_id FROM UserToSlotifyGroup
+WHERE slotify_group_id=? AND user_id!=?
+ORDER BY role='admin' DESC, user_id
+LIMIT 1
+`
+
+type GetSlotifyGroupSuccessorParams struct {
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	UserID         uint32 `json:"userID"`
+}
+
+func (q *Queries) GetSlotifyGroupSuccessor(ctx context.Context, arg GetSlotifyGroupSuccessorParams) (uint32, error) {
+	row := q.queryRow(ctx, q.getSlotifyGroupSuccessorStmt, getSlotifyGroupSuccessor, arg.SlotifyGroupID, arg.UserID)
+	var user_id uint32
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
 const getUnreadUserNotifications = `-- name: GetUnreadUserNotifications :many
-SELECT n.id, n.message, n.created FROM UserToNotification utn
+SELECT n.id, n.message, n.created, n.kind, n.version, n.payload FROM UserToNotification utn
 JOIN Notification n ON n.id=utn.notification_id 
 WHERE utn.user_id=? AND utn.is_read=FALSE
 ORDER BY n.created DESC
@@ -906,7 +1570,14 @@ func (q *Queries) GetUnreadUserNotifications(ctx context.Context, userID uint32)
 	items := []Notification{}
 	for rows.Next() {
 		var i Notification
-		if err := rows.Scan(&i.ID, &i.Message, &i.Created); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Message,
+			&i.Created,
+			&i.Kind,
+			&i.Version,
+			&i.Payload,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -921,7 +1592,7 @@ func (q *Queries) GetUnreadUserNotifications(ctx context.Context, userID uint32)
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, first_name, last_name, msft_home_account_id FROM User WHERE email=?
+SELECT id, email, first_name, last_name, msft_home_account_id, time_zone FROM User WHERE email=?
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -933,12 +1604,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.FirstName,
 		&i.LastName,
 		&i.MsftHomeAccountID,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, first_name, last_name, msft_home_account_id FROM User WHERE id=?
+SELECT id, email, first_name, last_name, msft_home_account_id, time_zone FROM User WHERE id=?
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id uint32) (User, error) {
@@ -950,12 +1622,70 @@ func (q *Queries) GetUserByID(ctx context.Context, id uint32) (User, error) {
 		&i.FirstName,
 		&i.LastName,
 		&i.MsftHomeAccountID,
+		&i.TimeZone,
+	)
+	return i, err
+}
+
+const getUserIdentity = `-- name: GetUserIdentity :one
+SELECT id, user_id, provider, subject, token_data FROM UserIdentity
+WHERE user_id=? AND provider=?
+`
+
+type GetUserIdentityParams struct {
+	UserID   uint32               `json:"userID"`
+	Provider UserIdentityProvider `json:"provider"`
+}
+
+func (q *Queries) GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error) {
+	row := q.queryRow(ctx, q.getUserIdentityStmt, getUserIdentity, arg.UserID, arg.Provider)
+	var i UserIdentity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.TokenData,
+	)
+	return i, err
+}
+
+const getUserPreferences = `-- name: GetUserPreferences :one
+SELECT up.user_id, up.lunch_start_time, up.lunch_end_time, up.working_hours_start, up.working_hours_end,
+  up.working_days, up.max_meetings_per_day, u.time_zone FROM UserPreferences up
+JOIN User u ON up.user_id=u.id
+WHERE up.user_id=?
+`
+
+type GetUserPreferencesRow struct {
+	UserID            uint32        `json:"userID"`
+	LunchStartTime    string        `json:"lunchStartTime"`
+	LunchEndTime      string        `json:"lunchEndTime"`
+	WorkingHoursStart string        `json:"workingHoursStart"`
+	WorkingHoursEnd   string        `json:"workingHoursEnd"`
+	WorkingDays       string        `json:"workingDays"`
+	MaxMeetingsPerDay sql.NullInt32 `json:"maxMeetingsPerDay"`
+	TimeZone          string        `json:"timeZone"`
+}
+
+func (q *Queries) GetUserPreferences(ctx context.Context, userID uint32) (GetUserPreferencesRow, error) {
+	row := q.queryRow(ctx, q.getUserPreferencesStmt, getUserPreferences, userID)
+	var i GetUserPreferencesRow
+	err := row.Scan(
+		&i.UserID,
+		&i.LunchStartTime,
+		&i.LunchEndTime,
+		&i.WorkingHoursStart,
+		&i.WorkingHoursEnd,
+		&i.WorkingDays,
+		&i.MaxMeetingsPerDay,
+		&i.TimeZone,
 	)
 	return i, err
 }
 
 const getUsersSlotifyGroups = `-- name: GetUsersSlotifyGroups :many
-SELECT sg.id, sg.name FROM UserToSlotifyGroup utsg
+SELECT sg.id, sg.name, sg.description, sg.avatar_colour, sg.visibility, sg.default_meeting_duration, sg.default_location_name, sg.default_location_email, sg.default_is_online_meeting FROM UserToSlotifyGroup utsg
 JOIN SlotifyGroup sg ON utsg.slotify_group_id=sg.id 
 WHERE utsg.user_id=?
 AND sg.id > ?
@@ -978,7 +1708,185 @@ func (q *Queries) GetUsersSlotifyGroups(ctx context.Context, arg GetUsersSlotify
 	items := []SlotifyGroup{}
 	for rows.Next() {
 		var i SlotifyGroup
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.AvatarColour,
+			&i.Visibility,
+			&i.DefaultMeetingDuration,
+			&i.DefaultLocationName,
+			&i.DefaultLocationEmail,
+			&i.DefaultIsOnlineMeeting,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCalendarEventCache = `-- name: ListCalendarEventCache :many
+SELECT user_id, event_id, start_time, end_time, busy, event FROM CalendarEventCache
+WHERE user_id=? AND start_time < ? AND end_time > ?
+ORDER BY start_time
+`
+
+type ListCalendarEventCacheParams struct {
+	UserID     uint32    `json:"userID"`
+	RangeEnd   time.Time `json:"rangeEnd"`
+	RangeStart time.Time `json:"rangeStart"`
+}
+
+func (q *Queries) ListCalendarEventCache(ctx context.Context, arg ListCalendarEventCacheParams) ([]CalendarEventCache, error) {
+	rows, err := q.query(ctx, q.listCalendarEventCacheStmt, listCalendarEventCache, arg.UserID, arg.RangeEnd, arg.RangeStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CalendarEventCache{}
+	for rows.Next() {
+		var i CalendarEventCache
+		if err := rows.Scan(
+			&i.UserID,
+			&i.EventID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Busy,
+			&i.Event,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCalendarSubscriptionsToRenew = `-- name: ListCalendarSubscriptionsToRenew :many
+SELECT id, user_id, client_state, expires_at FROM CalendarSubscription
+WHERE expires_at < ?
+ORDER BY expires_at
+LIMIT ?
+`
+
+type ListCalendarSubscriptionsToRenewParams struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	Limit     int32     `json:"limit"`
+}
+
+func (q *Queries) ListCalendarSubscriptionsToRenew(ctx context.Context, arg ListCalendarSubscriptionsToRenewParams) ([]CalendarSubscription, error) {
+	rows, err := q.query(ctx, q.listCalendarSubscriptionsToRenewStmt, listCalendarSubscriptionsToRenew, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CalendarSubscription{}
+	for rows.Next() {
+		var i CalendarSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ClientState,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCalendarSyncsToRefresh = `-- name: ListCalendarSyncsToRefresh :many
+SELECT user_id FROM CalendarSync
+WHERE last_synced_at < ? AND last_read_at >= ?
+ORDER BY last_synced_at
+LIMIT ?
+`
+
+type ListCalendarSyncsToRefreshParams struct {
+	LastSyncedAt time.Time `json:"lastSyncedAt"`
+	LastReadAt   time.Time `json:"lastReadAt"`
+	Limit        int32     `json:"limit"`
+}
+
+func (q *Queries) ListCalendarSyncsToRefresh(ctx context.Context, arg ListCalendarSyncsToRefreshParams) ([]uint32, error) {
+	rows, err := q.query(ctx, q.listCalendarSyncsToRefreshStmt, listCalendarSyncsToRefresh, arg.LastSyncedAt, arg.LastReadAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uint32{}
+	for rows.Next() {
+		var user_id uint32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDueNotificationOutbox = `-- name: ListDueNotificationOutbox :many
+SELECT id, idempotency_key, user_ids, message, created, kind, version, payload, notification_id, attempts, next_attempt_at, last_error, delivered_at FROM NotificationOutbox
+WHERE delivered_at IS NULL AND next_attempt_at <= ? AND attempts < ?
+ORDER BY id
+LIMIT ?
+`
+
+type ListDueNotificationOutboxParams struct {
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	Attempts      uint32    `json:"attempts"`
+	Limit         int32     `json:"limit"`
+}
+
+func (q *Queries) ListDueNotificationOutbox(ctx context.Context, arg ListDueNotificationOutboxParams) ([]NotificationOutbox, error) {
+	rows, err := q.query(ctx, q.listDueNotificationOutboxStmt, listDueNotificationOutbox, arg.NextAttemptAt, arg.Attempts, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationOutbox{}
+	for rows.Next() {
+		var i NotificationOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.IdempotencyKey,
+			&i.UserIds,
+			&i.Message,
+			&i.Created,
+			&i.Kind,
+			&i.Version,
+			&i.Payload,
+			&i.NotificationID,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.DeliveredAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1123,24 +2031,30 @@ func (q *Queries) ListInvitesMe(ctx context.Context, arg ListInvitesMeParams) ([
 	return items, nil
 }
 
-const listSlotifyGroups = `-- name: ListSlotifyGroups :many
-SELECT id, name FROM SlotifyGroup
-WHERE name = ifnull(?, name)
+const listMemberSlotifyGroupNotificationChannels = `-- name: ListMemberSlotifyGroupNotificationChannels :many
+SELECT sgnp.channel FROM SlotifyGroupNotificationPreferences sgnp
+JOIN UserToSlotifyGroup utsg ON utsg.slotify_group_id=sgnp.slotify_group_id
+WHERE utsg.user_id=? AND sgnp.kind=?
 `
 
-func (q *Queries) ListSlotifyGroups(ctx context.Context, name interface{}) ([]SlotifyGroup, error) {
-	rows, err := q.query(ctx, q.listSlotifyGroupsStmt, listSlotifyGroups, name)
+type ListMemberSlotifyGroupNotificationChannelsParams struct {
+	UserID uint32 `json:"userID"`
+	Kind   string `json:"kind"`
+}
+
+func (q *Queries) ListMemberSlotifyGroupNotificationChannels(ctx context.Context, arg ListMemberSlotifyGroupNotificationChannelsParams) ([]string, error) {
+	rows, err := q.query(ctx, q.listMemberSlotifyGroupNotificationChannelsStmt, listMemberSlotifyGroupNotificationChannels, arg.UserID, arg.Kind)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []SlotifyGroup{}
+	items := []string{}
 	for rows.Next() {
-		var i SlotifyGroup
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		var channel string
+		if err := rows.Scan(&channel); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, channel)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1151,13 +2065,608 @@ func (q *Queries) ListSlotifyGroups(ctx context.Context, name interface{}) ([]Sl
 	return items, nil
 }
 
-const markNotificationAsRead = `-- name: MarkNotificationAsRead :execrows
-UPDATE UserToNotification SET is_read=TRUE
-WHERE user_id=? AND notification_id=?
+const listNotificationPreferences = `-- name: ListNotificationPreferences :many
+SELECT user_id, kind, channel FROM NotificationPreferences
+WHERE user_id=?
+ORDER BY kind
 `
 
-type MarkNotificationAsReadParams struct {
-	UserID         uint32 `json:"userID"`
+func (q *Queries) ListNotificationPreferences(ctx context.Context, userID uint32) ([]NotificationPreferences, error) {
+	rows, err := q.query(ctx, q.listNotificationPreferencesStmt, listNotificationPreferences, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationPreferences{}
+	for rows.Next() {
+		var i NotificationPreferences
+		if err := rows.Scan(&i.UserID, &i.Kind, &i.Channel); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationRecipients = `-- name: ListNotificationRecipients :many
+SELECT user_id, channel FROM UserToNotification
+WHERE notification_id=?
+ORDER BY user_id
+`
+
+type ListNotificationRecipientsRow struct {
+	UserID  uint32 `json:"userID"`
+	Channel string `json:"channel"`
+}
+
+func (q *Queries) ListNotificationRecipients(ctx context.Context, notificationID uint32) ([]ListNotificationRecipientsRow, error) {
+	rows, err := q.query(ctx, q.listNotificationRecipientsStmt, listNotificationRecipients, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListNotificationRecipientsRow{}
+	for rows.Next() {
+		var i ListNotificationRecipientsRow
+		if err := rows.Scan(&i.UserID, &i.Channel); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingRescheduleRequesters = `-- name: ListPendingRescheduleRequesters :many
+SELECT DISTINCT rr.requested_by FROM ReschedulingRequest rr
+JOIN RequestToMeeting rtm ON rr.request_id=rtm.request_id
+WHERE rtm.meeting_id=? AND rr.status='pending'
+`
+
+func (q *Queries) ListPendingRescheduleRequesters(ctx context.Context, meetingID uint32) ([]uint32, error) {
+	rows, err := q.query(ctx, q.listPendingRescheduleRequestersStmt, listPendingRescheduleRequesters, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uint32{}
+	for rows.Next() {
+		var requested_by uint32
+		if err := rows.Scan(&requested_by); err != nil {
+			return nil, err
+		}
+		items = append(items, requested_by)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPollOptions = `-- name: ListPollOptions :many
+SELECT id, poll_id, start_time, end_time FROM PollOption
+WHERE poll_id=?
+ORDER BY start_time, id
+`
+
+func (q *Queries) ListPollOptions(ctx context.Context, pollID uint32) ([]PollOption, error) {
+	rows, err := q.query(ctx, q.listPollOptionsStmt, listPollOptions, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PollOption{}
+	for rows.Next() {
+		var i PollOption
+		if err := rows.Scan(
+			&i.ID,
+			&i.PollID,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPollVoters = `-- name: ListPollVoters :many
+SELECT user_id FROM PollVoter
+WHERE poll_id=?
+ORDER BY user_id
+`
+
+func (q *Queries) ListPollVoters(ctx context.Context, pollID uint32) ([]uint32, error) {
+	rows, err := q.query(ctx, q.listPollVotersStmt, listPollVoters, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uint32{}
+	for rows.Next() {
+		var user_id uint32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPollVotes = `-- name: ListPollVotes :many
+SELECT pv.option_id, pv.user_id, pv.vote, pv.updated_at FROM PollVote pv
+JOIN PollOption po ON po.id=pv.option_id
+WHERE po.poll_id=?
+ORDER BY pv.option_id, pv.user_id
+`
+
+func (q *Queries) ListPollVotes(ctx context.Context, pollID uint32) ([]PollVote, error) {
+	rows, err := q.query(ctx, q.listPollVotesStmt, listPollVotes, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PollVote{}
+	for rows.Next() {
+		var i PollVote
+		if err := rows.Scan(
+			&i.OptionID,
+			&i.UserID,
+			&i.Vote,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPollsPastDeadline = `-- name: ListPollsPastDeadline :many
+SELECT id FROM Poll
+WHERE status='open' AND deadline <= ? AND (closing_until IS NULL OR closing_until < ?)
+  AND (next_close_attempt_at IS NULL OR next_close_attempt_at <= ?)
+ORDER BY deadline
+LIMIT ?
+`
+
+type ListPollsPastDeadlineParams struct {
+	Now   time.Time `json:"now"`
+	Limit int32     `json:"limit"`
+}
+
+func (q *Queries) ListPollsPastDeadline(ctx context.Context, arg ListPollsPastDeadlineParams) ([]uint32, error) {
+	rows, err := q.query(ctx, q.listPollsPastDeadlineStmt, listPollsPastDeadline,
+		arg.Now,
+		arg.Now,
+		arg.Now,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uint32{}
+	for rows.Next() {
+		var id uint32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlotifyGroupNotificationPreferences = `-- name: ListSlotifyGroupNotificationPreferences :many
+SELECT slotify_group_id, kind, channel FROM SlotifyGroupNotificationPreferences
+WHERE slotify_group_id=?
+ORDER BY kind
+`
+
+func (q *Queries) ListSlotifyGroupNotificationPreferences(ctx context.Context, slotifyGroupID uint32) ([]SlotifyGroupNotificationPreferences, error) {
+	rows, err := q.query(ctx, q.listSlotifyGroupNotificationPreferencesStmt, listSlotifyGroupNotificationPreferences, slotifyGroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SlotifyGroupNotificationPreferences{}
+	for rows.Next() {
+		var i SlotifyGroupNotificationPreferences
+		if err := rows.Scan(&i.SlotifyGroupID, &i.Kind, &i.Channel); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlotifyGroups = `-- name: ListSlotifyGroups :many
+SELECT id, name, description, avatar_colour, visibility, default_meeting_duration, default_location_name, default_location_email, default_is_online_meeting FROM SlotifyGroup
+WHERE name = ifnull(?, name)
+`
+
+func (q *Queries) ListSlotifyGroups(ctx context.Context, name interface{}) ([]SlotifyGroup, error) {
+	rows, err := q.query(ctx, q.listSlotifyGroupsStmt, listSlotifyGroups, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SlotifyGroup{}
+	for rows.Next() {
+		var i SlotifyGroup
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.AvatarColour,
+			&i.Visibility,
+			&i.DefaultMeetingDuration,
+			&i.DefaultLocationName,
+			&i.DefaultLocationEmail,
+			&i.DefaultIsOnlineMeeting,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnreadDigestNotifications = `-- name: ListUnreadDigestNotifications :many
+SELECT u.id AS user_id, u.email, u.first_name, u.last_name, nd.sent_until,
+  n.id, n.message, n.created, n.kind, n.version, n.payload
+FROM UserToNotification utn
+JOIN Notification n ON n.id=utn.notification_id
+JOIN User u ON u.id=utn.user_id
+LEFT JOIN NotificationDigest nd ON nd.user_id=u.id
+WHERE utn.is_read=FALSE AND utn.channel='email_digest'
+AND n.created > COALESCE(nd.sent_until, ?) AND n.created <= ?
+AND (nd.sent_until IS NULL OR nd.sent_until <= ?)
+ORDER BY u.id, n.id
+`
+
+type ListUnreadDigestNotificationsParams struct {
+	FirstSince time.Time `json:"firstSince"`
+	Now        time.Time `json:"now"`
+	DueBefore  time.Time `json:"dueBefore"`
+}
+
+type ListUnreadDigestNotificationsRow struct {
+	UserID    uint32          `json:"userID"`
+	Email     string          `json:"email"`
+	FirstName string          `json:"firstName"`
+	LastName  string          `json:"lastName"`
+	SentUntil sql.NullTime    `json:"sentUntil"`
+	ID        uint32          `json:"id"`
+	Message   string          `json:"message"`
+	Created   time.Time       `json:"created"`
+	Kind      string          `json:"kind"`
+	Version   uint32          `json:"version"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) ListUnreadDigestNotifications(ctx context.Context, arg ListUnreadDigestNotificationsParams) ([]ListUnreadDigestNotificationsRow, error) {
+	rows, err := q.query(ctx, q.listUnreadDigestNotificationsStmt, listUnreadDigestNotifications, arg.FirstSince, arg.Now, arg.DueBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUnreadDigestNotificationsRow{}
+	for rows.Next() {
+		var i ListUnreadDigestNotificationsRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Email,
+			&i.FirstName,
+			&i.LastName,
+			&i.SentUntil,
+			&i.ID,
+			&i.Message,
+			&i.Created,
+			&i.Kind,
+			&i.Version,
+			&i.Payload,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserNotificationOutbox = `-- name: ListUserNotificationOutbox :many
+SELECT id, idempotency_key, user_ids, message, created, kind, version, payload, notification_id, attempts, next_attempt_at, last_error, delivered_at FROM NotificationOutbox
+WHERE JSON_CONTAINS(user_ids, ?)
+ORDER BY id
+`
+
+func (q *Queries) ListUserNotificationOutbox(ctx context.Context, userID interface{}) ([]NotificationOutbox, error) {
+	rows, err := q.query(ctx, q.listUserNotificationOutboxStmt, listUserNotificationOutbox, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationOutbox{}
+	for rows.Next() {
+		var i NotificationOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.IdempotencyKey,
+			&i.UserIds,
+			&i.Message,
+			&i.Created,
+			&i.Kind,
+			&i.Version,
+			&i.Payload,
+			&i.NotificationID,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserNotifications = `-- name: ListUserNotifications :many
+SELECT n.id, n.message, n.created, n.kind, n.version, n.payload, utn.is_read FROM UserToNotification utn
+JOIN Notification n ON n.id=utn.notification_id
+WHERE utn.user_id=? AND n.id < ?
+AND (? IS NULL OR utn.is_read=?)
+ORDER BY n.id DESC
+LIMIT ?
+`
+
+type ListUserNotificationsParams struct {
+	UserID uint32       `json:"userID"`
+	LastID uint32       `json:"lastID"`
+	IsRead sql.NullBool `json:"isRead"`
+	Limit  int32        `json:"limit"`
+}
+
+type ListUserNotificationsRow struct {
+	ID      uint32          `json:"id"`
+	Message string          `json:"message"`
+	Created time.Time       `json:"created"`
+	Kind    string          `json:"kind"`
+	Version uint32          `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+	IsRead  bool            `json:"isRead"`
+}
+
+func (q *Queries) ListUserNotifications(ctx context.Context, arg ListUserNotificationsParams) ([]ListUserNotificationsRow, error) {
+	rows, err := q.query(ctx, q.listUserNotificationsStmt, listUserNotifications,
+		arg.UserID,
+		arg.LastID,
+		arg.IsRead,
+		arg.IsRead,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserNotificationsRow{}
+	for rows.Next() {
+		var i ListUserNotificationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Message,
+			&i.Created,
+			&i.Kind,
+			&i.Version,
+			&i.Payload,
+			&i.IsRead,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserNotificationsAfterID = `-- name: ListUserNotificationsAfterID :many
+SELECT n.id, n.message, n.created, n.kind, n.version, n.payload FROM UserToNotification utn
+JOIN Notification n ON n.id=utn.notification_id
+WHERE utn.user_id=? AND n.id > ? AND utn.channel='in_app'
+ORDER BY n.id
+`
+
+type ListUserNotificationsAfterIDParams struct {
+	UserID uint32 `json:"userID"`
+	ID     uint32 `json:"id"`
+}
+
+func (q *Queries) ListUserNotificationsAfterID(ctx context.Context, arg ListUserNotificationsAfterIDParams) ([]Notification, error) {
+	rows, err := q.query(ctx, q.listUserNotificationsAfterIDStmt, listUserNotificationsAfterID, arg.UserID, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.Message,
+			&i.Created,
+			&i.Kind,
+			&i.Version,
+			&i.Payload,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserPolls = `-- name: ListUserPolls :many
+SELECT p.id, p.organiser_id, p.slotify_group_id, p.event, p.deadline, p.status, p.closing_until, p.chosen_option_id, p.event_id, p.created_at, p.closed_at, p.close_attempts, p.next_close_attempt_at FROM Poll p
+JOIN PollVoter pv ON pv.poll_id=p.id
+WHERE pv.user_id=? AND (? IS NULL OR p.status=?)
+ORDER BY p.created_at DESC, p.id DESC
+`
+
+type ListUserPollsParams struct {
+	UserID uint32         `json:"userID"`
+	Status NullPollStatus `json:"status"`
+}
+
+func (q *Queries) ListUserPolls(ctx context.Context, arg ListUserPollsParams) ([]Poll, error) {
+	rows, err := q.query(ctx, q.listUserPollsStmt, listUserPolls, arg.UserID, arg.Status, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Poll{}
+	for rows.Next() {
+		var i Poll
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganiserID,
+			&i.SlotifyGroupID,
+			&i.Event,
+			&i.Deadline,
+			&i.Status,
+			&i.ClosingUntil,
+			&i.ChosenOptionID,
+			&i.EventID,
+			&i.CreatedAt,
+			&i.ClosedAt,
+			&i.CloseAttempts,
+			&i.NextCloseAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPollForVote = `-- name: LockPollForVote :one
+SELECT COUNT(*) FROM Poll
+WHERE id=? AND status='open' AND deadline > ? AND chosen_option_id IS NULL
+AND (closing_until IS NULL OR closing_until < ?)
+FOR UPDATE
+`
+
+type LockPollForVoteParams struct {
+	ID  uint32    `json:"id"`
+	Now time.Time `json:"now"`
+}
+
+func (q *Queries) LockPollForVote(ctx context.Context, arg LockPollForVoteParams) (int64, error) {
+	row := q.queryRow(ctx, q.lockPollForVoteStmt, lockPollForVote, arg.ID, arg.Now, arg.Now)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const markAllNotificationsAsRead = `-- name: MarkAllNotificationsAsRead :execrows
+UPDATE UserToNotification SET is_read=TRUE
+WHERE user_id=? AND is_read=FALSE
+`
+
+func (q *Queries) MarkAllNotificationsAsRead(ctx context.Context, userID uint32) (int64, error) {
+	result, err := q.exec(ctx, q.markAllNotificationsAsReadStmt, markAllNotificationsAsRead, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markNotificationAsRead = `-- name: MarkNotificationAsRead :execrows
+UPDATE UserToNotification SET is_read=TRUE
+WHERE user_id=? AND notification_id=?
+`
+
+type MarkNotificationAsReadParams struct {
+	UserID         uint32 `json:"userID"`
 	NotificationID uint32 `json:"notificationID"`
 }
 
@@ -1169,6 +2678,37 @@ func (q *Queries) MarkNotificationAsRead(ctx context.Context, arg MarkNotificati
 	return result.RowsAffected()
 }
 
+const markNotificationOutboxDelivered = `-- name: MarkNotificationOutboxDelivered :execrows
+UPDATE NotificationOutbox SET delivered_at=?, last_error=NULL
+WHERE id=?
+`
+
+type MarkNotificationOutboxDeliveredParams struct {
+	DeliveredAt sql.NullTime `json:"deliveredAt"`
+	ID          uint32       `json:"id"`
+}
+
+func (q *Queries) MarkNotificationOutboxDelivered(ctx context.Context, arg MarkNotificationOutboxDeliveredParams) (int64, error) {
+	result, err := q.exec(ctx, q.markNotificationOutboxDeliveredStmt, markNotificationOutboxDelivered, arg.DeliveredAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releasePollClose = `-- name: ReleasePollClose :execrows
+UPDATE Poll SET closing_until=NULL
+WHERE id=?
+`
+
+func (q *Queries) ReleasePollClose(ctx context.Context, id uint32) (int64, error) {
+	result, err := q.exec(ctx, q.releasePollCloseStmt, releasePollClose, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const removeSlotifyGroup = `-- name: RemoveSlotifyGroup :execrows
 DELETE FROM SlotifyGroup
 WHERE id=?
@@ -1200,6 +2740,50 @@ func (q *Queries) RemoveSlotifyGroupMember(ctx context.Context, arg RemoveSlotif
 	return result.RowsAffected()
 }
 
+const retryNotificationOutbox = `-- name: RetryNotificationOutbox :execrows
+UPDATE NotificationOutbox SET next_attempt_at=?, last_error=?
+WHERE id=?
+`
+
+type RetryNotificationOutboxParams struct {
+	NextAttemptAt time.Time      `json:"nextAttemptAt"`
+	LastError     sql.NullString `json:"lastError"`
+	ID            uint32         `json:"id"`
+}
+
+func (q *Queries) RetryNotificationOutbox(ctx context.Context, arg RetryNotificationOutboxParams) (int64, error) {
+	result, err := q.exec(ctx, q.retryNotificationOutboxStmt, retryNotificationOutbox, arg.NextAttemptAt, arg.LastError, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retryPollCloseLater = `-- name: RetryPollCloseLater :execrows
+UPDATE Poll SET close_attempts=?, next_close_attempt_at=?, status=?
+WHERE id=? AND status='open'
+`
+
+type RetryPollCloseLaterParams struct {
+	CloseAttempts      uint32       `json:"closeAttempts"`
+	NextCloseAttemptAt sql.NullTime `json:"nextCloseAttemptAt"`
+	Status             PollStatus   `json:"status"`
+	ID                 uint32       `json:"id"`
+}
+
+func (q *Queries) RetryPollCloseLater(ctx context.Context, arg RetryPollCloseLaterParams) (int64, error) {
+	result, err := q.exec(ctx, q.retryPollCloseLaterStmt, retryPollCloseLater,
+		arg.CloseAttempts,
+		arg.NextCloseAttemptAt,
+		arg.Status,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const searchSlotifyGroupMembersByEmail = `-- name: SearchSlotifyGroupMembersByEmail :many
 SELECT u.id, u.email, u.first_name, u.last_name
 FROM SlotifyGroup sg
@@ -1400,6 +2984,117 @@ func (q *Queries) SearchUsersByName(ctx context.Context, name interface{}) ([]Se
 	return items, nil
 }
 
+const setNotificationOutboxNotificationID = `-- name: SetNotificationOutboxNotificationID :execrows
+UPDATE NotificationOutbox SET notification_id=?
+WHERE id=? AND notification_id IS NULL
+`
+
+type SetNotificationOutboxNotificationIDParams struct {
+	NotificationID sql.NullInt32 `json:"notificationID"`
+	ID             uint32        `json:"id"`
+}
+
+func (q *Queries) SetNotificationOutboxNotificationID(ctx context.Context, arg SetNotificationOutboxNotificationIDParams) (int64, error) {
+	result, err := q.exec(ctx, q.setNotificationOutboxNotificationIDStmt, setNotificationOutboxNotificationID, arg.NotificationID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setPollMeeting = `-- name: SetPollMeeting :execrows
+UPDATE Poll SET chosen_option_id=?, event_id=?
+WHERE id=? AND status IN ('open', 'failed') AND chosen_option_id IS NULL
+`
+
+type SetPollMeetingParams struct {
+	ChosenOptionID sql.NullInt32  `json:"chosenOptionID"`
+	EventID        sql.NullString `json:"eventID"`
+	ID             uint32         `json:"id"`
+}
+
+func (q *Queries) SetPollMeeting(ctx context.Context, arg SetPollMeetingParams) (int64, error) {
+	result, err := q.exec(ctx, q.setPollMeetingStmt, setPollMeeting, arg.ChosenOptionID, arg.EventID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setSlotifyGroupMemberAttendeeType = `-- name: SetSlotifyGroupMemberAttendeeType :execrows
+UPDATE UserToSlotifyGroup SET attendee_type=?
+WHERE user_id=? AND slotify_group_id=?
+`
+
+type SetSlotifyGroupMemberAttendeeTypeParams struct {
+	AttendeeType   UsertoslotifygroupAttendeeType `json:"attendeeType"`
+	UserID         uint32                         `json:"userID"`
+	SlotifyGroupID uint32                         `json:"slotifyGroupID"`
+}
+
+func (q *Queries) SetSlotifyGroupMemberAttendeeType(ctx context.Context, arg SetSlotifyGroupMemberAttendeeTypeParams) (int64, error) {
+	result, err := q.exec(ctx, q.setSlotifyGroupMemberAttendeeTypeStmt, setSlotifyGroupMemberAttendeeType, arg.AttendeeType, arg.UserID, arg.SlotifyGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setSlotifyGroupMemberRole = `-- name: SetSlotifyGroupMemberRole :execrows
+UPDATE UserToSlotifyGroup SET role=?
+WHERE user_id=? AND slotify_group_id=?
+`
+
+type SetSlotifyGroupMemberRoleParams struct {
+	Role           UsertoslotifygroupRole `json:"role"`
+	UserID         uint32                 `json:"userID"`
+	SlotifyGroupID uint32                 `json:"slotifyGroupID"`
+}
+
+func (q *Queries) SetSlotifyGroupMemberRole(ctx context.Context, arg SetSlotifyGroupMemberRoleParams) (int64, error) {
+	result, err := q.exec(ctx, q.setSlotifyGroupMemberRoleStmt, setSlotifyGroupMemberRole, arg.Role, arg.UserID, arg.SlotifyGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateCalendarSubscriptionExpiresAt = `-- name: UpdateCalendarSubscriptionExpiresAt :execrows
+UPDATE CalendarSubscription SET expires_at=?
+WHERE id=?
+`
+
+type UpdateCalendarSubscriptionExpiresAtParams struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	ID        string    `json:"id"`
+}
+
+func (q *Queries) UpdateCalendarSubscriptionExpiresAt(ctx context.Context, arg UpdateCalendarSubscriptionExpiresAtParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateCalendarSubscriptionExpiresAtStmt, updateCalendarSubscriptionExpiresAt, arg.ExpiresAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateCalendarSyncLastReadAt = `-- name: UpdateCalendarSyncLastReadAt :execrows
+UPDATE CalendarSync SET last_read_at=?
+WHERE user_id=?
+`
+
+type UpdateCalendarSyncLastReadAtParams struct {
+	LastReadAt time.Time `json:"lastReadAt"`
+	UserID     uint32    `json:"userID"`
+}
+
+func (q *Queries) UpdateCalendarSyncLastReadAt(ctx context.Context, arg UpdateCalendarSyncLastReadAtParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateCalendarSyncLastReadAtStmt, updateCalendarSyncLastReadAt, arg.LastReadAt, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateInviteMessage = `-- name: UpdateInviteMessage :execrows
 UPDATE Invite SET message=?
 WHERE id=? AND from_user_id=?
@@ -1485,6 +3180,43 @@ func (q *Queries) UpdateRequestStatusAsRejected(ctx context.Context, meetingID u
 	return result.RowsAffected()
 }
 
+const updateSlotifyGroup = `-- name: UpdateSlotifyGroup :execrows
+UPDATE SlotifyGroup
+SET name=?, description=?, avatar_colour=?, visibility=?, default_meeting_duration=?,
+default_location_name=?, default_location_email=?, default_is_online_meeting=?
+WHERE id=?
+`
+
+type UpdateSlotifyGroupParams struct {
+	Name                   string                 `json:"name"`
+	Description            string                 `json:"description"`
+	AvatarColour           sql.NullString         `json:"avatarColour"`
+	Visibility             SlotifygroupVisibility `json:"visibility"`
+	DefaultMeetingDuration sql.NullString         `json:"defaultMeetingDuration"`
+	DefaultLocationName    sql.NullString         `json:"defaultLocationName"`
+	DefaultLocationEmail   sql.NullString         `json:"defaultLocationEmail"`
+	DefaultIsOnlineMeeting bool                   `json:"defaultIsOnlineMeeting"`
+	ID                     uint32                 `json:"id"`
+}
+
+func (q *Queries) UpdateSlotifyGroup(ctx context.Context, arg UpdateSlotifyGroupParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateSlotifyGroupStmt, updateSlotifyGroup,
+		arg.Name,
+		arg.Description,
+		arg.AvatarColour,
+		arg.Visibility,
+		arg.DefaultMeetingDuration,
+		arg.DefaultLocationName,
+		arg.DefaultLocationEmail,
+		arg.DefaultIsOnlineMeeting,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateUserHomeAccountID = `-- name: UpdateUserHomeAccountID :execrows
 UPDATE User SET msft_home_account_id=? WHERE id=?
 `
@@ -1501,3 +3233,277 @@ func (q *Queries) UpdateUserHomeAccountID(ctx context.Context, arg UpdateUserHom
 	}
 	return result.RowsAffected()
 }
+
+const updateUserIdentityTokenData = `-- name: UpdateUserIdentityTokenData :execrows
+UPDATE UserIdentity SET token_data=?
+WHERE user_id=? AND provider=?
+`
+
+type UpdateUserIdentityTokenDataParams struct {
+	TokenData []byte               `json:"tokenData"`
+	UserID    uint32               `json:"userID"`
+	Provider  UserIdentityProvider `json:"provider"`
+}
+
+func (q *Queries) UpdateUserIdentityTokenData(ctx context.Context, arg UpdateUserIdentityTokenDataParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateUserIdentityTokenDataStmt, updateUserIdentityTokenData, arg.TokenData, arg.UserID, arg.Provider)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserTimeZone = `-- name: UpdateUserTimeZone :execrows
+UPDATE User SET time_zone=? WHERE id=?
+`
+
+type UpdateUserTimeZoneParams struct {
+	TimeZone string `json:"timeZone"`
+	ID       uint32 `json:"id"`
+}
+
+func (q *Queries) UpdateUserTimeZone(ctx context.Context, arg UpdateUserTimeZoneParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateUserTimeZoneStmt, updateUserTimeZone, arg.TimeZone, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertCalendarEventCache = `-- name: UpsertCalendarEventCache :execrows
+INSERT INTO CalendarEventCache (user_id, event_id, start_time, end_time, busy, event)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE start_time=VALUES(start_time), end_time=VALUES(end_time), busy=VALUES(busy),
+  event=VALUES(event)
+`
+
+type UpsertCalendarEventCacheParams struct {
+	UserID    uint32          `json:"userID"`
+	EventID   string          `json:"eventID"`
+	StartTime time.Time       `json:"startTime"`
+	EndTime   time.Time       `json:"endTime"`
+	Busy      bool            `json:"busy"`
+	Event     json.RawMessage `json:"event"`
+}
+
+func (q *Queries) UpsertCalendarEventCache(ctx context.Context, arg UpsertCalendarEventCacheParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertCalendarEventCacheStmt, upsertCalendarEventCache,
+		arg.UserID,
+		arg.EventID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Busy,
+		arg.Event,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertCalendarSubscription = `-- name: UpsertCalendarSubscription :execrows
+INSERT INTO CalendarSubscription (id, user_id, client_state, expires_at) VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE id=VALUES(id), client_state=VALUES(client_state), expires_at=VALUES(expires_at)
+`
+
+type UpsertCalendarSubscriptionParams struct {
+	ID          string    `json:"id"`
+	UserID      uint32    `json:"userID"`
+	ClientState string    `json:"clientState"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+func (q *Queries) UpsertCalendarSubscription(ctx context.Context, arg UpsertCalendarSubscriptionParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertCalendarSubscriptionStmt, upsertCalendarSubscription,
+		arg.ID,
+		arg.UserID,
+		arg.ClientState,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertCalendarSync = `-- name: UpsertCalendarSync :execrows
+INSERT INTO CalendarSync (user_id, delta_link, window_start, window_end, last_synced_at, last_read_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE delta_link=VALUES(delta_link), window_start=VALUES(window_start),
+  window_end=VALUES(window_end), last_synced_at=VALUES(last_synced_at)
+`
+
+type UpsertCalendarSyncParams struct {
+	UserID       uint32    `json:"userID"`
+	DeltaLink    string    `json:"deltaLink"`
+	WindowStart  time.Time `json:"windowStart"`
+	WindowEnd    time.Time `json:"windowEnd"`
+	LastSyncedAt time.Time `json:"lastSyncedAt"`
+	LastReadAt   time.Time `json:"lastReadAt"`
+}
+
+func (q *Queries) UpsertCalendarSync(ctx context.Context, arg UpsertCalendarSyncParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertCalendarSyncStmt, upsertCalendarSync,
+		arg.UserID,
+		arg.DeltaLink,
+		arg.WindowStart,
+		arg.WindowEnd,
+		arg.LastSyncedAt,
+		arg.LastReadAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertMSALTokenCache = `-- name: UpsertMSALTokenCache :execrows
+REPLACE INTO MSALTokenCache (partition_key, cache_data) VALUES (?, ?)
+`
+
+type UpsertMSALTokenCacheParams struct {
+	PartitionKey string `json:"partitionKey"`
+	CacheData    []byte `json:"cacheData"`
+}
+
+func (q *Queries) UpsertMSALTokenCache(ctx context.Context, arg UpsertMSALTokenCacheParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertMSALTokenCacheStmt, upsertMSALTokenCache, arg.PartitionKey, arg.CacheData)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertNotificationQuietHours = `-- name: UpsertNotificationQuietHours :execrows
+INSERT INTO NotificationQuietHours (user_id, start_time, end_time)
+VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE start_time=VALUES(start_time), end_time=VALUES(end_time)
+`
+
+type UpsertNotificationQuietHoursParams struct {
+	UserID    uint32 `json:"userID"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+func (q *Queries) UpsertNotificationQuietHours(ctx context.Context, arg UpsertNotificationQuietHoursParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertNotificationQuietHoursStmt, upsertNotificationQuietHours, arg.UserID, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertPollVote = `-- name: UpsertPollVote :execrows
+INSERT INTO PollVote (option_id, user_id, vote, updated_at) VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE vote=VALUES(vote), updated_at=VALUES(updated_at)
+`
+
+type UpsertPollVoteParams struct {
+	OptionID  uint32       `json:"optionID"`
+	UserID    uint32       `json:"userID"`
+	Vote      PollVoteVote `json:"vote"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+func (q *Queries) UpsertPollVote(ctx context.Context, arg UpsertPollVoteParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertPollVoteStmt, upsertPollVote,
+		arg.OptionID,
+		arg.UserID,
+		arg.Vote,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertSlotifyGroupScoringWeights = `-- name: UpsertSlotifyGroupScoringWeights :execrows
+INSERT INTO SlotifyGroupScoringWeights (slotify_group_id, meeting_load, back_to_back, time_of_day,
+  preferred_rooms, time_zone_fairness, preferred_room_emails)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE meeting_load=VALUES(meeting_load), back_to_back=VALUES(back_to_back),
+  time_of_day=VALUES(time_of_day), preferred_rooms=VALUES(preferred_rooms),
+  time_zone_fairness=VALUES(time_zone_fairness), preferred_room_emails=VALUES(preferred_room_emails)
+`
+
+type UpsertSlotifyGroupScoringWeightsParams struct {
+	SlotifyGroupID      uint32  `json:"slotifyGroupID"`
+	MeetingLoad         float64 `json:"meetingLoad"`
+	BackToBack          float64 `json:"backToBack"`
+	TimeOfDay           float64 `json:"timeOfDay"`
+	PreferredRooms      float64 `json:"preferredRooms"`
+	TimeZoneFairness    float64 `json:"timeZoneFairness"`
+	PreferredRoomEmails string  `json:"preferredRoomEmails"`
+}
+
+func (q *Queries) UpsertSlotifyGroupScoringWeights(ctx context.Context, arg UpsertSlotifyGroupScoringWeightsParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertSlotifyGroupScoringWeightsStmt, upsertSlotifyGroupScoringWeights,
+		arg.SlotifyGroupID,
+		arg.MeetingLoad,
+		arg.BackToBack,
+		arg.TimeOfDay,
+		arg.PreferredRooms,
+		arg.TimeZoneFairness,
+		arg.PreferredRoomEmails,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertUserIdentity = `-- name: UpsertUserIdentity :execrows
+INSERT INTO UserIdentity (user_id, provider, subject) VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE subject=VALUES(subject)
+`
+
+type UpsertUserIdentityParams struct {
+	UserID   uint32               `json:"userID"`
+	Provider UserIdentityProvider `json:"provider"`
+	Subject  string               `json:"subject"`
+}
+
+func (q *Queries) UpsertUserIdentity(ctx context.Context, arg UpsertUserIdentityParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertUserIdentityStmt, upsertUserIdentity, arg.UserID, arg.Provider, arg.Subject)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertUserPreferences = `-- name: UpsertUserPreferences :execrows
+INSERT INTO UserPreferences (user_id, lunch_start_time, lunch_end_time, working_hours_start,
+  working_hours_end, working_days, max_meetings_per_day)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE lunch_start_time=VALUES(lunch_start_time), lunch_end_time=VALUES(lunch_end_time),
+  working_hours_start=VALUES(working_hours_start), working_hours_end=VALUES(working_hours_end),
+  working_days=VALUES(working_days), max_meetings_per_day=VALUES(max_meetings_per_day)
+`
+
+type UpsertUserPreferencesParams struct {
+	UserID            uint32        `json:"userID"`
+	LunchStartTime    string        `json:"lunchStartTime"`
+	LunchEndTime      string        `json:"lunchEndTime"`
+	WorkingHoursStart string        `json:"workingHoursStart"`
+	WorkingHoursEnd   string        `json:"workingHoursEnd"`
+	WorkingDays       string        `json:"workingDays"`
+	MaxMeetingsPerDay sql.NullInt32 `json:"maxMeetingsPerDay"`
+}
+
+func (q *Queries) UpsertUserPreferences(ctx context.Context, arg UpsertUserPreferencesParams) (int64, error) {
+	result, err := q.exec(ctx, q.upsertUserPreferencesStmt, upsertUserPreferences,
+		arg.UserID,
+		arg.LunchStartTime,
+		arg.LunchEndTime,
+		arg.WorkingHoursStart,
+		arg.WorkingHoursEnd,
+		arg.WorkingDays,
+		arg.MaxMeetingsPerDay,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
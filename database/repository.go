@@ -30,6 +30,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addUserToSlotifyGroupStmt, err = db.PrepareContext(ctx, addUserToSlotifyGroup); err != nil {
 		return nil, fmt.Errorf("error preparing query AddUserToSlotifyGroup: %w", err)
 	}
+	if q.batchDeleteIdleCalendarSyncsStmt, err = db.PrepareContext(ctx, batchDeleteIdleCalendarSyncs); err != nil {
+		return nil, fmt.Errorf("error preparing query BatchDeleteIdleCalendarSyncs: %w", err)
+	}
 	if q.batchDeleteWeekOldInvitesStmt, err = db.PrepareContext(ctx, batchDeleteWeekOldInvites); err != nil {
 		return nil, fmt.Errorf("error preparing query BatchDeleteWeekOldInvites: %w", err)
 	}
@@ -42,6 +45,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.checkMemberInSlotifyGroupStmt, err = db.PrepareContext(ctx, checkMemberInSlotifyGroup); err != nil {
 		return nil, fmt.Errorf("error preparing query CheckMemberInSlotifyGroup: %w", err)
 	}
+	if q.checkPollVoterStmt, err = db.PrepareContext(ctx, checkPollVoter); err != nil {
+		return nil, fmt.Errorf("error preparing query CheckPollVoter: %w", err)
+	}
+	if q.claimNotificationDigestStmt, err = db.PrepareContext(ctx, claimNotificationDigest); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimNotificationDigest: %w", err)
+	}
+	if q.claimNotificationOutboxStmt, err = db.PrepareContext(ctx, claimNotificationOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimNotificationOutbox: %w", err)
+	}
+	if q.claimPollCloseStmt, err = db.PrepareContext(ctx, claimPollClose); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimPollClose: %w", err)
+	}
+	if q.closePollStmt, err = db.PrepareContext(ctx, closePoll); err != nil {
+		return nil, fmt.Errorf("error preparing query ClosePoll: %w", err)
+	}
 	if q.countExpiredInvitesStmt, err = db.PrepareContext(ctx, countExpiredInvites); err != nil {
 		return nil, fmt.Errorf("error preparing query CountExpiredInvites: %w", err)
 	}
@@ -51,6 +69,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.countSlotifyGroupMembersStmt, err = db.PrepareContext(ctx, countSlotifyGroupMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query CountSlotifyGroupMembers: %w", err)
 	}
+	if q.countUnreadUserNotificationsStmt, err = db.PrepareContext(ctx, countUnreadUserNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnreadUserNotifications: %w", err)
+	}
 	if q.countUserByEmailStmt, err = db.PrepareContext(ctx, countUserByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query CountUserByEmail: %w", err)
 	}
@@ -75,12 +96,30 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createNotificationStmt, err = db.PrepareContext(ctx, createNotification); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateNotification: %w", err)
 	}
+	if q.createNotificationDigestStmt, err = db.PrepareContext(ctx, createNotificationDigest); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNotificationDigest: %w", err)
+	}
+	if q.createNotificationOutboxStmt, err = db.PrepareContext(ctx, createNotificationOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNotificationOutbox: %w", err)
+	}
+	if q.createNotificationPreferenceStmt, err = db.PrepareContext(ctx, createNotificationPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNotificationPreference: %w", err)
+	}
 	if q.createPlaceholderMeetingStmt, err = db.PrepareContext(ctx, createPlaceholderMeeting); err != nil {
 		return nil, fmt.Errorf("error preparing query CreatePlaceholderMeeting: %w", err)
 	}
 	if q.createPlaceholderMeetingAttendeeStmt, err = db.PrepareContext(ctx, createPlaceholderMeetingAttendee); err != nil {
 		return nil, fmt.Errorf("error preparing query CreatePlaceholderMeetingAttendee: %w", err)
 	}
+	if q.createPollStmt, err = db.PrepareContext(ctx, createPoll); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePoll: %w", err)
+	}
+	if q.createPollOptionStmt, err = db.PrepareContext(ctx, createPollOption); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePollOption: %w", err)
+	}
+	if q.createPollVoterStmt, err = db.PrepareContext(ctx, createPollVoter); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePollVoter: %w", err)
+	}
 	if q.createRefreshTokenStmt, err = db.PrepareContext(ctx, createRefreshToken); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateRefreshToken: %w", err)
 	}
@@ -90,15 +129,36 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createReschedulingRequestStmt, err = db.PrepareContext(ctx, createReschedulingRequest); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateReschedulingRequest: %w", err)
 	}
+	if q.createSlotifyGroupNotificationPreferenceStmt, err = db.PrepareContext(ctx, createSlotifyGroupNotificationPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSlotifyGroupNotificationPreference: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
 	if q.createUserNotificationStmt, err = db.PrepareContext(ctx, createUserNotification); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUserNotification: %w", err)
 	}
+	if q.deleteCalendarEventCacheStmt, err = db.PrepareContext(ctx, deleteCalendarEventCache); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCalendarEventCache: %w", err)
+	}
+	if q.deleteCalendarEventCacheEventStmt, err = db.PrepareContext(ctx, deleteCalendarEventCacheEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCalendarEventCacheEvent: %w", err)
+	}
+	if q.deleteCalendarSubscriptionStmt, err = db.PrepareContext(ctx, deleteCalendarSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCalendarSubscription: %w", err)
+	}
+	if q.deleteDeliveredNotificationOutboxStmt, err = db.PrepareContext(ctx, deleteDeliveredNotificationOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteDeliveredNotificationOutbox: %w", err)
+	}
 	if q.deleteInviteByIDStmt, err = db.PrepareContext(ctx, deleteInviteByID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteInviteByID: %w", err)
 	}
+	if q.deleteNotificationPreferencesStmt, err = db.PrepareContext(ctx, deleteNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNotificationPreferences: %w", err)
+	}
+	if q.deleteNotificationQuietHoursStmt, err = db.PrepareContext(ctx, deleteNotificationQuietHours); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNotificationQuietHours: %w", err)
+	}
 	if q.deleteRefreshTokenByUserIDStmt, err = db.PrepareContext(ctx, deleteRefreshTokenByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteRefreshTokenByUserID: %w", err)
 	}
@@ -108,9 +168,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteSlotifyGroupByIDStmt, err = db.PrepareContext(ctx, deleteSlotifyGroupByID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteSlotifyGroupByID: %w", err)
 	}
+	if q.deleteSlotifyGroupNotificationPreferencesStmt, err = db.PrepareContext(ctx, deleteSlotifyGroupNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSlotifyGroupNotificationPreferences: %w", err)
+	}
 	if q.deleteUserByIDStmt, err = db.PrepareContext(ctx, deleteUserByID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteUserByID: %w", err)
 	}
+	if q.deleteUserNotificationStmt, err = db.PrepareContext(ctx, deleteUserNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserNotification: %w", err)
+	}
+	if q.deleteUserPreferencesStmt, err = db.PrepareContext(ctx, deleteUserPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserPreferences: %w", err)
+	}
+	if q.expireCalendarSyncStmt, err = db.PrepareContext(ctx, expireCalendarSync); err != nil {
+		return nil, fmt.Errorf("error preparing query ExpireCalendarSync: %w", err)
+	}
 	if q.getAllRequestsForOwnerStmt, err = db.PrepareContext(ctx, getAllRequestsForOwner); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllRequestsForOwner: %w", err)
 	}
@@ -123,9 +195,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getAllSlotifyGroupMembersExceptStmt, err = db.PrepareContext(ctx, getAllSlotifyGroupMembersExcept); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllSlotifyGroupMembersExcept: %w", err)
 	}
+	if q.getCalendarSubscriptionStmt, err = db.PrepareContext(ctx, getCalendarSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCalendarSubscription: %w", err)
+	}
+	if q.getCalendarSubscriptionByUserIDStmt, err = db.PrepareContext(ctx, getCalendarSubscriptionByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCalendarSubscriptionByUserID: %w", err)
+	}
+	if q.getCalendarSyncStmt, err = db.PrepareContext(ctx, getCalendarSync); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCalendarSync: %w", err)
+	}
 	if q.getInviteByIDStmt, err = db.PrepareContext(ctx, getInviteByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetInviteByID: %w", err)
 	}
+	if q.getMSALTokenCacheStmt, err = db.PrepareContext(ctx, getMSALTokenCache); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMSALTokenCache: %w", err)
+	}
 	if q.getMeetingByIDStmt, err = db.PrepareContext(ctx, getMeetingByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMeetingByID: %w", err)
 	}
@@ -138,12 +222,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getMeetingPreferencesStmt, err = db.PrepareContext(ctx, getMeetingPreferences); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMeetingPreferences: %w", err)
 	}
+	if q.getNotificationByIDStmt, err = db.PrepareContext(ctx, getNotificationByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationByID: %w", err)
+	}
+	if q.getNotificationPreferenceChannelStmt, err = db.PrepareContext(ctx, getNotificationPreferenceChannel); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationPreferenceChannel: %w", err)
+	}
+	if q.getNotificationQuietHoursStmt, err = db.PrepareContext(ctx, getNotificationQuietHours); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationQuietHours: %w", err)
+	}
 	if q.getOnlyRequestByIDStmt, err = db.PrepareContext(ctx, getOnlyRequestByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOnlyRequestByID: %w", err)
 	}
 	if q.getPlaceholderMeetingAttendeesByMeetingIDStmt, err = db.PrepareContext(ctx, getPlaceholderMeetingAttendeesByMeetingID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPlaceholderMeetingAttendeesByMeetingID: %w", err)
 	}
+	if q.getPollByIDStmt, err = db.PrepareContext(ctx, getPollByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPollByID: %w", err)
+	}
 	if q.getRefreshTokenByUserIDStmt, err = db.PrepareContext(ctx, getRefreshTokenByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetRefreshTokenByUserID: %w", err)
 	}
@@ -153,6 +249,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getSlotifyGroupByIDStmt, err = db.PrepareContext(ctx, getSlotifyGroupByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSlotifyGroupByID: %w", err)
 	}
+	if q.getSlotifyGroupMemberRoleStmt, err = db.PrepareContext(ctx, getSlotifyGroupMemberRole); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSlotifyGroupMemberRole: %w", err)
+	}
+	if q.getSlotifyGroupScoringWeightsStmt, err = db.PrepareContext(ctx, getSlotifyGroupScoringWeights); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSlotifyGroupScoringWeights: %w", err)
+	}
+	if q.getSlotifyGroupSuccessorStmt, err = db.PrepareContext(ctx, getSlotifyGroupSuccessor); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSlotifyGroupSuccessor: %w", err)
+	}
 	if q.getUnreadUserNotificationsStmt, err = db.PrepareContext(ctx, getUnreadUserNotifications); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUnreadUserNotifications: %w", err)
 	}
@@ -162,27 +267,105 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserByIDStmt, err = db.PrepareContext(ctx, getUserByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserByID: %w", err)
 	}
+	if q.getUserIdentityStmt, err = db.PrepareContext(ctx, getUserIdentity); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserIdentity: %w", err)
+	}
+	if q.getUserPreferencesStmt, err = db.PrepareContext(ctx, getUserPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserPreferences: %w", err)
+	}
 	if q.getUsersSlotifyGroupsStmt, err = db.PrepareContext(ctx, getUsersSlotifyGroups); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUsersSlotifyGroups: %w", err)
 	}
+	if q.listCalendarEventCacheStmt, err = db.PrepareContext(ctx, listCalendarEventCache); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCalendarEventCache: %w", err)
+	}
+	if q.listCalendarSubscriptionsToRenewStmt, err = db.PrepareContext(ctx, listCalendarSubscriptionsToRenew); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCalendarSubscriptionsToRenew: %w", err)
+	}
+	if q.listCalendarSyncsToRefreshStmt, err = db.PrepareContext(ctx, listCalendarSyncsToRefresh); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCalendarSyncsToRefresh: %w", err)
+	}
+	if q.listDueNotificationOutboxStmt, err = db.PrepareContext(ctx, listDueNotificationOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueNotificationOutbox: %w", err)
+	}
 	if q.listInvitesByGroupStmt, err = db.PrepareContext(ctx, listInvitesByGroup); err != nil {
 		return nil, fmt.Errorf("error preparing query ListInvitesByGroup: %w", err)
 	}
 	if q.listInvitesMeStmt, err = db.PrepareContext(ctx, listInvitesMe); err != nil {
 		return nil, fmt.Errorf("error preparing query ListInvitesMe: %w", err)
 	}
+	if q.listMemberSlotifyGroupNotificationChannelsStmt, err = db.PrepareContext(ctx, listMemberSlotifyGroupNotificationChannels); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMemberSlotifyGroupNotificationChannels: %w", err)
+	}
+	if q.listNotificationPreferencesStmt, err = db.PrepareContext(ctx, listNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNotificationPreferences: %w", err)
+	}
+	if q.listNotificationRecipientsStmt, err = db.PrepareContext(ctx, listNotificationRecipients); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNotificationRecipients: %w", err)
+	}
+	if q.listPendingRescheduleRequestersStmt, err = db.PrepareContext(ctx, listPendingRescheduleRequesters); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingRescheduleRequesters: %w", err)
+	}
+	if q.listPollOptionsStmt, err = db.PrepareContext(ctx, listPollOptions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPollOptions: %w", err)
+	}
+	if q.listPollVotersStmt, err = db.PrepareContext(ctx, listPollVoters); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPollVoters: %w", err)
+	}
+	if q.listPollVotesStmt, err = db.PrepareContext(ctx, listPollVotes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPollVotes: %w", err)
+	}
+	if q.listPollsPastDeadlineStmt, err = db.PrepareContext(ctx, listPollsPastDeadline); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPollsPastDeadline: %w", err)
+	}
+	if q.listSlotifyGroupNotificationPreferencesStmt, err = db.PrepareContext(ctx, listSlotifyGroupNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSlotifyGroupNotificationPreferences: %w", err)
+	}
 	if q.listSlotifyGroupsStmt, err = db.PrepareContext(ctx, listSlotifyGroups); err != nil {
 		return nil, fmt.Errorf("error preparing query ListSlotifyGroups: %w", err)
 	}
+	if q.listUnreadDigestNotificationsStmt, err = db.PrepareContext(ctx, listUnreadDigestNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnreadDigestNotifications: %w", err)
+	}
+	if q.listUserNotificationOutboxStmt, err = db.PrepareContext(ctx, listUserNotificationOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserNotificationOutbox: %w", err)
+	}
+	if q.listUserNotificationsStmt, err = db.PrepareContext(ctx, listUserNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserNotifications: %w", err)
+	}
+	if q.listUserNotificationsAfterIDStmt, err = db.PrepareContext(ctx, listUserNotificationsAfterID); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserNotificationsAfterID: %w", err)
+	}
+	if q.listUserPollsStmt, err = db.PrepareContext(ctx, listUserPolls); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserPolls: %w", err)
+	}
+	if q.lockPollForVoteStmt, err = db.PrepareContext(ctx, lockPollForVote); err != nil {
+		return nil, fmt.Errorf("error preparing query LockPollForVote: %w", err)
+	}
+	if q.markAllNotificationsAsReadStmt, err = db.PrepareContext(ctx, markAllNotificationsAsRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAllNotificationsAsRead: %w", err)
+	}
 	if q.markNotificationAsReadStmt, err = db.PrepareContext(ctx, markNotificationAsRead); err != nil {
 		return nil, fmt.Errorf("error preparing query MarkNotificationAsRead: %w", err)
 	}
+	if q.markNotificationOutboxDeliveredStmt, err = db.PrepareContext(ctx, markNotificationOutboxDelivered); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationOutboxDelivered: %w", err)
+	}
+	if q.releasePollCloseStmt, err = db.PrepareContext(ctx, releasePollClose); err != nil {
+		return nil, fmt.Errorf("error preparing query ReleasePollClose: %w", err)
+	}
 	if q.removeSlotifyGroupStmt, err = db.PrepareContext(ctx, removeSlotifyGroup); err != nil {
 		return nil, fmt.Errorf("error preparing query RemoveSlotifyGroup: %w", err)
 	}
 	if q.removeSlotifyGroupMemberStmt, err = db.PrepareContext(ctx, removeSlotifyGroupMember); err != nil {
 		return nil, fmt.Errorf("error preparing query RemoveSlotifyGroupMember: %w", err)
 	}
+	if q.retryNotificationOutboxStmt, err = db.PrepareContext(ctx, retryNotificationOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query RetryNotificationOutbox: %w", err)
+	}
+	if q.retryPollCloseLaterStmt, err = db.PrepareContext(ctx, retryPollCloseLater); err != nil {
+		return nil, fmt.Errorf("error preparing query RetryPollCloseLater: %w", err)
+	}
 	if q.searchSlotifyGroupMembersByEmailStmt, err = db.PrepareContext(ctx, searchSlotifyGroupMembersByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query SearchSlotifyGroupMembersByEmail: %w", err)
 	}
@@ -195,6 +378,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.searchUsersByNameStmt, err = db.PrepareContext(ctx, searchUsersByName); err != nil {
 		return nil, fmt.Errorf("error preparing query SearchUsersByName: %w", err)
 	}
+	if q.setNotificationOutboxNotificationIDStmt, err = db.PrepareContext(ctx, setNotificationOutboxNotificationID); err != nil {
+		return nil, fmt.Errorf("error preparing query SetNotificationOutboxNotificationID: %w", err)
+	}
+	if q.setPollMeetingStmt, err = db.PrepareContext(ctx, setPollMeeting); err != nil {
+		return nil, fmt.Errorf("error preparing query SetPollMeeting: %w", err)
+	}
+	if q.setSlotifyGroupMemberAttendeeTypeStmt, err = db.PrepareContext(ctx, setSlotifyGroupMemberAttendeeType); err != nil {
+		return nil, fmt.Errorf("error preparing query SetSlotifyGroupMemberAttendeeType: %w", err)
+	}
+	if q.setSlotifyGroupMemberRoleStmt, err = db.PrepareContext(ctx, setSlotifyGroupMemberRole); err != nil {
+		return nil, fmt.Errorf("error preparing query SetSlotifyGroupMemberRole: %w", err)
+	}
+	if q.updateCalendarSubscriptionExpiresAtStmt, err = db.PrepareContext(ctx, updateCalendarSubscriptionExpiresAt); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCalendarSubscriptionExpiresAt: %w", err)
+	}
+	if q.updateCalendarSyncLastReadAtStmt, err = db.PrepareContext(ctx, updateCalendarSyncLastReadAt); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCalendarSyncLastReadAt: %w", err)
+	}
 	if q.updateInviteMessageStmt, err = db.PrepareContext(ctx, updateInviteMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateInviteMessage: %w", err)
 	}
@@ -210,9 +411,45 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateRequestStatusAsRejectedStmt, err = db.PrepareContext(ctx, updateRequestStatusAsRejected); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateRequestStatusAsRejected: %w", err)
 	}
+	if q.updateSlotifyGroupStmt, err = db.PrepareContext(ctx, updateSlotifyGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateSlotifyGroup: %w", err)
+	}
 	if q.updateUserHomeAccountIDStmt, err = db.PrepareContext(ctx, updateUserHomeAccountID); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUserHomeAccountID: %w", err)
 	}
+	if q.updateUserIdentityTokenDataStmt, err = db.PrepareContext(ctx, updateUserIdentityTokenData); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserIdentityTokenData: %w", err)
+	}
+	if q.updateUserTimeZoneStmt, err = db.PrepareContext(ctx, updateUserTimeZone); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserTimeZone: %w", err)
+	}
+	if q.upsertCalendarEventCacheStmt, err = db.PrepareContext(ctx, upsertCalendarEventCache); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCalendarEventCache: %w", err)
+	}
+	if q.upsertCalendarSubscriptionStmt, err = db.PrepareContext(ctx, upsertCalendarSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCalendarSubscription: %w", err)
+	}
+	if q.upsertCalendarSyncStmt, err = db.PrepareContext(ctx, upsertCalendarSync); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCalendarSync: %w", err)
+	}
+	if q.upsertMSALTokenCacheStmt, err = db.PrepareContext(ctx, upsertMSALTokenCache); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertMSALTokenCache: %w", err)
+	}
+	if q.upsertNotificationQuietHoursStmt, err = db.PrepareContext(ctx, upsertNotificationQuietHours); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertNotificationQuietHours: %w", err)
+	}
+	if q.upsertPollVoteStmt, err = db.PrepareContext(ctx, upsertPollVote); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertPollVote: %w", err)
+	}
+	if q.upsertSlotifyGroupScoringWeightsStmt, err = db.PrepareContext(ctx, upsertSlotifyGroupScoringWeights); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertSlotifyGroupScoringWeights: %w", err)
+	}
+	if q.upsertUserIdentityStmt, err = db.PrepareContext(ctx, upsertUserIdentity); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertUserIdentity: %w", err)
+	}
+	if q.upsertUserPreferencesStmt, err = db.PrepareContext(ctx, upsertUserPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertUserPreferences: %w", err)
+	}
 	return &q, nil
 }
 
@@ -228,6 +465,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addUserToSlotifyGroupStmt: %w", cerr)
 		}
 	}
+	if q.batchDeleteIdleCalendarSyncsStmt != nil {
+		if cerr := q.batchDeleteIdleCalendarSyncsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing batchDeleteIdleCalendarSyncsStmt: %w", cerr)
+		}
+	}
 	if q.batchDeleteWeekOldInvitesStmt != nil {
 		if cerr := q.batchDeleteWeekOldInvitesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing batchDeleteWeekOldInvitesStmt: %w", cerr)
@@ -248,6 +490,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing checkMemberInSlotifyGroupStmt: %w", cerr)
 		}
 	}
+	if q.checkPollVoterStmt != nil {
+		if cerr := q.checkPollVoterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing checkPollVoterStmt: %w", cerr)
+		}
+	}
+	if q.claimNotificationDigestStmt != nil {
+		if cerr := q.claimNotificationDigestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimNotificationDigestStmt: %w", cerr)
+		}
+	}
+	if q.claimNotificationOutboxStmt != nil {
+		if cerr := q.claimNotificationOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimNotificationOutboxStmt: %w", cerr)
+		}
+	}
+	if q.claimPollCloseStmt != nil {
+		if cerr := q.claimPollCloseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimPollCloseStmt: %w", cerr)
+		}
+	}
+	if q.closePollStmt != nil {
+		if cerr := q.closePollStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closePollStmt: %w", cerr)
+		}
+	}
 	if q.countExpiredInvitesStmt != nil {
 		if cerr := q.countExpiredInvitesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countExpiredInvitesStmt: %w", cerr)
@@ -263,6 +530,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countSlotifyGroupMembersStmt: %w", cerr)
 		}
 	}
+	if q.countUnreadUserNotificationsStmt != nil {
+		if cerr := q.countUnreadUserNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadUserNotificationsStmt: %w", cerr)
+		}
+	}
 	if q.countUserByEmailStmt != nil {
 		if cerr := q.countUserByEmailStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countUserByEmailStmt: %w", cerr)
@@ -303,6 +575,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createNotificationStmt: %w", cerr)
 		}
 	}
+	if q.createNotificationDigestStmt != nil {
+		if cerr := q.createNotificationDigestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNotificationDigestStmt: %w", cerr)
+		}
+	}
+	if q.createNotificationOutboxStmt != nil {
+		if cerr := q.createNotificationOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNotificationOutboxStmt: %w", cerr)
+		}
+	}
+	if q.createNotificationPreferenceStmt != nil {
+		if cerr := q.createNotificationPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNotificationPreferenceStmt: %w", cerr)
+		}
+	}
 	if q.createPlaceholderMeetingStmt != nil {
 		if cerr := q.createPlaceholderMeetingStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createPlaceholderMeetingStmt: %w", cerr)
@@ -313,6 +600,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createPlaceholderMeetingAttendeeStmt: %w", cerr)
 		}
 	}
+	if q.createPollStmt != nil {
+		if cerr := q.createPollStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPollStmt: %w", cerr)
+		}
+	}
+	if q.createPollOptionStmt != nil {
+		if cerr := q.createPollOptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPollOptionStmt: %w", cerr)
+		}
+	}
+	if q.createPollVoterStmt != nil {
+		if cerr := q.createPollVoterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPollVoterStmt: %w", cerr)
+		}
+	}
 	if q.createRefreshTokenStmt != nil {
 		if cerr := q.createRefreshTokenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createRefreshTokenStmt: %w", cerr)
@@ -328,6 +630,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createReschedulingRequestStmt: %w", cerr)
 		}
 	}
+	if q.createSlotifyGroupNotificationPreferenceStmt != nil {
+		if cerr := q.createSlotifyGroupNotificationPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSlotifyGroupNotificationPreferenceStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
@@ -338,11 +645,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createUserNotificationStmt: %w", cerr)
 		}
 	}
+	if q.deleteCalendarEventCacheStmt != nil {
+		if cerr := q.deleteCalendarEventCacheStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCalendarEventCacheStmt: %w", cerr)
+		}
+	}
+	if q.deleteCalendarEventCacheEventStmt != nil {
+		if cerr := q.deleteCalendarEventCacheEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCalendarEventCacheEventStmt: %w", cerr)
+		}
+	}
+	if q.deleteCalendarSubscriptionStmt != nil {
+		if cerr := q.deleteCalendarSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCalendarSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.deleteDeliveredNotificationOutboxStmt != nil {
+		if cerr := q.deleteDeliveredNotificationOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteDeliveredNotificationOutboxStmt: %w", cerr)
+		}
+	}
 	if q.deleteInviteByIDStmt != nil {
 		if cerr := q.deleteInviteByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteInviteByIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteNotificationPreferencesStmt != nil {
+		if cerr := q.deleteNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNotificationPreferencesStmt: %w", cerr)
+		}
+	}
+	if q.deleteNotificationQuietHoursStmt != nil {
+		if cerr := q.deleteNotificationQuietHoursStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNotificationQuietHoursStmt: %w", cerr)
+		}
+	}
 	if q.deleteRefreshTokenByUserIDStmt != nil {
 		if cerr := q.deleteRefreshTokenByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteRefreshTokenByUserIDStmt: %w", cerr)
@@ -358,11 +695,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteSlotifyGroupByIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteSlotifyGroupNotificationPreferencesStmt != nil {
+		if cerr := q.deleteSlotifyGroupNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSlotifyGroupNotificationPreferencesStmt: %w", cerr)
+		}
+	}
 	if q.deleteUserByIDStmt != nil {
 		if cerr := q.deleteUserByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteUserByIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteUserNotificationStmt != nil {
+		if cerr := q.deleteUserNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserNotificationStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserPreferencesStmt != nil {
+		if cerr := q.deleteUserPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserPreferencesStmt: %w", cerr)
+		}
+	}
+	if q.expireCalendarSyncStmt != nil {
+		if cerr := q.expireCalendarSyncStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing expireCalendarSyncStmt: %w", cerr)
+		}
+	}
 	if q.getAllRequestsForOwnerStmt != nil {
 		if cerr := q.getAllRequestsForOwnerStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAllRequestsForOwnerStmt: %w", cerr)
@@ -383,11 +740,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAllSlotifyGroupMembersExceptStmt: %w", cerr)
 		}
 	}
+	if q.getCalendarSubscriptionStmt != nil {
+		if cerr := q.getCalendarSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCalendarSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.getCalendarSubscriptionByUserIDStmt != nil {
+		if cerr := q.getCalendarSubscriptionByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCalendarSubscriptionByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getCalendarSyncStmt != nil {
+		if cerr := q.getCalendarSyncStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCalendarSyncStmt: %w", cerr)
+		}
+	}
 	if q.getInviteByIDStmt != nil {
 		if cerr := q.getInviteByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getInviteByIDStmt: %w", cerr)
 		}
 	}
+	if q.getMSALTokenCacheStmt != nil {
+		if cerr := q.getMSALTokenCacheStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMSALTokenCacheStmt: %w", cerr)
+		}
+	}
 	if q.getMeetingByIDStmt != nil {
 		if cerr := q.getMeetingByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMeetingByIDStmt: %w", cerr)
@@ -408,6 +785,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getMeetingPreferencesStmt: %w", cerr)
 		}
 	}
+	if q.getNotificationByIDStmt != nil {
+		if cerr := q.getNotificationByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationByIDStmt: %w", cerr)
+		}
+	}
+	if q.getNotificationPreferenceChannelStmt != nil {
+		if cerr := q.getNotificationPreferenceChannelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationPreferenceChannelStmt: %w", cerr)
+		}
+	}
+	if q.getNotificationQuietHoursStmt != nil {
+		if cerr := q.getNotificationQuietHoursStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationQuietHoursStmt: %w", cerr)
+		}
+	}
 	if q.getOnlyRequestByIDStmt != nil {
 		if cerr := q.getOnlyRequestByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOnlyRequestByIDStmt: %w", cerr)
@@ -418,6 +810,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getPlaceholderMeetingAttendeesByMeetingIDStmt: %w", cerr)
 		}
 	}
+	if q.getPollByIDStmt != nil {
+		if cerr := q.getPollByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPollByIDStmt: %w", cerr)
+		}
+	}
 	if q.getRefreshTokenByUserIDStmt != nil {
 		if cerr := q.getRefreshTokenByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getRefreshTokenByUserIDStmt: %w", cerr)
@@ -433,6 +830,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getSlotifyGroupByIDStmt: %w", cerr)
 		}
 	}
+	if q.getSlotifyGroupMemberRoleStmt != nil {
+		if cerr := q.getSlotifyGroupMemberRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSlotifyGroupMemberRoleStmt: %w", cerr)
+		}
+	}
+	if q.getSlotifyGroupScoringWeightsStmt != nil {
+		if cerr := q.getSlotifyGroupScoringWeightsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSlotifyGroupScoringWeightsStmt: %w", cerr)
+		}
+	}
+	if q.getSlotifyGroupSuccessorStmt != nil {
+		if cerr := q.getSlotifyGroupSuccessorStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSlotifyGroupSuccessorStmt: %w", cerr)
+		}
+	}
 	if q.getUnreadUserNotificationsStmt != nil {
 		if cerr := q.getUnreadUserNotificationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUnreadUserNotificationsStmt: %w", cerr)
@@ -448,11 +860,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserByIDStmt: %w", cerr)
 		}
 	}
+	if q.getUserIdentityStmt != nil {
+		if cerr := q.getUserIdentityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserIdentityStmt: %w", cerr)
+		}
+	}
+	if q.getUserPreferencesStmt != nil {
+		if cerr := q.getUserPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserPreferencesStmt: %w", cerr)
+		}
+	}
 	if q.getUsersSlotifyGroupsStmt != nil {
 		if cerr := q.getUsersSlotifyGroupsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUsersSlotifyGroupsStmt: %w", cerr)
 		}
 	}
+	if q.listCalendarEventCacheStmt != nil {
+		if cerr := q.listCalendarEventCacheStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCalendarEventCacheStmt: %w", cerr)
+		}
+	}
+	if q.listCalendarSubscriptionsToRenewStmt != nil {
+		if cerr := q.listCalendarSubscriptionsToRenewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCalendarSubscriptionsToRenewStmt: %w", cerr)
+		}
+	}
+	if q.listCalendarSyncsToRefreshStmt != nil {
+		if cerr := q.listCalendarSyncsToRefreshStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCalendarSyncsToRefreshStmt: %w", cerr)
+		}
+	}
+	if q.listDueNotificationOutboxStmt != nil {
+		if cerr := q.listDueNotificationOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueNotificationOutboxStmt: %w", cerr)
+		}
+	}
 	if q.listInvitesByGroupStmt != nil {
 		if cerr := q.listInvitesByGroupStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listInvitesByGroupStmt: %w", cerr)
@@ -463,16 +905,106 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listInvitesMeStmt: %w", cerr)
 		}
 	}
+	if q.listMemberSlotifyGroupNotificationChannelsStmt != nil {
+		if cerr := q.listMemberSlotifyGroupNotificationChannelsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMemberSlotifyGroupNotificationChannelsStmt: %w", cerr)
+		}
+	}
+	if q.listNotificationPreferencesStmt != nil {
+		if cerr := q.listNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNotificationPreferencesStmt: %w", cerr)
+		}
+	}
+	if q.listNotificationRecipientsStmt != nil {
+		if cerr := q.listNotificationRecipientsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNotificationRecipientsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingRescheduleRequestersStmt != nil {
+		if cerr := q.listPendingRescheduleRequestersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingRescheduleRequestersStmt: %w", cerr)
+		}
+	}
+	if q.listPollOptionsStmt != nil {
+		if cerr := q.listPollOptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPollOptionsStmt: %w", cerr)
+		}
+	}
+	if q.listPollVotersStmt != nil {
+		if cerr := q.listPollVotersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPollVotersStmt: %w", cerr)
+		}
+	}
+	if q.listPollVotesStmt != nil {
+		if cerr := q.listPollVotesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPollVotesStmt: %w", cerr)
+		}
+	}
+	if q.listPollsPastDeadlineStmt != nil {
+		if cerr := q.listPollsPastDeadlineStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPollsPastDeadlineStmt: %w", cerr)
+		}
+	}
+	if q.listSlotifyGroupNotificationPreferencesStmt != nil {
+		if cerr := q.listSlotifyGroupNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSlotifyGroupNotificationPreferencesStmt: %w", cerr)
+		}
+	}
 	if q.listSlotifyGroupsStmt != nil {
 		if cerr := q.listSlotifyGroupsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listSlotifyGroupsStmt: %w", cerr)
 		}
 	}
+	if q.listUnreadDigestNotificationsStmt != nil {
+		if cerr := q.listUnreadDigestNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnreadDigestNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.listUserNotificationOutboxStmt != nil {
+		if cerr := q.listUserNotificationOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserNotificationOutboxStmt: %w", cerr)
+		}
+	}
+	if q.listUserNotificationsStmt != nil {
+		if cerr := q.listUserNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.listUserNotificationsAfterIDStmt != nil {
+		if cerr := q.listUserNotificationsAfterIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserNotificationsAfterIDStmt: %w", cerr)
+		}
+	}
+	if q.listUserPollsStmt != nil {
+		if cerr := q.listUserPollsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserPollsStmt: %w", cerr)
+		}
+	}
+	if q.lockPollForVoteStmt != nil {
+		if cerr := q.lockPollForVoteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockPollForVoteStmt: %w", cerr)
+		}
+	}
+	if q.markAllNotificationsAsReadStmt != nil {
+		if cerr := q.markAllNotificationsAsReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAllNotificationsAsReadStmt: %w", cerr)
+		}
+	}
 	if q.markNotificationAsReadStmt != nil {
 		if cerr := q.markNotificationAsReadStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing markNotificationAsReadStmt: %w", cerr)
 		}
 	}
+	if q.markNotificationOutboxDeliveredStmt != nil {
+		if cerr := q.markNotificationOutboxDeliveredStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationOutboxDeliveredStmt: %w", cerr)
+		}
+	}
+	if q.releasePollCloseStmt != nil {
+		if cerr := q.releasePollCloseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing releasePollCloseStmt: %w", cerr)
+		}
+	}
 	if q.removeSlotifyGroupStmt != nil {
 		if cerr := q.removeSlotifyGroupStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing removeSlotifyGroupStmt: %w", cerr)
@@ -483,6 +1015,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing removeSlotifyGroupMemberStmt: %w", cerr)
 		}
 	}
+	if q.retryNotificationOutboxStmt != nil {
+		if cerr := q.retryNotificationOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retryNotificationOutboxStmt: %w", cerr)
+		}
+	}
+	if q.retryPollCloseLaterStmt != nil {
+		if cerr := q.retryPollCloseLaterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retryPollCloseLaterStmt: %w", cerr)
+		}
+	}
 	if q.searchSlotifyGroupMembersByEmailStmt != nil {
 		if cerr := q.searchSlotifyGroupMembersByEmailStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing searchSlotifyGroupMembersByEmailStmt: %w", cerr)
@@ -503,6 +1045,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing searchUsersByNameStmt: %w", cerr)
 		}
 	}
+	if q.setNotificationOutboxNotificationIDStmt != nil {
+		if cerr := q.setNotificationOutboxNotificationIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setNotificationOutboxNotificationIDStmt: %w", cerr)
+		}
+	}
+	if q.setPollMeetingStmt != nil {
+		if cerr := q.setPollMeetingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setPollMeetingStmt: %w", cerr)
+		}
+	}
+	if q.setSlotifyGroupMemberAttendeeTypeStmt != nil {
+		if cerr := q.setSlotifyGroupMemberAttendeeTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setSlotifyGroupMemberAttendeeTypeStmt: %w", cerr)
+		}
+	}
+	if q.setSlotifyGroupMemberRoleStmt != nil {
+		if cerr := q.setSlotifyGroupMemberRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setSlotifyGroupMemberRoleStmt: %w", cerr)
+		}
+	}
+	if q.updateCalendarSubscriptionExpiresAtStmt != nil {
+		if cerr := q.updateCalendarSubscriptionExpiresAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCalendarSubscriptionExpiresAtStmt: %w", cerr)
+		}
+	}
+	if q.updateCalendarSyncLastReadAtStmt != nil {
+		if cerr := q.updateCalendarSyncLastReadAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCalendarSyncLastReadAtStmt: %w", cerr)
+		}
+	}
 	if q.updateInviteMessageStmt != nil {
 		if cerr := q.updateInviteMessageStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateInviteMessageStmt: %w", cerr)
@@ -528,11 +1100,71 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateRequestStatusAsRejectedStmt: %w", cerr)
 		}
 	}
+	if q.updateSlotifyGroupStmt != nil {
+		if cerr := q.updateSlotifyGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateSlotifyGroupStmt: %w", cerr)
+		}
+	}
 	if q.updateUserHomeAccountIDStmt != nil {
 		if cerr := q.updateUserHomeAccountIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserHomeAccountIDStmt: %w", cerr)
 		}
 	}
+	if q.updateUserIdentityTokenDataStmt != nil {
+		if cerr := q.updateUserIdentityTokenDataStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserIdentityTokenDataStmt: %w", cerr)
+		}
+	}
+	if q.updateUserTimeZoneStmt != nil {
+		if cerr := q.updateUserTimeZoneStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserTimeZoneStmt: %w", cerr)
+		}
+	}
+	if q.upsertCalendarEventCacheStmt != nil {
+		if cerr := q.upsertCalendarEventCacheStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCalendarEventCacheStmt: %w", cerr)
+		}
+	}
+	if q.upsertCalendarSubscriptionStmt != nil {
+		if cerr := q.upsertCalendarSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCalendarSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.upsertCalendarSyncStmt != nil {
+		if cerr := q.upsertCalendarSyncStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCalendarSyncStmt: %w", cerr)
+		}
+	}
+	if q.upsertMSALTokenCacheStmt != nil {
+		if cerr := q.upsertMSALTokenCacheStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertMSALTokenCacheStmt: %w", cerr)
+		}
+	}
+	if q.upsertNotificationQuietHoursStmt != nil {
+		if cerr := q.upsertNotificationQuietHoursStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertNotificationQuietHoursStmt: %w", cerr)
+		}
+	}
+	if q.upsertPollVoteStmt != nil {
+		if cerr := q.upsertPollVoteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertPollVoteStmt: %w", cerr)
+		}
+	}
+	if q.upsertSlotifyGroupScoringWeightsStmt != nil {
+		if cerr := q.upsertSlotifyGroupScoringWeightsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertSlotifyGroupScoringWeightsStmt: %w", cerr)
+		}
+	}
+	if q.upsertUserIdentityStmt != nil {
+		if cerr := q.upsertUserIdentityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertUserIdentityStmt: %w", cerr)
+		}
+	}
+	if q.upsertUserPreferencesStmt != nil {
+		if cerr := q.upsertUserPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertUserPreferencesStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -570,139 +1202,297 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                            DBTX
-	tx                                            *sql.Tx
-	addSlotifyGroupStmt                           *sql.Stmt
-	addUserToSlotifyGroupStmt                     *sql.Stmt
-	batchDeleteWeekOldInvitesStmt                 *sql.Stmt
-	batchDeleteWeekOldNotificationsStmt           *sql.Stmt
-	batchExpireInvitesStmt                        *sql.Stmt
-	checkMemberInSlotifyGroupStmt                 *sql.Stmt
-	countExpiredInvitesStmt                       *sql.Stmt
-	countSlotifyGroupByIDStmt                     *sql.Stmt
-	countSlotifyGroupMembersStmt                  *sql.Stmt
-	countUserByEmailStmt                          *sql.Stmt
-	countUserByIDStmt                             *sql.Stmt
-	countWeekOldInvitesStmt                       *sql.Stmt
-	countWeekOldNotificationsStmt                 *sql.Stmt
-	createInviteStmt                              *sql.Stmt
-	createMeetingStmt                             *sql.Stmt
-	createMeetingPreferencesStmt                  *sql.Stmt
-	createNotificationStmt                        *sql.Stmt
-	createPlaceholderMeetingStmt                  *sql.Stmt
-	createPlaceholderMeetingAttendeeStmt          *sql.Stmt
-	createRefreshTokenStmt                        *sql.Stmt
-	createRequestToMeetingStmt                    *sql.Stmt
-	createReschedulingRequestStmt                 *sql.Stmt
-	createUserStmt                                *sql.Stmt
-	createUserNotificationStmt                    *sql.Stmt
-	deleteInviteByIDStmt                          *sql.Stmt
-	deleteRefreshTokenByUserIDStmt                *sql.Stmt
-	deleteRequestStmt                             *sql.Stmt
-	deleteSlotifyGroupByIDStmt                    *sql.Stmt
-	deleteUserByIDStmt                            *sql.Stmt
-	getAllRequestsForOwnerStmt                    *sql.Stmt
-	getAllRequestsResponsesForUserIDStmt          *sql.Stmt
-	getAllSlotifyGroupMembersStmt                 *sql.Stmt
-	getAllSlotifyGroupMembersExceptStmt           *sql.Stmt
-	getInviteByIDStmt                             *sql.Stmt
-	getMeetingByIDStmt                            *sql.Stmt
-	getMeetingByMSFTIDStmt                        *sql.Stmt
-	getMeetingIDFromRequestIDStmt                 *sql.Stmt
-	getMeetingPreferencesStmt                     *sql.Stmt
-	getOnlyRequestByIDStmt                        *sql.Stmt
-	getPlaceholderMeetingAttendeesByMeetingIDStmt *sql.Stmt
-	getRefreshTokenByUserIDStmt                   *sql.Stmt
-	getRequestByIDStmt                            *sql.Stmt
-	getSlotifyGroupByIDStmt                       *sql.Stmt
-	getUnreadUserNotificationsStmt                *sql.Stmt
-	getUserByEmailStmt                            *sql.Stmt
-	getUserByIDStmt                               *sql.Stmt
-	getUsersSlotifyGroupsStmt                     *sql.Stmt
-	listInvitesByGroupStmt                        *sql.Stmt
-	listInvitesMeStmt                             *sql.Stmt
-	listSlotifyGroupsStmt                         *sql.Stmt
-	markNotificationAsReadStmt                    *sql.Stmt
-	removeSlotifyGroupStmt                        *sql.Stmt
-	removeSlotifyGroupMemberStmt                  *sql.Stmt
-	searchSlotifyGroupMembersByEmailStmt          *sql.Stmt
-	searchSlotifyGroupMembersByNameStmt           *sql.Stmt
-	searchUsersByEmailStmt                        *sql.Stmt
-	searchUsersByNameStmt                         *sql.Stmt
-	updateInviteMessageStmt                       *sql.Stmt
-	updateInviteStatusStmt                        *sql.Stmt
-	updateMeetingStartTimeStmt                    *sql.Stmt
-	updateRequestStatusAsAcceptedStmt             *sql.Stmt
-	updateRequestStatusAsRejectedStmt             *sql.Stmt
-	updateUserHomeAccountIDStmt                   *sql.Stmt
+	db                                             DBTX
+	tx                                             *sql.Tx
+	addSlotifyGroupStmt                            *sql.Stmt
+	addUserToSlotifyGroupStmt                      *sql.Stmt
+	batchDeleteIdleCalendarSyncsStmt               *sql.Stmt
+	batchDeleteWeekOldInvitesStmt                  *sql.Stmt
+	batchDeleteWeekOldNotificationsStmt            *sql.Stmt
+	batchExpireInvitesStmt                         *sql.Stmt
+	checkMemberInSlotifyGroupStmt                  *sql.Stmt
+	checkPollVoterStmt                             *sql.Stmt
+	claimNotificationDigestStmt                    *sql.Stmt
+	claimNotificationOutboxStmt                    *sql.Stmt
+	claimPollCloseStmt                             *sql.Stmt
+	closePollStmt                                  *sql.Stmt
+	countExpiredInvitesStmt                        *sql.Stmt
+	countSlotifyGroupByIDStmt                      *sql.Stmt
+	countSlotifyGroupMembersStmt                   *sql.Stmt
+	countUnreadUserNotificationsStmt               *sql.Stmt
+	countUserByEmailStmt                           *sql.Stmt
+	countUserByIDStmt                              *sql.Stmt
+	countWeekOldInvitesStmt                        *sql.Stmt
+	countWeekOldNotificationsStmt                  *sql.Stmt
+	createInviteStmt                               *sql.Stmt
+	createMeetingStmt                              *sql.Stmt
+	createMeetingPreferencesStmt                   *sql.Stmt
+	createNotificationStmt                         *sql.Stmt
+	createNotificationDigestStmt                   *sql.Stmt
+	createNotificationOutboxStmt                   *sql.Stmt
+	createNotificationPreferenceStmt               *sql.Stmt
+	createPlaceholderMeetingStmt                   *sql.Stmt
+	createPlaceholderMeetingAttendeeStmt           *sql.Stmt
+	createPollStmt                                 *sql.Stmt
+	createPollOptionStmt                           *sql.Stmt
+	createPollVoterStmt                            *sql.Stmt
+	createRefreshTokenStmt                         *sql.Stmt
+	createRequestToMeetingStmt                     *sql.Stmt
+	createReschedulingRequestStmt                  *sql.Stmt
+	createSlotifyGroupNotificationPreferenceStmt   *sql.Stmt
+	createUserStmt                                 *sql.Stmt
+	createUserNotificationStmt                     *sql.Stmt
+	deleteCalendarEventCacheStmt                   *sql.Stmt
+	deleteCalendarEventCacheEventStmt              *sql.Stmt
+	deleteCalendarSubscriptionStmt                 *sql.Stmt
+	deleteDeliveredNotificationOutboxStmt          *sql.Stmt
+	deleteInviteByIDStmt                           *sql.Stmt
+	deleteNotificationPreferencesStmt              *sql.Stmt
+	deleteNotificationQuietHoursStmt               *sql.Stmt
+	deleteRefreshTokenByUserIDStmt                 *sql.Stmt
+	deleteRequestStmt                              *sql.Stmt
+	deleteSlotifyGroupByIDStmt                     *sql.Stmt
+	deleteSlotifyGroupNotificationPreferencesStmt  *sql.Stmt
+	deleteUserByIDStmt                             *sql.Stmt
+	deleteUserNotificationStmt                     *sql.Stmt
+	deleteUserPreferencesStmt                      *sql.Stmt
+	expireCalendarSyncStmt                         *sql.Stmt
+	getAllRequestsForOwnerStmt                     *sql.Stmt
+	getAllRequestsResponsesForUserIDStmt           *sql.Stmt
+	getAllSlotifyGroupMembersStmt                  *sql.Stmt
+	getAllSlotifyGroupMembersExceptStmt            *sql.Stmt
+	getCalendarSubscriptionStmt                    *sql.Stmt
+	getCalendarSubscriptionByUserIDStmt            *sql.Stmt
+	getCalendarSyncStmt                            *sql.Stmt
+	getInviteByIDStmt                              *sql.Stmt
+	getMSALTokenCacheStmt                          *sql.Stmt
+	getMeetingByIDStmt                             *sql.Stmt
+	getMeetingByMSFTIDStmt                         *sql.Stmt
+	getMeetingIDFromRequestIDStmt                  *sql.Stmt
+	getMeetingPreferencesStmt                      *sql.Stmt
+	getNotificationByIDStmt                        *sql.Stmt
+	getNotificationPreferenceChannelStmt           *sql.Stmt
+	getNotificationQuietHoursStmt                  *sql.Stmt
+	getOnlyRequestByIDStmt                         *sql.Stmt
+	getPlaceholderMeetingAttendeesByMeetingIDStmt  *sql.Stmt
+	getPollByIDStmt                                *sql.Stmt
+	getRefreshTokenByUserIDStmt                    *sql.Stmt
+	getRequestByIDStmt                             *sql.Stmt
+	getSlotifyGroupByIDStmt                        *sql.Stmt
+	getSlotifyGroupMemberRoleStmt                  *sql.Stmt
+	getSlotifyGroupScoringWeightsStmt              *sql.Stmt
+	getSlotifyGroupSuccessorStmt                   *sql.Stmt
+	getUnreadUserNotificationsStmt                 *sql.Stmt
+	getUserByEmailStmt                             *sql.Stmt
+	getUserByIDStmt                                *sql.Stmt
+	getUserIdentityStmt                            *sql.Stmt
+	getUserPreferencesStmt                         *sql.Stmt
+	getUsersSlotifyGroupsStmt                      *sql.Stmt
+	listCalendarEventCacheStmt                     *sql.Stmt
+	listCalendarSubscriptionsToRenewStmt           *sql.Stmt
+	listCalendarSyncsToRefreshStmt                 *sql.Stmt
+	listDueNotificationOutboxStmt                  *sql.Stmt
+	listInvitesByGroupStmt                         *sql.Stmt
+	listInvitesMeStmt                              *sql.Stmt
+	listMemberSlotifyGroupNotificationChannelsStmt *sql.Stmt
+	listNotificationPreferencesStmt                *sql.Stmt
+	listNotificationRecipientsStmt                 *sql.Stmt
+	listPendingRescheduleRequestersStmt            *sql.Stmt
+	listPollOptionsStmt                            *sql.Stmt
+	listPollVotersStmt                             *sql.Stmt
+	listPollVotesStmt                              *sql.Stmt
+	listPollsPastDeadlineStmt                      *sql.Stmt
+	listSlotifyGroupNotificationPreferencesStmt    *sql.Stmt
+	listSlotifyGroupsStmt                          *sql.Stmt
+	listUnreadDigestNotificationsStmt              *sql.Stmt
+	listUserNotificationOutboxStmt                 *sql.Stmt
+	listUserNotificationsStmt                      *sql.Stmt
+	listUserNotificationsAfterIDStmt               *sql.Stmt
+	listUserPollsStmt                              *sql.Stmt
+	lockPollForVoteStmt                            *sql.Stmt
+	markAllNotificationsAsReadStmt                 *sql.Stmt
+	markNotificationAsReadStmt                     *sql.Stmt
+	markNotificationOutboxDeliveredStmt            *sql.Stmt
+	releasePollCloseStmt                           *sql.Stmt
+	removeSlotifyGroupStmt                         *sql.Stmt
+	removeSlotifyGroupMemberStmt                   *sql.Stmt
+	retryNotificationOutboxStmt                    *sql.Stmt
+	retryPollCloseLaterStmt                        *sql.Stmt
+	searchSlotifyGroupMembersByEmailStmt           *sql.Stmt
+	searchSlotifyGroupMembersByNameStmt            *sql.Stmt
+	searchUsersByEmailStmt                         *sql.Stmt
+	searchUsersByNameStmt                          *sql.Stmt
+	setNotificationOutboxNotificationIDStmt        *sql.Stmt
+	setPollMeetingStmt                             *sql.Stmt
+	setSlotifyGroupMemberAttendeeTypeStmt          *sql.Stmt
+	setSlotifyGroupMemberRoleStmt                  *sql.Stmt
+	updateCalendarSubscriptionExpiresAtStmt        *sql.Stmt
+	updateCalendarSyncLastReadAtStmt               *sql.Stmt
+	updateInviteMessageStmt                        *sql.Stmt
+	updateInviteStatusStmt                         *sql.Stmt
+	updateMeetingStartTimeStmt                     *sql.Stmt
+	updateRequestStatusAsAcceptedStmt              *sql.Stmt
+	updateRequestStatusAsRejectedStmt              *sql.Stmt
+	updateSlotifyGroupStmt                         *sql.Stmt
+	updateUserHomeAccountIDStmt                    *sql.Stmt
+	updateUserIdentityTokenDataStmt                *sql.Stmt
+	updateUserTimeZoneStmt                         *sql.Stmt
+	upsertCalendarEventCacheStmt                   *sql.Stmt
+	upsertCalendarSubscriptionStmt                 *sql.Stmt
+	upsertCalendarSyncStmt                         *sql.Stmt
+	upsertMSALTokenCacheStmt                       *sql.Stmt
+	upsertNotificationQuietHoursStmt               *sql.Stmt
+	upsertPollVoteStmt                             *sql.Stmt
+	upsertSlotifyGroupScoringWeightsStmt           *sql.Stmt
+	upsertUserIdentityStmt                         *sql.Stmt
+	upsertUserPreferencesStmt                      *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                            tx,
-		tx:                                            tx,
-		addSlotifyGroupStmt:                           q.addSlotifyGroupStmt,
-		addUserToSlotifyGroupStmt:                     q.addUserToSlotifyGroupStmt,
-		batchDeleteWeekOldInvitesStmt:                 q.batchDeleteWeekOldInvitesStmt,
-		batchDeleteWeekOldNotificationsStmt:           q.batchDeleteWeekOldNotificationsStmt,
-		batchExpireInvitesStmt:                        q.batchExpireInvitesStmt,
-		checkMemberInSlotifyGroupStmt:                 q.checkMemberInSlotifyGroupStmt,
-		countExpiredInvitesStmt:                       q.countExpiredInvitesStmt,
-		countSlotifyGroupByIDStmt:                     q.countSlotifyGroupByIDStmt,
-		countSlotifyGroupMembersStmt:                  q.countSlotifyGroupMembersStmt,
-		countUserByEmailStmt:                          q.countUserByEmailStmt,
-		countUserByIDStmt:                             q.countUserByIDStmt,
-		countWeekOldInvitesStmt:                       q.countWeekOldInvitesStmt,
-		countWeekOldNotificationsStmt:                 q.countWeekOldNotificationsStmt,
-		createInviteStmt:                              q.createInviteStmt,
-		createMeetingStmt:                             q.createMeetingStmt,
-		createMeetingPreferencesStmt:                  q.createMeetingPreferencesStmt,
-		createNotificationStmt:                        q.createNotificationStmt,
-		createPlaceholderMeetingStmt:                  q.createPlaceholderMeetingStmt,
-		createPlaceholderMeetingAttendeeStmt:          q.createPlaceholderMeetingAttendeeStmt,
-		createRefreshTokenStmt:                        q.createRefreshTokenStmt,
-		createRequestToMeetingStmt:                    q.createRequestToMeetingStmt,
-		createReschedulingRequestStmt:                 q.createReschedulingRequestStmt,
-		createUserStmt:                                q.createUserStmt,
-		createUserNotificationStmt:                    q.createUserNotificationStmt,
-		deleteInviteByIDStmt:                          q.deleteInviteByIDStmt,
-		deleteRefreshTokenByUserIDStmt:                q.deleteRefreshTokenByUserIDStmt,
-		deleteRequestStmt:                             q.deleteRequestStmt,
-		deleteSlotifyGroupByIDStmt:                    q.deleteSlotifyGroupByIDStmt,
-		deleteUserByIDStmt:                            q.deleteUserByIDStmt,
-		getAllRequestsForOwnerStmt:                    q.getAllRequestsForOwnerStmt,
-		getAllRequestsResponsesForUserIDStmt:          q.getAllRequestsResponsesForUserIDStmt,
-		getAllSlotifyGroupMembersStmt:                 q.getAllSlotifyGroupMembersStmt,
-		getAllSlotifyGroupMembersExceptStmt:           q.getAllSlotifyGroupMembersExceptStmt,
-		getInviteByIDStmt:                             q.getInviteByIDStmt,
-		getMeetingByIDStmt:                            q.getMeetingByIDStmt,
-		getMeetingByMSFTIDStmt:                        q.getMeetingByMSFTIDStmt,
-		getMeetingIDFromRequestIDStmt:                 q.getMeetingIDFromRequestIDStmt,
-		getMeetingPreferencesStmt:                     q.getMeetingPreferencesStmt,
-		getOnlyRequestByIDStmt:                        q.getOnlyRequestByIDStmt,
-		getPlaceholderMeetingAttendeesByMeetingIDStmt: q.getPlaceholderMeetingAttendeesByMeetingIDStmt,
-		getRefreshTokenByUserIDStmt:                   q.getRefreshTokenByUserIDStmt,
-		getRequestByIDStmt:                            q.getRequestByIDStmt,
-		getSlotifyGroupByIDStmt:                       q.getSlotifyGroupByIDStmt,
-		getUnreadUserNotificationsStmt:                q.getUnreadUserNotificationsStmt,
-		getUserByEmailStmt:                            q.getUserByEmailStmt,
-		getUserByIDStmt:                               q.getUserByIDStmt,
-		getUsersSlotifyGroupsStmt:                     q.getUsersSlotifyGroupsStmt,
-		listInvitesByGroupStmt:                        q.listInvitesByGroupStmt,
-		listInvitesMeStmt:                             q.listInvitesMeStmt,
-		listSlotifyGroupsStmt:                         q.listSlotifyGroupsStmt,
-		markNotificationAsReadStmt:                    q.markNotificationAsReadStmt,
-		removeSlotifyGroupStmt:                        q.removeSlotifyGroupStmt,
-		removeSlotifyGroupMemberStmt:                  q.removeSlotifyGroupMemberStmt,
-		searchSlotifyGroupMembersByEmailStmt:          q.searchSlotifyGroupMembersByEmailStmt,
-		searchSlotifyGroupMembersByNameStmt:           q.searchSlotifyGroupMembersByNameStmt,
-		searchUsersByEmailStmt:                        q.searchUsersByEmailStmt,
-		searchUsersByNameStmt:                         q.searchUsersByNameStmt,
-		updateInviteMessageStmt:                       q.updateInviteMessageStmt,
-		updateInviteStatusStmt:                        q.updateInviteStatusStmt,
-		updateMeetingStartTimeStmt:                    q.updateMeetingStartTimeStmt,
-		updateRequestStatusAsAcceptedStmt:             q.updateRequestStatusAsAcceptedStmt,
-		updateRequestStatusAsRejectedStmt:             q.updateRequestStatusAsRejectedStmt,
-		updateUserHomeAccountIDStmt:                   q.updateUserHomeAccountIDStmt,
+		db:                                             tx,
+		tx:                                             tx,
+		addSlotifyGroupStmt:                            q.addSlotifyGroupStmt,
+		addUserToSlotifyGroupStmt:                      q.addUserToSlotifyGroupStmt,
+		batchDeleteIdleCalendarSyncsStmt:               q.batchDeleteIdleCalendarSyncsStmt,
+		batchDeleteWeekOldInvitesStmt:                  q.batchDeleteWeekOldInvitesStmt,
+		batchDeleteWeekOldNotificationsStmt:            q.batchDeleteWeekOldNotificationsStmt,
+		batchExpireInvitesStmt:                         q.batchExpireInvitesStmt,
+		checkMemberInSlotifyGroupStmt:                  q.checkMemberInSlotifyGroupStmt,
+		checkPollVoterStmt:                             q.checkPollVoterStmt,
+		claimNotificationDigestStmt:                    q.claimNotificationDigestStmt,
+		claimNotificationOutboxStmt:                    q.claimNotificationOutboxStmt,
+		claimPollCloseStmt:                             q.claimPollCloseStmt,
+		closePollStmt:                                  q.closePollStmt,
+		countExpiredInvitesStmt:                        q.countExpiredInvitesStmt,
+		countSlotifyGroupByIDStmt:                      q.countSlotifyGroupByIDStmt,
+		countSlotifyGroupMembersStmt:                   q.countSlotifyGroupMembersStmt,
+		countUnreadUserNotificationsStmt:               q.countUnreadUserNotificationsStmt,
+		countUserByEmailStmt:                           q.countUserByEmailStmt,
+		countUserByIDStmt:                              q.countUserByIDStmt,
+		countWeekOldInvitesStmt:                        q.countWeekOldInvitesStmt,
+		countWeekOldNotificationsStmt:                  q.countWeekOldNotificationsStmt,
+		createInviteStmt:                               q.createInviteStmt,
+		createMeetingStmt:                              q.createMeetingStmt,
+		createMeetingPreferencesStmt:                   q.createMeetingPreferencesStmt,
+		createNotificationStmt:                         q.createNotificationStmt,
+		createNotificationDigestStmt:                   q.createNotificationDigestStmt,
+		createNotificationOutboxStmt:                   q.createNotificationOutboxStmt,
+		createNotificationPreferenceStmt:               q.createNotificationPreferenceStmt,
+		createPlaceholderMeetingStmt:                   q.createPlaceholderMeetingStmt,
+		createPlaceholderMeetingAttendeeStmt:           q.createPlaceholderMeetingAttendeeStmt,
+		createPollStmt:                                 q.createPollStmt,
+		createPollOptionStmt:                           q.createPollOptionStmt,
+		createPollVoterStmt:                            q.createPollVoterStmt,
+		createRefreshTokenStmt:                         q.createRefreshTokenStmt,
+		createRequestToMeetingStmt:                     q.createRequestToMeetingStmt,
+		createReschedulingRequestStmt:                  q.createReschedulingRequestStmt,
+		createSlotifyGroupNotificationPreferenceStmt:   q.createSlotifyGroupNotificationPreferenceStmt,
+		createUserStmt:                                 q.createUserStmt,
+		createUserNotificationStmt:                     q.createUserNotificationStmt,
+		deleteCalendarEventCacheStmt:                   q.deleteCalendarEventCacheStmt,
+		deleteCalendarEventCacheEventStmt:              q.deleteCalendarEventCacheEventStmt,
+		deleteCalendarSubscriptionStmt:                 q.deleteCalendarSubscriptionStmt,
+		deleteDeliveredNotificationOutboxStmt:          q.deleteDeliveredNotificationOutboxStmt,
+		deleteInviteByIDStmt:                           q.deleteInviteByIDStmt,
+		deleteNotificationPreferencesStmt:              q.deleteNotificationPreferencesStmt,
+		deleteNotificationQuietHoursStmt:               q.deleteNotificationQuietHoursStmt,
+		deleteRefreshTokenByUserIDStmt:                 q.deleteRefreshTokenByUserIDStmt,
+		deleteRequestStmt:                              q.deleteRequestStmt,
+		deleteSlotifyGroupByIDStmt:                     q.deleteSlotifyGroupByIDStmt,
+		deleteSlotifyGroupNotificationPreferencesStmt:  q.deleteSlotifyGroupNotificationPreferencesStmt,
+		deleteUserByIDStmt:                             q.deleteUserByIDStmt,
+		deleteUserNotificationStmt:                     q.deleteUserNotificationStmt,
+		deleteUserPreferencesStmt:                      q.deleteUserPreferencesStmt,
+		expireCalendarSyncStmt:                         q.expireCalendarSyncStmt,
+		getAllRequestsForOwnerStmt:                     q.getAllRequestsForOwnerStmt,
+		getAllRequestsResponsesForUserIDStmt:           q.getAllRequestsResponsesForUserIDStmt,
+		getAllSlotifyGroupMembersStmt:                  q.getAllSlotifyGroupMembersStmt,
+		getAllSlotifyGroupMembersExceptStmt:            q.getAllSlotifyGroupMembersExceptStmt,
+		getCalendarSubscriptionStmt:                    q.getCalendarSubscriptionStmt,
+		getCalendarSubscriptionByUserIDStmt:            q.getCalendarSubscriptionByUserIDStmt,
+		getCalendarSyncStmt:                            q.getCalendarSyncStmt,
+		getInviteByIDStmt:                              q.getInviteByIDStmt,
+		getMSALTokenCacheStmt:                          q.getMSALTokenCacheStmt,
+		getMeetingByIDStmt:                             q.getMeetingByIDStmt,
+		getMeetingByMSFTIDStmt:                         q.getMeetingByMSFTIDStmt,
+		getMeetingIDFromRequestIDStmt:                  q.getMeetingIDFromRequestIDStmt,
+		getMeetingPreferencesStmt:                      q.getMeetingPreferencesStmt,
+		getNotificationByIDStmt:                        q.getNotificationByIDStmt,
+		getNotificationPreferenceChannelStmt:           q.getNotificationPreferenceChannelStmt,
+		getNotificationQuietHoursStmt:                  q.getNotificationQuietHoursStmt,
+		getOnlyRequestByIDStmt:                         q.getOnlyRequestByIDStmt,
+		getPlaceholderMeetingAttendeesByMeetingIDStmt:  q.getPlaceholderMeetingAttendeesByMeetingIDStmt,
+		getPollByIDStmt:                                q.getPollByIDStmt,
+		getRefreshTokenByUserIDStmt:                    q.getRefreshTokenByUserIDStmt,
+		getRequestByIDStmt:                             q.getRequestByIDStmt,
+		getSlotifyGroupByIDStmt:                        q.getSlotifyGroupByIDStmt,
+		getSlotifyGroupMemberRoleStmt:                  q.getSlotifyGroupMemberRoleStmt,
+		getSlotifyGroupScoringWeightsStmt:              q.getSlotifyGroupScoringWeightsStmt,
+		getSlotifyGroupSuccessorStmt:                   q.getSlotifyGroupSuccessorStmt,
+		getUnreadUserNotificationsStmt:                 q.getUnreadUserNotificationsStmt,
+		getUserByEmailStmt:                             q.getUserByEmailStmt,
+		getUserByIDStmt:                                q.getUserByIDStmt,
+		getUserIdentityStmt:                            q.getUserIdentityStmt,
+		getUserPreferencesStmt:                         q.getUserPreferencesStmt,
+		getUsersSlotifyGroupsStmt:                      q.getUsersSlotifyGroupsStmt,
+		listCalendarEventCacheStmt:                     q.listCalendarEventCacheStmt,
+		listCalendarSubscriptionsToRenewStmt:           q.listCalendarSubscriptionsToRenewStmt,
+		listCalendarSyncsToRefreshStmt:                 q.listCalendarSyncsToRefreshStmt,
+		listDueNotificationOutboxStmt:                  q.listDueNotificationOutboxStmt,
+		listInvitesByGroupStmt:                         q.listInvitesByGroupStmt,
+		listInvitesMeStmt:                              q.listInvitesMeStmt,
+		listMemberSlotifyGroupNotificationChannelsStmt: q.listMemberSlotifyGroupNotificationChannelsStmt,
+		listNotificationPreferencesStmt:                q.listNotificationPreferencesStmt,
+		listNotificationRecipientsStmt:                 q.listNotificationRecipientsStmt,
+		listPendingRescheduleRequestersStmt:            q.listPendingRescheduleRequestersStmt,
+		listPollOptionsStmt:                            q.listPollOptionsStmt,
+		listPollVotersStmt:                             q.listPollVotersStmt,
+		listPollVotesStmt:                              q.listPollVotesStmt,
+		listPollsPastDeadlineStmt:                      q.listPollsPastDeadlineStmt,
+		listSlotifyGroupNotificationPreferencesStmt:    q.listSlotifyGroupNotificationPreferencesStmt,
+		listSlotifyGroupsStmt:                          q.listSlotifyGroupsStmt,
+		listUnreadDigestNotificationsStmt:              q.listUnreadDigestNotificationsStmt,
+		listUserNotificationOutboxStmt:                 q.listUserNotificationOutboxStmt,
+		listUserNotificationsStmt:                      q.listUserNotificationsStmt,
+		listUserNotificationsAfterIDStmt:               q.listUserNotificationsAfterIDStmt,
+		listUserPollsStmt:                              q.listUserPollsStmt,
+		lockPollForVoteStmt:                            q.lockPollForVoteStmt,
+		markAllNotificationsAsReadStmt:                 q.markAllNotificationsAsReadStmt,
+		markNotificationAsReadStmt:                     q.markNotificationAsReadStmt,
+		markNotificationOutboxDeliveredStmt:            q.markNotificationOutboxDeliveredStmt,
+		releasePollCloseStmt:                           q.releasePollCloseStmt,
+		removeSlotifyGroupStmt:                         q.removeSlotifyGroupStmt,
+		removeSlotifyGroupMemberStmt:                   q.removeSlotifyGroupMemberStmt,
+		retryNotificationOutboxStmt:                    q.retryNotificationOutboxStmt,
+		retryPollCloseLaterStmt:                        q.retryPollCloseLaterStmt,
+		searchSlotifyGroupMembersByEmailStmt:           q.searchSlotifyGroupMembersByEmailStmt,
+		searchSlotifyGroupMembersByNameStmt:            q.searchSlotifyGroupMembersByNameStmt,
+		searchUsersByEmailStmt:                         q.searchUsersByEmailStmt,
+		searchUsersByNameStmt:                          q.searchUsersByNameStmt,
+		setNotificationOutboxNotificationIDStmt:        q.setNotificationOutboxNotificationIDStmt,
+		setPollMeetingStmt:                             q.setPollMeetingStmt,
+		setSlotifyGroupMemberAttendeeTypeStmt:          q.setSlotifyGroupMemberAttendeeTypeStmt,
+		setSlotifyGroupMemberRoleStmt:                  q.setSlotifyGroupMemberRoleStmt,
+		updateCalendarSubscriptionExpiresAtStmt:        q.updateCalendarSubscriptionExpiresAtStmt,
+		updateCalendarSyncLastReadAtStmt:               q.updateCalendarSyncLastReadAtStmt,
+		updateInviteMessageStmt:                        q.updateInviteMessageStmt,
+		updateInviteStatusStmt:                         q.updateInviteStatusStmt,
+		updateMeetingStartTimeStmt:                     q.updateMeetingStartTimeStmt,
+		updateRequestStatusAsAcceptedStmt:              q.updateRequestStatusAsAcceptedStmt,
+		updateRequestStatusAsRejectedStmt:              q.updateRequestStatusAsRejectedStmt,
+		updateSlotifyGroupStmt:                         q.updateSlotifyGroupStmt,
+		updateUserHomeAccountIDStmt:                    q.updateUserHomeAccountIDStmt,
+		updateUserIdentityTokenDataStmt:                q.updateUserIdentityTokenDataStmt,
+		updateUserTimeZoneStmt:                         q.updateUserTimeZoneStmt,
+		upsertCalendarEventCacheStmt:                   q.upsertCalendarEventCacheStmt,
+		upsertCalendarSubscriptionStmt:                 q.upsertCalendarSubscriptionStmt,
+		upsertCalendarSyncStmt:                         q.upsertCalendarSyncStmt,
+		upsertMSALTokenCacheStmt:                       q.upsertMSALTokenCacheStmt,
+		upsertNotificationQuietHoursStmt:               q.upsertNotificationQuietHoursStmt,
+		upsertPollVoteStmt:                             q.upsertPollVoteStmt,
+		upsertSlotifyGroupScoringWeightsStmt:           q.upsertSlotifyGroupScoringWeightsStmt,
+		upsertUserIdentityStmt:                         q.upsertUserIdentityStmt,
+		upsertUserPreferencesStmt:                      q.upsertUserPreferencesStmt,
 	}
 }
@@ -0,0 +1,72 @@
+// Package email sends emails, eg. to notify users who aren't using the site.
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"time"
+)
+
+// Message is an email to a single recipient, with a plain text and an HTML body.
+type Message struct {
+	To      mail.Address
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// bytes encodes the message as a multipart/alternative MIME message from from, sent at date.
+func (m Message) bytes(from mail.Address, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		// Clients show the last part they support, so HTML goes last
+		{contentType: "text/plain; charset=utf-8", content: m.Text},
+		{contentType: "text/html; charset=utf-8", content: m.HTML},
+	} {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create email part: %w", err)
+		}
+
+		qp := quotedprintable.NewWriter(pw)
+		if _, err = qp.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write email part: %w", err)
+		}
+		if err = qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write email part: %w", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close email body: %w", err)
+	}
+
+	var msg bytes.Buffer
+	for _, h := range [][2]string{
+		{"From", from.String()},
+		{"To", m.To.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", w.Boundary())},
+	} {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h[0], h[1])
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,182 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	SMTPHostEnvName     = "SMTP_HOST"
+	SMTPPortEnvName     = "SMTP_PORT"
+	SMTPUsernameEnvName = "SMTP_USERNAME"
+	SMTPPasswordEnvName = "SMTP_PASSWORD"
+	SMTPFromEnvName     = "SMTP_FROM"
+
+	// DefaultSMTPPort is the SMTP submission port, used if SMTPPortEnvName is not set.
+	DefaultSMTPPort = 587
+
+	// smtpDialTimeout is the max time to connect to the SMTP server.
+	smtpDialTimeout = 10 * time.Second
+)
+
+// Sender sends emails.
+type Sender interface {
+	// Send sends msg, returning once it has been accepted for delivery.
+	Send(ctx context.Context, msg Message) error
+}
+
+// ensure that we've conformed to the `Sender` interface with a compile-time check.
+var _ Sender = (*SMTPSender)(nil)
+
+// SMTPConfig stores details of the SMTP server emails are sent through.
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username and Password are used to authenticate if Username is set.
+	Username string
+	Password string
+	// From is the address emails are sent from, eg. 'Slotify <noreply@slotify.app>'.
+	From string
+}
+
+// NewSMTPConfigFromEnv creates an SMTPConfig from the SMTP env variables.
+func NewSMTPConfigFromEnv() (SMTPConfig, error) {
+	host, present := os.LookupEnv(SMTPHostEnvName)
+	if !present {
+		return SMTPConfig{}, fmt.Errorf("failed to get %s env value", SMTPHostEnvName)
+	}
+
+	from, present := os.LookupEnv(SMTPFromEnvName)
+	if !present {
+		return SMTPConfig{}, fmt.Errorf("failed to get %s env value", SMTPFromEnvName)
+	}
+
+	port := DefaultSMTPPort
+	if v, ok := os.LookupEnv(SMTPPortEnvName); ok {
+		var err error
+		if port, err = strconv.Atoi(v); err != nil {
+			return SMTPConfig{}, fmt.Errorf("failed to parse %s env value: %w", SMTPPortEnvName, err)
+		}
+	}
+
+	return SMTPConfig{
+		Host:     host,
+		Port:     port,
+		Username: os.Getenv(SMTPUsernameEnvName),
+		Password: os.Getenv(SMTPPasswordEnvName),
+		From:     from,
+	}, nil
+}
+
+// SMTPSender is a Sender that sends emails through an SMTP server, using STARTTLS when the server supports it.
+type SMTPSender struct {
+	addr     string
+	host     string
+	from     mail.Address
+	username string
+	password string
+}
+
+// NewSMTPSender creates a new instance of SMTPSender.
+func NewSMTPSender(cfg SMTPConfig) (*SMTPSender, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("smtp host must not be empty")
+	}
+	if cfg.Port <= 0 {
+		return nil, fmt.Errorf("invalid smtp port %d", cfg.Port)
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address '%s': %w", cfg.From, err)
+	}
+
+	return &SMTPSender{
+		addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host:     cfg.Host,
+		from:     *from,
+		username: cfg.Username,
+		password: cfg.Password,
+	}, nil
+}
+
+// NewSMTPSenderFromEnv creates a new instance of SMTPSender from the SMTP env variables.
+func NewSMTPSenderFromEnv() (*SMTPSender, error) {
+	cfg, err := NewSMTPConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewSMTPSender(cfg)
+}
+
+// Send sends msg through the SMTP server.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	data, err := msg.bytes(s.from, time.Now())
+	if err != nil {
+		return err
+	}
+
+	d := net.Dialer{Timeout: smtpDialTimeout}
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return fmt.Errorf("failed to set smtp connection deadline: %w", err)
+		}
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("failed to start tls with smtp server: %w", err)
+		}
+	}
+
+	if s.username != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("failed to authenticate with smtp server: %w", err)
+		}
+	}
+
+	if err = c.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("smtp server rejected sender: %w", err)
+	}
+	if err = c.Rcpt(msg.To.Address); err != nil {
+		return fmt.Errorf("smtp server rejected recipient: %w", err)
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start sending email data: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		return fmt.Errorf("failed to send email data: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected email: %w", err)
+	}
+
+	if err = c.Quit(); err != nil {
+		return fmt.Errorf("failed to quit smtp session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package email_test
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/SlotifyApp/slotify-backend/email"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPSender_Send(t *testing.T) {
+	t.Parallel()
+
+	sink := testutil.NewSMTPSink(t)
+	host, port := sink.Addr()
+
+	sender, err := email.NewSMTPSender(email.SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "Slotify <noreply@slotify.app>",
+	})
+	require.NoError(t, err, "creating smtp sender should not return error")
+
+	msg := email.Message{
+		To:      mail.Address{Name: "Ada Lovelace", Address: "ada@example.com"},
+		Subject: "Réunion moved",
+		// A line starting with a dot is escaped by the smtp client
+		Text: "Your meeting moved.\n.\nSee you there",
+		HTML: "<p>Your meeting moved.</p>",
+	}
+	require.NoError(t, sender.Send(t.Context(), msg), "sending email should not return error")
+
+	received := sink.Messages()
+	require.Len(t, received, 1, "one email is received")
+	require.Equal(t, "noreply@slotify.app", received[0].From)
+	require.Equal(t, []string{"ada@example.com"}, received[0].To)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(received[0].Data))
+	require.NoError(t, err, "received email should be a valid message")
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, msg.Subject, subject, "non ascii subjects are encoded")
+	require.Equal(t, `"Ada Lovelace" <ada@example.com>`, parsed.Header.Get("To"))
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	r := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		// The quoted-printable encoding is undone by the reader
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts[part.Header.Get("Content-Type")] = string(body)
+	}
+
+	// Line endings are sent as CRLF
+	require.Equal(t, map[string]string{
+		"text/plain; charset=utf-8": strings.ReplaceAll(msg.Text, "\n", "\r\n"),
+		"text/html; charset=utf-8":  msg.HTML,
+	}, parts, "email has a text and an html part")
+}
+
+func TestNewSMTPSender_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, cfg := range map[string]email.SMTPConfig{
+		"missing host":         {Port: 587, From: "noreply@slotify.app"},
+		"invalid port":         {Host: "localhost", From: "noreply@slotify.app"},
+		"invalid from address": {Host: "localhost", Port: 587, From: "noreply"},
+	} {
+		_, err := email.NewSMTPSender(cfg)
+		require.Error(t, err, name)
+	}
+}
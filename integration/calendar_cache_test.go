@@ -0,0 +1,71 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarCache_GetAPICalendarMe(t *testing.T) {
+	t.Parallel()
+
+	cacheConfig := api.DefaultCalendarCacheConfig()
+	cacheConfig.MaxStaleness = time.Hour
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.DeltaFactory()),
+		testutil.WithCalendarCacheConfig(cacheConfig))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	start := now.Add(-time.Hour)
+	end := now.Add(24 * time.Hour)
+
+	getEvents := func(forceRefresh bool) []api.CalendarEvent {
+		query := url.Values{}
+		query.Set("start", start.Format(time.RFC3339))
+		query.Set("end", end.Format(time.RFC3339))
+		params := api.GetAPICalendarMeParams{Start: start, End: end}
+		if forceRefresh {
+			query.Set("forceRefresh", "true")
+			params.ForceRefresh = &forceRefresh
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/calendar/me?"+query.Encode(), nil)
+		req = withUser(req, user.Id)
+		rr := httptest.NewRecorder()
+
+		server.GetAPICalendarMe(rr, req, params)
+
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		testutil.OpenAPIValidateTest(t, rr, req)
+
+		var events []api.CalendarEvent
+		err := json.NewDecoder(rr.Result().Body).Decode(&events)
+		require.NoError(t, err, "response body can be decoded into calendar events")
+		return events
+	}
+
+	first := fakeCalendar.AddEvent(user.Id, newFakeCalendarEvent("first", now, now.Add(time.Hour)))
+	require.Equal(t, []api.CalendarEvent{first}, getEvents(false), "first read syncs the cache")
+
+	// Added behind the server's back, so only a refresh will see it
+	second := fakeCalendar.AddEvent(user.Id, newFakeCalendarEvent("second",
+		now.Add(2*time.Hour), now.Add(3*time.Hour)))
+	require.Equal(t, []api.CalendarEvent{first}, getEvents(false), "fresh cache is read without syncing")
+
+	require.Equal(t, []api.CalendarEvent{first, second}, getEvents(true), "forceRefresh syncs the cache")
+}
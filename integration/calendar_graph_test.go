@@ -0,0 +1,125 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/microsoft/kiota-abstractions-go/authentication"
+	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/stretchr/testify/require"
+)
+
+// newPagedGraphCalendar creates a GraphCalendarProvider against a stub Graph API whose calendar
+// view returns pages of events, each page linking to the next with @odata.nextLink. Event times are
+// returned as wall clock times in loc, which Graph calls timeZone.
+func newPagedGraphCalendar(t *testing.T, pages [][]string, loc *time.Location,
+	timeZone string,
+) *api.GraphCalendarProvider {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 0
+		if r.URL.Path != "/v1.0/me/calendar/calendarView" {
+			if _, err := fmt.Sscanf(r.URL.Path, "/page/%d", &page); err != nil {
+				http.NotFound(w, r)
+				return
+			}
+		}
+
+		start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+		events := []map[string]any{}
+		for i, subject := range pages[page] {
+			eventStart := start.Add(time.Duration(page*100+i) * time.Hour)
+			events = append(events, map[string]any{
+				"id":      subject,
+				"subject": subject,
+				"start":   map[string]string{"dateTime": formatGraphDateTime(eventStart, loc), "timeZone": timeZone},
+				"end": map[string]string{
+					"dateTime": formatGraphDateTime(eventStart.Add(time.Hour), loc),
+					"timeZone": timeZone,
+				},
+				"organizer": map[string]any{"emailAddress": map[string]string{"address": "organiser@example.com"}},
+			})
+		}
+
+		resp := map[string]any{"value": events}
+		if page+1 < len(pages) {
+			resp["@odata.nextLink"] = fmt.Sprintf("%s/page/%d", srv.URL, page+1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(srv.Close)
+
+	adapter, err := msgraphsdkgo.NewGraphRequestAdapter(&authentication.AnonymousAuthenticationProvider{})
+	require.NoError(t, err, "failed to create graph request adapter")
+	adapter.SetBaseUrl(srv.URL + "/v1.0")
+
+	provider, err := api.NewGraphCalendarProvider(msgraphsdkgo.NewGraphServiceClient(adapter))
+	require.NoError(t, err, "failed to create graph calendar provider")
+	return provider
+}
+
+// formatGraphDateTime formats t as Graph does, a wall clock time in loc without an offset.
+func formatGraphDateTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01-02T15:04:05.0000000")
+}
+
+func TestGraphCalendarProvider_Pagination(t *testing.T) {
+	t.Parallel()
+
+	provider := newPagedGraphCalendar(t, [][]string{{"a", "b"}, {"c"}, {"d", "e"}}, time.UTC, "UTC")
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 6, 0)
+
+	events, err := provider.ListEvents(t.Context(), start, end)
+	require.NoError(t, err)
+	subjects := []string{}
+	for _, e := range events {
+		subjects = append(subjects, *e.Subject)
+	}
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, subjects, "every page is followed")
+
+	busy, err := provider.FreeBusy(t.Context(), start, end)
+	require.NoError(t, err)
+	require.Len(t, busy, 5, "busy time is built from every page")
+
+	subjects = []string{}
+	err = provider.StreamEvents(t.Context(), start, end, func(e api.CalendarEvent) bool {
+		subjects = append(subjects, *e.Subject)
+		return len(subjects) < 3
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, subjects, "streaming stops when yield returns false")
+}
+
+func TestGraphCalendarProvider_TimeZones(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// The third page is 200 hours later, after New York's clocks go forward on 9 March
+	provider := newPagedGraphCalendar(t, [][]string{{"before"}, {"during"}, {"after"}}, newYork, "Eastern Standard Time")
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 6, 0)
+
+	before := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	after := before.Add(200 * time.Hour)
+
+	events, err := provider.ListEvents(t.Context(), start, end)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, api.FormatCalendarEventTime(before), *events[0].StartTime, "EST is UTC-5")
+	require.Equal(t, api.FormatCalendarEventTime(after), *events[2].StartTime, "EDT is UTC-4")
+
+	busy, err := provider.FreeBusy(t.Context(), start, end)
+	require.NoError(t, err)
+	require.Len(t, busy, 3)
+	require.Equal(t, api.TimeInterval{Start: before, End: before.Add(time.Hour)}, busy[0], "EST is UTC-5")
+	require.Equal(t, api.TimeInterval{Start: after, End: after.Add(time.Hour)}, busy[2], "EDT is UTC-4")
+}
@@ -0,0 +1,449 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeCalendarEvent creates an event for the FakeCalendar between start and end.
+func newFakeCalendarEvent(subject string, start, end time.Time) api.CalendarEvent {
+	startTime := testutil.FormatFakeCalendarTime(start)
+	endTime := testutil.FormatFakeCalendarTime(end)
+	return api.CalendarEvent{
+		Subject:   &subject,
+		StartTime: &startTime,
+		EndTime:   &endTime,
+	}
+}
+
+// withUser adds the user and request id to the request's context, as the auth middleware does.
+func withUser(req *http.Request, userID uint32) *http.Request {
+	ctx := context.WithValue(req.Context(), api.UserIDCtxKey{}, userID)
+	ctx = context.WithValue(ctx, api.RequestIDCtxKey{}, uuid.NewString())
+	return req.WithContext(ctx)
+}
+
+func TestCalendar_GetAPICalendarMe(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+	otherUser := testutil.InsertUser(t, db)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	inRange := fakeCalendar.AddEvent(user.Id, newFakeCalendarEvent("in range", now, now.Add(time.Hour)))
+	fakeCalendar.AddEvent(user.Id, newFakeCalendarEvent("out of range",
+		now.Add(72*time.Hour), now.Add(73*time.Hour)))
+	fakeCalendar.AddEvent(otherUser.Id, newFakeCalendarEvent("other user", now, now.Add(time.Hour)))
+
+	query := url.Values{}
+	query.Set("start", now.Add(-time.Hour).Format(time.RFC3339))
+	query.Set("end", now.Add(24*time.Hour).Format(time.RFC3339))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/calendar/me?"+query.Encode(), nil)
+	req = withUser(req, user.Id)
+	rr := httptest.NewRecorder()
+
+	server.GetAPICalendarMe(rr, req, api.GetAPICalendarMeParams{
+		Start: now.Add(-time.Hour),
+		End:   now.Add(24 * time.Hour),
+	})
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var events []api.CalendarEvent
+	err := json.NewDecoder(rr.Result().Body).Decode(&events)
+	require.NoError(t, err, "response body can be decoded into calendar events")
+	require.Equal(t, []api.CalendarEvent{inRange}, events, "only the user's events in range are returned")
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+}
+
+func TestCalendar_GetAPICalendarMeTimeZone(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+	_, err := slotifyDB.UpdateUserTimeZone(t.Context(), database.UpdateUserTimeZoneParams{
+		TimeZone: "America/New_York",
+		ID:       user.Id,
+	})
+	require.NoError(t, err)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	fakeCalendar.AddEvent(user.Id, newFakeCalendarEvent("Team sync", start, start.Add(time.Hour)))
+
+	getEvents := func(t *testing.T, timeZone *string) *httptest.ResponseRecorder {
+		query := url.Values{}
+		query.Set("start", start.Add(-time.Hour).Format(time.RFC3339))
+		query.Set("end", start.Add(2*time.Hour).Format(time.RFC3339))
+		if timeZone != nil {
+			query.Set("timeZone", *timeZone)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/calendar/me?"+query.Encode(), nil)
+		req = withUser(req, user.Id)
+		rr := httptest.NewRecorder()
+		server.GetAPICalendarMe(rr, req, api.GetAPICalendarMeParams{
+			Start:    start.Add(-time.Hour),
+			End:      start.Add(2 * time.Hour),
+			TimeZone: timeZone,
+		})
+		testutil.OpenAPIValidateTest(t, rr, req)
+		return rr
+	}
+
+	singapore, tokyo, invalidZone := "Asia/Singapore", "Tokyo Standard Time", "Mars/Olympus_Mons"
+	tests := map[string]struct {
+		timeZone *string
+		location string
+		testMsg  string
+	}{
+		"user's time zone": {
+			location: "America/New_York",
+			testMsg:  "events are returned in the user's time zone",
+		},
+		"requested time zone": {
+			timeZone: &singapore,
+			location: "Asia/Singapore",
+			testMsg:  "events are returned in the time zone asked for",
+		},
+		"requested windows time zone": {
+			timeZone: &tokyo,
+			location: "Asia/Tokyo",
+			testMsg:  "windows time zones are accepted",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			loc, err := time.LoadLocation(tt.location)
+			require.NoError(t, err)
+
+			rr := getEvents(t, tt.timeZone)
+			require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+			var events []api.CalendarEvent
+			require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&events))
+			require.Len(t, events, 1)
+			require.NotNil(t, events[0].StartTime)
+			require.NotNil(t, events[0].EndTime)
+			require.Equal(t, start.In(loc).Format(time.RFC3339), *events[0].StartTime, tt.testMsg)
+			require.Equal(t, start.Add(time.Hour).In(loc).Format(time.RFC3339), *events[0].EndTime, tt.testMsg)
+		})
+	}
+
+	require.Equal(t, http.StatusBadRequest, getEvents(t, &invalidZone).Result().StatusCode,
+		"unknown time zones are a bad request")
+}
+
+func TestCalendar_PostAPICalendarMeAndGetEvent(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	eventReq := newFakeCalendarEvent("Team sync", start, start.Add(30*time.Minute))
+
+	reqBody, err := json.Marshal(eventReq)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/calendar/me", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, user.Id)
+	rr := httptest.NewRecorder()
+
+	server.PostAPICalendarMe(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var created api.CalendarEvent
+	err = json.NewDecoder(rr.Result().Body).Decode(&created)
+	require.NoError(t, err, "response body can be decoded into calendar event")
+	require.NotNil(t, created.Id, "created event has an id")
+	require.Equal(t, eventReq.Subject, created.Subject)
+	require.NotNil(t, created.TimeZone)
+	require.Equal(t, "UTC", *created.TimeZone, "events are created in the user's time zone")
+	require.Len(t, fakeCalendar.Events(user.Id), 1, "event was created in the user's calendar")
+
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, user.Id)
+	require.Len(t, outbox, 1, "a notification is enqueued for the user")
+	require.Equal(t, string(notification.KindMeetingCreated), outbox[0].Kind)
+
+	// Get the created event by its id
+	getReq := httptest.NewRequest(http.MethodGet,
+		fmt.Sprintf("/api/calendar/event?msftID=%s&isICalUId=false", url.QueryEscape(*created.Id)), nil)
+	getReq = withUser(getReq, user.Id)
+	getRR := httptest.NewRecorder()
+
+	server.GetAPICalendarEvent(getRR, getReq, api.GetAPICalendarEventParams{
+		MsftID:    *created.Id,
+		IsICalUId: false,
+	})
+
+	require.Equal(t, http.StatusOK, getRR.Result().StatusCode)
+
+	var got api.CalendarEvent
+	err = json.NewDecoder(getRR.Result().Body).Decode(&got)
+	require.NoError(t, err, "response body can be decoded into calendar event")
+	require.Equal(t, created, got, "got the created event")
+
+	// Missing events are reported as a bad gateway
+	missingRR := httptest.NewRecorder()
+	server.GetAPICalendarEvent(missingRR, withUser(getReq, user.Id), api.GetAPICalendarEventParams{
+		MsftID: uuid.NewString(),
+	})
+	require.Equal(t, http.StatusBadGateway, missingRR.Result().StatusCode)
+
+	// Unknown time zones are a bad request
+	invalidZone := "Mars/Olympus_Mons"
+	eventReq.TimeZone = &invalidZone
+	reqBody, err = json.Marshal(eventReq)
+	require.NoError(t, err, "could not marshal json req body")
+
+	invalidReq := httptest.NewRequest(http.MethodPost, "/api/calendar/me", bytes.NewReader(reqBody))
+	invalidReq.Header.Set("Content-Type", "application/json")
+	invalidRR := httptest.NewRecorder()
+	server.PostAPICalendarMe(invalidRR, withUser(invalidReq, user.Id))
+	require.Equal(t, http.StatusBadRequest, invalidRR.Result().StatusCode)
+	require.Len(t, fakeCalendar.Events(user.Id), 1, "no event is created")
+}
+
+func TestCalendar_PatchAPIRescheduleRequestRequestIDAccept(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	owner := testutil.InsertUser(t, db)
+	requester := testutil.InsertUser(t, db)
+
+	oldStart := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	event := fakeCalendar.AddEvent(owner.Id, newFakeCalendarEvent("Planning", oldStart, oldStart.Add(time.Hour)))
+
+	// Setup the rescheduling request for the owner's meeting
+	meetingPrefID, err := slotifyDB.CreateMeetingPreferences(t.Context(), database.CreateMeetingPreferencesParams{
+		MeetingStartTime: oldStart,
+		StartDateRange:   time.Now(),
+		EndDateRange:     oldStart.Add(7 * 24 * time.Hour),
+	})
+	require.NoError(t, err, "failed to create meeting preferences")
+
+	meetingID, err := slotifyDB.CreateMeeting(t.Context(), database.CreateMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		MeetingPrefID: uint32(meetingPrefID),
+		OwnerEmail:    string(owner.Email),
+		MsftMeetingID: *event.ICalUId,
+	})
+	require.NoError(t, err, "failed to create meeting")
+
+	requestID, err := slotifyDB.CreateReschedulingRequest(t.Context(), database.CreateReschedulingRequestParams{
+		RequestedBy: requester.Id,
+		CreatedAt:   time.Now(),
+		Scope:       database.ReschedulingrequestScopeOccurrence,
+	})
+	require.NoError(t, err, "failed to create rescheduling request")
+
+	_, err = slotifyDB.CreateRequestToMeeting(t.Context(), database.CreateRequestToMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		RequestID: uint32(requestID),
+		//nolint: gosec // id is unsigned 32 bit int
+		MeetingID: uint32(meetingID),
+	})
+	require.NoError(t, err, "failed to link request to meeting")
+
+	newStart := oldStart.Add(24 * time.Hour)
+	reqBody, err := json.Marshal(api.ReschedulingRequestAcceptBodySchema{
+		NewStartTime: newStart,
+		NewEndTime:   newStart.Add(time.Hour),
+	})
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPatch,
+		fmt.Sprintf("/api/reschedule/request/%d/accept", requestID), bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, owner.Id)
+	rr := httptest.NewRecorder()
+
+	//nolint: gosec // id is unsigned 32 bit int
+	server.PatchAPIRescheduleRequestRequestIDAccept(rr, req, uint32(requestID))
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	events := fakeCalendar.Events(owner.Id)
+	require.Len(t, events, 1)
+	require.Equal(t, testutil.FormatFakeCalendarTime(newStart), *events[0].StartTime, "event start was moved")
+	require.Equal(t, testutil.FormatFakeCalendarTime(newStart.Add(time.Hour)), *events[0].EndTime,
+		"event end was moved")
+
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, owner.Id)
+	require.Len(t, outbox, 1, "a notification is enqueued for the owner")
+	require.Equal(t, string(notification.KindRescheduleAccepted), outbox[0].Kind)
+}
+
+func TestCalendar_PatchAPIRescheduleRequestRequestIDAcceptRecurring(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	firstStart := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	secondStart := firstStart.AddDate(0, 0, 7)
+	newStart := secondStart.Add(24 * time.Hour)
+
+	tests := map[string]struct {
+		scope                database.ReschedulingrequestScope
+		occurrenceStart      sql.NullTime
+		httpStatus           int
+		seriesStart          time.Time
+		movedOccurrenceStart time.Time
+		testMsg              string
+	}{
+		"one occurrence": {
+			scope:                database.ReschedulingrequestScopeOccurrence,
+			occurrenceStart:      sql.NullTime{Time: secondStart, Valid: true},
+			httpStatus:           http.StatusOK,
+			seriesStart:          firstStart,
+			movedOccurrenceStart: newStart,
+			testMsg:              "only the occurrence is moved",
+		},
+		"whole series": {
+			scope:                database.ReschedulingrequestScopeSeries,
+			httpStatus:           http.StatusOK,
+			seriesStart:          newStart,
+			movedOccurrenceStart: secondStart,
+			testMsg:              "the series is moved",
+		},
+		"occurrence not given": {
+			scope:                database.ReschedulingrequestScopeOccurrence,
+			httpStatus:           http.StatusBadGateway,
+			seriesStart:          firstStart,
+			movedOccurrenceStart: secondStart,
+			testMsg:              "nothing is moved if the occurrence isn't known",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			owner := testutil.InsertUser(t, db)
+			requester := testutil.InsertUser(t, db)
+
+			master := newFakeCalendarEvent("Standup", firstStart, firstStart.Add(time.Hour))
+			master.Recurrence = &api.Recurrence{Pattern: api.Weekly}
+			master = fakeCalendar.AddEvent(owner.Id, master)
+
+			occurrence := newFakeCalendarEvent("Standup", secondStart, secondStart.Add(time.Hour))
+			occurrence.SeriesMasterId = master.Id
+			occurrence = fakeCalendar.AddEvent(owner.Id, occurrence)
+
+			meetingPrefID, err := slotifyDB.CreateMeetingPreferences(t.Context(),
+				database.CreateMeetingPreferencesParams{
+					MeetingStartTime: firstStart,
+					StartDateRange:   time.Now(),
+					EndDateRange:     firstStart.Add(7 * 24 * time.Hour),
+				})
+			require.NoError(t, err, "failed to create meeting preferences")
+
+			meetingID, err := slotifyDB.CreateMeeting(t.Context(), database.CreateMeetingParams{
+				//nolint: gosec // id is unsigned 32 bit int
+				MeetingPrefID: uint32(meetingPrefID),
+				OwnerEmail:    string(owner.Email),
+				MsftMeetingID: *master.ICalUId,
+			})
+			require.NoError(t, err, "failed to create meeting")
+
+			requestID, err := slotifyDB.CreateReschedulingRequest(t.Context(),
+				database.CreateReschedulingRequestParams{
+					RequestedBy:     requester.Id,
+					CreatedAt:       time.Now(),
+					Scope:           tt.scope,
+					OccurrenceStart: tt.occurrenceStart,
+				})
+			require.NoError(t, err, "failed to create rescheduling request")
+
+			_, err = slotifyDB.CreateRequestToMeeting(t.Context(), database.CreateRequestToMeetingParams{
+				//nolint: gosec // id is unsigned 32 bit int
+				RequestID: uint32(requestID),
+				//nolint: gosec // id is unsigned 32 bit int
+				MeetingID: uint32(meetingID),
+			})
+			require.NoError(t, err, "failed to link request to meeting")
+
+			reqBody, err := json.Marshal(api.ReschedulingRequestAcceptBodySchema{
+				NewStartTime: newStart,
+				NewEndTime:   newStart.Add(time.Hour),
+			})
+			require.NoError(t, err, "could not marshal json req body")
+
+			req := httptest.NewRequest(http.MethodPatch,
+				fmt.Sprintf("/api/reschedule/request/%d/accept", requestID), bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req = withUser(req, owner.Id)
+			rr := httptest.NewRecorder()
+
+			//nolint: gosec // id is unsigned 32 bit int
+			server.PatchAPIRescheduleRequestRequestIDAccept(rr, req, uint32(requestID))
+
+			require.Equal(t, tt.httpStatus, rr.Result().StatusCode, tt.testMsg)
+
+			starts := map[string]string{}
+			for _, e := range fakeCalendar.Events(owner.Id) {
+				starts[*e.Id] = *e.StartTime
+			}
+			require.Equal(t, testutil.FormatFakeCalendarTime(tt.seriesStart), starts[*master.Id], tt.testMsg)
+			require.Equal(t, testutil.FormatFakeCalendarTime(tt.movedOccurrenceStart), starts[*occurrence.Id],
+				tt.testMsg)
+		})
+	}
+}
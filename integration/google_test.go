@@ -0,0 +1,213 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+// loginWithGoogle logs in a new Google user through the Google callback route.
+func loginWithGoogle(t *testing.T, server *api.Server, fakeGoogle *testutil.FakeGoogleAPI,
+	googleUser testutil.FakeGoogleUser,
+) database.User {
+	code := uuid.NewString()
+	fakeGoogle.AddUser(code, googleUser)
+
+	query := url.Values{}
+	query.Set("code", code)
+	query.Set("state", uuid.NewString())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?"+query.Encode(), nil)
+	rr := httptest.NewRecorder()
+
+	server.GetAPIAuthGoogleCallback(rr, req, api.GetAPIAuthGoogleCallbackParams{
+		Code:  query.Get("code"),
+		State: query.Get("state"),
+	})
+
+	require.Equal(t, http.StatusFound, rr.Result().StatusCode)
+	require.Equal(t, "http://localhost:3000/dashboard", rr.Result().Header.Get("Location"))
+
+	u, err := server.DB.GetUserByEmail(t.Context(), googleUser.Email)
+	require.NoError(t, err, "google user was created")
+	return u
+}
+
+func newFakeGoogleUser() testutil.FakeGoogleUser {
+	return testutil.FakeGoogleUser{
+		Subject:   uuid.NewString(),
+		Email:     gofakeit.Email(),
+		GivenName: gofakeit.FirstName(),
+		LastName:  gofakeit.LastName(),
+	}
+}
+
+// Not parallel as FRONTEND_URL is set.
+func TestGoogle_GetAPIAuthGoogleCallback(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "http://localhost:3000")
+
+	fakeGoogle := testutil.NewFakeGoogleAPI(t)
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithGoogleConfig(fakeGoogle.Config(t)))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	googleUser := newFakeGoogleUser()
+	u := loginWithGoogle(t, server, fakeGoogle, googleUser)
+	require.Equal(t, googleUser.GivenName, u.FirstName)
+	require.Equal(t, googleUser.LastName, u.LastName)
+
+	identity, err := slotifyDB.GetUserIdentity(t.Context(), database.GetUserIdentityParams{
+		UserID:   u.ID,
+		Provider: database.UserIdentityProviderGoogle,
+	})
+	require.NoError(t, err, "google identity was created")
+	require.Equal(t, googleUser.Subject, identity.Subject)
+	require.NotEmpty(t, identity.TokenData, "google tokens were stored")
+
+	// Logging in again uses the same user
+	secondLogin := loginWithGoogle(t, server, fakeGoogle, googleUser)
+	require.Equal(t, u.ID, secondLogin.ID)
+
+	count, err := slotifyDB.CountUserByEmail(t.Context(), googleUser.Email)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count, "only one user exists for the google email")
+
+	// Unknown auth codes are rejected
+	rr := httptest.NewRecorder()
+	server.GetAPIAuthGoogleCallback(rr,
+		httptest.NewRequest(http.MethodGet, "/api/auth/google/callback?code=bad&state=s", nil),
+		api.GetAPIAuthGoogleCallbackParams{Code: "bad", State: "s"})
+	require.Equal(t, http.StatusBadGateway, rr.Result().StatusCode)
+}
+
+// Not parallel as FRONTEND_URL is set.
+func TestGoogle_GetAPICalendarMe(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "http://localhost:3000")
+
+	fakeGoogle := testutil.NewFakeGoogleAPI(t)
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithGoogleConfig(fakeGoogle.Config(t)))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	googleUser := newFakeGoogleUser()
+	u := loginWithGoogle(t, server, fakeGoogle, googleUser)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	fakeGoogle.AddEvent(googleUser.Email, "in range", now, now.Add(time.Hour))
+	fakeGoogle.AddEvent(googleUser.Email, "out of range", now.Add(72*time.Hour), now.Add(73*time.Hour))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/calendar/me", nil)
+	req = withUser(req, u.ID)
+	rr := httptest.NewRecorder()
+
+	// The default calendar provider uses Google for Google users
+	server.GetAPICalendarMe(rr, req, api.GetAPICalendarMeParams{
+		Start: now.Add(-time.Hour),
+		End:   now.Add(24 * time.Hour),
+	})
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var events []api.CalendarEvent
+	err := json.NewDecoder(rr.Result().Body).Decode(&events)
+	require.NoError(t, err, "response body can be decoded into calendar events")
+	require.Len(t, events, 1, "only the event in range is returned")
+	require.Equal(t, "in range", *events[0].Subject)
+	require.Equal(t, api.FormatCalendarEventTime(now), *events[0].StartTime)
+	require.Equal(t, api.FormatCalendarEventTime(now.Add(time.Hour)), *events[0].EndTime)
+}
+
+// Not parallel as FRONTEND_URL is set.
+func TestGoogle_PostAPISchedulingSlotsMixedProviders(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "http://localhost:3000")
+
+	fakeGoogle := testutil.NewFakeGoogleAPI(t)
+	googleCfg := fakeGoogle.Config(t)
+	fakeCalendar := testutil.NewFakeCalendar()
+
+	// Google users are served by the stub, everyone else by the FakeCalendar as if they were MSFT users
+	var googleUserID uint32
+	var slotifyDB *database.Database
+	factory := func(ctx context.Context, userID uint32) (api.CalendarProvider, error) {
+		if userID == googleUserID {
+			return api.NewGoogleCalendarProviderFactory(googleCfg, slotifyDB)(ctx, userID)
+		}
+		return fakeCalendar.Factory()(ctx, userID)
+	}
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithGoogleConfig(googleCfg),
+		testutil.WithCalendarProviderFactory(factory))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	googleUser := newFakeGoogleUser()
+	googleUserID = loginWithGoogle(t, server, fakeGoogle, googleUser).ID
+
+	nine := nextWeekday().Add(9 * time.Hour)
+
+	// Organiser is busy 9-10 in MSFT, the Google attendee is busy 10-11 in Google
+	fakeCalendar.AddEvent(organiser.Id, newFakeCalendarEvent("msft meeting", nine, nine.Add(time.Hour)))
+	fakeGoogle.AddEvent(googleUser.Email, "google meeting", nine.Add(time.Hour), nine.Add(2*time.Hour))
+
+	body := api.SchedulingSlotsBodySchema{
+		Attendees: []api.AttendeeBase{
+			{
+				AttendeeType: api.Required,
+				EmailAddress: api.EmailAddress{
+					Address: openapi_types.Email(googleUser.Email),
+					Name:    googleUser.GivenName,
+				},
+			},
+		},
+		MeetingDuration: "PT1H",
+		MeetingName:     "Mixed sync",
+		TimeConstraint: api.TimeConstraint{
+			TimeSlots: []api.MeetingTimeSlot{{Start: nine, End: nine.Add(3 * time.Hour)}},
+		},
+	}
+
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/scheduling/slots", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, organiser.Id)
+	rr := httptest.NewRecorder()
+
+	server.PostAPISchedulingSlots(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var resp api.SchedulingSlotsSuccessResponseBody
+	err = json.NewDecoder(rr.Result().Body).Decode(&resp)
+	require.NoError(t, err, "response body can be decoded into scheduling slots")
+	require.NotNil(t, resp.MeetingTimeSuggestions)
+	require.Len(t, *resp.MeetingTimeSuggestions, 1, "only 11-12 is free for both providers")
+
+	slot := (*resp.MeetingTimeSuggestions)[0].MeetingTimeSlot
+	require.True(t, nine.Add(2*time.Hour).Equal(slot.Start), "suggestion starts at 11")
+	require.True(t, nine.Add(3*time.Hour).Equal(slot.End), "suggestion ends at 12")
+}
@@ -0,0 +1,258 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// putGroupAttendeeType sets the attendee type of memberID in a group, as userID.
+func putGroupAttendeeType(t *testing.T, server *api.Server, userID, slotifyGroupID, memberID uint32,
+	attendeeType api.AttendeeType,
+) *httptest.ResponseRecorder {
+	return doJSONRequest(t, http.MethodPut, "/api/slotify-groups/1/users/1/attendee-type", userID,
+		api.SlotifyGroupMemberAttendeeType{AttendeeType: attendeeType},
+		func(w http.ResponseWriter, r *http.Request) {
+			server.PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType(w, r, slotifyGroupID, memberID)
+		})
+}
+
+func TestGroupMeeting_PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDAttendeeType(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	admin := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+	outsider := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, admin.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+
+	tests := map[string]struct {
+		httpStatus   int
+		userID       uint32
+		memberID     uint32
+		attendeeType api.AttendeeType
+		testMsg      string
+	}{
+		"admin makes a member optional": {
+			httpStatus:   http.StatusOK,
+			userID:       admin.Id,
+			memberID:     member.Id,
+			attendeeType: api.Optional,
+			testMsg:      "admins can set the attendee type of members",
+		},
+		"member makes the admin optional": {
+			httpStatus:   http.StatusForbidden,
+			userID:       member.Id,
+			memberID:     admin.Id,
+			attendeeType: api.Optional,
+			testMsg:      "members can't set attendee types",
+		},
+		"members can't be resources": {
+			httpStatus:   http.StatusBadRequest,
+			userID:       admin.Id,
+			memberID:     member.Id,
+			attendeeType: api.Resource,
+			testMsg:      "only required and optional are allowed",
+		},
+		"user outside the group": {
+			httpStatus:   http.StatusBadRequest,
+			userID:       outsider.Id,
+			memberID:     member.Id,
+			attendeeType: api.Optional,
+			testMsg:      "users outside the group can't set attendee types",
+		},
+		"user isn't a member": {
+			httpStatus:   http.StatusNotFound,
+			userID:       admin.Id,
+			memberID:     outsider.Id,
+			attendeeType: api.Optional,
+			testMsg:      "only members have an attendee type",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			rr := putGroupAttendeeType(t, server, tt.userID, group.Id, tt.memberID, tt.attendeeType)
+			require.Equal(t, tt.httpStatus, rr.Result().StatusCode, tt.testMsg)
+		})
+	}
+}
+
+func TestGroupMeeting_PostAPISlotifyGroupsSlotifyGroupIDMeetings(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	first := testutil.InsertUser(t, db)
+	second := testutil.InsertUser(t, db)
+	third := testutil.InsertUser(t, db)
+	outsider := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, organiser.Id, group.Id, api.Admin)
+	for _, u := range []api.User{first, second, third} {
+		testutil.AddUserToSlotifyGroup(t, db, u.Id, group.Id)
+	}
+	lonelyGroup := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroup(t, db, organiser.Id, lonelyGroup.Id)
+
+	prefs := newPreferences()
+	require.Equal(t, http.StatusOK, putPreferences(t, server, organiser.Id, prefs).Result().StatusCode)
+
+	// Only two thirds of the group is free at 10:00 and only a third at 9:00
+	nine := nextWeekday().Add(9 * time.Hour)
+	fakeCalendar.AddEvent(first.Id, newFakeCalendarEvent("standup", nine, nine.Add(time.Hour)))
+	fakeCalendar.AddEvent(second.Id, newFakeCalendarEvent("standup", nine, nine.Add(time.Hour)))
+	fakeCalendar.AddEvent(third.Id, newFakeCalendarEvent("review", nine.Add(time.Hour), nine.Add(2*time.Hour)))
+
+	for _, u := range []api.User{first, second, third} {
+		rr := putGroupAttendeeType(t, server, organiser.Id, group.Id, u.Id, api.Optional)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	}
+
+	subject := "Team sync"
+	duration := "PT1H"
+	newBody := func(quorum float64) api.SlotifyGroupMeetingBody {
+		return api.SlotifyGroupMeetingBody{
+			Event:           api.CalendarEvent{Subject: &subject, Attendees: []api.Attendee{}, Locations: []api.Location{}},
+			MeetingDuration: &duration,
+			TimeConstraint: api.TimeConstraint{
+				TimeSlots: []api.MeetingTimeSlot{{Start: nine, End: nine.Add(2 * time.Hour)}},
+			},
+			MinimumAttendeePercentage: &quorum,
+		}
+	}
+	scheduleMeeting := func(userID, slotifyGroupID uint32, body api.SlotifyGroupMeetingBody) *httptest.ResponseRecorder {
+		return doJSONRequest(t, http.MethodPost, "/api/slotify-groups/1/meetings", userID, body,
+			func(w http.ResponseWriter, r *http.Request) {
+				server.PostAPISlotifyGroupsSlotifyGroupIDMeetings(w, r, slotifyGroupID)
+			})
+	}
+
+	// No slot has everyone
+	rr := scheduleMeeting(organiser.Id, group.Id, newBody(100))
+	require.Equal(t, http.StatusConflict, rr.Result().StatusCode, "no slot meets the quorum")
+	require.Empty(t, fakeCalendar.Events(organiser.Id), "no meeting is created without a slot")
+
+	rr = scheduleMeeting(organiser.Id, group.Id, newBody(60))
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var meeting api.SlotifyGroupMeeting
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&meeting), "response body can be decoded")
+	require.True(t, meeting.Suggestion.MeetingTimeSlot.Start.Equal(nine.Add(time.Hour)),
+		"the only slot with two thirds of the group free is chosen")
+
+	events := fakeCalendar.Events(organiser.Id)
+	require.Len(t, events, 1, "the meeting is created in the organiser's calendar")
+	require.Equal(t, testutil.FormatFakeCalendarTime(nine.Add(time.Hour)), *events[0].StartTime)
+	attendees := map[string]api.AttendeeType{}
+	for _, a := range events[0].Attendees {
+		require.NotNil(t, a.AttendeeType)
+		attendees[string(a.Email)] = *a.AttendeeType
+	}
+	require.Equal(t, map[string]api.AttendeeType{
+		string(first.Email):  api.Optional,
+		string(second.Email): api.Optional,
+		string(third.Email):  api.Optional,
+	}, attendees, "every other member is invited with their attendee type")
+
+	for _, u := range []api.User{first, second, third} {
+		outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, u.Id)
+		require.Len(t, outbox, 1, "members are told about the meeting")
+		require.Equal(t, string(notification.KindGroupMeetingScheduled), outbox[0].Kind)
+	}
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, organiser.Id)
+	require.Len(t, outbox, 1, "the organiser is told they created the meeting")
+	require.Equal(t, string(notification.KindMeetingCreated), outbox[0].Kind)
+
+	// Required members must be free, whatever the quorum
+	require.Equal(t, http.StatusOK,
+		putGroupAttendeeType(t, server, organiser.Id, group.Id, third.Id, api.Required).Result().StatusCode)
+	rr = scheduleMeeting(organiser.Id, group.Id, newBody(30))
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	events = fakeCalendar.Events(organiser.Id)
+	require.Len(t, events, 2)
+	require.Equal(t, testutil.FormatFakeCalendarTime(nine), *events[1].StartTime,
+		"the slot the required member is free in is chosen")
+
+	rr = scheduleMeeting(outsider.Id, group.Id, newBody(60))
+	require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode, "only members can schedule group meetings")
+
+	rr = scheduleMeeting(organiser.Id, lonelyGroup.Id, newBody(60))
+	require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode, "the group needs other members")
+
+	rr = scheduleMeeting(organiser.Id, group.Id, newBody(150))
+	require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode, "the quorum is a percentage")
+}
+
+func TestGroupMeeting_PostAPISlotifyGroupsSlotifyGroupIDMeetingsRequiredMembers(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	// Without preferences a slot search for Microsoft users would use MSFT findMeetingTimes
+	organiser := testutil.InsertUser(t, db)
+	required := testutil.InsertUser(t, db)
+	optional := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, organiser.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, required.Id, group.Id)
+	testutil.AddUserToSlotifyGroup(t, db, optional.Id, group.Id)
+	require.Equal(t, http.StatusOK,
+		putGroupAttendeeType(t, server, organiser.Id, group.Id, required.Id, api.Required).Result().StatusCode)
+	require.Equal(t, http.StatusOK,
+		putGroupAttendeeType(t, server, organiser.Id, group.Id, optional.Id, api.Optional).Result().StatusCode)
+
+	nine := nextWeekday().Add(9 * time.Hour)
+	fakeCalendar.AddEvent(required.Id, newFakeCalendarEvent("standup", nine, nine.Add(time.Hour)))
+
+	subject := "Team sync"
+	duration := "PT1H"
+	body := api.SlotifyGroupMeetingBody{
+		Event:           api.CalendarEvent{Subject: &subject, Attendees: []api.Attendee{}, Locations: []api.Location{}},
+		MeetingDuration: &duration,
+		TimeConstraint: api.TimeConstraint{
+			TimeSlots: []api.MeetingTimeSlot{{Start: nine, End: nine.Add(2 * time.Hour)}},
+		},
+	}
+	rr := doJSONRequest(t, http.MethodPost, fmt.Sprintf("/api/slotify-groups/%d/meetings", group.Id), organiser.Id,
+		body, func(w http.ResponseWriter, r *http.Request) {
+			server.PostAPISlotifyGroupsSlotifyGroupIDMeetings(w, r, group.Id)
+		})
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	events := fakeCalendar.Events(organiser.Id)
+	require.Len(t, events, 1, "the meeting is created in the organiser's calendar")
+	require.Equal(t, testutil.FormatFakeCalendarTime(nine.Add(time.Hour)), *events[0].StartTime,
+		"the first slot the required member is free in is chosen")
+}
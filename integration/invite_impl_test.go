@@ -46,7 +46,7 @@ func TestInvites_PostInvites(t *testing.T) {
 	// Setup
 	slotifyGroup := testutil.InsertSlotifyGroup(t, db)
 
-	testutil.AddUserToSlotifyGroup(t, db, fromUser.Id, slotifyGroup.Id)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, fromUser.Id, slotifyGroup.Id, api.Admin)
 
 	// test 1 setup
 	inviteBody1 := api.PostAPIInvitesJSONRequestBody{
@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/cache"
+	"github.com/SlotifyApp/slotify-backend/msalcache"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMSALCache stands in for the MSAL in-memory cache, which is (un)marshalled by the DBCache.
+type fakeMSALCache struct {
+	data []byte
+}
+
+func (f *fakeMSALCache) Marshal() ([]byte, error) {
+	return f.data, nil
+}
+
+func (f *fakeMSALCache) Unmarshal(b []byte) error {
+	f.data = b
+	return nil
+}
+
+func TestMSALCache_SurvivesRestart(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err, "failed to generate key")
+
+	partitionKey := uuid.NewString()
+	tokens := []byte(`{"AccessToken":{"some-token":{"secret":"super-secret-access-token"}}}`)
+
+	// Export with the first "process"
+	db1 := testutil.NewDB(t, t.Context())
+	t.Cleanup(func() {
+		testutil.CloseDB(db1.DB)
+	})
+
+	cache1, err := msalcache.NewDBCache(db1, key)
+	require.NoError(t, err, "failed to create db cache")
+
+	err = cache1.Export(t.Context(), &fakeMSALCache{data: tokens}, cache.ExportHints{PartitionKey: partitionKey})
+	require.NoError(t, err, "failed to export cache")
+
+	stored, err := db1.GetMSALTokenCache(t.Context(), partitionKey)
+	require.NoError(t, err, "failed to get stored cache")
+	require.False(t, bytes.Contains(stored, []byte("super-secret-access-token")), "cache must be encrypted at rest")
+
+	// Replace with a second "process" after a restart
+	db2 := testutil.NewDB(t, t.Context())
+	t.Cleanup(func() {
+		testutil.CloseDB(db2.DB)
+	})
+
+	cache2, err := msalcache.NewDBCache(db2, key)
+	require.NoError(t, err, "failed to create db cache")
+
+	restored := &fakeMSALCache{}
+	err = cache2.Replace(t.Context(), restored, cache.ReplaceHints{PartitionKey: partitionKey})
+	require.NoError(t, err, "failed to replace cache")
+	require.Equal(t, tokens, restored.data)
+
+	// A partition that was never exported leaves the in-memory cache untouched
+	untouched := &fakeMSALCache{data: []byte("in-memory")}
+	err = cache2.Replace(t.Context(), untouched, cache.ReplaceHints{PartitionKey: uuid.NewString()})
+	require.NoError(t, err, "missing partition should not error")
+	require.Equal(t, []byte("in-memory"), untouched.data)
+
+	// A cache that was encrypted with a different key cannot be read
+	otherKey := make([]byte, 32)
+	_, err = rand.Read(otherKey)
+	require.NoError(t, err, "failed to generate key")
+
+	cache3, err := msalcache.NewDBCache(db2, otherKey)
+	require.NoError(t, err, "failed to create db cache")
+	err = cache3.Replace(t.Context(), &fakeMSALCache{}, cache.ReplaceHints{PartitionKey: partitionKey})
+	require.Error(t, err, "wrong key should fail to decrypt")
+}
+
+func TestMSALCache_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	db := testutil.NewDB(t, t.Context())
+	t.Cleanup(func() {
+		testutil.CloseDB(db.DB)
+	})
+
+	_, err := msalcache.NewDBCache(db, []byte("too-short"))
+	require.ErrorIs(t, err, msalcache.ErrEncryptionKeyInvalid)
+}
@@ -0,0 +1,281 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotification_RenderEventResume(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	// The client saw the first notification before it was disconnected
+	created := time.Now().UTC().Truncate(time.Second)
+	var notifIDs []int64
+	for _, message := range []string{"Seen before disconnecting", "Sent while disconnected"} {
+		notifID, err := slotifyDB.CreateNotification(t.Context(), database.CreateNotificationParams{
+			Message: message,
+			Created: created,
+		})
+		require.NoError(t, err, "failed to create notification")
+
+		_, err = slotifyDB.CreateUserNotification(t.Context(), database.CreateUserNotificationParams{
+			UserID: user.Id,
+			//nolint: gosec // id is unsigned 32 bit int
+			NotificationID: uint32(notifID),
+			Channel:        string(notification.ChannelInApp),
+		})
+		require.NoError(t, err, "failed to link user to notification")
+
+		notifIDs = append(notifIDs, notifID)
+	}
+
+	//nolint: gosec // id is unsigned 32 bit int
+	lastEventID := uint32(notifIDs[0])
+
+	// The stream is held open until the request is done
+	ctx, cancel := context.WithTimeout(t.Context(), 500*time.Millisecond)
+	defer cancel()
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/events", nil)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(uint64(lastEventID), 10))
+	req = withUser(req, user.Id)
+	rr := httptest.NewRecorder()
+
+	server.RenderEvent(rr, req, api.RenderEventParams{LastEventID: &lastEventID})
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, "text/event-stream", rr.Result().Header.Get("Content-Type"))
+
+	body := rr.Body.String()
+	require.True(t, strings.HasPrefix(body, "retry: 3000\n\n"), "reconnect delay is sent first")
+	require.Contains(t, body, fmt.Sprintf("id: %d\nevent: calendar_notification\n", notifIDs[1]),
+		"missed notification is replayed with its id")
+	require.Contains(t, body, "Sent while disconnected")
+	require.NotContains(t, body, "Seen before disconnecting", "notifications the client saw are not replayed")
+}
+
+// getInbox gets a page of a user's notifications with the status filter.
+func getInbox(t *testing.T, server *api.Server, userID uint32,
+	params api.GetAPIUsersMeNotificationsParams,
+) ([]api.InboxNotification, uint32) {
+	req := httptest.NewRequest(http.MethodGet, "/api/users/me/notifications", nil)
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	server.GetAPIUsersMeNotifications(rr, req, params)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var page api.NotificationsAndPagination
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&page), "response body can be decoded")
+	return page.Notifications, page.NextPageToken
+}
+
+// getUnreadCount gets a user's unread notification count.
+func getUnreadCount(t *testing.T, server *api.Server, userID uint32) int64 {
+	req := httptest.NewRequest(http.MethodGet, "/api/users/me/notifications/unread-count", nil)
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	server.GetAPIUsersMeNotificationsUnreadCount(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+
+	var count api.UnreadNotificationCount
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&count), "response body can be decoded")
+	return count.Count
+}
+
+// markRead marks a user's notifications as read.
+func markRead(t *testing.T, server *api.Server, userID uint32, body api.NotificationsReadBody) {
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/users/me/notifications/read", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	server.PatchAPIUsersMeNotificationsRead(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+}
+
+func TestNotification_Inbox(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+	other := testutil.InsertUser(t, db)
+
+	notifCount := 5
+	for i := range notifCount {
+		notif, err := notification.NewNotificationParams(notification.GroupJoined{
+			SlotifyGroupID: 1,
+			GroupName:      fmt.Sprintf("Team %d", i),
+		}, time.Now())
+		require.NoError(t, err)
+		testutil.CreateNotificationAndLink(t, t.Context(), slotifyDB, notif, user.Id)
+	}
+
+	all := api.All
+	notifs, _ := getInbox(t, server, user.Id, api.GetAPIUsersMeNotificationsParams{Limit: 10, Status: &all})
+	require.Len(t, notifs, notifCount)
+	require.Equal(t, "You were added to SlotifyGroup Team 4!", notifs[0].Message, "newest notification is first")
+
+	_, err := slotifyDB.MarkNotificationAsRead(t.Context(), database.MarkNotificationAsReadParams{
+		UserID:         user.Id,
+		NotificationID: notifs[0].Id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(notifCount-1), getUnreadCount(t, server, user.Id))
+
+	// Unread notifications are paged through by default
+	var unread []api.InboxNotification
+	var pageToken *uint32
+	for range notifCount {
+		page, next := getInbox(t, server, user.Id, api.GetAPIUsersMeNotificationsParams{
+			Limit:     2,
+			PageToken: pageToken,
+		})
+		unread = append(unread, page...)
+		if next == 0 {
+			break
+		}
+		pageToken = &next
+	}
+	require.Len(t, unread, notifCount-1, "all unread notifications are paged through")
+	for i, n := range unread {
+		require.False(t, n.IsRead)
+		require.Equal(t, notifs[i+1].Id, n.Id, "pages are in order")
+	}
+
+	read := api.Read
+	readNotifs, next := getInbox(t, server, user.Id, api.GetAPIUsersMeNotificationsParams{Limit: 10, Status: &read})
+	require.Len(t, readNotifs, 1, "read history has the read notification")
+	require.True(t, readNotifs[0].IsRead)
+	require.Zero(t, next, "there is no next page")
+
+	// Other users' notifications are left alone
+	markRead(t, server, user.Id, api.NotificationsReadBody{NotificationIDs: &[]uint32{notifs[1].Id, 0}})
+	require.Equal(t, int64(notifCount-2), getUnreadCount(t, server, user.Id))
+
+	markRead(t, server, user.Id, api.NotificationsReadBody{})
+	require.Zero(t, getUnreadCount(t, server, user.Id), "all notifications are marked as read")
+	require.Zero(t, getUnreadCount(t, server, other.Id))
+
+	deleteReq := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/notifications/%d", notifs[0].Id), nil)
+	deleteReq = withUser(deleteReq, user.Id)
+	rr := httptest.NewRecorder()
+	server.DeleteAPINotificationsNotificationID(rr, deleteReq, notifs[0].Id)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	testutil.OpenAPIValidateTest(t, rr, deleteReq)
+
+	notifs, _ = getInbox(t, server, user.Id, api.GetAPIUsersMeNotificationsParams{Limit: 10, Status: &all})
+	require.Len(t, notifs, notifCount-1, "deleted notification is no longer in the inbox")
+
+	rr = httptest.NewRecorder()
+	server.DeleteAPINotificationsNotificationID(rr, withUser(deleteReq, other.Id), notifs[0].Id)
+	require.Equal(t, http.StatusNotFound, rr.Result().StatusCode, "users can't delete others' notifications")
+}
+
+func TestNotification_RenderEventWebSocket(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	// The client saw the first notification before it was disconnected
+	for _, name := range []string{"Seen", "Missed"} {
+		notif, err := notification.NewNotificationParams(notification.GroupJoined{
+			SlotifyGroupID: 1,
+			GroupName:      name,
+		}, time.Now())
+		require.NoError(t, err)
+		testutil.CreateNotificationAndLink(t, t.Context(), slotifyDB, notif, user.Id)
+	}
+	all := api.All
+	notifs, _ := getInbox(t, server, user.Id, api.GetAPIUsersMeNotificationsParams{Limit: 10, Status: &all})
+	require.Len(t, notifs, 2)
+	// Oldest first
+	notifIDs := []uint32{notifs[1].Id, notifs[0].Id}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params api.RenderEventWebSocketParams
+		if lastEventID, err := strconv.ParseUint(r.URL.Query().Get("lastEventID"), 10, 32); err == nil {
+			//nolint: gosec // parsed as a 32 bit uint
+			id := uint32(lastEventID)
+			params.LastEventID = &id
+		}
+		server.RenderEventWebSocket(w, withUser(r, user.Id), params)
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	// Browsers on other sites can't connect
+	_, resp, err := websocket.DefaultDialer.DialContext(t.Context(), wsURL,
+		http.Header{"Origin": []string{"https://evil.example.com"}})
+	require.Error(t, err, "cross origin handshakes fail")
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(t.Context(),
+		fmt.Sprintf("%s?lastEventID=%d", wsURL, notifIDs[0]), nil)
+	require.NoError(t, err, "dialing websocket should not return error")
+	defer resp.Body.Close()
+	defer conn.Close()
+
+	var msg notification.WebSocketMessage
+	require.NoError(t, conn.ReadJSON(&msg), "missed notification is replayed")
+	require.Equal(t, notification.WebSocketMessageNotification, msg.Type)
+	require.Equal(t, notifIDs[1], msg.ID)
+
+	require.NoError(t, conn.WriteJSON(notification.WebSocketMessage{
+		Type: notification.WebSocketMessageAck,
+		ID:   notifIDs[1],
+	}))
+	// Messages are handled in order, so the ack is handled before the pong is sent
+	require.NoError(t, conn.WriteJSON(notification.WebSocketMessage{Type: notification.WebSocketMessagePing}))
+
+	require.NoError(t, conn.ReadJSON(&msg), "ping is answered")
+	require.Equal(t, notification.WebSocketMessagePong, msg.Type)
+
+	unread, err := slotifyDB.GetUnreadUserNotifications(t.Context(), user.Id)
+	require.NoError(t, err)
+	require.Len(t, unread, 1, "acked notification is marked as read")
+	require.Equal(t, notifIDs[0], unread[0].ID)
+}
@@ -0,0 +1,251 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/logger"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingService is a notification.Service that keeps the notifications delivered to some users.
+// Delivering another user's notification fails the test, the dispatcher took a notification it shouldn't have.
+type recordingService struct {
+	notification.Service
+
+	t       *testing.T
+	mu      sync.Mutex
+	userIDs []uint32
+	// failures is how many deliveries to the users fail before they succeed.
+	failures  int
+	delivered []database.Notification
+}
+
+func (s *recordingService) DeliverNotification(_ context.Context, _ *logger.Logger,
+	_ database.NotificationDatabase, recipients []database.NotificationRecipient, notif database.Notification,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !slices.ContainsFunc(recipients, func(r database.NotificationRecipient) bool {
+		return slices.Contains(s.userIDs, r.UserID)
+	}) {
+		s.t.Errorf("notification id(%d) of another test was dispatched", notif.ID)
+		return errors.New("notification is for another test")
+	}
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("client went away")
+	}
+	s.delivered = append(s.delivered, notif)
+	return nil
+}
+
+func (s *recordingService) Delivered() []database.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]database.Notification{}, s.delivered...)
+}
+
+// enqueue enqueues a notification for a user in a transaction, which is committed if commit is true.
+func enqueue(t *testing.T, db *database.Database, key string, userID uint32, commit bool) {
+	// Created in the past so it is due straight away
+	notif, err := notification.NewNotificationParams(notification.GroupJoined{
+		SlotifyGroupID: 1,
+		GroupName:      "Team",
+	}, time.Now().Add(-time.Minute))
+	require.NoError(t, err, "creating notification should not return error")
+
+	tx, err := db.DB.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	err = notification.Enqueue(t.Context(), db.WithTx(tx), key, []uint32{userID}, notif)
+	require.NoError(t, err, "enqueueing notification should not return error")
+
+	if commit {
+		require.NoError(t, tx.Commit())
+	} else {
+		require.NoError(t, tx.Rollback())
+	}
+}
+
+// deferOtherNotifications pushes back the due outbox notifications of everyone but userID, eg. ones left by
+// earlier tests, so a dispatcher only delivers userID's. The outbox is shared, so tests calling it can't be
+// parallel.
+func deferOtherNotifications(t *testing.T, db *database.Database, userID uint32) {
+	// The user id is passed as a JSON document
+	_, err := db.DB.ExecContext(t.Context(),
+		"UPDATE NotificationOutbox SET next_attempt_at=? WHERE delivered_at IS NULL AND NOT JSON_CONTAINS(user_ids, ?)",
+		time.Now().Add(time.Hour), strconv.FormatUint(uint64(userID), 10))
+	require.NoError(t, err, "failed to defer other outbox notifications")
+}
+
+// expireLease makes an outbox notification due again, as if the dispatcher that claimed it crashed.
+func expireLease(t *testing.T, db *database.Database, id uint32) {
+	_, err := db.DB.ExecContext(t.Context(),
+		"UPDATE NotificationOutbox SET next_attempt_at=? WHERE id=?", time.Now().Add(-time.Minute), id)
+	require.NoError(t, err, "failed to expire outbox lease")
+}
+
+func newDispatcher(t *testing.T, db *database.Database, service notification.Service) *notification.Dispatcher {
+	d, err := notification.NewDispatcher(db, service, testutil.NewLogger(t))
+	require.NoError(t, err, "creating dispatcher should not return error")
+	return d
+}
+
+func TestDispatcher_DeliversAfterCrash(t *testing.T) {
+	// Not parallel, a dispatcher delivers every due notification in the shared outbox
+	db := testutil.NewDB(t, t.Context())
+	user := testutil.InsertUser(t, db.DB)
+	deferOtherNotifications(t, db, user.Id)
+
+	// The replica crashes after the transaction is committed, before anything is delivered
+	enqueue(t, db, "", user.Id, true)
+
+	service := &recordingService{t: t, userIDs: []uint32{user.Id}}
+	d := newDispatcher(t, db, service)
+
+	_, err := d.DispatchDue(t.Context())
+	require.NoError(t, err, "dispatching should not return error")
+	require.Len(t, service.Delivered(), 1, "notification is delivered by another dispatcher")
+
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), db, user.Id)
+	require.Len(t, outbox, 1)
+	require.True(t, outbox[0].DeliveredAt.Valid, "notification is marked as delivered")
+
+	stored, err := db.GetUnreadUserNotifications(t.Context(), user.Id)
+	require.NoError(t, err)
+	require.Len(t, stored, 1, "notification is stored for the user")
+
+	_, err = d.DispatchDue(t.Context())
+	require.NoError(t, err, "dispatching should not return error")
+	require.Len(t, service.Delivered(), 1, "delivered notifications aren't delivered again")
+}
+
+func TestDispatcher_RolledBack(t *testing.T) {
+	// Not parallel, a dispatcher delivers every due notification in the shared outbox
+	db := testutil.NewDB(t, t.Context())
+	user := testutil.InsertUser(t, db.DB)
+	deferOtherNotifications(t, db, user.Id)
+
+	// The domain change failed, so the notification about it is never sent
+	enqueue(t, db, "", user.Id, false)
+
+	service := &recordingService{t: t, userIDs: []uint32{user.Id}}
+	_, err := newDispatcher(t, db, service).DispatchDue(t.Context())
+	require.NoError(t, err, "dispatching should not return error")
+
+	require.Empty(t, testutil.GetOutboxNotifications(t, t.Context(), db, user.Id), "nothing is enqueued")
+	require.Empty(t, service.Delivered(), "nothing is delivered")
+}
+
+func TestDispatcher_RetriesFailedDelivery(t *testing.T) {
+	// Not parallel, a dispatcher delivers every due notification in the shared outbox
+	db := testutil.NewDB(t, t.Context())
+	user := testutil.InsertUser(t, db.DB)
+	deferOtherNotifications(t, db, user.Id)
+
+	enqueue(t, db, "", user.Id, true)
+
+	// Delivery fails after the notification is stored
+	service := &recordingService{t: t, userIDs: []uint32{user.Id}, failures: 1}
+	d := newDispatcher(t, db, service)
+
+	_, err := d.DispatchDue(t.Context())
+	require.NoError(t, err, "failed deliveries are logged, not returned")
+	require.Empty(t, service.Delivered())
+
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), db, user.Id)
+	require.Len(t, outbox, 1)
+	require.False(t, outbox[0].DeliveredAt.Valid, "notification isn't marked as delivered")
+	require.True(t, outbox[0].NotificationID.Valid, "notification was stored")
+	require.Equal(t, "failed to deliver notification: client went away", outbox[0].LastError.String)
+	require.Equal(t, uint32(1), outbox[0].Attempts)
+	require.True(t, outbox[0].NextAttemptAt.After(time.Now()), "retry is backed off")
+
+	expireLease(t, db, outbox[0].ID)
+
+	_, err = d.DispatchDue(t.Context())
+	require.NoError(t, err, "dispatching should not return error")
+
+	delivered := service.Delivered()
+	require.Len(t, delivered, 1, "notification is delivered on retry")
+	//nolint: gosec // id is unsigned 32 bit int
+	require.Equal(t, uint32(outbox[0].NotificationID.Int32), delivered[0].ID,
+		"the notification stored the first time is delivered")
+
+	stored, err := db.GetUnreadUserNotifications(t.Context(), user.Id)
+	require.NoError(t, err)
+	require.Len(t, stored, 1, "notification isn't stored again on retry")
+}
+
+func TestDispatcher_RedeliversAfterLease(t *testing.T) {
+	// Not parallel, a dispatcher delivers every due notification in the shared outbox
+	db := testutil.NewDB(t, t.Context())
+	user := testutil.InsertUser(t, db.DB)
+	deferOtherNotifications(t, db, user.Id)
+
+	enqueue(t, db, "", user.Id, true)
+
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), db, user.Id)
+	require.Len(t, outbox, 1)
+
+	// Another replica claims the notification then crashes
+	rows, err := db.ClaimNotificationOutbox(t.Context(), database.ClaimNotificationOutboxParams{
+		NextAttemptAt: time.Now().Add(notification.DispatchLease),
+		ID:            outbox[0].ID,
+		Attempts:      outbox[0].Attempts,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows, "notification is claimed")
+
+	service := &recordingService{t: t, userIDs: []uint32{user.Id}}
+	d := newDispatcher(t, db, service)
+
+	_, err = d.DispatchDue(t.Context())
+	require.NoError(t, err, "dispatching should not return error")
+	require.Empty(t, service.Delivered(), "claimed notifications aren't delivered during the lease")
+
+	expireLease(t, db, outbox[0].ID)
+
+	_, err = d.DispatchDue(t.Context())
+	require.NoError(t, err, "dispatching should not return error")
+	require.Len(t, service.Delivered(), 1, "notification is delivered once the lease is over")
+
+	outbox = testutil.GetOutboxNotifications(t, t.Context(), db, user.Id)
+	require.Len(t, outbox, 1)
+	require.Equal(t, uint32(2), outbox[0].Attempts)
+	require.True(t, outbox[0].DeliveredAt.Valid, "notification is marked as delivered")
+}
+
+func TestEnqueue_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	db := testutil.NewDB(t, t.Context())
+	user := testutil.InsertUser(t, db.DB)
+
+	key := notification.IdempotencyKey(string(notification.KindGroupJoined), uuid.NewString())
+	enqueue(t, db, key, user.Id, true)
+	// The same change is handled again, eg. a webhook delivered twice
+	enqueue(t, db, key, user.Id, true)
+
+	require.Len(t, testutil.GetOutboxNotifications(t, t.Context(), db, user.Id), 1,
+		"notifications with the same key are enqueued once")
+
+	enqueue(t, db, "", user.Id, true)
+	enqueue(t, db, "", user.Id, true)
+
+	require.Len(t, testutil.GetOutboxNotifications(t, t.Context(), db, user.Id), 3,
+		"notifications without a key are always enqueued")
+}
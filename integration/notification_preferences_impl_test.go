@@ -0,0 +1,180 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// putNotificationPreferences sets prefs as the notification preferences of userID.
+func putNotificationPreferences(t *testing.T, server *api.Server, userID uint32,
+	prefs api.NotificationPreferences,
+) *httptest.ResponseRecorder {
+	reqBody, err := json.Marshal(prefs)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPut, "/api/users/me/notification-preferences", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	server.PutAPIUsersMeNotificationPreferences(rr, req)
+	return rr
+}
+
+// putSlotifyGroupNotificationPreferences sets prefs as the default notification channels of a slotifyGroup
+// as userID.
+func putSlotifyGroupNotificationPreferences(t *testing.T, server *api.Server, userID uint32, slotifyGroupID uint32,
+	prefs api.SlotifyGroupNotificationPreferences,
+) *httptest.ResponseRecorder {
+	reqBody, err := json.Marshal(prefs)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPut, "/api/slotify-groups/1/notification-preferences",
+		bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	server.PutAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(rr, req, slotifyGroupID)
+	return rr
+}
+
+func TestNotificationPreferences_UserPreferences(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	getPrefs := func() api.NotificationPreferences {
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/notification-preferences", nil)
+		req = withUser(req, user.Id)
+		rr := httptest.NewRecorder()
+		server.GetAPIUsersMeNotificationPreferences(rr, req)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+		var got api.NotificationPreferences
+		require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+		return got
+	}
+
+	require.Equal(t, api.NotificationPreferences{Channels: []api.NotificationChannelPreference{}}, getPrefs(),
+		"users start without preferences")
+
+	prefs := api.NotificationPreferences{
+		Channels: []api.NotificationChannelPreference{
+			{Kind: api.GroupJoined, Channel: api.None},
+			{Kind: api.InviteReceived, Channel: api.EmailDigest},
+		},
+		QuietHours: &api.NotificationQuietHours{Start: "22:00", End: "07:00"},
+	}
+	rr := putNotificationPreferences(t, server, user.Id, prefs)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, prefs, getPrefs(), "saved preferences are returned")
+
+	// Preferences are replaced, not merged
+	prefs = api.NotificationPreferences{
+		Channels: []api.NotificationChannelPreference{{Kind: api.MeetingCreated, Channel: api.InApp}},
+	}
+	require.Equal(t, http.StatusOK, putNotificationPreferences(t, server, user.Id, prefs).Result().StatusCode)
+	require.Equal(t, prefs, getPrefs(), "preferences and quiet hours are replaced")
+
+	for name, invalid := range map[string]api.NotificationPreferences{
+		"invalid kind": {
+			Channels: []api.NotificationChannelPreference{{Kind: "party", Channel: api.None}},
+		},
+		"invalid channel": {
+			Channels: []api.NotificationChannelPreference{{Kind: api.GroupJoined, Channel: "pigeon"}},
+		},
+		"kind given twice": {
+			Channels: []api.NotificationChannelPreference{
+				{Kind: api.GroupJoined, Channel: api.None},
+				{Kind: api.GroupJoined, Channel: api.InApp},
+			},
+		},
+		"quiet hours start and end together": {
+			Channels:   []api.NotificationChannelPreference{},
+			QuietHours: &api.NotificationQuietHours{Start: "22:00", End: "22:00"},
+		},
+	} {
+		require.Equal(t, http.StatusBadRequest,
+			putNotificationPreferences(t, server, user.Id, invalid).Result().StatusCode, name)
+	}
+	require.Equal(t, prefs, getPrefs(), "invalid preferences aren't saved")
+}
+
+func TestNotificationPreferences_SlotifyGroupPreferences(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	member := testutil.InsertUser(t, db)
+	nonMember := testutil.InsertUser(t, db)
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, member.Id, group.Id, api.Admin)
+
+	getPrefs := func(userID uint32) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/slotify-groups/1/notification-preferences", nil)
+		req = withUser(req, userID)
+		rr := httptest.NewRecorder()
+		server.GetAPISlotifyGroupsSlotifyGroupIDNotificationPreferences(rr, req, group.Id)
+		return rr
+	}
+
+	prefs := api.SlotifyGroupNotificationPreferences{
+		Channels: []api.NotificationChannelPreference{{Kind: api.GroupMemberJoined, Channel: api.None}},
+	}
+	require.Equal(t, http.StatusOK,
+		putSlotifyGroupNotificationPreferences(t, server, member.Id, group.Id, prefs).Result().StatusCode)
+
+	rr := getPrefs(member.Id)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.SlotifyGroupNotificationPreferences
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+	require.Equal(t, prefs, got, "saved preferences are returned")
+
+	require.Equal(t, http.StatusBadRequest, getPrefs(nonMember.Id).Result().StatusCode,
+		"non members can't see the preferences")
+	require.Equal(t, http.StatusBadRequest,
+		putSlotifyGroupNotificationPreferences(t, server, nonMember.Id, group.Id, prefs).Result().StatusCode,
+		"non members can't set the preferences")
+
+	// The group's default mutes the notification for its member, but not for a user outside the group
+	notifParams, err := notification.NewNotificationParams(notification.GroupMemberJoined{
+		SlotifyGroupID: group.Id,
+		GroupName:      group.Name,
+		UserID:         nonMember.Id,
+		FirstName:      nonMember.FirstName,
+		LastName:       nonMember.LastName,
+	}, time.Now().UTC().Truncate(time.Second))
+	require.NoError(t, err, "creating notification should not return error")
+
+	err = notification.NewSSENotificationService().SendNotification(t.Context(), testutil.NewLogger(t), slotifyDB,
+		[]uint32{member.Id, nonMember.Id}, notifParams)
+	require.NoError(t, err, "sending notification should not return error")
+
+	memberNotifs, err := slotifyDB.GetUnreadUserNotifications(t.Context(), member.Id)
+	require.NoError(t, err)
+	require.Empty(t, memberNotifs, "notification muted by the group isn't stored for its member")
+
+	nonMemberNotifs, err := slotifyDB.GetUnreadUserNotifications(t.Context(), nonMember.Id)
+	require.NoError(t, err)
+	require.Len(t, nonMemberNotifs, 1, "notification is stored for users outside the group")
+}
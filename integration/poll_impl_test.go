@@ -0,0 +1,559 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// doJSONRequest sends a request with a json body to handler as userID.
+func doJSONRequest(t *testing.T, method, target string, userID uint32, body any,
+	handler func(http.ResponseWriter, *http.Request),
+) *httptest.ResponseRecorder {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		require.NoError(t, err, "could not marshal json req body")
+	}
+
+	req := httptest.NewRequest(method, target, bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	handler(rr, req)
+
+	if rr.Result().StatusCode < http.StatusBadRequest {
+		testutil.OpenAPIValidateTest(t, rr, req)
+	}
+	return rr
+}
+
+// decodePoll decodes a poll from a response.
+func decodePoll(t *testing.T, rr *httptest.ResponseRecorder) api.Poll {
+	var poll api.Poll
+	err := json.NewDecoder(rr.Result().Body).Decode(&poll)
+	require.NoError(t, err, "response body can be decoded into a poll")
+	return poll
+}
+
+// newPollCreateBody creates the body of a poll with a slot at each of starts, voted on by attendees.
+func newPollCreateBody(subject string, deadline time.Time, attendees []uint32, starts ...time.Time,
+) api.PollCreateBody {
+	options := make([]api.PollOptionBody, 0, len(starts))
+	for _, start := range starts {
+		options = append(options, api.PollOptionBody{StartTime: start, EndTime: start.Add(30 * time.Minute)})
+	}
+	return api.PollCreateBody{
+		Event:     api.CalendarEvent{Subject: &subject, Attendees: []api.Attendee{}, Locations: []api.Location{}},
+		Options:   options,
+		Attendees: &attendees,
+		Deadline:  deadline,
+	}
+}
+
+func TestPoll_PostAPIPollsAndVote(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+	groupMember := testutil.InsertUser(t, db)
+	outsider := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroup(t, db, organiser.Id, group.Id)
+	testutil.AddUserToSlotifyGroup(t, db, groupMember.Id, group.Id)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	body := newPollCreateBody("Planning", start.Add(-24*time.Hour), []uint32{attendee.Id}, start,
+		start.Add(2*time.Hour))
+	body.SlotifyGroupID = &group.Id
+
+	rr := doJSONRequest(t, http.MethodPost, "/api/polls", organiser.Id, body, server.PostAPIPolls)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	poll := decodePoll(t, rr)
+	require.Equal(t, api.Open, poll.Status)
+	require.Equal(t, organiser.Id, poll.OrganiserID)
+	require.ElementsMatch(t, []uint32{organiser.Id, attendee.Id, groupMember.Id}, poll.Voters,
+		"the organiser, attendees and group members vote")
+	require.Len(t, poll.Options, 2)
+	require.NotNil(t, poll.Event.TimeZone, "the meeting's time zone is set")
+	require.Equal(t, "UTC", *poll.Event.TimeZone, "the meeting is in the organiser's time zone")
+
+	for _, voter := range []api.User{attendee, groupMember} {
+		outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, voter.Id)
+		require.Len(t, outbox, 1, "voters are notified of the poll")
+		require.Equal(t, string(notification.KindPollCreated), outbox[0].Kind)
+	}
+	require.Empty(t, testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, organiser.Id),
+		"the organiser isn't notified of their own poll")
+
+	// Vote on the later slot
+	votes := api.PollVotesBody{Votes: []api.PollVoteBody{
+		{OptionID: poll.Options[0].Id, Vote: api.No},
+		{OptionID: poll.Options[1].Id, Vote: api.Yes},
+	}}
+	rr = doJSONRequest(t, http.MethodPut, fmt.Sprintf("/api/polls/%d/votes", poll.Id), attendee.Id, votes,
+		func(w http.ResponseWriter, r *http.Request) { server.PutAPIPollsPollIDVotes(w, r, poll.Id) })
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	voted := decodePoll(t, rr)
+	require.Equal(t, 1, voted.Options[0].No)
+	require.Equal(t, 1, voted.Options[1].Yes)
+	require.Len(t, voted.Votes, 2)
+
+	// Votes can be changed
+	votes = api.PollVotesBody{Votes: []api.PollVoteBody{{OptionID: poll.Options[0].Id, Vote: api.Maybe}}}
+	rr = doJSONRequest(t, http.MethodPut, fmt.Sprintf("/api/polls/%d/votes", poll.Id), attendee.Id, votes,
+		func(w http.ResponseWriter, r *http.Request) { server.PutAPIPollsPollIDVotes(w, r, poll.Id) })
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	voted = decodePoll(t, rr)
+	require.Equal(t, 0, voted.Options[0].No)
+	require.Equal(t, 1, voted.Options[0].Maybe)
+
+	// Votes are streamed to the other voters
+	for _, voter := range []api.User{organiser, groupMember} {
+		var kinds []string
+		for _, n := range testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, voter.Id) {
+			kinds = append(kinds, n.Kind)
+		}
+		require.Equal(t, 2, countKind(kinds, notification.KindPollVoteCast), "voters are sent each vote")
+	}
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, attendee.Id)
+	require.Len(t, outbox, 1, "the voter isn't sent their own votes")
+
+	// Voting on a slot that isn't in the poll is a bad request
+	votes = api.PollVotesBody{Votes: []api.PollVoteBody{{OptionID: poll.Options[1].Id + 100, Vote: api.Yes}}}
+	rr = doJSONRequest(t, http.MethodPut, fmt.Sprintf("/api/polls/%d/votes", poll.Id), attendee.Id, votes,
+		func(w http.ResponseWriter, r *http.Request) { server.PutAPIPollsPollIDVotes(w, r, poll.Id) })
+	require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	// Users who can't vote on the poll can't see it or vote on it
+	rr = doJSONRequest(t, http.MethodGet, fmt.Sprintf("/api/polls/%d", poll.Id), outsider.Id, nil,
+		func(w http.ResponseWriter, r *http.Request) { server.GetAPIPollsPollID(w, r, poll.Id) })
+	require.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	votes = api.PollVotesBody{Votes: []api.PollVoteBody{{OptionID: poll.Options[1].Id, Vote: api.Yes}}}
+	rr = doJSONRequest(t, http.MethodPut, fmt.Sprintf("/api/polls/%d/votes", poll.Id), outsider.Id, votes,
+		func(w http.ResponseWriter, r *http.Request) { server.PutAPIPollsPollIDVotes(w, r, poll.Id) })
+	require.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	// Voters can list the poll
+	open := api.Open
+	rr = doJSONRequest(t, http.MethodGet, "/api/polls/me", groupMember.Id, nil,
+		func(w http.ResponseWriter, r *http.Request) {
+			server.GetAPIPollsMe(w, r, api.GetAPIPollsMeParams{Status: &open})
+		})
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var polls []api.Poll
+	err := json.NewDecoder(rr.Result().Body).Decode(&polls)
+	require.NoError(t, err, "response body can be decoded into polls")
+	require.Len(t, polls, 1)
+	require.Equal(t, poll.Id, polls[0].Id)
+}
+
+// countKind counts the notifications of kind in kinds.
+func countKind(kinds []string, kind notification.Kind) int {
+	count := 0
+	for _, k := range kinds {
+		if k == string(kind) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPoll_PostAPIPollsInvalid(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+	group := testutil.InsertSlotifyGroup(t, db)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	deadline := start.Add(-24 * time.Hour)
+
+	tests := map[string]struct {
+		body func() api.PollCreateBody
+	}{
+		"deadline has passed": {
+			body: func() api.PollCreateBody {
+				return newPollCreateBody("Planning", time.Now().Add(-time.Hour), []uint32{attendee.Id}, start)
+			},
+		},
+		"slot ends before it starts": {
+			body: func() api.PollCreateBody {
+				body := newPollCreateBody("Planning", deadline, []uint32{attendee.Id}, start)
+				body.Options[0].EndTime = start.Add(-time.Hour)
+				return body
+			},
+		},
+		"nobody else to vote": {
+			body: func() api.PollCreateBody {
+				return newPollCreateBody("Planning", deadline, []uint32{organiser.Id}, start)
+			},
+		},
+		"attendee does not exist": {
+			body: func() api.PollCreateBody {
+				return newPollCreateBody("Planning", deadline, []uint32{attendee.Id + 1000}, start)
+			},
+		},
+		"organiser is not in the group": {
+			body: func() api.PollCreateBody {
+				body := newPollCreateBody("Planning", deadline, []uint32{attendee.Id}, start)
+				body.SlotifyGroupID = &group.Id
+				return body
+			},
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			rr := doJSONRequest(t, http.MethodPost, "/api/polls", organiser.Id, tt.body(), server.PostAPIPolls)
+			require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+		})
+	}
+
+	require.Equal(t, 0, testutil.GetCount(t, db, "Poll"), "no polls are created")
+}
+
+func TestPoll_PostAPIPollsPollIDClose(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	first := testutil.InsertUser(t, db)
+	second := testutil.InsertUser(t, db)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	body := newPollCreateBody("Planning", start.Add(-24*time.Hour), []uint32{first.Id, second.Id}, start,
+		start.Add(2*time.Hour), start.Add(4*time.Hour))
+
+	rr := doJSONRequest(t, http.MethodPost, "/api/polls", organiser.Id, body, server.PostAPIPolls)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	poll := decodePoll(t, rr)
+
+	// The last two slots tie on support, but someone can't make the second
+	for voterID, votes := range map[uint32][]api.PollVoteChoice{
+		first.Id:  {api.No, api.Yes, api.Maybe},
+		second.Id: {api.Maybe, api.No, api.Maybe},
+	} {
+		body := api.PollVotesBody{}
+		for i, vote := range votes {
+			body.Votes = append(body.Votes, api.PollVoteBody{OptionID: poll.Options[i].Id, Vote: vote})
+		}
+		rr = doJSONRequest(t, http.MethodPut, fmt.Sprintf("/api/polls/%d/votes", poll.Id), voterID, body,
+			func(w http.ResponseWriter, r *http.Request) { server.PutAPIPollsPollIDVotes(w, r, poll.Id) })
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	}
+
+	closePoll := func(userID uint32) *httptest.ResponseRecorder {
+		return doJSONRequest(t, http.MethodPost, fmt.Sprintf("/api/polls/%d/close", poll.Id), userID, api.PollCloseBody{},
+			func(w http.ResponseWriter, r *http.Request) { server.PostAPIPollsPollIDClose(w, r, poll.Id) })
+	}
+
+	// Only the organiser can close the poll
+	rr = closePoll(first.Id)
+	require.Equal(t, http.StatusForbidden, rr.Result().StatusCode)
+
+	rr = closePoll(organiser.Id)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	closed := decodePoll(t, rr)
+	require.Equal(t, api.Closed, closed.Status)
+	require.NotNil(t, closed.ChosenOptionID)
+	require.Equal(t, poll.Options[2].Id, *closed.ChosenOptionID, "the slot with the fewest objections is chosen")
+	require.NotNil(t, closed.EventID)
+
+	events := fakeCalendar.Events(organiser.Id)
+	require.Len(t, events, 1, "the meeting is created in the organiser's calendar")
+	require.Equal(t, *closed.EventID, *events[0].Id)
+	require.Equal(t, testutil.FormatFakeCalendarTime(start.Add(4*time.Hour)), *events[0].StartTime)
+	var attendees []string
+	for _, a := range events[0].Attendees {
+		attendees = append(attendees, string(a.Email))
+	}
+	require.ElementsMatch(t, []string{string(first.Email), string(second.Email)}, attendees,
+		"voters are invited to the meeting")
+
+	for _, voter := range []api.User{organiser, first, second} {
+		var kinds []string
+		for _, n := range testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, voter.Id) {
+			kinds = append(kinds, n.Kind)
+		}
+		require.Equal(t, 1, countKind(kinds, notification.KindPollClosed), "voters are told the poll closed")
+	}
+
+	// A closed poll can't be closed again or voted on
+	rr = closePoll(organiser.Id)
+	require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+	require.Len(t, fakeCalendar.Events(organiser.Id), 1, "no other meeting is created")
+
+	votes := api.PollVotesBody{Votes: []api.PollVoteBody{{OptionID: poll.Options[0].Id, Vote: api.Yes}}}
+	rr = doJSONRequest(t, http.MethodPut, fmt.Sprintf("/api/polls/%d/votes", poll.Id), first.Id, votes,
+		func(w http.ResponseWriter, r *http.Request) { server.PutAPIPollsPollIDVotes(w, r, poll.Id) })
+	require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestPoll_ClosePollsPastDeadline(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	var polls []api.Poll
+	for range 2 {
+		body := newPollCreateBody("Planning", start.Add(-24*time.Hour), []uint32{attendee.Id}, start,
+			start.Add(time.Hour))
+		rr := doJSONRequest(t, http.MethodPost, "/api/polls", organiser.Id, body, server.PostAPIPolls)
+		require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+		polls = append(polls, decodePoll(t, rr))
+	}
+
+	// Only the first poll's deadline has passed
+	_, err := db.ExecContext(t.Context(), "UPDATE Poll SET deadline=? WHERE id=?",
+		time.Now().UTC().Add(-time.Minute), polls[0].Id)
+	require.NoError(t, err, "failed to move poll deadline")
+
+	server.ClosePollsPastDeadline(t.Context())
+
+	rr := doJSONRequest(t, http.MethodGet, fmt.Sprintf("/api/polls/%d", polls[0].Id), organiser.Id, nil,
+		func(w http.ResponseWriter, r *http.Request) { server.GetAPIPollsPollID(w, r, polls[0].Id) })
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	closed := decodePoll(t, rr)
+	require.Equal(t, api.Closed, closed.Status, "polls past their deadline are closed")
+	require.NotNil(t, closed.ChosenOptionID)
+	require.Equal(t, polls[0].Options[0].Id, *closed.ChosenOptionID, "the earliest slot wins without votes")
+
+	rr = doJSONRequest(t, http.MethodGet, fmt.Sprintf("/api/polls/%d", polls[1].Id), organiser.Id, nil,
+		func(w http.ResponseWriter, r *http.Request) { server.GetAPIPollsPollID(w, r, polls[1].Id) })
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, api.Open, decodePoll(t, rr).Status, "polls before their deadline stay open")
+
+	require.Len(t, fakeCalendar.Events(organiser.Id), 1, "the closed poll's meeting is created")
+}
+
+func TestPoll_ClosePollsPastDeadlineRetries(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	fakeFactory := fakeCalendar.Factory()
+	var calendarDown atomic.Bool
+	factory := func(ctx context.Context, userID uint32) (api.CalendarProvider, error) {
+		if calendarDown.Load() {
+			return nil, errors.New("calendar is down")
+		}
+		return fakeFactory(ctx, userID)
+	}
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(), testutil.WithCalendarProviderFactory(factory))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	body := newPollCreateBody("Planning", start.Add(-24*time.Hour), []uint32{attendee.Id}, start, start.Add(time.Hour))
+	rr := doJSONRequest(t, http.MethodPost, "/api/polls", organiser.Id, body, server.PostAPIPolls)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	poll := decodePoll(t, rr)
+
+	_, err := db.ExecContext(t.Context(), "UPDATE Poll SET deadline=? WHERE id=?",
+		time.Now().UTC().Add(-time.Minute), poll.Id)
+	require.NoError(t, err, "failed to move poll deadline")
+
+	getPoll := func() database.Poll {
+		dbPoll, getErr := slotifyDB.GetPollByID(t.Context(), poll.Id)
+		require.NoError(t, getErr)
+		return dbPoll
+	}
+
+	calendarDown.Store(true)
+	server.ClosePollsPastDeadline(t.Context())
+
+	p := getPoll()
+	require.Equal(t, database.PollStatusOpen, p.Status, "the poll stays open if its meeting can't be created")
+	require.Equal(t, uint32(1), p.CloseAttempts)
+	require.True(t, p.NextCloseAttemptAt.Valid)
+	require.True(t, p.NextCloseAttemptAt.Time.After(time.Now()), "the poll isn't tried again straight away")
+
+	pollIDs, err := slotifyDB.ListPollsPastDeadline(t.Context(), database.ListPollsPastDeadlineParams{
+		Now:   time.Now().UTC(),
+		Limit: 50,
+	})
+	require.NoError(t, err)
+	require.NotContains(t, pollIDs, poll.Id, "polls waiting to be tried again aren't listed")
+
+	// Every attempt fails until the poll is given up on
+	for p.Status == database.PollStatusOpen {
+		_, err = db.ExecContext(t.Context(), "UPDATE Poll SET next_close_attempt_at=NULL WHERE id=?", poll.Id)
+		require.NoError(t, err)
+		server.ClosePollsPastDeadline(t.Context())
+		p = getPoll()
+	}
+	require.Equal(t, database.PollStatusFailed, p.Status, "the poll is marked failed")
+	require.Equal(t, uint32(5), p.CloseAttempts, "the poll is tried a limited number of times")
+
+	_, err = db.ExecContext(t.Context(), "UPDATE Poll SET next_close_attempt_at=NULL WHERE id=?", poll.Id)
+	require.NoError(t, err)
+	pollIDs, err = slotifyDB.ListPollsPastDeadline(t.Context(), database.ListPollsPastDeadlineParams{
+		Now:   time.Now().UTC(),
+		Limit: 50,
+	})
+	require.NoError(t, err)
+	require.NotContains(t, pollIDs, poll.Id, "failed polls aren't tried again")
+
+	// The organiser can still close a failed poll
+	calendarDown.Store(false)
+	rr = doJSONRequest(t, http.MethodPost, fmt.Sprintf("/api/polls/%d/close", poll.Id), organiser.Id,
+		api.PollCloseBody{},
+		func(w http.ResponseWriter, r *http.Request) { server.PostAPIPollsPollIDClose(w, r, poll.Id) })
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, api.Closed, decodePoll(t, rr).Status)
+	require.Len(t, fakeCalendar.Events(organiser.Id), 1, "the meeting is created once")
+}
+
+func TestPoll_ClosePollKeepsCreatedMeeting(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	body := newPollCreateBody("Planning", start.Add(-24*time.Hour), []uint32{attendee.Id}, start, start.Add(time.Hour))
+	rr := doJSONRequest(t, http.MethodPost, "/api/polls", organiser.Id, body, server.PostAPIPolls)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	poll := decodePoll(t, rr)
+
+	// An earlier attempt created the meeting in the second slot but failed to close the poll
+	event := fakeCalendar.AddEvent(organiser.Id, newFakeCalendarEvent("Planning", start.Add(time.Hour),
+		start.Add(time.Hour+30*time.Minute)))
+	_, err := slotifyDB.SetPollMeeting(t.Context(), database.SetPollMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		ChosenOptionID: sql.NullInt32{Int32: int32(poll.Options[1].Id), Valid: true},
+		EventID:        sql.NullString{String: *event.Id, Valid: true},
+		ID:             poll.Id,
+	})
+	require.NoError(t, err)
+
+	optionID := poll.Options[0].Id
+	rr = doJSONRequest(t, http.MethodPost, fmt.Sprintf("/api/polls/%d/close", poll.Id), organiser.Id,
+		api.PollCloseBody{OptionID: &optionID},
+		func(w http.ResponseWriter, r *http.Request) { server.PostAPIPollsPollIDClose(w, r, poll.Id) })
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	closed := decodePoll(t, rr)
+	require.Equal(t, api.Closed, closed.Status)
+	require.NotNil(t, closed.ChosenOptionID)
+	require.Equal(t, poll.Options[1].Id, *closed.ChosenOptionID, "the slot the meeting was created in is kept")
+	require.NotNil(t, closed.EventID)
+	require.Equal(t, *event.Id, *closed.EventID)
+	require.Len(t, fakeCalendar.Events(organiser.Id), 1, "no other meeting is created")
+}
+
+func TestPoll_PutAPIPollsPollIDVotesWhileClosing(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	body := newPollCreateBody("Planning", start.Add(-24*time.Hour), []uint32{attendee.Id}, start, start.Add(time.Hour))
+	rr := doJSONRequest(t, http.MethodPost, "/api/polls", organiser.Id, body, server.PostAPIPolls)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	poll := decodePoll(t, rr)
+
+	vote := func() *httptest.ResponseRecorder {
+		votes := api.PollVotesBody{Votes: []api.PollVoteBody{{OptionID: poll.Options[0].Id, Vote: api.Yes}}}
+		return doJSONRequest(t, http.MethodPut, fmt.Sprintf("/api/polls/%d/votes", poll.Id), attendee.Id, votes,
+			func(w http.ResponseWriter, r *http.Request) { server.PutAPIPollsPollIDVotes(w, r, poll.Id) })
+	}
+
+	now := time.Now().UTC()
+	claimed, err := slotifyDB.ClaimPollClose(t.Context(), database.ClaimPollCloseParams{
+		ClosingUntil: sql.NullTime{Time: now.Add(api.PollCloseLease), Valid: true},
+		ID:           poll.Id,
+		Now:          sql.NullTime{Time: now, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), claimed, "poll is claimed")
+	require.Equal(t, http.StatusBadRequest, vote().Result().StatusCode, "a poll being closed can't be voted on")
+
+	_, err = slotifyDB.ReleasePollClose(t.Context(), poll.Id)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, vote().Result().StatusCode, "the poll can be voted on once released")
+
+	// An attempt to close the poll created the meeting but failed to close it
+	_, err = slotifyDB.SetPollMeeting(t.Context(), database.SetPollMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		ChosenOptionID: sql.NullInt32{Int32: int32(poll.Options[1].Id), Valid: true},
+		EventID:        sql.NullString{String: "event", Valid: true},
+		ID:             poll.Id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, vote().Result().StatusCode,
+		"a poll whose meeting is recorded can't be voted on")
+}
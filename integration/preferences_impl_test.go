@@ -0,0 +1,251 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+// putPreferences saves prefs as the preferences of userID.
+func putPreferences(t *testing.T, server *api.Server, userID uint32,
+	prefs api.UserPreferencesBody,
+) *httptest.ResponseRecorder {
+	reqBody, err := json.Marshal(prefs)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPut, "/api/users/me/preferences", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	server.PutAPIUsersMePreferences(rr, req)
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+	return rr
+}
+
+func newPreferences() api.UserPreferencesBody {
+	maxMeetings := uint32(4)
+	return api.UserPreferencesBody{
+		LunchStartTime:    "12:00",
+		LunchEndTime:      "13:00",
+		WorkingHoursStart: "09:00",
+		WorkingHoursEnd:   "17:00",
+		WorkingDays:       []api.Weekday{api.Monday, api.Tuesday, api.Wednesday, api.Thursday, api.Friday},
+		MaxMeetingsPerDay: &maxMeetings,
+	}
+}
+
+func TestPreferences_CRUD(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	getPreferences := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/preferences", nil)
+		req = withUser(req, user.Id)
+		rr := httptest.NewRecorder()
+		server.GetAPIUsersMePreferences(rr, req)
+		testutil.OpenAPIValidateTest(t, rr, req)
+		return rr
+	}
+	deletePreferences := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/users/me/preferences", nil)
+		req = withUser(req, user.Id)
+		rr := httptest.NewRecorder()
+		server.DeleteAPIUsersMePreferences(rr, req)
+		testutil.OpenAPIValidateTest(t, rr, req)
+		return rr
+	}
+
+	require.Equal(t, http.StatusNotFound, getPreferences().Result().StatusCode, "user has no preferences yet")
+
+	prefs := newPreferences()
+	rr := putPreferences(t, server, user.Id, prefs)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	expected := api.UserPreferences{
+		UserID:            user.Id,
+		LunchStartTime:    prefs.LunchStartTime,
+		LunchEndTime:      prefs.LunchEndTime,
+		WorkingHoursStart: prefs.WorkingHoursStart,
+		WorkingHoursEnd:   prefs.WorkingHoursEnd,
+		WorkingDays:       prefs.WorkingDays,
+		TimeZone:          "UTC",
+		MaxMeetingsPerDay: prefs.MaxMeetingsPerDay,
+	}
+
+	rr = getPreferences()
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.UserPreferences
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+	require.Equal(t, expected, got, "saved preferences are returned in the user's time zone")
+
+	// Preferences follow the user's time zone rather than keeping their own
+	_, err := slotifyDB.UpdateUserTimeZone(t.Context(), database.UpdateUserTimeZoneParams{
+		TimeZone: "Europe/London",
+		ID:       user.Id,
+	})
+	require.NoError(t, err)
+	rr = getPreferences()
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+	require.Equal(t, "Europe/London", got.TimeZone, "preferences are in the user's new time zone")
+
+	// Saving again replaces the preferences
+	prefs.WorkingHoursStart = "08:30"
+	prefs.MaxMeetingsPerDay = nil
+	require.Equal(t, http.StatusOK, putPreferences(t, server, user.Id, prefs).Result().StatusCode)
+
+	rr = getPreferences()
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+	require.Equal(t, "08:30", got.WorkingHoursStart)
+	require.Nil(t, got.MaxMeetingsPerDay, "max meetings per day was cleared")
+
+	require.Equal(t, http.StatusOK, deletePreferences().Result().StatusCode)
+	require.Equal(t, http.StatusNotFound, getPreferences().Result().StatusCode, "preferences were deleted")
+	require.Equal(t, http.StatusNotFound, deletePreferences().Result().StatusCode, "nothing left to delete")
+}
+
+func TestPreferences_PutAPIUsersMePreferencesInvalid(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	tests := map[string]struct {
+		update  func(p *api.UserPreferencesBody)
+		testMsg string
+	}{
+		"working hours end before they start": {
+			update:  func(p *api.UserPreferencesBody) { p.WorkingHoursEnd = "08:00" },
+			testMsg: "working hours must end after they start",
+		},
+		"lunch end before it starts": {
+			update:  func(p *api.UserPreferencesBody) { p.LunchEndTime = p.LunchStartTime },
+			testMsg: "lunch must end after it starts",
+		},
+		"no working days": {
+			update:  func(p *api.UserPreferencesBody) { p.WorkingDays = []api.Weekday{} },
+			testMsg: "at least one working day is required",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			prefs := newPreferences()
+			tt.update(&prefs)
+
+			reqBody, err := json.Marshal(prefs)
+			require.NoError(t, err, "could not marshal json req body")
+
+			req := httptest.NewRequest(http.MethodPut, "/api/users/me/preferences", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req = withUser(req, user.Id)
+			rr := httptest.NewRecorder()
+
+			server.PutAPIUsersMePreferences(rr, req)
+
+			require.Equal(t, http.StatusBadRequest, rr.Result().StatusCode, tt.testMsg)
+		})
+	}
+}
+
+func TestPreferences_PostAPISchedulingSlots(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+
+	// Tokyo has no DST, so 18:00-23:00 is always 09:00-14:00 UTC with lunch at 11:00-12:00 UTC
+	_, err := slotifyDB.UpdateUserTimeZone(t.Context(), database.UpdateUserTimeZoneParams{
+		TimeZone: "Asia/Tokyo",
+		ID:       attendee.Id,
+	})
+	require.NoError(t, err)
+	prefs := newPreferences()
+	prefs.WorkingHoursStart = "18:00"
+	prefs.WorkingHoursEnd = "23:00"
+	prefs.LunchStartTime = "20:00"
+	prefs.LunchEndTime = "21:00"
+	require.Equal(t, http.StatusOK, putPreferences(t, server, attendee.Id, prefs).Result().StatusCode)
+
+	day := nextWeekday()
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	// No slot finder is given, the native scheduler is used as MSFT can't see the preferences
+	body := api.SchedulingSlotsBodySchema{
+		Attendees: []api.AttendeeBase{
+			{
+				AttendeeType: api.Required,
+				EmailAddress: api.EmailAddress{
+					Address: openapi_types.Email(attendee.Email),
+					Name:    attendee.FirstName,
+				},
+			},
+		},
+		MeetingDuration: "PT1H",
+		MeetingName:     "Preferences sync",
+		TimeConstraint: api.TimeConstraint{
+			TimeSlots: []api.MeetingTimeSlot{{Start: at(8, 0), End: at(16, 0)}},
+		},
+	}
+
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/scheduling/slots", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, organiser.Id)
+	rr := httptest.NewRecorder()
+
+	server.PostAPISchedulingSlots(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var resp api.SchedulingSlotsSuccessResponseBody
+	err = json.NewDecoder(rr.Result().Body).Decode(&resp)
+	require.NoError(t, err, "response body can be decoded into scheduling slots")
+	require.NotNil(t, resp.MeetingTimeSuggestions)
+
+	starts := []time.Time{}
+	for _, s := range *resp.MeetingTimeSuggestions {
+		starts = append(starts, s.MeetingTimeSlot.Start.UTC())
+	}
+	// Suggestions are ordered by their score, not their start time
+	require.ElementsMatch(t, []time.Time{at(9, 0), at(9, 30), at(10, 0), at(12, 0), at(12, 30), at(13, 0)}, starts,
+		"slots are within the attendee's working hours and miss their lunch")
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+}
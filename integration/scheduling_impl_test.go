@@ -0,0 +1,372 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+// nextWeekday returns midnight UTC of the next weekday at least two days away.
+func nextWeekday() time.Time {
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(48 * time.Hour)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.Add(24 * time.Hour)
+	}
+	return day
+}
+
+func TestScheduling_PostAPISchedulingSlotsNative(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+
+	nine := nextWeekday().Add(9 * time.Hour)
+	fakeCalendar.AddEvent(organiser.Id, newFakeCalendarEvent("standup", nine, nine.Add(time.Hour)))
+	fakeCalendar.AddEvent(attendee.Id, newFakeCalendarEvent("review", nine.Add(2*time.Hour), nine.Add(3*time.Hour)))
+	// The retro is only in the way of a weekly series' third occurrence
+	retro := nine.AddDate(0, 0, 14).Add(7 * time.Hour)
+	fakeCalendar.AddEvent(attendee.Id, newFakeCalendarEvent("retro", retro, retro.Add(time.Hour)))
+
+	native := api.Native
+	buffer := "PT15M"
+	newBody := func(start, end time.Time) api.SchedulingSlotsBodySchema {
+		return api.SchedulingSlotsBodySchema{
+			Attendees: []api.AttendeeBase{
+				{
+					AttendeeType: api.Required,
+					EmailAddress: api.EmailAddress{
+						Address: openapi_types.Email(attendee.Email),
+						Name:    attendee.FirstName,
+					},
+				},
+			},
+			BufferBefore:    &buffer,
+			BufferAfter:     &buffer,
+			MeetingDuration: "PT1H",
+			MeetingName:     "Native sync",
+			SlotFinder:      &native,
+			TimeConstraint: api.TimeConstraint{
+				TimeSlots: []api.MeetingTimeSlot{{Start: start, End: end}},
+			},
+		}
+	}
+
+	withRecurrence := func(b api.SchedulingSlotsBodySchema, r api.Recurrence) api.SchedulingSlotsBodySchema {
+		b.Recurrence = &r
+		return b
+	}
+	two, three := int32(2), int32(3)
+	endDate := openapi_types.Date{Time: nine.AddDate(0, 0, 7)}
+
+	tests := map[string]struct {
+		httpStatus     int
+		body           api.SchedulingSlotsBodySchema
+		expectedStarts []time.Time
+		testMsg        string
+	}{
+		"buffers are kept free": {
+			httpStatus: http.StatusOK,
+			body:       newBody(nine, nine.Add(4*time.Hour)),
+			// 10:00 and 10:30 are too close to the standup and the review with 15 minute buffers
+			expectedStarts: []time.Time{},
+			testMsg:        "no slot fits between the meetings with buffers",
+		},
+		"slots outside working hours are skipped": {
+			httpStatus:     http.StatusOK,
+			body:           newBody(nine.Add(7*time.Hour), nine.Add(10*time.Hour)),
+			expectedStarts: []time.Time{nine.Add(7 * time.Hour)},
+			testMsg:        "only 16:00-17:00 is within working hours",
+		},
+		"invalid buffer": {
+			httpStatus: http.StatusBadRequest,
+			body: func() api.SchedulingSlotsBodySchema {
+				b := newBody(nine, nine.Add(4*time.Hour))
+				invalid := "15 minutes"
+				b.BufferBefore = &invalid
+				return b
+			}(),
+			testMsg: "invalid buffers are a bad request",
+		},
+		"series busy on a later occurrence": {
+			httpStatus: http.StatusOK,
+			body: withRecurrence(newBody(nine.Add(7*time.Hour), nine.Add(10*time.Hour)),
+				api.Recurrence{Pattern: api.Weekly, Occurrences: &three}),
+			expectedStarts: []time.Time{},
+			testMsg:        "the attendee's retro clashes with the third occurrence",
+		},
+		"series ending before the clash": {
+			httpStatus: http.StatusOK,
+			body: withRecurrence(newBody(nine.Add(7*time.Hour), nine.Add(10*time.Hour)),
+				api.Recurrence{Pattern: api.Weekly, Occurrences: &two}),
+			expectedStarts: []time.Time{nine.Add(7 * time.Hour)},
+			testMsg:        "both occurrences are free",
+		},
+		"invalid recurrence": {
+			httpStatus: http.StatusBadRequest,
+			body: withRecurrence(newBody(nine, nine.Add(4*time.Hour)),
+				api.Recurrence{Pattern: api.Weekly, Occurrences: &two, EndDate: &endDate}),
+			testMsg: "occurrences and endDate can't both be set",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			reqBody, err := json.Marshal(tt.body)
+			require.NoError(t, err, "could not marshal json req body")
+
+			req := httptest.NewRequest(http.MethodPost, "/api/scheduling/slots", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req = withUser(req, organiser.Id)
+			rr := httptest.NewRecorder()
+
+			server.PostAPISchedulingSlots(rr, req)
+
+			require.Equal(t, tt.httpStatus, rr.Result().StatusCode)
+			if tt.httpStatus != http.StatusOK {
+				return
+			}
+
+			var resp api.SchedulingSlotsSuccessResponseBody
+			err = json.NewDecoder(rr.Result().Body).Decode(&resp)
+			require.NoError(t, err, "response body can be decoded into scheduling slots")
+			require.NotNil(t, resp.MeetingTimeSuggestions)
+
+			starts := []time.Time{}
+			for _, s := range *resp.MeetingTimeSuggestions {
+				starts = append(starts, s.MeetingTimeSlot.Start.UTC())
+			}
+			require.Equal(t, tt.expectedStarts, starts, tt.testMsg)
+
+			testutil.OpenAPIValidateTest(t, rr, req)
+		})
+	}
+}
+
+func TestScheduling_PostAPISchedulingSlotsTimeZones(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	// Neither has preferences, so both work 9 to 5 in their own time zone
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+	for id, timeZone := range map[uint32]string{organiser.Id: "Europe/London", attendee.Id: "America/New_York"} {
+		_, err := slotifyDB.UpdateUserTimeZone(t.Context(), database.UpdateUserTimeZoneParams{
+			TimeZone: timeZone,
+			ID:       id,
+		})
+		require.NoError(t, err, "failed to set user time zone")
+	}
+
+	// New York's clocks go forward on 14 March 2027, two weeks before London's
+	beforeChange := time.Date(2027, time.March, 12, 0, 0, 0, 0, time.UTC)
+	afterChange := time.Date(2027, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	native := api.Native
+	newBody := func(day time.Time) api.SchedulingSlotsBodySchema {
+		return api.SchedulingSlotsBodySchema{
+			Attendees: []api.AttendeeBase{
+				{
+					AttendeeType: api.Required,
+					EmailAddress: api.EmailAddress{
+						Address: openapi_types.Email(attendee.Email),
+						Name:    attendee.FirstName,
+					},
+				},
+			},
+			MeetingDuration: "PT1H",
+			MeetingName:     "Transatlantic sync",
+			SlotFinder:      &native,
+			TimeConstraint: api.TimeConstraint{
+				TimeSlots: []api.MeetingTimeSlot{
+					{Start: day.Add(12*time.Hour + 30*time.Minute), End: day.Add(14*time.Hour + 30*time.Minute)},
+				},
+			},
+		}
+	}
+	singapore := "Singapore Standard Time"
+
+	tests := map[string]struct {
+		body           api.SchedulingSlotsBodySchema
+		expectedStarts []time.Time
+		expectedOffset int
+		testMsg        string
+	}{
+		"before new york's clocks change": {
+			body:           newBody(beforeChange),
+			expectedStarts: []time.Time{},
+			testMsg:        "9am in New York is 14:00 UTC",
+		},
+		"after new york's clocks change": {
+			body:           newBody(afterChange),
+			expectedStarts: []time.Time{afterChange.Add(13 * time.Hour), afterChange.Add(13*time.Hour + 30*time.Minute)},
+			testMsg:        "9am in New York is 13:00 UTC",
+		},
+		"request time zone": {
+			body: func() api.SchedulingSlotsBodySchema {
+				b := newBody(afterChange)
+				b.TimeZone = &singapore
+				return b
+			}(),
+			expectedStarts: []time.Time{afterChange.Add(13 * time.Hour), afterChange.Add(13*time.Hour + 30*time.Minute)},
+			expectedOffset: 8 * 60 * 60,
+			testMsg:        "slots are returned in the request's time zone",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			reqBody, err := json.Marshal(tt.body)
+			require.NoError(t, err, "could not marshal json req body")
+
+			req := httptest.NewRequest(http.MethodPost, "/api/scheduling/slots", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req = withUser(req, organiser.Id)
+			rr := httptest.NewRecorder()
+
+			server.PostAPISchedulingSlots(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+			var resp api.SchedulingSlotsSuccessResponseBody
+			err = json.NewDecoder(rr.Result().Body).Decode(&resp)
+			require.NoError(t, err, "response body can be decoded into scheduling slots")
+			require.NotNil(t, resp.MeetingTimeSuggestions)
+
+			starts := []time.Time{}
+			for _, s := range *resp.MeetingTimeSuggestions {
+				_, offset := s.MeetingTimeSlot.Start.Zone()
+				require.Equal(t, tt.expectedOffset, offset, tt.testMsg)
+				starts = append(starts, s.MeetingTimeSlot.Start.UTC())
+			}
+			require.ElementsMatch(t, tt.expectedStarts, starts, tt.testMsg)
+
+			testutil.OpenAPIValidateTest(t, rr, req)
+		})
+	}
+}
+
+func TestScheduling_PostAPISchedulingSlotsSlowAttendee(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	fakeFactory := fakeCalendar.Factory()
+
+	// slowUserID's calendar never responds, only giving up once its context is done
+	var slowUserID uint32
+	factory := func(ctx context.Context, userID uint32) (api.CalendarProvider, error) {
+		if userID == slowUserID {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return fakeFactory(ctx, userID)
+	}
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(factory),
+		testutil.WithAttendeeFetchConfig(api.AttendeeFetchConfig{Concurrency: 2, Timeout: 200 * time.Millisecond}))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+	slowAttendee := testutil.InsertUser(t, db)
+	slowUserID = slowAttendee.Id
+
+	// The slow attendee's working hours are read before their calendar so they still apply
+	prefs := newPreferences()
+	prefs.WorkingHoursStart = "11:00"
+	require.Equal(t, http.StatusOK, putPreferences(t, server, slowAttendee.Id, prefs).Result().StatusCode)
+
+	nine := nextWeekday().Add(9 * time.Hour)
+	fakeCalendar.AddEvent(attendee.Id, newFakeCalendarEvent("standup", nine, nine.Add(time.Hour)))
+
+	native := api.Native
+	body := api.SchedulingSlotsBodySchema{
+		Attendees: []api.AttendeeBase{
+			{
+				AttendeeType: api.Required,
+				EmailAddress: api.EmailAddress{
+					Address: openapi_types.Email(attendee.Email),
+					Name:    attendee.FirstName,
+				},
+			},
+			{
+				AttendeeType: api.Required,
+				EmailAddress: api.EmailAddress{
+					Address: openapi_types.Email(slowAttendee.Email),
+					Name:    slowAttendee.FirstName,
+				},
+			},
+		},
+		MeetingDuration: "PT1H",
+		MeetingName:     "Slow sync",
+		SlotFinder:      &native,
+		TimeConstraint: api.TimeConstraint{
+			TimeSlots: []api.MeetingTimeSlot{{Start: nine, End: nine.Add(3 * time.Hour)}},
+		},
+	}
+
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/scheduling/slots", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, organiser.Id)
+	rr := httptest.NewRecorder()
+
+	server.PostAPISchedulingSlots(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var resp api.SchedulingSlotsSuccessResponseBody
+	err = json.NewDecoder(rr.Result().Body).Decode(&resp)
+	require.NoError(t, err, "response body can be decoded into scheduling slots")
+	require.NotNil(t, resp.MeetingTimeSuggestions)
+	require.NotEmpty(t, *resp.MeetingTimeSuggestions, "the slow attendee doesn't stop slots being found")
+
+	for _, s := range *resp.MeetingTimeSuggestions {
+		require.False(t, s.MeetingTimeSlot.Start.Before(nine.Add(time.Hour)), "the other attendee is still busy 9-10")
+		require.False(t, s.MeetingTimeSlot.Start.Before(nine.Add(2*time.Hour)),
+			"the slow attendee's working hours start at 11")
+		require.NotNil(t, s.AttendeeAvailability)
+		for _, a := range *s.AttendeeAvailability {
+			if a.Attendee.EmailAddress.Address == openapi_types.Email(slowAttendee.Email) {
+				require.Equal(t, api.FreeBusyStatusUnknown, a.Availability, "the slow attendee is unknown")
+			}
+		}
+	}
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+}
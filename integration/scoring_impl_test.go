@@ -0,0 +1,204 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+// putScoringWeights sets weights as the scoring weights of a slotifyGroup as userID.
+func putScoringWeights(t *testing.T, server *api.Server, userID uint32, slotifyGroupID uint32,
+	weights api.ScoringWeights,
+) *httptest.ResponseRecorder {
+	reqBody, err := json.Marshal(weights)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/slotify-groups/%d/scoring-weights", slotifyGroupID),
+		bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, userID)
+	rr := httptest.NewRecorder()
+
+	server.PutAPISlotifyGroupsSlotifyGroupIDScoringWeights(rr, req, slotifyGroupID)
+	return rr
+}
+
+func TestScoring_ScoringWeights(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	member := testutil.InsertUser(t, db)
+	nonMember := testutil.InsertUser(t, db)
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, member.Id, group.Id, api.Admin)
+
+	getWeights := func(userID uint32) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/slotify-groups/%d/scoring-weights", group.Id), nil)
+		req = withUser(req, userID)
+		rr := httptest.NewRecorder()
+		server.GetAPISlotifyGroupsSlotifyGroupIDScoringWeights(rr, req, group.Id)
+		return rr
+	}
+
+	rr := getWeights(member.Id)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.ScoringWeights
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+	require.Equal(t, api.ScoringWeights{
+		MeetingLoad:         1,
+		BackToBack:          1,
+		TimeOfDay:           1,
+		PreferredRooms:      1,
+		TimeZoneFairness:    1,
+		PreferredRoomEmails: []openapi_types.Email{},
+	}, got, "groups without weights get the defaults")
+
+	weights := api.ScoringWeights{
+		MeetingLoad:         2,
+		BackToBack:          0.5,
+		TimeOfDay:           0,
+		PreferredRooms:      3,
+		TimeZoneFairness:    1,
+		PreferredRoomEmails: []openapi_types.Email{"boardroom@example.com", "studio@example.com"},
+	}
+	require.Equal(t, http.StatusOK, putScoringWeights(t, server, member.Id, group.Id, weights).Result().StatusCode)
+
+	rr = getWeights(member.Id)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&got))
+	require.Equal(t, weights, got, "saved weights are returned")
+
+	negative := weights
+	negative.MeetingLoad = -1
+	require.Equal(t, http.StatusBadRequest,
+		putScoringWeights(t, server, member.Id, group.Id, negative).Result().StatusCode,
+		"weights can't be negative")
+
+	require.Equal(t, http.StatusBadRequest, getWeights(nonMember.Id).Result().StatusCode,
+		"non members can't see the weights")
+	require.Equal(t, http.StatusBadRequest,
+		putScoringWeights(t, server, nonMember.Id, group.Id, weights).Result().StatusCode,
+		"non members can't set the weights")
+}
+
+func TestScoring_PostAPISchedulingSlotsBreakdown(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	attendee := testutil.InsertUser(t, db)
+	optional := testutil.InsertUser(t, db)
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, organiser.Id, group.Id, api.Admin)
+
+	// Only back to back meetings count for this group
+	require.Equal(t, http.StatusOK, putScoringWeights(t, server, organiser.Id, group.Id, api.ScoringWeights{
+		BackToBack:          1,
+		PreferredRoomEmails: []openapi_types.Email{},
+	}).Result().StatusCode)
+
+	nine := nextWeekday().Add(9 * time.Hour)
+	fakeCalendar.AddEvent(attendee.Id, newFakeCalendarEvent("first", nine, nine.Add(time.Hour)))
+	fakeCalendar.AddEvent(attendee.Id, newFakeCalendarEvent("second", nine.Add(2*time.Hour), nine.Add(3*time.Hour)))
+	fakeCalendar.AddEvent(optional.Id, newFakeCalendarEvent("third", nine.Add(3*time.Hour), nine.Add(4*time.Hour)))
+
+	native := api.Native
+	body := api.SchedulingSlotsBodySchema{
+		Attendees: []api.AttendeeBase{
+			{
+				AttendeeType: api.Required,
+				EmailAddress: api.EmailAddress{
+					Address: openapi_types.Email(attendee.Email),
+					Name:    attendee.FirstName,
+				},
+			},
+			{
+				AttendeeType: api.Optional,
+				EmailAddress: api.EmailAddress{
+					Address: openapi_types.Email(optional.Email),
+					Name:    optional.FirstName,
+				},
+			},
+		},
+		MeetingDuration: "PT1H",
+		MeetingName:     "Scored sync",
+		SlotFinder:      &native,
+		SlotifyGroupID:  &group.Id,
+		TimeConstraint: api.TimeConstraint{
+			TimeSlots: []api.MeetingTimeSlot{{Start: nine, End: nine.Add(4 * time.Hour)}},
+		},
+	}
+
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err, "could not marshal json req body")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/scheduling/slots", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUser(req, organiser.Id)
+	rr := httptest.NewRecorder()
+
+	server.PostAPISchedulingSlots(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	var resp api.SchedulingSlotsSuccessResponseBody
+	err = json.NewDecoder(rr.Result().Body).Decode(&resp)
+	require.NoError(t, err, "response body can be decoded into scheduling slots")
+	require.NotNil(t, resp.MeetingTimeSuggestions)
+	suggestions := *resp.MeetingTimeSuggestions
+	require.Len(t, suggestions, 2, "only 10-11 and 12-13 suit the required attendee")
+
+	// 12-13 is only next to one of the attendee's meetings, 10-11 is between both. 12-13 scores higher,
+	// but the optional attendee is busy then so 10-11 is still suggested first
+	expected := []struct {
+		start         time.Time
+		confidence    float64
+		score         float64
+		attendeeScore float64
+	}{
+		{start: nine.Add(time.Hour), confidence: 100, score: 200.0 / 3, attendeeScore: 0},
+		{start: nine.Add(3 * time.Hour), confidence: 50, score: 250.0 / 3, attendeeScore: 50},
+	}
+	for i, e := range expected {
+		s := suggestions[i]
+		require.True(t, e.start.Equal(s.MeetingTimeSlot.Start),
+			"suggestions are ordered by the attendees available and then by score")
+		require.Equal(t, int32(i+1), *s.Order)
+		require.InDelta(t, e.confidence, *s.Confidence, 0.001, "confidence is the percentage of attendees available")
+
+		require.NotNil(t, s.ScoreBreakdown)
+		require.InDelta(t, e.score, s.ScoreBreakdown.Score, 0.001)
+		require.Len(t, s.ScoreBreakdown.Attendees, 3, "the attendees and organiser are scored")
+
+		attendeeScore := s.ScoreBreakdown.Attendees[0]
+		require.Equal(t, openapi_types.Email(attendee.Email), attendeeScore.Email)
+		for _, f := range attendeeScore.Factors {
+			if f.Factor == api.BackToBack {
+				require.InDelta(t, e.attendeeScore, f.Score, 0.001)
+			}
+		}
+	}
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+}
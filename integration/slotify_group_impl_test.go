@@ -121,7 +121,7 @@ func TestSlotifyGroup_DeleteSlotifyGroupsSlotifyGroupID(t *testing.T) {
 
 	slotifyGroupInserted := testutil.InsertSlotifyGroup(t, db)
 	u := testutil.InsertUser(t, db)
-	testutil.AddUserToSlotifyGroup(t, db, u.Id, slotifyGroupInserted.Id)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, u.Id, slotifyGroupInserted.Id, api.Owner)
 
 	tests := map[string]struct {
 		expectedRespBody any
@@ -130,7 +130,7 @@ func TestSlotifyGroup_DeleteSlotifyGroupsSlotifyGroupID(t *testing.T) {
 		testMsg          string
 	}{
 		"deleting slotifyGroup that doesn't exist": {
-			expectedRespBody: "You are not a member of the group, you cannot delete it.",
+			expectedRespBody: "You are not a member of the group, you cannot delete the group.",
 			httpStatus:       http.StatusBadRequest,
 			slotifyGroupID:   10000,
 			testMsg:          "slotifyGroup that doesn't exist, returns client error",
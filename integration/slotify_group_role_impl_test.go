@@ -0,0 +1,370 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+// getGroupRole gets the role of userID in a group.
+func getGroupRole(t *testing.T, slotifyDB *database.Database, userID, slotifyGroupID uint32) api.SlotifyGroupRole {
+	role, err := slotifyDB.GetSlotifyGroupMemberRole(t.Context(), database.GetSlotifyGroupMemberRoleParams{
+		UserID:         userID,
+		SlotifyGroupID: slotifyGroupID,
+	})
+	require.NoError(t, err, "failed to get role of member")
+	return api.SlotifyGroupRole(role)
+}
+
+// outboxKinds gets the kinds of the notifications in userID's outbox.
+func outboxKinds(t *testing.T, slotifyDB *database.Database, userID uint32) []string {
+	var kinds []string
+	for _, n := range testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, userID) {
+		kinds = append(kinds, n.Kind)
+	}
+	return kinds
+}
+
+// putGroupRole sets the role of memberID in a group, as userID.
+func putGroupRole(t *testing.T, server *api.Server, userID, slotifyGroupID, memberID uint32,
+	role api.SlotifyGroupRole,
+) *httptest.ResponseRecorder {
+	return doJSONRequest(t, http.MethodPut,
+		fmt.Sprintf("/api/slotify-groups/%d/users/%d/role", slotifyGroupID, memberID), userID,
+		api.SlotifyGroupMemberRole{Role: role},
+		func(w http.ResponseWriter, r *http.Request) {
+			server.PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole(w, r, slotifyGroupID, memberID)
+		})
+}
+
+// removeGroupMember removes memberID from a group, as userID.
+func removeGroupMember(t *testing.T, server *api.Server, userID, slotifyGroupID, memberID uint32,
+) *httptest.ResponseRecorder {
+	return doJSONRequest(t, http.MethodDelete,
+		fmt.Sprintf("/api/slotify-groups/%d/users/%d", slotifyGroupID, memberID), userID, nil,
+		func(w http.ResponseWriter, r *http.Request) {
+			server.DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID(w, r, slotifyGroupID, memberID)
+		})
+}
+
+func TestSlotifyGroupRole_PostAPISlotifyGroupsCreatorIsOwner(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	user := testutil.InsertUser(t, db)
+
+	rr := doJSONRequest(t, http.MethodPost, "/api/slotify-groups", user.Id,
+		api.SlotifyGroupCreate{Name: "Design"}, server.PostAPISlotifyGroups)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	var group api.SlotifyGroup
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&group), "response body can be decoded")
+	require.Equal(t, api.Owner, getGroupRole(t, slotifyDB, user.Id, group.Id), "the creator owns the group")
+}
+
+func TestSlotifyGroupRole_DeleteAPISlotifyGroupsSlotifyGroupID(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	owner := testutil.InsertUser(t, db)
+	admin := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, owner.Id, group.Id, api.Owner)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, admin.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+
+	deleteGroup := func(userID uint32) *httptest.ResponseRecorder {
+		return doJSONRequest(t, http.MethodDelete, fmt.Sprintf("/api/slotify-groups/%d", group.Id), userID, nil,
+			func(w http.ResponseWriter, r *http.Request) {
+				server.DeleteAPISlotifyGroupsSlotifyGroupID(w, r, group.Id)
+			})
+	}
+
+	require.Equal(t, http.StatusForbidden, deleteGroup(member.Id).Result().StatusCode,
+		"members can't delete the group")
+	require.Equal(t, http.StatusForbidden, deleteGroup(admin.Id).Result().StatusCode,
+		"admins can't delete the group")
+	require.Equal(t, http.StatusOK, deleteGroup(owner.Id).Result().StatusCode, "the owner can delete the group")
+	require.Equal(t, 0, testutil.GetCount(t, db, "SlotifyGroup"), "the group is deleted")
+}
+
+func TestSlotifyGroupRole_PostAPIInvites(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	admin := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+	toUser := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, admin.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+
+	invite := func(userID uint32) *httptest.ResponseRecorder {
+		return doJSONRequest(t, http.MethodPost, "/api/invites", userID, api.PostAPIInvitesJSONRequestBody{
+			CreatedAt:      time.Now(),
+			ExpiryDate:     openapi_types.Date{Time: time.Now().Add(24 * time.Hour)},
+			Message:        "Join us",
+			SlotifyGroupID: group.Id,
+			ToUserID:       toUser.Id,
+		}, server.PostAPIInvites)
+	}
+
+	require.Equal(t, http.StatusForbidden, invite(member.Id).Result().StatusCode, "members can't invite")
+	require.Equal(t, http.StatusCreated, invite(admin.Id).Result().StatusCode, "admins can invite")
+}
+
+func TestSlotifyGroupRole_PutAPISlotifyGroupsSlotifyGroupIDUsersUserIDRole(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	owner := testutil.InsertUser(t, db)
+	admin := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+	outsider := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, owner.Id, group.Id, api.Owner)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, admin.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+
+	tests := map[string]struct {
+		httpStatus int
+		userID     uint32
+		memberID   uint32
+		role       api.SlotifyGroupRole
+		testMsg    string
+	}{
+		"admin promotes member": {
+			httpStatus: http.StatusForbidden,
+			userID:     admin.Id,
+			memberID:   member.Id,
+			role:       api.Admin,
+			testMsg:    "only the owner can change roles",
+		},
+		"member promotes themselves": {
+			httpStatus: http.StatusForbidden,
+			userID:     member.Id,
+			memberID:   member.Id,
+			role:       api.Admin,
+			testMsg:    "members can't promote themselves",
+		},
+		"outsider changes a role": {
+			httpStatus: http.StatusBadRequest,
+			userID:     outsider.Id,
+			memberID:   member.Id,
+			role:       api.Admin,
+			testMsg:    "users outside the group can't change roles",
+		},
+		"owner demotes themselves": {
+			httpStatus: http.StatusBadRequest,
+			userID:     owner.Id,
+			memberID:   owner.Id,
+			role:       api.Member,
+			testMsg:    "the owner can't change their own role",
+		},
+		"owner promotes an outsider": {
+			httpStatus: http.StatusNotFound,
+			userID:     owner.Id,
+			memberID:   outsider.Id,
+			role:       api.Admin,
+			testMsg:    "only members have a role",
+		},
+		"owner sets an unknown role": {
+			httpStatus: http.StatusBadRequest,
+			userID:     owner.Id,
+			memberID:   member.Id,
+			role:       "superuser",
+			testMsg:    "only owner, admin and member are roles",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			rr := putGroupRole(t, server, tt.userID, group.Id, tt.memberID, tt.role)
+			require.Equal(t, tt.httpStatus, rr.Result().StatusCode, tt.testMsg)
+		})
+	}
+
+	require.Equal(t, api.Member, getGroupRole(t, slotifyDB, member.Id, group.Id), "rejected changes aren't saved")
+
+	rr := putGroupRole(t, server, owner.Id, group.Id, member.Id, api.Admin)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, api.Admin, getGroupRole(t, slotifyDB, member.Id, group.Id), "the owner can promote members")
+
+	rr = putGroupRole(t, server, owner.Id, group.Id, admin.Id, api.Member)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, api.Member, getGroupRole(t, slotifyDB, admin.Id, group.Id), "the owner can demote admins")
+
+	rr = putGroupRole(t, server, owner.Id, group.Id, member.Id, api.Owner)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, api.Owner, getGroupRole(t, slotifyDB, member.Id, group.Id), "the owner can hand over the group")
+	require.Equal(t, api.Admin, getGroupRole(t, slotifyDB, owner.Id, group.Id), "the old owner becomes an admin")
+
+	rr = putGroupRole(t, server, owner.Id, group.Id, admin.Id, api.Admin)
+	require.Equal(t, http.StatusForbidden, rr.Result().StatusCode, "the old owner can no longer change roles")
+}
+
+func TestSlotifyGroupRole_DeleteAPISlotifyGroupsSlotifyGroupIDUsersUserID(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	owner := testutil.InsertUser(t, db)
+	admin := testutil.InsertUser(t, db)
+	otherAdmin := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+	otherMember := testutil.InsertUser(t, db)
+	outsider := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, owner.Id, group.Id, api.Owner)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, admin.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, otherAdmin.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+	testutil.AddUserToSlotifyGroup(t, db, otherMember.Id, group.Id)
+
+	tests := map[string]struct {
+		httpStatus int
+		userID     uint32
+		memberID   uint32
+		testMsg    string
+	}{
+		"member removes member": {
+			httpStatus: http.StatusForbidden,
+			userID:     member.Id,
+			memberID:   otherMember.Id,
+			testMsg:    "members can't remove anyone",
+		},
+		"admin removes admin": {
+			httpStatus: http.StatusForbidden,
+			userID:     admin.Id,
+			memberID:   otherAdmin.Id,
+			testMsg:    "admins can't remove other admins",
+		},
+		"admin removes owner": {
+			httpStatus: http.StatusForbidden,
+			userID:     admin.Id,
+			memberID:   owner.Id,
+			testMsg:    "admins can't remove the owner",
+		},
+		"admin removes themselves": {
+			httpStatus: http.StatusBadRequest,
+			userID:     admin.Id,
+			memberID:   admin.Id,
+			testMsg:    "members leave rather than remove themselves",
+		},
+		"admin removes outsider": {
+			httpStatus: http.StatusNotFound,
+			userID:     admin.Id,
+			memberID:   outsider.Id,
+			testMsg:    "only members can be removed",
+		},
+		"outsider removes member": {
+			httpStatus: http.StatusBadRequest,
+			userID:     outsider.Id,
+			memberID:   member.Id,
+			testMsg:    "users outside the group can't remove members",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			rr := removeGroupMember(t, server, tt.userID, group.Id, tt.memberID)
+			require.Equal(t, tt.httpStatus, rr.Result().StatusCode, tt.testMsg)
+		})
+	}
+
+	require.Equal(t, 5, testutil.GetCount(t, db, "UserToSlotifyGroup"), "rejected removals don't remove anyone")
+
+	rr := removeGroupMember(t, server, admin.Id, group.Id, member.Id)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode, "admins can remove members")
+	require.Equal(t, 0, countKind(outboxKinds(t, slotifyDB, member.Id), notification.KindGroupMemberLeft),
+		"the removed member isn't told they left")
+	require.Equal(t, 1, countKind(outboxKinds(t, slotifyDB, otherMember.Id), notification.KindGroupMemberLeft),
+		"the remaining members are told")
+
+	rr = removeGroupMember(t, server, owner.Id, group.Id, otherAdmin.Id)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode, "the owner can remove admins")
+	require.Equal(t, 3, testutil.GetCount(t, db, "UserToSlotifyGroup"))
+}
+
+func TestSlotifyGroupRole_DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	owner := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+	admin := testutil.InsertUser(t, db)
+	nonMember := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, owner.Id, group.Id, api.Owner)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, admin.Id, group.Id, api.Admin)
+
+	leave := func(userID uint32) *httptest.ResponseRecorder {
+		return doJSONRequest(t, http.MethodDelete, fmt.Sprintf("/api/slotify-groups/%d/leave/me", group.Id), userID,
+			nil,
+			func(w http.ResponseWriter, r *http.Request) {
+				server.DeleteSlotifyGroupsSlotifyGroupIDLeaveMe(w, r, group.Id)
+			})
+	}
+
+	require.Equal(t, http.StatusBadRequest, leave(nonMember.Id).Result().StatusCode,
+		"only members can leave the group")
+	require.Equal(t, 3, testutil.GetCount(t, db, "UserToSlotifyGroup"))
+
+	require.Equal(t, http.StatusOK, leave(owner.Id).Result().StatusCode)
+	require.Equal(t, api.Owner, getGroupRole(t, slotifyDB, admin.Id, group.Id),
+		"an admin takes over from the owner before members")
+	require.Equal(t, api.Member, getGroupRole(t, slotifyDB, member.Id, group.Id))
+
+	require.Equal(t, http.StatusOK, leave(admin.Id).Result().StatusCode)
+	require.Equal(t, api.Owner, getGroupRole(t, slotifyDB, member.Id, group.Id),
+		"a member takes over when there are no admins")
+
+	require.Equal(t, http.StatusOK, leave(member.Id).Result().StatusCode)
+	require.Equal(t, 0, testutil.GetCount(t, db, "SlotifyGroup"), "the last member leaving deletes the group")
+}
@@ -0,0 +1,278 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// patchGroup updates the settings of a group, as userID.
+func patchGroup(t *testing.T, server *api.Server, userID, slotifyGroupID uint32,
+	body api.SlotifyGroupUpdate,
+) *httptest.ResponseRecorder {
+	return doJSONRequest(t, http.MethodPatch, fmt.Sprintf("/api/slotify-groups/%d", slotifyGroupID), userID, body,
+		func(w http.ResponseWriter, r *http.Request) {
+			server.PatchAPISlotifyGroupsSlotifyGroupID(w, r, slotifyGroupID)
+		})
+}
+
+// decodeGroup decodes a SlotifyGroup response body.
+func decodeGroup(t *testing.T, rr *httptest.ResponseRecorder) api.SlotifyGroup {
+	var group api.SlotifyGroup
+	require.NoError(t, json.NewDecoder(rr.Result().Body).Decode(&group), "response body can be decoded")
+	return group
+}
+
+func TestSlotifyGroupSettings_PatchAPISlotifyGroupsSlotifyGroupID(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	admin := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+	outsider := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, admin.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+
+	description := "Everyone working on the design system"
+	colour := "#4F46E5"
+	discoverable := api.Discoverable
+	duration := "PT45M"
+	room, roomEmail := "Boardroom", "boardroom@example.com"
+	teams := true
+
+	rr := patchGroup(t, server, admin.Id, group.Id, api.SlotifyGroupUpdate{
+		Description:            &description,
+		AvatarColour:           &colour,
+		Visibility:             &discoverable,
+		DefaultMeetingDuration: &duration,
+		DefaultLocation:        &api.Location{Name: &room, EmailAddress: &roomEmail},
+		DefaultIsOnlineMeeting: &teams,
+	})
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	roomType := api.ConferenceRoom
+	expected := api.SlotifyGroup{
+		Id:                     group.Id,
+		Name:                   group.Name,
+		Description:            description,
+		AvatarColour:           &colour,
+		Visibility:             api.Discoverable,
+		DefaultMeetingDuration: &duration,
+		DefaultLocation:        &api.Location{Name: &room, EmailAddress: &roomEmail, RoomType: &roomType},
+		DefaultIsOnlineMeeting: true,
+	}
+	require.Equal(t, expected, decodeGroup(t, rr), "admins can change the group's settings")
+
+	rr = doJSONRequest(t, http.MethodGet, fmt.Sprintf("/api/slotify-groups/%d", group.Id), member.Id, nil,
+		func(w http.ResponseWriter, r *http.Request) {
+			server.GetAPISlotifyGroupsSlotifyGroupID(w, r, group.Id)
+		})
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, expected, decodeGroup(t, rr), "the settings are saved")
+	require.Empty(t, testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, member.Id),
+		"members aren't told about changes other than renames")
+
+	// Settings left out are kept and empty ones are cleared
+	empty := ""
+	newName := group.Name + " Team"
+	rr = patchGroup(t, server, admin.Id, group.Id, api.SlotifyGroupUpdate{
+		Name:                   &newName,
+		AvatarColour:           &empty,
+		DefaultMeetingDuration: &empty,
+		DefaultLocation:        &api.Location{Name: &empty},
+	})
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	expected.Name = newName
+	expected.AvatarColour, expected.DefaultMeetingDuration, expected.DefaultLocation = nil, nil, nil
+	require.Equal(t, expected, decodeGroup(t, rr))
+
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, member.Id)
+	require.Len(t, outbox, 1, "members are told the group was renamed")
+	require.Equal(t, string(notification.KindGroupRenamed), outbox[0].Kind)
+	require.Empty(t, testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, admin.Id),
+		"whoever renamed the group isn't told")
+
+	// The description limit is in characters, not bytes
+	accentedDescription := strings.Repeat("é", 1024)
+	rr = patchGroup(t, server, admin.Id, group.Id, api.SlotifyGroupUpdate{Description: &accentedDescription})
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode, "descriptions can use all their characters")
+	require.Equal(t, accentedDescription, decodeGroup(t, rr).Description)
+
+	invalidColour := "indigo"
+	invalidVisibility := api.SlotifyGroupVisibility("hidden")
+	invalidDuration := "an hour"
+	longDescription := strings.Repeat("a", 1025)
+	longAccentedDescription := strings.Repeat("é", 1025)
+	tests := map[string]struct {
+		httpStatus int
+		userID     uint32
+		groupID    uint32
+		body       api.SlotifyGroupUpdate
+		testMsg    string
+	}{
+		"member changes settings": {
+			httpStatus: http.StatusForbidden,
+			userID:     member.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{Description: &description},
+			testMsg:    "members can't change the group's settings",
+		},
+		"outsider changes settings": {
+			httpStatus: http.StatusBadRequest,
+			userID:     outsider.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{Description: &description},
+			testMsg:    "users outside the group can't change its settings",
+		},
+		"group doesn't exist": {
+			httpStatus: http.StatusNotFound,
+			userID:     admin.Id,
+			groupID:    10000,
+			body:       api.SlotifyGroupUpdate{Description: &description},
+			testMsg:    "only existing groups can be updated",
+		},
+		"empty name": {
+			httpStatus: http.StatusBadRequest,
+			userID:     admin.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{Name: &empty},
+			testMsg:    "groups need a name",
+		},
+		"invalid avatar colour": {
+			httpStatus: http.StatusBadRequest,
+			userID:     admin.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{AvatarColour: &invalidColour},
+			testMsg:    "avatar colours are hex colours",
+		},
+		"invalid visibility": {
+			httpStatus: http.StatusBadRequest,
+			userID:     admin.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{Visibility: &invalidVisibility},
+			testMsg:    "groups are private or discoverable",
+		},
+		"invalid meeting duration": {
+			httpStatus: http.StatusBadRequest,
+			userID:     admin.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{DefaultMeetingDuration: &invalidDuration},
+			testMsg:    "default meeting durations are ISO 8601 durations",
+		},
+		"long description": {
+			httpStatus: http.StatusBadRequest,
+			userID:     admin.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{Description: &longDescription},
+			testMsg:    "descriptions fit in the description column",
+		},
+		"long multi-byte description": {
+			httpStatus: http.StatusBadRequest,
+			userID:     admin.Id,
+			groupID:    group.Id,
+			body:       api.SlotifyGroupUpdate{Description: &longAccentedDescription},
+			testMsg:    "descriptions are limited in characters",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			rr := patchGroup(t, server, tt.userID, tt.groupID, tt.body)
+			require.Equal(t, tt.httpStatus, rr.Result().StatusCode, tt.testMsg)
+		})
+	}
+}
+
+func TestSlotifyGroupSettings_GroupMeetingDefaults(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	organiser := testutil.InsertUser(t, db)
+	member := testutil.InsertUser(t, db)
+
+	group := testutil.InsertSlotifyGroup(t, db)
+	testutil.AddUserToSlotifyGroupWithRole(t, db, organiser.Id, group.Id, api.Admin)
+	testutil.AddUserToSlotifyGroup(t, db, member.Id, group.Id)
+
+	prefs := newPreferences()
+	require.Equal(t, http.StatusOK, putPreferences(t, server, organiser.Id, prefs).Result().StatusCode)
+
+	duration := "PT2H"
+	room, roomEmail := "Boardroom", "boardroom@example.com"
+	teams := true
+	rr := patchGroup(t, server, organiser.Id, group.Id, api.SlotifyGroupUpdate{
+		DefaultMeetingDuration: &duration,
+		DefaultLocation:        &api.Location{Name: &room, EmailAddress: &roomEmail},
+		DefaultIsOnlineMeeting: &teams,
+	})
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	nine := nextWeekday().Add(9 * time.Hour)
+	subject := "Design review"
+	scheduleMeeting := func(event api.CalendarEvent) *httptest.ResponseRecorder {
+		return doJSONRequest(t, http.MethodPost, fmt.Sprintf("/api/slotify-groups/%d/meetings", group.Id), organiser.Id,
+			api.SlotifyGroupMeetingBody{
+				Event: event,
+				TimeConstraint: api.TimeConstraint{
+					TimeSlots: []api.MeetingTimeSlot{{Start: nine, End: nine.Add(4 * time.Hour)}},
+				},
+			},
+			func(w http.ResponseWriter, r *http.Request) {
+				server.PostAPISlotifyGroupsSlotifyGroupIDMeetings(w, r, group.Id)
+			})
+	}
+
+	rr = scheduleMeeting(api.CalendarEvent{Subject: &subject, Attendees: []api.Attendee{}, Locations: []api.Location{}})
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	events := fakeCalendar.Events(organiser.Id)
+	require.Len(t, events, 1)
+	require.Equal(t, testutil.FormatFakeCalendarTime(nine), *events[0].StartTime)
+	require.Equal(t, testutil.FormatFakeCalendarTime(nine.Add(2*time.Hour)), *events[0].EndTime,
+		"the group's default meeting duration is used")
+	require.Len(t, events[0].Locations, 1)
+	require.Equal(t, room, *events[0].Locations[0].Name, "the group's default room is used")
+	require.Equal(t, roomEmail, *events[0].Locations[0].EmailAddress)
+	require.NotNil(t, events[0].IsOnlineMeeting)
+	require.True(t, *events[0].IsOnlineMeeting, "group meetings are Teams meetings by default")
+
+	// The meeting's own settings win over the group's
+	studio := "Studio"
+	inPerson := false
+	rr = scheduleMeeting(api.CalendarEvent{
+		Subject:         &subject,
+		Attendees:       []api.Attendee{},
+		Locations:       []api.Location{{Name: &studio}},
+		IsOnlineMeeting: &inPerson,
+	})
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+
+	events = fakeCalendar.Events(organiser.Id)
+	require.Len(t, events, 2)
+	require.Len(t, events[1].Locations, 1)
+	require.Equal(t, studio, *events[1].Locations[0].Name)
+	require.NotNil(t, events[1].IsOnlineMeeting)
+	require.False(t, *events[1].IsOnlineMeeting)
+}
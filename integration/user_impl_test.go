@@ -32,6 +32,9 @@ func TestUser_GetUsersUserID(t *testing.T) {
 
 	// Setup
 	insertedUser := testutil.InsertUser(t, db)
+	utc := "UTC"
+	expectedUser := insertedUser
+	expectedUser.TimeZone = &utc
 
 	tests := map[string]struct {
 		httpStatus   int
@@ -48,8 +51,8 @@ func TestUser_GetUsersUserID(t *testing.T) {
 		"user exists": {
 			httpStatus:   http.StatusOK,
 			userID:       insertedUser.Id,
-			expectedBody: insertedUser,
-			testMsg:      "correctly got existing user",
+			expectedBody: expectedUser,
+			testMsg:      "correctly got existing user, users are in UTC until they set a time zone",
 		},
 	}
 
@@ -83,6 +86,82 @@ func TestUser_GetUsersUserID(t *testing.T) {
 	}
 }
 
+func TestUser_PutAPIUsersMeTimeZone(t *testing.T) {
+	t.Parallel()
+
+	database, server := testutil.NewServerAndDB(t, t.Context())
+	db := database.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	tests := map[string]struct {
+		httpStatus       int
+		timeZone         string
+		expectedTimeZone string
+		testMsg          string
+	}{
+		"iana time zone": {
+			httpStatus:       http.StatusOK,
+			timeZone:         "Asia/Singapore",
+			expectedTimeZone: "Asia/Singapore",
+			testMsg:          "iana time zones are stored as they are",
+		},
+		"windows time zone": {
+			httpStatus:       http.StatusOK,
+			timeZone:         "Eastern Standard Time",
+			expectedTimeZone: "America/New_York",
+			testMsg:          "windows time zones are stored as their iana equivalent",
+		},
+		"unknown time zone": {
+			httpStatus:       http.StatusBadRequest,
+			timeZone:         "Mars/Olympus_Mons",
+			expectedTimeZone: "UTC",
+			testMsg:          "unknown time zones are rejected",
+		},
+		"local time zone": {
+			httpStatus:       http.StatusBadRequest,
+			timeZone:         "Local",
+			expectedTimeZone: "UTC",
+			testMsg:          "the server's time zone can't be used",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			user := testutil.InsertUser(t, db)
+
+			body, err := json.Marshal(api.UserTimeZoneBody{TimeZone: tt.timeZone})
+			require.NoError(t, err, "could not marshal json req body")
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/api/users/me/time-zone", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = withUser(req, user.Id)
+
+			server.PutAPIUsersMeTimeZone(rr, req)
+			require.Equal(t, tt.httpStatus, rr.Result().StatusCode, tt.testMsg)
+
+			dbUser, err := database.GetUserByID(t.Context(), user.Id)
+			require.NoError(t, err, "failed to get user")
+			require.Equal(t, tt.expectedTimeZone, dbUser.TimeZone, tt.testMsg)
+
+			if tt.httpStatus == http.StatusOK {
+				var got api.User
+				err = json.NewDecoder(rr.Result().Body).Decode(&got)
+				require.NoError(t, err, "response body can be decoded into a user")
+				require.NotNil(t, got.TimeZone)
+				require.Equal(t, tt.expectedTimeZone, *got.TimeZone, tt.testMsg)
+			}
+
+			req.Body = io.NopCloser(bytes.NewBuffer(body))
+			testutil.OpenAPIValidateTest(t, rr, req)
+		})
+	}
+}
+
 func TestUser_PostUsers(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,274 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/database"
+	notif "github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// graphNotification fills in the Graph change notification fixture.
+type graphNotification struct {
+	SubscriptionID string
+	ClientState    string
+	ChangeType     api.GraphChangeNotificationChangeType
+	EventID        string
+}
+
+// postGraphNotification posts the Graph change notification fixture to the calendar webhook.
+func postGraphNotification(t *testing.T, server *api.Server, n graphNotification) *httptest.ResponseRecorder {
+	t.Helper()
+
+	fixture, err := template.ParseFiles("testdata/graph_calendar_notification.json")
+	require.NoError(t, err, "failed to parse notification fixture")
+
+	var body bytes.Buffer
+	require.NoError(t, fixture.Execute(&body, n), "failed to fill in notification fixture")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhooks/calendar", &body)
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.WithValue(req.Context(), api.RequestIDCtxKey{}, uuid.NewString()))
+	rr := httptest.NewRecorder()
+
+	server.PostAPIWebhooksCalendar(rr, req, api.PostAPIWebhooksCalendarParams{})
+
+	return rr
+}
+
+// insertRescheduleRequest creates a meeting owned by owner for event, with a pending reschedule request
+// from requester. The meeting id is returned.
+func insertRescheduleRequest(t *testing.T, slotifyDB *database.Database, owner, requester api.User,
+	event api.CalendarEvent,
+	start time.Time,
+) uint32 {
+	t.Helper()
+
+	meetingPrefID, err := slotifyDB.CreateMeetingPreferences(t.Context(), database.CreateMeetingPreferencesParams{
+		MeetingStartTime: start,
+		StartDateRange:   time.Now(),
+		EndDateRange:     start.Add(7 * 24 * time.Hour),
+	})
+	require.NoError(t, err, "failed to create meeting preferences")
+
+	meetingID, err := slotifyDB.CreateMeeting(t.Context(), database.CreateMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		MeetingPrefID: uint32(meetingPrefID),
+		OwnerEmail:    string(owner.Email),
+		MsftMeetingID: *event.ICalUId,
+	})
+	require.NoError(t, err, "failed to create meeting")
+
+	requestID, err := slotifyDB.CreateReschedulingRequest(t.Context(), database.CreateReschedulingRequestParams{
+		RequestedBy: requester.Id,
+		CreatedAt:   time.Now(),
+	})
+	require.NoError(t, err, "failed to create rescheduling request")
+
+	_, err = slotifyDB.CreateRequestToMeeting(t.Context(), database.CreateRequestToMeetingParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		RequestID: uint32(requestID),
+		//nolint: gosec // id is unsigned 32 bit int
+		MeetingID: uint32(meetingID),
+	})
+	require.NoError(t, err, "failed to link request to meeting")
+
+	//nolint: gosec // id is unsigned 32 bit int
+	return uint32(meetingID)
+}
+
+func TestWebhooks_PostAPIWebhooksCalendarValidation(t *testing.T) {
+	t.Parallel()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context())
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	token := "Validation: Testing client application reachability for subscription Request-Id: " + uuid.NewString()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhooks/calendar?validationToken="+
+		url.QueryEscape(token), nil)
+	req = req.WithContext(context.WithValue(req.Context(), api.RequestIDCtxKey{}, uuid.NewString()))
+	rr := httptest.NewRecorder()
+
+	server.PostAPIWebhooksCalendar(rr, req, api.PostAPIWebhooksCalendarParams{ValidationToken: &token})
+
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.Equal(t, "text/plain", rr.Result().Header.Get("Content-Type"))
+	require.Equal(t, token, rr.Body.String(), "validation token is echoed back")
+
+	testutil.OpenAPIValidateTest(t, rr, req)
+}
+
+func TestWebhooks_PostAPIWebhooksCalendarMeetingMoved(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	owner := testutil.InsertUser(t, db)
+	requester := testutil.InsertUser(t, db)
+
+	oldStart := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	event := fakeCalendar.AddEvent(owner.Id, newFakeCalendarEvent("Planning", oldStart, oldStart.Add(time.Hour)))
+	meetingID := insertRescheduleRequest(t, slotifyDB, owner, requester, event, oldStart)
+
+	subscriptionID := uuid.NewString()
+	clientState := uuid.NewString()
+	_, err := slotifyDB.UpsertCalendarSubscription(t.Context(), database.UpsertCalendarSubscriptionParams{
+		ID:          subscriptionID,
+		UserID:      owner.Id,
+		ClientState: clientState,
+		ExpiresAt:   time.Now().Add(48 * time.Hour),
+	})
+	require.NoError(t, err, "failed to create calendar subscription")
+
+	// The owner moves the meeting outside of Slotify
+	newStart := oldStart.Add(24 * time.Hour)
+	newEnd := newStart.Add(time.Hour)
+	calendar, err := fakeCalendar.Factory()(t.Context(), owner.Id)
+	require.NoError(t, err)
+	err = calendar.PatchEvent(t.Context(), *event.Id, api.CalendarEventPatch{Start: &newStart, End: &newEnd})
+	require.NoError(t, err)
+
+	notification := graphNotification{
+		SubscriptionID: subscriptionID,
+		ClientState:    clientState,
+		ChangeType:     api.Updated,
+		EventID:        *event.Id,
+	}
+	rr := postGraphNotification(t, server, notification)
+	require.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+	require.Equal(t, 1, server.CalendarChanges.HandleQueued(t.Context()), "the change is handled in the background")
+
+	meeting, err := slotifyDB.GetMeetingByID(t.Context(), meetingID)
+	require.NoError(t, err)
+	prefs, err := slotifyDB.GetMeetingPreferences(t.Context(), meeting.MeetingPrefID)
+	require.NoError(t, err)
+	require.True(t, newStart.Equal(prefs.MeetingStartTime), "meeting start time is updated")
+
+	// Graph can send the same notification more than once, the requester is only told once
+	rr = postGraphNotification(t, server, notification)
+	require.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+	require.Equal(t, 1, server.CalendarChanges.HandleQueued(t.Context()))
+
+	outbox := testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, requester.Id)
+	require.Len(t, outbox, 1, "the requester is told the meeting moved")
+	require.Equal(t, string(notif.KindMeetingMoved), outbox[0].Kind)
+}
+
+func TestWebhooks_PostAPIWebhooksCalendarWrongClientState(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	owner := testutil.InsertUser(t, db)
+	requester := testutil.InsertUser(t, db)
+
+	oldStart := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	event := fakeCalendar.AddEvent(owner.Id, newFakeCalendarEvent("Planning", oldStart, oldStart.Add(time.Hour)))
+	insertRescheduleRequest(t, slotifyDB, owner, requester, event, oldStart)
+
+	subscriptionID := uuid.NewString()
+	_, err := slotifyDB.UpsertCalendarSubscription(t.Context(), database.UpsertCalendarSubscriptionParams{
+		ID:          subscriptionID,
+		UserID:      owner.Id,
+		ClientState: uuid.NewString(),
+		ExpiresAt:   time.Now().Add(48 * time.Hour),
+	})
+	require.NoError(t, err, "failed to create calendar subscription")
+
+	newStart := oldStart.Add(24 * time.Hour)
+	newEnd := newStart.Add(time.Hour)
+	calendar, err := fakeCalendar.Factory()(t.Context(), owner.Id)
+	require.NoError(t, err)
+	err = calendar.PatchEvent(t.Context(), *event.Id, api.CalendarEventPatch{Start: &newStart, End: &newEnd})
+	require.NoError(t, err)
+
+	for _, n := range []graphNotification{
+		{SubscriptionID: subscriptionID, ClientState: "forged", ChangeType: api.Updated, EventID: *event.Id},
+		{SubscriptionID: uuid.NewString(), ClientState: "forged", ChangeType: api.Updated, EventID: *event.Id},
+	} {
+		rr := postGraphNotification(t, server, n)
+		require.Equal(t, http.StatusAccepted, rr.Result().StatusCode, "notifications are accepted but ignored")
+	}
+	require.Zero(t, server.CalendarChanges.HandleQueued(t.Context()), "forged notifications aren't queued")
+
+	require.Empty(t, testutil.GetOutboxNotifications(t, t.Context(), slotifyDB, requester.Id),
+		"the requester isn't notified")
+}
+
+func TestWebhooks_RenewCalendarSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	fakeCalendar := testutil.NewFakeCalendar()
+	slotifyDB, server := testutil.NewServerAndDB(t, t.Context(),
+		testutil.WithCalendarProviderFactory(fakeCalendar.Factory()),
+		testutil.WithCalendarSubscriptionConfig(api.CalendarSubscriptionConfig{
+			NotificationURL: "https://slotify.example.com/api/webhooks/calendar",
+			Lifetime:        api.DefaultCalendarSubscriptionLifetime,
+		}))
+	db := slotifyDB.DB
+	t.Cleanup(func() {
+		testutil.CloseDB(db)
+	})
+
+	expiringUser := testutil.InsertUser(t, db)
+	lapsedUser := testutil.InsertUser(t, db)
+
+	// Subscribe the expiring user with the fake calendar, then make it due for renewal
+	calendar, err := fakeCalendar.Factory()(t.Context(), expiringUser.Id)
+	require.NoError(t, err)
+	subscriber, ok := calendar.(api.CalendarSubscriber)
+	require.True(t, ok, "fake calendar supports subscriptions")
+	expiring, err := subscriber.Subscribe(t.Context(), "", "", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	for _, sub := range []database.UpsertCalendarSubscriptionParams{
+		{ID: expiring.ID, UserID: expiringUser.Id, ClientState: uuid.NewString(), ExpiresAt: expiring.ExpiresAt},
+		// Not known to the fake calendar, so renewing it fails as if it lapsed
+		{ID: uuid.NewString(), UserID: lapsedUser.Id, ClientState: uuid.NewString(), ExpiresAt: time.Now()},
+	} {
+		_, err = slotifyDB.UpsertCalendarSubscription(t.Context(), sub)
+		require.NoError(t, err, "failed to create calendar subscription")
+	}
+
+	server.RenewCalendarSubscriptions(t.Context())
+
+	renewed, err := slotifyDB.GetCalendarSubscriptionByUserID(t.Context(), expiringUser.Id)
+	require.NoError(t, err)
+	require.Equal(t, expiring.ID, renewed.ID, "subscription is renewed in place")
+	require.True(t, renewed.ExpiresAt.After(time.Now().Add(api.CalendarSubscriptionRenewBefore)),
+		"subscription expiry is extended")
+
+	recreated, err := slotifyDB.GetCalendarSubscriptionByUserID(t.Context(), lapsedUser.Id)
+	require.NoError(t, err)
+	fakeSubscription, ok := fakeCalendar.Subscription(lapsedUser.Id)
+	require.True(t, ok, "lapsed subscription is created again")
+	require.Equal(t, fakeSubscription.ID, recreated.ID)
+}
@@ -70,3 +70,78 @@ func (mr *MockNotificationDatabaseMockRecorder) CreateUserNotification(ctx, arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserNotification", reflect.TypeOf((*MockNotificationDatabase)(nil).CreateUserNotification), ctx, arg)
 }
+
+// GetNotificationPreferenceChannel mocks base method.
+func (m *MockNotificationDatabase) GetNotificationPreferenceChannel(ctx context.Context, arg database.GetNotificationPreferenceChannelParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferenceChannel", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferenceChannel indicates an expected call of GetNotificationPreferenceChannel.
+func (mr *MockNotificationDatabaseMockRecorder) GetNotificationPreferenceChannel(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferenceChannel", reflect.TypeOf((*MockNotificationDatabase)(nil).GetNotificationPreferenceChannel), ctx, arg)
+}
+
+// GetNotificationQuietHours mocks base method.
+func (m *MockNotificationDatabase) GetNotificationQuietHours(ctx context.Context, userID uint32) (database.GetNotificationQuietHoursRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationQuietHours", ctx, userID)
+	ret0, _ := ret[0].(database.GetNotificationQuietHoursRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationQuietHours indicates an expected call of GetNotificationQuietHours.
+func (mr *MockNotificationDatabaseMockRecorder) GetNotificationQuietHours(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationQuietHours", reflect.TypeOf((*MockNotificationDatabase)(nil).GetNotificationQuietHours), ctx, userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockNotificationDatabase) GetUserByID(ctx context.Context, id uint32) (database.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(database.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockNotificationDatabaseMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockNotificationDatabase)(nil).GetUserByID), ctx, id)
+}
+
+// ListMemberSlotifyGroupNotificationChannels mocks base method.
+func (m *MockNotificationDatabase) ListMemberSlotifyGroupNotificationChannels(ctx context.Context, arg database.ListMemberSlotifyGroupNotificationChannelsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberSlotifyGroupNotificationChannels", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberSlotifyGroupNotificationChannels indicates an expected call of ListMemberSlotifyGroupNotificationChannels.
+func (mr *MockNotificationDatabaseMockRecorder) ListMemberSlotifyGroupNotificationChannels(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberSlotifyGroupNotificationChannels", reflect.TypeOf((*MockNotificationDatabase)(nil).ListMemberSlotifyGroupNotificationChannels), ctx, arg)
+}
+
+// ListUserNotificationsAfterID mocks base method.
+func (m *MockNotificationDatabase) ListUserNotificationsAfterID(ctx context.Context, arg database.ListUserNotificationsAfterIDParams) ([]database.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserNotificationsAfterID", ctx, arg)
+	ret0, _ := ret[0].([]database.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserNotificationsAfterID indicates an expected call of ListUserNotificationsAfterID.
+func (mr *MockNotificationDatabaseMockRecorder) ListUserNotificationsAfterID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserNotificationsAfterID", reflect.TypeOf((*MockNotificationDatabase)(nil).ListUserNotificationsAfterID), ctx, arg)
+}
@@ -55,6 +55,20 @@ func (mr *MockServiceMockRecorder) DeleteUserConn(l, userID, w any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserConn", reflect.TypeOf((*MockService)(nil).DeleteUserConn), l, userID, w)
 }
 
+// DeliverNotification mocks base method.
+func (m *MockService) DeliverNotification(ctx context.Context, l *logger.Logger, db database.NotificationDatabase, recipients []database.NotificationRecipient, notif database.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverNotification", ctx, l, db, recipients, notif)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverNotification indicates an expected call of DeliverNotification.
+func (mr *MockServiceMockRecorder) DeliverNotification(ctx, l, db, recipients, notif any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverNotification", reflect.TypeOf((*MockService)(nil).DeliverNotification), ctx, l, db, recipients, notif)
+}
+
 // RegisterUserClient mocks base method.
 func (m *MockService) RegisterUserClient(l *logger.Logger, userID uint32, w http.ResponseWriter) error {
 	m.ctrl.T.Helper()
@@ -69,6 +83,34 @@ func (mr *MockServiceMockRecorder) RegisterUserClient(l, userID, w any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUserClient", reflect.TypeOf((*MockService)(nil).RegisterUserClient), l, userID, w)
 }
 
+// ResumeUserClient mocks base method.
+func (m *MockService) ResumeUserClient(ctx context.Context, l *logger.Logger, db database.NotificationDatabase, userID uint32, w http.ResponseWriter, lastEventID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeUserClient", ctx, l, db, userID, w, lastEventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeUserClient indicates an expected call of ResumeUserClient.
+func (mr *MockServiceMockRecorder) ResumeUserClient(ctx, l, db, userID, w, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeUserClient", reflect.TypeOf((*MockService)(nil).ResumeUserClient), ctx, l, db, userID, w, lastEventID)
+}
+
+// SendHeartbeat mocks base method.
+func (m *MockService) SendHeartbeat(w http.ResponseWriter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeartbeat", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeartbeat indicates an expected call of SendHeartbeat.
+func (mr *MockServiceMockRecorder) SendHeartbeat(w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeartbeat", reflect.TypeOf((*MockService)(nil).SendHeartbeat), w)
+}
+
 // SendNotification mocks base method.
 func (m *MockService) SendNotification(ctx context.Context, l *logger.Logger, db database.NotificationDatabase, userIDs []uint32, notif database.CreateNotificationParams) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,6 @@
+package msalcache
+
+import "github.com/SlotifyApp/slotify-backend/tokencrypt"
+
+// ErrEncryptionKeyInvalid is returned if the cache encryption key is not a valid AES-256 key.
+var ErrEncryptionKeyInvalid = tokencrypt.ErrKeyInvalid
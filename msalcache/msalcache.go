@@ -0,0 +1,145 @@
+// Package msalcache implements a persistent MSAL token cache backed by the database.
+//
+// Each user's serialized MSAL cache is stored in the 'MSALTokenCache' table, keyed by the
+// partition key MSAL gives us (the user's home account id), and encrypted at rest with AES-256-GCM.
+package msalcache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/cache"
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/tokencrypt"
+	"github.com/avast/retry-go"
+)
+
+// EncryptionKeyEnvName is the env variable holding the base64 encoded AES-256 key.
+const EncryptionKeyEnvName = "MSAL_CACHE_ENCRYPTION_KEY"
+
+// ensure that we've conformed to the `ExportReplace` interface with a compile-time check.
+var _ cache.ExportReplace = (*DBCache)(nil)
+
+// DBCache is a cache.ExportReplace impl that stores encrypted MSAL caches in the database.
+type DBCache struct {
+	db     database.MSALTokenCacheDatabase
+	cipher *tokencrypt.Cipher
+}
+
+// NewDBCache creates a new DBCache, key must be 32 bytes long.
+func NewDBCache(db database.MSALTokenCacheDatabase, key []byte) (*DBCache, error) {
+	if db == nil {
+		return nil, errors.New("db must not be nil")
+	}
+
+	c, err := tokencrypt.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBCache{
+		db:     db,
+		cipher: c,
+	}, nil
+}
+
+// NewDBCacheFromEnv creates a new DBCache, reading the encryption key from the environment.
+func NewDBCacheFromEnv(db database.MSALTokenCacheDatabase) (*DBCache, error) {
+	if db == nil {
+		return nil, errors.New("db must not be nil")
+	}
+
+	c, err := tokencrypt.NewFromEnv(EncryptionKeyEnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBCache{
+		db:     db,
+		cipher: c,
+	}, nil
+}
+
+// Replace replaces the in-memory MSAL cache with the one stored in the database.
+// If nothing has been stored for the partition key yet, the in-memory cache is left untouched.
+func (c *DBCache) Replace(ctx context.Context, u cache.Unmarshaler, hints cache.ReplaceHints) error {
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	var ciphertext []byte
+	var err error
+	err = retry.Do(func() error {
+		ciphertext, err = c.db.GetMSALTokenCache(ctx, hints.PartitionKey)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) || errors.Is(err, context.Canceled) ||
+				errors.Is(err, context.DeadlineExceeded) {
+				return retry.Unrecoverable(err)
+			}
+			return fmt.Errorf("failed to get msal token cache: %w", err)
+		}
+		return nil
+	}, retry.Attempts(3), retry.Delay(time.Millisecond*100), retry.LastErrorOnly(true))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	plaintext, err := c.cipher.Decrypt(ciphertext)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt msal token cache: %w", err)
+	}
+
+	if err = u.Unmarshal(plaintext); err != nil {
+		return fmt.Errorf("failed to unmarshal msal token cache: %w", err)
+	}
+
+	return nil
+}
+
+// Export encrypts and writes the in-memory MSAL cache to the database.
+func (c *DBCache) Export(ctx context.Context, m cache.Marshaler, hints cache.ExportHints) error {
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	plaintext, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal msal token cache: %w", err)
+	}
+
+	ciphertext, err := c.cipher.Encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt msal token cache: %w", err)
+	}
+
+	err = retry.Do(func() error {
+		if _, err = c.db.UpsertMSALTokenCache(ctx, database.UpsertMSALTokenCacheParams{
+			PartitionKey: hints.PartitionKey,
+			CacheData:    ciphertext,
+		}); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return retry.Unrecoverable(err)
+			}
+			return fmt.Errorf("failed to upsert msal token cache: %w", err)
+		}
+		return nil
+	}, retry.Attempts(3), retry.Delay(time.Millisecond*100), retry.LastErrorOnly(true))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// withDefaultTimeout gives ctx the database timeout if it doesn't already have a deadline,
+// as required by the cache.ExportReplace contract.
+func withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, database.DatabaseTimeout)
+}
@@ -0,0 +1,186 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryBrokerBuffer is how many messages a MemoryBroker subscription holds before publishing blocks.
+const memoryBrokerBuffer = 64
+
+// Broker carries messages published on a channel to every subscriber of that channel,
+// which may be in other API replicas.
+type Broker interface {
+	// Publish sends payload to every subscriber of channel.
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe subscribes to channel, messages published after it returns are received.
+	Subscribe(ctx context.Context, channel string) (Subscription, error)
+}
+
+// Subscription receives the messages published to a broker channel.
+type Subscription interface {
+	// Messages returns the published payloads, it is closed when the subscription is closed.
+	Messages() <-chan []byte
+	// Close stops receiving messages.
+	Close() error
+}
+
+// MemoryBroker is a Broker within a single process, eg. for tests or running one replica.
+type MemoryBroker struct {
+	mu   sync.RWMutex
+	subs map[string]map[*memorySubscription]struct{}
+}
+
+// NewMemoryBroker creates a new instance of MemoryBroker.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subs: make(map[string]map[*memorySubscription]struct{}),
+	}
+}
+
+// Publish sends payload to every subscriber of channel, waiting for any with a full buffer.
+func (b *MemoryBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	// Sending under the read lock stops a subscription's messages being closed mid send
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs[channel] {
+		select {
+		case sub.messages <- payload:
+		case <-sub.done:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to publish to memory broker: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+// Subscribe subscribes to channel.
+func (b *MemoryBroker) Subscribe(_ context.Context, channel string) (Subscription, error) {
+	sub := &memorySubscription{
+		broker:   b,
+		channel:  channel,
+		messages: make(chan []byte, memoryBrokerBuffer),
+		done:     make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subs[channel] == nil {
+		b.subs[channel] = make(map[*memorySubscription]struct{})
+	}
+	b.subs[channel][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// memorySubscription is a subscription to a MemoryBroker channel.
+type memorySubscription struct {
+	broker   *MemoryBroker
+	channel  string
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (s *memorySubscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *memorySubscription) Close() error {
+	s.once.Do(func() {
+		// Unblock any publishers waiting on this subscription before taking the lock they hold
+		close(s.done)
+
+		s.broker.mu.Lock()
+		defer s.broker.mu.Unlock()
+
+		delete(s.broker.subs[s.channel], s)
+		close(s.messages)
+	})
+	return nil
+}
+
+// RedisBroker is a Broker backed by Redis pub/sub, so notifications reach every API replica.
+type RedisBroker struct {
+	client *redis.Client
+}
+
+// NewRedisBroker creates a RedisBroker using client.
+func NewRedisBroker(client *redis.Client) *RedisBroker {
+	return &RedisBroker{client: client}
+}
+
+// NewRedisBrokerFromURL creates a RedisBroker connected to the Redis at url, eg. redis://localhost:6379/0.
+func NewRedisBrokerFromURL(url string) (*RedisBroker, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+	return NewRedisBroker(redis.NewClient(opts)), nil
+}
+
+// Publish publishes payload to channel.
+func (b *RedisBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	if err := b.client.Publish(ctx, channel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish to redis: %w", err)
+	}
+	return nil
+}
+
+// Subscribe subscribes to channel, returning once Redis has confirmed the subscription.
+func (b *RedisBroker) Subscribe(ctx context.Context, channel string) (Subscription, error) {
+	pubSub := b.client.Subscribe(ctx, channel)
+	if _, err := pubSub.Receive(ctx); err != nil {
+		_ = pubSub.Close()
+		return nil, fmt.Errorf("failed to subscribe to redis: %w", err)
+	}
+
+	sub := &redisSubscription{
+		pubSub:   pubSub,
+		messages: make(chan []byte),
+		done:     make(chan struct{}),
+	}
+	go sub.receive()
+
+	return sub, nil
+}
+
+// redisSubscription is a subscription to a Redis channel.
+type redisSubscription struct {
+	pubSub   *redis.PubSub
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+}
+
+// receive forwards messages from Redis until the subscription is closed.
+func (s *redisSubscription) receive() {
+	defer close(s.messages)
+
+	// The go-redis channel is closed when the pub/sub is closed
+	for msg := range s.pubSub.Channel() {
+		select {
+		case s.messages <- []byte(msg.Payload):
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *redisSubscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *redisSubscription) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		err = s.pubSub.Close()
+	})
+	return err
+}
@@ -0,0 +1,170 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/logger"
+)
+
+// BrokerChannel is the broker channel notifications are published on.
+const BrokerChannel = "slotify:notifications"
+
+// ensure that we've conformed to the `Service` interface with a compile-time check.
+var _ Service = (*BrokerNotificationService)(nil)
+
+// brokerMessage is a notification published to every API replica.
+type brokerMessage struct {
+	UserIDs      []uint32              `json:"userIDs"`
+	Notification database.Notification `json:"notification"`
+}
+
+// BrokerNotificationService is a notification service impl for running many API replicas.
+// Notifications are published to a Broker and every replica sends them to its own SSE clients.
+type BrokerNotificationService struct {
+	// local holds the clients connected to this replica.
+	local  *SSENotificationService
+	broker Broker
+}
+
+// NewBrokerNotificationService creates a new instance of BrokerNotificationService, subscribing to broker
+// until ctx is done.
+func NewBrokerNotificationService(ctx context.Context, l *logger.Logger,
+	broker Broker,
+	serviceOpts ...ServiceOption,
+) (*BrokerNotificationService, error) {
+	if broker == nil {
+		return nil, errors.New("notification broker must not be nil")
+	}
+
+	sub, err := broker.Subscribe(ctx, BrokerChannel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to notification broker: %w", err)
+	}
+
+	b := &BrokerNotificationService{
+		local:  NewSSENotificationService(serviceOpts...),
+		broker: broker,
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := sub.Close(); err != nil {
+			l.Errorf("failed to close notification broker subscription: %v", err)
+		}
+	}()
+
+	go b.receive(l, sub)
+
+	return b, nil
+}
+
+// GetUserClients exposes the clients connected to this replica.
+func (b *BrokerNotificationService) GetUserClients() map[uint32]ClientSet {
+	return b.local.GetUserClients()
+}
+
+// RegisterUserClient registers a user client connected to this replica to send notifications to.
+func (b *BrokerNotificationService) RegisterUserClient(l *logger.Logger, userID uint32, w http.ResponseWriter) error {
+	return b.local.RegisterUserClient(l, userID, w)
+}
+
+// ResumeUserClient registers a user client connected to this replica that is reconnecting after the event
+// lastEventID, first replaying the notifications it missed.
+func (b *BrokerNotificationService) ResumeUserClient(ctx context.Context, l *logger.Logger,
+	db database.NotificationDatabase, userID uint32, w http.ResponseWriter, lastEventID uint32,
+) error {
+	return b.local.ResumeUserClient(ctx, l, db, userID, w, lastEventID)
+}
+
+// SendHeartbeat writes a heartbeat to a client connected to this replica.
+func (b *BrokerNotificationService) SendHeartbeat(w http.ResponseWriter) error {
+	return b.local.SendHeartbeat(w)
+}
+
+// DeleteUserConn deletes a user client connected to this replica.
+func (b *BrokerNotificationService) DeleteUserConn(l *logger.Logger, userID uint32, w http.ResponseWriter) {
+	b.local.DeleteUserConn(l, userID, w)
+}
+
+// SendNotification stores a notification for some users, then publishes it so every replica sends it to the
+// clients of the users who get it live. Users' preferences are applied as in SSENotificationService, emails
+// are sent by this replica.
+func (b *BrokerNotificationService) SendNotification(ctx context.Context, l *logger.Logger,
+	db database.NotificationDatabase, userIDs []uint32, notif database.CreateNotificationParams,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	// Stored once here, rather than by each replica
+	storedNotif, d, err := storeNotification(ctx, db, userIDs, notif)
+	if err != nil || storedNotif == nil {
+		return err
+	}
+
+	return b.deliver(ctx, l, db, d, *storedNotif)
+}
+
+// DeliverNotification publishes a notification already stored for its recipients, as SendNotification does once
+// it has stored it.
+func (b *BrokerNotificationService) DeliverNotification(ctx context.Context, l *logger.Logger,
+	db database.NotificationDatabase, recipients []database.NotificationRecipient, notif database.Notification,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	d, err := recipientDelivery(ctx, db, recipients, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to apply notification preferences: %w", err)
+	}
+
+	return b.deliver(ctx, l, db, d, notif)
+}
+
+// deliver publishes a stored notification to every replica and emails it from this one.
+func (b *BrokerNotificationService) deliver(ctx context.Context, l *logger.Logger,
+	db database.NotificationDatabase, d delivery, notif database.Notification,
+) error {
+	return errors.Join(b.publish(ctx, d.liveUserIDs, notif),
+		emailUsers(ctx, l, db, b.local.emailer, d.emailUserIDs, notif))
+}
+
+// publish publishes a stored notification so every replica sends it to the users' clients.
+func (b *BrokerNotificationService) publish(ctx context.Context, userIDs []uint32,
+	notif database.Notification,
+) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	msg, err := json.Marshal(brokerMessage{UserIDs: userIDs, Notification: notif})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification as json: %w", err)
+	}
+
+	if err = b.broker.Publish(ctx, BrokerChannel, msg); err != nil {
+		return fmt.Errorf("failed to publish notification: %w", err)
+	}
+	return nil
+}
+
+// receive sends the notifications published by every replica to the clients connected to this one,
+// until the subscription is closed.
+func (b *BrokerNotificationService) receive(l *logger.Logger, sub Subscription) {
+	for payload := range sub.Messages() {
+		var msg brokerMessage
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			l.Errorf("failed to decode notification from broker: %v", err)
+			continue
+		}
+
+		if err := b.local.sendToClients(l, msg.UserIDs, msg.Notification); err != nil {
+			l.Errorf("failed to send notification to clients: %v", err)
+		}
+	}
+}
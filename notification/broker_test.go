@@ -0,0 +1,135 @@
+package notification_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/mocks"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// syncRecorder is a ResponseRecorder that can be read while notifications are being sent to it.
+type syncRecorder struct {
+	mu sync.Mutex
+	rr *httptest.ResponseRecorder
+}
+
+func newSyncRecorder() *syncRecorder {
+	return &syncRecorder{rr: httptest.NewRecorder()}
+}
+
+func (r *syncRecorder) Header() http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rr.Header()
+}
+
+func (r *syncRecorder) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rr.Write(b)
+}
+
+func (r *syncRecorder) WriteHeader(statusCode int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rr.WriteHeader(statusCode)
+}
+
+func (r *syncRecorder) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rr.Flush()
+}
+
+func (r *syncRecorder) Body() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rr.Body.String()
+}
+
+func Test_BrokerSendNotification(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	var userID uint32 = 1
+	var notifID int64 = 7
+	notifParams, err := notification.NewNotificationParams(notification.GroupJoined{
+		SlotifyGroupID: 3,
+		GroupName:      "Team",
+	}, time.Now())
+	require.NoError(t, err, "creating notification should not return error")
+	expectedBody := testutil.GetExpectedSSERetry() + testutil.GetExpectedNotificationSSE(notifID, notifParams)
+
+	// The notification is stored once, however many replicas there are
+	ctrl := gomock.NewController(t)
+	mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+	testutil.ExpectNoNotificationPreferences(mockNotificationDB)
+	mockNotificationDB.EXPECT().CreateNotification(
+		gomock.Any(), gomock.Eq(notifParams)).Return(notifID, nil).Times(1)
+	mockNotificationDB.EXPECT().CreateUserNotification(gomock.Any(), gomock.Eq(database.CreateUserNotificationParams{
+		UserID:         userID,
+		NotificationID: uint32(notifID),
+		Channel:        string(notification.ChannelInApp),
+	})).Return(int64(1), nil).Times(1)
+
+	// Two API replicas sharing a broker
+	broker := notification.NewMemoryBroker()
+	replicaA, err := notification.NewBrokerNotificationService(t.Context(), l, broker)
+	require.NoError(t, err, "creating replica A notification service should not return error")
+	replicaB, err := notification.NewBrokerNotificationService(t.Context(), l, broker)
+	require.NoError(t, err, "creating replica B notification service should not return error")
+
+	clientA := newSyncRecorder()
+	clientB := newSyncRecorder()
+	require.NoError(t, replicaA.RegisterUserClient(l, userID, clientA), "registering client should not return error")
+	require.NoError(t, replicaB.RegisterUserClient(l, userID, clientB), "registering client should not return error")
+
+	_, present := replicaA.GetUserClients()[userID][clientB]
+	require.False(t, present, "clients are only registered with the replica they are connected to")
+
+	err = replicaA.SendNotification(t.Context(), l, mockNotificationDB, []uint32{userID}, notifParams)
+	require.NoError(t, err, "sending notification should not return error")
+
+	for name, client := range map[string]*syncRecorder{"replica A": clientA, "replica B": clientB} {
+		require.Eventually(t, func() bool {
+			return client.Body() == expectedBody
+		}, 5*time.Second, 10*time.Millisecond, "client of %s receives the notification once", name)
+	}
+}
+
+func Test_MemoryBrokerSubscriptionClose(t *testing.T) {
+	t.Parallel()
+
+	broker := notification.NewMemoryBroker()
+
+	open, err := broker.Subscribe(t.Context(), "channel")
+	require.NoError(t, err)
+	closed, err := broker.Subscribe(t.Context(), "channel")
+	require.NoError(t, err)
+	other, err := broker.Subscribe(t.Context(), "other channel")
+	require.NoError(t, err)
+
+	require.NoError(t, closed.Close())
+	require.NoError(t, closed.Close(), "closing twice is a no-op")
+
+	_, ok := <-closed.Messages()
+	require.False(t, ok, "messages are closed with the subscription")
+
+	require.NoError(t, broker.Publish(t.Context(), "channel", []byte("hello")))
+
+	require.Equal(t, []byte("hello"), <-open.Messages(), "open subscriptions receive the message")
+	select {
+	case msg := <-other.Messages():
+		t.Fatalf("subscription to another channel received %q", msg)
+	default:
+	}
+}
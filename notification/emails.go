@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/mail"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/email"
+	"github.com/SlotifyApp/slotify-backend/logger"
+)
+
+const (
+	// genericEmailTemplate is used for kinds without their own email template, it shows the message.
+	genericEmailTemplate = "notification"
+	// digestEmailTemplate is used for digests of unread notifications.
+	digestEmailTemplate = "digest"
+)
+
+//go:embed templates/*.tmpl
+var emailTemplatesFS embed.FS
+
+// Each email template is a subject and text body, '<name>.subject' and '<name>.txt', and an HTML body,
+// '<name>.html'. Kinds of notification are emailed with the template named after them.
+var (
+	// nolint: gochecknoglobals // parsed once, wont change at runtime
+	emailTextTemplates = texttemplate.Must(texttemplate.New("email").
+				Funcs(texttemplate.FuncMap{"formatTime": formatTime}).
+				ParseFS(emailTemplatesFS, "templates/*.txt.tmpl"))
+	// nolint: gochecknoglobals // parsed once, wont change at runtime
+	emailHTMLTemplates = htmltemplate.Must(htmltemplate.New("email").
+				Funcs(htmltemplate.FuncMap{"formatTime": formatTime}).
+				ParseFS(emailTemplatesFS, "templates/*.html.tmpl"))
+)
+
+// emailData is what email templates are rendered with.
+type emailData struct {
+	FirstName string
+	// Notification and Payload are set for emails of one notification.
+	Notification database.Notification
+	Payload      Payload
+	// Notifications is set for digests.
+	Notifications []database.Notification
+	// URL is the page of the site the email links to, empty if the site's URL isn't known.
+	URL string
+}
+
+// Emailer emails notifications to users.
+type Emailer struct {
+	sender email.Sender
+	// appURL is the URL of the site, emails link to its dashboard.
+	appURL string
+}
+
+// NewEmailer creates a new instance of Emailer, appURL may be empty if the site's URL isn't known.
+func NewEmailer(sender email.Sender, appURL string) (*Emailer, error) {
+	if sender == nil {
+		return nil, errors.New("email sender must not be nil")
+	}
+	return &Emailer{sender: sender, appURL: strings.TrimSuffix(appURL, "/")}, nil
+}
+
+// EmailNotification emails a notification to a user, with the template for its kind.
+func (e *Emailer) EmailNotification(ctx context.Context, user database.User, notif database.Notification) error {
+	payload, err := DecodePayload(Kind(notif.Kind), notif.Payload)
+	if err != nil {
+		return err
+	}
+
+	name := notif.Kind
+	if payload == nil || emailTextTemplates.Lookup(name+".txt") == nil {
+		name = genericEmailTemplate
+	}
+
+	msg, err := e.render(name, user, emailData{Notification: notif, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	if err = e.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send %s notification email: %w", notif.Kind, err)
+	}
+	return nil
+}
+
+// EmailDigest emails a user a digest of their unread notifications.
+func (e *Emailer) EmailDigest(ctx context.Context, user database.User, notifs []database.Notification) error {
+	if len(notifs) == 0 {
+		return nil
+	}
+
+	msg, err := e.render(digestEmailTemplate, user, emailData{Notifications: notifs})
+	if err != nil {
+		return err
+	}
+
+	if err = e.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send notification digest email: %w", err)
+	}
+	return nil
+}
+
+// render renders the email template name for a user.
+func (e *Emailer) render(name string, user database.User, data emailData) (email.Message, error) {
+	data.FirstName = user.FirstName
+	if e.appURL != "" {
+		data.URL = e.appURL + "/dashboard"
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := emailTextTemplates.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return email.Message{}, fmt.Errorf("failed to render %s email subject: %w", name, err)
+	}
+	if err := emailTextTemplates.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return email.Message{}, fmt.Errorf("failed to render %s email text: %w", name, err)
+	}
+	if err := emailHTMLTemplates.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return email.Message{}, fmt.Errorf("failed to render %s email html: %w", name, err)
+	}
+
+	return email.Message{
+		To:      mail.Address{Name: strings.TrimSpace(user.FirstName + " " + user.LastName), Address: user.Email},
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// emailUsers emails a notification to some users, the users who could be emailed still are if some fail.
+func emailUsers(ctx context.Context, logger *logger.Logger, db database.NotificationDatabase, emailer *Emailer,
+	userIDs []uint32, notif database.Notification,
+) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if emailer == nil {
+		logger.Warnf("email is not configured, not emailing notification id(%d) to %d users", notif.ID, len(userIDs))
+		return nil
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		user, err := db.GetUserByID(ctx, userID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get user id(%d) to email: %w", userID, err))
+			continue
+		}
+
+		if err = emailer.EmailNotification(ctx, user, notif); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
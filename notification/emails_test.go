@@ -0,0 +1,288 @@
+package notification_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/email"
+	"github.com/SlotifyApp/slotify-backend/mocks"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingSender is an email.Sender that keeps the emails sent with it.
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []email.Message
+}
+
+func (s *recordingSender) Send(_ context.Context, msg email.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *recordingSender) Messages() []email.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]email.Message{}, s.messages...)
+}
+
+// storedNotification creates a notification as it would be stored, with payload p.
+func storedNotification(t *testing.T, id uint32, p notification.Payload) database.Notification {
+	params, err := notification.NewNotificationParams(p, time.Date(2025, time.March, 4, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err, "creating notification should not return error")
+
+	return database.Notification{
+		ID:      id,
+		Message: params.Message,
+		Created: params.Created,
+		Kind:    params.Kind,
+		Version: params.Version,
+		Payload: params.Payload,
+	}
+}
+
+func Test_EmailerEmailNotification(t *testing.T) {
+	t.Parallel()
+
+	user := database.User{ID: 1, Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace"}
+	start := time.Date(2025, time.March, 4, 9, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		payload         notification.Payload
+		expectedSubject string
+		// expectedText is in both the text and html bodies
+		expectedText string
+	}{
+		"invite received": {
+			payload:         notification.InviteReceived{InviteID: 1, SlotifyGroupID: 2, GroupName: "Design"},
+			expectedSubject: "You're invited to join Design",
+			expectedText:    "You have a new invite to join the team",
+		},
+		"reschedule requested": {
+			payload:         notification.RescheduleRequested{RequestID: 1, MeetingID: 2, NewMeeting: true},
+			expectedSubject: "A meeting reschedule has been requested",
+			expectedText:    "with a new meeting to replace it",
+		},
+		"reschedule accepted": {
+			payload:         notification.RescheduleAccepted{RequestID: 1, MeetingID: 2, Start: start},
+			expectedSubject: "Meeting rescheduled",
+			expectedText:    "Tue 4 Mar 09:30 UTC",
+		},
+		"reschedule rejected": {
+			payload:         notification.RescheduleRejected{RequestID: 1, MeetingID: 2},
+			expectedSubject: "Reschedule request rejected",
+			expectedText:    "the meeting stays at its original time",
+		},
+		"group joined": {
+			payload:         notification.GroupJoined{SlotifyGroupID: 2, GroupName: "Design"},
+			expectedSubject: "You were added to Design",
+			expectedText:    "You were added to the team",
+		},
+		"group member joined": {
+			payload: notification.GroupMemberJoined{
+				SlotifyGroupID: 2, GroupName: "Design", UserID: 3, FirstName: "Grace", LastName: "Hopper",
+			},
+			expectedSubject: "Grace Hopper joined Design",
+			expectedText:    "Say hi to Grace Hopper",
+		},
+		"group member left": {
+			payload: notification.GroupMemberLeft{
+				SlotifyGroupID: 2, GroupName: "Design", UserID: 3, FirstName: "Grace", LastName: "Hopper",
+			},
+			expectedSubject: "Grace Hopper left Design",
+			expectedText:    "Grace Hopper just left",
+		},
+		"poll created": {
+			payload:         notification.PollCreated{PollID: 1, OrganiserID: 2, Subject: "Planning", Deadline: start},
+			expectedSubject: "Vote on a time for Planning",
+			expectedText:    "Voting closes at",
+		},
+		"poll closed": {
+			payload: notification.PollClosed{
+				PollID: 1, OptionID: 3, EventID: "event", Subject: "Planning", Start: start, End: start.Add(time.Hour),
+			},
+			expectedSubject: "Planning scheduled for Tue 4 Mar 09:30 UTC",
+			expectedText:    "the meeting is at",
+		},
+		"group meeting scheduled without subject": {
+			payload: notification.GroupMeetingScheduled{
+				SlotifyGroupID: 2, GroupName: "Design", OrganiserID: 3, EventID: "event", Start: start,
+				End: start.Add(time.Hour),
+			},
+			expectedSubject: "Design meeting scheduled for Tue 4 Mar 09:30 UTC",
+			expectedText:    "was scheduled for the team",
+		},
+		"group renamed": {
+			payload: notification.GroupRenamed{
+				SlotifyGroupID: 2, OldName: "Design", GroupName: "Product Design", UserID: 3, FirstName: "Ada",
+				LastName: "Lovelace",
+			},
+			expectedSubject: "Design is now Product Design",
+			expectedText:    "Ada Lovelace renamed the team",
+		},
+		"kind without a template": {
+			payload:         notification.MeetingCreated{EventID: "event", Subject: "Planning"},
+			expectedSubject: "New notification from Slotify",
+			expectedText:    "Created meeting &#34;Planning&#34;!",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			sender := &recordingSender{}
+			emailer, err := notification.NewEmailer(sender, "https://slotify.app/")
+			require.NoError(t, err, "creating emailer should not return error")
+
+			err = emailer.EmailNotification(t.Context(), user, storedNotification(t, 1, tt.payload))
+			require.NoError(t, err, "emailing notification should not return error")
+
+			messages := sender.Messages()
+			require.Len(t, messages, 1, "one email is sent")
+
+			msg := messages[0]
+			require.Equal(t, "ada@example.com", msg.To.Address)
+			require.Equal(t, "Ada Lovelace", msg.To.Name)
+			require.Equal(t, tt.expectedSubject, msg.Subject)
+			require.Contains(t, msg.Text, "Hi Ada,")
+			require.Contains(t, msg.HTML, "<p>Hi Ada,</p>")
+			require.Contains(t, msg.HTML, tt.expectedText)
+			require.Contains(t, msg.Text, "https://slotify.app/dashboard", "emails link to the site")
+			require.Contains(t, msg.HTML, `href="https://slotify.app/dashboard"`, "emails link to the site")
+		})
+	}
+}
+
+func Test_EmailerEmailDigest(t *testing.T) {
+	t.Parallel()
+
+	sender := &recordingSender{}
+	emailer, err := notification.NewEmailer(sender, "")
+	require.NoError(t, err, "creating emailer should not return error")
+
+	user := database.User{ID: 1, Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace"}
+
+	require.NoError(t, emailer.EmailDigest(t.Context(), user, nil), "empty digest should not return error")
+	require.Empty(t, sender.Messages(), "empty digests aren't sent")
+
+	notifs := []database.Notification{
+		storedNotification(t, 1, notification.GroupJoined{SlotifyGroupID: 2, GroupName: "Design"}),
+		storedNotification(t, 2, notification.InviteReceived{InviteID: 1, SlotifyGroupID: 3, GroupName: "<Ops>"}),
+	}
+	require.NoError(t, emailer.EmailDigest(t.Context(), user, notifs), "emailing digest should not return error")
+
+	messages := sender.Messages()
+	require.Len(t, messages, 1, "one email is sent")
+
+	msg := messages[0]
+	require.Equal(t, "You have 2 unread Slotify notifications", msg.Subject)
+	require.Contains(t, msg.Text, "- You were added to SlotifyGroup Design! (Tue 4 Mar 09:00 UTC)")
+	require.Contains(t, msg.Text, "- You have a new invite to team <Ops>!")
+	require.Contains(t, msg.HTML, "You have a new invite to team &lt;Ops&gt;!", "html is escaped")
+	require.NotContains(t, msg.Text, "Open Slotify", "there is no link without the site's url")
+}
+
+func Test_SSESendNotificationEmail(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	var userID uint32 = 1
+	var notifID int64 = 3
+	user := database.User{ID: userID, Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace"}
+
+	notifParams, err := notification.NewNotificationParams(notification.GroupJoined{
+		SlotifyGroupID: 2,
+		GroupName:      "Team",
+	}, time.Now())
+	require.NoError(t, err, "creating notification should not return error")
+
+	now := time.Now().UTC()
+	quietNow := database.GetNotificationQuietHoursRow{
+		StartTime: now.Add(-time.Hour).Format("15:04"),
+		EndTime:   now.Add(time.Hour).Format("15:04"),
+		TimeZone:  "UTC",
+	}
+
+	tests := map[string]struct {
+		quietHours      *database.GetNotificationQuietHoursRow
+		expectedChannel notification.Channel
+		expectedEmailed bool
+	}{
+		"emailed": {
+			expectedChannel: notification.ChannelEmail,
+			expectedEmailed: true,
+		},
+		"left for the digest during quiet hours": {
+			quietHours:      &quietNow,
+			expectedChannel: notification.ChannelEmailDigest,
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+
+			mockNotificationDB.EXPECT().GetNotificationPreferenceChannel(gomock.Any(), gomock.Any()).
+				Return(string(notification.ChannelEmail), nil).Times(1)
+
+			quietHours := database.GetNotificationQuietHoursRow{}
+			quietHoursErr := sql.ErrNoRows
+			if tt.quietHours != nil {
+				quietHours, quietHoursErr = *tt.quietHours, nil
+			}
+			mockNotificationDB.EXPECT().GetNotificationQuietHours(gomock.Any(), gomock.Eq(userID)).
+				Return(quietHours, quietHoursErr).Times(1)
+
+			mockNotificationDB.EXPECT().CreateNotification(gomock.Any(), gomock.Eq(notifParams)).
+				Return(notifID, nil).Times(1)
+			mockNotificationDB.EXPECT().CreateUserNotification(gomock.Any(),
+				gomock.Eq(database.CreateUserNotificationParams{
+					UserID:         userID,
+					NotificationID: uint32(notifID),
+					Channel:        string(tt.expectedChannel),
+				})).Return(int64(1), nil).Times(1)
+
+			emailed := 0
+			if tt.expectedEmailed {
+				emailed = 1
+			}
+			mockNotificationDB.EXPECT().GetUserByID(gomock.Any(), gomock.Eq(userID)).
+				Return(user, nil).Times(emailed)
+
+			sender := &recordingSender{}
+			emailer, err := notification.NewEmailer(sender, "")
+			require.NoError(t, err, "creating emailer should not return error")
+
+			sseNotificationService := notification.NewSSENotificationService(notification.WithEmailer(emailer))
+			client := httptest.NewRecorder()
+			require.NoError(t, sseNotificationService.RegisterUserClient(l, userID, client),
+				"registering client should not return error")
+
+			err = sseNotificationService.
+				SendNotification(t.Context(), l, mockNotificationDB, []uint32{userID}, notifParams)
+			require.NoError(t, err, "send notification should execute successfully and produce no error")
+
+			sseNotificationService.DeleteUserConn(l, userID, client)
+
+			require.Equal(t, testutil.GetExpectedSSERetry(), client.Body.String(), "notification is not sent live")
+			require.Len(t, sender.Messages(), emailed)
+		})
+	}
+}
@@ -6,3 +6,15 @@ import (
 
 // ErrNotifClientNil is returned if the notification service attempts to register a nil client.
 var ErrNotifClientNil = errors.New("notification client for user cannot be nil")
+
+// ErrNotifClientNotRegistered is returned if the notification service is asked to write to a client that
+// has not been registered.
+var ErrNotifClientNotRegistered = errors.New("notification client is not registered")
+
+// ErrNotifClientNotFlusher is returned if the notification service attempts to register a client that can't
+// flush what is written to it.
+var ErrNotifClientNotFlusher = errors.New("notification client doesn't implement flusher interface")
+
+// ErrTooManyClients is returned if the notification service attempts to register a client for a user who
+// already has MaxClientsPerUser.
+var ErrTooManyClients = errors.New("user has too many notification clients")
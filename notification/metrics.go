@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"expvar"
+)
+
+// ClientMetricsName is the expvar name DefaultClientMetrics are published as.
+const ClientMetricsName = "notification_clients"
+
+// DefaultClientMetrics are the metrics of services created without WithClientMetrics.
+// nolint: gochecknoglobals // published once, expvar names can't be reused
+var DefaultClientMetrics = NewClientMetrics(ClientMetricsName)
+
+// ClientMetrics counts the clients a notification service gave up on, and the events they weren't sent.
+type ClientMetrics struct {
+	// Dropped is how many events weren't written to clients because they were evicted.
+	Dropped expvar.Int
+	// Evicted is how many clients were evicted because their queue was full.
+	Evicted expvar.Int
+	// Rejected is how many clients weren't registered because their user had too many.
+	Rejected expvar.Int
+}
+
+// NewClientMetrics creates ClientMetrics published with expvar as name, it panics if name is already published.
+func NewClientMetrics(name string) *ClientMetrics {
+	metrics := &ClientMetrics{}
+
+	published := expvar.NewMap(name)
+	published.Set("dropped", &metrics.Dropped)
+	published.Set("evicted", &metrics.Evicted)
+	published.Set("rejected", &metrics.Rejected)
+
+	return metrics
+}
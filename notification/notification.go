@@ -13,6 +13,19 @@ import (
 	"github.com/SlotifyApp/slotify-backend/logger"
 )
 
+const (
+	// HeartbeatInterval is how often clients are sent a heartbeat, so proxies don't close idle streams.
+	HeartbeatInterval = 15 * time.Second
+	// ReconnectDelay is how long a browser should wait before reconnecting a dropped stream.
+	ReconnectDelay = 3 * time.Second
+	// ClientQueueSize is how many events may wait to be written to a client before it is evicted.
+	ClientQueueSize = 64
+	// MaxClientsPerUser is how many clients a user may have connected to a service at once.
+	MaxClientsPerUser = 10
+	// clientWriteWait is how long a write to a client may take.
+	clientWriteWait = 10 * time.Second
+)
+
 // ClientSet represents a set of clients for a user.
 type ClientSet map[http.ResponseWriter]struct{}
 
@@ -20,23 +33,135 @@ type ClientSet map[http.ResponseWriter]struct{}
 type Service interface {
 	DeleteUserConn(l *logger.Logger, userID uint32, w http.ResponseWriter)
 	RegisterUserClient(l *logger.Logger, userID uint32, w http.ResponseWriter) error
+	ResumeUserClient(ctx context.Context, l *logger.Logger, db database.NotificationDatabase,
+		userID uint32, w http.ResponseWriter, lastEventID uint32) error
+	SendHeartbeat(w http.ResponseWriter) error
 	SendNotification(ctx context.Context, l *logger.Logger, db database.NotificationDatabase,
 		userIDs []uint32, notif database.CreateNotificationParams) error
+	DeliverNotification(ctx context.Context, l *logger.Logger, db database.NotificationDatabase,
+		recipients []database.NotificationRecipient, notif database.Notification) error
+}
+
+// ServiceOption configures a notification service.
+type ServiceOption func(*serviceOptions)
+
+type serviceOptions struct {
+	emailer           *Emailer
+	metrics           *ClientMetrics
+	queueSize         int
+	maxClientsPerUser int
+}
+
+// WithEmailer emails notifications to the users who chose the email channel for their kind.
+// Without it they are only stored.
+func WithEmailer(emailer *Emailer) ServiceOption {
+	return func(options *serviceOptions) {
+		options.emailer = emailer
+	}
+}
+
+// WithClientMetrics counts the clients the service gives up on in metrics, instead of DefaultClientMetrics.
+func WithClientMetrics(metrics *ClientMetrics) ServiceOption {
+	return func(options *serviceOptions) {
+		options.metrics = metrics
+	}
+}
+
+// WithClientQueueSize sets how many events may wait to be written to a client, instead of ClientQueueSize.
+func WithClientQueueSize(size int) ServiceOption {
+	return func(options *serviceOptions) {
+		options.queueSize = size
+	}
+}
+
+// WithMaxClientsPerUser sets how many clients a user may have connected, instead of MaxClientsPerUser.
+func WithMaxClientsPerUser(maxClients int) ServiceOption {
+	return func(options *serviceOptions) {
+		options.maxClientsPerUser = maxClients
+	}
+}
+
+// clientEvent is an event waiting to be written to a client, it is a heartbeat if notif is nil.
+type clientEvent struct {
+	notif     *database.Notification
+	notifJSON []byte
+}
+
+// clientState is kept for each client. Events are queued for the client's own writer goroutine, so a client
+// that is slow to read doesn't hold up sending to the others.
+type clientState struct {
+	// mu is held while notifications are replayed, so none are queued meanwhile.
+	mu sync.Mutex
+	// replayedID is the id of the last notification the client has been sent by ResumeUserClient,
+	// notifications up to it are not sent again.
+	replayedID uint32
+
+	queue chan clientEvent
+	// removed is closed once the client is removed from the service, which stops its writer. The writer first
+	// writes the events still queued if drain is set, which it is when the client is deleted.
+	removed chan struct{}
+	drain   bool
+	// stopped is closed once the writer has returned.
+	stopped chan struct{}
+}
+
+// queueNotification queues a notification for a client unless it was already replayed to it, returning false
+// if the client's queue is full.
+func (state *clientState) queueNotification(notif *database.Notification, notifJSON []byte) bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if notif.ID <= state.replayedID {
+		return true
+	}
+
+	select {
+	case state.queue <- clientEvent{notif: notif, notifJSON: notifJSON}:
+		return true
+	default:
+		return false
+	}
 }
 
 // SSENotificationService is a Server-Side Events notification service impl.
 type SSENotificationService struct {
 	// Maps a userID to a set of clients that can be used to send notifications to.
 	conns map[uint32]ClientSet
+	// Maps a client to its state.
+	states map[http.ResponseWriter]*clientState
+	// Maps a client the service removed, eg. because it was evicted, to its state until it is deleted.
+	retired map[http.ResponseWriter]*clientState
 
 	// Need a lock as many goroutines may be affecting these maps.
 	mu sync.Mutex
+
+	// emailer is nil if email isn't configured.
+	emailer *Emailer
+
+	metrics           *ClientMetrics
+	queueSize         int
+	maxClientsPerUser int
 }
 
 // NewSSENotificationService creates a new instance of SSENotificationService.
-func NewSSENotificationService() *SSENotificationService {
+func NewSSENotificationService(serviceOpts ...ServiceOption) *SSENotificationService {
+	opts := serviceOptions{
+		metrics:           DefaultClientMetrics,
+		queueSize:         ClientQueueSize,
+		maxClientsPerUser: MaxClientsPerUser,
+	}
+	for _, opt := range serviceOpts {
+		opt(&opts)
+	}
+
 	return &SSENotificationService{
-		conns: make(map[uint32]ClientSet),
+		conns:             make(map[uint32]ClientSet),
+		states:            make(map[http.ResponseWriter]*clientState),
+		retired:           make(map[http.ResponseWriter]*clientState),
+		emailer:           opts.emailer,
+		metrics:           opts.metrics,
+		queueSize:         opts.queueSize,
+		maxClientsPerUser: opts.maxClientsPerUser,
 	}
 }
 
@@ -49,94 +174,437 @@ func (sse *SSENotificationService) GetUserClients() map[uint32]ClientSet {
 	return sse.conns
 }
 
-// RegisterUserClient registers a user client to send notifications to.
+// RegisterUserClient registers a user client to send notifications to, first telling it how long to wait
+// before reconnecting. A user may have at most MaxClientsPerUser clients.
 func (sse *SSENotificationService) RegisterUserClient(logger *logger.Logger,
 	userID uint32, w http.ResponseWriter,
 ) error {
+	state, err := sse.addClient(logger, userID, w)
+	if err != nil || state == nil {
+		return err
+	}
+	state.mu.Unlock()
+
+	if err = writeRetry(w); err != nil {
+		sse.discardClient(userID, w, state)
+		return err
+	}
+
+	sse.startWriter(logger, userID, w, state)
+
+	return nil
+}
+
+// ResumeUserClient registers a user client that is reconnecting after the event lastEventID, first replaying
+// the notifications it missed. Notifications sent meanwhile wait for the replay, so none are missed or
+// sent out of order. A client that is already registered isn't replayed to.
+func (sse *SSENotificationService) ResumeUserClient(ctx context.Context, logger *logger.Logger,
+	db database.NotificationDatabase, userID uint32, w http.ResponseWriter, lastEventID uint32,
+) error {
+	state, err := sse.addClient(logger, userID, w)
+	if err != nil || state == nil {
+		return err
+	}
+	if err = writeRetry(w); err != nil {
+		state.mu.Unlock()
+		sse.discardClient(userID, w, state)
+		return err
+	}
+
+	defer sse.startWriter(logger, userID, w, state)
+	defer state.mu.Unlock()
+
+	// The client already has everything up to its last event
+	state.replayedID = max(state.replayedID, lastEventID)
+
+	ctx, cancel := context.WithTimeout(ctx, database.DatabaseTimeout)
+	defer cancel()
+
+	missed, err := db.ListUserNotificationsAfterID(ctx, database.ListUserNotificationsAfterIDParams{
+		UserID: userID,
+		ID:     lastEventID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get missed notifications: %w", err)
+	}
+
+	for _, notif := range missed {
+		var notifJSON []byte
+		if notifJSON, err = json.Marshal(notif); err != nil {
+			return fmt.Errorf("failed to encode notification as json: %w", err)
+		}
+
+		if err = writeEvent(w, notif, notifJSON); err != nil {
+			return err
+		}
+		state.replayedID = max(state.replayedID, notif.ID)
+	}
+
+	logger.Infof("Replayed %d notifications to client for userID id(%d)", len(missed), userID)
+
+	return nil
+}
+
+// addClient adds a client for a user, returning its state locked or nil if it was already added.
+// The client's writer must be started once it is ready to be sent notifications.
+func (sse *SSENotificationService) addClient(logger *logger.Logger, userID uint32,
+	w http.ResponseWriter,
+) (*clientState, error) {
 	if w == nil {
-		return ErrNotifClientNil
+		return nil, ErrNotifClientNil
+	}
+
+	// The client writes events itself, or they are written to it as an SSE stream that has to be flushed
+	if _, ok := w.(eventWriter); !ok {
+		if _, ok = w.(http.Flusher); !ok {
+			return nil, ErrNotifClientNotFlusher
+		}
 	}
 
 	sse.mu.Lock()
 
 	defer sse.mu.Unlock()
+
 	if sse.conns[userID] == nil {
 		sse.conns[userID] = make(ClientSet)
 	}
 
 	clientSet := sse.conns[userID]
 
+	if _, ok := clientSet[w]; ok {
+		//nolint: nilnil // the client is already being sent notifications
+		return nil, nil
+	}
+
+	if len(clientSet) >= sse.maxClientsPerUser {
+		sse.metrics.Rejected.Add(1)
+		logger.Warnf("Rejected client for userID id(%d), it has %d clients", userID, len(clientSet))
+		return nil, ErrTooManyClients
+	}
+
 	// add client
 	clientSet[w] = struct{}{}
 
+	state := &clientState{
+		queue:   make(chan clientEvent, sse.queueSize),
+		removed: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	// Locked before notifications can be queued, so none are queued before a replay
+	state.mu.Lock()
+	sse.states[w] = state
+
 	logger.Infof("Successfully added client for userID id(%d), clients: %+v", userID, clientSet)
 
-	return nil
+	return state, nil
+}
+
+// takeClient removes a user's client from the maps, returning its state or nil if it wasn't registered.
+// The caller must hold sse.mu.
+func (sse *SSENotificationService) takeClient(userID uint32, w http.ResponseWriter) *clientState {
+	clientSet := sse.conns[userID]
+
+	state, ok := sse.states[w]
+	if _, registered := clientSet[w]; !registered || !ok {
+		return nil
+	}
+
+	delete(clientSet, w)
+	delete(sse.states, w)
+
+	return state
+}
+
+// discardClient removes a client whose writer was never started.
+func (sse *SSENotificationService) discardClient(userID uint32, w http.ResponseWriter, state *clientState) {
+	sse.mu.Lock()
+	sse.takeClient(userID, w)
+	sse.mu.Unlock()
+
+	close(state.stopped)
+}
+
+// retireClient removes a client the service gave up on, stopping its writer at once. DeleteUserConn still
+// waits for the writer, so it doesn't write to the client once its handler has returned.
+func (sse *SSENotificationService) retireClient(userID uint32, w http.ResponseWriter) *clientState {
+	sse.mu.Lock()
+
+	defer sse.mu.Unlock()
+
+	state := sse.takeClient(userID, w)
+	if state == nil {
+		return nil
+	}
+
+	close(state.removed)
+	sse.retired[w] = state
+
+	return state
+}
+
+// evict removes a client whose queue is full, the events queued for it are dropped.
+func (sse *SSENotificationService) evict(logger *logger.Logger, userID uint32, w http.ResponseWriter) {
+	state := sse.retireClient(userID, w)
+	if state == nil {
+		return
+	}
+
+	// The notification that didn't fit in the queue is dropped too
+	dropped := len(state.queue) + 1
+
+	sse.metrics.Evicted.Add(1)
+	sse.metrics.Dropped.Add(int64(dropped))
+
+	logger.Warnf("Evicted client for userID id(%d), it fell %d events behind", userID, dropped)
 }
 
 // DeleteUserClients attempts to deletes a user from the conns map, if there is no user this is a no-op.
+// It returns once the events already queued for the client have been written.
 func (sse *SSENotificationService) DeleteUserConn(logger *logger.Logger, userID uint32, w http.ResponseWriter) {
+	logger.Info("Deleting user id(%d) connection", userID)
+
 	sse.mu.Lock()
+	state := sse.takeClient(userID, w)
+	if state != nil {
+		state.drain = true
+		close(state.removed)
+	} else if state = sse.retired[w]; state != nil {
+		delete(sse.retired, w)
+	}
+	sse.mu.Unlock()
 
-	defer sse.mu.Unlock()
+	if state != nil {
+		<-state.stopped
+	}
+}
 
-	clientSet := sse.conns[userID]
+// SendHeartbeat queues a comment for a client, or a ping for a WebSocket client, so the stream isn't closed
+// by proxies while it is idle. No heartbeat is needed if the client's queue is full.
+// ErrNotifClientNotRegistered is returned once the client has been removed, eg. because it was evicted.
+func (sse *SSENotificationService) SendHeartbeat(w http.ResponseWriter) error {
+	sse.mu.Lock()
+	state, ok := sse.states[w]
+	sse.mu.Unlock()
 
-	logger.Info("Deleting user id(%d) connection, clients: %+v", userID, clientSet)
+	if !ok {
+		return ErrNotifClientNotRegistered
+	}
 
-	delete(sse.conns[userID], w)
+	select {
+	case state.queue <- clientEvent{}:
+	default:
+	}
+	return nil
 }
 
 // SendNotification sends a notification to ALL clients of some users.
-// The notification is also stored in the database regardless of whether the user has a client or not.
+// The notification is also stored in the database regardless of whether the user has a client or not,
+// unless the user muted its kind. Users are only sent it live if they chose the in-app channel for its kind
+// and aren't in their quiet hours, users who chose the email channel are emailed it instead.
 func (sse *SSENotificationService) SendNotification(ctx context.Context, logger *logger.Logger,
 	db database.NotificationDatabase, userIDs []uint32, notif database.CreateNotificationParams,
 ) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	// For all users, store this notification.
-	storedNotif, err := database.StoreNotification(ctx, db, userIDs, notif)
+	storedNotif, d, err := storeNotification(ctx, db, userIDs, notif)
+	if err != nil || storedNotif == nil {
+		return err
+	}
+
+	return sse.deliver(ctx, logger, db, d, *storedNotif)
+}
+
+// DeliverNotification sends a notification already stored for its recipients, as SendNotification does once it
+// has stored it. Recipients on the in-app channel aren't sent it live during their quiet hours.
+func (sse *SSENotificationService) DeliverNotification(ctx context.Context, logger *logger.Logger,
+	db database.NotificationDatabase, recipients []database.NotificationRecipient, notif database.Notification,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	d, err := recipientDelivery(ctx, db, recipients, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to store notification for user: %w", err)
+		return fmt.Errorf("failed to apply notification preferences: %w", err)
 	}
 
-	var notifJSON []byte
-	if notifJSON, err = json.Marshal(*storedNotif); err != nil {
+	return sse.deliver(ctx, logger, db, d, notif)
+}
+
+// deliver sends a stored notification to the clients connected to this service and emails it.
+func (sse *SSENotificationService) deliver(ctx context.Context, logger *logger.Logger,
+	db database.NotificationDatabase, d delivery, notif database.Notification,
+) error {
+	err := sse.sendToClients(logger, d.liveUserIDs, notif)
+	return errors.Join(err, emailUsers(ctx, logger, db, sse.emailer, d.emailUserIDs, notif))
+}
+
+// storeNotification applies users' preferences to a notification and stores it for the users who haven't
+// muted it, returning the stored notification and who to send it to.
+// A nil notification is returned if every user muted it.
+func storeNotification(ctx context.Context, db database.NotificationDatabase, userIDs []uint32,
+	notif database.CreateNotificationParams,
+) (*database.Notification, delivery, error) {
+	d, err := resolveDelivery(ctx, db, userIDs, Kind(notif.Kind), time.Now())
+	if err != nil {
+		return nil, delivery{}, fmt.Errorf("failed to apply notification preferences: %w", err)
+	}
+
+	if len(d.recipients) == 0 {
+		return nil, delivery{}, nil
+	}
+
+	storedNotif, err := database.StoreNotification(ctx, db, d.recipients, notif)
+	if err != nil {
+		return nil, delivery{}, fmt.Errorf("failed to store notification for user: %w", err)
+	}
+	return storedNotif, d, nil
+}
+
+// sendToClients queues a notification for ALL clients of some users connected to this service.
+// Clients whose queue is full are evicted.
+func (sse *SSENotificationService) sendToClients(logger *logger.Logger, userIDs []uint32,
+	notif database.Notification,
+) error {
+	notifJSON, err := json.Marshal(notif)
+	if err != nil {
 		return fmt.Errorf("failed to encode notification as json: %w", err)
 	}
 
-	// for each user, get their clients and flush notification.
+	// for each user, get their clients and queue notification.
 	for _, userID := range userIDs {
 		sse.mu.Lock()
-		clients := sse.conns[userID]
+		clients := make(map[http.ResponseWriter]*clientState, len(sse.conns[userID]))
+		for c := range sse.conns[userID] {
+			clients[c] = sse.states[c]
+		}
 
 		sse.mu.Unlock()
 
-		if clients == nil {
+		if len(clients) == 0 {
 			logger.Infof("user id(%d) does not have a client", userID)
 			// No clients available for user so go to next user
 			continue
 		}
 
-		for c := range clients {
-			logger.Info("attempting to flush to client")
-			if c == nil {
-				logger.Warn("client was nil, attempting to delete")
-				sse.DeleteUserConn(logger, userID, c)
-				continue
+		for c, state := range clients {
+			if !state.queueNotification(&notif, notifJSON) {
+				sse.evict(logger, userID, c)
 			}
+		}
+	}
+	return nil
+}
 
-			fmt.Fprintf(c, "event: calendar_notification\n")
-			fmt.Fprintf(c, "data: %s\n\n", notifJSON)
-
-			f, ok := c.(http.Flusher)
+// startWriter starts a client's writer goroutine, which writes the events queued for the client until it is
+// removed. A client that fails a write is removed.
+func (sse *SSENotificationService) startWriter(logger *logger.Logger, userID uint32, w http.ResponseWriter,
+	state *clientState,
+) {
+	write := func(event clientEvent) bool {
+		err := writeClientEvent(w, event)
+		if err != nil {
+			logger.Warnf("Removing client for userID id(%d), failed to write to it: %v", userID, err)
+			sse.retireClient(userID, w)
+		}
+		return err == nil
+	}
 
-			if !ok {
-				return errors.New("client doesn't implement flusher interface")
+	go func() {
+		defer close(state.stopped)
+
+		for {
+			select {
+			case event := <-state.queue:
+				// Both may be ready, an evicted client isn't written the events still queued
+				select {
+				case <-state.removed:
+					if !state.drain {
+						return
+					}
+				default:
+				}
+
+				if !write(event) {
+					return
+				}
+			case <-state.removed:
+				// Only the writer receives from the queue, events queued once it is empty are dropped with the client
+				for state.drain && len(state.queue) > 0 {
+					if !write(<-state.queue) {
+						return
+					}
+				}
+				return
 			}
-			f.Flush()
 		}
+	}()
+}
+
+// writeClientEvent writes a queued event to a client.
+func writeClientEvent(w http.ResponseWriter, event clientEvent) error {
+	if event.notif == nil {
+		return writeHeartbeat(w)
+	}
+	return writeEvent(w, *event.notif, event.notifJSON)
+}
+
+// writeRetry tells an SSE client how long to wait before reconnecting, it resends the last event id when it
+// does.
+func writeRetry(w http.ResponseWriter) error {
+	if _, ok := w.(eventWriter); ok {
+		return nil
+	}
+
+	setWriteDeadline(w)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", ReconnectDelay.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to write reconnect delay: %w", err)
+	}
+	return flush(w)
+}
+
+// writeHeartbeat writes a comment to a client, or a ping to a WebSocket client.
+func writeHeartbeat(w http.ResponseWriter) error {
+	if ew, ok := w.(eventWriter); ok {
+		return ew.WriteHeartbeat()
+	}
+
+	setWriteDeadline(w)
+	if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+		return fmt.Errorf("failed to write heartbeat: %w", err)
+	}
+	return flush(w)
+}
+
+// writeEvent writes an encoded notification to a client as an event named after its kind, with the
+// notification id as the event id so the client can resume from it. Clients that aren't SSE streams write it
+// themselves.
+func writeEvent(w http.ResponseWriter, notif database.Notification, notifJSON []byte) error {
+	if ew, ok := w.(eventWriter); ok {
+		return ew.WriteEvent(notif, notifJSON)
+	}
+
+	setWriteDeadline(w)
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", notif.ID, Kind(notif.Kind).EventName(),
+		notifJSON); err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+	return flush(w)
+}
+
+// setWriteDeadline bounds how long the next write to an SSE client may take, so a client that stopped reading
+// fails its writes instead of blocking its writer. Writers that can't set a deadline are left as they are.
+func setWriteDeadline(w http.ResponseWriter) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(clientWriteWait))
+}
+
+// flush flushes what has been written to a client.
+func flush(w http.ResponseWriter) error {
+	f, ok := w.(http.Flusher)
+
+	if !ok {
+		return ErrNotifClientNotFlusher
 	}
+	f.Flush()
 	return nil
 }
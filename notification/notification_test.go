@@ -1,10 +1,13 @@
 package notification_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,15 +27,15 @@ func Test_SSERegisterUserClient(t *testing.T) {
 	var userID1 uint32 = 5
 
 	// Test 2 set up
-	w1 := httptest.NewRecorder()
+	w1 := newSyncRecorder()
 	registeredClientsMap1 := make(map[uint32]notification.ClientSet)
 	registeredClientsMap1[userID1] = make(notification.ClientSet)
 	registeredClientsMap1[userID1][w1] = struct{}{}
 
 	// Test 3 set up
 	var userID2 uint32 = 10
-	w2 := httptest.NewRecorder()
-	w3 := httptest.NewRecorder()
+	w2 := newSyncRecorder()
+	w3 := newSyncRecorder()
 	registeredClientsMap2 := make(map[uint32]notification.ClientSet)
 	registeredClientsMap2[userID2] = make(notification.ClientSet)
 	registeredClientsMap2[userID2][w2] = struct{}{}
@@ -52,6 +55,13 @@ func Test_SSERegisterUserClient(t *testing.T) {
 			expectedError:   notification.ErrNotifClientNil,
 			expectedClients: nil,
 		},
+		"register client that can't flush": {
+			clientToAdd:     []http.ResponseWriter{struct{ http.ResponseWriter }{httptest.NewRecorder()}},
+			testMsg:         "registering client that can't flush returns error",
+			userID:          userID1,
+			expectedError:   notification.ErrNotifClientNotFlusher,
+			expectedClients: nil,
+		},
 		"register client": {
 			clientToAdd:     []http.ResponseWriter{w1},
 			testMsg:         "registering 1 client is correct",
@@ -129,9 +139,9 @@ func Test_SSEDeleteUserConn(t *testing.T) {
 	// Test 1 set up
 	var userID1 uint32 = 1
 	var userID2 uint32 = 2
-	w1 := httptest.NewRecorder()
-	w2 := httptest.NewRecorder()
-	w3 := httptest.NewRecorder()
+	w1 := newSyncRecorder()
+	w2 := newSyncRecorder()
+	w3 := newSyncRecorder()
 
 	// test1: remove userID1's w1 client
 	expectedClients1 := make(map[uint32]notification.ClientSet)
@@ -245,7 +255,10 @@ func Test_SSESendNotification(t *testing.T) {
 	var notifID1 int64 = 1
 	created1 := time.Now()
 	notifMessage1 := "This is my notification 1"
-	expectedBody := testutil.GetExpectedNotificationSSE(notifID1, notifMessage1, created1)
+	expectedBody := testutil.GetExpectedNotificationSSE(notifID1, database.CreateNotificationParams{
+		Message: notifMessage1,
+		Created: created1,
+	})
 	tests := map[string]struct {
 		userID          uint32
 		testMsg         string
@@ -278,10 +291,12 @@ func Test_SSESendNotification(t *testing.T) {
 				UserID: tt.userID,
 
 				NotificationID: uint32(tt.notifID),
+				Channel:        string(notification.ChannelInApp),
 			}
 
 			ctrl := gomock.NewController(t)
 			mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+			testutil.ExpectNoNotificationPreferences(mockNotificationDB)
 			mockNotificationDB.EXPECT().CreateNotification(
 				gomock.Any(), gomock.Eq(notifParams)).Return(tt.notifID, nil).Times(tt.expectedDBCalls)
 
@@ -301,13 +316,16 @@ func Test_SSESendNotification(t *testing.T) {
 			require.NoError(t, err,
 				"send notification should execute successfully and produce no error")
 
+			// Deleting the client waits for the notification to be written
+			sseNotificationService.DeleteUserConn(l, tt.userID, client)
+
 			resp := client.Result()
 			require.True(t, client.Flushed, "notification was flushed to body")
 			var body []byte
 			body, err = io.ReadAll(resp.Body)
 			require.NoError(t, err, "failed to read response body")
 
-			expectedData := testutil.GetExpectedNotificationSSE(tt.notifID, tt.notifMessage, tt.created)
+			expectedData := testutil.GetExpectedSSERetry() + testutil.GetExpectedNotificationSSE(tt.notifID, notifParams)
 
 			require.Equal(t, expectedData, string(body), "body of event stream is correct")
 		})
@@ -334,10 +352,12 @@ func Test_SSESendNotification(t *testing.T) {
 			UserID: userID,
 
 			NotificationID: uint32(notifID),
+			Channel:        string(notification.ChannelInApp),
 		}
 
 		ctrl := gomock.NewController(t)
 		mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+		testutil.ExpectNoNotificationPreferences(mockNotificationDB)
 
 		firstCreateNotifCall := mockNotificationDB.
 			EXPECT().
@@ -377,15 +397,17 @@ func Test_SSESendNotification(t *testing.T) {
 		require.NoError(t, err,
 			"send notification should execute successfully and produce no error")
 
+		sseNotificationService.DeleteUserConn(l, userID, client)
+
 		resp := client.Result()
 		require.True(t, client.Flushed, "notification was flushed to body")
 		var body []byte
 		body, err = io.ReadAll(resp.Body)
 		require.NoError(t, err, "failed to read response body")
 
-		expectedData := testutil.
-			GetExpectedNotificationSSE(notifID, message1, created) +
-			testutil.GetExpectedNotificationSSE(notifID, message2, created)
+		expectedData := testutil.GetExpectedSSERetry() +
+			testutil.GetExpectedNotificationSSE(notifID, notifParams1) +
+			testutil.GetExpectedNotificationSSE(notifID, notifParams2)
 
 		require.Equal(t,
 			expectedData,
@@ -393,3 +415,217 @@ func Test_SSESendNotification(t *testing.T) {
 			"body of event stream is correct for multiple notifications")
 	})
 }
+
+func Test_SSEResumeUserClient(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	var userID uint32 = 1
+	var lastEventID uint32 = 4
+	created := time.Now()
+
+	missed := []database.Notification{
+		{ID: 5, Message: "Missed notification 1", Created: created},
+		{ID: 6, Message: "Missed notification 2", Created: created},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+	testutil.ExpectNoNotificationPreferences(mockNotificationDB)
+	mockNotificationDB.EXPECT().ListUserNotificationsAfterID(gomock.Any(),
+		gomock.Eq(database.ListUserNotificationsAfterIDParams{UserID: userID, ID: lastEventID})).
+		Return(missed, nil).
+		Times(1)
+
+	sseNotificationService := notification.NewSSENotificationService()
+	client := httptest.NewRecorder()
+	require.NoError(t, sseNotificationService.ResumeUserClient(t.Context(), l, mockNotificationDB, userID, client,
+		lastEventID), "resuming client should not return error")
+
+	present := sseNotificationService.GetUserClients()[userID][client]
+	require.Equal(t, struct{}{}, present, "client was correctly registered for user")
+
+	// Notification 6 was stored before the client resumed, but is sent afterwards
+	for _, notifID := range []int64{6, 7} {
+		notifParams := database.CreateNotificationParams{
+			Message: fmt.Sprintf("Sent notification %d", notifID),
+			Created: created,
+		}
+		mockNotificationDB.EXPECT().CreateNotification(gomock.Any(), gomock.Eq(notifParams)).
+			Return(notifID, nil).
+			Times(1)
+		mockNotificationDB.EXPECT().CreateUserNotification(gomock.Any(),
+			gomock.Eq(database.CreateUserNotificationParams{
+				UserID:         userID,
+				NotificationID: uint32(notifID),
+				Channel:        string(notification.ChannelInApp),
+			})).
+			Return(int64(1), nil).
+			Times(1)
+
+		err := sseNotificationService.
+			SendNotification(t.Context(), l, mockNotificationDB, []uint32{userID}, notifParams)
+		require.NoError(t, err, "send notification should execute successfully and produce no error")
+	}
+
+	sseNotificationService.DeleteUserConn(l, userID, client)
+
+	expectedData := testutil.GetExpectedSSERetry() +
+		testutil.GetExpectedNotificationSSE(5,
+			database.CreateNotificationParams{Message: "Missed notification 1", Created: created}) +
+		testutil.GetExpectedNotificationSSE(6,
+			database.CreateNotificationParams{Message: "Missed notification 2", Created: created}) +
+		testutil.GetExpectedNotificationSSE(7,
+			database.CreateNotificationParams{Message: "Sent notification 7", Created: created})
+
+	require.Equal(t, expectedData, client.Body.String(),
+		"missed notifications are replayed in order and not sent twice")
+}
+
+func Test_SSESendHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	sseNotificationService := notification.NewSSENotificationService()
+	client := httptest.NewRecorder()
+
+	require.ErrorIs(t, sseNotificationService.SendHeartbeat(client), notification.ErrNotifClientNotRegistered,
+		"heartbeats are only sent to registered clients")
+
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, 1, client),
+		"registering client should not return error")
+	require.NoError(t, sseNotificationService.SendHeartbeat(client), "sending heartbeat should not return error")
+
+	sseNotificationService.DeleteUserConn(l, 1, client)
+
+	require.True(t, client.Flushed, "heartbeat was flushed to body")
+	require.Equal(t, testutil.GetExpectedSSERetry()+": heartbeat\n\n", client.Body.String(),
+		"heartbeat is an event stream comment")
+	require.ErrorIs(t, sseNotificationService.SendHeartbeat(client), notification.ErrNotifClientNotRegistered,
+		"heartbeats are not sent to deleted clients")
+}
+
+// stalledClient is a client that stops reading once stall is closed, its writes then block until release is.
+type stalledClient struct {
+	*syncRecorder
+	stall   chan struct{}
+	stalled chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func newStalledClient() *stalledClient {
+	return &stalledClient{
+		syncRecorder: newSyncRecorder(),
+		stall:        make(chan struct{}),
+		stalled:      make(chan struct{}),
+		release:      make(chan struct{}),
+	}
+}
+
+func (c *stalledClient) Write(b []byte) (int, error) {
+	select {
+	case <-c.stall:
+		c.once.Do(func() { close(c.stalled) })
+		<-c.release
+	default:
+	}
+	return c.syncRecorder.Write(b)
+}
+
+func Test_SSESlowClientEviction(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	var userID uint32 = 1
+	metrics := &notification.ClientMetrics{}
+	sseNotificationService := notification.NewSSENotificationService(
+		notification.WithClientQueueSize(2), notification.WithClientMetrics(metrics))
+
+	ctrl := gomock.NewController(t)
+	mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+	testutil.ExpectNoNotificationPreferences(mockNotificationDB)
+
+	slow := newStalledClient()
+	fast := newSyncRecorder()
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, userID, slow),
+		"registering client should not return error")
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, userID, fast),
+		"registering client should not return error")
+	close(slow.stall)
+	release := sync.OnceFunc(func() { close(slow.release) })
+	defer release()
+
+	recipients := []database.NotificationRecipient{{UserID: userID, Channel: string(notification.ChannelInApp)}}
+	expectedBody := testutil.GetExpectedSSERetry()
+	for id := range uint32(4) {
+		notif := storedNotification(t, id+1, notification.GroupJoined{SlotifyGroupID: 2, GroupName: "Team"})
+		require.NoError(t, sseNotificationService.DeliverNotification(t.Context(), l, mockNotificationDB,
+			recipients, notif), "delivering notification should not return error")
+
+		notifJSON, err := json.Marshal(notif)
+		require.NoError(t, err)
+		expectedBody += fmt.Sprintf("id: %d\nevent: group_joined\ndata: %s\n\n", notif.ID, notifJSON)
+
+		require.Eventually(t, func() bool {
+			return fast.Body() == expectedBody
+		}, 5*time.Second, 10*time.Millisecond, "fast client is sent notification %d", notif.ID)
+
+		if id == 0 {
+			// The slow client's writer is stuck on the first notification, the rest are queued
+			<-slow.stalled
+		}
+	}
+
+	_, present := sseNotificationService.GetUserClients()[userID][slow]
+	require.False(t, present, "slow client was evicted when its queue overflowed")
+	require.ErrorIs(t, sseNotificationService.SendHeartbeat(slow), notification.ErrNotifClientNotRegistered,
+		"evicted client is told it was removed by its heartbeat")
+
+	require.Equal(t, int64(1), metrics.Evicted.Value(), "eviction is counted")
+	require.Equal(t, int64(3), metrics.Dropped.Value(), "queued notification and overflowing one are dropped")
+	require.Zero(t, metrics.Rejected.Value())
+
+	// The evicted client's writer was still stuck, deleting the client waits for it
+	release()
+	sseNotificationService.DeleteUserConn(l, userID, slow)
+	require.Equal(t, testutil.GetExpectedSSERetry(), slow.Body()[:len(testutil.GetExpectedSSERetry())],
+		"evicted client was only written the notification its writer was stuck on")
+	require.NotContains(t, slow.Body(), "id: 2\n", "queued notifications are dropped")
+}
+
+func Test_SSEMaxClientsPerUser(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	metrics := &notification.ClientMetrics{}
+	sseNotificationService := notification.NewSSENotificationService(
+		notification.WithMaxClientsPerUser(2), notification.WithClientMetrics(metrics))
+
+	w1 := httptest.NewRecorder()
+	w2 := httptest.NewRecorder()
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, 1, w1), "registering client should not return error")
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, 1, w2), "registering client should not return error")
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, 1, w2),
+		"registering a client again doesn't count towards the limit")
+
+	rejected := httptest.NewRecorder()
+	require.ErrorIs(t, sseNotificationService.RegisterUserClient(l, 1, rejected), notification.ErrTooManyClients,
+		"user can't register more than the max clients")
+	require.ErrorIs(t, sseNotificationService.ResumeUserClient(t.Context(), l, mocks.NewMockNotificationDatabase(
+		gomock.NewController(t)), 1, rejected, 1), notification.ErrTooManyClients,
+		"user can't resume more than the max clients")
+	require.Empty(t, rejected.Body.String(), "nothing is written to rejected clients")
+	require.Equal(t, int64(2), metrics.Rejected.Value(), "rejected clients are counted")
+
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, 2, httptest.NewRecorder()),
+		"other users' clients don't count towards the limit")
+
+	sseNotificationService.DeleteUserConn(l, 1, w1)
+	require.NoError(t, sseNotificationService.RegisterUserClient(l, 1, rejected),
+		"user can register a client once another is deleted")
+}
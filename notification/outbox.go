@@ -0,0 +1,345 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// DispatchInterval is how often the outbox is checked for notifications to deliver.
+	DispatchInterval = time.Second
+	// DispatchBatchSize is the max number of notifications delivered in one check of the outbox.
+	DispatchBatchSize = 100
+	// DispatchMaxAttempts is how many times delivering a notification is attempted before it is given up on,
+	// it is left in the outbox with its last error.
+	DispatchMaxAttempts = 10
+	// DispatchLease is how long a dispatcher has to deliver a notification it has claimed, after which
+	// another may retry it, eg. if the replica crashed mid-delivery.
+	DispatchLease = 2 * time.Minute
+	// DefaultRetryBackoff is how long to wait before the first retry, doubling on each one after.
+	DefaultRetryBackoff = time.Second
+	// DefaultMaxRetryBackoff caps the wait between retries.
+	DefaultMaxRetryBackoff = 10 * time.Minute
+)
+
+// OutboxDatabase is where notifications are written to be delivered, it should be the transaction the change
+// they are about is made in so that both are saved or neither is.
+type OutboxDatabase interface {
+	CreateNotificationOutbox(ctx context.Context, arg database.CreateNotificationOutboxParams) (int64, error)
+}
+
+// IdempotencyKey builds the key of a notification from its kind and the ids of what it is about, eg. an invite.
+func IdempotencyKey(kind string, ids ...any) string {
+	parts := make([]string, 0, len(ids)+1)
+	parts = append(parts, kind)
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprint(id))
+	}
+	return strings.Join(parts, ":")
+}
+
+// Enqueue writes a notification for some users to the outbox, a Dispatcher delivers it once db's transaction
+// is committed. Only the first notification with a key is kept, an empty key is replaced by a random one.
+func Enqueue(ctx context.Context, db OutboxDatabase, key string, userIDs []uint32,
+	notif database.CreateNotificationParams,
+) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if key == "" {
+		key = uuid.NewString()
+	}
+
+	userIDsJSON, err := json.Marshal(userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification user ids: %w", err)
+	}
+
+	if _, err = db.CreateNotificationOutbox(ctx, database.CreateNotificationOutboxParams{
+		IdempotencyKey: key,
+		UserIds:        userIDsJSON,
+		Message:        notif.Message,
+		Created:        notif.Created,
+		Kind:           notif.Kind,
+		Version:        notif.Version,
+		Payload:        notif.Payload,
+		NextAttemptAt:  notif.Created,
+	}); err != nil {
+		return fmt.Errorf("failed to add notification to outbox: %w", err)
+	}
+	return nil
+}
+
+// DispatcherOption configures a Dispatcher.
+type DispatcherOption func(*dispatcherOptions)
+
+type dispatcherOptions struct {
+	interval        *time.Duration
+	retryBackoff    *time.Duration
+	maxRetryBackoff *time.Duration
+}
+
+// WithDispatchInterval sets how often the outbox is checked, DispatchInterval by default.
+func WithDispatchInterval(interval time.Duration) DispatcherOption {
+	return func(options *dispatcherOptions) {
+		options.interval = &interval
+	}
+}
+
+// WithRetryBackoff sets the wait before the first retry and the max wait between retries,
+// DefaultRetryBackoff and DefaultMaxRetryBackoff by default.
+func WithRetryBackoff(backoff, maxBackoff time.Duration) DispatcherOption {
+	return func(options *dispatcherOptions) {
+		options.retryBackoff = &backoff
+		options.maxRetryBackoff = &maxBackoff
+	}
+}
+
+// Dispatcher delivers the notifications in the outbox at least once, retrying with backoff.
+// Many dispatchers can share an outbox, a notification is claimed for DispatchLease while it is delivered.
+// A notification is stored for its users exactly once, a retry only sends it to clients and emails again.
+type Dispatcher struct {
+	db              *database.Database
+	service         Service
+	l               *logger.Logger
+	interval        time.Duration
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+}
+
+// NewDispatcher creates a Dispatcher delivering the outbox of db with service.
+func NewDispatcher(db *database.Database, service Service, l *logger.Logger,
+	dispatcherOpts ...DispatcherOption,
+) (*Dispatcher, error) {
+	if db == nil || service == nil {
+		return nil, errors.New("notification dispatcher needs a db and a notification service")
+	}
+
+	var opts dispatcherOptions
+	for _, opt := range dispatcherOpts {
+		opt(&opts)
+	}
+
+	d := &Dispatcher{
+		db:              db,
+		service:         service,
+		l:               l,
+		interval:        DispatchInterval,
+		retryBackoff:    DefaultRetryBackoff,
+		maxRetryBackoff: DefaultMaxRetryBackoff,
+	}
+	if opts.interval != nil {
+		d.interval = *opts.interval
+	}
+	if opts.retryBackoff != nil {
+		d.retryBackoff = *opts.retryBackoff
+		d.maxRetryBackoff = *opts.maxRetryBackoff
+	}
+	return d, nil
+}
+
+// Run delivers the outbox every interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			d.l.Errorf("failed to dispatch notifications: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue delivers the notifications in the outbox that are due, returning how many were delivered.
+// Failed deliveries are logged and retried later.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	listCtx, cancel := context.WithTimeout(ctx, database.DatabaseTimeout)
+	due, err := d.db.ListDueNotificationOutbox(listCtx, database.ListDueNotificationOutboxParams{
+		NextAttemptAt: time.Now(),
+		Attempts:      DispatchMaxAttempts,
+		Limit:         DispatchBatchSize,
+	})
+	cancel()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due notifications from outbox: %w", err)
+	}
+
+	delivered := 0
+	for _, msg := range due {
+		ok, dispatchErr := d.dispatch(ctx, msg)
+		if dispatchErr != nil {
+			d.l.Errorf("failed to dispatch notification id(%d) from outbox: %v", msg.ID, dispatchErr)
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// dispatch claims and delivers one notification, returning whether it was delivered. A notification claimed by
+// another dispatcher is skipped.
+func (d *Dispatcher) dispatch(ctx context.Context, msg database.NotificationOutbox) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, DispatchLease)
+	defer cancel()
+
+	rows, err := d.db.ClaimNotificationOutbox(ctx, database.ClaimNotificationOutboxParams{
+		NextAttemptAt: time.Now().Add(DispatchLease),
+		ID:            msg.ID,
+		Attempts:      msg.Attempts,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", err)
+	}
+	if rows != 1 {
+		return false, nil
+	}
+	msg.Attempts++
+
+	if err = d.deliver(ctx, msg); err != nil {
+		if _, retryErr := d.db.RetryNotificationOutbox(ctx, database.RetryNotificationOutboxParams{
+			NextAttemptAt: time.Now().Add(d.backoff(msg.Attempts)),
+			LastError:     sql.NullString{String: err.Error(), Valid: true},
+			ID:            msg.ID,
+		}); retryErr != nil {
+			// Retried once the lease is over instead
+			return false, errors.Join(err, fmt.Errorf("failed to schedule retry: %w", retryErr))
+		}
+		return false, err
+	}
+
+	if _, err = d.db.MarkNotificationOutboxDelivered(ctx, database.MarkNotificationOutboxDeliveredParams{
+		DeliveredAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:          msg.ID,
+	}); err != nil {
+		return false, fmt.Errorf("failed to mark notification as delivered: %w", err)
+	}
+	return true, nil
+}
+
+// backoff returns how long to wait before retrying a notification that has been attempted attempts times.
+func (d *Dispatcher) backoff(attempts uint32) time.Duration {
+	backoff := d.retryBackoff
+	for i := uint32(1); i < attempts && backoff < d.maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, d.maxRetryBackoff)
+}
+
+// deliver stores a notification from the outbox if it hasn't been already, then sends it to its recipients.
+func (d *Dispatcher) deliver(ctx context.Context, msg database.NotificationOutbox) error {
+	notif, recipients, err := d.store(ctx, msg)
+	if err != nil || notif == nil {
+		return err
+	}
+
+	if err = d.service.DeliverNotification(ctx, d.l, d.db, recipients, *notif); err != nil {
+		return fmt.Errorf("failed to deliver notification: %w", err)
+	}
+	return nil
+}
+
+// store stores a notification from the outbox for the users who haven't muted it, in the same transaction as
+// recording it on the outbox so a retry doesn't store it again. A nil notification is returned if every user
+// muted it.
+func (d *Dispatcher) store(ctx context.Context,
+	msg database.NotificationOutbox,
+) (*database.Notification, []database.NotificationRecipient, error) {
+	if msg.NotificationID.Valid {
+		return d.stored(ctx, msg)
+	}
+
+	var userIDs []uint32
+	if err := json.Unmarshal(msg.UserIds, &userIDs); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode notification user ids: %w", err)
+	}
+
+	tx, err := d.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start db transaction: %w", err)
+	}
+
+	defer func() {
+		if err = tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			d.l.Errorf("failed to rollback db transaction: %v", err)
+		}
+	}()
+
+	qtx := d.db.WithTx(tx)
+
+	dlv, err := resolveDelivery(ctx, qtx, userIDs, Kind(msg.Kind), time.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply notification preferences: %w", err)
+	}
+	if len(dlv.recipients) == 0 {
+		return nil, nil, nil
+	}
+
+	notif, err := database.StoreNotification(ctx, qtx, dlv.recipients, database.CreateNotificationParams{
+		Message: msg.Message,
+		Created: msg.Created,
+		Kind:    msg.Kind,
+		Version: msg.Version,
+		Payload: msg.Payload,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to store notification for user: %w", err)
+	}
+
+	rows, err := qtx.SetNotificationOutboxNotificationID(ctx, database.SetNotificationOutboxNotificationIDParams{
+		//nolint: gosec // id is unsigned 32 bit int
+		NotificationID: sql.NullInt32{Int32: int32(notif.ID), Valid: true},
+		ID:             msg.ID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to record stored notification on outbox: %w", err)
+	}
+	if rows != 1 {
+		// Stored by another dispatcher after this one's lease ran out
+		return nil, nil, database.WrongNumberSQLRowsError{ActualRows: rows, ExpectedRows: []int64{1}}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit db transaction: %w", err)
+	}
+	return notif, dlv.recipients, nil
+}
+
+// stored returns a notification from the outbox that has already been stored, and who it was stored for.
+func (d *Dispatcher) stored(ctx context.Context,
+	msg database.NotificationOutbox,
+) (*database.Notification, []database.NotificationRecipient, error) {
+	//nolint: gosec // id is unsigned 32 bit int
+	notifID := uint32(msg.NotificationID.Int32)
+
+	notif, err := d.db.GetNotificationByID(ctx, notifID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get stored notification: %w", err)
+	}
+
+	rows, err := d.db.ListNotificationRecipients(ctx, notifID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get stored notification recipients: %w", err)
+	}
+
+	recipients := make([]database.NotificationRecipient, 0, len(rows))
+	for _, r := range rows {
+		recipients = append(recipients, database.NotificationRecipient{UserID: r.UserID, Channel: r.Channel})
+	}
+	return &notif, recipients, nil
+}
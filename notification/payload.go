@@ -0,0 +1,370 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+)
+
+// PayloadVersion is the version of the payload schemas below, it is stored with each notification and must be
+// bumped when a payload's fields change in a way clients can't ignore.
+const PayloadVersion = 1
+
+// legacyEventName is the SSE event name of notifications without a kind, which only have a message.
+const legacyEventName = "calendar_notification"
+
+// Kind is the kind of a notification, it decides the fields of its payload and its SSE event name.
+type Kind string
+
+// The kinds of notification, their payloads are below.
+const (
+	// KindMessage notifications only have a message, notifications stored before kinds were added are these.
+	KindMessage               Kind = "message"
+	KindInviteReceived        Kind = "invite_received"
+	KindInviteSent            Kind = "invite_sent"
+	KindGroupJoined           Kind = "group_joined"
+	KindGroupMemberJoined     Kind = "group_member_joined"
+	KindGroupMemberLeft       Kind = "group_member_left"
+	KindMeetingCreated        Kind = "meeting_created"
+	KindMeetingMoved          Kind = "meeting_moved"
+	KindMeetingRescheduled    Kind = "meeting_rescheduled"
+	KindRescheduleRequested   Kind = "reschedule_requested"
+	KindRescheduleAccepted    Kind = "reschedule_accepted"
+	KindRescheduleRejected    Kind = "reschedule_rejected"
+	KindPollCreated           Kind = "poll_created"
+	KindPollVoteCast          Kind = "poll_vote_cast"
+	KindPollClosed            Kind = "poll_closed"
+	KindGroupMeetingScheduled Kind = "group_meeting_scheduled"
+	KindGroupRenamed          Kind = "group_renamed"
+)
+
+// EventName returns the SSE event name notifications of this kind are sent with.
+func (k Kind) EventName() string {
+	if k == "" || k == KindMessage {
+		return legacyEventName
+	}
+	return string(k)
+}
+
+// Valid returns whether k is one of the kinds above.
+func (k Kind) Valid() bool {
+	switch k {
+	case KindMessage, KindInviteReceived, KindInviteSent, KindGroupJoined, KindGroupMemberJoined,
+		KindGroupMemberLeft, KindMeetingCreated, KindMeetingMoved, KindMeetingRescheduled,
+		KindRescheduleRequested, KindRescheduleAccepted, KindRescheduleRejected, KindPollCreated, KindPollVoteCast,
+		KindPollClosed, KindGroupMeetingScheduled, KindGroupRenamed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Payload is the structured content of a notification, clients use it to deep-link and localise notifications.
+type Payload interface {
+	// Kind returns the kind of notification the payload is for.
+	Kind() Kind
+}
+
+// InviteReceived is sent to a user invited to a group.
+type InviteReceived struct {
+	InviteID       uint32 `json:"inviteID"`
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	GroupName      string `json:"groupName"`
+	FromUserID     uint32 `json:"fromUserID"`
+}
+
+// Kind implements Payload.
+func (InviteReceived) Kind() Kind { return KindInviteReceived }
+
+// InviteSent is sent to a user who invited someone to a group.
+type InviteSent struct {
+	InviteID        uint32 `json:"inviteID"`
+	SlotifyGroupID  uint32 `json:"slotifyGroupID"`
+	GroupName       string `json:"groupName"`
+	ToUserID        uint32 `json:"toUserID"`
+	ToUserFirstName string `json:"toUserFirstName"`
+	ToUserLastName  string `json:"toUserLastName"`
+}
+
+// Kind implements Payload.
+func (InviteSent) Kind() Kind { return KindInviteSent }
+
+// GroupJoined is sent to a user who was added to a group.
+type GroupJoined struct {
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	GroupName      string `json:"groupName"`
+}
+
+// Kind implements Payload.
+func (GroupJoined) Kind() Kind { return KindGroupJoined }
+
+// GroupMemberJoined is sent to the members of a group when someone joins it.
+type GroupMemberJoined struct {
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	GroupName      string `json:"groupName"`
+	UserID         uint32 `json:"userID"`
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+}
+
+// Kind implements Payload.
+func (GroupMemberJoined) Kind() Kind { return KindGroupMemberJoined }
+
+// GroupMemberLeft is sent to the members of a group when someone leaves it.
+type GroupMemberLeft struct {
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	GroupName      string `json:"groupName"`
+	UserID         uint32 `json:"userID"`
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+}
+
+// Kind implements Payload.
+func (GroupMemberLeft) Kind() Kind { return KindGroupMemberLeft }
+
+// MeetingCreated is sent to a user who created a meeting.
+type MeetingCreated struct {
+	EventID string `json:"eventID"`
+	Subject string `json:"subject"`
+}
+
+// Kind implements Payload.
+func (MeetingCreated) Kind() Kind { return KindMeetingCreated }
+
+// MeetingMoved is sent to users with a pending reschedule request for a meeting its owner moved.
+type MeetingMoved struct {
+	MeetingID uint32    `json:"meetingID"`
+	EventID   string    `json:"eventID"`
+	Subject   string    `json:"subject"`
+	Start     time.Time `json:"start"`
+}
+
+// Kind implements Payload.
+func (MeetingMoved) Kind() Kind { return KindMeetingMoved }
+
+// MeetingRescheduled is sent to the attendees of a meeting when a reschedule request for it is accepted.
+type MeetingRescheduled struct {
+	MeetingID uint32    `json:"meetingID"`
+	EventID   string    `json:"eventID"`
+	Subject   string    `json:"subject"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
+
+// Kind implements Payload.
+func (MeetingRescheduled) Kind() Kind { return KindMeetingRescheduled }
+
+// RescheduleRequested is sent to the owner of a meeting someone has asked to reschedule.
+type RescheduleRequested struct {
+	RequestID   uint32 `json:"requestID"`
+	MeetingID   uint32 `json:"meetingID"`
+	RequestedBy uint32 `json:"requestedBy"`
+	// NewMeeting is whether the request is for a new meeting to replace this one.
+	NewMeeting bool `json:"newMeeting"`
+}
+
+// Kind implements Payload.
+func (RescheduleRequested) Kind() Kind { return KindRescheduleRequested }
+
+// RescheduleAccepted is sent to the owner of a meeting when they accept a reschedule request for it.
+type RescheduleAccepted struct {
+	RequestID uint32    `json:"requestID"`
+	MeetingID uint32    `json:"meetingID"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
+
+// Kind implements Payload.
+func (RescheduleAccepted) Kind() Kind { return KindRescheduleAccepted }
+
+// RescheduleRejected is sent to the requester and the owner of a meeting when a reschedule request for it is
+// rejected.
+type RescheduleRejected struct {
+	RequestID uint32 `json:"requestID"`
+	MeetingID uint32 `json:"meetingID"`
+}
+
+// Kind implements Payload.
+func (RescheduleRejected) Kind() Kind { return KindRescheduleRejected }
+
+// PollCreated is sent to the voters of a poll when it is published.
+type PollCreated struct {
+	PollID      uint32    `json:"pollID"`
+	OrganiserID uint32    `json:"organiserID"`
+	Subject     string    `json:"subject"`
+	Deadline    time.Time `json:"deadline"`
+}
+
+// Kind implements Payload.
+func (PollCreated) Kind() Kind { return KindPollCreated }
+
+// PollOptionTally is how many voters voted each way for a slot suggested in a poll.
+type PollOptionTally struct {
+	OptionID uint32 `json:"optionID"`
+	Yes      int    `json:"yes"`
+	Maybe    int    `json:"maybe"`
+	No       int    `json:"no"`
+}
+
+// PollVoteCast is sent to the other voters of a poll when someone votes on it, with the poll's new tally so
+// clients can update it live.
+type PollVoteCast struct {
+	PollID  uint32            `json:"pollID"`
+	UserID  uint32            `json:"userID"`
+	Subject string            `json:"subject"`
+	Tally   []PollOptionTally `json:"tally"`
+}
+
+// Kind implements Payload.
+func (PollVoteCast) Kind() Kind { return KindPollVoteCast }
+
+// PollClosed is sent to the voters of a poll when it is closed and the meeting created in the chosen slot.
+type PollClosed struct {
+	PollID   uint32    `json:"pollID"`
+	OptionID uint32    `json:"optionID"`
+	EventID  string    `json:"eventID"`
+	Subject  string    `json:"subject"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
+// Kind implements Payload.
+func (PollClosed) Kind() Kind { return KindPollClosed }
+
+// GroupMeetingScheduled is sent to the members of a group when a meeting is scheduled for the whole group.
+type GroupMeetingScheduled struct {
+	SlotifyGroupID uint32    `json:"slotifyGroupID"`
+	GroupName      string    `json:"groupName"`
+	OrganiserID    uint32    `json:"organiserID"`
+	EventID        string    `json:"eventID"`
+	Subject        string    `json:"subject"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+}
+
+// Kind implements Payload.
+func (GroupMeetingScheduled) Kind() Kind { return KindGroupMeetingScheduled }
+
+// GroupRenamed is sent to the members of a group when someone renames it.
+type GroupRenamed struct {
+	SlotifyGroupID uint32 `json:"slotifyGroupID"`
+	OldName        string `json:"oldName"`
+	GroupName      string `json:"groupName"`
+	UserID         uint32 `json:"userID"`
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+}
+
+// Kind implements Payload.
+func (GroupRenamed) Kind() Kind { return KindGroupRenamed }
+
+// DecodePayload decodes the stored payload of a notification of kind, nil is returned for message notifications.
+func DecodePayload(kind Kind, data json.RawMessage) (Payload, error) {
+	var p Payload
+	switch kind {
+	case "", KindMessage:
+		//nolint: nilnil // message notifications have no payload
+		return nil, nil
+	case KindInviteReceived:
+		p = &InviteReceived{}
+	case KindInviteSent:
+		p = &InviteSent{}
+	case KindGroupJoined:
+		p = &GroupJoined{}
+	case KindGroupMemberJoined:
+		p = &GroupMemberJoined{}
+	case KindGroupMemberLeft:
+		p = &GroupMemberLeft{}
+	case KindMeetingCreated:
+		p = &MeetingCreated{}
+	case KindMeetingMoved:
+		p = &MeetingMoved{}
+	case KindMeetingRescheduled:
+		p = &MeetingRescheduled{}
+	case KindRescheduleRequested:
+		p = &RescheduleRequested{}
+	case KindRescheduleAccepted:
+		p = &RescheduleAccepted{}
+	case KindRescheduleRejected:
+		p = &RescheduleRejected{}
+	case KindPollCreated:
+		p = &PollCreated{}
+	case KindPollVoteCast:
+		p = &PollVoteCast{}
+	case KindPollClosed:
+		p = &PollClosed{}
+	case KindGroupMeetingScheduled:
+		p = &GroupMeetingScheduled{}
+	case KindGroupRenamed:
+		p = &GroupRenamed{}
+	default:
+		return nil, fmt.Errorf("unknown notification kind '%s'", kind)
+	}
+
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to decode %s notification payload: %w", kind, err)
+	}
+	return p, nil
+}
+
+// messageTemplates render the message of each kind of notification from its payload.
+// nolint: gochecknoglobals // parsed once, wont change at runtime
+var messageTemplates = template.Must(template.New("messages").Funcs(template.FuncMap{
+	"formatTime": formatTime,
+}).Parse(`
+{{define "invite_received"}}You have a new invite to team {{.GroupName}}!{{end}}
+{{define "invite_sent"}}You successfully created an invite on behalf of team {{.GroupName}} to ` +
+	`{{.ToUserFirstName}} {{.ToUserLastName}}!{{end}}
+{{define "group_joined"}}You were added to SlotifyGroup {{.GroupName}}!{{end}}
+{{define "group_member_joined"}}Say hi to {{.FirstName}} {{.LastName}}, they just joined SlotifyGroup ` +
+	`{{.GroupName}}{{end}}
+{{define "group_member_left"}}{{.FirstName}} {{.LastName}} just left group {{.GroupName}}{{end}}
+{{define "meeting_created"}}Created meeting{{with .Subject}} {{printf "%q" .}}{{end}}!{{end}}
+{{define "meeting_moved"}}{{with .Subject}}{{printf "%q" .}}, a meeting you requested to reschedule,` +
+	`{{else}}A meeting you requested to reschedule{{end}} was moved by the owner to {{formatTime .Start}}{{end}}
+{{define "meeting_rescheduled"}}{{with .Subject}}Meeting {{printf "%q" .}}{{else}}A meeting{{end}} has been ` +
+	`rescheduled to {{formatTime .Start}}{{end}}
+{{define "reschedule_requested"}}Reschedule request for meeting{{if .NewMeeting}} for a new meeting{{end}}{{end}}
+{{define "reschedule_accepted"}}You have successfully rescheduled the meeting to {{formatTime .Start}}{{end}}
+{{define "reschedule_rejected"}}Reschedule request rejected{{end}}
+{{define "poll_created"}}Vote on a time for {{with .Subject}}{{printf "%q" .}}{{else}}a meeting{{end}} by ` +
+	`{{formatTime .Deadline}}{{end}}
+{{define "poll_vote_cast"}}New vote on the poll for {{with .Subject}}{{printf "%q" .}}{{else}}a meeting{{end}}{{end}}
+{{define "poll_closed"}}The poll for {{with .Subject}}{{printf "%q" .}}{{else}}a meeting{{end}} has closed, ` +
+	`the meeting is at {{formatTime .Start}}{{end}}
+{{define "group_meeting_scheduled"}}{{with .Subject}}Meeting {{printf "%q" .}}{{else}}A meeting{{end}} was scheduled ` +
+	`for team {{.GroupName}} at {{formatTime .Start}}{{end}}
+{{define "group_renamed"}}{{.FirstName}} {{.LastName}} renamed team {{.OldName}} to {{.GroupName}}{{end}}
+`))
+
+// formatTime formats times in notifications and emails.
+func formatTime(t time.Time) string {
+	return t.Format("Mon 2 Jan 15:04 MST")
+}
+
+// NewNotificationParams creates the params to store a notification with payload p, its message is rendered
+// from the template for its kind.
+func NewNotificationParams(p Payload, created time.Time) (database.CreateNotificationParams, error) {
+	var message bytes.Buffer
+	if err := messageTemplates.ExecuteTemplate(&message, string(p.Kind()), p); err != nil {
+		return database.CreateNotificationParams{}, fmt.Errorf("failed to render %s notification: %w", p.Kind(), err)
+	}
+
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return database.CreateNotificationParams{},
+			fmt.Errorf("failed to encode %s notification payload as json: %w", p.Kind(), err)
+	}
+
+	return database.CreateNotificationParams{
+		Message: message.String(),
+		Created: created,
+		Kind:    string(p.Kind()),
+		Version: PayloadVersion,
+		Payload: payload,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewNotificationParams(t *testing.T) {
+	t.Parallel()
+
+	created := time.Now()
+	start := time.Date(2025, time.March, 4, 9, 30, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := map[string]struct {
+		payload         notification.Payload
+		expectedMessage string
+	}{
+		"invite received": {
+			payload: notification.InviteReceived{
+				InviteID: 1, SlotifyGroupID: 2, GroupName: "Design", FromUserID: 3,
+			},
+			expectedMessage: "You have a new invite to team Design!",
+		},
+		"invite sent": {
+			payload: notification.InviteSent{
+				InviteID: 1, SlotifyGroupID: 2, GroupName: "Design", ToUserID: 3,
+				ToUserFirstName: "Ada", ToUserLastName: "Lovelace",
+			},
+			expectedMessage: "You successfully created an invite on behalf of team Design to Ada Lovelace!",
+		},
+		"group joined": {
+			payload:         notification.GroupJoined{SlotifyGroupID: 2, GroupName: "Design"},
+			expectedMessage: "You were added to SlotifyGroup Design!",
+		},
+		"group member joined": {
+			payload: notification.GroupMemberJoined{
+				SlotifyGroupID: 2, GroupName: "Design", UserID: 3, FirstName: "Ada", LastName: "Lovelace",
+			},
+			expectedMessage: "Say hi to Ada Lovelace, they just joined SlotifyGroup Design",
+		},
+		"group member left": {
+			payload: notification.GroupMemberLeft{
+				SlotifyGroupID: 2, GroupName: "Design", UserID: 3, FirstName: "Ada", LastName: "Lovelace",
+			},
+			expectedMessage: "Ada Lovelace just left group Design",
+		},
+		"meeting created": {
+			payload:         notification.MeetingCreated{EventID: "event", Subject: "Planning"},
+			expectedMessage: `Created meeting "Planning"!`,
+		},
+		"meeting created without subject": {
+			payload:         notification.MeetingCreated{EventID: "event"},
+			expectedMessage: "Created meeting!",
+		},
+		"meeting moved": {
+			payload: notification.MeetingMoved{MeetingID: 1, EventID: "event", Subject: "Planning", Start: start},
+			expectedMessage: `"Planning", a meeting you requested to reschedule, was moved by the owner to ` +
+				"Tue 4 Mar 09:30 UTC",
+		},
+		"meeting moved without subject": {
+			payload: notification.MeetingMoved{MeetingID: 1, EventID: "event", Start: start},
+			expectedMessage: "A meeting you requested to reschedule was moved by the owner to " +
+				"Tue 4 Mar 09:30 UTC",
+		},
+		"meeting rescheduled": {
+			payload: notification.MeetingRescheduled{
+				MeetingID: 1, EventID: "event", Subject: "Planning", Start: start, End: end,
+			},
+			expectedMessage: `Meeting "Planning" has been rescheduled to Tue 4 Mar 09:30 UTC`,
+		},
+		"reschedule requested": {
+			payload:         notification.RescheduleRequested{RequestID: 1, MeetingID: 2, RequestedBy: 3},
+			expectedMessage: "Reschedule request for meeting",
+		},
+		"reschedule requested for a new meeting": {
+			payload: notification.RescheduleRequested{
+				RequestID: 1, MeetingID: 2, RequestedBy: 3, NewMeeting: true,
+			},
+			expectedMessage: "Reschedule request for meeting for a new meeting",
+		},
+		"reschedule accepted": {
+			payload:         notification.RescheduleAccepted{RequestID: 1, MeetingID: 2, Start: start, End: end},
+			expectedMessage: "You have successfully rescheduled the meeting to Tue 4 Mar 09:30 UTC",
+		},
+		"reschedule rejected": {
+			payload:         notification.RescheduleRejected{RequestID: 1, MeetingID: 2},
+			expectedMessage: "Reschedule request rejected",
+		},
+		"poll created": {
+			payload:         notification.PollCreated{PollID: 1, OrganiserID: 2, Subject: "Planning", Deadline: start},
+			expectedMessage: `Vote on a time for "Planning" by Tue 4 Mar 09:30 UTC`,
+		},
+		"poll vote cast": {
+			payload: notification.PollVoteCast{
+				PollID: 1, UserID: 2, Subject: "Planning",
+				Tally: []notification.PollOptionTally{{OptionID: 3, Yes: 1}},
+			},
+			expectedMessage: `New vote on the poll for "Planning"`,
+		},
+		"poll closed without subject": {
+			payload:         notification.PollClosed{PollID: 1, OptionID: 3, EventID: "event", Start: start, End: end},
+			expectedMessage: "The poll for a meeting has closed, the meeting is at Tue 4 Mar 09:30 UTC",
+		},
+		"group meeting scheduled": {
+			payload: notification.GroupMeetingScheduled{
+				SlotifyGroupID: 1, GroupName: "Design", OrganiserID: 2, EventID: "event", Subject: "Planning",
+				Start: start, End: end,
+			},
+			expectedMessage: `Meeting "Planning" was scheduled for team Design at Tue 4 Mar 09:30 UTC`,
+		},
+		"group renamed": {
+			payload: notification.GroupRenamed{
+				SlotifyGroupID: 1, OldName: "Design", GroupName: "Product Design", UserID: 2, FirstName: "Ada",
+				LastName: "Lovelace",
+			},
+			expectedMessage: "Ada Lovelace renamed team Design to Product Design",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := notification.NewNotificationParams(tt.payload, created)
+			require.NoError(t, err, "creating notification should not return error")
+
+			require.Equal(t, tt.expectedMessage, params.Message, "message is rendered from the kind's template")
+			require.Equal(t, created, params.Created)
+			require.Equal(t, string(tt.payload.Kind()), params.Kind)
+			require.Equal(t, uint32(notification.PayloadVersion), params.Version)
+
+			expectedPayload, err := json.Marshal(tt.payload)
+			require.NoError(t, err)
+			require.JSONEq(t, string(expectedPayload), string(params.Payload), "payload holds the structured fields")
+		})
+	}
+}
+
+func Test_KindEventName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "calendar_notification", notification.KindMessage.EventName(),
+		"message notifications keep the original event name")
+	require.Equal(t, "calendar_notification", notification.Kind("").EventName(),
+		"notifications without a kind keep the original event name")
+	require.Equal(t, "invite_received", notification.KindInviteReceived.EventName(),
+		"other notifications are sent as an event named after their kind")
+}
@@ -0,0 +1,236 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+)
+
+// Channel is how a user is notified of a kind of notification.
+type Channel string
+
+const (
+	// ChannelInApp notifications are stored and sent live to the user's clients.
+	ChannelInApp Channel = "in_app"
+	// ChannelEmail notifications are stored and emailed to the user rather than sent live.
+	ChannelEmail Channel = "email"
+	// ChannelEmailDigest notifications are stored but not sent live, they are sent in the user's email digest.
+	ChannelEmailDigest Channel = "email_digest"
+	// ChannelNone notifications are muted, they are not stored for the user.
+	ChannelNone Channel = "none"
+
+	// DefaultChannel is used for kinds neither the user nor their groups have chosen a channel for.
+	DefaultChannel = ChannelInApp
+)
+
+// Valid returns whether c is one of the channels above.
+func (c Channel) Valid() bool {
+	switch c {
+	case ChannelInApp, ChannelEmail, ChannelEmailDigest, ChannelNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// permissiveness orders channels by how much of a notification reaches the user, it is used to pick one of
+// the defaults set by a user's groups.
+func (c Channel) permissiveness() int {
+	switch c {
+	case ChannelInApp:
+		return 3
+	case ChannelEmail:
+		return 2
+	case ChannelEmailDigest:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// QuietHours is a daily window in a user's time zone during which notifications are stored but not sent live or
+// emailed, emails wait for the next digest instead.
+// Start may be after End, in which case the window runs over midnight.
+type QuietHours struct {
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+// NewQuietHours parses DB quiet hours, their times are 'HH:MM' or 'HH:MM:SS' in the user's IANA time zone.
+func NewQuietHours(qh database.GetNotificationQuietHoursRow) (QuietHours, error) {
+	start, err := parseTimeOfDay(qh.StartTime)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	end, err := parseTimeOfDay(qh.EndTime)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if start == end {
+		return QuietHours{}, fmt.Errorf("quiet hours must not start and end at the same time '%s'", qh.StartTime)
+	}
+	if qh.TimeZone == "" || qh.TimeZone == "Local" {
+		return QuietHours{}, fmt.Errorf("invalid user time zone '%s'", qh.TimeZone)
+	}
+	loc, err := time.LoadLocation(qh.TimeZone)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid user time zone '%s'", qh.TimeZone)
+	}
+	return QuietHours{Start: start, End: end, Location: loc}, nil
+}
+
+// parseTimeOfDay parses an 'HH:MM' or 'HH:MM:SS' time into the duration since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	for _, layout := range []string{"15:04", time.TimeOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time of day '%s'", s)
+}
+
+// Contains returns whether t is during the quiet hours.
+func (qh QuietHours) Contains(t time.Time) bool {
+	local := t.In(qh.Location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+
+	if qh.Start < qh.End {
+		return sinceMidnight >= qh.Start && sinceMidnight < qh.End
+	}
+	// Over midnight
+	return sinceMidnight >= qh.Start || sinceMidnight < qh.End
+}
+
+// delivery is who a notification is stored for and who it is sent to, once users' preferences are applied.
+type delivery struct {
+	recipients   []database.NotificationRecipient
+	liveUserIDs  []uint32
+	emailUserIDs []uint32
+}
+
+// resolveDelivery applies users' preferences to a notification of kind about to be sent at now.
+func resolveDelivery(ctx context.Context, db database.NotificationDatabase, userIDs []uint32,
+	kind Kind, now time.Time,
+) (delivery, error) {
+	if kind == "" {
+		kind = KindMessage
+	}
+
+	var d delivery
+	for _, userID := range userIDs {
+		channel, err := resolveChannel(ctx, db, userID, kind)
+		if err != nil {
+			return delivery{}, err
+		}
+
+		if channel == ChannelNone {
+			continue
+		}
+
+		if channel == ChannelInApp || channel == ChannelEmail {
+			var quiet bool
+			if quiet, err = inQuietHours(ctx, db, userID, now); err != nil {
+				return delivery{}, err
+			}
+
+			switch {
+			case quiet && channel == ChannelEmail:
+				// Emailed in the next digest instead
+				channel = ChannelEmailDigest
+			case !quiet && channel == ChannelInApp:
+				d.liveUserIDs = append(d.liveUserIDs, userID)
+			case !quiet && channel == ChannelEmail:
+				d.emailUserIDs = append(d.emailUserIDs, userID)
+			}
+		}
+
+		d.recipients = append(d.recipients, database.NotificationRecipient{UserID: userID, Channel: string(channel)})
+	}
+	return d, nil
+}
+
+// recipientDelivery is who a notification already stored for recipients is sent to at now. Their channels were
+// resolved when it was stored, so only their quiet hours are checked again.
+func recipientDelivery(ctx context.Context, db database.NotificationDatabase,
+	recipients []database.NotificationRecipient, now time.Time,
+) (delivery, error) {
+	d := delivery{recipients: recipients}
+	for _, r := range recipients {
+		switch Channel(r.Channel) {
+		case ChannelInApp:
+			quiet, err := inQuietHours(ctx, db, r.UserID, now)
+			if err != nil {
+				return delivery{}, err
+			}
+			if !quiet {
+				d.liveUserIDs = append(d.liveUserIDs, r.UserID)
+			}
+		case ChannelEmail:
+			// Stored on the digest channel instead if it was sent during their quiet hours
+			d.emailUserIDs = append(d.emailUserIDs, r.UserID)
+		case ChannelEmailDigest, ChannelNone:
+		}
+	}
+	return d, nil
+}
+
+// resolveChannel returns the channel a user is notified of kind on. Their own choice is used first, then the
+// most permissive default of their groups, then DefaultChannel.
+func resolveChannel(ctx context.Context, db database.NotificationDatabase, userID uint32,
+	kind Kind,
+) (Channel, error) {
+	channel, err := db.GetNotificationPreferenceChannel(ctx, database.GetNotificationPreferenceChannelParams{
+		UserID: userID,
+		Kind:   string(kind),
+	})
+	if err == nil {
+		return Channel(channel), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("failed to get user's notification preference: %w", err)
+	}
+
+	groupChannels, err := db.ListMemberSlotifyGroupNotificationChannels(ctx,
+		database.ListMemberSlotifyGroupNotificationChannelsParams{
+			UserID: userID,
+			Kind:   string(kind),
+		})
+	if err != nil {
+		return "", fmt.Errorf("failed to get user's slotify group notification preferences: %w", err)
+	}
+	if len(groupChannels) == 0 {
+		return DefaultChannel, nil
+	}
+
+	resolved := ChannelNone
+	for _, c := range groupChannels {
+		if Channel(c).permissiveness() > resolved.permissiveness() {
+			resolved = Channel(c)
+		}
+	}
+	return resolved, nil
+}
+
+// inQuietHours returns whether now is during a user's quiet hours.
+func inQuietHours(ctx context.Context, db database.NotificationDatabase, userID uint32,
+	now time.Time,
+) (bool, error) {
+	dbQuietHours, err := db.GetNotificationQuietHours(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get user's quiet hours: %w", err)
+	}
+
+	qh, err := NewQuietHours(dbQuietHours)
+	if err != nil {
+		return false, err
+	}
+	return qh.Contains(now), nil
+}
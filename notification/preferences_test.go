@@ -0,0 +1,226 @@
+package notification_test
+
+import (
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/mocks"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_QuietHoursContains(t *testing.T) {
+	t.Parallel()
+
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		quietHours database.GetNotificationQuietHoursRow
+		t          time.Time
+		expected   bool
+	}{
+		"during quiet hours": {
+			quietHours: database.GetNotificationQuietHoursRow{StartTime: "12:00", EndTime: "14:00", TimeZone: "UTC"},
+			t:          time.Date(2025, time.March, 4, 13, 0, 0, 0, time.UTC),
+			expected:   true,
+		},
+		"end is not quiet": {
+			quietHours: database.GetNotificationQuietHoursRow{StartTime: "12:00", EndTime: "14:00", TimeZone: "UTC"},
+			t:          time.Date(2025, time.March, 4, 14, 0, 0, 0, time.UTC),
+			expected:   false,
+		},
+		"over midnight before midnight": {
+			quietHours: database.GetNotificationQuietHoursRow{StartTime: "22:00:00", EndTime: "07:00:00", TimeZone: "UTC"},
+			t:          time.Date(2025, time.March, 4, 23, 30, 0, 0, time.UTC),
+			expected:   true,
+		},
+		"over midnight after midnight": {
+			quietHours: database.GetNotificationQuietHoursRow{StartTime: "22:00:00", EndTime: "07:00:00", TimeZone: "UTC"},
+			t:          time.Date(2025, time.March, 4, 6, 59, 0, 0, time.UTC),
+			expected:   true,
+		},
+		"over midnight during the day": {
+			quietHours: database.GetNotificationQuietHoursRow{StartTime: "22:00:00", EndTime: "07:00:00", TimeZone: "UTC"},
+			t:          time.Date(2025, time.March, 4, 12, 0, 0, 0, time.UTC),
+			expected:   false,
+		},
+		"in the user's time zone": {
+			// 21:30 UTC is 22:30 in London during summer time
+			quietHours: database.GetNotificationQuietHoursRow{
+				StartTime: "22:00", EndTime: "07:00", TimeZone: "Europe/London",
+			},
+			t:        time.Date(2025, time.July, 1, 21, 30, 0, 0, time.UTC),
+			expected: true,
+		},
+		"time zone of t is ignored": {
+			quietHours: database.GetNotificationQuietHoursRow{StartTime: "22:00", EndTime: "07:00", TimeZone: "UTC"},
+			t:          time.Date(2025, time.July, 1, 22, 30, 0, 0, london),
+			expected:   false,
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			qh, err := notification.NewQuietHours(tt.quietHours)
+			require.NoError(t, err, "parsing quiet hours should not return error")
+			require.Equal(t, tt.expected, qh.Contains(tt.t))
+		})
+	}
+}
+
+func Test_NewQuietHoursInvalid(t *testing.T) {
+	t.Parallel()
+
+	for testName, qh := range map[string]database.GetNotificationQuietHoursRow{
+		"invalid start":     {StartTime: "25:00", EndTime: "07:00", TimeZone: "UTC"},
+		"invalid end":       {StartTime: "22:00", EndTime: "seven", TimeZone: "UTC"},
+		"empty window":      {StartTime: "22:00", EndTime: "22:00:00", TimeZone: "UTC"},
+		"invalid time zone": {StartTime: "22:00", EndTime: "07:00", TimeZone: "Mars/Olympus_Mons"},
+		"local time zone":   {StartTime: "22:00", EndTime: "07:00", TimeZone: "Local"},
+		"missing time zone": {StartTime: "22:00", EndTime: "07:00"},
+		"missing start":     {EndTime: "07:00", TimeZone: "UTC"},
+		"invalid minute":    {StartTime: "22:60", EndTime: "07:00", TimeZone: "UTC"},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := notification.NewQuietHours(qh)
+			require.Error(t, err, "invalid quiet hours should return error")
+		})
+	}
+}
+
+func Test_SSESendNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	var notifID int64 = 3
+	notifParams, err := notification.NewNotificationParams(notification.GroupJoined{
+		SlotifyGroupID: 2,
+		GroupName:      "Team",
+	}, time.Now())
+	require.NoError(t, err, "creating notification should not return error")
+
+	// Quiet for the hours around when the test runs
+	now := time.Now().UTC()
+	quietNow := database.GetNotificationQuietHoursRow{
+		StartTime: now.Add(-time.Hour).Format("15:04"),
+		EndTime:   now.Add(time.Hour).Format("15:04"),
+		TimeZone:  "UTC",
+	}
+
+	tests := map[string]struct {
+		userChannel   *notification.Channel
+		groupChannels []string
+		quietHours    *database.GetNotificationQuietHoursRow
+		// expectedChannel is the channel the notification is stored with, empty if it isn't stored
+		expectedChannel notification.Channel
+		expectedLive    bool
+	}{
+		"defaults to in-app": {
+			expectedChannel: notification.ChannelInApp,
+			expectedLive:    true,
+		},
+		"muted by user": {
+			userChannel: ptr(notification.ChannelNone),
+		},
+		"email digest chosen by user": {
+			userChannel:     ptr(notification.ChannelEmailDigest),
+			expectedChannel: notification.ChannelEmailDigest,
+		},
+		"user's choice overrides groups": {
+			userChannel:     ptr(notification.ChannelInApp),
+			groupChannels:   []string{string(notification.ChannelNone)},
+			expectedChannel: notification.ChannelInApp,
+			expectedLive:    true,
+		},
+		"muted by group": {
+			groupChannels: []string{string(notification.ChannelNone)},
+		},
+		"most permissive group default is used": {
+			groupChannels:   []string{string(notification.ChannelNone), string(notification.ChannelEmailDigest)},
+			expectedChannel: notification.ChannelEmailDigest,
+		},
+		"stored but not sent during quiet hours": {
+			quietHours:      &quietNow,
+			expectedChannel: notification.ChannelInApp,
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			var userID uint32 = 1
+
+			ctrl := gomock.NewController(t)
+			mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+
+			prefParams := database.GetNotificationPreferenceChannelParams{UserID: userID, Kind: notifParams.Kind}
+			if tt.userChannel != nil {
+				mockNotificationDB.EXPECT().GetNotificationPreferenceChannel(gomock.Any(), gomock.Eq(prefParams)).
+					Return(string(*tt.userChannel), nil).Times(1)
+			} else {
+				mockNotificationDB.EXPECT().GetNotificationPreferenceChannel(gomock.Any(), gomock.Eq(prefParams)).
+					Return("", sql.ErrNoRows).Times(1)
+				mockNotificationDB.EXPECT().ListMemberSlotifyGroupNotificationChannels(gomock.Any(),
+					gomock.Eq(database.ListMemberSlotifyGroupNotificationChannelsParams{
+						UserID: userID,
+						Kind:   notifParams.Kind,
+					})).Return(append([]string{}, tt.groupChannels...), nil).Times(1)
+			}
+
+			quietHours := database.GetNotificationQuietHoursRow{}
+			quietHoursErr := sql.ErrNoRows
+			if tt.quietHours != nil {
+				quietHours, quietHoursErr = *tt.quietHours, nil
+			}
+			mockNotificationDB.EXPECT().GetNotificationQuietHours(gomock.Any(), gomock.Eq(userID)).
+				Return(quietHours, quietHoursErr).AnyTimes()
+
+			stored := 0
+			if tt.expectedChannel != "" {
+				stored = 1
+			}
+			mockNotificationDB.EXPECT().CreateNotification(gomock.Any(), gomock.Eq(notifParams)).
+				Return(notifID, nil).Times(stored)
+			mockNotificationDB.EXPECT().CreateUserNotification(gomock.Any(),
+				gomock.Eq(database.CreateUserNotificationParams{
+					UserID:         userID,
+					NotificationID: uint32(notifID),
+					Channel:        string(tt.expectedChannel),
+				})).Return(int64(1), nil).Times(stored)
+
+			sseNotificationService := notification.NewSSENotificationService()
+			client := httptest.NewRecorder()
+			require.NoError(t, sseNotificationService.RegisterUserClient(l, userID, client),
+				"registering client should not return error")
+
+			err := sseNotificationService.
+				SendNotification(t.Context(), l, mockNotificationDB, []uint32{userID}, notifParams)
+			require.NoError(t, err, "send notification should execute successfully and produce no error")
+
+			sseNotificationService.DeleteUserConn(l, userID, client)
+
+			if tt.expectedLive {
+				require.Equal(t, testutil.GetExpectedSSERetry()+testutil.GetExpectedNotificationSSE(notifID, notifParams),
+					client.Body.String(), "notification is sent live")
+			} else {
+				require.Equal(t, testutil.GetExpectedSSERetry(), client.Body.String(), "notification is not sent live")
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,123 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketMessageType is the type of a message sent over a WebSocket client.
+type WebSocketMessageType string
+
+const (
+	// WebSocketMessageNotification is sent to the client with a notification.
+	WebSocketMessageNotification WebSocketMessageType = "notification"
+	// WebSocketMessageAck is sent by the client once it has shown a notification, which marks it as read.
+	WebSocketMessageAck WebSocketMessageType = "ack"
+	// WebSocketMessagePing is sent by the client to check the connection, browsers can't send ping frames.
+	WebSocketMessagePing WebSocketMessageType = "ping"
+	// WebSocketMessagePong is sent to the client in answer to a ping message.
+	WebSocketMessagePong WebSocketMessageType = "pong"
+)
+
+// WebSocketPongWait is how long a client has to answer a ping frame, or send any message, before it is
+// considered gone.
+const WebSocketPongWait = 2 * HeartbeatInterval
+
+// WebSocketMessage is a message sent over a WebSocket client in either direction.
+type WebSocketMessage struct {
+	Type WebSocketMessageType `json:"type"`
+	// ID is the id of the notification sent or acked.
+	ID uint32 `json:"id,omitempty"`
+	// Event is the name the notification's event has over SSE.
+	Event string `json:"event,omitempty"`
+	// Data is the notification, as sent over SSE.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// eventWriter is implemented by clients that aren't SSE streams, notifications and heartbeats are written with it
+// instead of as SSE events.
+type eventWriter interface {
+	WriteEvent(notif database.Notification, notifJSON []byte) error
+	WriteHeartbeat() error
+}
+
+// WebSocketClient is a WebSocket connection that can be registered with a Service in place of an SSE stream.
+// Notifications are sent as WebSocketMessages and heartbeats as ping frames.
+type WebSocketClient struct {
+	conn   *websocket.Conn
+	header http.Header
+
+	// mu serialises writes, a connection supports one writer at a time.
+	mu sync.Mutex
+}
+
+// ensure that WebSocketClient can be registered as a client with a compile-time check.
+var (
+	_ http.ResponseWriter = (*WebSocketClient)(nil)
+	_ eventWriter         = (*WebSocketClient)(nil)
+)
+
+// NewWebSocketClient creates a WebSocketClient for an upgraded connection.
+func NewWebSocketClient(conn *websocket.Conn) *WebSocketClient {
+	return &WebSocketClient{conn: conn, header: make(http.Header)}
+}
+
+// Header is unused, the connection's headers were sent when it was upgraded.
+func (c *WebSocketClient) Header() http.Header {
+	return c.header
+}
+
+// WriteHeader is a no-op, the connection's status was sent when it was upgraded.
+func (c *WebSocketClient) WriteHeader(int) {}
+
+// Write sends p as a text message.
+func (c *WebSocketClient) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(clientWriteWait)); err != nil {
+		return 0, err
+	}
+	if err := c.conn.WriteMessage(websocket.TextMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteMessage sends a message to the client.
+func (c *WebSocketClient) WriteMessage(msg WebSocketMessage) error {
+	msgJSON, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode websocket message as json: %w", err)
+	}
+
+	if _, err = c.Write(msgJSON); err != nil {
+		return fmt.Errorf("failed to write websocket message: %w", err)
+	}
+	return nil
+}
+
+// WriteEvent sends an encoded notification to the client.
+func (c *WebSocketClient) WriteEvent(notif database.Notification, notifJSON []byte) error {
+	return c.WriteMessage(WebSocketMessage{
+		Type:  WebSocketMessageNotification,
+		ID:    notif.ID,
+		Event: Kind(notif.Kind).EventName(),
+		Data:  notifJSON,
+	})
+}
+
+// WriteHeartbeat sends a ping frame, the client has WebSocketPongWait to answer it.
+func (c *WebSocketClient) WriteHeartbeat() error {
+	// Control frames may be written alongside other writes
+	if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(clientWriteWait)); err != nil {
+		return fmt.Errorf("failed to write ping: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package notification_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/mocks"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"github.com/SlotifyApp/slotify-backend/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_SSEWebSocketClient(t *testing.T) {
+	t.Parallel()
+
+	l := testutil.NewLogger(t)
+
+	var userID uint32 = 1
+	sseNotificationService := notification.NewSSENotificationService()
+
+	registered := make(chan *notification.WebSocketClient, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		client := notification.NewWebSocketClient(conn)
+		if err = sseNotificationService.RegisterUserClient(l, userID, client); err != nil {
+			return
+		}
+		defer sseNotificationService.DeleteUserConn(l, userID, client)
+		registered <- client
+
+		// Held open until the client disconnects
+		for {
+			if _, _, err = conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(t.Context(),
+		"ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err, "dialing websocket should not return error")
+	defer resp.Body.Close()
+	defer conn.Close()
+
+	client := <-registered
+
+	ctrl := gomock.NewController(t)
+	mockNotificationDB := mocks.NewMockNotificationDatabase(ctrl)
+	mockNotificationDB.EXPECT().GetNotificationQuietHours(gomock.Any(), gomock.Eq(userID)).
+		Return(database.GetNotificationQuietHoursRow{}, sql.ErrNoRows).Times(1)
+
+	notif := storedNotification(t, 7, notification.GroupJoined{SlotifyGroupID: 2, GroupName: "Team"})
+	err = sseNotificationService.DeliverNotification(t.Context(), l, mockNotificationDB,
+		[]database.NotificationRecipient{{UserID: userID, Channel: string(notification.ChannelInApp)}}, notif)
+	require.NoError(t, err, "delivering notification should not return error")
+
+	var msg notification.WebSocketMessage
+	require.NoError(t, conn.ReadJSON(&msg), "client should receive a message")
+	require.Equal(t, notification.WebSocketMessageNotification, msg.Type)
+	require.Equal(t, notif.ID, msg.ID)
+	require.Equal(t, "group_joined", msg.Event, "event is named as over SSE")
+
+	var got database.Notification
+	require.NoError(t, json.Unmarshal(msg.Data, &got))
+	require.Equal(t, notif, got, "notification is the message's data")
+
+	// Heartbeats are ping frames, which are handled while reading
+	pinged := make(chan struct{}, 1)
+	conn.SetPingHandler(func(string) error {
+		pinged <- struct{}{}
+		return nil
+	})
+	go func() {
+		_, _, _ = conn.ReadMessage()
+	}()
+
+	require.NoError(t, sseNotificationService.SendHeartbeat(client), "sending heartbeat should not return error")
+
+	select {
+	case <-pinged:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "client should be pinged")
+	}
+}
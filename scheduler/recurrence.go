@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultOccurrences is the number of occurrences checked if Recurrence.Count is not set.
+	DefaultOccurrences = 10
+	// MaxOccurrences is the most occurrences of a series FindSlots checks, a year of weekly meetings.
+	MaxOccurrences = 52
+
+	// maxOccurrenceSearch stops a monthly series on the 31st from looking for months that have one forever.
+	maxOccurrenceSearch = 1000
+)
+
+var (
+	ErrInvalidFrequency   = errors.New("recurrence frequency must be daily, weekly or monthly")
+	ErrInvalidInterval    = errors.New("recurrence interval must not be negative")
+	ErrInvalidOccurrences = fmt.Errorf("recurrence count must be between 0 and %d", MaxOccurrences)
+)
+
+// Frequency is the unit a series repeats in.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+)
+
+// Recurrence is how a meeting repeats. Occurrences are at the same wall clock time as the first in
+// the organiser's time zone, so a 9am standup stays at 9am across a DST change.
+type Recurrence struct {
+	Frequency Frequency
+	// Interval is the number of days, weeks or months between occurrences, 1 if zero.
+	Interval int
+	// Weekdays are the days a weekly series is on, the day of the first occurrence if empty.
+	Weekdays []time.Weekday
+	// Count is the number of occurrences that must be free, including the first, DefaultOccurrences if zero.
+	Count int
+	// Until is the last time an occurrence can start, no limit if zero.
+	Until time.Time
+}
+
+// validate checks the recurrence and fills in defaults.
+func (r Recurrence) validate() (Recurrence, error) {
+	if r.Frequency != FrequencyDaily && r.Frequency != FrequencyWeekly && r.Frequency != FrequencyMonthly {
+		return Recurrence{}, ErrInvalidFrequency
+	}
+	if r.Interval < 0 {
+		return Recurrence{}, ErrInvalidInterval
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	if r.Count < 0 || r.Count > MaxOccurrences {
+		return Recurrence{}, ErrInvalidOccurrences
+	}
+	if r.Count == 0 {
+		r.Count = DefaultOccurrences
+	}
+	return r, nil
+}
+
+// Occurrences returns the start times of the first Count occurrences of a series starting at first,
+// in loc (UTC if nil). The first occurrence is always first, even if it isn't on one of Weekdays.
+func (r Recurrence) Occurrences(first time.Time, loc *time.Location) ([]time.Time, error) {
+	r, err := r.validate()
+	if err != nil {
+		return nil, err
+	}
+	return r.occurrences(first, loc), nil
+}
+
+// occurrences is Occurrences for a validated recurrence.
+func (r Recurrence) occurrences(first time.Time, loc *time.Location) []time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	first = first.In(loc)
+
+	occurrences := []time.Time{first}
+	for i := 0; len(occurrences) < r.Count && i < maxOccurrenceSearch; i++ {
+		for _, occurrence := range r.period(first, i) {
+			if !occurrence.After(first) {
+				continue
+			}
+			if !r.Until.IsZero() && occurrence.After(r.Until) {
+				return occurrences
+			}
+			occurrences = append(occurrences, occurrence)
+			if len(occurrences) == r.Count {
+				break
+			}
+		}
+	}
+	return occurrences
+}
+
+// period returns the occurrences in the i'th day, week or month of the series in order, the 0th being
+// the one first is in. The wall clock of first is used rather than adding durations so days with a DST
+// change are correct.
+func (r Recurrence) period(first time.Time, i int) []time.Time {
+	year, month, day := first.Date()
+	hour, minute, sec := first.Clock()
+	on := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, sec, first.Nanosecond(), first.Location())
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		return []time.Time{on(year, month, day+i*r.Interval)}
+	case FrequencyWeekly:
+		weekdays := r.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{first.Weekday()}
+		}
+		weekdays = slices.Clone(weekdays)
+		slices.Sort(weekdays)
+		weekdays = slices.Compact(weekdays)
+
+		// Weeks start on Sunday, matching MSFT's default firstDayOfWeek
+		weekStart := day - int(first.Weekday()) + i*r.Interval*7
+		occurrences := make([]time.Time, 0, len(weekdays))
+		for _, weekday := range weekdays {
+			occurrences = append(occurrences, on(year, month, weekStart+int(weekday)))
+		}
+		return occurrences
+	case FrequencyMonthly:
+		occurrence := on(year, month+time.Month(i*r.Interval), day)
+		// Months without the day are skipped, eg. a series on the 31st skips April
+		if occurrence.Day() != day {
+			return nil
+		}
+		return []time.Time{occurrence}
+	}
+	return nil
+}
+
+// seriesAvailability works out whether p can attend every occurrence of a meeting starting at the
+// occurrences. The first occurrence p can't make decides the result, otherwise being over the meeting
+// limit for any occurrence beats being unknown.
+func (p Participant) seriesAvailability(
+	occurrences []time.Time, duration, bufferBefore, bufferAfter time.Duration,
+) Availability {
+	result := AvailabilityFree
+	for _, start := range occurrences {
+		switch availability := p.availability(start, start.Add(duration), bufferBefore, bufferAfter); availability {
+		case AvailabilityBusy, AvailabilityOutsideWorkingHours:
+			return availability
+		case AvailabilityOverMeetingLimit:
+			result = AvailabilityOverMeetingLimit
+		case AvailabilityUnknown:
+			if result == AvailabilityFree {
+				result = AvailabilityUnknown
+			}
+		case AvailabilityFree:
+		}
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurrence_Occurrences(t *testing.T) {
+	t.Parallel()
+
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err, "failed to load location")
+
+	// The clocks in London go forward on 2030-03-31
+	beforeDST := time.Date(2030, time.March, 29, 9, 0, 0, 0, london)
+
+	tests := map[string]struct {
+		recurrence scheduler.Recurrence
+		first      time.Time
+		loc        *time.Location
+		expected   []time.Time
+		testMsg    string
+	}{
+		"daily": {
+			recurrence: scheduler.Recurrence{Frequency: scheduler.FrequencyDaily, Count: 3},
+			first:      at(monday, 9, 0),
+			expected:   []time.Time{at(monday, 9, 0), at(monday.AddDate(0, 0, 1), 9, 0), at(monday.AddDate(0, 0, 2), 9, 0)},
+			testMsg:    "a daily series is on consecutive days",
+		},
+		"every other day": {
+			recurrence: scheduler.Recurrence{Frequency: scheduler.FrequencyDaily, Interval: 2, Count: 2},
+			first:      at(monday, 9, 0),
+			expected:   []time.Time{at(monday, 9, 0), at(monday.AddDate(0, 0, 2), 9, 0)},
+			testMsg:    "the interval is the number of days between occurrences",
+		},
+		"weekly on the first day": {
+			recurrence: scheduler.Recurrence{Frequency: scheduler.FrequencyWeekly, Count: 2},
+			first:      at(monday, 9, 0),
+			expected:   []time.Time{at(monday, 9, 0), at(monday.AddDate(0, 0, 7), 9, 0)},
+			testMsg:    "a weekly series without weekdays is on the day of the first occurrence",
+		},
+		"weekly on several days": {
+			recurrence: scheduler.Recurrence{
+				Frequency: scheduler.FrequencyWeekly,
+				Weekdays:  []time.Weekday{time.Friday, time.Monday, time.Wednesday},
+				Count:     5,
+			},
+			first: at(monday.AddDate(0, 0, 2), 9, 0),
+			expected: []time.Time{
+				at(monday.AddDate(0, 0, 2), 9, 0),
+				at(monday.AddDate(0, 0, 4), 9, 0),
+				at(monday.AddDate(0, 0, 7), 9, 0),
+				at(monday.AddDate(0, 0, 9), 9, 0),
+				at(monday.AddDate(0, 0, 11), 9, 0),
+			},
+			testMsg: "weekdays before the first occurrence start the next week",
+		},
+		"fortnightly": {
+			recurrence: scheduler.Recurrence{
+				Frequency: scheduler.FrequencyWeekly,
+				Interval:  2,
+				Weekdays:  []time.Weekday{time.Monday, time.Tuesday},
+				Count:     4,
+			},
+			first: at(monday, 9, 0),
+			expected: []time.Time{
+				at(monday, 9, 0),
+				at(monday.AddDate(0, 0, 1), 9, 0),
+				at(monday.AddDate(0, 0, 14), 9, 0),
+				at(monday.AddDate(0, 0, 15), 9, 0),
+			},
+			testMsg: "weeks in between are skipped",
+		},
+		"monthly on the 31st": {
+			recurrence: scheduler.Recurrence{Frequency: scheduler.FrequencyMonthly, Count: 3},
+			first:      time.Date(2030, time.January, 31, 9, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2030, time.January, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2030, time.March, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2030, time.May, 31, 9, 0, 0, 0, time.UTC),
+			},
+			testMsg: "months without the day are skipped",
+		},
+		"until": {
+			recurrence: scheduler.Recurrence{
+				Frequency: scheduler.FrequencyDaily,
+				Count:     10,
+				Until:     at(monday.AddDate(0, 0, 1), 23, 59),
+			},
+			first:    at(monday, 9, 0),
+			expected: []time.Time{at(monday, 9, 0), at(monday.AddDate(0, 0, 1), 9, 0)},
+			testMsg:  "the series ends at until even if count isn't reached",
+		},
+		"dst change": {
+			recurrence: scheduler.Recurrence{Frequency: scheduler.FrequencyDaily, Count: 3},
+			first:      beforeDST.UTC(),
+			loc:        london,
+			expected: []time.Time{
+				time.Date(2030, time.March, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2030, time.March, 30, 9, 0, 0, 0, time.UTC),
+				time.Date(2030, time.March, 31, 8, 0, 0, 0, time.UTC),
+			},
+			testMsg: "occurrences stay at the same wall clock time in the organiser's time zone",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			occurrences, err := tt.recurrence.Occurrences(tt.first, tt.loc)
+			require.NoError(t, err)
+
+			utc := make([]time.Time, 0, len(occurrences))
+			for _, o := range occurrences {
+				utc = append(utc, o.UTC())
+			}
+			require.Equal(t, tt.expected, utc, tt.testMsg)
+		})
+	}
+}
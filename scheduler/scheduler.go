@@ -0,0 +1,442 @@
+// Package scheduler finds meeting slots from the free/busy of the participants.
+//
+// It has no dependencies on a calendar provider, callers gather everyone's busy intervals,
+// working hours and time zones and FindSlots ranks the times everyone can make.
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultStep is the gap between candidate start times if Request.Step is not set.
+	DefaultStep = 30 * time.Minute
+	// DefaultMinAttendeePercentage matches the MSFT findMeetingTimes default.
+	DefaultMinAttendeePercentage = 50.0
+	// DefaultMaxCandidates is the number of slots returned if Request.MaxCandidates is not set.
+	DefaultMaxCandidates = 10
+
+	// MaxSearchSpan is the longest time from the start of the first window to the end of the last.
+	MaxSearchSpan = 31 * 24 * time.Hour
+
+	// minStep stops callers from generating a huge number of candidates.
+	minStep = 5 * time.Minute
+	// maxOccurrenceChecks is the most candidate start times, counting every occurrence of a series,
+	// a request can check.
+	maxOccurrenceChecks = 50000
+)
+
+var (
+	ErrInvalidDuration = errors.New("meeting duration must be positive")
+	ErrInvalidStep     = fmt.Errorf("step must be at least %s", minStep)
+	ErrInvalidBuffer   = errors.New("buffers must not be negative")
+	ErrNoWindows       = errors.New("at least one search window is required")
+	ErrInvalidWindow   = errors.New("search window must end after it starts")
+	ErrSearchTooLong   = fmt.Errorf("search windows must be within %s of each other", MaxSearchSpan)
+	ErrTooManyChecks   = errors.New("too many candidate slots, use fewer or shorter windows, a longer step or " +
+		"fewer occurrences")
+)
+
+// Availability is how available a participant is for a slot.
+type Availability string
+
+const (
+	AvailabilityFree                Availability = "free"
+	AvailabilityBusy                Availability = "busy"
+	AvailabilityOutsideWorkingHours Availability = "outsideWorkingHours"
+	AvailabilityUnknown             Availability = "unknown"
+	// AvailabilityOverMeetingLimit is a participant who is free but already has their maximum meetings that day.
+	AvailabilityOverMeetingLimit Availability = "overMeetingLimit"
+)
+
+// EmptyReason explains why no slots were found.
+type EmptyReason string
+
+const (
+	EmptyReasonNone                          EmptyReason = ""
+	EmptyReasonOrganiserUnavailable          EmptyReason = "organiserUnavailable"
+	EmptyReasonAttendeesUnavailable          EmptyReason = "attendeesUnavailable"
+	EmptyReasonAttendeesUnavailableOrUnknown EmptyReason = "attendeesUnavailableOrUnknown"
+	// EmptyReasonNoCandidates is returned when the windows are too short to fit the meeting.
+	EmptyReasonNoCandidates EmptyReason = "noCandidates"
+)
+
+// Interval is a period of time, Start is inclusive and End exclusive.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// overlaps checks if the interval overlaps [start, end).
+func (i Interval) overlaps(start, end time.Time) bool {
+	return i.Start.Before(end) && i.End.After(start)
+}
+
+// TimeOfDay is a wall clock time, stored as the duration since midnight.
+type TimeOfDay time.Duration
+
+// NewTimeOfDay creates a TimeOfDay from an hour and minute.
+func NewTimeOfDay(hour, minute int) TimeOfDay {
+	return TimeOfDay(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+// on returns the time of day on the date of day, in day's location.
+// The wall clock is used rather than adding to midnight so days with a DST change are correct.
+func (t TimeOfDay) on(day time.Time) time.Time {
+	year, month, date := day.Date()
+	d := time.Duration(t)
+	return time.Date(year, month, date, int(d/time.Hour), int(d%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+// DailyWindow is a period of every day, eg. a lunch break.
+type DailyWindow struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// WorkingHours are the hours a participant can be booked in, in their own time zone.
+type WorkingHours struct {
+	Start TimeOfDay
+	End   TimeOfDay
+	// Days are the days worked, Monday to Friday if empty.
+	Days []time.Weekday
+}
+
+// worksOn checks if weekday is a working day.
+func (w WorkingHours) worksOn(weekday time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return weekday != time.Saturday && weekday != time.Sunday
+	}
+	return slices.Contains(w.Days, weekday)
+}
+
+// Participant is an organiser or attendee of a meeting.
+type Participant struct {
+	// ID identifies the participant in the result, eg. their email.
+	ID       string
+	Required bool
+	// Unknown is set when the participant's calendar could not be read, their working hours and breaks still apply.
+	Unknown bool
+	Busy    []Interval
+	// Location is the participant's time zone, UTC if nil.
+	Location *time.Location
+	// WorkingHours restricts when the participant can be booked, any time if nil.
+	WorkingHours *WorkingHours
+	// Breaks are daily periods the participant can't be booked in, eg. lunch.
+	Breaks []DailyWindow
+	// MaxMeetingsPerDay down-ranks slots on days the participant already has this many meetings, no limit if zero.
+	MaxMeetingsPerDay int
+}
+
+// Request is the input to FindSlots.
+type Request struct {
+	// Windows are the periods the meeting can be scheduled in.
+	Windows  []Interval
+	Duration time.Duration
+	// BufferBefore and BufferAfter must also be free of busy intervals around the meeting.
+	BufferBefore time.Duration
+	BufferAfter  time.Duration
+	// Step is the gap between candidate start times, DefaultStep if zero.
+	Step              time.Duration
+	Organiser         Participant
+	OrganiserOptional bool
+	Attendees         []Participant
+	// MinAttendeePercentage is the percentage of attendees that must be free, DefaultMinAttendeePercentage if nil.
+	MinAttendeePercentage *float64
+	// MaxCandidates is the maximum number of slots returned, DefaultMaxCandidates if zero.
+	MaxCandidates int
+	// Recurrence makes the meeting a series, slots are only suggested if every occurrence is free.
+	// Occurrences follow the organiser's time zone.
+	Recurrence *Recurrence
+}
+
+// ParticipantAvailability is the availability of a participant for a slot.
+type ParticipantAvailability struct {
+	ID           string
+	Availability Availability
+}
+
+// Slot is a suggested meeting time.
+type Slot struct {
+	Start time.Time
+	End   time.Time
+	// Score is the percentage of attendees who are free, slots are ranked by it.
+	Score                 float64
+	OrganiserAvailability Availability
+	Attendees             []ParticipantAvailability
+}
+
+// Result is the output of FindSlots.
+type Result struct {
+	// Slots are ranked best first.
+	Slots []Slot
+	// EmptyReason is set when no slots were found.
+	EmptyReason EmptyReason
+}
+
+// validate checks the request and fills in defaults.
+func (r Request) validate() (Request, error) {
+	if r.Duration <= 0 {
+		return Request{}, ErrInvalidDuration
+	}
+	if r.BufferBefore < 0 || r.BufferAfter < 0 {
+		return Request{}, ErrInvalidBuffer
+	}
+	if r.Step == 0 {
+		r.Step = DefaultStep
+	}
+	if r.Step < minStep {
+		return Request{}, ErrInvalidStep
+	}
+	if len(r.Windows) == 0 {
+		return Request{}, ErrNoWindows
+	}
+	first, last := r.Windows[0].Start, r.Windows[0].End
+	for _, w := range r.Windows {
+		if !w.End.After(w.Start) {
+			return Request{}, ErrInvalidWindow
+		}
+		first, last = minTime(first, w.Start), maxTime(last, w.End)
+	}
+	if last.Sub(first) > MaxSearchSpan {
+		return Request{}, ErrSearchTooLong
+	}
+	if r.MinAttendeePercentage == nil {
+		minPercentage := DefaultMinAttendeePercentage
+		r.MinAttendeePercentage = &minPercentage
+	}
+	if r.MaxCandidates <= 0 {
+		r.MaxCandidates = DefaultMaxCandidates
+	}
+	if r.Recurrence != nil {
+		recurrence, err := r.Recurrence.validate()
+		if err != nil {
+			return Request{}, err
+		}
+		r.Recurrence = &recurrence
+	}
+	if r.occurrenceChecks() > maxOccurrenceChecks {
+		return Request{}, ErrTooManyChecks
+	}
+	return r, nil
+}
+
+// occurrenceChecks is the number of candidate start times in the windows, times the number of
+// occurrences checked for each.
+func (r Request) occurrenceChecks() int {
+	candidates := 0
+	for _, w := range r.Windows {
+		if span := w.End.Sub(w.Start); span >= r.Duration {
+			candidates += int((span-r.Duration)/r.Step) + 1
+		}
+	}
+	if r.Recurrence != nil {
+		return candidates * r.Recurrence.Count
+	}
+	return candidates
+}
+
+// minTime returns the earlier of a and b.
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// maxTime returns the later of a and b.
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// availability works out whether p can attend a meeting between start and end.
+func (p Participant) availability(start, end time.Time, bufferBefore, bufferAfter time.Duration) Availability {
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	localStart := start.In(loc)
+	localEnd := end.In(loc)
+
+	if p.WorkingHours != nil {
+		dayStart := p.WorkingHours.Start.on(localStart)
+		dayEnd := p.WorkingHours.End.on(localStart)
+		if !p.WorkingHours.worksOn(localStart.Weekday()) ||
+			localStart.Before(dayStart) || localEnd.After(dayEnd) {
+			return AvailabilityOutsideWorkingHours
+		}
+	}
+
+	// Check the breaks on each local day the meeting touches
+	for _, b := range p.Breaks {
+		for _, day := range []time.Time{localStart, localEnd} {
+			br := Interval{Start: b.Start.on(day), End: b.End.on(day)}
+			if br.overlaps(localStart, localEnd) {
+				return AvailabilityBusy
+			}
+		}
+	}
+
+	if p.Unknown {
+		return AvailabilityUnknown
+	}
+
+	bufferedStart := start.Add(-bufferBefore)
+	bufferedEnd := end.Add(bufferAfter)
+	for _, b := range p.Busy {
+		if b.overlaps(bufferedStart, bufferedEnd) {
+			return AvailabilityBusy
+		}
+	}
+
+	if p.MaxMeetingsPerDay > 0 && p.meetingsOn(localStart) >= p.MaxMeetingsPerDay {
+		return AvailabilityOverMeetingLimit
+	}
+
+	return AvailabilityFree
+}
+
+// availability works out whether p can attend the meeting at the occurrences, which is just the one
+// slot unless the meeting repeats.
+func (r Request) availability(p Participant, occurrences []time.Time) Availability {
+	if len(occurrences) == 1 {
+		return p.availability(occurrences[0], occurrences[0].Add(r.Duration), r.BufferBefore, r.BufferAfter)
+	}
+	return p.seriesAvailability(occurrences, r.Duration, r.BufferBefore, r.BufferAfter)
+}
+
+// occurrences returns the start of every occurrence of the meeting if it starts at start.
+func (r Request) occurrences(start time.Time) []time.Time {
+	if r.Recurrence == nil {
+		return []time.Time{start}
+	}
+	return r.Recurrence.occurrences(start, r.Organiser.Location)
+}
+
+// meetingsOn counts the busy intervals starting on the date of day, in day's location.
+func (p Participant) meetingsOn(day time.Time) int {
+	year, month, date := day.Date()
+	count := 0
+	for _, b := range p.Busy {
+		y, m, d := b.Start.In(day.Location()).Date()
+		if y == year && m == month && d == date {
+			count++
+		}
+	}
+	return count
+}
+
+// FindSlots returns the meeting slots the participants can make, ranked by the percentage of
+// attendees who are free and then by start time.
+// The organiser (unless optional) and required attendees must be free. Unknown participants are
+// assumed to be free when checking MinAttendeePercentage, but only attendees known to be free count
+// towards Score. Participants over their meeting limit don't exclude a slot but don't count as free.
+// If the meeting repeats a participant's availability for a slot is the worst of their availability
+// for each occurrence.
+func FindSlots(req Request) (Result, error) {
+	req, err := req.validate()
+	if err != nil {
+		return Result{}, err
+	}
+
+	slots := []Slot{}
+	// Windows may overlap, so only consider each start time once
+	seen := map[time.Time]bool{}
+	candidates := 0
+	organiserUnavailable := false
+	attendeesUnknown := false
+
+	for _, w := range req.Windows {
+		for start := w.Start; !start.Add(req.Duration).After(w.End); start = start.Add(req.Step) {
+			if seen[start.UTC()] {
+				continue
+			}
+			seen[start.UTC()] = true
+			candidates++
+			end := start.Add(req.Duration)
+			occurrences := req.occurrences(start)
+
+			organiserAvailability := req.availability(req.Organiser, occurrences)
+			if !req.OrganiserOptional && organiserAvailability != AvailabilityFree &&
+				organiserAvailability != AvailabilityUnknown && organiserAvailability != AvailabilityOverMeetingLimit {
+				organiserUnavailable = true
+				continue
+			}
+
+			attendees := make([]ParticipantAvailability, 0, len(req.Attendees))
+			freeCount, unknownCount := 0, 0
+			requiredUnavailable := false
+			for _, a := range req.Attendees {
+				availability := req.availability(a, occurrences)
+				switch availability {
+				case AvailabilityFree:
+					freeCount++
+				case AvailabilityUnknown:
+					unknownCount++
+					attendeesUnknown = true
+				case AvailabilityOverMeetingLimit:
+				case AvailabilityBusy, AvailabilityOutsideWorkingHours:
+					if a.Required {
+						requiredUnavailable = true
+					}
+				}
+				attendees = append(attendees, ParticipantAvailability{ID: a.ID, Availability: availability})
+			}
+			if requiredUnavailable {
+				continue
+			}
+
+			score, assumedFree := 100.0, 100.0
+			if len(req.Attendees) > 0 {
+				score = float64(freeCount) / float64(len(req.Attendees)) * 100
+				assumedFree = float64(freeCount+unknownCount) / float64(len(req.Attendees)) * 100
+			}
+			if assumedFree < *req.MinAttendeePercentage {
+				continue
+			}
+
+			slots = append(slots, Slot{
+				Start:                 start,
+				End:                   end,
+				Score:                 score,
+				OrganiserAvailability: organiserAvailability,
+				Attendees:             attendees,
+			})
+		}
+	}
+
+	slices.SortStableFunc(slots, func(a, b Slot) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return a.Start.Compare(b.Start)
+	})
+	if len(slots) > req.MaxCandidates {
+		slots = slots[:req.MaxCandidates]
+	}
+
+	res := Result{Slots: slots}
+	if len(slots) == 0 {
+		switch {
+		case candidates == 0:
+			res.EmptyReason = EmptyReasonNoCandidates
+		case organiserUnavailable:
+			res.EmptyReason = EmptyReasonOrganiserUnavailable
+		case attendeesUnknown:
+			res.EmptyReason = EmptyReasonAttendeesUnavailableOrUnknown
+		default:
+			res.EmptyReason = EmptyReasonAttendeesUnavailable
+		}
+	}
+
+	return res, nil
+}
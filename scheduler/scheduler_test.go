@@ -0,0 +1,444 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+// monday is a Monday at midnight UTC.
+// nolint: gochecknoglobals // fixed test date, wont change at runtime
+var monday = time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+
+func at(day time.Time, hour, minute int) time.Time {
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func interval(day time.Time, startHour, endHour int) scheduler.Interval {
+	return scheduler.Interval{Start: at(day, startHour, 0), End: at(day, endHour, 0)}
+}
+
+func percentage(p float64) *float64 {
+	return &p
+}
+
+func TestFindSlots(t *testing.T) {
+	t.Parallel()
+
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err, "failed to load location")
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err, "failed to load location")
+
+	nineToFive := &scheduler.WorkingHours{
+		Start: scheduler.NewTimeOfDay(9, 0),
+		End:   scheduler.NewTimeOfDay(17, 0),
+	}
+
+	tests := map[string]struct {
+		req           scheduler.Request
+		expectedStart []time.Time
+		expectedScore []float64
+		emptyReason   scheduler.EmptyReason
+		testMsg       string
+	}{
+		"everyone free": {
+			req: scheduler.Request{
+				Windows:   []scheduler.Interval{interval(monday, 9, 11)},
+				Duration:  time.Hour,
+				Attendees: []scheduler.Participant{{ID: "a", Required: true}},
+			},
+			expectedStart: []time.Time{at(monday, 9, 0), at(monday, 9, 30), at(monday, 10, 0)},
+			expectedScore: []float64{100, 100, 100},
+			testMsg:       "every step that fits in the window is suggested",
+		},
+		"organiser busy": {
+			req: scheduler.Request{
+				Windows:   []scheduler.Interval{interval(monday, 9, 11)},
+				Duration:  time.Hour,
+				Organiser: scheduler.Participant{Busy: []scheduler.Interval{interval(monday, 9, 10)}},
+			},
+			expectedStart: []time.Time{at(monday, 10, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "slots overlapping the organiser's busy time are skipped",
+		},
+		"organiser optional": {
+			req: scheduler.Request{
+				Windows:           []scheduler.Interval{interval(monday, 9, 10)},
+				Duration:          time.Hour,
+				Organiser:         scheduler.Participant{Busy: []scheduler.Interval{interval(monday, 9, 10)}},
+				OrganiserOptional: true,
+			},
+			expectedStart: []time.Time{at(monday, 9, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "an optional organiser being busy doesn't exclude slots",
+		},
+		"required attendee busy": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 11)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, Busy: []scheduler.Interval{interval(monday, 9, 10)}},
+				},
+			},
+			expectedStart: []time.Time{at(monday, 10, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "slots a required attendee can't make are skipped",
+		},
+		"optional attendee busy ranks lower": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 11)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true},
+					{ID: "b", Busy: []scheduler.Interval{interval(monday, 9, 10)}},
+				},
+			},
+			expectedStart: []time.Time{at(monday, 10, 0), at(monday, 9, 0), at(monday, 9, 30)},
+			expectedScore: []float64{100, 50, 50},
+			testMsg:       "slots are ranked by the percentage of attendees free, then start time",
+		},
+		"minimum attendee percentage": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 11)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a"},
+					{ID: "b", Busy: []scheduler.Interval{interval(monday, 9, 10)}},
+				},
+				MinAttendeePercentage: percentage(100),
+			},
+			expectedStart: []time.Time{at(monday, 10, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "slots below the minimum attendee percentage are skipped",
+		},
+		"buffers": {
+			req: scheduler.Request{
+				Windows:      []scheduler.Interval{interval(monday, 9, 13)},
+				Duration:     time.Hour,
+				BufferBefore: 15 * time.Minute,
+				BufferAfter:  15 * time.Minute,
+				Organiser: scheduler.Participant{Busy: []scheduler.Interval{
+					interval(monday, 9, 10), interval(monday, 12, 13),
+				}},
+			},
+			expectedStart: []time.Time{at(monday, 10, 30)},
+			expectedScore: []float64{100},
+			testMsg:       "buffers around the meeting must be free",
+		},
+		"working hours": {
+			req: scheduler.Request{
+				Windows:   []scheduler.Interval{interval(monday, 7, 10)},
+				Duration:  time.Hour,
+				Organiser: scheduler.Participant{WorkingHours: nineToFive},
+			},
+			expectedStart: []time.Time{at(monday, 9, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "slots outside the organiser's working hours are skipped",
+		},
+		"working days": {
+			req: scheduler.Request{
+				// Saturday
+				Windows:   []scheduler.Interval{interval(monday.AddDate(0, 0, 5), 9, 10)},
+				Duration:  time.Hour,
+				Organiser: scheduler.Participant{WorkingHours: nineToFive},
+			},
+			expectedStart: []time.Time{},
+			emptyReason:   scheduler.EmptyReasonOrganiserUnavailable,
+			testMsg:       "weekends are outside the default working days",
+		},
+		"time zones": {
+			req: scheduler.Request{
+				Windows:   []scheduler.Interval{interval(monday, 9, 18)},
+				Duration:  time.Hour,
+				Organiser: scheduler.Participant{Location: london, WorkingHours: nineToFive},
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, Location: newYork, WorkingHours: nineToFive},
+				},
+			},
+			// New York is UTC-5 in January, so 9am there is 2pm in London
+			expectedStart: []time.Time{
+				at(monday, 14, 0), at(monday, 14, 30), at(monday, 15, 0), at(monday, 15, 30), at(monday, 16, 0),
+			},
+			expectedScore: []float64{100, 100, 100, 100, 100},
+			testMsg:       "working hours are in each participant's own time zone",
+		},
+		"working hours across dst": {
+			req: scheduler.Request{
+				// London is UTC+1 on the 1st of July, so 9am local is 8am UTC
+				Windows:   []scheduler.Interval{interval(time.Date(2030, time.July, 1, 0, 0, 0, 0, time.UTC), 7, 9)},
+				Duration:  time.Hour,
+				Organiser: scheduler.Participant{Location: london, WorkingHours: nineToFive},
+			},
+			expectedStart: []time.Time{at(time.Date(2030, time.July, 1, 0, 0, 0, 0, time.UTC), 8, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "working hours follow daylight saving time",
+		},
+		"lunch break": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 11, 14)},
+				Duration: time.Hour,
+				Organiser: scheduler.Participant{Breaks: []scheduler.DailyWindow{
+					{Start: scheduler.NewTimeOfDay(12, 0), End: scheduler.NewTimeOfDay(13, 0)},
+				}},
+			},
+			expectedStart: []time.Time{at(monday, 11, 0), at(monday, 13, 0)},
+			expectedScore: []float64{100, 100},
+			testMsg:       "slots overlapping a daily break are skipped",
+		},
+		"unknown attendee": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 10)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, Unknown: true},
+					{ID: "b"},
+				},
+			},
+			expectedStart: []time.Time{at(monday, 9, 0)},
+			expectedScore: []float64{50},
+			testMsg:       "unknown attendees don't exclude slots but don't count as free",
+		},
+		"only attendee unknown": {
+			req: scheduler.Request{
+				Windows:   []scheduler.Interval{interval(monday, 9, 10)},
+				Duration:  time.Hour,
+				Attendees: []scheduler.Participant{{ID: "a", Unknown: true}},
+			},
+			expectedStart: []time.Time{at(monday, 9, 0)},
+			expectedScore: []float64{0},
+			testMsg:       "unknown attendees are assumed to be free for the minimum attendee percentage",
+		},
+		"max candidates": {
+			req: scheduler.Request{
+				Windows:       []scheduler.Interval{interval(monday, 9, 17)},
+				Duration:      time.Hour,
+				MaxCandidates: 2,
+			},
+			expectedStart: []time.Time{at(monday, 9, 0), at(monday, 9, 30)},
+			expectedScore: []float64{100, 100},
+			testMsg:       "at most MaxCandidates slots are returned",
+		},
+		"overlapping windows": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 10), interval(monday, 9, 10)},
+				Duration: time.Hour,
+			},
+			expectedStart: []time.Time{at(monday, 9, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "the same start time is only suggested once",
+		},
+		"window too short": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 10)},
+				Duration: 2 * time.Hour,
+			},
+			expectedStart: []time.Time{},
+			emptyReason:   scheduler.EmptyReasonNoCandidates,
+			testMsg:       "no slots are returned if the meeting doesn't fit in a window",
+		},
+		"unknown attendee outside working hours": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 8, 10)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, Unknown: true, WorkingHours: nineToFive},
+				},
+				MinAttendeePercentage: percentage(0),
+			},
+			expectedStart: []time.Time{at(monday, 9, 0)},
+			expectedScore: []float64{0},
+			testMsg:       "working hours apply to attendees whose calendar can't be read",
+		},
+		"meeting limit": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 10), interval(monday.Add(24*time.Hour), 9, 10)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, MaxMeetingsPerDay: 1, Busy: []scheduler.Interval{interval(monday, 14, 15)}},
+				},
+				MinAttendeePercentage: percentage(0),
+			},
+			expectedStart: []time.Time{at(monday.Add(24*time.Hour), 9, 0), at(monday, 9, 0)},
+			expectedScore: []float64{100, 0},
+			testMsg:       "days where an attendee has reached their meeting limit are ranked last",
+		},
+		"attendees unavailable": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 10)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, Busy: []scheduler.Interval{interval(monday, 9, 10)}},
+				},
+			},
+			expectedStart: []time.Time{},
+			emptyReason:   scheduler.EmptyReasonAttendeesUnavailable,
+			testMsg:       "the empty reason says attendees are unavailable",
+		},
+		"series busy on a later occurrence": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 11)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, Busy: []scheduler.Interval{interval(monday.AddDate(0, 0, 14), 9, 10)}},
+				},
+				Recurrence: &scheduler.Recurrence{Frequency: scheduler.FrequencyWeekly, Count: 3},
+			},
+			expectedStart: []time.Time{at(monday, 10, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "slots are skipped if an attendee is busy for any occurrence",
+		},
+		"series past the last occurrence": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 10)},
+				Duration: time.Hour,
+				Attendees: []scheduler.Participant{
+					{ID: "a", Required: true, Busy: []scheduler.Interval{interval(monday.AddDate(0, 0, 21), 9, 10)}},
+				},
+				Recurrence: &scheduler.Recurrence{Frequency: scheduler.FrequencyWeekly, Count: 3},
+			},
+			expectedStart: []time.Time{at(monday, 9, 0)},
+			expectedScore: []float64{100},
+			testMsg:       "only the first Count occurrences need to be free",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := scheduler.FindSlots(tt.req)
+			require.NoError(t, err)
+
+			starts := []time.Time{}
+			scores := []float64{}
+			for _, s := range res.Slots {
+				starts = append(starts, s.Start.UTC())
+				scores = append(scores, s.Score)
+				require.Equal(t, tt.req.Duration, s.End.Sub(s.Start), "slot is as long as the meeting")
+			}
+			require.Equal(t, tt.expectedStart, starts, tt.testMsg)
+			if tt.expectedScore != nil {
+				require.Equal(t, tt.expectedScore, scores, tt.testMsg)
+			}
+			require.Equal(t, tt.emptyReason, res.EmptyReason, tt.testMsg)
+		})
+	}
+}
+
+func TestFindSlots_Availability(t *testing.T) {
+	t.Parallel()
+
+	res, err := scheduler.FindSlots(scheduler.Request{
+		Windows:  []scheduler.Interval{interval(monday, 9, 10)},
+		Duration: time.Hour,
+		Attendees: []scheduler.Participant{
+			{ID: "free"},
+			{ID: "busy", Busy: []scheduler.Interval{interval(monday, 9, 10)}},
+			{ID: "unknown", Unknown: true},
+			{ID: "overbooked", MaxMeetingsPerDay: 1, Busy: []scheduler.Interval{interval(monday, 14, 15)}},
+			{ID: "asleep", WorkingHours: &scheduler.WorkingHours{
+				Start: scheduler.NewTimeOfDay(12, 0),
+				End:   scheduler.NewTimeOfDay(20, 0),
+			}},
+		},
+		MinAttendeePercentage: percentage(0),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Slots, 1)
+
+	require.Equal(t, scheduler.AvailabilityFree, res.Slots[0].OrganiserAvailability)
+	require.Equal(t, []scheduler.ParticipantAvailability{
+		{ID: "free", Availability: scheduler.AvailabilityFree},
+		{ID: "busy", Availability: scheduler.AvailabilityBusy},
+		{ID: "unknown", Availability: scheduler.AvailabilityUnknown},
+		{ID: "overbooked", Availability: scheduler.AvailabilityOverMeetingLimit},
+		{ID: "asleep", Availability: scheduler.AvailabilityOutsideWorkingHours},
+	}, res.Slots[0].Attendees, "every attendee's availability is reported")
+}
+
+func TestFindSlots_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		req         scheduler.Request
+		expectedErr error
+	}{
+		"no duration": {
+			req:         scheduler.Request{Windows: []scheduler.Interval{interval(monday, 9, 10)}},
+			expectedErr: scheduler.ErrInvalidDuration,
+		},
+		"negative buffer": {
+			req: scheduler.Request{
+				Windows:      []scheduler.Interval{interval(monday, 9, 10)},
+				Duration:     time.Hour,
+				BufferBefore: -time.Minute,
+			},
+			expectedErr: scheduler.ErrInvalidBuffer,
+		},
+		"tiny step": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 9, 10)},
+				Duration: time.Hour,
+				Step:     time.Minute,
+			},
+			expectedErr: scheduler.ErrInvalidStep,
+		},
+		"no windows": {
+			req:         scheduler.Request{Duration: time.Hour},
+			expectedErr: scheduler.ErrNoWindows,
+		},
+		"backwards window": {
+			req: scheduler.Request{
+				Windows:  []scheduler.Interval{interval(monday, 10, 9)},
+				Duration: time.Hour,
+			},
+			expectedErr: scheduler.ErrInvalidWindow,
+		},
+		"search too long": {
+			req: scheduler.Request{
+				Windows: []scheduler.Interval{
+					interval(monday, 9, 10),
+					interval(monday.Add(scheduler.MaxSearchSpan), 9, 10),
+				},
+				Duration: time.Hour,
+			},
+			expectedErr: scheduler.ErrSearchTooLong,
+		},
+		"too many checks": {
+			req: scheduler.Request{
+				Windows:    []scheduler.Interval{{Start: monday, End: monday.Add(28 * 24 * time.Hour)}},
+				Duration:   time.Hour,
+				Recurrence: &scheduler.Recurrence{Frequency: scheduler.FrequencyWeekly, Count: scheduler.MaxOccurrences},
+			},
+			expectedErr: scheduler.ErrTooManyChecks,
+		},
+		"too many occurrences": {
+			req: scheduler.Request{
+				Windows:    []scheduler.Interval{interval(monday, 9, 10)},
+				Duration:   time.Hour,
+				Recurrence: &scheduler.Recurrence{Frequency: scheduler.FrequencyDaily, Count: scheduler.MaxOccurrences + 1},
+			},
+			expectedErr: scheduler.ErrInvalidOccurrences,
+		},
+		"unknown frequency": {
+			req: scheduler.Request{
+				Windows:    []scheduler.Interval{interval(monday, 9, 10)},
+				Duration:   time.Hour,
+				Recurrence: &scheduler.Recurrence{Frequency: "yearly"},
+			},
+			expectedErr: scheduler.ErrInvalidFrequency,
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := scheduler.FindSlots(tt.req)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
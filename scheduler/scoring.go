@@ -0,0 +1,273 @@
+package scheduler
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Factor is something that makes a slot better or worse for the participants.
+type Factor string
+
+const (
+	// FactorMeetingLoad prefers days where participants have more of their working day free.
+	FactorMeetingLoad Factor = "meetingLoad"
+	// FactorBackToBack prefers slots that don't start or end right next to another meeting.
+	FactorBackToBack Factor = "backToBack"
+	// FactorTimeOfDay prefers slots in the core of the participant's working day.
+	FactorTimeOfDay Factor = "timeOfDay"
+	// FactorPreferredRooms prefers slots with a preferred room available.
+	FactorPreferredRooms Factor = "preferredRooms"
+	// FactorTimeZoneFairness prefers slots that are equally convenient for everyone, wherever they are.
+	FactorTimeZoneFairness Factor = "timeZoneFairness"
+)
+
+const (
+	// DefaultWorkingDay is the length of the working day used for participants without working hours.
+	DefaultWorkingDay = 8 * time.Hour
+	// AdjacentGap is how close another meeting has to be to count as back-to-back.
+	AdjacentGap = 10 * time.Minute
+
+	// coreHoursMargin is trimmed from both ends of the working day to get the core hours.
+	coreHoursMargin = time.Hour
+	maxScore        = 100.0
+)
+
+var ErrInvalidWeights = errors.New("weights must not be negative and at least one must be positive")
+
+// Factors are every factor, in the order they are reported.
+// nolint: gochecknoglobals // immutable list, wont change at runtime
+var Factors = []Factor{
+	FactorMeetingLoad,
+	FactorBackToBack,
+	FactorTimeOfDay,
+	FactorPreferredRooms,
+	FactorTimeZoneFairness,
+}
+
+// Weights are how much each factor counts towards a slot's score, factors missing from the map don't count.
+type Weights map[Factor]float64
+
+// DefaultWeights weight every factor equally.
+func DefaultWeights() Weights {
+	w := Weights{}
+	for _, f := range Factors {
+		w[f] = 1
+	}
+	return w
+}
+
+// Validate checks the weights can be used to score slots.
+func (w Weights) Validate() error {
+	total := 0.0
+	for _, weight := range w {
+		if weight < 0 {
+			return ErrInvalidWeights
+		}
+		total += weight
+	}
+	if total == 0 {
+		return ErrInvalidWeights
+	}
+	return nil
+}
+
+// FactorScore is the score, out of 100, a slot got for a factor.
+type FactorScore struct {
+	Factor Factor
+	Weight float64
+	Score  float64
+}
+
+// ParticipantScore is how good a slot is for one participant.
+type ParticipantScore struct {
+	ID string
+	// Score is the weighted mean of Factors.
+	Score   float64
+	Factors []FactorScore
+}
+
+// SlotScore explains how a slot was scored.
+type SlotScore struct {
+	// Score is the weighted mean of Factors.
+	Score float64
+	// Factors are the participant factors averaged over participants, plus the slot-wide factors.
+	Factors      []FactorScore
+	Participants []ParticipantScore
+}
+
+// Scorer scores slots by a weighted mean of factors.
+type Scorer struct {
+	Weights Weights
+	// PreferredRooms are the emails of rooms to prefer, FactorPreferredRooms isn't used if empty.
+	PreferredRooms []string
+}
+
+// weightedMean returns the mean of the scores weighted by their factor's weight.
+func weightedMean(scores []FactorScore) float64 {
+	total, weights := 0.0, 0.0
+	for _, s := range scores {
+		total += s.Score * s.Weight
+		weights += s.Weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
+
+// workingDay returns the participant's working day on the date of day, in their location.
+func (p Participant) workingDay(day time.Time) Interval {
+	workingHours := p.WorkingHours
+	if workingHours == nil {
+		start := NewTimeOfDay(9, 0) //nolint: mnd // 9am
+		workingHours = &WorkingHours{Start: start, End: start + TimeOfDay(DefaultWorkingDay)}
+	}
+	return Interval{Start: workingHours.Start.on(day), End: workingHours.End.on(day)}
+}
+
+// location returns the participant's time zone.
+func (p Participant) location() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+	return p.Location
+}
+
+// meetingLoadScore is the percentage of the working day that is still free once the slot is booked.
+func (p Participant) meetingLoadScore(slot Interval) float64 {
+	day := p.workingDay(slot.Start.In(p.location()))
+	workingMinutes := day.End.Sub(day.Start).Minutes()
+	if workingMinutes <= 0 {
+		return 0
+	}
+
+	booked := slot.End.Sub(slot.Start).Minutes()
+	for _, b := range p.Busy {
+		if !b.overlaps(day.Start, day.End) {
+			continue
+		}
+		start, end := b.Start, b.End
+		if start.Before(day.Start) {
+			start = day.Start
+		}
+		if end.After(day.End) {
+			end = day.End
+		}
+		booked += end.Sub(start).Minutes()
+	}
+
+	return max(0, (workingMinutes-booked)/workingMinutes*maxScore)
+}
+
+// backToBackScore is 100 with no adjacent meetings, 50 with a meeting on one side and 0 with both.
+func (p Participant) backToBackScore(slot Interval) float64 {
+	before, after := false, false
+	for _, b := range p.Busy {
+		if !b.End.After(slot.Start) && b.End.After(slot.Start.Add(-AdjacentGap)) {
+			before = true
+		}
+		if !b.Start.Before(slot.End) && b.Start.Before(slot.End.Add(AdjacentGap)) {
+			after = true
+		}
+	}
+
+	switch {
+	case before && after:
+		return 0
+	case before || after:
+		return maxScore / 2 //nolint: mnd // half marks
+	default:
+		return maxScore
+	}
+}
+
+// timeOfDayScore is 100 in the participant's core hours, the working day without its first and
+// last hour, 50 in the rest of the working day and 0 outside of it.
+func (p Participant) timeOfDayScore(slot Interval) float64 {
+	start := slot.Start.In(p.location())
+	end := slot.End.In(p.location())
+	day := p.workingDay(start)
+
+	core := Interval{Start: day.Start.Add(coreHoursMargin), End: day.End.Add(-coreHoursMargin)}
+	if !core.End.After(core.Start) {
+		core = day
+	}
+
+	switch {
+	case !start.Before(core.Start) && !end.After(core.End):
+		return maxScore
+	case !start.Before(day.Start) && !end.After(day.End):
+		return maxScore / 2 //nolint: mnd // half marks
+	default:
+		return 0
+	}
+}
+
+// scoreParticipant scores a slot for one participant, only the time of day is known for unknown participants.
+func (s Scorer) scoreParticipant(slot Interval, p Participant) ParticipantScore {
+	factors := []FactorScore{}
+	if !p.Unknown {
+		factors = append(factors,
+			FactorScore{Factor: FactorMeetingLoad, Weight: s.Weights[FactorMeetingLoad], Score: p.meetingLoadScore(slot)},
+			FactorScore{Factor: FactorBackToBack, Weight: s.Weights[FactorBackToBack], Score: p.backToBackScore(slot)},
+		)
+	}
+	factors = append(factors,
+		FactorScore{Factor: FactorTimeOfDay, Weight: s.Weights[FactorTimeOfDay], Score: p.timeOfDayScore(slot)})
+
+	return ParticipantScore{ID: p.ID, Score: weightedMean(factors), Factors: factors}
+}
+
+// Score scores a slot for the participants, rooms are the emails of the rooms available for it.
+func (s Scorer) Score(slot Interval, rooms []string, participants []Participant) SlotScore {
+	res := SlotScore{Participants: make([]ParticipantScore, 0, len(participants))}
+
+	totals := map[Factor]float64{}
+	counts := map[Factor]int{}
+	minTimeOfDay, maxTimeOfDay := maxScore, 0.0
+	for _, p := range participants {
+		ps := s.scoreParticipant(slot, p)
+		res.Participants = append(res.Participants, ps)
+		for _, f := range ps.Factors {
+			totals[f.Factor] += f.Score
+			counts[f.Factor]++
+			if f.Factor == FactorTimeOfDay {
+				minTimeOfDay = min(minTimeOfDay, f.Score)
+				maxTimeOfDay = max(maxTimeOfDay, f.Score)
+			}
+		}
+	}
+
+	for _, f := range []Factor{FactorMeetingLoad, FactorBackToBack, FactorTimeOfDay} {
+		if counts[f] > 0 {
+			res.Factors = append(res.Factors,
+				FactorScore{Factor: f, Weight: s.Weights[f], Score: totals[f] / float64(counts[f])})
+		}
+	}
+
+	if len(s.PreferredRooms) > 0 {
+		score := 0.0
+		for _, room := range rooms {
+			for _, preferred := range s.PreferredRooms {
+				if strings.EqualFold(room, preferred) {
+					score = maxScore
+				}
+			}
+		}
+		res.Factors = append(res.Factors,
+			FactorScore{Factor: FactorPreferredRooms, Weight: s.Weights[FactorPreferredRooms], Score: score})
+	}
+
+	// Fairness only means something if there is someone to be fair between
+	if len(participants) > 1 {
+		res.Factors = append(res.Factors, FactorScore{
+			Factor: FactorTimeZoneFairness,
+			Weight: s.Weights[FactorTimeZoneFairness],
+			Score:  maxScore - (maxTimeOfDay - minTimeOfDay),
+		})
+	}
+
+	res.Score = weightedMean(res.Factors)
+	return res
+}
@@ -0,0 +1,150 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+// factorScores maps a breakdown's factors to their scores.
+func factorScores(factors []scheduler.FactorScore) map[scheduler.Factor]float64 {
+	scores := map[scheduler.Factor]float64{}
+	for _, f := range factors {
+		scores[f.Factor] = f.Score
+	}
+	return scores
+}
+
+func TestScorer_Score(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err, "failed to load location")
+
+	tests := map[string]struct {
+		scorer         scheduler.Scorer
+		slot           scheduler.Interval
+		rooms          []string
+		participants   []scheduler.Participant
+		expectedSlot   map[scheduler.Factor]float64
+		expectedPeople []map[scheduler.Factor]float64
+		expectedScore  float64
+		testMsg        string
+	}{
+		"free day in core hours": {
+			scorer:       scheduler.Scorer{Weights: scheduler.DefaultWeights()},
+			slot:         interval(monday, 11, 12),
+			participants: []scheduler.Participant{{ID: "a"}},
+			expectedSlot: map[scheduler.Factor]float64{
+				scheduler.FactorMeetingLoad: 87.5,
+				scheduler.FactorBackToBack:  100,
+				scheduler.FactorTimeOfDay:   100,
+			},
+			expectedPeople: []map[scheduler.Factor]float64{{
+				scheduler.FactorMeetingLoad: 87.5,
+				scheduler.FactorBackToBack:  100,
+				scheduler.FactorTimeOfDay:   100,
+			}},
+			expectedScore: (87.5 + 100 + 100) / 3,
+			testMsg:       "the meeting itself counts towards the meeting load",
+		},
+		"busy day with back to back meetings": {
+			scorer: scheduler.Scorer{Weights: scheduler.DefaultWeights()},
+			slot:   interval(monday, 11, 12),
+			participants: []scheduler.Participant{{
+				ID:   "a",
+				Busy: []scheduler.Interval{interval(monday, 9, 11), interval(monday, 12, 14)},
+			}},
+			expectedSlot: map[scheduler.Factor]float64{
+				scheduler.FactorMeetingLoad: 37.5,
+				scheduler.FactorBackToBack:  0,
+				scheduler.FactorTimeOfDay:   100,
+			},
+			expectedPeople: []map[scheduler.Factor]float64{{
+				scheduler.FactorMeetingLoad: 37.5,
+				scheduler.FactorBackToBack:  0,
+				scheduler.FactorTimeOfDay:   100,
+			}},
+			expectedScore: (37.5 + 0 + 100) / 3,
+			testMsg:       "meetings either side of the slot are back to back",
+		},
+		"edge of the working day": {
+			scorer:       scheduler.Scorer{Weights: scheduler.Weights{scheduler.FactorTimeOfDay: 1}},
+			slot:         interval(monday, 16, 17),
+			participants: []scheduler.Participant{{ID: "a"}},
+			expectedSlot: map[scheduler.Factor]float64{
+				scheduler.FactorMeetingLoad: 87.5,
+				scheduler.FactorBackToBack:  100,
+				scheduler.FactorTimeOfDay:   50,
+			},
+			expectedPeople: []map[scheduler.Factor]float64{{
+				scheduler.FactorMeetingLoad: 87.5,
+				scheduler.FactorBackToBack:  100,
+				scheduler.FactorTimeOfDay:   50,
+			}},
+			expectedScore: 50,
+			testMsg:       "factors without a weight are reported but don't count",
+		},
+		"preferred room": {
+			scorer: scheduler.Scorer{
+				Weights:        scheduler.Weights{scheduler.FactorPreferredRooms: 1},
+				PreferredRooms: []string{"Room@example.com"},
+			},
+			slot:         interval(monday, 11, 12),
+			rooms:        []string{"other@example.com", "room@example.com"},
+			participants: []scheduler.Participant{{ID: "a", Unknown: true}},
+			expectedSlot: map[scheduler.Factor]float64{
+				scheduler.FactorTimeOfDay:      100,
+				scheduler.FactorPreferredRooms: 100,
+			},
+			expectedPeople: []map[scheduler.Factor]float64{{scheduler.FactorTimeOfDay: 100}},
+			expectedScore:  100,
+			testMsg:        "rooms are matched case insensitively and unknown participants only get a time of day",
+		},
+		"unfair across time zones": {
+			scorer: scheduler.Scorer{Weights: scheduler.Weights{scheduler.FactorTimeZoneFairness: 1}},
+			slot:   interval(monday, 11, 12),
+			participants: []scheduler.Participant{
+				{ID: "london", Unknown: true},
+				{ID: "tokyo", Unknown: true, Location: tokyo},
+			},
+			expectedSlot: map[scheduler.Factor]float64{
+				scheduler.FactorTimeOfDay:        50,
+				scheduler.FactorTimeZoneFairness: 0,
+			},
+			expectedPeople: []map[scheduler.Factor]float64{
+				{scheduler.FactorTimeOfDay: 100},
+				{scheduler.FactorTimeOfDay: 0},
+			},
+			expectedScore: 0,
+			testMsg:       "8pm in Tokyo is unfair on the Tokyo participant",
+		},
+	}
+
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			res := tt.scorer.Score(tt.slot, tt.rooms, tt.participants)
+
+			require.Equal(t, tt.expectedSlot, factorScores(res.Factors), tt.testMsg)
+			require.Len(t, res.Participants, len(tt.expectedPeople))
+			for i, p := range res.Participants {
+				require.Equal(t, tt.participants[i].ID, p.ID)
+				require.Equal(t, tt.expectedPeople[i], factorScores(p.Factors), tt.testMsg)
+			}
+			require.InDelta(t, tt.expectedScore, res.Score, 0.001, tt.testMsg)
+		})
+	}
+}
+
+func TestWeights_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, scheduler.DefaultWeights().Validate())
+	require.NoError(t, scheduler.Weights{scheduler.FactorMeetingLoad: 0, scheduler.FactorBackToBack: 2}.Validate())
+	require.ErrorIs(t, scheduler.Weights{scheduler.FactorMeetingLoad: -1}.Validate(), scheduler.ErrInvalidWeights)
+	require.ErrorIs(t, scheduler.Weights{}.Validate(), scheduler.ErrInvalidWeights)
+}
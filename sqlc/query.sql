@@ -19,6 +19,9 @@ SELECT * FROM User WHERE email=?;
 -- name: UpdateUserHomeAccountID :execrows
 UPDATE User SET msft_home_account_id=? WHERE id=?;
 
+-- name: UpdateUserTimeZone :execrows
+UPDATE User SET time_zone=? WHERE id=?;
+
 -- name: GetUsersSlotifyGroups :many
 SELECT sg.* FROM UserToSlotifyGroup utsg
 JOIN SlotifyGroup sg ON utsg.slotify_group_id=sg.id 
@@ -40,13 +43,13 @@ LIMIT 10;
 
 
 -- name: AddUserToSlotifyGroup :execrows
-INSERT INTO UserToSlotifyGroup (user_id, slotify_group_id) VALUES (?, ?);
+INSERT INTO UserToSlotifyGroup (user_id, slotify_group_id, role) VALUES (?, ?, ?);
 
 -- name: CountSlotifyGroupByID :one
 SELECT COUNT(*) FROM SlotifyGroup WHERE id=?;
 
 -- name: GetAllSlotifyGroupMembers :many
-SELECT u.id, u.email, u.first_name, u.last_name FROM SlotifyGroup sg
+SELECT u.id, u.email, u.first_name, u.last_name, utsg.attendee_type FROM SlotifyGroup sg
 JOIN UserToSlotifyGroup utsg ON sg.id=utsg.slotify_group_id
 JOIN User u ON u.id=utsg.user_id 
 WHERE sg.id=?
@@ -92,6 +95,12 @@ WHERE sg.id=sqlc.arg('slotifyGroupID') AND u.id!=sqlc.arg('userID');
 -- name: GetSlotifyGroupByID :one
 SELECT * FROM SlotifyGroup WHERE id=?;
 
+-- name: UpdateSlotifyGroup :execrows
+UPDATE SlotifyGroup
+SET name=?, description=?, avatar_colour=?, visibility=?, default_meeting_duration=?,
+default_location_name=?, default_location_email=?, default_is_online_meeting=?
+WHERE id=?;
+
 -- name: DeleteSlotifyGroupByID :execrows
 DELETE FROM SlotifyGroup WHERE id=?;
 
@@ -114,6 +123,24 @@ WHERE id=?;
 SELECT COUNT(*) FROM UserToSlotifyGroup
 WHERE user_id=? AND slotify_group_id=?;
 
+-- name: SetSlotifyGroupMemberAttendeeType :execrows
+UPDATE UserToSlotifyGroup SET attendee_type=?
+WHERE user_id=? AND slotify_group_id=?;
+
+-- name: GetSlotifyGroupMemberRole :one
+SELECT role FROM UserToSlotifyGroup
+WHERE user_id=? AND slotify_group_id=?;
+
+-- name: SetSlotifyGroupMemberRole :execrows
+UPDATE UserToSlotifyGroup SET role=?
+WHERE user_id=? AND slotify_group_id=?;
+
+-- name: GetSlotifyGroupSuccessor :one
+SELECT user_id FROM UserToSlotifyGroup
+WHERE slotify_group_id=? AND user_id!=?
+ORDER BY role='admin' DESC, user_id
+LIMIT 1;
+
 
 
 
@@ -127,7 +154,7 @@ SELECT * FROM RefreshToken WHERE user_id=?;
 DELETE FROM RefreshToken WHERE user_id=?;
 
 -- name: CreateNotification :execlastid
-INSERT INTO Notification (message, created) VALUES(?, ?);
+INSERT INTO Notification (message, created, kind, version, payload) VALUES(?, ?, ?, ?, ?);
 
 -- name: CountWeekOldNotifications :one
 SELECT COUNT(*) FROM Notification
@@ -141,7 +168,7 @@ ORDER BY id
 LIMIT ?;
 
 -- name: CreateUserNotification :execrows
-INSERT INTO UserToNotification (user_id, notification_id, is_read) VALUES(?, ?, FALSE);
+INSERT INTO UserToNotification (user_id, notification_id, is_read, channel) VALUES(?, ?, FALSE, ?);
 
 -- name: GetUnreadUserNotifications :many
 SELECT n.* FROM UserToNotification utn
@@ -149,10 +176,143 @@ JOIN Notification n ON n.id=utn.notification_id
 WHERE utn.user_id=? AND utn.is_read=FALSE
 ORDER BY n.created DESC;
 
+-- name: ListUserNotificationsAfterID :many
+SELECT n.* FROM UserToNotification utn
+JOIN Notification n ON n.id=utn.notification_id
+WHERE utn.user_id=? AND n.id > ? AND utn.channel='in_app'
+ORDER BY n.id;
+
+-- name: ListUnreadDigestNotifications :many
+SELECT u.id AS user_id, u.email, u.first_name, u.last_name, nd.sent_until,
+  n.id, n.message, n.created, n.kind, n.version, n.payload
+FROM UserToNotification utn
+JOIN Notification n ON n.id=utn.notification_id
+JOIN User u ON u.id=utn.user_id
+LEFT JOIN NotificationDigest nd ON nd.user_id=u.id
+WHERE utn.is_read=FALSE AND utn.channel='email_digest'
+AND n.created > COALESCE(nd.sent_until, sqlc.arg('first_since')) AND n.created <= sqlc.arg('now')
+AND (nd.sent_until IS NULL OR nd.sent_until <= sqlc.arg('due_before'))
+ORDER BY u.id, n.id;
+
+-- name: CreateNotificationDigest :execrows
+INSERT IGNORE INTO NotificationDigest (user_id, sent_until) VALUES (?, ?);
+
+-- name: ClaimNotificationDigest :execrows
+UPDATE NotificationDigest SET sent_until=sqlc.arg('sent_until')
+WHERE user_id=? AND sent_until=sqlc.arg('last_sent_until');
+
 -- name: MarkNotificationAsRead :execrows
 UPDATE UserToNotification SET is_read=TRUE
 WHERE user_id=? AND notification_id=?;
 
+-- name: MarkAllNotificationsAsRead :execrows
+UPDATE UserToNotification SET is_read=TRUE
+WHERE user_id=? AND is_read=FALSE;
+
+-- name: ListUserNotifications :many
+SELECT n.*, utn.is_read FROM UserToNotification utn
+JOIN Notification n ON n.id=utn.notification_id
+WHERE utn.user_id=? AND n.id < sqlc.arg('last_id')
+AND (sqlc.narg('is_read') IS NULL OR utn.is_read=sqlc.narg('is_read'))
+ORDER BY n.id DESC
+LIMIT ?;
+
+-- name: CountUnreadUserNotifications :one
+SELECT COUNT(*) FROM UserToNotification
+WHERE user_id=? AND is_read=FALSE;
+
+-- name: DeleteUserNotification :execrows
+DELETE FROM UserToNotification
+WHERE user_id=? AND notification_id=?;
+
+-- name: GetNotificationByID :one
+SELECT * FROM Notification WHERE id=?;
+
+-- name: ListNotificationRecipients :many
+SELECT user_id, channel FROM UserToNotification
+WHERE notification_id=?
+ORDER BY user_id;
+
+-- name: CreateNotificationOutbox :execrows
+INSERT INTO NotificationOutbox (idempotency_key, user_ids, message, created, kind, version, payload, next_attempt_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE id=id;
+
+-- name: ListDueNotificationOutbox :many
+SELECT * FROM NotificationOutbox
+WHERE delivered_at IS NULL AND next_attempt_at <= ? AND attempts < ?
+ORDER BY id
+LIMIT ?;
+
+-- name: ListUserNotificationOutbox :many
+SELECT * FROM NotificationOutbox
+WHERE JSON_CONTAINS(user_ids, sqlc.arg(user_id))
+ORDER BY id;
+
+-- name: ClaimNotificationOutbox :execrows
+UPDATE NotificationOutbox SET attempts=attempts+1, next_attempt_at=?
+WHERE id=? AND attempts=? AND delivered_at IS NULL;
+
+-- name: SetNotificationOutboxNotificationID :execrows
+UPDATE NotificationOutbox SET notification_id=?
+WHERE id=? AND notification_id IS NULL;
+
+-- name: MarkNotificationOutboxDelivered :execrows
+UPDATE NotificationOutbox SET delivered_at=?, last_error=NULL
+WHERE id=?;
+
+-- name: RetryNotificationOutbox :execrows
+UPDATE NotificationOutbox SET next_attempt_at=?, last_error=?
+WHERE id=?;
+
+-- name: DeleteDeliveredNotificationOutbox :execrows
+DELETE FROM NotificationOutbox
+WHERE delivered_at <= ?;
+
+-- name: ListNotificationPreferences :many
+SELECT * FROM NotificationPreferences
+WHERE user_id=?
+ORDER BY kind;
+
+-- name: GetNotificationPreferenceChannel :one
+SELECT channel FROM NotificationPreferences
+WHERE user_id=? AND kind=?;
+
+-- name: CreateNotificationPreference :execrows
+INSERT INTO NotificationPreferences (user_id, kind, channel) VALUES (?, ?, ?);
+
+-- name: DeleteNotificationPreferences :execrows
+DELETE FROM NotificationPreferences WHERE user_id=?;
+
+-- name: GetNotificationQuietHours :one
+SELECT nqh.user_id, nqh.start_time, nqh.end_time, u.time_zone FROM NotificationQuietHours nqh
+JOIN User u ON nqh.user_id=u.id
+WHERE nqh.user_id=?;
+
+-- name: UpsertNotificationQuietHours :execrows
+INSERT INTO NotificationQuietHours (user_id, start_time, end_time)
+VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE start_time=VALUES(start_time), end_time=VALUES(end_time);
+
+-- name: DeleteNotificationQuietHours :execrows
+DELETE FROM NotificationQuietHours WHERE user_id=?;
+
+-- name: ListSlotifyGroupNotificationPreferences :many
+SELECT * FROM SlotifyGroupNotificationPreferences
+WHERE slotify_group_id=?
+ORDER BY kind;
+
+-- name: CreateSlotifyGroupNotificationPreference :execrows
+INSERT INTO SlotifyGroupNotificationPreferences (slotify_group_id, kind, channel) VALUES (?, ?, ?);
+
+-- name: DeleteSlotifyGroupNotificationPreferences :execrows
+DELETE FROM SlotifyGroupNotificationPreferences WHERE slotify_group_id=?;
+
+-- name: ListMemberSlotifyGroupNotificationChannels :many
+SELECT sgnp.channel FROM SlotifyGroupNotificationPreferences sgnp
+JOIN UserToSlotifyGroup utsg ON utsg.slotify_group_id=sgnp.slotify_group_id
+WHERE utsg.user_id=? AND sgnp.kind=?;
+
 
 
 
@@ -246,7 +406,7 @@ INSERT INTO MeetingPreferences (meeting_start_time, start_date_range, end_date_r
 INSERT INTO Meeting (meeting_pref_id, owner_email, msft_meeting_id) VALUES (?,?,?);
 
 -- name: CreateReschedulingRequest :execlastid
-INSERT INTO ReschedulingRequest (requested_by, created_at) VALUES (?, ?);
+INSERT INTO ReschedulingRequest (requested_by, created_at, scope, occurrence_start) VALUES (?, ?, ?, ?);
 
 -- name: CreatePlaceholderMeeting :execlastid
 INSERT INTO PlaceholderMeeting (request_id, title, location, duration, start_date_range, end_date_range) VALUES (?,?,?,?,?,?);
@@ -308,3 +468,207 @@ WHERE pma.meeting_id=?;
 -- name: DeleteRequest :exec
 DELETE FROM ReschedulingRequest
 WHERE request_id = ?;
+
+
+
+
+-- name: GetMSALTokenCache :one
+SELECT cache_data FROM MSALTokenCache
+WHERE partition_key=?;
+
+-- name: UpsertMSALTokenCache :execrows
+REPLACE INTO MSALTokenCache (partition_key, cache_data) VALUES (?, ?);
+
+-- name: UpsertUserIdentity :execrows
+INSERT INTO UserIdentity (user_id, provider, subject) VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE subject=VALUES(subject);
+
+-- name: GetUserIdentity :one
+SELECT * FROM UserIdentity
+WHERE user_id=? AND provider=?;
+
+-- name: UpdateUserIdentityTokenData :execrows
+UPDATE UserIdentity SET token_data=?
+WHERE user_id=? AND provider=?;
+
+-- name: GetUserPreferences :one
+SELECT up.user_id, up.lunch_start_time, up.lunch_end_time, up.working_hours_start, up.working_hours_end,
+  up.working_days, up.max_meetings_per_day, u.time_zone FROM UserPreferences up
+JOIN User u ON up.user_id=u.id
+WHERE up.user_id=?;
+
+-- name: UpsertUserPreferences :execrows
+INSERT INTO UserPreferences (user_id, lunch_start_time, lunch_end_time, working_hours_start,
+  working_hours_end, working_days, max_meetings_per_day)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE lunch_start_time=VALUES(lunch_start_time), lunch_end_time=VALUES(lunch_end_time),
+  working_hours_start=VALUES(working_hours_start), working_hours_end=VALUES(working_hours_end),
+  working_days=VALUES(working_days), max_meetings_per_day=VALUES(max_meetings_per_day);
+
+-- name: DeleteUserPreferences :execrows
+DELETE FROM UserPreferences WHERE user_id=?;
+
+-- name: GetSlotifyGroupScoringWeights :one
+SELECT * FROM SlotifyGroupScoringWeights
+WHERE slotify_group_id=?;
+
+-- name: UpsertSlotifyGroupScoringWeights :execrows
+INSERT INTO SlotifyGroupScoringWeights (slotify_group_id, meeting_load, back_to_back, time_of_day,
+  preferred_rooms, time_zone_fairness, preferred_room_emails)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE meeting_load=VALUES(meeting_load), back_to_back=VALUES(back_to_back),
+  time_of_day=VALUES(time_of_day), preferred_rooms=VALUES(preferred_rooms),
+  time_zone_fairness=VALUES(time_zone_fairness), preferred_room_emails=VALUES(preferred_room_emails);
+
+-- name: GetCalendarSync :one
+SELECT * FROM CalendarSync
+WHERE user_id=?;
+
+-- name: UpsertCalendarSync :execrows
+INSERT INTO CalendarSync (user_id, delta_link, window_start, window_end, last_synced_at, last_read_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE delta_link=VALUES(delta_link), window_start=VALUES(window_start),
+  window_end=VALUES(window_end), last_synced_at=VALUES(last_synced_at);
+
+-- name: UpdateCalendarSyncLastReadAt :execrows
+UPDATE CalendarSync SET last_read_at=?
+WHERE user_id=?;
+
+-- name: ExpireCalendarSync :execrows
+UPDATE CalendarSync SET last_synced_at='1970-01-01 00:00:00'
+WHERE user_id=?;
+
+-- name: ListCalendarSyncsToRefresh :many
+SELECT user_id FROM CalendarSync
+WHERE last_synced_at < ? AND last_read_at >= ?
+ORDER BY last_synced_at
+LIMIT ?;
+
+-- name: BatchDeleteIdleCalendarSyncs :execrows
+DELETE FROM CalendarSync
+WHERE last_read_at < ?
+LIMIT ?;
+
+-- name: ListCalendarEventCache :many
+SELECT * FROM CalendarEventCache
+WHERE user_id=? AND start_time < sqlc.arg('range_end') AND end_time > sqlc.arg('range_start')
+ORDER BY start_time;
+
+-- name: UpsertCalendarEventCache :execrows
+INSERT INTO CalendarEventCache (user_id, event_id, start_time, end_time, busy, event)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE start_time=VALUES(start_time), end_time=VALUES(end_time), busy=VALUES(busy),
+  event=VALUES(event);
+
+-- name: DeleteCalendarEventCacheEvent :execrows
+DELETE FROM CalendarEventCache
+WHERE user_id=? AND event_id=?;
+
+-- name: DeleteCalendarEventCache :execrows
+DELETE FROM CalendarEventCache
+WHERE user_id=?;
+
+-- name: GetCalendarSubscription :one
+SELECT * FROM CalendarSubscription
+WHERE id=?;
+
+-- name: GetCalendarSubscriptionByUserID :one
+SELECT * FROM CalendarSubscription
+WHERE user_id=?;
+
+-- name: UpsertCalendarSubscription :execrows
+INSERT INTO CalendarSubscription (id, user_id, client_state, expires_at) VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE id=VALUES(id), client_state=VALUES(client_state), expires_at=VALUES(expires_at);
+
+-- name: UpdateCalendarSubscriptionExpiresAt :execrows
+UPDATE CalendarSubscription SET expires_at=?
+WHERE id=?;
+
+-- name: DeleteCalendarSubscription :execrows
+DELETE FROM CalendarSubscription WHERE id=?;
+
+-- name: ListCalendarSubscriptionsToRenew :many
+SELECT * FROM CalendarSubscription
+WHERE expires_at < ?
+ORDER BY expires_at
+LIMIT ?;
+
+-- name: ListPendingRescheduleRequesters :many
+SELECT DISTINCT rr.requested_by FROM ReschedulingRequest rr
+JOIN RequestToMeeting rtm ON rr.request_id=rtm.request_id
+WHERE rtm.meeting_id=? AND rr.status='pending';
+
+-- name: CreatePoll :execlastid
+INSERT INTO Poll (organiser_id, slotify_group_id, event, deadline, created_at) VALUES (?, ?, ?, ?, ?);
+
+-- name: CreatePollOption :execlastid
+INSERT INTO PollOption (poll_id, start_time, end_time) VALUES (?, ?, ?);
+
+-- name: CreatePollVoter :execrows
+INSERT IGNORE INTO PollVoter (poll_id, user_id) VALUES (?, ?);
+
+-- name: GetPollByID :one
+SELECT * FROM Poll WHERE id=?;
+
+-- name: ListPollOptions :many
+SELECT * FROM PollOption
+WHERE poll_id=?
+ORDER BY start_time, id;
+
+-- name: ListPollVoters :many
+SELECT user_id FROM PollVoter
+WHERE poll_id=?
+ORDER BY user_id;
+
+-- name: ListPollVotes :many
+SELECT pv.* FROM PollVote pv
+JOIN PollOption po ON po.id=pv.option_id
+WHERE po.poll_id=?
+ORDER BY pv.option_id, pv.user_id;
+
+-- name: CheckPollVoter :one
+SELECT COUNT(*) FROM PollVoter
+WHERE poll_id=? AND user_id=?;
+
+-- name: LockPollForVote :one
+SELECT COUNT(*) FROM Poll
+WHERE id=? AND status='open' AND deadline > sqlc.arg('now') AND chosen_option_id IS NULL
+AND (closing_until IS NULL OR closing_until < sqlc.arg('now'))
+FOR UPDATE;
+
+-- name: UpsertPollVote :execrows
+INSERT INTO PollVote (option_id, user_id, vote, updated_at) VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE vote=VALUES(vote), updated_at=VALUES(updated_at);
+
+-- name: ListUserPolls :many
+SELECT p.* FROM Poll p
+JOIN PollVoter pv ON pv.poll_id=p.id
+WHERE pv.user_id=? AND (sqlc.narg('status') IS NULL OR p.status=sqlc.narg('status'))
+ORDER BY p.created_at DESC, p.id DESC;
+
+-- name: ListPollsPastDeadline :many
+SELECT id FROM Poll
+WHERE status='open' AND deadline <= sqlc.arg('now') AND (closing_until IS NULL OR closing_until < sqlc.arg('now'))
+  AND (next_close_attempt_at IS NULL OR next_close_attempt_at <= sqlc.arg('now'))
+ORDER BY deadline
+LIMIT ?;
+
+-- name: ClaimPollClose :execrows
+UPDATE Poll SET closing_until=?
+WHERE id=? AND status IN ('open', 'failed') AND (closing_until IS NULL OR closing_until < sqlc.arg('now'));
+
+-- name: ReleasePollClose :execrows
+UPDATE Poll SET closing_until=NULL
+WHERE id=?;
+
+-- name: SetPollMeeting :execrows
+UPDATE Poll SET chosen_option_id=?, event_id=?
+WHERE id=? AND status IN ('open', 'failed') AND chosen_option_id IS NULL;
+
+-- name: RetryPollCloseLater :execrows
+UPDATE Poll SET close_attempts=?, next_close_attempt_at=?, status=?
+WHERE id=? AND status='open';
+
+-- name: ClosePoll :execrows
+UPDATE Poll SET status='closed', chosen_option_id=?, event_id=?, closed_at=?, closing_until=NULL
+WHERE id=? AND status IN ('open', 'failed');
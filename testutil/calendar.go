@@ -0,0 +1,382 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/google/uuid"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+)
+
+// FakeCalendarTimeLayout is the layout event times are stored in, this matches the calendar providers.
+const FakeCalendarTimeLayout = api.CalendarEventTimeLayout
+
+// ensure that we've conformed to the `CalendarProvider`, `CalendarSubscriber` and `CalendarDeltaSyncer`
+// interfaces with a compile-time check.
+var (
+	_ api.CalendarProvider    = (*fakeCalendarProvider)(nil)
+	_ api.CalendarSubscriber  = (*fakeCalendarProvider)(nil)
+	_ api.CalendarDeltaSyncer = (*fakeDeltaCalendarProvider)(nil)
+)
+
+// FakeCalendar is an in-memory calendar backend holding events for many users.
+// Use Factory with api.WithCalendarProviderFactory so handlers can be tested without a tenant.
+type FakeCalendar struct {
+	mu            sync.Mutex
+	events        map[uint32][]api.CalendarEvent
+	rooms         []api.Room
+	subscriptions map[string]fakeCalendarSubscription
+}
+
+// fakeCalendarSubscription is a subscription to changes to a user's events in a FakeCalendar.
+type fakeCalendarSubscription struct {
+	userID    uint32
+	expiresAt time.Time
+}
+
+// NewFakeCalendar creates an empty FakeCalendar.
+func NewFakeCalendar() *FakeCalendar {
+	return &FakeCalendar{
+		events:        map[uint32][]api.CalendarEvent{},
+		rooms:         []api.Room{},
+		subscriptions: map[string]fakeCalendarSubscription{},
+	}
+}
+
+// Factory returns a CalendarProviderFactory that creates providers backed by this FakeCalendar.
+func (f *FakeCalendar) Factory() api.CalendarProviderFactory {
+	return func(_ context.Context, userID uint32) (api.CalendarProvider, error) {
+		return &fakeCalendarProvider{calendar: f, userID: userID}, nil
+	}
+}
+
+// DeltaFactory returns a CalendarProviderFactory like Factory, but its providers support delta syncs
+// so the server caches their events. Every delta sync returns every event.
+func (f *FakeCalendar) DeltaFactory() api.CalendarProviderFactory {
+	return func(_ context.Context, userID uint32) (api.CalendarProvider, error) {
+		return &fakeDeltaCalendarProvider{fakeCalendarProvider{calendar: f, userID: userID}}, nil
+	}
+}
+
+// AddEvent adds an event to a user's calendar, an id and iCalUId are generated if not set.
+func (f *FakeCalendar) AddEvent(userID uint32, event api.CalendarEvent) api.CalendarEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.addEventLocked(userID, event)
+}
+
+// Events returns a copy of the events in a user's calendar.
+func (f *FakeCalendar) Events(userID uint32) []api.CalendarEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	events := make([]api.CalendarEvent, len(f.events[userID]))
+	copy(events, f.events[userID])
+	return events
+}
+
+// SetRooms sets the rooms returned by ListRooms.
+func (f *FakeCalendar) SetRooms(rooms []api.Room) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rooms = rooms
+}
+
+// Subscription returns a user's subscription to changes to their events, false is returned if they don't
+// have one.
+func (f *FakeCalendar) Subscription(userID uint32) (api.CalendarSubscription, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id, s := range f.subscriptions {
+		if s.userID == userID {
+			return api.CalendarSubscription{ID: id, ExpiresAt: s.expiresAt}, true
+		}
+	}
+	return api.CalendarSubscription{}, false
+}
+
+func (f *FakeCalendar) addEventLocked(userID uint32, event api.CalendarEvent) api.CalendarEvent {
+	if event.Id == nil {
+		id := uuid.NewString()
+		event.Id = &id
+	}
+	if event.ICalUId == nil {
+		iCalUID := uuid.NewString()
+		event.ICalUId = &iCalUID
+	}
+	if event.Attendees == nil {
+		event.Attendees = []api.Attendee{}
+	}
+	if event.Locations == nil {
+		event.Locations = []api.Location{}
+	}
+	f.events[userID] = append(f.events[userID], event)
+	return event
+}
+
+// FormatFakeCalendarTime formats t in the layout used by the FakeCalendar.
+func FormatFakeCalendarTime(t time.Time) string {
+	return t.UTC().Format(FakeCalendarTimeLayout)
+}
+
+// fakeCalendarProvider is a FakeCalendar acting on behalf of a single user.
+type fakeCalendarProvider struct {
+	calendar *FakeCalendar
+	userID   uint32
+}
+
+func (p *fakeCalendarProvider) ListEvents(_ context.Context, start, end time.Time) ([]api.CalendarEvent, error) {
+	events := []api.CalendarEvent{}
+	for _, e := range p.calendar.Events(p.userID) {
+		eventStart, eventEnd, err := parseFakeEventTimes(e)
+		if err != nil {
+			return nil, err
+		}
+		if eventStart.Before(end) && eventEnd.After(start) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (p *fakeCalendarProvider) GetEvent(_ context.Context, eventID string) (api.CalendarEvent, error) {
+	for _, e := range p.calendar.Events(p.userID) {
+		if e.Id != nil && *e.Id == eventID {
+			return e, nil
+		}
+	}
+	return api.CalendarEvent{}, api.ErrCalendarEventNotFound
+}
+
+func (p *fakeCalendarProvider) GetEventByICalUID(_ context.Context, iCalUID string) (api.CalendarEvent, error) {
+	for _, e := range p.calendar.Events(p.userID) {
+		if e.ICalUId != nil && *e.ICalUId == iCalUID {
+			return e, nil
+		}
+	}
+	return api.CalendarEvent{}, api.ErrCalendarEventNotFound
+}
+
+// GetEventOccurrence gets the event with SeriesMasterId seriesID, originally starting at originalStart.
+// Occurrences without an OriginalStartTime haven't moved, so their StartTime is used.
+func (p *fakeCalendarProvider) GetEventOccurrence(_ context.Context, seriesID string,
+	originalStart time.Time,
+) (api.CalendarEvent, error) {
+	want := FormatFakeCalendarTime(originalStart)
+	for _, e := range p.calendar.Events(p.userID) {
+		if e.SeriesMasterId == nil || *e.SeriesMasterId != seriesID {
+			continue
+		}
+		start := e.OriginalStartTime
+		if start == nil {
+			start = e.StartTime
+		}
+		if start != nil && *start == want {
+			return e, nil
+		}
+	}
+	return api.CalendarEvent{}, api.ErrCalendarEventNotFound
+}
+
+func (p *fakeCalendarProvider) CreateEvent(_ context.Context, event api.CalendarEvent) (api.CalendarEvent, error) {
+	p.calendar.mu.Lock()
+	defer p.calendar.mu.Unlock()
+
+	event.Id = nil
+	event.ICalUId = nil
+
+	// Store times in the same layout the calendar providers return them in
+	for _, t := range []**string{&event.StartTime, &event.EndTime} {
+		if *t == nil {
+			return api.CalendarEvent{}, errors.New("event is missing a start or end time")
+		}
+		parsed, err := api.ParseCalendarEventTime(**t)
+		if err != nil {
+			return api.CalendarEvent{}, fmt.Errorf("failed to parse event time: %w", err)
+		}
+		formatted := FormatFakeCalendarTime(parsed)
+		*t = &formatted
+	}
+
+	return p.calendar.addEventLocked(p.userID, event), nil
+}
+
+func (p *fakeCalendarProvider) PatchEvent(_ context.Context, eventID string, patch api.CalendarEventPatch) error {
+	p.calendar.mu.Lock()
+	defer p.calendar.mu.Unlock()
+
+	for i, e := range p.calendar.events[p.userID] {
+		if e.Id == nil || *e.Id != eventID {
+			continue
+		}
+		if patch.Start != nil {
+			start := FormatFakeCalendarTime(*patch.Start)
+			p.calendar.events[p.userID][i].StartTime = &start
+		}
+		if patch.End != nil {
+			end := FormatFakeCalendarTime(*patch.End)
+			p.calendar.events[p.userID][i].EndTime = &end
+		}
+		return nil
+	}
+	return api.ErrCalendarEventNotFound
+}
+
+// FindMeetingTimes suggests the start of every time constraint slot in which the user is free.
+func (p *fakeCalendarProvider) FindMeetingTimes(ctx context.Context,
+	body api.SchedulingSlotsBodySchema,
+) (api.SchedulingSlotsSuccessResponseBody, error) {
+	isoDuration, err := serialization.ParseISODuration(body.MeetingDuration)
+	if err != nil {
+		return api.SchedulingSlotsSuccessResponseBody{},
+			fmt.Errorf("failed to parse meeting duration %s as ISO Duration: %w", body.MeetingDuration, err)
+	}
+	duration := time.Duration(isoDuration.GetDays())*24*time.Hour +
+		time.Duration(isoDuration.GetHours())*time.Hour +
+		time.Duration(isoDuration.GetMinutes())*time.Minute +
+		time.Duration(isoDuration.GetSeconds())*time.Second
+
+	suggestions := []api.MeetingTimeSuggestion{}
+	for _, ts := range body.TimeConstraint.TimeSlots {
+		start := ts.Start
+		end := ts.Start.Add(duration)
+		if end.After(ts.End) {
+			continue
+		}
+
+		var events []api.CalendarEvent
+		if events, err = p.ListEvents(ctx, start, end); err != nil {
+			return api.SchedulingSlotsSuccessResponseBody{}, err
+		}
+		if len(events) > 0 {
+			continue
+		}
+
+		confidence := 100.0
+		order := int32(len(suggestions) + 1) //nolint: gosec // number of suggestions is small
+		suggestions = append(suggestions, api.MeetingTimeSuggestion{
+			Confidence: &confidence,
+			Order:      &order,
+			MeetingTimeSlot: &api.MeetingTimeSlot{
+				Start: start,
+				End:   end,
+			},
+			AttendeeAvailability: &[]api.AttendeeAvailability{},
+			Locations:            &[]api.Location{},
+		})
+	}
+
+	resp := api.SchedulingSlotsSuccessResponseBody{
+		MeetingTimeSuggestions: &suggestions,
+	}
+	if len(suggestions) == 0 {
+		reason := api.EmptySuggestionsReasonOrganizerUnavailable
+		resp.EmptySuggestionsReason = &reason
+	}
+
+	return resp, nil
+}
+
+func (p *fakeCalendarProvider) ListRooms(_ context.Context) ([]api.Room, error) {
+	p.calendar.mu.Lock()
+	defer p.calendar.mu.Unlock()
+
+	rooms := make([]api.Room, len(p.calendar.rooms))
+	copy(rooms, p.calendar.rooms)
+	return rooms, nil
+}
+
+func (p *fakeCalendarProvider) FreeBusy(ctx context.Context, start, end time.Time) ([]api.TimeInterval, error) {
+	events, err := p.ListEvents(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := []api.TimeInterval{}
+	for _, e := range events {
+		if e.IsCancelled != nil && *e.IsCancelled {
+			continue
+		}
+		var eventStart, eventEnd time.Time
+		if eventStart, eventEnd, err = parseFakeEventTimes(e); err != nil {
+			return nil, err
+		}
+		busy = append(busy, api.TimeInterval{Start: eventStart, End: eventEnd})
+	}
+	return busy, nil
+}
+
+// parseFakeEventTimes parses the start and end times of an event stored in a FakeCalendar.
+func parseFakeEventTimes(e api.CalendarEvent) (time.Time, time.Time, error) {
+	if e.StartTime == nil || e.EndTime == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("event %v is missing a start or end time", e.Id)
+	}
+
+	start, err := time.Parse(FakeCalendarTimeLayout, *e.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to parse event start time: %w", err)
+	}
+
+	end, err := time.Parse(FakeCalendarTimeLayout, *e.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to parse event end time: %w", err)
+	}
+
+	return start, end, nil
+}
+
+func (p *fakeCalendarProvider) Subscribe(_ context.Context, _, _ string,
+	expiresAt time.Time,
+) (api.CalendarSubscription, error) {
+	p.calendar.mu.Lock()
+	defer p.calendar.mu.Unlock()
+
+	id := uuid.NewString()
+	p.calendar.subscriptions[id] = fakeCalendarSubscription{userID: p.userID, expiresAt: expiresAt}
+	return api.CalendarSubscription{ID: id, ExpiresAt: expiresAt}, nil
+}
+
+func (p *fakeCalendarProvider) RenewSubscription(_ context.Context, subscriptionID string,
+	expiresAt time.Time,
+) (api.CalendarSubscription, error) {
+	p.calendar.mu.Lock()
+	defer p.calendar.mu.Unlock()
+
+	s, ok := p.calendar.subscriptions[subscriptionID]
+	if !ok || s.userID != p.userID {
+		return api.CalendarSubscription{}, api.ErrCalendarSubscriptionNotFound
+	}
+	s.expiresAt = expiresAt
+	p.calendar.subscriptions[subscriptionID] = s
+	return api.CalendarSubscription{ID: subscriptionID, ExpiresAt: expiresAt}, nil
+}
+
+// fakeDeltaCalendarProvider is a fakeCalendarProvider that supports delta syncs.
+type fakeDeltaCalendarProvider struct {
+	fakeCalendarProvider
+}
+
+func (p *fakeDeltaCalendarProvider) DeltaEvents(ctx context.Context, _ string,
+	start, end time.Time,
+) (api.CalendarDelta, error) {
+	events, err := p.ListEvents(ctx, start, end)
+	if err != nil {
+		return api.CalendarDelta{}, err
+	}
+
+	delta := api.CalendarDelta{Events: []api.CalendarDeltaEvent{}, DeltaLink: uuid.NewString()}
+	for _, e := range events {
+		delta.Events = append(delta.Events, api.CalendarDeltaEvent{
+			Event: e,
+			Busy:  e.IsCancelled == nil || !*e.IsCancelled,
+		})
+	}
+	return delta, nil
+}
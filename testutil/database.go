@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -41,6 +42,7 @@ func CreateNotificationAndLink(t *testing.T, ctx context.Context, db *database.D
 			UserID: userID,
 			//nolint: gosec // id is unsigned 32 bit int
 			NotificationID: uint32(notificationID),
+			Channel:        string(notification.ChannelInApp),
 		})
 
 	require.NoError(t, err, "failed to link user to notification")
@@ -70,6 +72,17 @@ func GetWeekOldInviteCount(t *testing.T, ctx context.Context, db *database.Datab
 	return int(count)
 }
 
+// GetOutboxNotifications gets the notifications enqueued for a user, oldest first.
+func GetOutboxNotifications(t *testing.T, ctx context.Context, db *database.Database,
+	userID uint32,
+) []database.NotificationOutbox {
+	// The user id is passed as a JSON document
+	notifs, err := db.ListUserNotificationOutbox(ctx, strconv.FormatUint(uint64(userID), 10))
+	require.NoError(t, err, "failed to list outbox notifications")
+
+	return notifs
+}
+
 // GetCount gets the row count of a given SQL table.
 func GetCount(t *testing.T, db *sql.DB, table string) int {
 	//nolint: gosec //This is a test helper, not used in actual production.
@@ -132,6 +145,20 @@ func AddUserToSlotifyGroup(t *testing.T, db *sql.DB, userID uint32, slotifyGroup
 	require.Equal(t, int64(1), rows, "rows returned is not correct")
 }
 
+// AddUserToSlotifyGroupWithRole adds a user to a slotifyGroup with a role other than member.
+func AddUserToSlotifyGroupWithRole(t *testing.T, db *sql.DB, userID uint32, slotifyGroupID uint32,
+	role api.SlotifyGroupRole,
+) {
+	res, err := db.Exec("INSERT INTO UserToSlotifyGroup (user_id, slotify_group_id, role) VALUES (?, ?, ?)",
+		userID, slotifyGroupID, role)
+	require.NoError(t, err, "failed to execute sql query to add user to slotifyGroup")
+
+	rows, err := res.RowsAffected()
+	require.NoError(t, err, "failed to get the number of rows affected")
+
+	require.Equal(t, int64(1), rows, "rows returned is not correct")
+}
+
 func InsertSlotifyGroup(t *testing.T, db *sql.DB) api.SlotifyGroup {
 	name := gofakeit.ProductName()
 
@@ -162,13 +189,19 @@ func InsertSlotifyGroup(t *testing.T, db *sql.DB) api.SlotifyGroup {
 
 	return api.SlotifyGroup{
 		//nolint: gosec // id is unsigned 32 bit int
-		Id:   uint32(id),
-		Name: name,
+		Id:         uint32(id),
+		Name:       name,
+		Visibility: api.Private,
 	}
 }
 
 type options struct {
-	notificationService notification.Service
+	notificationService     notification.Service
+	calendarProviderFactory api.CalendarProviderFactory
+	googleConfig            *api.GoogleConfig
+	attendeeFetchConfig     *api.AttendeeFetchConfig
+	calendarCacheConfig     *api.CalendarCacheConfig
+	calendarSubscriptionCfg *api.CalendarSubscriptionConfig
 }
 
 func WithNotificationService(notifService notification.Service) TestServerOption {
@@ -181,6 +214,52 @@ func WithNotificationService(notifService notification.Service) TestServerOption
 	}
 }
 
+// WithCalendarProviderFactory sets the calendar provider used by the server, eg. a FakeCalendar.
+func WithCalendarProviderFactory(factory api.CalendarProviderFactory) TestServerOption {
+	return func(options *options) error {
+		if factory == nil {
+			return errors.New("calendar provider factory must not be nil")
+		}
+		options.calendarProviderFactory = factory
+		return nil
+	}
+}
+
+// WithGoogleConfig sets the Google config used by the server, eg. one from a FakeGoogleAPI.
+func WithGoogleConfig(googleCfg *api.GoogleConfig) TestServerOption {
+	return func(options *options) error {
+		if googleCfg == nil {
+			return errors.New("google config must not be nil")
+		}
+		options.googleConfig = googleCfg
+		return nil
+	}
+}
+
+// WithAttendeeFetchConfig sets the limits on fetching attendee calendars when scheduling.
+func WithAttendeeFetchConfig(cfg api.AttendeeFetchConfig) TestServerOption {
+	return func(options *options) error {
+		options.attendeeFetchConfig = &cfg
+		return nil
+	}
+}
+
+// WithCalendarCacheConfig sets how the server caches calendar events.
+func WithCalendarCacheConfig(cfg api.CalendarCacheConfig) TestServerOption {
+	return func(options *options) error {
+		options.calendarCacheConfig = &cfg
+		return nil
+	}
+}
+
+// WithCalendarSubscriptionConfig sets how the server subscribes to users' calendars.
+func WithCalendarSubscriptionConfig(cfg api.CalendarSubscriptionConfig) TestServerOption {
+	return func(options *options) error {
+		options.calendarSubscriptionCfg = &cfg
+		return nil
+	}
+}
+
 type TestServerOption func(opts *options) error
 
 // NewServerAndDB creates a server and a db, test fails
@@ -205,8 +284,26 @@ func NewServerAndDB(t *testing.T,
 
 	db := NewDB(t, ctx)
 
-	server, err := api.NewServerWithContext(ctx, db,
-		api.WithNotInitMSALClient(), api.WithNotificationService(notificationService))
+	serverOpts := []api.ServerOption{
+		api.WithNotInitMSALClient(), api.WithNotificationService(notificationService),
+	}
+	if opts.calendarProviderFactory != nil {
+		serverOpts = append(serverOpts, api.WithCalendarProviderFactory(opts.calendarProviderFactory))
+	}
+	if opts.googleConfig != nil {
+		serverOpts = append(serverOpts, api.WithGoogleConfig(opts.googleConfig))
+	}
+	if opts.attendeeFetchConfig != nil {
+		serverOpts = append(serverOpts, api.WithAttendeeFetchConfig(*opts.attendeeFetchConfig))
+	}
+	if opts.calendarCacheConfig != nil {
+		serverOpts = append(serverOpts, api.WithCalendarCacheConfig(*opts.calendarCacheConfig))
+	}
+	if opts.calendarSubscriptionCfg != nil {
+		serverOpts = append(serverOpts, api.WithCalendarSubscriptionConfig(*opts.calendarSubscriptionCfg))
+	}
+
+	server, err := api.NewServerWithContext(ctx, db, serverOpts...)
 
 	require.NoError(t, err, "error creating server ")
 	require.NotNil(t, db, "server cannot be nil")
@@ -0,0 +1,293 @@
+package testutil
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SlotifyApp/slotify-backend/api"
+	"github.com/SlotifyApp/slotify-backend/tokencrypt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeGoogleUser is a Google account known to a FakeGoogleAPI.
+type FakeGoogleUser struct {
+	Subject   string
+	Email     string
+	GivenName string
+	LastName  string
+}
+
+// fakeGoogleEvent is an event in a FakeGoogleAPI calendar.
+type fakeGoogleEvent struct {
+	ID      string
+	ICalUID string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// FakeGoogleAPI is a local HTTP stub of the Google OAuth, userinfo and Calendar APIs.
+// Point an api.GoogleConfig at it with Config.
+type FakeGoogleAPI struct {
+	Server *httptest.Server
+
+	mu sync.Mutex
+	// users by auth code
+	users map[string]FakeGoogleUser
+	// emails by access or refresh token
+	tokens map[string]string
+	// events by email
+	events map[string][]fakeGoogleEvent
+}
+
+// NewFakeGoogleAPI starts a FakeGoogleAPI, it is closed when the test finishes.
+func NewFakeGoogleAPI(t *testing.T) *FakeGoogleAPI {
+	f := &FakeGoogleAPI{
+		users:  map[string]FakeGoogleUser{},
+		tokens: map[string]string{},
+		events: map[string][]fakeGoogleEvent{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", f.handleToken)
+	mux.HandleFunc("GET /userinfo", f.handleUserInfo)
+	mux.HandleFunc("GET /calendar/v3/calendars/primary/events", f.handleListEvents)
+	mux.HandleFunc("POST /calendar/v3/freeBusy", f.handleFreeBusy)
+
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Server.Close)
+
+	return f
+}
+
+// Config creates an api.GoogleConfig that talks to the FakeGoogleAPI.
+func (f *FakeGoogleAPI) Config(t *testing.T) *api.GoogleConfig {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err, "failed to generate google token key")
+
+	tokenCipher, err := tokencrypt.New(key)
+	require.NoError(t, err, "failed to create google token cipher")
+
+	return &api.GoogleConfig{
+		ClientID:         "fake-client-id",
+		ClientSecret:     "fake-client-secret",
+		RedirectURL:      "http://localhost/api/auth/google/callback",
+		AuthURL:          f.Server.URL + "/auth",
+		TokenURL:         f.Server.URL + "/token",
+		UserInfoURL:      f.Server.URL + "/userinfo",
+		CalendarBaseURL:  f.Server.URL + "/calendar/v3",
+		DirectoryBaseURL: f.Server.URL + "/admin/directory/v1",
+		TokenCipher:      tokenCipher,
+		HTTPClient:       f.Server.Client(),
+	}
+}
+
+// AddUser makes authCode exchangeable for tokens belonging to user.
+func (f *FakeGoogleAPI) AddUser(authCode string, user FakeGoogleUser) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.users[authCode] = user
+}
+
+// AddEvent adds an event to the Google calendar of email.
+func (f *FakeGoogleAPI) AddEvent(email, summary string, start, end time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.events[email] = append(f.events[email], fakeGoogleEvent{
+		ID:      strings.ReplaceAll(uuid.NewString(), "-", ""),
+		ICalUID: uuid.NewString() + "@google.com",
+		Summary: summary,
+		Start:   start.UTC(),
+		End:     end.UTC(),
+	})
+}
+
+func (f *FakeGoogleAPI) writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// emailForRequest returns the email of the user whose access token authenticates r.
+func (f *FakeGoogleAPI) emailForRequest(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return "", false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	email, ok := f.tokens[token]
+	return email, ok
+}
+
+func (f *FakeGoogleAPI) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var email string
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		user, ok := f.users[r.PostForm.Get("code")]
+		if !ok {
+			f.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		email = user.Email
+	case "refresh_token":
+		var ok bool
+		if email, ok = f.tokens[r.PostForm.Get("refresh_token")]; !ok {
+			f.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+	default:
+		f.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	accessToken := uuid.NewString()
+	refreshToken := uuid.NewString()
+	f.tokens[accessToken] = email
+	f.tokens[refreshToken] = email
+
+	f.writeJSON(w, http.StatusOK, map[string]any{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    3600,
+	})
+}
+
+func (f *FakeGoogleAPI) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	email, ok := f.emailForRequest(r)
+	if !ok {
+		f.writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, u := range f.users {
+		if u.Email != email {
+			continue
+		}
+		f.writeJSON(w, http.StatusOK, map[string]any{
+			"sub":            u.Subject,
+			"email":          u.Email,
+			"email_verified": true,
+			"given_name":     u.GivenName,
+			"family_name":    u.LastName,
+		})
+		return
+	}
+
+	f.writeJSON(w, http.StatusNotFound, map[string]string{"error": "not_found"})
+}
+
+func (f *FakeGoogleAPI) handleListEvents(w http.ResponseWriter, r *http.Request) {
+	email, ok := f.emailForRequest(r)
+	if !ok {
+		f.writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+
+	query := r.URL.Query()
+	timeMin, minErr := time.Parse(time.RFC3339, query.Get("timeMin"))
+	timeMax, maxErr := time.Parse(time.RFC3339, query.Get("timeMax"))
+	iCalUID := query.Get("iCalUID")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	items := []map[string]any{}
+	for _, e := range f.events[email] {
+		if iCalUID != "" && e.ICalUID != iCalUID {
+			continue
+		}
+		if minErr == nil && maxErr == nil && (!e.Start.Before(timeMax) || !e.End.After(timeMin)) {
+			continue
+		}
+		items = append(items, map[string]any{
+			"id":        e.ID,
+			"iCalUID":   e.ICalUID,
+			"status":    "confirmed",
+			"summary":   e.Summary,
+			"start":     map[string]string{"dateTime": e.Start.Format(time.RFC3339), "timeZone": "UTC"},
+			"end":       map[string]string{"dateTime": e.End.Format(time.RFC3339), "timeZone": "UTC"},
+			"organizer": map[string]string{"email": email},
+		})
+	}
+
+	f.writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (f *FakeGoogleAPI) handleFreeBusy(w http.ResponseWriter, r *http.Request) {
+	email, ok := f.emailForRequest(r)
+	if !ok {
+		f.writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+
+	var body struct {
+		TimeMin time.Time `json:"timeMin"`
+		TimeMax time.Time `json:"timeMax"`
+		Items   []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("invalid body: %s", err)})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	calendars := map[string]any{}
+	for _, item := range body.Items {
+		calendarEmail := item.ID
+		if calendarEmail == "primary" {
+			calendarEmail = email
+		}
+
+		events, known := f.events[calendarEmail]
+		if !known && calendarEmail != email {
+			calendars[item.ID] = map[string]any{
+				"busy":   []any{},
+				"errors": []map[string]string{{"domain": "global", "reason": "notFound"}},
+			}
+			continue
+		}
+
+		busy := []map[string]string{}
+		for _, e := range events {
+			if e.Start.Before(body.TimeMax) && e.End.After(body.TimeMin) {
+				busy = append(busy, map[string]string{
+					"start": e.Start.Format(time.RFC3339),
+					"end":   e.End.Format(time.RFC3339),
+				})
+			}
+		}
+		calendars[item.ID] = map[string]any{"busy": busy}
+	}
+
+	f.writeJSON(w, http.StatusOK, map[string]any{"calendars": calendars})
+}
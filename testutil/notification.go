@@ -1,13 +1,41 @@
 package testutil
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/SlotifyApp/slotify-backend/database"
+	"github.com/SlotifyApp/slotify-backend/mocks"
+	"github.com/SlotifyApp/slotify-backend/notification"
+	"go.uber.org/mock/gomock"
 )
 
 // GetExpectedNotificationSSE returns the expected notification that was flushed to the client.
-func GetExpectedNotificationSSE(notifID int64, message string, created time.Time) string {
+func GetExpectedNotificationSSE(notifID int64, notif database.CreateNotificationParams) string {
+	payload := "null"
+	if notif.Payload != nil {
+		payload = string(notif.Payload)
+	}
+
 	return fmt.Sprintf(
-		"event: calendar_notification\ndata: {\"id\":%d,\"message\":\"%s\",\"created\":\"%s\"}\n\n",
-		notifID, message, created.Format(time.RFC3339Nano))
+		"id: %d\nevent: %s\ndata: {\"id\":%d,\"message\":\"%s\",\"created\":\"%s\",\"kind\":\"%s\","+
+			"\"version\":%d,\"payload\":%s}\n\n",
+		notifID, notification.Kind(notif.Kind).EventName(), notifID, notif.Message,
+		notif.Created.Format(time.RFC3339Nano), notif.Kind, notif.Version, payload)
+}
+
+// ExpectNoNotificationPreferences sets up a mock notification DB where no user or group has notification
+// preferences or quiet hours, so notifications are stored and sent live on the default channel.
+func ExpectNoNotificationPreferences(db *mocks.MockNotificationDatabase) {
+	db.EXPECT().GetNotificationPreferenceChannel(gomock.Any(), gomock.Any()).Return("", sql.ErrNoRows).AnyTimes()
+	db.EXPECT().ListMemberSlotifyGroupNotificationChannels(gomock.Any(), gomock.Any()).
+		Return([]string{}, nil).AnyTimes()
+	db.EXPECT().GetNotificationQuietHours(gomock.Any(), gomock.Any()).
+		Return(database.GetNotificationQuietHoursRow{}, sql.ErrNoRows).AnyTimes()
+}
+
+// GetExpectedSSERetry returns the reconnect delay an SSE client is sent first when it is registered.
+func GetExpectedSSERetry() string {
+	return fmt.Sprintf("retry: %d\n\n", notification.ReconnectDelay.Milliseconds())
 }
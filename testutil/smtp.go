@@ -0,0 +1,141 @@
+package testutil
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// SMTPSinkMessage is an email received by an SMTPSink.
+type SMTPSinkMessage struct {
+	From string
+	To   []string
+	// Data is the raw message, with CRLF line endings.
+	Data string
+}
+
+// SMTPSink is a local SMTP server that keeps the emails sent to it, it doesn't support STARTTLS or AUTH.
+type SMTPSink struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []SMTPSinkMessage
+}
+
+// NewSMTPSink starts an SMTPSink on a random local port, it is stopped when the test finishes.
+func NewSMTPSink(t *testing.T) *SMTPSink {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "failed to start smtp sink")
+
+	s := &SMTPSink{listener: listener}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+// Addr returns the host and port of the sink.
+func (s *SMTPSink) Addr() (string, int) {
+	addr, _ := s.listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+// Messages returns the emails received so far.
+func (s *SMTPSink) Messages() []SMTPSinkMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SMTPSinkMessage{}, s.messages...)
+}
+
+// serve handles one SMTP session.
+func (s *SMTPSink) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) bool {
+		_, err := conn.Write([]byte(line + "\r\n"))
+		return err == nil
+	}
+
+	if !reply("220 localhost smtp sink") {
+		return
+	}
+
+	var msg SMTPSinkMessage
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			msg = SMTPSinkMessage{From: smtpPath(line)}
+			reply("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, smtpPath(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+			for {
+				if line, err = r.ReadString('\n'); err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				// Undo dot-stuffing
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			msg.Data = data.String()
+
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+
+			reply("250 OK")
+		case "RSET":
+			msg = SMTPSinkMessage{}
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// smtpPath returns the address in a 'MAIL FROM:<address>' or 'RCPT TO:<address>' command.
+func smtpPath(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start == -1 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
@@ -0,0 +1,85 @@
+// Package tokencrypt encrypts tokens before they are stored at rest, using AES-256-GCM.
+package tokencrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// keyLen is the key length in bytes required for AES-256.
+const keyLen = 32
+
+// ErrKeyInvalid is returned if the encryption key is not a valid AES-256 key.
+var ErrKeyInvalid = errors.New("encryption key must be 32 bytes, base64 encoded")
+
+// ErrCiphertextTooShort is returned if a ciphertext is too short to contain a nonce.
+var ErrCiphertextTooShort = errors.New("ciphertext is too short to be decrypted")
+
+// Cipher encrypts and decrypts tokens with AES-256-GCM.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// New creates a new Cipher, key must be 32 bytes long.
+func New(key []byte) (*Cipher, error) {
+	if len(key) != keyLen {
+		return nil, ErrKeyInvalid
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aes cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm cipher: %w", err)
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// NewFromEnv creates a new Cipher, reading the base64 encoded key from the env variable envName.
+func NewFromEnv(envName string) (*Cipher, error) {
+	encodedKey, present := os.LookupEnv(envName)
+	if !present {
+		return nil, fmt.Errorf("failed to get %s env variable", envName)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w: %w", envName, ErrKeyInvalid, err)
+	}
+
+	return New(key)
+}
+
+// Encrypt seals plaintext, the random nonce is prepended to the ciphertext.
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens a ciphertext created by Encrypt.
+func (c *Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, ErrCiphertextTooShort
+	}
+
+	nonce, sealed := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ciphertext: %w", err)
+	}
+	return plaintext, nil
+}